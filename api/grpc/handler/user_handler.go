@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/chats/go-user-api/api/grpc/interceptor"
+	commonv1 "github.com/chats/go-user-api/api/proto/common/v1"
+	userv1 "github.com/chats/go-user-api/api/proto/user/v1"
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserHandler serves the user.v1 UserService with the use cases behind the HTTP user routes
+type UserHandler struct {
+	userv1.UnimplementedUserServiceServer
+
+	userUseCase usecase.UserUseCase
+	register    config.RegistrationConfig
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(userUseCase usecase.UserUseCase, register config.RegistrationConfig) *UserHandler {
+	return &UserHandler{
+		userUseCase: userUseCase,
+		register:    register,
+	}
+}
+
+// PublicMethods returns the methods callable without an access token
+func (h *UserHandler) PublicMethods() []string {
+	return []string{userv1.UserService_CreateUser_FullMethodName}
+}
+
+// CreateUser registers a new user. When existing accounts are concealed, the response carries no user,
+// whether or not the email already had an account.
+func (h *UserHandler) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
+	if req.GetEmail() == "" || req.GetUsername() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email, username, and password are required")
+	}
+
+	profile := entity.UserProfile{FirstName: req.GetFirstName(), LastName: req.GetLastName()}
+	user, err := h.userUseCase.Register(ctx, req.GetEmail(), req.GetUsername(), req.GetPassword(), profile, nil, "")
+
+	// Answer alike whether or not the email has an account, both are told by email
+	if h.register.ConcealExistingAccounts && (err == nil || errors.Is(err, usecase.ErrEmailAlreadyExists)) {
+		return &userv1.CreateUserResponse{}, nil
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("email", req.GetEmail()).Msg("Failed to register user")
+
+		switch {
+		case errors.Is(err, usecase.ErrEmailAlreadyExists):
+			return nil, status.Error(codes.AlreadyExists, "email already exists")
+		case errors.Is(err, usecase.ErrUsernameAlreadyExists):
+			return nil, status.Error(codes.AlreadyExists, "username already exists")
+		case errors.Is(err, usecase.ErrDuplicateRegistration):
+			return nil, status.Error(codes.Aborted, "a registration for this email is already being processed")
+		case errors.Is(err, usecase.ErrInvalidUsername):
+			return nil, status.Error(codes.InvalidArgument, "invalid username, usernames cannot contain @")
+		case errors.Is(err, service.ErrWeakPassword):
+			return nil, status.Error(codes.InvalidArgument, "this password is too easy to guess, please choose a stronger one")
+		case errors.Is(err, service.ErrBreachedPassword):
+			return nil, status.Error(codes.InvalidArgument, "this password appeared in a data breach, please choose another one")
+		case errors.Is(err, service.ErrPasswordCheckUnavailable):
+			return nil, status.Error(codes.Unavailable, "the password could not be checked, please try again later")
+		default:
+			return nil, profileError(err, "failed to register user")
+		}
+	}
+
+	return &userv1.CreateUserResponse{User: toUserMessage(user)}, nil
+}
+
+// GetUser returns a user by ID
+func (h *UserHandler) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	id, err := parseUserID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSelfOrRole(ctx, id, entity.UserRoleAdmin, entity.UserRoleOrgAdmin); err != nil {
+		return nil, err
+	}
+	if err := h.checkScope(ctx, id); err != nil {
+		return nil, err
+	}
+
+	user, err := h.userUseCase.GetByID(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", req.GetId()).Msg("Failed to get user")
+
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	return &userv1.GetUserResponse{User: toUserMessage(user)}, nil
+}
+
+// UpdateUser updates the names of a user, the other profile fields are left unchanged
+func (h *UserHandler) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
+	id, err := parseUserID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSelfOrRole(ctx, id, entity.UserRoleAdmin, entity.UserRoleOrgAdmin); err != nil {
+		return nil, err
+	}
+	if err := h.checkScope(ctx, id); err != nil {
+		return nil, err
+	}
+
+	profile := entity.UserProfile{FirstName: req.GetFirstName(), LastName: req.GetLastName()}
+	user, err := h.userUseCase.Update(ctx, id, profile)
+	if err != nil {
+		log.Error().Err(err).Str("id", req.GetId()).Msg("Failed to update user")
+
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, service.ErrVerificationRequired):
+			return nil, status.Error(codes.PermissionDenied, "account verification required")
+		default:
+			return nil, profileError(err, "failed to update user")
+		}
+	}
+
+	return &userv1.UpdateUserResponse{User: toUserMessage(user)}, nil
+}
+
+// DeleteUser deletes a user, or schedules the deletion when a restoration window is configured
+func (h *UserHandler) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
+	id, err := parseUserID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSelfOrRole(ctx, id, entity.UserRoleAdmin, entity.UserRoleOrgAdmin); err != nil {
+		return nil, err
+	}
+	if err := h.checkScope(ctx, id); err != nil {
+		return nil, err
+	}
+
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		log.Error().Msg("Claims not found in context")
+		return nil, status.Error(codes.Internal, "failed to delete user")
+	}
+
+	if _, err := h.userUseCase.Delete(ctx, claims.UserID, id, entity.ActionReason{}); err != nil {
+		log.Error().Err(err).Str("id", req.GetId()).Msg("Failed to delete user")
+
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, usecase.ErrDeletionPending):
+			return nil, status.Error(codes.FailedPrecondition, "user is already pending deletion")
+		default:
+			return nil, status.Error(codes.Internal, "failed to delete user")
+		}
+	}
+
+	return &userv1.DeleteUserResponse{}, nil
+}
+
+// ListUsers returns a page of users, org admins only see the members of their organization
+func (h *UserHandler) ListUsers(ctx context.Context, req *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	if err := requireRole(ctx, entity.UserRoleAdmin, entity.UserRoleOrgAdmin); err != nil {
+		return nil, err
+	}
+
+	page := int(req.GetPage().GetPage())
+	if page < 1 {
+		page = 1
+	}
+
+	limit := int(req.GetPage().GetPageSize())
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	opts := entity.UserListOptions{
+		Status: fromStatusEnum(req.GetStatus()),
+		Tag:    req.GetTag(),
+		Role:   req.GetRole(),
+	}
+	if orgID := scopedOrgID(ctx); orgID != nil {
+		opts.OrgID = orgID
+	}
+
+	users, total, err := h.userUseCase.List(ctx, page, limit, opts)
+	if err != nil {
+		log.Error().Err(err).Int("page", page).Int("limit", limit).Msg("Failed to list users")
+
+		switch {
+		case errors.Is(err, usecase.ErrInvalidStatus):
+			return nil, status.Error(codes.InvalidArgument, "invalid status filter")
+		case errors.Is(err, usecase.ErrInvalidTag):
+			return nil, status.Error(codes.InvalidArgument, "invalid tag filter")
+		default:
+			return nil, status.Error(codes.Internal, "failed to list users")
+		}
+	}
+
+	messages := make([]*userv1.User, 0, len(users))
+	for _, user := range users {
+		messages = append(messages, toUserMessage(user))
+	}
+
+	return &userv1.ListUsersResponse{
+		Users: messages,
+		PageInfo: &commonv1.PageInfo{
+			Page:       int32(page),
+			PageSize:   int32(limit),
+			Total:      total,
+			TotalPages: int32((total + int64(limit) - 1) / int64(limit)),
+		},
+	}, nil
+}
+
+// checkScope restricts org admins to the members of their organization, like the HTTP org scope middleware.
+// Platform admins, regular users and users acting on themselves are not restricted here.
+func (h *UserHandler) checkScope(ctx context.Context, id uuid.UUID) error {
+	orgID := scopedOrgID(ctx)
+	if orgID == nil {
+		return nil
+	}
+	if claims, _ := interceptor.ClaimsFromContext(ctx); claims.UserID == id {
+		return nil
+	}
+
+	user, err := h.userUseCase.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil
+		}
+		log.Error().Err(err).Str("id", id.String()).Msg("Failed to resolve organization scope")
+		return status.Error(codes.Internal, "failed to check organization scope")
+	}
+
+	if user.OrgID == nil || *user.OrgID != *orgID {
+		return status.Error(codes.PermissionDenied, "user is outside of your organization")
+	}
+	return nil
+}
+
+// requireRole checks that the caller has one of the roles, like the HTTP role middleware
+func requireRole(ctx context.Context, roles ...string) error {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "access denied")
+	}
+	if !slices.Contains(roles, claims.Role) {
+		return status.Error(codes.PermissionDenied, "insufficient permissions")
+	}
+	return nil
+}
+
+// requireSelfOrRole lets callers act on themselves and callers with one of the roles act on anyone,
+// like the HTTP self-or-role middleware
+func requireSelfOrRole(ctx context.Context, id uuid.UUID, roles ...string) error {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "access denied")
+	}
+	if claims.UserID != id && !slices.Contains(roles, claims.Role) {
+		return status.Error(codes.PermissionDenied, "you can only manage your own account")
+	}
+	return nil
+}
+
+// scopedOrgID returns the organization an org admin is limited to, or nil when the caller is not scoped.
+// An org admin without an organization is scoped to uuid.Nil, which matches no user.
+func scopedOrgID(ctx context.Context) *uuid.UUID {
+	claims, ok := interceptor.ClaimsFromContext(ctx)
+	if !ok || claims.Role != entity.UserRoleOrgAdmin {
+		return nil
+	}
+
+	orgID := uuid.Nil
+	if claims.OrgID != nil {
+		orgID = *claims.OrgID
+	}
+	return &orgID
+}
+
+// parseUserID parses the user ID of a request
+func parseUserID(value string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "user ID is required")
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		log.Error().Err(err).Str("id", value).Msg("Invalid user ID format")
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid user ID format")
+	}
+	return id, nil
+}
+
+// profileError maps the validation errors of a submitted profile to INVALID_ARGUMENT, other errors to INTERNAL
+func profileError(err error, fallback string) error {
+	var fieldErr *entity.ProfileFieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("the %s field is %s by your organization", fieldErr.Field, fieldErr.Mode))
+	case errors.Is(err, usecase.ErrRegistrationClosed):
+		return status.Error(codes.PermissionDenied, "the organization is closed to self-registration")
+	case errors.Is(err, usecase.ErrMemberLimitReached):
+		return status.Error(codes.ResourceExhausted, "the organization has reached its member limit")
+	default:
+		return status.Error(codes.Internal, fallback)
+	}
+}
+
+// toUserMessage maps a user to its protobuf message, the password is never exposed
+func toUserMessage(user *entity.User) *userv1.User {
+	message := &userv1.User{
+		Id:            user.ID.String(),
+		Email:         user.Email,
+		Username:      user.Username,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Role:          user.Role,
+		Status:        toStatusEnum(user.Status),
+		EmailVerified: user.EmailVerified,
+		PhoneVerified: user.PhoneVerified,
+		Tags:          user.Tags,
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+		UpdatedAt:     timestamppb.New(user.UpdatedAt),
+	}
+	if user.OrgID != nil {
+		message.OrgId = user.OrgID.String()
+	}
+	return message
+}
+
+// toStatusEnum maps a user status to the contract, statuses the contract does not define are unspecified
+func toStatusEnum(value string) userv1.UserStatus {
+	switch value {
+	case entity.UserStatusActive:
+		return userv1.UserStatus_USER_STATUS_ACTIVE
+	case entity.UserStatusInactive:
+		return userv1.UserStatus_USER_STATUS_INACTIVE
+	case entity.UserStatusBlocked:
+		return userv1.UserStatus_USER_STATUS_BLOCKED
+	default:
+		return userv1.UserStatus_USER_STATUS_UNSPECIFIED
+	}
+}
+
+// fromStatusEnum maps a status filter of the contract to a user status, empty for no filter
+func fromStatusEnum(value userv1.UserStatus) string {
+	switch value {
+	case userv1.UserStatus_USER_STATUS_ACTIVE:
+		return entity.UserStatusActive
+	case userv1.UserStatus_USER_STATUS_INACTIVE:
+		return entity.UserStatusInactive
+	case userv1.UserStatus_USER_STATUS_BLOCKED:
+		return entity.UserStatusBlocked
+	default:
+		return ""
+	}
+}
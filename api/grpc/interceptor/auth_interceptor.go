@@ -0,0 +1,108 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// claimsKey is the context key of the claims of the authenticated caller
+type claimsKey struct{}
+
+// AuthInterceptor validates the access token sent in the authorization metadata, like the HTTP auth middleware.
+// Public methods are let through without a token.
+type AuthInterceptor struct {
+	authUseCase   usecase.AuthUseCase
+	publicMethods map[string]bool
+}
+
+// NewAuthInterceptor creates a new AuthInterceptor, publicMethods are full method names such as
+// /user.v1.UserService/CreateUser
+func NewAuthInterceptor(authUseCase usecase.AuthUseCase, publicMethods ...string) *AuthInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+
+	return &AuthInterceptor{
+		authUseCase:   authUseCase,
+		publicMethods: public,
+	}
+}
+
+// UnaryServerInterceptor creates an interceptor authenticating unary calls
+func (a *AuthInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor creates an interceptor authenticating streams when they are opened
+func (a *AuthInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate returns the context carrying the claims of the caller's access token
+func (a *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if a.publicMethods[method] {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization format, expected 'Bearer {token}'")
+	}
+
+	claims, err := a.authUseCase.ValidateToken(ctx, parts[1])
+	if err != nil {
+		log.Error().Err(err).Str("method", method).Msg("Failed to validate token")
+
+		if errors.Is(err, service.ErrInvalidToken) || errors.Is(err, service.ErrExpiredToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+		return nil, status.Error(codes.Internal, "failed to validate token")
+	}
+
+	return context.WithValue(ctx, claimsKey{}, claims), nil
+}
+
+// ClaimsFromContext returns the claims of the caller authenticated by the AuthInterceptor
+func ClaimsFromContext(ctx context.Context) (*service.TokenClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*service.TokenClaims)
+	return claims, ok
+}
+
+// authenticatedStream is a server stream carrying the context of the authenticated caller
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the authenticated caller
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
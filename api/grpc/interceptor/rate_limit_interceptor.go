@@ -0,0 +1,161 @@
+package interceptor
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/chats/go-user-api/internal/infrastructure/ratelimit"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// MetadataClientID identifies internal callers sharing a host, the peer address is used when absent
+	MetadataClientID = "x-client-id"
+	// MetadataRetryAfter is the trailer telling limited callers how many seconds to wait
+	MetadataRetryAfter = "retry-after"
+)
+
+// RateLimiter limits gRPC calls per client and, when an access token is sent, per user.
+// Budgets are shared with the HTTP rate limiter's store, so all instances enforce them together.
+type RateLimiter struct {
+	limiter            ratelimit.Limiter
+	tokenService       service.TokenService
+	enforcementUseCase usecase.EnforcementUseCase
+	config             config.RateLimitConfig
+}
+
+// NewRateLimiter creates a new RateLimiter
+func NewRateLimiter(limiter ratelimit.Limiter, tokenService service.TokenService, enforcementUseCase usecase.EnforcementUseCase, config config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		limiter:            limiter,
+		tokenService:       tokenService,
+		enforcementUseCase: enforcementUseCase,
+		config:             config,
+	}
+}
+
+// UnaryServerInterceptor creates an interceptor enforcing the budgets on unary calls
+func (rl *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := rl.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor creates an interceptor enforcing the budgets when streams are opened
+func (rl *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rl.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// budget is a rate limit budget of a caller
+type budget struct {
+	key    string
+	limit  int
+	policy string
+	userID uuid.UUID
+}
+
+// allow consumes one call from the client budget and the user budget of the caller
+func (rl *RateLimiter) allow(ctx context.Context, method string) error {
+	budgets := []budget{{key: "grpc:client:" + clientID(ctx), limit: rl.config.GRPCClientMax, policy: entity.EnforcementPolicyRateLimitGRPCClient}}
+	if userID := rl.userID(ctx); userID != uuid.Nil {
+		budgets = append(budgets, budget{key: "grpc:user:" + userID.String(), limit: rl.config.GRPCUserMax, policy: entity.EnforcementPolicyRateLimitGRPCUser, userID: userID})
+	}
+
+	for _, budget := range budgets {
+		result, err := rl.limiter.Allow(ctx, budget.key, budget.limit, rl.config.Window)
+		if err != nil {
+			// Fail open, losing the limiter must not take the API down
+			log.Warn().Err(err).Str("caller", budget.key).Msg("Failed to apply gRPC rate limit")
+			continue
+		}
+
+		if !result.Allowed && rl.enforcementUseCase.Enforce(ctx, budget.policy, budget.key, budget.userID, result) {
+			log.Warn().Str("caller", budget.key).Str("method", method).Msg("gRPC rate limit reached")
+			return exhausted(ctx, result)
+		}
+	}
+
+	return nil
+}
+
+// userID returns the user of the access token sent in the authorization metadata, uuid.Nil if there is none
+func (rl *RateLimiter) userID(ctx context.Context) uuid.UUID {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return uuid.Nil
+	}
+
+	for _, value := range md.Get("authorization") {
+		parts := strings.Split(value, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			continue
+		}
+		claims, err := rl.tokenService.ValidateToken(parts[1])
+		if err == nil && claims.TokenType == entity.AccessToken {
+			return claims.UserID
+		}
+	}
+
+	return uuid.Nil
+}
+
+// clientID identifies the calling client by its declared ID, by its host otherwise
+func clientID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(MetadataClientID); len(ids) > 0 && ids[0] != "" {
+			return "id:" + ids[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+
+	return "unknown"
+}
+
+// exhausted builds the RESOURCE_EXHAUSTED error of a spent budget, with the delay until it resets
+// as RetryInfo details and in the retry-after trailer
+func exhausted(ctx context.Context, result ratelimit.Result) error {
+	retryAfter := result.RetryAfter()
+
+	if err := grpc.SetTrailer(ctx, metadata.Pairs(MetadataRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))); err != nil {
+		log.Debug().Err(err).Msg("Failed to set retry-after trailer")
+	}
+
+	st := status.New(codes.ResourceExhausted, "too many requests, please try again later")
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/api/http/middleware"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// AdminHandler handles HTTP requests for administrative operations
+type AdminHandler struct {
+	maintenanceUseCase usecase.MaintenanceUseCase
+	meteringUseCase    usecase.MeteringUseCase
+	selfTestUseCase    usecase.SelfTestUseCase
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(maintenanceUseCase usecase.MaintenanceUseCase, meteringUseCase usecase.MeteringUseCase, selfTestUseCase usecase.SelfTestUseCase) *AdminHandler {
+	return &AdminHandler{
+		maintenanceUseCase: maintenanceUseCase,
+		meteringUseCase:    meteringUseCase,
+		selfTestUseCase:    selfTestUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes for the admin handler and returns the admin group
+// so other handlers can register their administrative routes behind the same checks
+func (h *AdminHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler) fiber.Router {
+	adminGroup := router.Group("/admin", authMiddleware, middleware.RoleMiddleware(entity.UserRoleAdmin))
+
+	adminGroup.Get("/read-only", h.GetReadOnly)
+	adminGroup.Put("/read-only", h.SetReadOnly)
+	adminGroup.Get("/usage", h.GetUsage)
+	adminGroup.Get("/selftest", h.SelfTest)
+
+	return adminGroup
+}
+
+// GetReadOnly returns whether read-only mode is enabled
+func (h *AdminHandler) GetReadOnly(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"enabled": h.maintenanceUseCase.IsReadOnly(c.Context()),
+	})
+}
+
+// SetReadOnly enables or disables read-only mode
+func (h *AdminHandler) SetReadOnly(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Enabled *bool `json:"enabled" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Enabled == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.maintenanceUseCase.SetReadOnly(c.Context(), *req.Enabled); err != nil {
+		log.Error().Err(err).Msg("Failed to set read-only mode")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to set read-only mode",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"enabled": *req.Enabled,
+	})
+}
+
+// GetUsage exports the billable usage of a period, defaulting to the current month
+func (h *AdminHandler) GetUsage(c *fiber.Ctx) error {
+	period := c.Query("period", entity.UsagePeriod(time.Now()))
+
+	report, err := h.meteringUseCase.Report(c.Context(), period)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPeriod):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid period, expected YYYY-MM",
+			})
+		default:
+			log.Error().Err(err).Str("period", period).Msg("Failed to get usage report")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get usage report",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// SelfTest runs the self-test and returns its report, with status 503 when a check failed so smoke tests can
+// assert on the status alone
+func (h *AdminHandler) SelfTest(c *fiber.Ctx) error {
+	report := h.selfTestUseCase.Run(c.Context())
+	if !report.Passed() {
+		log.Warn().Interface("checks", report.Checks).Msg("Self-test failed")
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
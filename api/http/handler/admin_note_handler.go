@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// AdminNoteHandler handles HTTP requests for the internal notes administrators attach to users
+type AdminNoteHandler struct {
+	adminNoteUseCase usecase.AdminNoteUseCase
+}
+
+// NewAdminNoteHandler creates a new AdminNoteHandler
+func NewAdminNoteHandler(adminNoteUseCase usecase.AdminNoteUseCase) *AdminNoteHandler {
+	return &AdminNoteHandler{
+		adminNoteUseCase: adminNoteUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes managing the notes on users on the admin group, the only place notes are
+// exposed
+func (h *AdminNoteHandler) RegisterRoutes(adminGroup fiber.Router) {
+	adminGroup.Get("/users/:id/notes", h.List)
+	adminGroup.Post("/users/:id/notes", h.Create)
+	adminGroup.Put("/users/:id/notes/:note_id", h.Update)
+	adminGroup.Delete("/users/:id/notes/:note_id", h.Delete)
+}
+
+// List lists the notes on a user, newest first
+func (h *AdminNoteHandler) List(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	notes, err := h.adminNoteUseCase.List(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list admin notes")
+		return adminNoteError(c, err, "Failed to list admin notes")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"notes": notes,
+	})
+}
+
+// Create writes a note on a user, the authenticated administrator being its author
+func (h *AdminNoteHandler) Create(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req struct {
+		Text string `json:"text" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create admin note request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	note, err := h.adminNoteUseCase.Create(c.Context(), actorID, userID, req.Text)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create admin note")
+		return adminNoteError(c, err, "Failed to create admin note")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(note)
+}
+
+// Update replaces the text of a note written by the authenticated administrator
+func (h *AdminNoteHandler) Update(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+	noteID, err := uuid.Parse(c.Params("note_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid admin note ID",
+		})
+	}
+
+	var req struct {
+		Text string `json:"text" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update admin note request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	note, err := h.adminNoteUseCase.Update(c.Context(), actorID, userID, noteID, req.Text)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("note_id", noteID.String()).Msg("Failed to update admin note")
+		return adminNoteError(c, err, "Failed to update admin note")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(note)
+}
+
+// Delete deletes a note on a user
+func (h *AdminNoteHandler) Delete(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+	noteID, err := uuid.Parse(c.Params("note_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid admin note ID",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if err := h.adminNoteUseCase.Delete(c.Context(), actorID, userID, noteID); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("note_id", noteID.String()).Msg("Failed to delete admin note")
+		return adminNoteError(c, err, "Failed to delete admin note")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Admin note deleted successfully",
+	})
+}
+
+// adminNoteError maps the errors of the admin note routes to responses
+func adminNoteError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidAdminNote):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid admin note, notes are required and at most 4000 characters",
+		})
+	case errors.Is(err, usecase.ErrAdminNoteNotAuthor):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Only the author of an admin note may edit it",
+		})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	case errors.Is(err, usecase.ErrAdminNoteNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Admin note not found",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+	})
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// AnomalyHandler handles HTTP requests reviewing and reversing the automated responses to sign in anomalies
+type AnomalyHandler struct {
+	anomalyUseCase usecase.AnomalyUseCase
+}
+
+// NewAnomalyHandler creates a new AnomalyHandler
+func NewAnomalyHandler(anomalyUseCase usecase.AnomalyUseCase) *AnomalyHandler {
+	return &AnomalyHandler{
+		anomalyUseCase: anomalyUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes for the anomaly handler
+func (h *AnomalyHandler) RegisterRoutes(adminGroup fiber.Router) {
+	adminGroup.Get("/ip-denials", h.ListIPDenials)
+	adminGroup.Delete("/ip-denials/:ip", h.LiftIPDenial)
+	adminGroup.Get("/users/:id/login-countries", h.ListLoginCountries)
+	adminGroup.Post("/users/:id/login-countries", h.TrustLoginCountry)
+}
+
+// ListIPDenials lists the IP addresses denied sign in, newest first
+func (h *AnomalyHandler) ListIPDenials(c *fiber.Ctx) error {
+	denials, err := h.anomalyUseCase.ListIPDenials(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list IP denials")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list IP denials",
+		})
+	}
+
+	if denials == nil {
+		denials = []*entity.IPDenial{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"denials": denials,
+	})
+}
+
+// LiftIPDenial lets an IP address sign in again before its denial expires
+func (h *AnomalyHandler) LiftIPDenial(c *fiber.Ctx) error {
+	ip, err := url.PathUnescape(c.Params("ip"))
+	if err != nil || ip == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid IP address",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if err := h.anomalyUseCase.LiftIPDenial(c.Context(), actorID, ip); err != nil {
+		log.Error().Err(err).Str("ip", ip).Msg("Failed to lift IP denial")
+		return anomalyError(c, err, "Failed to lift IP denial")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "IP denial lifted successfully",
+	})
+}
+
+// ListLoginCountries lists the countries a user signed in from
+func (h *AnomalyHandler) ListLoginCountries(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	countries, err := h.anomalyUseCase.ListLoginCountries(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list login countries")
+		return anomalyError(c, err, "Failed to list login countries")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"countries": countries,
+	})
+}
+
+// TrustLoginCountry lets a user sign in with a password from a country without a step-up
+func (h *AnomalyHandler) TrustLoginCountry(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req struct {
+		Country string `json:"country" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse trust login country request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if err := h.anomalyUseCase.TrustLoginCountry(c.Context(), actorID, userID, req.Country); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to trust login country")
+		return anomalyError(c, err, "Failed to trust login country")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Login country trusted successfully",
+	})
+}
+
+// anomalyError maps the errors of the anomaly use case to responses
+func anomalyError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, usecase.ErrIPDenialNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "IP denial not found",
+		})
+	case errors.Is(err, usecase.ErrInvalidIP):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid IP address",
+		})
+	case errors.Is(err, usecase.ErrInvalidCountry):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid country, an ISO 3166-1 alpha-2 code is required",
+		})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
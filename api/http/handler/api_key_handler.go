@@ -0,0 +1,440 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/api/http/middleware"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// APIKeyHandler handles HTTP requests for the API keys users and organizations call the API with
+type APIKeyHandler struct {
+	apiKeyUseCase usecase.APIKeyUseCase
+	quotaUseCase  usecase.QuotaUseCase
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler, quotaUseCase is nil when quotas are turned off
+func NewAPIKeyHandler(apiKeyUseCase usecase.APIKeyUseCase, quotaUseCase usecase.QuotaUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+		quotaUseCase:  quotaUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes managing the API keys of the authenticated user and of organizations on the
+// router, and the routes listing and revoking the API keys of any user on the admin group. The routes reporting
+// the usage of keys and setting their quota are registered when quotas are turned on.
+func (h *APIKeyHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler, adminGroup fiber.Router) {
+	// API keys cannot create or revoke API keys, a leaked key must not outlive its revocation
+	apiKeyGroup := router.Group("/users/me/api-keys", authMiddleware, h.requireSession)
+
+	apiKeyGroup.Post("/", h.Create)
+	apiKeyGroup.Get("/", h.List)
+	apiKeyGroup.Delete("/:id", h.Revoke)
+
+	adminGroup.Get("/users/:id/api-keys", h.ListOfUser)
+	adminGroup.Delete("/users/:id/api-keys/:key_id", h.RevokeOfUser)
+
+	// The keys of an organization are managed by platform admins and the org admins of the organization
+	orgKeyGroup := router.Group("/organizations/:id/api-keys", authMiddleware, h.requireSession,
+		middleware.RoleMiddleware(entity.UserRoleAdmin, entity.UserRoleOrgAdmin), h.requireOrgScope)
+
+	orgKeyGroup.Post("/", h.CreateForOrg)
+	orgKeyGroup.Get("/", h.ListOfOrg)
+	orgKeyGroup.Delete("/:key_id", h.RevokeOfOrg)
+
+	if h.quotaUseCase != nil {
+		apiKeyGroup.Get("/:id/usage", h.Usage)
+		orgKeyGroup.Get("/:key_id/usage", h.UsageOfOrg)
+
+		// Only platform admins set quotas, they stand for the plan of the owner
+		adminGroup.Get("/users/:id/api-keys/:key_id/usage", h.UsageOfUser)
+		adminGroup.Put("/users/:id/api-keys/:key_id/quota", h.SetQuotaOfUser)
+		adminGroup.Put("/organizations/:id/api-keys/:key_id/quota", h.SetQuotaOfOrg)
+	}
+}
+
+// requireSession refuses the requests authenticated by an API key
+func (h *APIKeyHandler) requireSession(c *fiber.Ctx) error {
+	if _, ok := c.Locals("api_key_id").(uuid.UUID); ok {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "API keys cannot be managed with an API key, please sign in",
+			"code":  "SESSION_REQUIRED",
+		})
+	}
+	return c.Next()
+}
+
+// requireOrgScope refuses the requests of org admins targeting another organization than theirs
+func (h *APIKeyHandler) requireOrgScope(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	if orgID := middleware.ScopedOrgID(c); orgID != nil && *orgID != id {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Organization is not yours",
+		})
+	}
+	return c.Next()
+}
+
+// Create creates an API key for the authenticated user, the key is only returned in this response
+func (h *APIKeyHandler) Create(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create API key",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Name      string     `json:"name"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create API key request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	apiKey, err := h.apiKeyUseCase.Create(c.Context(), userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create API key")
+		return apiKeyError(c, err, "Failed to create API key")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(apiKey)
+}
+
+// List lists the API keys of the authenticated user
+func (h *APIKeyHandler) List(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list API keys",
+		})
+	}
+
+	return h.list(c, userID)
+}
+
+// Revoke revokes an API key of the authenticated user
+func (h *APIKeyHandler) Revoke(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to revoke API key",
+		})
+	}
+
+	return h.revoke(c, userID, userID, c.Params("id"))
+}
+
+// ListOfUser lists the API keys of a user on behalf of an administrator
+func (h *APIKeyHandler) ListOfUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	return h.list(c, userID)
+}
+
+// RevokeOfUser revokes an API key of a user on behalf of an administrator
+func (h *APIKeyHandler) RevokeOfUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+
+	return h.revoke(c, actorID, userID, c.Params("key_id"))
+}
+
+// CreateForOrg creates an API key for an organization, the key is only returned in this response
+func (h *APIKeyHandler) CreateForOrg(c *fiber.Ctx) error {
+	// The organization ID is checked by requireOrgScope
+	orgID, _ := uuid.Parse(c.Params("id"))
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+
+	// Parse request body
+	var req struct {
+		Name      string     `json:"name"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create organization API key request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	apiKey, err := h.apiKeyUseCase.CreateForOrg(c.Context(), actorID, orgID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to create organization API key")
+		return apiKeyError(c, err, "Failed to create API key")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(apiKey)
+}
+
+// ListOfOrg lists the API keys of an organization
+func (h *APIKeyHandler) ListOfOrg(c *fiber.Ctx) error {
+	orgID, _ := uuid.Parse(c.Params("id"))
+
+	apiKeys, err := h.apiKeyUseCase.ListForOrg(c.Context(), orgID)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to list organization API keys")
+		return apiKeyError(c, err, "Failed to list API keys")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"api_keys": apiKeys,
+	})
+}
+
+// RevokeOfOrg revokes an API key of an organization
+func (h *APIKeyHandler) RevokeOfOrg(c *fiber.Ctx) error {
+	orgID, _ := uuid.Parse(c.Params("id"))
+	id, err := uuid.Parse(c.Params("key_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+
+	if err := h.apiKeyUseCase.RevokeForOrg(c.Context(), actorID, orgID, id); err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("api_key_id", id.String()).Msg("Failed to revoke organization API key")
+		return apiKeyError(c, err, "Failed to revoke API key")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "API key revoked successfully",
+	})
+}
+
+// Usage reports the quota and usage of an API key of the authenticated user
+func (h *APIKeyHandler) Usage(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get API key usage",
+		})
+	}
+
+	return h.usage(c, userID, c.Params("id"))
+}
+
+// UsageOfUser reports the quota and usage of an API key of a user on behalf of an administrator
+func (h *APIKeyHandler) UsageOfUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	return h.usage(c, userID, c.Params("key_id"))
+}
+
+// UsageOfOrg reports the quota and usage of an API key of an organization
+func (h *APIKeyHandler) UsageOfOrg(c *fiber.Ctx) error {
+	orgID, _ := uuid.Parse(c.Params("id"))
+	id, err := uuid.Parse(c.Params("key_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+
+	report, err := h.quotaUseCase.OrgAPIKeyUsage(c.Context(), orgID, id)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("api_key_id", id.String()).Msg("Failed to get organization API key usage")
+		return apiKeyError(c, err, "Failed to get API key usage")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// SetQuotaOfUser sets the quota of an API key of a user on behalf of an administrator
+func (h *APIKeyHandler) SetQuotaOfUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+	id, err := uuid.Parse(c.Params("key_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+
+	var req entity.SetQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse set API key quota request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	report, err := h.quotaUseCase.SetAPIKeyQuota(c.Context(), actorID, userID, id, req.Quota)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("api_key_id", id.String()).Msg("Failed to set API key quota")
+		return apiKeyError(c, err, "Failed to set API key quota")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// SetQuotaOfOrg sets the quota of an API key of an organization on behalf of an administrator
+func (h *APIKeyHandler) SetQuotaOfOrg(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+	id, err := uuid.Parse(c.Params("key_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+
+	var req entity.SetQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse set organization API key quota request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	report, err := h.quotaUseCase.SetOrgAPIKeyQuota(c.Context(), actorID, orgID, id, req.Quota)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("api_key_id", id.String()).Msg("Failed to set organization API key quota")
+		return apiKeyError(c, err, "Failed to set API key quota")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// list responds with the API keys of a user
+func (h *APIKeyHandler) list(c *fiber.Ctx, userID uuid.UUID) error {
+	apiKeys, err := h.apiKeyUseCase.List(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list API keys")
+		return apiKeyError(c, err, "Failed to list API keys")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"api_keys": apiKeys,
+	})
+}
+
+// revoke revokes an API key of a user on behalf of an actor
+func (h *APIKeyHandler) revoke(c *fiber.Ctx, actorID, userID uuid.UUID, keyID string) error {
+	id, err := uuid.Parse(keyID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+
+	if err := h.apiKeyUseCase.Revoke(c.Context(), actorID, userID, id); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("api_key_id", id.String()).Msg("Failed to revoke API key")
+		return apiKeyError(c, err, "Failed to revoke API key")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "API key revoked successfully",
+	})
+}
+
+// usage responds with the quota and usage of an API key of a user
+func (h *APIKeyHandler) usage(c *fiber.Ctx, userID uuid.UUID, keyID string) error {
+	id, err := uuid.Parse(keyID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+
+	report, err := h.quotaUseCase.APIKeyUsage(c.Context(), userID, id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("api_key_id", id.String()).Msg("Failed to get API key usage")
+		return apiKeyError(c, err, "Failed to get API key usage")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// apiKeyError maps the errors of the API key routes to responses
+func apiKeyError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidAPIKeyName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key name, names are at most 64 characters",
+		})
+	case errors.Is(err, usecase.ErrInvalidAPIKeyScope):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key scope, scopes are " + strings.Join(entity.AllAPIKeyScopes, ", "),
+		})
+	case errors.Is(err, usecase.ErrInvalidAPIKeyExpiration):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key expiration, it must be in the future and within the maximum lifetime",
+		})
+	case errors.Is(err, usecase.ErrInvalidQuota):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid quota, quotas are non-negative numbers of requests, 0 for unlimited",
+		})
+	case errors.Is(err, usecase.ErrTooManyAPIKeys):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Too many API keys, revoke one before creating another",
+		})
+	case errors.Is(err, usecase.ErrAPIKeyNotFound), errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "API key not found",
+		})
+	case errors.Is(err, usecase.ErrOrganizationNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Organization not found",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+	})
+}
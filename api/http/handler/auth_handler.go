@@ -2,23 +2,36 @@ package handler
 
 import (
 	"errors"
+	"time"
 
+	"github.com/chats/go-user-api/config"
 	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/service"
 	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/chats/go-user-api/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
+// passwordChangePath is where clients complete a sign in that must change the password
+const passwordChangePath = "/api/v1/auth/password-change"
+
 // AuthHandler handles HTTP requests for authentication
 type AuthHandler struct {
 	authUseCase usecase.AuthUseCase
+	nameService service.NameService
+	session     config.SessionConfig
+	anomaly     config.AnomalyConfig
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(authUseCase usecase.AuthUseCase) *AuthHandler {
+func NewAuthHandler(authUseCase usecase.AuthUseCase, nameService service.NameService, session config.SessionConfig, anomaly config.AnomalyConfig) *AuthHandler {
 	return &AuthHandler{
 		authUseCase: authUseCase,
+		nameService: nameService,
+		session:     session,
+		anomaly:     anomaly,
 	}
 }
 
@@ -33,14 +46,41 @@ func (h *AuthHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.H
 	// Protected routes
 	authGroup.Post("/logout", authMiddleware, h.Logout)
 	authGroup.Post("/logout-all", authMiddleware, h.LogoutAll)
+	authGroup.Post("/verify-email", authMiddleware, h.RequestEmailVerification)
+	authGroup.Post("/verify-email/confirm", h.ConfirmEmailVerification)
+	authGroup.Put("/recovery-email", authMiddleware, h.SetRecoveryEmail)
+	authGroup.Delete("/recovery-email", authMiddleware, h.RemoveRecoveryEmail)
+	authGroup.Post("/recovery-email/confirm", h.ConfirmRecoveryEmail)
+	authGroup.Post("/forgot-password", h.RequestPasswordReset)
+	authGroup.Post("/reset-password", h.ResetPassword)
+	authGroup.Post("/password-change", h.ChangeRequiredPassword)
+
+	// Former paths of the forgot password flow, kept for existing clients
+	authGroup.Post("/password-reset", h.RequestPasswordReset)
+	authGroup.Post("/password-reset/confirm", h.ResetPassword)
+
+	// Passkeys, signing in is public while managing passkeys requires a session
+	passkeyGroup := authGroup.Group("/passkeys")
+
+	passkeyGroup.Post("/login/begin", h.BeginPasskeyLogin)
+	passkeyGroup.Post("/login/finish", h.FinishPasskeyLogin)
+	passkeyGroup.Post("/register/begin", authMiddleware, h.BeginPasskeyRegistration)
+	passkeyGroup.Post("/register/finish", authMiddleware, h.FinishPasskeyRegistration)
+	passkeyGroup.Get("/", authMiddleware, h.ListPasskeys)
+	passkeyGroup.Delete("/:id", authMiddleware, h.DeletePasskey)
 }
 
 // Login handles user login and returns access and refresh tokens
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	// Parse request body
 	var req struct {
-		Email    string `json:"email" validate:"required,email"`
+		// Identifier is the email or username of the account
+		Identifier string `json:"identifier"`
+		// Email is accepted in place of the identifier for existing clients
+		Email    string `json:"email"`
 		Password string `json:"password" validate:"required"`
+		// RememberMe asks for a persistent session, whose refresh token lasts longer
+		RememberMe bool `json:"remember_me"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
@@ -50,57 +90,174 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
+	identifier := req.Identifier
+	if identifier == "" {
+		identifier = req.Email
+	}
+
 	// Validate request
-	if req.Email == "" || req.Password == "" {
+	if identifier == "" || req.Password == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Email and password are required",
+			"error": "Identifier and password are required",
 		})
 	}
 
 	// Login user
-	response, err := h.authUseCase.Login(c.Context(), req.Email, req.Password)
+	response, err := h.authUseCase.Login(c.Context(), identifier, req.Password, req.RememberMe, h.clientInfo(c))
 	if err != nil {
-		log.Error().Err(err).Str("email", req.Email).Msg("Failed to login user")
+		log.Error().Err(err).Str("identifier", identifier).Msg("Failed to login user")
+		return h.loginError(c, err)
+	}
 
-		if errors.Is(err, usecase.ErrInvalidCredentials) {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid credentials",
-			})
-		}
+	return h.loginResponse(c, response)
+}
 
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to login user",
+// loginError maps the errors of a sign in to responses
+func (h *AuthHandler) loginError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, usecase.ErrInvalidCredentials) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid credentials",
+		})
+	}
+	if errors.Is(err, usecase.ErrAccountLocked) {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": "Too many failed login attempts, please try again later",
+			"code":  "ACCOUNT_LOCKED",
 		})
 	}
 
-	// Return tokens and user info
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+	var blockedErr *usecase.AccountBlockedError
+	if errors.As(err, &blockedErr) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":       "The account is blocked",
+			"code":        "ACCOUNT_BLOCKED",
+			"reason_code": blockedErr.Reason.Code,
+			"note":        blockedErr.Reason.Note,
+		})
+	}
+
+	if errors.Is(err, usecase.ErrAccountInactive) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "The account is inactive",
+			"code":  "ACCOUNT_INACTIVE",
+		})
+	}
+
+	if errors.Is(err, usecase.ErrAccountWaitlisted) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "The account is on the waitlist, you will be emailed once it is approved",
+			"code":  "ACCOUNT_WAITLISTED",
+		})
+	}
+
+	// The organization of the user requires its provider, point the client at it
+	var ssoErr *usecase.SSORequiredError
+	if errors.As(err, &ssoErr) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":     "The organization requires signing in with " + ssoErr.Provider,
+			"code":      "SSO_REQUIRED",
+			"provider":  ssoErr.Provider,
+			"login_url": ssoErr.LoginURL,
+		})
+	}
+
+	if errors.Is(err, usecase.ErrPasswordResetRequired) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "The password must be reset before signing in",
+			"code":  "PASSWORD_RESET_REQUIRED",
+		})
+	}
+
+	// An administrator required a new password, point the client at the change screen
+	var changeErr *usecase.PasswordChangeRequiredError
+	if errors.As(err, &changeErr) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":        "The password must be changed before signing in",
+			"code":         "PASSWORD_CHANGE_REQUIRED",
+			"change_token": changeErr.Token,
+			"expires_at":   changeErr.ExpiresAt,
+			"change_url":   passwordChangePath,
+		})
+	}
+
+	if errors.Is(err, usecase.ErrIPDenied) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Sign in from this address is temporarily denied",
+			"code":  "IP_DENIED",
+		})
+	}
+
+	if errors.Is(err, usecase.ErrSessionLimitReached) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Too many active sessions, sign out of another device first",
+			"code":  "SESSION_LIMIT_REACHED",
+		})
+	}
+
+	if errors.Is(err, usecase.ErrStepUpRequired) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Sign in with a passkey to continue",
+			"code":  "STEP_UP_REQUIRED",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to login user",
+	})
+}
+
+// clientInfo returns the address of the client and the country the edge proxy located it in
+func (h *AuthHandler) clientInfo(c *fiber.Ctx) entity.ClientInfo {
+	client := entity.ClientInfo{IP: c.IP()}
+	if h.anomaly.CountryHeader != "" {
+		client.Country = c.Get(h.anomaly.CountryHeader)
+	}
+	return client
+}
+
+// loginResponse returns the tokens and user info of a successful sign in
+func (h *AuthHandler) loginResponse(c *fiber.Ctx, response *entity.LoginResponse) error {
+	body, err := h.tokenResponse(c, &response.AuthTokens, fiber.Map{
 		"user": fiber.Map{
-			"id":         response.User.ID,
-			"email":      response.User.Email,
-			"username":   response.User.Username,
-			"first_name": response.User.FirstName,
-			"last_name":  response.User.LastName,
-			"role":       response.User.Role,
-			"status":     response.User.Status,
+			"id":           response.User.ID,
+			"email":        response.User.Email,
+			"username":     response.User.Username,
+			"first_name":   response.User.FirstName,
+			"last_name":    response.User.LastName,
+			"display_name": h.nameService.DisplayName(response.User),
+			"locale":       response.User.Locale,
+			"role":         response.User.Role,
+			"status":       response.User.Status,
 		},
-		"token_type":    "Bearer",
-		"access_token":  response.AuthTokens.AccessToken,
-		"refresh_token": response.AuthTokens.RefreshToken,
-		"expires_at":    response.AuthTokens.ExpiresAt,
 	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate CSRF token")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to login user",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(body)
 }
 
 // RefreshToken refreshes the access token using a refresh token
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
-	// Parse request body
 	var req entity.RefreshTokenRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		log.Error().Err(err).Msg("Failed to parse refresh token request body")
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	// In cookie mode the refresh token comes from the session cookie, the body stays
+	// accepted for clients that are not browsers
+	if h.session.CookieMode {
+		req.RefreshToken = c.Cookies(h.session.CookieName)
+	}
+
+	// Parse request body
+	if req.RefreshToken == "" {
+		if err := c.BodyParser(&req); err != nil {
+			log.Error().Err(err).Msg("Failed to parse refresh token request body")
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
 	}
 
 	// Validate request
@@ -115,7 +272,8 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to refresh token")
 
-		if errors.Is(err, usecase.ErrInvalidRefreshToken) || errors.Is(err, usecase.ErrRefreshTokenExpired) {
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) || errors.Is(err, usecase.ErrRefreshTokenExpired) || errors.Is(err, usecase.ErrRefreshTokenReused) {
+			h.clearRefreshCookie(c)
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "Invalid or expired refresh token",
 			})
@@ -127,17 +285,27 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	}
 
 	// Return new tokens
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"token_type":    "Bearer",
-		"access_token":  tokens.AccessToken,
-		"refresh_token": tokens.RefreshToken,
-		"expires_at":    tokens.ExpiresAt,
-	})
+	body, err := h.tokenResponse(c, tokens, fiber.Map{})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate CSRF token")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to refresh token",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(body)
 }
 
-// Logout logs out a user by invalidating their access token
+// Logout logs out a user by invalidating the access and refresh tokens of their session
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
-	// Get token ID from context
+	// Get session and token IDs from context
+	sessionID, ok := c.Locals("session_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("Session ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to logout",
+		})
+	}
 	tokenID, ok := c.Locals("token_id").(uuid.UUID)
 	if !ok {
 		log.Error().Msg("Token ID not found in context")
@@ -147,13 +315,14 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 	}
 
 	// Logout user
-	if err := h.authUseCase.Logout(c.Context(), tokenID); err != nil {
-		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to logout user")
+	if err := h.authUseCase.Logout(c.Context(), sessionID, tokenID); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Str("token_id", tokenID.String()).Msg("Failed to logout user")
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to logout",
 		})
 	}
 
+	h.clearRefreshCookie(c)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Successfully logged out",
 	})
@@ -178,7 +347,530 @@ func (h *AuthHandler) LogoutAll(c *fiber.Ctx) error {
 		})
 	}
 
+	h.clearRefreshCookie(c)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Successfully logged out from all devices",
 	})
 }
+
+// RequestEmailVerification emails a verification token to the authenticated user
+func (h *AuthHandler) RequestEmailVerification(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to send email verification",
+		})
+	}
+
+	if err := h.authUseCase.RequestEmailVerification(c.Context(), userID); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to send email verification")
+
+		switch {
+		case errors.Is(err, usecase.ErrAlreadyVerified):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Email already verified",
+			})
+		case errors.Is(err, service.ErrEmailSuppressed):
+			return emailSuppressedError(c)
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to send email verification",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Verification email sent",
+	})
+}
+
+// ConfirmEmailVerification verifies an email address with a token received by email
+func (h *AuthHandler) ConfirmEmailVerification(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Verification token is required",
+		})
+	}
+
+	if err := h.authUseCase.ConfirmEmailVerification(c.Context(), req.Token); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidVerificationToken):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid or expired verification token",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to confirm email verification")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to verify email",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Email verified successfully",
+	})
+}
+
+// SetRecoveryEmail sets the recovery email of the authenticated user and emails it a verification token
+func (h *AuthHandler) SetRecoveryEmail(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Recovery email is required",
+		})
+	}
+
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to set recovery email",
+		})
+	}
+
+	if err := h.authUseCase.SetRecoveryEmail(c.Context(), userID, req.Email); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidRecoveryEmail):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Recovery email must be a valid address different from the account email",
+			})
+		case errors.Is(err, service.ErrEmailSuppressed):
+			return emailSuppressedError(c)
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		default:
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to set recovery email")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to set recovery email",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Verification email sent to the recovery email",
+	})
+}
+
+// RemoveRecoveryEmail removes the recovery email of the authenticated user
+func (h *AuthHandler) RemoveRecoveryEmail(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to remove recovery email",
+		})
+	}
+
+	if err := h.authUseCase.RemoveRecoveryEmail(c.Context(), userID); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrRecoveryEmailNotSet), errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Recovery email not set",
+			})
+		default:
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to remove recovery email")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to remove recovery email",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Recovery email removed successfully",
+	})
+}
+
+// ConfirmRecoveryEmail verifies a recovery email with a token received at that address
+func (h *AuthHandler) ConfirmRecoveryEmail(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Token string `json:"token" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Verification token is required",
+		})
+	}
+
+	if err := h.authUseCase.ConfirmRecoveryEmail(c.Context(), req.Token); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidVerificationToken):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid or expired verification token",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to confirm recovery email")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to verify recovery email",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Recovery email verified successfully",
+	})
+}
+
+// RequestPasswordReset emails a password reset token to the account email or verified recovery email.
+// The response is the same whether or not an account matches, so it cannot be used to probe for accounts.
+func (h *AuthHandler) RequestPasswordReset(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Email is required",
+		})
+	}
+
+	if err := h.authUseCase.RequestPasswordReset(c.Context(), req.Email); err != nil {
+		log.Error().Err(err).Msg("Failed to request password reset")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to request password reset",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "If an account matches, a password reset email has been sent",
+	})
+}
+
+// ResetPassword sets a new password with a token received by email
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required,min=8"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Token == "" || req.Password == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Reset token and password are required",
+		})
+	}
+
+	if err := h.authUseCase.ResetPassword(c.Context(), req.Token, req.Password); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidResetToken):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid or expired reset token",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to reset password")
+			return passwordError(c, err, "Failed to reset password")
+		}
+	}
+
+	h.clearRefreshCookie(c)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Password reset successfully",
+	})
+}
+
+// ChangeRequiredPassword changes a password an administrator required to change, with the token returned by the
+// sign in, and completes the sign in
+func (h *AuthHandler) ChangeRequiredPassword(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		ChangeToken string `json:"change_token" validate:"required"`
+		NewPassword string `json:"new_password" validate:"required,min=8"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.ChangeToken == "" || req.NewPassword == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Change token and new password are required",
+		})
+	}
+
+	response, err := h.authUseCase.ChangeRequiredPassword(c.Context(), req.ChangeToken, req.NewPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPasswordChangeToken):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid or expired change token, please sign in again",
+			})
+		case errors.Is(err, usecase.ErrPasswordUnchanged):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "The new password must differ from the current one, please sign in again",
+				"code":  "PASSWORD_UNCHANGED",
+			})
+		case errors.Is(err, usecase.ErrAccountBlocked), errors.Is(err, usecase.ErrAccountInactive),
+			errors.Is(err, usecase.ErrAccountWaitlisted), errors.Is(err, usecase.ErrSSORequired),
+			errors.Is(err, usecase.ErrPasswordResetRequired):
+			return h.loginError(c, err)
+		default:
+			log.Error().Err(err).Msg("Failed to change required password")
+			return passwordError(c, err, "Failed to change password")
+		}
+	}
+
+	return h.loginResponse(c, response)
+}
+
+// BeginPasskeyRegistration starts the registration of a passkey by the authenticated user
+func (h *AuthHandler) BeginPasskeyRegistration(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to start passkey registration",
+		})
+	}
+
+	response, err := h.authUseCase.BeginPasskeyRegistration(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to start passkey registration")
+		return passkeyError(c, err, "Failed to start passkey registration")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// FinishPasskeyRegistration stores the passkey created by the authenticator of the authenticated user
+func (h *AuthHandler) FinishPasskeyRegistration(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to register passkey",
+		})
+	}
+
+	// Parse request body, the credential is the JSON serialization of the PublicKeyCredential
+	var req struct {
+		CeremonyID uuid.UUID `json:"ceremony_id" validate:"required"`
+		Name       string    `json:"name"`
+		Credential struct {
+			RawID    entity.Base64URL          `json:"rawId"`
+			Response entity.PasskeyAttestation `json:"response"`
+		} `json:"credential" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse passkey registration request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	attestation := req.Credential.Response
+	attestation.RawID = req.Credential.RawID
+	passkey, err := h.authUseCase.FinishPasskeyRegistration(c.Context(), userID, req.CeremonyID, req.Name, &attestation)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to register passkey")
+		return passkeyError(c, err, "Failed to register passkey")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(passkey)
+}
+
+// BeginPasskeyLogin starts a sign in with a passkey
+func (h *AuthHandler) BeginPasskeyLogin(c *fiber.Ctx) error {
+	response, err := h.authUseCase.BeginPasskeyLogin(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to start passkey sign in")
+		return passkeyError(c, err, "Failed to start passkey sign in")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// FinishPasskeyLogin signs in the owner of the passkey that signed the challenge and returns tokens
+func (h *AuthHandler) FinishPasskeyLogin(c *fiber.Ctx) error {
+	// Parse request body, the credential is the JSON serialization of the PublicKeyCredential
+	var req struct {
+		CeremonyID uuid.UUID `json:"ceremony_id" validate:"required"`
+		Credential struct {
+			RawID    entity.Base64URL        `json:"rawId"`
+			Response entity.PasskeyAssertion `json:"response"`
+		} `json:"credential" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse passkey sign in request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	assertion := req.Credential.Response
+	assertion.RawID = req.Credential.RawID
+	response, err := h.authUseCase.FinishPasskeyLogin(c.Context(), req.CeremonyID, &assertion, h.clientInfo(c))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sign in with passkey")
+
+		// Unknown passkeys and signatures that do not verify are answered alike
+		if errors.Is(err, usecase.ErrPasskeyNotFound) || errors.Is(err, service.ErrInvalidPasskey) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid passkey",
+			})
+		}
+		if errors.Is(err, usecase.ErrPasskeyCeremonyNotFound) {
+			return passkeyError(c, err, "Failed to sign in with passkey")
+		}
+		return h.loginError(c, err)
+	}
+
+	return h.loginResponse(c, response)
+}
+
+// ListPasskeys lists the passkeys of the authenticated user
+func (h *AuthHandler) ListPasskeys(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list passkeys",
+		})
+	}
+
+	passkeys, err := h.authUseCase.ListPasskeys(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list passkeys")
+		return passkeyError(c, err, "Failed to list passkeys")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"passkeys": passkeys,
+	})
+}
+
+// DeletePasskey deletes a passkey of the authenticated user
+func (h *AuthHandler) DeletePasskey(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete passkey",
+		})
+	}
+
+	if err := h.authUseCase.DeletePasskey(c.Context(), userID, c.Params("id")); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete passkey")
+		return passkeyError(c, err, "Failed to delete passkey")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Passkey deleted successfully",
+	})
+}
+
+// passkeyError maps the errors of the passkey routes to responses
+func passkeyError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidPasskey):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid passkey",
+		})
+	case errors.Is(err, usecase.ErrPasskeyCeremonyNotFound):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Unknown or expired passkey ceremony, please start again",
+			"code":  "PASSKEY_CEREMONY_EXPIRED",
+		})
+	case errors.Is(err, usecase.ErrInvalidPasskeyName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid passkey name, names are at most 64 characters",
+		})
+	case errors.Is(err, usecase.ErrTooManyPasskeys):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Too many passkeys, remove one before adding another",
+		})
+	case errors.Is(err, usecase.ErrPasskeyNotFound), errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Passkey not found",
+		})
+	case errors.Is(err, usecase.ErrLastCredential):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "This passkey is the only way to sign in to your account, set a password or add another sign in method first",
+			"code":  "LAST_CREDENTIAL",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+	})
+}
+
+// tokenResponse adds the tokens to a response body, in cookie mode the refresh token
+// is set in the session cookie and left out of the body, along with a new CSRF token
+func (h *AuthHandler) tokenResponse(c *fiber.Ctx, tokens *entity.AuthTokens, body fiber.Map) (fiber.Map, error) {
+	body["token_type"] = "Bearer"
+	body["access_token"] = tokens.AccessToken
+	body["expires_at"] = tokens.ExpiresAt
+
+	if !h.session.CookieMode {
+		body["refresh_token"] = tokens.RefreshToken
+		return body, nil
+	}
+
+	csrfToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Cookie(h.refreshCookie(tokens.RefreshToken, tokens.RefreshExpiresAt))
+	c.Cookie(h.csrfCookie(csrfToken, tokens.RefreshExpiresAt))
+	body["csrf_token"] = csrfToken
+	return body, nil
+}
+
+// clearRefreshCookie expires the session and CSRF cookies in cookie mode
+func (h *AuthHandler) clearRefreshCookie(c *fiber.Ctx) {
+	if h.session.CookieMode {
+		c.Cookie(h.refreshCookie("", time.Unix(0, 0)))
+		c.Cookie(h.csrfCookie("", time.Unix(0, 0)))
+	}
+}
+
+// csrfCookie builds the cookie carrying the CSRF token, readable by scripts so they can echo it in the CSRF header
+func (h *AuthHandler) csrfCookie(value string, expires time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     h.session.CSRFCookieName,
+		Value:    value,
+		Path:     "/",
+		Domain:   h.session.CookieDomain,
+		Expires:  expires,
+		Secure:   h.session.CookieSecure,
+		SameSite: h.session.CookieSameSite,
+	}
+}
+
+// refreshCookie builds the httpOnly cookie carrying the refresh token
+func (h *AuthHandler) refreshCookie(value string, expires time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     h.session.CookieName,
+		Value:    value,
+		Path:     h.session.CookiePath,
+		Domain:   h.session.CookieDomain,
+		Expires:  expires,
+		Secure:   h.session.CookieSecure,
+		HTTPOnly: true,
+		SameSite: h.session.CookieSameSite,
+	}
+}
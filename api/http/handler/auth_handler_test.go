@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLoginIssuesWorkingTokens(t *testing.T) {
+	c := newTestClient(t)
+
+	tokens := c.login(userEmail, userPassword)
+
+	if status := c.do(fiber.MethodGet, "/api/v1/users/"+tokens.User.ID, tokens.AccessToken, nil, nil); status != fiber.StatusOK {
+		t.Errorf("own account with the access token: got status %d, want %d", status, fiber.StatusOK)
+	}
+	if _, status := c.refresh(tokens.RefreshToken); status != fiber.StatusOK {
+		t.Errorf("refresh: got status %d, want %d", status, fiber.StatusOK)
+	}
+}
+
+func TestLoginRejectsWrongPassword(t *testing.T) {
+	c := newTestClient(t)
+
+	body := map[string]string{"email": userEmail, "password": "not " + userPassword}
+	if status := c.do(fiber.MethodPost, "/api/v1/auth/login", "", body, nil); status != fiber.StatusUnauthorized {
+		t.Errorf("got status %d, want %d", status, fiber.StatusUnauthorized)
+	}
+}
+
+func TestRefreshTokenReuseSignsOutSession(t *testing.T) {
+	c := newTestClient(t)
+	original := c.login(userEmail, userPassword)
+
+	rotated, status := c.refresh(original.RefreshToken)
+	if status != fiber.StatusOK {
+		t.Fatalf("refresh: got status %d, want %d", status, fiber.StatusOK)
+	}
+
+	if _, status := c.refresh(original.RefreshToken); status != fiber.StatusUnauthorized {
+		t.Fatalf("rotated refresh token: got status %d, want %d", status, fiber.StatusUnauthorized)
+	}
+
+	// The tokens rotated to the legitimate client, or to the thief, are revoked with the session
+	if status := c.do(fiber.MethodGet, "/api/v1/users/"+original.User.ID, rotated.AccessToken, nil, nil); status != fiber.StatusUnauthorized {
+		t.Errorf("rotated access token: got status %d, want %d", status, fiber.StatusUnauthorized)
+	}
+	if _, status := c.refresh(rotated.RefreshToken); status != fiber.StatusUnauthorized {
+		t.Errorf("rotated refresh token: got status %d, want %d", status, fiber.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// DeviceHandler handles HTTP requests for the device authorization grant of headless clients
+type DeviceHandler struct {
+	deviceUseCase usecase.DeviceUseCase
+}
+
+// NewDeviceHandler creates a new DeviceHandler
+func NewDeviceHandler(deviceUseCase usecase.DeviceUseCase) *DeviceHandler {
+	return &DeviceHandler{
+		deviceUseCase: deviceUseCase,
+	}
+}
+
+// RegisterRoutes registers the public routes polled by devices and the protected routes of the verification page
+func (h *DeviceHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler) {
+	deviceGroup := router.Group("/auth/device")
+
+	// Public routes
+	deviceGroup.Post("/code", h.RequestCode)
+	deviceGroup.Post("/token", h.Token)
+
+	// Protected routes
+	deviceGroup.Get("/:user_code", authMiddleware, h.GetPending)
+	deviceGroup.Post("/:user_code/approve", authMiddleware, h.Approve)
+	deviceGroup.Post("/:user_code/deny", authMiddleware, h.Deny)
+}
+
+// RequestCode starts the sign in of a device, returning its device code and the user code to display
+func (h *DeviceHandler) RequestCode(c *fiber.Ctx) error {
+	var req struct {
+		ClientID string `json:"client_id" form:"client_id"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             "invalid_request",
+			"error_description": "Invalid request body",
+		})
+	}
+
+	codes, err := h.deviceUseCase.RequestCode(c.Context(), req.ClientID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrDeviceInvalidRequest) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":             "invalid_request",
+				"error_description": "A client_id of up to 64 characters is required",
+			})
+		}
+		log.Error().Err(err).Msg("Failed to request device code")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "server_error",
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(codes)
+}
+
+// Token returns the tokens of an approved sign in to the polling device. Errors follow RFC 8628,
+// so devices keep polling on authorization_pending and slow down on slow_down.
+func (h *DeviceHandler) Token(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	var req struct {
+		GrantType  string `json:"grant_type" form:"grant_type"`
+		DeviceCode string `json:"device_code" form:"device_code"`
+		ClientID   string `json:"client_id" form:"client_id"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             "invalid_request",
+			"error_description": "Invalid request body",
+		})
+	}
+
+	tokens, err := h.deviceUseCase.PollToken(c.Context(), req.GrantType, req.DeviceCode, req.ClientID)
+	if err != nil {
+		var code string
+		switch {
+		case errors.Is(err, usecase.ErrDeviceInvalidRequest):
+			code = "invalid_request"
+		case errors.Is(err, usecase.ErrDeviceUnsupportedGrantType):
+			code = "unsupported_grant_type"
+		case errors.Is(err, usecase.ErrDeviceAuthorizationPending):
+			code = "authorization_pending"
+		case errors.Is(err, usecase.ErrDeviceSlowDown):
+			code = "slow_down"
+		case errors.Is(err, usecase.ErrDeviceAccessDenied):
+			code = "access_denied"
+		case errors.Is(err, usecase.ErrDeviceCodeExpired):
+			code = "expired_token"
+		case errors.Is(err, usecase.ErrSessionLimitReached):
+			// The approval is spent, the user has to end a session and sign the device in again
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":             "access_denied",
+				"error_description": "Too many active sessions",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to poll device token")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "server_error",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": code,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokens)
+}
+
+// GetPending returns the pending sign in of a user code, for the user to check the client before approving it
+func (h *DeviceHandler) GetPending(c *fiber.Ctx) error {
+	authorization, err := h.deviceUseCase.GetPending(c.Context(), c.Params("user_code"))
+	if err != nil {
+		return deviceError(c, err, "Failed to get device sign in")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user_code":  authorization.UserCode,
+		"client_id":  authorization.ClientID,
+		"created_at": authorization.CreatedAt,
+		"expires_at": authorization.ExpiresAt,
+	})
+}
+
+// Approve approves the pending sign in of a user code as the authenticated user
+func (h *DeviceHandler) Approve(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to approve device sign in",
+		})
+	}
+
+	if err := h.deviceUseCase.Approve(c.Context(), userID, c.Params("user_code")); err != nil {
+		return deviceError(c, err, "Failed to approve device sign in")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Device signed in successfully",
+	})
+}
+
+// Deny denies the pending sign in of a user code
+func (h *DeviceHandler) Deny(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to deny device sign in",
+		})
+	}
+
+	if err := h.deviceUseCase.Deny(c.Context(), userID, c.Params("user_code")); err != nil {
+		return deviceError(c, err, "Failed to deny device sign in")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Device sign in denied",
+	})
+}
+
+// deviceError maps the errors of the verification page routes to responses
+func deviceError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, usecase.ErrDeviceCodeNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Unknown or expired user code",
+			"code":  "DEVICE_CODE_NOT_FOUND",
+		})
+	case errors.Is(err, usecase.ErrAccountInactive):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Account is not active",
+			"code":  "ACCOUNT_INACTIVE",
+		})
+	case errors.Is(err, usecase.ErrPasswordResetRequired):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Password reset required",
+			"code":  "PASSWORD_RESET_REQUIRED",
+		})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	default:
+		log.Error().Err(err).Msg(message)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": message,
+		})
+	}
+}
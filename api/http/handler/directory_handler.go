@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// DirectoryHandler handles HTTP requests for the reconciliation of the users against the upstream directory
+type DirectoryHandler struct {
+	directoryUseCase usecase.DirectoryUseCase
+}
+
+// NewDirectoryHandler creates a new DirectoryHandler
+func NewDirectoryHandler(directoryUseCase usecase.DirectoryUseCase) *DirectoryHandler {
+	return &DirectoryHandler{
+		directoryUseCase: directoryUseCase,
+	}
+}
+
+// RegisterRoutes registers the directory routes on the admin group
+func (h *DirectoryHandler) RegisterRoutes(adminGroup fiber.Router) {
+	adminGroup.Get("/directory/report", h.LatestReport)
+	adminGroup.Post("/directory/sync", h.Sync)
+}
+
+// Sync reconciles the users against the directory now, returning the drift report. The body is optional, a dry
+// run only reports the changes.
+func (h *DirectoryHandler) Sync(c *fiber.Ctx) error {
+	var req struct {
+		DryRun bool `json:"dry_run"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			log.Error().Err(err).Msg("Failed to parse directory sync request body")
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	actorID := c.Locals("user_id").(uuid.UUID)
+	report, err := h.directoryUseCase.Reconcile(c.Context(), actorID, req.DryRun)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sync users with the directory")
+
+		switch {
+		case errors.Is(err, usecase.ErrDirectoryNotConfigured):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "No directory is configured",
+				"code":  "DIRECTORY_NOT_CONFIGURED",
+			})
+		case errors.Is(err, usecase.ErrDirectorySyncRunning):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "A directory sync is already running",
+				"code":  "DIRECTORY_SYNC_RUNNING",
+			})
+		case errors.Is(err, usecase.ErrDirectoryUnavailable), errors.Is(err, usecase.ErrDirectoryEmpty):
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+				"error": "Failed to read the directory",
+				"code":  "DIRECTORY_UNAVAILABLE",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to sync users with the directory",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// LatestReport returns the drift report of the latest reconciliation
+func (h *DirectoryHandler) LatestReport(c *fiber.Ctx) error {
+	report, err := h.directoryUseCase.LatestReport(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get directory drift report")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get directory drift report",
+		})
+	}
+	if report == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "No reconciliation has run yet",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
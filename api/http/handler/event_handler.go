@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EventHandler handles HTTP requests for the domain event schemas
+type EventHandler struct {
+	eventService service.EventService
+}
+
+// NewEventHandler creates a new EventHandler
+func NewEventHandler(eventService service.EventService) *EventHandler {
+	return &EventHandler{
+		eventService: eventService,
+	}
+}
+
+// RegisterRoutes registers the routes for the event handler
+func (h *EventHandler) RegisterRoutes(router fiber.Router) {
+	eventGroup := router.Group("/events")
+
+	eventGroup.Get("/schemas", h.ListSchemas)
+	eventGroup.Get("/schemas/:type/:version", h.GetSchema)
+}
+
+// ListSchemas lists every version of every event schema
+func (h *EventHandler) ListSchemas(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"schemas": h.eventService.Schemas(),
+	})
+}
+
+// GetSchema returns the JSON Schema of an event type at a version, e.g. /events/schemas/user.created/v1
+func (h *EventHandler) GetSchema(c *fiber.Ctx) error {
+	version, err := strconv.Atoi(strings.TrimPrefix(c.Params("version"), "v"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid schema version",
+		})
+	}
+
+	schema, ok := h.eventService.Schema(c.Params("type"), version)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Schema not found",
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	c.Set(fiber.HeaderContentType, "application/schema+json")
+	return c.Status(fiber.StatusOK).Send(schema.Schema)
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/server"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Accounts seeded in the in-memory repositories
+const (
+	adminEmail    = "admin@example.com"
+	adminPassword = "admin123"
+	userEmail     = "test@example.com"
+	userPassword  = "test123"
+)
+
+// testClient sends requests to a server set up on the in-memory database and cache
+type testClient struct {
+	t   *testing.T
+	app *fiber.App
+}
+
+// newTestClient sets up a server with the default configuration, stopped when the test ends
+func newTestClient(t *testing.T) *testClient {
+	t.Helper()
+
+	s, err := server.NewTestServer(config.LoadConfig())
+	if err != nil {
+		t.Fatalf("failed to set up server: %v", err)
+	}
+	t.Cleanup(s.Close)
+
+	return &testClient{t: t, app: s.GetHTTPServer()}
+}
+
+// do sends a request with an optional JSON body and bearer token, and decodes the JSON response into out when given
+func (c *testClient) do(method, path, token string, body, out any) int {
+	c.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+
+	resp, err := c.app.Test(req, -1)
+	if err != nil {
+		c.t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < fiber.StatusBadRequest {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			c.t.Fatalf("failed to decode response of %s %s: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// tokenResponse is the body of the login and refresh responses
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	User         struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+// login signs in and returns the tokens issued
+func (c *testClient) login(email, password string) *tokenResponse {
+	c.t.Helper()
+
+	var tokens tokenResponse
+	body := map[string]string{"email": email, "password": password}
+	if status := c.do(fiber.MethodPost, "/api/v1/auth/login", "", body, &tokens); status != fiber.StatusOK {
+		c.t.Fatalf("login of %s: got status %d, want %d", email, status, fiber.StatusOK)
+	}
+	return &tokens
+}
+
+// refresh presents a refresh token, returning the tokens issued when accepted
+func (c *testClient) refresh(refreshToken string) (*tokenResponse, int) {
+	c.t.Helper()
+
+	var tokens tokenResponse
+	status := c.do(fiber.MethodPost, "/api/v1/auth/refresh", "", map[string]string{"refresh_token": refreshToken}, &tokens)
+	return &tokens, status
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// InactivityHandler handles HTTP requests for the inactivity policy
+type InactivityHandler struct {
+	inactivityUseCase usecase.InactivityUseCase
+}
+
+// NewInactivityHandler creates a new InactivityHandler
+func NewInactivityHandler(inactivityUseCase usecase.InactivityUseCase) *InactivityHandler {
+	return &InactivityHandler{
+		inactivityUseCase: inactivityUseCase,
+	}
+}
+
+// RegisterRoutes registers the inactivity routes on the admin group
+func (h *InactivityHandler) RegisterRoutes(adminGroup fiber.Router) {
+	adminGroup.Get("/inactivity/report", h.LatestReport)
+	adminGroup.Post("/inactivity/run", h.Run)
+}
+
+// Run applies the inactivity policy now, returning the report of the run
+func (h *InactivityHandler) Run(c *fiber.Ctx) error {
+	actorID := c.Locals("user_id").(uuid.UUID)
+	report, err := h.inactivityUseCase.Enforce(c.Context(), actorID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to apply inactivity policy")
+
+		if errors.Is(err, usecase.ErrInactivityPolicyRunning) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "The inactivity policy is already running",
+				"code":  "INACTIVITY_POLICY_RUNNING",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to apply inactivity policy",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// LatestReport returns the report of the latest run of the inactivity policy
+func (h *InactivityHandler) LatestReport(c *fiber.Ctx) error {
+	report, err := h.inactivityUseCase.LatestReport(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get inactivity report")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get inactivity report",
+		})
+	}
+	if report == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "The inactivity policy has not run yet",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
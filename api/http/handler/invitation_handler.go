@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// InvitationHandler handles HTTP requests for users created by administrators
+type InvitationHandler struct {
+	invitationUseCase usecase.InvitationUseCase
+}
+
+// NewInvitationHandler creates a new InvitationHandler
+func NewInvitationHandler(invitationUseCase usecase.InvitationUseCase) *InvitationHandler {
+	return &InvitationHandler{
+		invitationUseCase: invitationUseCase,
+	}
+}
+
+// RegisterRoutes registers the acceptance routes on the router and the invitation routes on the admin group.
+// The invite, re-invite and activate routes are aliases of the original ones.
+func (h *InvitationHandler) RegisterRoutes(router fiber.Router, adminGroup fiber.Router) {
+	router.Post("/invitations/accept", h.Accept)
+	router.Post("/users/activate", h.Accept)
+
+	adminGroup.Post("/users", h.Invite)
+	adminGroup.Post("/users/invite", h.Invite)
+	adminGroup.Post("/users/:id/invitation", h.Resend)
+	adminGroup.Post("/users/:id/reinvite", h.Resend)
+}
+
+// Invite creates a user without a password and emails them an invitation
+func (h *InvitationHandler) Invite(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Email     string `json:"email" validate:"required,email"`
+		Username  string `json:"username" validate:"required,min=3,max=50"`
+		FirstName string `json:"first_name" validate:"required"`
+		LastName  string `json:"last_name" validate:"required"`
+		Role      string `json:"role"`
+
+		// OrgID adds the user to an organization, within its member limit
+		OrgID *uuid.UUID `json:"org_id"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse invite user request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Validate request
+	if req.Email == "" || req.Username == "" || req.FirstName == "" || req.LastName == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Email, username, first name and last name are required",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to invite user",
+		})
+	}
+
+	user, err := h.invitationUseCase.Invite(c.Context(), actorID, req.Email, req.Username, req.FirstName, req.LastName, req.Role, req.OrgID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvitationNotSent):
+			// The user was created, the administrator can resend the invitation
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send invitation")
+			return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+				"user":            user,
+				"invitation_sent": false,
+			})
+		case errors.Is(err, usecase.ErrEmailAlreadyExists):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Email already exists",
+			})
+		case errors.Is(err, usecase.ErrUsernameAlreadyExists):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Username already exists",
+			})
+		case errors.Is(err, usecase.ErrInvalidRole):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid role",
+			})
+		case errors.Is(err, usecase.ErrInvalidUsername):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid username, usernames cannot contain @",
+			})
+		case errors.Is(err, usecase.ErrOrganizationNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Organization not found",
+			})
+		case errors.Is(err, usecase.ErrMemberLimitReached):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "The organization has reached its member limit",
+				"code":  "ORG_MEMBER_LIMIT_REACHED",
+			})
+		default:
+			log.Error().Err(err).Str("email", req.Email).Msg("Failed to invite user")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to invite user",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"user":            user,
+		"invitation_sent": true,
+	})
+}
+
+// Resend emails a new invitation to a user who has not accepted theirs yet
+func (h *InvitationHandler) Resend(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to resend invitation",
+		})
+	}
+
+	if err := h.invitationUseCase.Resend(c.Context(), actorID, id); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		case errors.Is(err, usecase.ErrNotInvited):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "User has already accepted their invitation",
+			})
+		default:
+			log.Error().Err(err).Str("id", idParam).Msg("Failed to resend invitation")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to resend invitation",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Invitation sent",
+	})
+}
+
+// Accept sets the password of an invited user with a token received by email
+func (h *InvitationHandler) Accept(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Token       string `json:"token" validate:"required"`
+		Password    string `json:"password" validate:"required,min=8"`
+		AcceptTerms bool   `json:"accept_terms"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Token == "" || req.Password == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invitation token and password are required",
+		})
+	}
+
+	user, err := h.invitationUseCase.Accept(c.Context(), req.Token, req.Password, req.AcceptTerms)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrTermsNotAccepted):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "The terms must be accepted",
+			})
+		case errors.Is(err, usecase.ErrInvalidInvitationToken):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid or expired invitation token",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to accept invitation")
+			return passwordError(c, err, "Failed to accept invitation")
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// KeyHandler handles HTTP requests for token signing keys
+type KeyHandler struct {
+	keyUseCase usecase.KeyUseCase
+}
+
+// NewKeyHandler creates a new KeyHandler
+func NewKeyHandler(keyUseCase usecase.KeyUseCase) *KeyHandler {
+	return &KeyHandler{
+		keyUseCase: keyUseCase,
+	}
+}
+
+// RegisterRoutes registers the public key set on the app and the rotation route on the admin group
+func (h *KeyHandler) RegisterRoutes(app fiber.Router, adminGroup fiber.Router) {
+	app.Get("/.well-known/jwks.json", h.KeySet)
+
+	adminGroup.Post("/keys/rotate", h.Rotate)
+}
+
+// KeySet publishes the public keys accepted for token verification
+func (h *KeyHandler) KeySet(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=60")
+	return c.Status(fiber.StatusOK).JSON(h.keyUseCase.KeySet())
+}
+
+// Rotate generates a new signing key, the administrator must confirm their password
+func (h *KeyHandler) Rotate(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Password string `json:"password" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Password == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to rotate signing key",
+		})
+	}
+
+	keyID, err := h.keyUseCase.Rotate(c.Context(), actorID, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrKeyRotationDisabled):
+			return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
+				"error": "Signing key rotation is not configured",
+			})
+		case errors.Is(err, usecase.ErrInvalidCredentials):
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid password",
+			})
+		case errors.Is(err, usecase.ErrAccountLocked):
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many failed login attempts, please try again later",
+				"code":  "ACCOUNT_LOCKED",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to rotate signing key")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to rotate signing key",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"kid": keyID,
+	})
+}
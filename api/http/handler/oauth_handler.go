@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// oauthStateCookie binds a sign in with a provider to the browser that started it, so a callback carrying the
+	// state of someone else's sign in is rejected
+	oauthStateCookie = "oauth_state"
+
+	// oauthPath scopes the state cookie to the sign in routes
+	oauthPath = "/api/v1/auth/oauth"
+)
+
+// OAuthHandler handles HTTP requests for signing in with an account at an external OAuth2 provider, and for
+// linking accounts at providers to signed in users
+type OAuthHandler struct {
+	oauthUseCase    usecase.OAuthUseCase
+	authHandler     *AuthHandler
+	stateExpiration time.Duration
+}
+
+// NewOAuthHandler creates a new OAuthHandler, successful sign ins are answered like password sign ins by the
+// auth handler
+func NewOAuthHandler(oauthUseCase usecase.OAuthUseCase, authHandler *AuthHandler, stateExpiration time.Duration) *OAuthHandler {
+	return &OAuthHandler{
+		oauthUseCase:    oauthUseCase,
+		authHandler:     authHandler,
+		stateExpiration: stateExpiration,
+	}
+}
+
+// RegisterRoutes registers the routes for the OAuth handler
+func (h *OAuthHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler) {
+	oauthGroup := router.Group("/auth/oauth")
+
+	oauthGroup.Get("/:provider/login", h.Login)
+	oauthGroup.Get("/:provider/callback", h.Callback)
+	oauthGroup.Post("/:provider/link", authMiddleware, h.Link)
+	oauthGroup.Delete("/:provider/link", authMiddleware, h.Unlink)
+}
+
+// Login redirects to the consent page of a provider to sign in there
+func (h *OAuthHandler) Login(c *fiber.Ctx) error {
+	authURL, state, err := h.oauthUseCase.Begin(c.Context(), c.Params("provider"))
+	if err != nil {
+		log.Error().Err(err).Str("provider", c.Params("provider")).Msg("Failed to start OAuth sign in")
+
+		if errors.Is(err, usecase.ErrOAuthProviderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Provider not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to start sign in",
+		})
+	}
+
+	c.Cookie(h.stateCookie(state, time.Now().Add(h.stateExpiration)))
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Link starts linking an account at a provider to the authenticated user, returning the URL of the consent page
+// of the provider. The client navigates there, and the callback links the account the user signs in to.
+func (h *OAuthHandler) Link(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to start linking the account",
+		})
+	}
+
+	authURL, state, err := h.oauthUseCase.BeginLink(c.Context(), c.Params("provider"), userID)
+	if err != nil {
+		log.Error().Err(err).Str("provider", c.Params("provider")).Msg("Failed to start OAuth link")
+
+		if errors.Is(err, usecase.ErrOAuthProviderNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Provider not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to start linking the account",
+		})
+	}
+
+	c.Cookie(h.stateCookie(state, time.Now().Add(h.stateExpiration)))
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"auth_url": authURL,
+	})
+}
+
+// Unlink unlinks the account of the authenticated user at a provider
+func (h *OAuthHandler) Unlink(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to unlink the account",
+		})
+	}
+
+	if err := h.oauthUseCase.Unlink(c.Context(), userID, c.Params("provider")); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("provider", c.Params("provider")).Msg("Failed to unlink OAuth account")
+
+		switch {
+		case errors.Is(err, usecase.ErrOAuthIdentityNotFound), errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "No account at this provider is linked",
+			})
+		case errors.Is(err, usecase.ErrLastCredential):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "This account is the only way to sign in to your account, set a password or add another sign in method first",
+				"code":  "LAST_CREDENTIAL",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to unlink the account",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Account unlinked successfully",
+	})
+}
+
+// Callback signs in the user the provider redirected back, returning tokens like a password sign in, or links
+// their account at the provider when they started a link
+func (h *OAuthHandler) Callback(c *fiber.Ctx) error {
+	cookieState := c.Cookies(oauthStateCookie)
+	c.Cookie(h.stateCookie("", time.Unix(0, 0)))
+
+	// The user declined on the consent page, or the provider refused the request
+	if c.Query("error") != "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Sign in with the provider was not completed",
+			"code":  "OAUTH_DENIED",
+		})
+	}
+
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "State and code are required",
+		})
+	}
+	if subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid or expired sign in, please try again",
+			"code":  "OAUTH_STATE_INVALID",
+		})
+	}
+
+	result, err := h.oauthUseCase.Finish(c.Context(), c.Params("provider"), state, code)
+	if err != nil {
+		log.Error().Err(err).Str("provider", c.Params("provider")).Msg("Failed to sign in with OAuth provider")
+		return h.callbackError(c, err)
+	}
+
+	if result.Linked != nil {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message":  "Account linked successfully",
+			"identity": result.Linked,
+		})
+	}
+	return h.authHandler.loginResponse(c, result.Login)
+}
+
+// callbackError maps the errors of a sign in with a provider to responses
+func (h *OAuthHandler) callbackError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrOAuthProviderNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Provider not found",
+		})
+	case errors.Is(err, usecase.ErrInvalidOAuthState):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid or expired sign in, please try again",
+			"code":  "OAUTH_STATE_INVALID",
+		})
+	case errors.Is(err, usecase.ErrInvalidOAuthCode):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "The provider rejected the sign in, please try again",
+			"code":  "OAUTH_CODE_INVALID",
+		})
+	case errors.Is(err, usecase.ErrOAuthEmailUnverified):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "The email of the account at the provider must be verified",
+			"code":  "OAUTH_EMAIL_UNVERIFIED",
+		})
+	case errors.Is(err, usecase.ErrOAuthAccountConflict):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "An account with this email exists, sign in with its password and verify its email to link it",
+			"code":  "OAUTH_ACCOUNT_CONFLICT",
+		})
+	case errors.Is(err, usecase.ErrOAuthIdentityInUse):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "This account at the provider is linked to another user",
+			"code":  "OAUTH_ACCOUNT_IN_USE",
+		})
+	case errors.Is(err, usecase.ErrOAuthProviderLinked):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Another account at this provider is linked already, unlink it first",
+			"code":  "OAUTH_PROVIDER_LINKED",
+		})
+	}
+
+	return h.authHandler.loginError(c, err)
+}
+
+// stateCookie builds the httpOnly cookie carrying the state of a sign in with a provider. It is sent on the
+// redirect back from the provider, a top-level navigation, so it is Lax whatever the session cookies are.
+func (h *OAuthHandler) stateCookie(value string, expires time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     oauthStateCookie,
+		Value:    value,
+		Path:     oauthPath,
+		Domain:   h.authHandler.session.CookieDomain,
+		Expires:  expires,
+		Secure:   h.authHandler.session.CookieSecure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	}
+}
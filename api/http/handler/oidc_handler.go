@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// OIDCHandler handles the HTTP requests of the OpenID Connect provider
+type OIDCHandler struct {
+	oidcUseCase usecase.OIDCUseCase
+	loginURL    string
+}
+
+// NewOIDCHandler creates a new OIDCHandler, authorization requests are forwarded to the login page to be approved
+func NewOIDCHandler(oidcUseCase usecase.OIDCUseCase, loginURL string) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUseCase: oidcUseCase,
+		loginURL:    loginURL,
+	}
+}
+
+// RegisterRoutes registers the discovery document and the provider endpoints on the app. Authorizations are
+// approved by signed in users, the user info is read with the access tokens issued by the token endpoint.
+func (h *OIDCHandler) RegisterRoutes(app fiber.Router, authMiddleware, oidcTokenMiddleware fiber.Handler) {
+	app.Get("/.well-known/openid-configuration", h.Discovery)
+
+	oidcGroup := app.Group("/oidc")
+
+	oidcGroup.Get("/authorize", h.StartAuthorization)
+	oidcGroup.Post("/authorize", authMiddleware, h.Authorize)
+	oidcGroup.Post("/token", h.Token)
+	oidcGroup.Get("/userinfo", oidcTokenMiddleware, h.UserInfo)
+	oidcGroup.Post("/userinfo", oidcTokenMiddleware, h.UserInfo)
+}
+
+// Discovery publishes the OpenID Connect discovery document
+func (h *OIDCHandler) Discovery(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	return c.Status(fiber.StatusOK).JSON(h.oidcUseCase.Discovery())
+}
+
+// StartAuthorization checks an authorization request and forwards it to the login page, which signs the user in
+// and posts the approved request back. Errors are redirected to the client unless the client or redirect URI is invalid.
+func (h *OIDCHandler) StartAuthorization(c *fiber.Ctx) error {
+	var req entity.AuthorizationRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid authorization request",
+			"code":  "invalid_request",
+		})
+	}
+
+	if err := h.oidcUseCase.ValidateAuthorizationRequest(&req); err != nil {
+		if errors.Is(err, usecase.ErrOIDCInvalidClient) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Unknown client or unregistered redirect URI",
+				"code":  "invalid_client",
+			})
+		}
+		return c.Redirect(usecase.AuthorizationRedirect(&req, url.Values{"error": {oauthErrorCode(err)}}), fiber.StatusFound)
+	}
+
+	if h.loginURL == "" {
+		return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
+			"error": "OIDC login page is not configured",
+		})
+	}
+
+	return c.Redirect(h.loginURL+"?"+string(c.Request().URI().QueryString()), fiber.StatusFound)
+}
+
+// Authorize issues an authorization code for the approved request to the signed in user,
+// and returns the redirect URI of the client carrying it
+func (h *OIDCHandler) Authorize(c *fiber.Ctx) error {
+	var req entity.AuthorizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to authorize client",
+		})
+	}
+
+	redirectTo, err := h.oidcUseCase.Authorize(c.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		case errors.Is(err, usecase.ErrAccountInactive):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Account is not active",
+				"code":  "ACCOUNT_INACTIVE",
+			})
+		case oauthErrorCode(err) != "server_error":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid authorization request",
+				"code":  oauthErrorCode(err),
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to authorize client")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to authorize client",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"redirect_to": redirectTo,
+	})
+}
+
+// Token exchanges an authorization code or a refresh token for tokens, errors follow RFC 6749 so standard clients understand them
+func (h *OIDCHandler) Token(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(fiber.HeaderPragma, "no-cache")
+
+	var req entity.TokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             "invalid_request",
+			"error_description": "Invalid request body",
+		})
+	}
+
+	// Confidential clients may send their credentials with HTTP Basic authentication instead of the body,
+	// not both (RFC 6749 section 2.3.1)
+	basicAuth := c.Get(fiber.HeaderAuthorization) != ""
+	if basicAuth {
+		clientID, secret, ok := parseClientCredentials(c.Get(fiber.HeaderAuthorization))
+		if !ok || req.ClientSecret != "" || (req.ClientID != "" && req.ClientID != clientID) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":             "invalid_request",
+				"error_description": "Invalid client authentication",
+			})
+		}
+		req.ClientID, req.ClientSecret = clientID, secret
+	}
+
+	tokens, err := h.oidcUseCase.Exchange(c.Context(), &req)
+	if err != nil {
+		code := oauthErrorCode(err)
+		if code == "server_error" {
+			log.Error().Err(err).Msg("Failed to issue OIDC tokens")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": code,
+			})
+		}
+		if code == "invalid_client" {
+			if basicAuth {
+				c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="oidc"`)
+			}
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":             code,
+				"error_description": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             code,
+			"error_description": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(tokens)
+}
+
+// UserInfo returns the claims of the granted scopes about the user of the access token
+func (h *OIDCHandler) UserInfo(c *fiber.Ctx) error {
+	userID, userOK := c.Locals("user_id").(uuid.UUID)
+	sessionID, sessionOK := c.Locals("session_id").(uuid.UUID)
+	if !userOK || !sessionOK {
+		log.Error().Msg("User or session ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user info",
+		})
+	}
+
+	info, err := h.oidcUseCase.UserInfo(c.Context(), userID, sessionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOIDCInvalidToken), errors.Is(err, usecase.ErrUserNotFound):
+			c.Set(fiber.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "invalid_token",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to get user info")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get user info",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(info)
+}
+
+// oauthErrorCode returns the OAuth 2.0 error code of an OIDC use case error
+func oauthErrorCode(err error) string {
+	switch {
+	case errors.Is(err, usecase.ErrOIDCInvalidClient):
+		return "invalid_client"
+	case errors.Is(err, usecase.ErrOIDCInvalidRequest):
+		return "invalid_request"
+	case errors.Is(err, usecase.ErrOIDCUnsupportedResponseType):
+		return "unsupported_response_type"
+	case errors.Is(err, usecase.ErrOIDCInvalidScope):
+		return "invalid_scope"
+	case errors.Is(err, usecase.ErrOIDCUnsupportedGrantType):
+		return "unsupported_grant_type"
+	case errors.Is(err, usecase.ErrOIDCInvalidGrant):
+		return "invalid_grant"
+	default:
+		return "server_error"
+	}
+}
+
+// parseClientCredentials returns the client ID and secret of an HTTP Basic authorization header, both are
+// form-encoded before being joined (RFC 6749 section 2.3.1)
+func parseClientCredentials(header string) (string, string, bool) {
+	encoded, ok := strings.CutPrefix(header, "Basic ")
+	if !ok {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", false
+	}
+	rawID, rawSecret, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", false
+	}
+
+	clientID, err := url.QueryUnescape(rawID)
+	if err != nil || clientID == "" {
+		return "", "", false
+	}
+	secret, err := url.QueryUnescape(rawSecret)
+	if err != nil {
+		return "", "", false
+	}
+	return clientID, secret, true
+}
@@ -0,0 +1,491 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/api/http/middleware"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// OrganizationHandler handles HTTP requests for organizations
+type OrganizationHandler struct {
+	organizationUseCase usecase.OrganizationUseCase
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(organizationUseCase usecase.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes for the organization handler
+func (h *OrganizationHandler) RegisterRoutes(router fiber.Router, adminGroup fiber.Router, authMiddleware fiber.Handler) {
+	orgGroup := adminGroup.Group("/organizations")
+
+	orgGroup.Get("/", h.List)
+	orgGroup.Post("/", h.Create)
+	orgGroup.Get("/:id", h.Get)
+	orgGroup.Put("/:id/members/:user_id", h.AddMember)
+	orgGroup.Delete("/:id/members/:user_id", h.RemoveMember)
+	orgGroup.Put("/:id/self-registration", h.SetSelfRegistration)
+	orgGroup.Put("/:id/sso", h.SetSSOPolicy)
+	orgGroup.Put("/:id/plan", h.SetPlan)
+
+	// Profile field rules are public so registration and profile forms can follow them, and are managed by
+	// platform admins and the org admins of the organization
+	router.Get("/organizations/:id/profile-fields", h.GetProfileFields)
+	router.Put("/organizations/:id/profile-fields", authMiddleware,
+		middleware.RoleMiddleware(entity.UserRoleAdmin, entity.UserRoleOrgAdmin), h.SetProfileFields)
+
+	// Branding is public so the hosted pages linked from emails can follow it
+	router.Get("/organizations/:id/branding", h.GetBranding)
+	router.Put("/organizations/:id/branding", authMiddleware,
+		middleware.RoleMiddleware(entity.UserRoleAdmin, entity.UserRoleOrgAdmin), h.SetBranding)
+
+	// Platform admins and the org admins of the organization follow its members against its plan
+	router.Get("/organizations/:id/quota", authMiddleware,
+		middleware.RoleMiddleware(entity.UserRoleAdmin, entity.UserRoleOrgAdmin), h.GetMemberQuota)
+}
+
+// List lists the organizations
+func (h *OrganizationHandler) List(c *fiber.Ctx) error {
+	orgs, err := h.organizationUseCase.ListOrganizations(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list organizations")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list organizations",
+		})
+	}
+
+	if orgs == nil {
+		orgs = []*entity.Organization{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"organizations": orgs,
+	})
+}
+
+// Create creates an organization
+func (h *OrganizationHandler) Create(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create organization request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	org, err := h.organizationUseCase.CreateOrganization(c.Context(), req.Name)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create organization")
+		return organizationError(c, err, "Failed to create organization")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(org)
+}
+
+// Get returns an organization
+func (h *OrganizationHandler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	org, err := h.organizationUseCase.GetOrganization(c.Context(), id)
+	if err != nil {
+		return organizationError(c, err, "Failed to get organization")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(org)
+}
+
+// AddMember moves a user into an organization
+func (h *OrganizationHandler) AddMember(c *fiber.Ctx) error {
+	return h.changeMember(c, h.organizationUseCase.AddMember)
+}
+
+// RemoveMember removes a user from an organization
+func (h *OrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	return h.changeMember(c, h.organizationUseCase.RemoveMember)
+}
+
+// changeMember applies a membership change on behalf of the acting user
+func (h *OrganizationHandler) changeMember(c *fiber.Ctx, change func(ctx context.Context, actorID, orgID, userID uuid.UUID) error) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+	userID, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update organization members",
+		})
+	}
+
+	if err := change(c.Context(), actorID, orgID, userID); err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Str("user_id", userID.String()).Msg("Failed to update organization members")
+		return organizationError(c, err, "Failed to update organization members")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Organization members updated successfully",
+	})
+}
+
+// GetProfileFields returns the profile field rules of an organization
+func (h *OrganizationHandler) GetProfileFields(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	org, err := h.organizationUseCase.GetOrganization(c.Context(), id)
+	if err != nil {
+		return organizationError(c, err, "Failed to get profile field rules")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(profileFieldsResponse(org))
+}
+
+// SetProfileFields replaces the profile field rules of an organization
+func (h *OrganizationHandler) SetProfileFields(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	// Org admins only manage the rules of their own organization
+	if orgID := middleware.ScopedOrgID(c); orgID != nil && *orgID != id {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Organization is not yours",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		ProfileFields entity.ProfileFieldRules `json:"profile_fields"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse profile fields request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update profile field rules",
+		})
+	}
+
+	org, err := h.organizationUseCase.SetProfileFields(c.Context(), actorID, id, req.ProfileFields)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", id.String()).Msg("Failed to update profile field rules")
+		return organizationError(c, err, "Failed to update profile field rules")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(profileFieldsResponse(org))
+}
+
+// SetSelfRegistration opens or closes an organization to self-registration
+func (h *OrganizationHandler) SetSelfRegistration(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse self-registration request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update self-registration",
+		})
+	}
+
+	org, err := h.organizationUseCase.SetSelfRegistration(c.Context(), actorID, id, req.Enabled)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", id.String()).Msg("Failed to update self-registration")
+		return organizationError(c, err, "Failed to update self-registration")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(org)
+}
+
+// SetSSOPolicy replaces the single sign-on policy of an organization
+func (h *OrganizationHandler) SetSSOPolicy(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	// Parse request body
+	var req entity.SSOPolicy
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse SSO policy request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update SSO policy",
+		})
+	}
+
+	org, err := h.organizationUseCase.SetSSOPolicy(c.Context(), actorID, id, req)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", id.String()).Msg("Failed to update SSO policy")
+		return organizationError(c, err, "Failed to update SSO policy")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(org)
+}
+
+// GetMemberQuota returns the number of members of an organization against the limit of its plan
+func (h *OrganizationHandler) GetMemberQuota(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	// Org admins only follow the members of their own organization
+	if orgID := middleware.ScopedOrgID(c); orgID != nil && *orgID != id {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Organization is not yours",
+		})
+	}
+
+	quota, err := h.organizationUseCase.GetMemberQuota(c.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", id.String()).Msg("Failed to get member quota")
+		return organizationError(c, err, "Failed to get member quota")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"quota":   quota,
+		"reached": quota.Reached(),
+	})
+}
+
+// SetPlan changes the plan of an organization and overrides its member limit
+func (h *OrganizationHandler) SetPlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Plan string `json:"plan"`
+
+		// MemberLimit overrides the limit of the plan, 0 for unlimited, and is cleared when omitted
+		MemberLimit *int `json:"member_limit"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse plan request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update plan",
+		})
+	}
+
+	org, err := h.organizationUseCase.SetPlan(c.Context(), actorID, id, req.Plan, req.MemberLimit)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", id.String()).Msg("Failed to update plan")
+		return organizationError(c, err, "Failed to update plan")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(org)
+}
+
+// GetBranding returns the branding of an organization, completed with the default branding
+func (h *OrganizationHandler) GetBranding(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	branding, err := h.organizationUseCase.GetBranding(c.Context(), id)
+	if err != nil {
+		return organizationError(c, err, "Failed to get branding")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"org_id":   id,
+		"branding": branding,
+	})
+}
+
+// SetBranding replaces the branding of an organization
+func (h *OrganizationHandler) SetBranding(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization ID format",
+		})
+	}
+
+	// Org admins only manage the branding of their own organization
+	if orgID := middleware.ScopedOrgID(c); orgID != nil && *orgID != id {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Organization is not yours",
+		})
+	}
+
+	// Parse request body
+	var req entity.Branding
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse branding request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update branding",
+		})
+	}
+
+	org, err := h.organizationUseCase.SetBranding(c.Context(), actorID, id, req)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", id.String()).Msg("Failed to update branding")
+		return organizationError(c, err, "Failed to update branding")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(org)
+}
+
+// profileFieldsResponse returns the profile field rules of an organization, listing the mode of every field
+func profileFieldsResponse(org *entity.Organization) fiber.Map {
+	fields := make(map[string]string, len(entity.ProfileFields))
+	for _, field := range entity.ProfileFields {
+		fields[field] = entity.ProfileFieldOptional
+		if mode, ok := org.ProfileFields[field]; ok {
+			fields[field] = mode
+		}
+	}
+
+	return fiber.Map{
+		"org_id":            org.ID,
+		"profile_fields":    fields,
+		"self_registration": org.SelfRegistration,
+	}
+}
+
+// organizationError maps organization use case errors to HTTP responses
+func organizationError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, usecase.ErrOrganizationNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Organization not found",
+		})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	case errors.Is(err, usecase.ErrNotOrganizationMember):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "User is not a member of the organization",
+		})
+	case errors.Is(err, usecase.ErrInvalidOrganizationName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid organization name",
+		})
+	case errors.Is(err, usecase.ErrInvalidProfileFields):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid profile field rules, fields map to required, optional or hidden",
+		})
+	case errors.Is(err, usecase.ErrInvalidBranding):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid branding, the logo URL must use HTTPS and colors must be hex colors such as #1f2937",
+		})
+	case errors.Is(err, usecase.ErrInvalidPlan):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid plan, the plan must be configured in ORG_PLANS and the member limit cannot be negative",
+		})
+	case errors.Is(err, usecase.ErrMemberLimitReached):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "The organization has reached its member limit",
+			"code":  "ORG_MEMBER_LIMIT_REACHED",
+		})
+	case errors.Is(err, usecase.ErrInvalidSSOPolicy):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SSO policy, a required policy must name a configured sign in provider",
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// errInvalidCursor is returned when a cursor query parameter was not issued by a list endpoint
+var errInvalidCursor = errors.New("invalid cursor")
+
+// pagination is the page of a list a request asks for, with the page and limit query parameters or the cursor of
+// the previous page
+type pagination struct {
+	Page  int
+	Limit int
+}
+
+// parsePagination parses the pagination query parameters of a list request. A cursor takes precedence over page
+// and carries the limit of the page it follows, unless limit is given too. Pages below 1 and limits out of range
+// fall back to the first page and the default limit.
+func parsePagination(c *fiber.Ctx, defaultLimit, maxLimit int) (pagination, error) {
+	p := pagination{
+		Page:  c.QueryInt("page", 1),
+		Limit: parseLimit(c, defaultLimit, maxLimit),
+	}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		page, limit, err := decodeCursor(cursor)
+		if err != nil || limit > maxLimit {
+			return pagination{}, errInvalidCursor
+		}
+		p.Page = page
+		if c.Query("limit") == "" {
+			p.Limit = limit
+		}
+	}
+	return p, nil
+}
+
+// parseLimit parses the limit query parameter of a list request, falling back to the default when out of range
+func parseLimit(c *fiber.Ctx, defaultLimit, maxLimit int) int {
+	limit := c.QueryInt("limit", defaultLimit)
+	if limit < 1 || limit > maxLimit {
+		return defaultLimit
+	}
+	return limit
+}
+
+// Offset returns the number of items before the page
+func (p pagination) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// response adds the pagination metadata to the body of a list response: the total number of items, the page, the
+// limit, the number of pages and the cursor of the next page, null on the last one
+func (p pagination) response(body fiber.Map, total int64) fiber.Map {
+	totalPages := (total + int64(p.Limit) - 1) / int64(p.Limit)
+
+	var nextCursor *string
+	if int64(p.Page) < totalPages {
+		cursor := encodeCursor(p.Page+1, p.Limit)
+		nextCursor = &cursor
+	}
+
+	body["total"] = total
+	body["page"] = p.Page
+	body["limit"] = p.Limit
+	body["total_pages"] = totalPages
+	body["next_cursor"] = nextCursor
+	return body
+}
+
+// uncountedResponse adds the pagination metadata to the body of a list response whose items are not counted. The
+// next cursor is set whenever the page is full, so the last page may come out empty.
+func (p pagination) uncountedResponse(body fiber.Map, count int) fiber.Map {
+	var nextCursor *string
+	if count >= p.Limit {
+		cursor := encodeCursor(p.Page+1, p.Limit)
+		nextCursor = &cursor
+	}
+
+	body["page"] = p.Page
+	body["limit"] = p.Limit
+	body["next_cursor"] = nextCursor
+	return body
+}
+
+// paginate returns the page of a list held in memory
+func paginate[T any](items []T, p pagination) []T {
+	start := min(p.Offset(), len(items))
+	end := min(start+p.Limit, len(items))
+	return items[start:end]
+}
+
+// encodeCursor encodes a page and its limit as an opaque cursor
+func encodeCursor(page, limit int) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", page, limit))
+}
+
+// decodeCursor decodes a cursor encoded by encodeCursor
+func decodeCursor(cursor string) (int, int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+	pageText, limitText, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return 0, 0, errInvalidCursor
+	}
+	page, err := strconv.Atoi(pageText)
+	if err != nil || page < 1 {
+		return 0, 0, errInvalidCursor
+	}
+	limit, err := strconv.Atoi(limitText)
+	if err != nil || limit < 1 {
+		return 0, 0, errInvalidCursor
+	}
+	return page, limit, nil
+}
+
+// invalidCursorError responds to a cursor query parameter which was not issued by the endpoint
+func invalidCursorError(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Invalid cursor",
+		"code":  "INVALID_CURSOR",
+	})
+}
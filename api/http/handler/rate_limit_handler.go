@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"github.com/chats/go-user-api/api/http/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// RateLimitHandler handles HTTP requests for rate limit information
+type RateLimitHandler struct {
+	rateLimiter *middleware.RateLimiter
+}
+
+// NewRateLimitHandler creates a new RateLimitHandler
+func NewRateLimitHandler(rateLimiter *middleware.RateLimiter) *RateLimitHandler {
+	return &RateLimitHandler{
+		rateLimiter: rateLimiter,
+	}
+}
+
+// RegisterRoutes registers the routes for the rate limit handler
+func (h *RateLimitHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/rate-limits", h.GetLimits)
+}
+
+// GetLimits returns the caller's current budget for every route group
+func (h *RateLimitHandler) GetLimits(c *fiber.Ctx) error {
+	limits, err := h.rateLimiter.Status(c)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get rate limits")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get rate limits",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"limits": limits,
+	})
+}
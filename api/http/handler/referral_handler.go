@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ReferralHandler handles HTTP requests for referral codes and stats
+type ReferralHandler struct {
+	referralUseCase usecase.ReferralUseCase
+}
+
+// NewReferralHandler creates a new ReferralHandler
+func NewReferralHandler(referralUseCase usecase.ReferralUseCase) *ReferralHandler {
+	return &ReferralHandler{
+		referralUseCase: referralUseCase,
+	}
+}
+
+// RegisterRoutes registers the referral stats route on the router, the leaderboard on the admin group
+func (h *ReferralHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler, adminGroup fiber.Router) {
+	router.Get("/users/me/referral", authMiddleware, h.GetStats)
+
+	adminGroup.Get("/referrals/leaderboard", h.Leaderboard)
+}
+
+// GetStats returns the referral code of the authenticated user and the number of users they referred
+func (h *ReferralHandler) GetStats(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get referral stats",
+		})
+	}
+
+	stats, err := h.referralUseCase.GetStats(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get referral stats")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get referral stats",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+// Leaderboard lists the users with the most referrals
+func (h *ReferralHandler) Leaderboard(c *fiber.Ctx) error {
+	limit := parseLimit(c, 10, 100)
+
+	rankings, err := h.referralUseCase.Leaderboard(c.Context(), limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get referral leaderboard")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get referral leaderboard",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"referrers": rankings,
+	})
+}
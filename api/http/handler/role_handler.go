@@ -0,0 +1,704 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// RoleHandler handles HTTP requests for role definitions, their assignment to users and the approval of
+// privileged role changes
+type RoleHandler struct {
+	roleUseCase         usecase.RoleUseCase
+	roleApprovalUseCase usecase.RoleApprovalUseCase
+}
+
+// NewRoleHandler creates a new RoleHandler
+func NewRoleHandler(roleUseCase usecase.RoleUseCase, roleApprovalUseCase usecase.RoleApprovalUseCase) *RoleHandler {
+	return &RoleHandler{
+		roleUseCase:         roleUseCase,
+		roleApprovalUseCase: roleApprovalUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes for the role handler on the admin group, and the route resolving the
+// permissions of the authenticated user on the router
+func (h *RoleHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler, adminGroup fiber.Router) {
+	router.Get("/users/me/permissions", authMiddleware, h.MyPermissions)
+
+	roleGroup := adminGroup.Group("/roles")
+
+	roleGroup.Get("/", h.List)
+	roleGroup.Post("/", h.Create)
+	roleGroup.Get("/:name", h.Get)
+	roleGroup.Put("/:name", h.Update)
+	roleGroup.Delete("/:name", h.Delete)
+	roleGroup.Get("/:name/effective-permissions", h.EffectivePermissions)
+	roleGroup.Get("/:name/users", h.ListRoleUsers)
+
+	permissionGroup := adminGroup.Group("/permissions")
+
+	permissionGroup.Get("/", h.ListPermissions)
+	permissionGroup.Post("/", h.CreatePermission)
+	permissionGroup.Get("/:name", h.GetPermission)
+	permissionGroup.Put("/:name", h.UpdatePermission)
+	permissionGroup.Delete("/:name", h.DeletePermission)
+
+	adminGroup.Get("/users/:id/roles", h.ListAssignedRoles)
+	adminGroup.Post("/users/:id/roles", h.AssignRole)
+	adminGroup.Delete("/users/:id/roles/:role", h.UnassignRole)
+	adminGroup.Get("/users/:id/permissions", h.UserPermissions)
+
+	roleChangeGroup := adminGroup.Group("/role-changes")
+
+	roleChangeGroup.Get("/", h.ListRoleChanges)
+	roleChangeGroup.Get("/:id", h.GetRoleChange)
+	roleChangeGroup.Post("/:id/approve", h.ApproveRoleChange)
+	roleChangeGroup.Post("/:id/reject", h.RejectRoleChange)
+
+	groupGroup := adminGroup.Group("/permission-groups")
+
+	groupGroup.Get("/", h.ListGroups)
+	groupGroup.Post("/", h.CreateGroup)
+	groupGroup.Get("/:name", h.GetGroup)
+	groupGroup.Put("/:name", h.UpdateGroup)
+	groupGroup.Delete("/:name", h.DeleteGroup)
+}
+
+// roleRequest is the body of the role create and update requests
+type roleRequest struct {
+	Name string `json:"name"`
+	entity.RoleSpec
+}
+
+// permissionRequest is the body of the permission create and update requests
+type permissionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// permissionGroupRequest is the body of the permission group create and update requests
+type permissionGroupRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// List lists the built-in and custom roles along with the names of the permissions they may grant
+func (h *RoleHandler) List(c *fiber.Ctx) error {
+	roles, err := h.roleUseCase.ListRoles(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list roles")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list roles",
+		})
+	}
+	permissions, err := h.roleUseCase.ListPermissions(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list permissions")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list roles",
+		})
+	}
+
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, permission.Name)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"roles":       roles,
+		"permissions": names,
+	})
+}
+
+// Create defines a new custom role
+func (h *RoleHandler) Create(c *fiber.Ctx) error {
+	// Parse request body
+	var req roleRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create role request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	role, err := h.roleUseCase.CreateRole(c.Context(), req.Name, req.RoleSpec)
+	if err != nil {
+		log.Error().Err(err).Str("role", req.Name).Msg("Failed to create role")
+		return roleError(c, err, "Failed to create role")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(role)
+}
+
+// Get returns a role
+func (h *RoleHandler) Get(c *fiber.Ctx) error {
+	role, err := h.roleUseCase.GetRole(c.Context(), c.Params("name"))
+	if err != nil {
+		return roleError(c, err, "Failed to get role")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(role)
+}
+
+// Update replaces the description and permissions of a custom role
+func (h *RoleHandler) Update(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	// Parse request body
+	var req roleRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update role request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	role, err := h.roleUseCase.UpdateRole(c.Context(), name, req.RoleSpec)
+	if err != nil {
+		log.Error().Err(err).Str("role", name).Msg("Failed to update role")
+		return roleError(c, err, "Failed to update role")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(role)
+}
+
+// Delete deletes a custom role
+func (h *RoleHandler) Delete(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	if err := h.roleUseCase.DeleteRole(c.Context(), name); err != nil {
+		log.Error().Err(err).Str("role", name).Msg("Failed to delete role")
+		return roleError(c, err, "Failed to delete role")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Role deleted successfully",
+	})
+}
+
+// EffectivePermissions returns the resolved permissions of a role and where each of them comes from
+func (h *RoleHandler) EffectivePermissions(c *fiber.Ctx) error {
+	effective, err := h.roleUseCase.EffectivePermissions(c.Context(), c.Params("name"))
+	if err != nil {
+		return roleError(c, err, "Failed to resolve permissions")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(effective)
+}
+
+// ListGroups lists the permission groups
+func (h *RoleHandler) ListGroups(c *fiber.Ctx) error {
+	groups, err := h.roleUseCase.ListPermissionGroups(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list permission groups")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list permission groups",
+		})
+	}
+
+	if groups == nil {
+		groups = []*entity.PermissionGroup{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"groups": groups,
+	})
+}
+
+// CreateGroup defines a new permission group
+func (h *RoleHandler) CreateGroup(c *fiber.Ctx) error {
+	// Parse request body
+	var req permissionGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create permission group request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	group, err := h.roleUseCase.CreatePermissionGroup(c.Context(), req.Name, req.Description, req.Permissions)
+	if err != nil {
+		log.Error().Err(err).Str("permission_group", req.Name).Msg("Failed to create permission group")
+		return roleError(c, err, "Failed to create permission group")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(group)
+}
+
+// GetGroup returns a permission group
+func (h *RoleHandler) GetGroup(c *fiber.Ctx) error {
+	group, err := h.roleUseCase.GetPermissionGroup(c.Context(), c.Params("name"))
+	if err != nil {
+		return roleError(c, err, "Failed to get permission group")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(group)
+}
+
+// UpdateGroup replaces the description and permissions of a permission group
+func (h *RoleHandler) UpdateGroup(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	// Parse request body
+	var req permissionGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update permission group request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	group, err := h.roleUseCase.UpdatePermissionGroup(c.Context(), name, req.Description, req.Permissions)
+	if err != nil {
+		log.Error().Err(err).Str("permission_group", name).Msg("Failed to update permission group")
+		return roleError(c, err, "Failed to update permission group")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(group)
+}
+
+// DeleteGroup deletes a permission group
+func (h *RoleHandler) DeleteGroup(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	if err := h.roleUseCase.DeletePermissionGroup(c.Context(), name); err != nil {
+		log.Error().Err(err).Str("permission_group", name).Msg("Failed to delete permission group")
+		return roleError(c, err, "Failed to delete permission group")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Permission group deleted successfully",
+	})
+}
+
+// ListRoleUsers lists the users a role is assigned to on top of their primary role
+func (h *RoleHandler) ListRoleUsers(c *fiber.Ctx) error {
+	assignments, err := h.roleUseCase.ListRoleUsers(c.Context(), c.Params("name"))
+	if err != nil {
+		return roleError(c, err, "Failed to list role users")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"assignments": assignments,
+	})
+}
+
+// ListPermissions lists the built-in and custom permissions
+func (h *RoleHandler) ListPermissions(c *fiber.Ctx) error {
+	permissions, err := h.roleUseCase.ListPermissions(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list permissions")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list permissions",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"permissions": permissions,
+	})
+}
+
+// CreatePermission defines a new custom permission
+func (h *RoleHandler) CreatePermission(c *fiber.Ctx) error {
+	// Parse request body
+	var req permissionRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create permission request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	permission, err := h.roleUseCase.CreatePermission(c.Context(), req.Name, req.Description)
+	if err != nil {
+		log.Error().Err(err).Str("permission", req.Name).Msg("Failed to create permission")
+		return roleError(c, err, "Failed to create permission")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(permission)
+}
+
+// GetPermission returns a permission
+func (h *RoleHandler) GetPermission(c *fiber.Ctx) error {
+	name, err := url.PathUnescape(c.Params("name"))
+	if err != nil {
+		return roleError(c, usecase.ErrPermissionNotFound, "Failed to get permission")
+	}
+
+	permission, err := h.roleUseCase.GetPermission(c.Context(), name)
+	if err != nil {
+		return roleError(c, err, "Failed to get permission")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(permission)
+}
+
+// UpdatePermission replaces the description of a custom permission
+func (h *RoleHandler) UpdatePermission(c *fiber.Ctx) error {
+	name, err := url.PathUnescape(c.Params("name"))
+	if err != nil {
+		return roleError(c, usecase.ErrPermissionNotFound, "Failed to update permission")
+	}
+
+	// Parse request body
+	var req permissionRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update permission request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	permission, err := h.roleUseCase.UpdatePermission(c.Context(), name, req.Description)
+	if err != nil {
+		log.Error().Err(err).Str("permission", name).Msg("Failed to update permission")
+		return roleError(c, err, "Failed to update permission")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(permission)
+}
+
+// DeletePermission deletes a custom permission
+func (h *RoleHandler) DeletePermission(c *fiber.Ctx) error {
+	name, err := url.PathUnescape(c.Params("name"))
+	if err != nil {
+		return roleError(c, usecase.ErrPermissionNotFound, "Failed to delete permission")
+	}
+
+	if err := h.roleUseCase.DeletePermission(c.Context(), name); err != nil {
+		log.Error().Err(err).Str("permission", name).Msg("Failed to delete permission")
+		return roleError(c, err, "Failed to delete permission")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Permission deleted successfully",
+	})
+}
+
+// ListAssignedRoles lists the roles assigned to a user on top of their primary role
+func (h *RoleHandler) ListAssignedRoles(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	assignments, err := h.roleUseCase.ListAssignedRoles(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list assigned roles")
+		return roleError(c, err, "Failed to list assigned roles")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"assignments": assignments,
+	})
+}
+
+// AssignRole assigns a role to a user on top of their primary role, for good or for a duration such as "8h".
+// Privileged roles are assigned once a second administrator approves the assignment, the duration starts then.
+func (h *RoleHandler) AssignRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Role     string `json:"role" validate:"required"`
+		Duration string `json:"duration"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse assign role request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	var duration time.Duration
+	if req.Duration != "" {
+		duration, err = time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			return roleError(c, usecase.ErrInvalidRoleExpiration, "Failed to assign role")
+		}
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if h.roleApprovalUseCase.RequiresApproval(req.Role) {
+		request, err := h.roleApprovalUseCase.Request(c.Context(), actorID, userID, entity.RoleChangeKindAssignment, req.Role, duration, entity.ActionReason{})
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Str("role", req.Role).Msg("Failed to request role assignment")
+			return roleError(c, err, "Failed to assign role")
+		}
+		return c.Status(fiber.StatusAccepted).JSON(request)
+	}
+
+	var expiresAt *time.Time
+	if duration > 0 {
+		expiration := time.Now().Add(duration)
+		expiresAt = &expiration
+	}
+	assignment, err := h.roleUseCase.AssignRole(c.Context(), actorID, userID, req.Role, expiresAt)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("role", req.Role).Msg("Failed to assign role")
+		return roleError(c, err, "Failed to assign role")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(assignment)
+}
+
+// UnassignRole removes a role assigned to a user
+func (h *RoleHandler) UnassignRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+	role := c.Params("role")
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if err := h.roleUseCase.UnassignRole(c.Context(), actorID, userID, role); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("role", role).Msg("Failed to unassign role")
+		return roleError(c, err, "Failed to unassign role")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Role unassigned successfully",
+	})
+}
+
+// UserPermissions returns the resolved permissions of a user
+func (h *RoleHandler) UserPermissions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	return h.userPermissions(c, userID)
+}
+
+// MyPermissions returns the resolved permissions of the authenticated user, so clients can adapt to them
+func (h *RoleHandler) MyPermissions(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to resolve permissions",
+		})
+	}
+
+	return h.userPermissions(c, userID)
+}
+
+// userPermissions responds with the resolved permissions of a user
+func (h *RoleHandler) userPermissions(c *fiber.Ctx, userID uuid.UUID) error {
+	permissions, err := h.roleUseCase.UserPermissions(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to resolve user permissions")
+		return roleError(c, err, "Failed to resolve permissions")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(permissions)
+}
+
+// ListRoleChanges lists the role change requests, optionally filtered by status
+func (h *RoleHandler) ListRoleChanges(c *fiber.Ctx) error {
+	requests, err := h.roleApprovalUseCase.List(c.Context(), c.Query("status"))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list role change requests")
+		return roleError(c, err, "Failed to list role change requests")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"requests": requests,
+	})
+}
+
+// GetRoleChange returns a role change request
+func (h *RoleHandler) GetRoleChange(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid role change request ID",
+		})
+	}
+
+	request, err := h.roleApprovalUseCase.Get(c.Context(), id)
+	if err != nil {
+		return roleError(c, err, "Failed to get role change request")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(request)
+}
+
+// ApproveRoleChange approves a role change request, applying the role change
+func (h *RoleHandler) ApproveRoleChange(c *fiber.Ctx) error {
+	return h.decideRoleChange(c, h.roleApprovalUseCase.Approve, "Failed to approve role change request")
+}
+
+// RejectRoleChange rejects a role change request
+func (h *RoleHandler) RejectRoleChange(c *fiber.Ctx) error {
+	return h.decideRoleChange(c, h.roleApprovalUseCase.Reject, "Failed to reject role change request")
+}
+
+// decideRoleChange decides on a role change request on behalf of the acting administrator
+func (h *RoleHandler) decideRoleChange(
+	c *fiber.Ctx,
+	decide func(ctx context.Context, actorID, id uuid.UUID, note string) (*entity.RoleChangeRequest, error),
+	message string,
+) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid role change request ID",
+		})
+	}
+
+	// The note is optional, so is the body
+	var req struct {
+		Note string `json:"note"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			log.Error().Err(err).Msg("Failed to parse role change decision request body")
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	request, err := decide(c.Context(), actorID, id, req.Note)
+	if err != nil {
+		log.Error().Err(err).Str("request_id", id.String()).Msg(message)
+		return roleError(c, err, message)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(request)
+}
+
+// roleError maps role, permission and permission group use case errors to HTTP responses
+func roleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, usecase.ErrRoleNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Role not found",
+		})
+	case errors.Is(err, usecase.ErrRoleAlreadyExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Role already exists",
+		})
+	case errors.Is(err, usecase.ErrRoleInUse):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Role is still assigned to users or teams or inherited by roles",
+		})
+	case errors.Is(err, usecase.ErrRoleCycle):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Role inheritance would create a cycle",
+		})
+	case errors.Is(err, usecase.ErrPermissionGroupNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Permission group not found",
+		})
+	case errors.Is(err, usecase.ErrPermissionGroupAlreadyExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Permission group already exists",
+		})
+	case errors.Is(err, usecase.ErrPermissionGroupInUse):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Permission group is still included by roles",
+		})
+	case errors.Is(err, usecase.ErrBuiltInRole):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Built-in roles cannot be changed",
+		})
+	case errors.Is(err, usecase.ErrInvalidRoleName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid name",
+		})
+	case errors.Is(err, usecase.ErrPermissionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Permission not found",
+		})
+	case errors.Is(err, usecase.ErrPermissionAlreadyExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Permission already exists",
+		})
+	case errors.Is(err, usecase.ErrPermissionInUse):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Permission is still granted by roles or permission groups",
+		})
+	case errors.Is(err, usecase.ErrBuiltInPermission):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Built-in permissions cannot be changed",
+		})
+	case errors.Is(err, usecase.ErrInvalidPermissionName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid permission name, names are a resource and an action, e.g. invoices:approve",
+		})
+	case errors.Is(err, usecase.ErrRoleAlreadyAssigned):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Role already assigned to the user",
+		})
+	case errors.Is(err, usecase.ErrInvalidRoleExpiration):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid duration, expected a positive duration such as 8h",
+		})
+	case errors.Is(err, usecase.ErrRoleNotAssigned):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Role not assigned to the user",
+		})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	case errors.Is(err, usecase.ErrInvalidRole):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid role",
+		})
+	case errors.Is(err, usecase.ErrInvalidReason):
+		return invalidReasonError(c)
+	case errors.Is(err, usecase.ErrRoleChangeNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Role change request not found",
+		})
+	case errors.Is(err, usecase.ErrRoleChangeDecided):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Role change request already approved, rejected or expired",
+		})
+	case errors.Is(err, usecase.ErrRoleChangeSelfApproval):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Role change requests must be decided by another administrator",
+			"code":  "SECOND_APPROVER_REQUIRED",
+		})
+	case errors.Is(err, usecase.ErrRoleChangeAlreadyRequested):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Role change already pending approval",
+		})
+	case errors.Is(err, usecase.ErrInvalidRoleChangeStatus):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid status filter",
+		})
+	case errors.Is(err, usecase.ErrInvalidPermission):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid permission",
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// scimContentType is the media type of the SCIM requests and responses
+const scimContentType = "application/scim+json"
+
+// SCIMHandler handles the SCIM provisioning requests of the identity provider, and the admin requests reporting how
+// its groups are synced
+type SCIMHandler struct {
+	scimUseCase usecase.SCIMUseCase
+	token       string
+}
+
+// NewSCIMHandler creates a new SCIMHandler accepting the bearer token the identity provider is configured with
+func NewSCIMHandler(scimUseCase usecase.SCIMUseCase, token string) *SCIMHandler {
+	return &SCIMHandler{
+		scimUseCase: scimUseCase,
+		token:       token,
+	}
+}
+
+// RegisterRoutes registers the SCIM endpoints under /scim/v2, and the group sync status routes on the admin group
+func (h *SCIMHandler) RegisterRoutes(app fiber.Router, readOnlyMiddleware fiber.Handler, adminGroup fiber.Router) {
+	scimGroup := app.Group("/scim/v2", readOnlyMiddleware, h.authenticate)
+
+	scimGroup.Get("/ServiceProviderConfig", h.ServiceProviderConfig)
+	scimGroup.Get("/Users", h.ListUsers)
+	scimGroup.Get("/Users/:id", h.GetUser)
+	scimGroup.Get("/Groups", h.ListGroups)
+	scimGroup.Post("/Groups", h.CreateGroup)
+	scimGroup.Get("/Groups/:id", h.GetGroup)
+	scimGroup.Put("/Groups/:id", h.ReplaceGroup)
+	scimGroup.Patch("/Groups/:id", h.PatchGroup)
+	scimGroup.Delete("/Groups/:id", h.DeleteGroup)
+
+	adminGroup.Get("/scim/groups", h.ListGroupStatuses)
+	adminGroup.Post("/scim/groups/:id/sync", h.ResyncGroup)
+}
+
+// authenticate accepts the requests carrying the configured bearer token, every request when none is configured
+// being refused
+func (h *SCIMHandler) authenticate(c *fiber.Ctx) error {
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !ok || h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		return scimErrorResponse(c, fiber.StatusUnauthorized, "", "Invalid or missing bearer token")
+	}
+
+	return c.Next()
+}
+
+// ServiceProviderConfig describes the SCIM features supported
+func (h *SCIMHandler) ServiceProviderConfig(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"schemas":        []string{entity.SCIMSchemaServiceProviderConfig},
+		"patch":          fiber.Map{"supported": true},
+		"bulk":           fiber.Map{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         fiber.Map{"supported": true, "maxResults": 100},
+		"changePassword": fiber.Map{"supported": false},
+		"sort":           fiber.Map{"supported": false},
+		"etag":           fiber.Map{"supported": false},
+		"authenticationSchemes": []fiber.Map{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the bearer token configured as SCIM_TOKEN",
+		}},
+	}, scimContentType)
+}
+
+// ListUsers lists the users, or finds one by userName, the email of the user
+func (h *SCIMHandler) ListUsers(c *fiber.Ctx) error {
+	startIndex, count := scimPage(c)
+	users, total, err := h.scimUseCase.ListUsers(c.Context(), c.Query("filter"), startIndex, count)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list SCIM users")
+		return scimError(c, err)
+	}
+
+	resources := make([]*entity.SCIMUserResource, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, scimUserResource(c, user))
+	}
+	return c.Status(fiber.StatusOK).JSON(entity.SCIMListResponse{
+		Schemas:      []string{entity.SCIMSchemaListResponse},
+		TotalResults: int(total),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, scimContentType)
+}
+
+// GetUser returns a user
+func (h *SCIMHandler) GetUser(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return scimError(c, usecase.ErrUserNotFound)
+	}
+
+	user, err := h.scimUseCase.GetUser(c.Context(), id)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(scimUserResource(c, user), scimContentType)
+}
+
+// ListGroups lists the groups, or finds them by displayName or externalId
+func (h *SCIMHandler) ListGroups(c *fiber.Ctx) error {
+	startIndex, count := scimPage(c)
+	groups, total, err := h.scimUseCase.ListGroups(c.Context(), c.Query("filter"), startIndex, count)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list SCIM groups")
+		return scimError(c, err)
+	}
+
+	resources := make([]*entity.SCIMGroupResource, 0, len(groups))
+	for _, group := range groups {
+		resources = append(resources, scimGroupResource(c, group))
+	}
+	return c.Status(fiber.StatusOK).JSON(entity.SCIMListResponse{
+		Schemas:      []string{entity.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, scimContentType)
+}
+
+// CreateGroup creates a group and gives its members the role and organization it is mapped to
+func (h *SCIMHandler) CreateGroup(c *fiber.Ctx) error {
+	var req entity.SCIMGroupResource
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create SCIM group request body")
+		return scimErrorResponse(c, fiber.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	group, err := h.scimUseCase.CreateGroup(c.Context(), &req)
+	if err != nil {
+		log.Error().Err(err).Str("display_name", req.DisplayName).Msg("Failed to create SCIM group")
+		return scimError(c, err)
+	}
+
+	resource := scimGroupResource(c, group)
+	c.Set(fiber.HeaderLocation, resource.Meta.Location)
+	return c.Status(fiber.StatusCreated).JSON(resource, scimContentType)
+}
+
+// GetGroup returns a group
+func (h *SCIMHandler) GetGroup(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return scimError(c, usecase.ErrSCIMGroupNotFound)
+	}
+
+	group, err := h.scimUseCase.GetGroup(c.Context(), id)
+	if err != nil {
+		return scimError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(scimGroupResource(c, group), scimContentType)
+}
+
+// ReplaceGroup replaces a group and syncs the members added and removed
+func (h *SCIMHandler) ReplaceGroup(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return scimError(c, usecase.ErrSCIMGroupNotFound)
+	}
+
+	var req entity.SCIMGroupResource
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse replace SCIM group request body")
+		return scimErrorResponse(c, fiber.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	group, err := h.scimUseCase.ReplaceGroup(c.Context(), id, &req)
+	if err != nil {
+		log.Error().Err(err).Str("scim_group_id", id.String()).Msg("Failed to replace SCIM group")
+		return scimError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(scimGroupResource(c, group), scimContentType)
+}
+
+// PatchGroup applies PATCH operations to a group and syncs the members added and removed
+func (h *SCIMHandler) PatchGroup(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return scimError(c, usecase.ErrSCIMGroupNotFound)
+	}
+
+	var req entity.SCIMPatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse patch SCIM group request body")
+		return scimErrorResponse(c, fiber.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	group, err := h.scimUseCase.PatchGroup(c.Context(), id, req.Operations)
+	if err != nil {
+		log.Error().Err(err).Str("scim_group_id", id.String()).Msg("Failed to patch SCIM group")
+		return scimError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(scimGroupResource(c, group), scimContentType)
+}
+
+// DeleteGroup deletes a group, its former members losing the role and organization it is mapped to
+func (h *SCIMHandler) DeleteGroup(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return scimError(c, usecase.ErrSCIMGroupNotFound)
+	}
+
+	if err := h.scimUseCase.DeleteGroup(c.Context(), id); err != nil {
+		log.Error().Err(err).Str("scim_group_id", id.String()).Msg("Failed to delete SCIM group")
+		return scimError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListGroupStatuses lists the groups with their mapping and the outcome of their latest sync
+func (h *SCIMHandler) ListGroupStatuses(c *fiber.Ctx) error {
+	groups, err := h.scimUseCase.ListGroupStatuses(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list SCIM group statuses")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list SCIM groups",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"groups": groups,
+	})
+}
+
+// ResyncGroup syncs every member of a group again and returns the outcome
+func (h *SCIMHandler) ResyncGroup(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid group ID",
+		})
+	}
+
+	group, err := h.scimUseCase.ResyncGroup(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrSCIMGroupNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "SCIM group not found",
+			})
+		}
+		log.Error().Err(err).Str("scim_group_id", id.String()).Msg("Failed to sync SCIM group")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to sync SCIM group",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(group)
+}
+
+// scimPage returns the startIndex and count query parameters, -1 for a missing count so the default applies
+func scimPage(c *fiber.Ctx) (int, int) {
+	startIndex := max(c.QueryInt("startIndex", 1), 1)
+	count, err := strconv.Atoi(c.Query("count"))
+	if err != nil {
+		count = -1
+	}
+	return startIndex, count
+}
+
+// scimUserResource converts a user to a SCIM user resource
+func scimUserResource(c *fiber.Ctx, user *entity.User) *entity.SCIMUserResource {
+	return &entity.SCIMUserResource{
+		Schemas:  []string{entity.SCIMSchemaUser},
+		ID:       user.ID.String(),
+		UserName: user.Email,
+		Name: entity.SCIMName{
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+		},
+		Emails: []entity.SCIMEmail{{Value: user.Email, Primary: true}},
+		Active: user.Status == entity.UserStatusActive,
+		Meta: &entity.SCIMMeta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     c.BaseURL() + "/scim/v2/Users/" + user.ID.String(),
+		},
+	}
+}
+
+// scimGroupResource converts a group to a SCIM group resource
+func scimGroupResource(c *fiber.Ctx, group *entity.SCIMGroup) *entity.SCIMGroupResource {
+	members := make([]entity.SCIMMember, 0, len(group.Members))
+	for _, id := range group.Members {
+		members = append(members, entity.SCIMMember{
+			Value: id.String(),
+			Ref:   c.BaseURL() + "/scim/v2/Users/" + id.String(),
+		})
+	}
+
+	return &entity.SCIMGroupResource{
+		Schemas:     []string{entity.SCIMSchemaGroup},
+		ID:          group.ID.String(),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     members,
+		Meta: &entity.SCIMMeta{
+			ResourceType: "Group",
+			Created:      group.CreatedAt,
+			LastModified: group.UpdatedAt,
+			Location:     c.BaseURL() + "/scim/v2/Groups/" + group.ID.String(),
+		},
+	}
+}
+
+// scimError maps a SCIM use case error to a SCIM error response
+func scimError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return scimErrorResponse(c, fiber.StatusNotFound, "", "User not found")
+	case errors.Is(err, usecase.ErrSCIMGroupNotFound):
+		return scimErrorResponse(c, fiber.StatusNotFound, "", "Group not found")
+	case errors.Is(err, usecase.ErrSCIMGroupExists):
+		return scimErrorResponse(c, fiber.StatusConflict, "uniqueness", "A group with this displayName already exists")
+	case errors.Is(err, usecase.ErrInvalidSCIMGroup):
+		return scimErrorResponse(c, fiber.StatusBadRequest, "invalidValue", "displayName is required")
+	case errors.Is(err, usecase.ErrSCIMUnknownMember):
+		return scimErrorResponse(c, fiber.StatusBadRequest, "invalidValue", "Members must be the IDs of existing users")
+	case errors.Is(err, usecase.ErrInvalidSCIMFilter):
+		return scimErrorResponse(c, fiber.StatusBadRequest, "invalidFilter", `Only filters such as displayName eq "value" are supported`)
+	case errors.Is(err, usecase.ErrInvalidSCIMPatch):
+		return scimErrorResponse(c, fiber.StatusBadRequest, "invalidPath", "Unsupported PATCH operation")
+	default:
+		return scimErrorResponse(c, fiber.StatusInternalServerError, "", "Internal server error")
+	}
+}
+
+// scimErrorResponse writes a SCIM error response
+func scimErrorResponse(c *fiber.Ctx, status int, scimType, detail string) error {
+	return c.Status(status).JSON(entity.SCIMError{
+		Schemas:  []string{entity.SCIMSchemaError},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	}, scimContentType)
+}
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ServiceAccountHandler handles HTTP requests for the service accounts of backend services and their tokens
+type ServiceAccountHandler struct {
+	serviceAccountUseCase usecase.ServiceAccountUseCase
+	quotaUseCase          usecase.QuotaUseCase
+}
+
+// NewServiceAccountHandler creates a new ServiceAccountHandler, quotaUseCase is nil when quotas are turned off
+func NewServiceAccountHandler(serviceAccountUseCase usecase.ServiceAccountUseCase, quotaUseCase usecase.QuotaUseCase) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		serviceAccountUseCase: serviceAccountUseCase,
+		quotaUseCase:          quotaUseCase,
+	}
+}
+
+// RegisterRoutes registers the token endpoint of the client credentials grant on the router, and the routes
+// managing service accounts on the admin group, along with their usage and quota when quotas are turned on
+func (h *ServiceAccountHandler) RegisterRoutes(router fiber.Router, adminGroup fiber.Router) {
+	router.Post("/auth/token", h.Token)
+
+	serviceAccountGroup := adminGroup.Group("/service-accounts")
+
+	serviceAccountGroup.Get("/", h.List)
+	serviceAccountGroup.Post("/", h.Create)
+	serviceAccountGroup.Delete("/:id", h.Delete)
+	serviceAccountGroup.Post("/:id/rotate-secret", h.RotateSecret)
+	serviceAccountGroup.Get("/:id/tokens", h.ListTokens)
+	serviceAccountGroup.Delete("/:id/tokens", h.RevokeTokens)
+
+	if h.quotaUseCase != nil {
+		serviceAccountGroup.Get("/:id/usage", h.Usage)
+		serviceAccountGroup.Put("/:id/quota", h.SetQuota)
+	}
+}
+
+// Token exchanges the client credentials of a service account for an access token, errors follow RFC 6749 so
+// standard clients understand them
+func (h *ServiceAccountHandler) Token(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(fiber.HeaderPragma, "no-cache")
+
+	var req entity.ClientTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             "invalid_request",
+			"error_description": "Invalid request body",
+		})
+	}
+
+	// The client credentials may be sent with HTTP Basic authentication instead of the body, not both
+	basicAuth := c.Get(fiber.HeaderAuthorization) != ""
+	if basicAuth {
+		clientID, secret, ok := parseClientCredentials(c.Get(fiber.HeaderAuthorization))
+		if !ok || req.ClientSecret != "" || (req.ClientID != "" && req.ClientID != clientID) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":             "invalid_request",
+				"error_description": "Invalid client authentication",
+			})
+		}
+		req.ClientID, req.ClientSecret = clientID, secret
+	}
+
+	token, err := h.serviceAccountUseCase.IssueToken(c.Context(), &req)
+	if err != nil {
+		code := oauthErrorCode(err)
+		switch code {
+		case "server_error":
+			log.Error().Err(err).Msg("Failed to issue service account token")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": code,
+			})
+		case "invalid_client":
+			if basicAuth {
+				c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="service-accounts"`)
+			}
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":             code,
+				"error_description": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             code,
+			"error_description": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(token)
+}
+
+// List lists the service accounts
+func (h *ServiceAccountHandler) List(c *fiber.Ctx) error {
+	accounts, err := h.serviceAccountUseCase.List(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list service accounts")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list service accounts",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"service_accounts": accounts,
+	})
+}
+
+// Create creates a service account, its client secret is only returned in this response
+func (h *ServiceAccountHandler) Create(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		Name   string   `json:"name" validate:"required"`
+		Scopes []string `json:"scopes" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create service account request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	account, err := h.serviceAccountUseCase.Create(c.Context(), actorID, req.Name, req.Scopes)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create service account")
+		return serviceAccountError(c, err, "Failed to create service account")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(account)
+}
+
+// Delete deletes a service account and revokes its tokens
+func (h *ServiceAccountHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account ID",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if err := h.serviceAccountUseCase.Delete(c.Context(), actorID, id); err != nil {
+		log.Error().Err(err).Str("service_account_id", id.String()).Msg("Failed to delete service account")
+		return serviceAccountError(c, err, "Failed to delete service account")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Service account deleted successfully",
+	})
+}
+
+// RotateSecret replaces the client secret of a service account, the new secret is only returned in this response
+func (h *ServiceAccountHandler) RotateSecret(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account ID",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	account, err := h.serviceAccountUseCase.RotateSecret(c.Context(), actorID, id)
+	if err != nil {
+		log.Error().Err(err).Str("service_account_id", id.String()).Msg("Failed to rotate service account secret")
+		return serviceAccountError(c, err, "Failed to rotate service account secret")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(account)
+}
+
+// ListTokens lists the sessions of the live tokens of a service account
+func (h *ServiceAccountHandler) ListTokens(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account ID",
+		})
+	}
+
+	sessions, err := h.serviceAccountUseCase.ListTokens(c.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Str("service_account_id", id.String()).Msg("Failed to list service account tokens")
+		return serviceAccountError(c, err, "Failed to list service account tokens")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"sessions": sessions,
+	})
+}
+
+// RevokeTokens revokes every token of a service account
+func (h *ServiceAccountHandler) RevokeTokens(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account ID",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if err := h.serviceAccountUseCase.RevokeTokens(c.Context(), actorID, id); err != nil {
+		log.Error().Err(err).Str("service_account_id", id.String()).Msg("Failed to revoke service account tokens")
+		return serviceAccountError(c, err, "Failed to revoke service account tokens")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Service account tokens revoked successfully",
+	})
+}
+
+// Usage reports the quota and usage of a service account
+func (h *ServiceAccountHandler) Usage(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account ID",
+		})
+	}
+
+	report, err := h.quotaUseCase.ServiceAccountUsage(c.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Str("service_account_id", id.String()).Msg("Failed to get service account usage")
+		return serviceAccountError(c, err, "Failed to get service account usage")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// SetQuota sets the quota of a service account, a null quota restoring the default one
+func (h *ServiceAccountHandler) SetQuota(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account ID",
+		})
+	}
+
+	var req entity.SetQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse set service account quota request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	report, err := h.quotaUseCase.SetServiceAccountQuota(c.Context(), actorID, id, req.Quota)
+	if err != nil {
+		log.Error().Err(err).Str("service_account_id", id.String()).Msg("Failed to set service account quota")
+		return serviceAccountError(c, err, "Failed to set service account quota")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// serviceAccountError maps the errors of the service account routes to responses
+func serviceAccountError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidServiceAccountName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account name, names are required and at most 64 characters",
+		})
+	case errors.Is(err, usecase.ErrInvalidServiceAccountScope):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid service account scopes, at least one of " + strings.Join(entity.AllAPIKeyScopes, ", ") + " is required",
+		})
+	case errors.Is(err, usecase.ErrInvalidQuota):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid quota, quotas are non-negative numbers of requests, 0 for unlimited",
+		})
+	case errors.Is(err, usecase.ErrServiceAccountNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Service account not found",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+	})
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// SessionHandler handles HTTP requests for login sessions
+type SessionHandler struct {
+	authUseCase usecase.AuthUseCase
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(authUseCase usecase.AuthUseCase) *SessionHandler {
+	return &SessionHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+// RegisterRoutes registers the security overview and activity report routes on the router, the session and
+// token revocation routes on the admin group
+func (h *SessionHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler, adminGroup fiber.Router) {
+	router.Get("/users/me/security", authMiddleware, h.GetSecurityOverview)
+	router.Post("/users/me/report-activity", authMiddleware, h.ReportActivity)
+
+	sessionGroup := adminGroup.Group("/sessions")
+
+	sessionGroup.Get("/:id", h.Get)
+	sessionGroup.Delete("/:id", h.Revoke)
+
+	// Emergency revocation, for incident response when tokens or signing keys leak
+	tokenGroup := adminGroup.Group("/tokens")
+
+	tokenGroup.Post("/revoke", h.RevokeTokensIssuedBefore)
+	tokenGroup.Post("/:id/deny", h.DenyToken)
+}
+
+// Get returns a session and the rotation history of its tokens
+func (h *SessionHandler) Get(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid session ID",
+		})
+	}
+
+	session, err := h.authUseCase.GetSession(c.Context(), sessionID)
+	if err != nil {
+		return sessionError(c, err, "Failed to get session")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// Revoke invalidates the access and refresh tokens of a session
+func (h *SessionHandler) Revoke(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid session ID",
+		})
+	}
+
+	if err := h.authUseCase.RevokeSession(c.Context(), sessionID); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to revoke session")
+		return sessionError(c, err, "Failed to revoke session")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Session revoked successfully",
+	})
+}
+
+// GetSecurityOverview returns the security settings and active sessions of the authenticated user
+func (h *SessionHandler) GetSecurityOverview(c *fiber.Ctx) error {
+	// Get user and session IDs from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get security overview",
+		})
+	}
+	sessionID, _ := c.Locals("session_id").(uuid.UUID)
+
+	overview, err := h.authUseCase.GetSecurityOverview(c.Context(), userID, sessionID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get security overview")
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		}
+		return sessionError(c, err, "Failed to get security overview")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(overview)
+}
+
+// ReportActivity revokes a session the authenticated user reports they did not start
+func (h *SessionHandler) ReportActivity(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		SessionID          string `json:"session_id" validate:"required"`
+		ForcePasswordReset bool   `json:"force_password_reset"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse report activity request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	sessionID, err := uuid.Parse(req.SessionID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid session ID",
+		})
+	}
+
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to report activity",
+		})
+	}
+
+	if err := h.authUseCase.ReportActivity(c.Context(), userID, sessionID, req.ForcePasswordReset); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to report activity")
+		return sessionError(c, err, "Failed to report activity")
+	}
+
+	message := "Session revoked"
+	if req.ForcePasswordReset {
+		message = "Session revoked, a password reset email has been sent"
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":                 message,
+		"password_reset_required": req.ForcePasswordReset,
+	})
+}
+
+// DenyToken immediately rejects an access or refresh token by ID
+func (h *SessionHandler) DenyToken(c *fiber.Ctx) error {
+	tokenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid token ID",
+		})
+	}
+
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to deny token",
+		})
+	}
+
+	if err := h.authUseCase.DenyToken(c.Context(), actorID, tokenID); err != nil {
+		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to deny token")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to deny token",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Token denylisted",
+	})
+}
+
+// RevokeTokensIssuedBefore rejects every token issued before a time, now when the body is empty
+func (h *SessionHandler) RevokeTokensIssuedBefore(c *fiber.Ctx) error {
+	var req struct {
+		IssuedBefore *time.Time `json:"issued_before"`
+	}
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			log.Error().Err(err).Msg("Failed to parse revoke tokens request body")
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	cutoff := time.Now()
+	if req.IssuedBefore != nil {
+		cutoff = *req.IssuedBefore
+	}
+
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to revoke tokens",
+		})
+	}
+
+	cutoff, err := h.authUseCase.RevokeTokensIssuedBefore(c.Context(), actorID, cutoff)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to revoke tokens")
+
+		if errors.Is(err, usecase.ErrInvalidCutoff) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "The cutoff cannot be in the future",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to revoke tokens",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":       "Tokens issued before the cutoff are revoked",
+		"issued_before": cutoff,
+	})
+}
+
+// sessionError maps session use case errors to HTTP responses
+func sessionError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, usecase.ErrSessionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Session not found",
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
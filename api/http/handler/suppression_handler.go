@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// SuppressionHandler handles the email events posted by the email provider and the administration of the
+// suppression list
+type SuppressionHandler struct {
+	suppressionUseCase usecase.SuppressionUseCase
+	config             config.MailerConfig
+}
+
+// NewSuppressionHandler creates a new SuppressionHandler
+func NewSuppressionHandler(suppressionUseCase usecase.SuppressionUseCase, config config.MailerConfig) *SuppressionHandler {
+	return &SuppressionHandler{
+		suppressionUseCase: suppressionUseCase,
+		config:             config,
+	}
+}
+
+// RegisterRoutes registers the routes for the suppression handler
+func (h *SuppressionHandler) RegisterRoutes(router fiber.Router, adminGroup fiber.Router) {
+	// Authenticated with the events secret shared with the email provider
+	router.Post("/email/events", h.HandleEvents)
+
+	adminGroup.Get("/email-suppressions", h.List)
+	adminGroup.Delete("/email-suppressions/:email", h.Remove)
+}
+
+// HandleEvents records the bounces and complaints posted by the email provider
+func (h *SuppressionHandler) HandleEvents(c *fiber.Ctx) error {
+	if h.config.EventsSecret == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Email event intake is not configured",
+		})
+	}
+
+	secret := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(h.config.EventsSecret)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid email events secret",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Events []entity.EmailEvent `json:"events" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse email events request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.suppressionUseCase.HandleEvents(c.Context(), req.Events); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidEmailEvent):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid email event, a bounce or complaint with a valid email is required",
+			})
+		case errors.Is(err, usecase.ErrTooManyEmailEvents):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "At most 100 email events can be posted at once",
+			})
+		default:
+			log.Error().Err(err).Msg("Failed to handle email events")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to handle email events",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Email events recorded",
+	})
+}
+
+// List lists the suppressed email addresses
+func (h *SuppressionHandler) List(c *fiber.Ctx) error {
+	suppressions, err := h.suppressionUseCase.ListSuppressions(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list email suppressions")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list email suppressions",
+		})
+	}
+
+	if suppressions == nil {
+		suppressions = []*entity.EmailSuppression{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"suppressions": suppressions,
+	})
+}
+
+// Remove lifts the suppression of an email address
+func (h *SuppressionHandler) Remove(c *fiber.Ctx) error {
+	email, err := url.PathUnescape(c.Params("email"))
+	if err != nil || email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid email",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to remove email suppression",
+		})
+	}
+
+	if err := h.suppressionUseCase.RemoveSuppression(c.Context(), actorID, email); err != nil {
+		if errors.Is(err, usecase.ErrSuppressionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Email suppression not found",
+			})
+		}
+		log.Error().Err(err).Msg("Failed to remove email suppression")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to remove email suppression",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Email suppression removed successfully",
+	})
+}
+
+// emailSuppressedError responds to an action that could not email an address of the suppression list
+func emailSuppressedError(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"error": "Email address is suppressed after a bounce or complaint",
+		"code":  "EMAIL_SUPPRESSED",
+	})
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// TeamHandler handles HTTP requests for teams and their members
+type TeamHandler struct {
+	teamUseCase usecase.TeamUseCase
+}
+
+// NewTeamHandler creates a new TeamHandler
+func NewTeamHandler(teamUseCase usecase.TeamUseCase) *TeamHandler {
+	return &TeamHandler{
+		teamUseCase: teamUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes managing teams on the admin group, and the route listing the teams of the
+// authenticated user on the router
+func (h *TeamHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler, adminGroup fiber.Router) {
+	router.Get("/users/me/teams", authMiddleware, h.MyTeams)
+
+	teamGroup := adminGroup.Group("/teams")
+
+	teamGroup.Get("/", h.List)
+	teamGroup.Post("/", h.Create)
+	teamGroup.Get("/:name", h.Get)
+	teamGroup.Put("/:name", h.Update)
+	teamGroup.Delete("/:name", h.Delete)
+	teamGroup.Get("/:name/members", h.ListMembers)
+	teamGroup.Post("/:name/members", h.AddMember)
+	teamGroup.Delete("/:name/members/:user_id", h.RemoveMember)
+
+	adminGroup.Get("/users/:id/teams", h.UserTeams)
+}
+
+// teamRequest is the body of the team create and update requests
+type teamRequest struct {
+	Name string `json:"name"`
+	entity.TeamSpec
+}
+
+// List lists the teams
+func (h *TeamHandler) List(c *fiber.Ctx) error {
+	teams, err := h.teamUseCase.ListTeams(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list teams")
+		return teamError(c, err, "Failed to list teams")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"teams": teams,
+	})
+}
+
+// Create defines a new team
+func (h *TeamHandler) Create(c *fiber.Ctx) error {
+	var req teamRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create team request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	team, err := h.teamUseCase.CreateTeam(c.Context(), req.Name, req.TeamSpec)
+	if err != nil {
+		log.Error().Err(err).Str("team", req.Name).Msg("Failed to create team")
+		return teamError(c, err, "Failed to create team")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(team)
+}
+
+// Get returns a team
+func (h *TeamHandler) Get(c *fiber.Ctx) error {
+	team, err := h.teamUseCase.GetTeam(c.Context(), c.Params("name"))
+	if err != nil {
+		return teamError(c, err, "Failed to get team")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(team)
+}
+
+// Update replaces the description and roles of a team
+func (h *TeamHandler) Update(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	var req teamRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update team request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	team, err := h.teamUseCase.UpdateTeam(c.Context(), name, req.TeamSpec)
+	if err != nil {
+		log.Error().Err(err).Str("team", name).Msg("Failed to update team")
+		return teamError(c, err, "Failed to update team")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(team)
+}
+
+// Delete deletes a team, its members lose the roles it granted
+func (h *TeamHandler) Delete(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	if err := h.teamUseCase.DeleteTeam(c.Context(), name); err != nil {
+		log.Error().Err(err).Str("team", name).Msg("Failed to delete team")
+		return teamError(c, err, "Failed to delete team")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Team deleted successfully",
+	})
+}
+
+// ListMembers lists the members of a team
+func (h *TeamHandler) ListMembers(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	members, err := h.teamUseCase.ListMembers(c.Context(), name)
+	if err != nil {
+		log.Error().Err(err).Str("team", name).Msg("Failed to list team members")
+		return teamError(c, err, "Failed to list team members")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"members": members,
+	})
+}
+
+// AddMember adds a user to a team
+func (h *TeamHandler) AddMember(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	var req struct {
+		UserID uuid.UUID `json:"user_id" validate:"required"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.UserID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	member, err := h.teamUseCase.AddMember(c.Context(), actorID, name, req.UserID)
+	if err != nil {
+		log.Error().Err(err).Str("team", name).Str("user_id", req.UserID.String()).Msg("Failed to add team member")
+		return teamError(c, err, "Failed to add team member")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(member)
+}
+
+// RemoveMember removes a user from a team
+func (h *TeamHandler) RemoveMember(c *fiber.Ctx) error {
+	name := c.Params("name")
+	userID, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	if err := h.teamUseCase.RemoveMember(c.Context(), actorID, name, userID); err != nil {
+		log.Error().Err(err).Str("team", name).Str("user_id", userID.String()).Msg("Failed to remove team member")
+		return teamError(c, err, "Failed to remove team member")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Team member removed successfully",
+	})
+}
+
+// UserTeams lists the teams of a user
+func (h *TeamHandler) UserTeams(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	return h.userTeams(c, userID)
+}
+
+// MyTeams lists the teams of the authenticated user
+func (h *TeamHandler) MyTeams(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list teams",
+		})
+	}
+
+	return h.userTeams(c, userID)
+}
+
+// userTeams responds with the teams of a user
+func (h *TeamHandler) userTeams(c *fiber.Ctx, userID uuid.UUID) error {
+	teams, err := h.teamUseCase.ListUserTeams(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list user teams")
+		return teamError(c, err, "Failed to list teams")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"teams": teams,
+	})
+}
+
+// teamError maps team use case errors to HTTP responses
+func teamError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, usecase.ErrTeamNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Team not found",
+		})
+	case errors.Is(err, usecase.ErrTeamAlreadyExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Team already exists",
+		})
+	case errors.Is(err, usecase.ErrInvalidTeamName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid name",
+		})
+	case errors.Is(err, usecase.ErrInvalidRole):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid role",
+		})
+	case errors.Is(err, usecase.ErrTeamRoleRequiresApproval):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Roles requiring approval cannot be granted by teams",
+			"code":  "ROLE_REQUIRES_APPROVAL",
+		})
+	case errors.Is(err, usecase.ErrTeamMemberAlreadyAdded):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "User already a member of the team",
+		})
+	case errors.Is(err, usecase.ErrTeamMemberNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not a member of the team",
+		})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -1,9 +1,17 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/chats/go-user-api/api/http/middleware"
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/service"
 	"github.com/chats/go-user-api/internal/domain/usecase"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -12,43 +20,97 @@ import (
 
 // UserHandler handles HTTP requests for user operations
 type UserHandler struct {
-	userUseCase usecase.UserUseCase
+	userUseCase         usecase.UserUseCase
+	roleApprovalUseCase usecase.RoleApprovalUseCase
+	nameService         service.NameService
+	passwordService     service.PasswordService
+	register            config.RegistrationConfig
 }
 
 // NewUserHandler creates a new UserHandler
-func NewUserHandler(userUseCase usecase.UserUseCase) *UserHandler {
+func NewUserHandler(userUseCase usecase.UserUseCase, roleApprovalUseCase usecase.RoleApprovalUseCase, nameService service.NameService, passwordService service.PasswordService, register config.RegistrationConfig) *UserHandler {
 	return &UserHandler{
-		userUseCase: userUseCase,
+		userUseCase:         userUseCase,
+		roleApprovalUseCase: roleApprovalUseCase,
+		nameService:         nameService,
+		passwordService:     passwordService,
+		register:            register,
 	}
 }
 
 // RegisterRoutes registers the routes for the user handler
-func (h *UserHandler) RegisterRoutes(router fiber.Router, authMiddleware fiber.Handler) {
+func (h *UserHandler) RegisterRoutes(router fiber.Router, adminGroup fiber.Router, authMiddleware fiber.Handler) {
 	userGroup := router.Group("/users")
 
 	// Routes that don't require authentication
 	userGroup.Post("/register", h.Register)
+	userGroup.Post("/password-strength", h.PasswordStrength)
 	//userGroup.Post("/login", h.Login) // login moved to auth group.
 
 	// Routes that require authentication
-	// In a real application, these would be protected by middleware
-	userGroup.Get("/:id", authMiddleware, h.GetByID)
-	userGroup.Put("/:id", authMiddleware, h.Update)
-	userGroup.Delete("/:id", authMiddleware, h.Delete)
-	userGroup.Get("/", authMiddleware, h.List)
-	userGroup.Put("/:id/password", authMiddleware, h.ChangePassword)
-	userGroup.Put("/:id/status", authMiddleware, h.UpdateStatus)
+	// Users manage their own account, admins manage anyone and org admins the members of their organization
+	orgScope := middleware.OrgScopeMiddleware(h.userUseCase)
+	adminOnly := middleware.RoleMiddleware(entity.UserRoleAdmin, entity.UserRoleOrgAdmin)
+	selfOrAdmin := middleware.SelfOrRoleMiddleware(entity.UserRoleAdmin, entity.UserRoleOrgAdmin)
+
+	userGroup.Get("/:id", authMiddleware, selfOrAdmin, orgScope, h.GetByID)
+	userGroup.Put("/:id", authMiddleware, selfOrAdmin, orgScope, h.Update)
+	userGroup.Delete("/:id", authMiddleware, selfOrAdmin, orgScope, h.Delete)
+	userGroup.Get("/", authMiddleware, adminOnly, h.List)
+	userGroup.Put("/:id/password", authMiddleware, selfOrAdmin, orgScope, h.ChangePassword)
+	userGroup.Put("/:id/status", authMiddleware, adminOnly, orgScope, h.UpdateStatus)
+	userGroup.Get("/:id/status-history", authMiddleware, adminOnly, orgScope, h.StatusHistory)
+	userGroup.Put("/:id/role", authMiddleware, adminOnly, orgScope, h.UpdateRole)
+	userGroup.Put("/:id/notification-channels", authMiddleware, selfOrAdmin, orgScope, h.UpdateNotificationChannels)
+	userGroup.Put("/:id/lifecycle-emails", authMiddleware, selfOrAdmin, orgScope, h.UpdateLifecycleEmails)
+	userGroup.Put("/:id/verification", authMiddleware, adminOnly, orgScope, h.UpdateVerification)
+	userGroup.Put("/:id/must-change-password", authMiddleware, adminOnly, orgScope, h.RequirePasswordChange)
+	userGroup.Post("/:id/tags", authMiddleware, adminOnly, orgScope, h.AddTags)
+	userGroup.Delete("/:id/tags/:tag", authMiddleware, adminOnly, orgScope, h.RemoveTag)
+
+	// Restoration of the users pending deletion, across organizations
+	adminGroup.Get("/users/deleted", h.ListPendingDeletions)
+	adminGroup.Post("/users/:id/cancel-deletion", h.CancelDeletion)
+
+	// Migration of the users of a legacy system, across organizations
+	adminGroup.Post("/users/import", h.Import)
+}
+
+// PasswordStrength estimates the strength of a password as it is typed, scored like the server scores new
+// passwords, so forms can show live feedback
+func (h *UserHandler) PasswordStrength(c *fiber.Ctx) error {
+	var req struct {
+		Password  string `json:"password"`
+		Email     string `json:"email"`
+		Username  string `json:"username"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse password strength request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(h.passwordService.Estimate(req.Password, req.Email, req.Username, req.FirstName, req.LastName))
 }
 
 // Register handles user registration
 func (h *UserHandler) Register(c *fiber.Ctx) error {
 	// Parse request body
 	var req struct {
-		Email     string `json:"email" validate:"required,email"`
-		Username  string `json:"username" validate:"required,min=3,max=50"`
-		Password  string `json:"password" validate:"required,min=8"`
-		FirstName string `json:"first_name" validate:"required"`
-		LastName  string `json:"last_name" validate:"required"`
+		Email    string `json:"email" validate:"required,email"`
+		Username string `json:"username" validate:"required,min=3,max=50"`
+		Password string `json:"password" validate:"required,min=8"`
+		profileRequest
+
+		// OrgID registers the user into an organization open to self-registration
+		OrgID *uuid.UUID `json:"org_id"`
+
+		// ReferralCode records the user as referred by the owner of the code
+		ReferralCode string `json:"referral_code"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
@@ -71,7 +133,15 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 	}
 
 	// Register user
-	user, err := h.userUseCase.Register(c.Context(), req.Email, req.Username, req.Password, req.FirstName, req.LastName)
+	user, err := h.userUseCase.Register(c.Context(), req.Email, req.Username, req.Password, req.profile(), req.OrgID, req.ReferralCode)
+
+	// Answer alike whether or not the email has an account, both are told by email
+	if h.register.ConcealExistingAccounts && (err == nil || errors.Is(err, usecase.ErrEmailAlreadyExists)) {
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"message": "Registration received, check your email to continue",
+		})
+	}
+
 	if err != nil {
 		log.Error().Err(err).Str("email", req.Email).Msg("Failed to register user")
 
@@ -84,23 +154,50 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"error": "Username already exists",
 			})
-		default:
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to register user",
+		case errors.Is(err, usecase.ErrDuplicateRegistration):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "A registration for this email is already being processed",
+				"code":  "DUPLICATE_REQUEST",
+			})
+		case errors.Is(err, usecase.ErrInvalidUsername):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid username, usernames cannot contain @",
+			})
+		case errors.Is(err, usecase.ErrInvalidReferralCode):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid referral code",
 			})
+		case errors.Is(err, usecase.ErrRegistrationClosed):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "The organization is closed to self-registration",
+				"code":  "REGISTRATION_CLOSED",
+			})
+		case errors.Is(err, usecase.ErrMemberLimitReached):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "The organization has reached its member limit",
+				"code":  "ORG_MEMBER_LIMIT_REACHED",
+			})
+		case errors.Is(err, service.ErrWeakPassword), errors.Is(err, service.ErrBreachedPassword), errors.Is(err, service.ErrPasswordCheckUnavailable):
+			return passwordError(c, err, "Failed to register user")
+		default:
+			return profileError(c, err, "Failed to register user")
 		}
 	}
 
 	// Return success response
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"id":         user.ID,
-		"email":      user.Email,
-		"username":   user.Username,
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-		"role":       user.Role,
-		"status":     user.Status,
-		"created_at": user.CreatedAt,
+		"id":           user.ID,
+		"email":        user.Email,
+		"username":     user.Username,
+		"first_name":   user.FirstName,
+		"last_name":    user.LastName,
+		"display_name": h.nameService.DisplayName(user),
+		"locale":       user.Locale,
+		"phone":        user.Phone,
+		"birth_date":   user.BirthDate,
+		"role":         user.Role,
+		"status":       user.Status,
+		"created_at":   user.CreatedAt,
 	})
 }
 
@@ -145,13 +242,17 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 	// In a real application, you would generate a JWT token here
 	// For now, we'll just return the user information
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"id":         user.ID,
-		"email":      user.Email,
-		"username":   user.Username,
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-		"role":       user.Role,
-		"status":     user.Status,
+		"id":           user.ID,
+		"email":        user.Email,
+		"username":     user.Username,
+		"first_name":   user.FirstName,
+		"last_name":    user.LastName,
+		"display_name": h.nameService.DisplayName(user),
+		"locale":       user.Locale,
+		"phone":        user.Phone,
+		"birth_date":   user.BirthDate,
+		"role":         user.Role,
+		"status":       user.Status,
 		// Don't include the password in the response
 	})
 }
@@ -175,9 +276,14 @@ func (h *UserHandler) GetByID(c *fiber.Ctx) error {
 		})
 	}
 
+	fields, ok := parseFields(c.Query("fields"), userFields)
+	if !ok {
+		return invalidFieldsError(c, userFields)
+	}
+
 	// Get user
 	user, err := h.userUseCase.GetByID(c.Context(), id)
-	if err != nil {
+	if err != nil && !errors.Is(err, usecase.ErrUserNotFound) {
 		log.Error().Err(err).Str("id", idParam).Msg("Failed to get user")
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to get user",
@@ -191,17 +297,35 @@ func (h *UserHandler) GetByID(c *fiber.Ctx) error {
 	}
 
 	// Return user
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"id":         user.ID,
-		"email":      user.Email,
-		"username":   user.Username,
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-		"role":       user.Role,
-		"status":     user.Status,
-		"created_at": user.CreatedAt,
-		"updated_at": user.UpdatedAt,
-	})
+	response := selectFields(fiber.Map{
+		"id":                            user.ID,
+		"email":                         user.Email,
+		"username":                      user.Username,
+		"first_name":                    user.FirstName,
+		"last_name":                     user.LastName,
+		"display_name":                  h.nameService.DisplayName(user),
+		"locale":                        user.Locale,
+		"phone":                         user.Phone,
+		"birth_date":                    user.BirthDate,
+		"role":                          user.Role,
+		"status":                        user.Status,
+		"email_verified":                user.EmailVerified,
+		"email_reverification_required": user.EmailReverificationRequired,
+		"phone_verified":                user.PhoneVerified,
+		"tags":                          user.Tags,
+		"org_id":                        user.OrgID,
+		"lifecycle_emails_disabled":     user.LifecycleEmailsDisabled,
+		"must_change_password":          user.MustChangePassword,
+		"last_active_at":                user.LastActiveAt,
+		"created_at":                    user.CreatedAt,
+		"updated_at":                    user.UpdatedAt,
+	}, fields)
+
+	// Served from the cache while the database is unavailable, it may lag behind the latest changes
+	if user.Stale {
+		response["stale"] = true
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 // Update updates a user
@@ -224,10 +348,7 @@ func (h *UserHandler) Update(c *fiber.Ctx) error {
 	}
 
 	// Parse request body
-	var req struct {
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
-	}
+	var req profileRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		log.Error().Err(err).Msg("Failed to parse update request body")
@@ -237,31 +358,39 @@ func (h *UserHandler) Update(c *fiber.Ctx) error {
 	}
 
 	// Update user
-	user, err := h.userUseCase.Update(c.Context(), id, req.FirstName, req.LastName)
+	user, err := h.userUseCase.Update(c.Context(), id, req.profile())
 	if err != nil {
 		log.Error().Err(err).Str("id", idParam).Msg("Failed to update user")
 
-		if errors.Is(err, usecase.ErrUserNotFound) {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "User not found",
 			})
+		case errors.Is(err, service.ErrVerificationRequired):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Account verification required",
+				"code":  "VERIFICATION_REQUIRED",
+			})
+		default:
+			return profileError(c, err, "Failed to update user")
 		}
-
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update user",
-		})
 	}
 
 	// Return updated user
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"id":         user.ID,
-		"email":      user.Email,
-		"username":   user.Username,
-		"first_name": user.FirstName,
-		"last_name":  user.LastName,
-		"role":       user.Role,
-		"status":     user.Status,
-		"updated_at": user.UpdatedAt,
+		"id":           user.ID,
+		"email":        user.Email,
+		"username":     user.Username,
+		"first_name":   user.FirstName,
+		"last_name":    user.LastName,
+		"display_name": h.nameService.DisplayName(user),
+		"locale":       user.Locale,
+		"phone":        user.Phone,
+		"birth_date":   user.BirthDate,
+		"role":         user.Role,
+		"status":       user.Status,
+		"updated_at":   user.UpdatedAt,
 	})
 }
 
@@ -284,8 +413,28 @@ func (h *UserHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
+	// Parse the optional request body
+	var req reasonRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			log.Error().Err(err).Msg("Failed to parse delete user request body")
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete user",
+		})
+	}
+
 	// Delete user
-	err = h.userUseCase.Delete(c.Context(), id)
+	purgeAt, err := h.userUseCase.Delete(c.Context(), actorID, id, req.reason())
 	if err != nil {
 		log.Error().Err(err).Str("id", idParam).Msg("Failed to delete user")
 
@@ -294,6 +443,12 @@ func (h *UserHandler) Delete(c *fiber.Ctx) error {
 				"error": "User not found",
 			})
 		}
+		if errors.Is(err, usecase.ErrInvalidReason) {
+			return invalidReasonError(c)
+		}
+		if errors.Is(err, usecase.ErrDeletionPending) {
+			return deletionPendingError(c)
+		}
 
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to delete user",
@@ -301,6 +456,12 @@ func (h *UserHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	// Return success response
+	if purgeAt != nil {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message":  "User scheduled for deletion",
+			"purge_at": purgeAt,
+		})
+	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "User deleted successfully",
 	})
@@ -309,48 +470,80 @@ func (h *UserHandler) Delete(c *fiber.Ctx) error {
 // List lists users with pagination
 func (h *UserHandler) List(c *fiber.Ctx) error {
 	// Parse pagination params
-	page := c.QueryInt("page", 1)
-	if page < 1 {
-		page = 1
+	p, err := parsePagination(c, 10, 100)
+	if err != nil {
+		return invalidCursorError(c)
+	}
+
+	// Parse filters
+	opts := entity.UserListOptions{
+		Status:         c.Query("status"),
+		Tag:            c.Query("tag"),
+		Role:           c.Query("role"),
+		EstimatedCount: c.QueryBool("estimated", false),
+	}
+
+	// Org admins only see the members of their organization
+	if orgID := middleware.ScopedOrgID(c); orgID != nil {
+		opts.OrgID = orgID
 	}
 
-	limit := c.QueryInt("limit", 10)
-	if limit < 1 || limit > 100 {
-		limit = 10
+	fields, ok := parseFields(c.Query("fields"), userListFields)
+	if !ok {
+		return invalidFieldsError(c, userListFields)
 	}
 
 	// List users
-	users, total, err := h.userUseCase.List(c.Context(), page, limit)
+	users, total, err := h.userUseCase.List(c.Context(), p.Page, p.Limit, opts)
 	if err != nil {
-		log.Error().Err(err).Int("page", page).Int("limit", limit).Msg("Failed to list users")
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list users",
-		})
+		log.Error().Err(err).Int("page", p.Page).Int("limit", p.Limit).Msg("Failed to list users")
+
+		switch {
+		case errors.Is(err, usecase.ErrInvalidStatus):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid status filter",
+			})
+		case errors.Is(err, usecase.ErrInvalidTag):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid tag filter",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to list users",
+			})
+		}
 	}
 
 	// Map users to response format
 	userResponses := make([]fiber.Map, 0, len(users))
 	for _, user := range users {
-		userResponses = append(userResponses, fiber.Map{
-			"id":         user.ID,
-			"email":      user.Email,
-			"username":   user.Username,
-			"first_name": user.FirstName,
-			"last_name":  user.LastName,
-			"role":       user.Role,
-			"status":     user.Status,
-			"created_at": user.CreatedAt,
-			"updated_at": user.UpdatedAt,
-		})
+		userResponses = append(userResponses, selectFields(fiber.Map{
+			"id":                            user.ID,
+			"email":                         user.Email,
+			"username":                      user.Username,
+			"first_name":                    user.FirstName,
+			"last_name":                     user.LastName,
+			"display_name":                  h.nameService.DisplayName(user),
+			"locale":                        user.Locale,
+			"phone":                         user.Phone,
+			"birth_date":                    user.BirthDate,
+			"role":                          user.Role,
+			"status":                        user.Status,
+			"email_verified":                user.EmailVerified,
+			"email_reverification_required": user.EmailReverificationRequired,
+			"phone_verified":                user.PhoneVerified,
+			"tags":                          user.Tags,
+			"org_id":                        user.OrgID,
+			"created_at":                    user.CreatedAt,
+			"updated_at":                    user.UpdatedAt,
+		}, fields))
 	}
 
 	// Return users
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"users": userResponses,
-		"total": total,
-		"page":  page,
-		"limit": limit,
-	})
+	return c.Status(fiber.StatusOK).JSON(p.response(fiber.Map{
+		"users":     userResponses,
+		"estimated": opts.EstimatedCount && !opts.Filtered(),
+	}, total))
 }
 
 // ChangePassword changes a user's password
@@ -406,9 +599,7 @@ func (h *UserHandler) ChangePassword(c *fiber.Ctx) error {
 				"error": "Invalid old password",
 			})
 		default:
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to change password",
-			})
+			return passwordError(c, err, "Failed to change password")
 		}
 	}
 
@@ -440,6 +631,10 @@ func (h *UserHandler) UpdateStatus(c *fiber.Ctx) error {
 	// Parse request body
 	var req struct {
 		Status string `json:"status" validate:"required,oneof=active inactive blocked"`
+		reasonRequest
+
+		// Reason is accepted in place of the note
+		Reason string `json:"reason"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
@@ -456,32 +651,762 @@ func (h *UserHandler) UpdateStatus(c *fiber.Ctx) error {
 		})
 	}
 
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update status",
+		})
+	}
+
 	// Update status
-	err = h.userUseCase.UpdateStatus(c.Context(), id, req.Status)
+	if req.Note == "" {
+		req.Note = req.Reason
+	}
+
+	// Update status
+	err = h.userUseCase.UpdateStatus(c.Context(), actorID, id, req.Status, req.reason())
 	if err != nil {
 		log.Error().Err(err).Str("id", idParam).Str("status", req.Status).Msg("Failed to update status")
 
-		if errors.Is(err, usecase.ErrUserNotFound) {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "User not found",
 			})
+		case errors.Is(err, usecase.ErrInvalidStatus):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid status",
+			})
+		case errors.Is(err, usecase.ErrStatusReasonRequired):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "A reason code or note is required to block a user",
+				"code":  "STATUS_REASON_REQUIRED",
+			})
+		case errors.Is(err, usecase.ErrInvalidReason):
+			return invalidReasonError(c)
+		case errors.Is(err, usecase.ErrDeletionPending):
+			return deletionPendingError(c)
+		case errors.Is(err, usecase.ErrUserWaitlisted):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "User is waitlisted, approve them from the waitlist instead",
+				"code":  "USER_WAITLISTED",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update status",
+			})
 		}
+	}
+
+	// Return success response
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Status updated successfully",
+	})
+}
 
+// ListPendingDeletions lists the users pending deletion along with the time left to restore them
+func (h *UserHandler) ListPendingDeletions(c *fiber.Ctx) error {
+	// Parse pagination params
+	p, err := parsePagination(c, 10, 100)
+	if err != nil {
+		return invalidCursorError(c)
+	}
+
+	users, total, err := h.userUseCase.ListPendingDeletions(c.Context(), p.Page, p.Limit)
+	if err != nil {
+		log.Error().Err(err).Int("page", p.Page).Int("limit", p.Limit).Msg("Failed to list users pending deletion")
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update status",
+			"error": "Failed to list users pending deletion",
 		})
 	}
 
-	// Return success response
+	now := time.Now()
+	userResponses := make([]fiber.Map, 0, len(users))
+	for _, user := range users {
+		var remaining time.Duration
+		if user.PurgeAt != nil {
+			remaining = max(user.PurgeAt.Sub(now), 0)
+		}
+		userResponses = append(userResponses, fiber.Map{
+			"id":                     user.ID,
+			"email":                  user.Email,
+			"username":               user.Username,
+			"display_name":           h.nameService.DisplayName(user),
+			"org_id":                 user.OrgID,
+			"previous_status":        user.StatusBeforeDeletion,
+			"deletion_requested_at":  user.DeletionRequestedAt,
+			"purge_at":               user.PurgeAt,
+			"time_remaining_seconds": int64(remaining.Seconds()),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(p.response(fiber.Map{
+		"users": userResponses,
+	}, total))
+}
+
+// CancelDeletion restores a user pending deletion before it is purged
+func (h *UserHandler) CancelDeletion(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to cancel deletion",
+		})
+	}
+
+	user, err := h.userUseCase.CancelDeletion(c.Context(), actorID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			// Purged already, or never existed
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		case errors.Is(err, usecase.ErrNotPendingDeletion):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "User is not pending deletion",
+				"code":  "NOT_PENDING_DELETION",
+			})
+		default:
+			log.Error().Err(err).Str("id", idParam).Msg("Failed to cancel deletion")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to cancel deletion",
+			})
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Status updated successfully",
+		"message": "Deletion cancelled",
+		"id":      user.ID,
+		"status":  user.Status,
 	})
 }
 
-// HealthCheck is a simple health check endpoint
-func (h *UserHandler) HealthCheck(c *fiber.Ctx) error {
+// Import creates users exported from a legacy system with their password hashes, reporting the users failing to
+// import by their position
+func (h *UserHandler) Import(c *fiber.Ctx) error {
+	var req struct {
+		Users []entity.UserImport `json:"users"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse import request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if len(req.Users) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No users to import",
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(uuid.UUID)
+	report, err := h.userUseCase.Import(c.Context(), actorID, req.Users)
+	if err != nil {
+		if errors.Is(err, usecase.ErrImportTooLarge) {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+				"error": "Too many users to import at once",
+				"code":  "IMPORT_TOO_LARGE",
+			})
+		}
+		log.Error().Err(err).Msg("Failed to import users")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to import users",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// deletionPendingError responds to an action on a user pending deletion, which must be cancelled first
+func deletionPendingError(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"error": "User is pending deletion, cancel the deletion first",
+		"code":  "DELETION_PENDING",
+	})
+}
+
+// StatusHistory lists the status changes of a user, newest first
+func (h *UserHandler) StatusHistory(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	p, err := parsePagination(c, 50, 100)
+	if err != nil {
+		return invalidCursorError(c)
+	}
+
+	history, err := h.userUseCase.StatusHistory(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		}
+		log.Error().Err(err).Str("id", idParam).Msg("Failed to list status history")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list status history",
+		})
+	}
+
+	page := paginate(history, p)
+	if page == nil {
+		page = []*entity.StatusChange{}
+	}
+	return c.Status(fiber.StatusOK).JSON(p.response(fiber.Map{
+		"history": page,
+	}, int64(len(history))))
+}
+
+// UpdateRole updates a user's role
+func (h *UserHandler) UpdateRole(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Role string `json:"role" validate:"required"`
+		reasonRequest
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update role request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Org admins cannot grant platform-wide administration
+	if middleware.ScopedOrgID(c) != nil && req.Role == entity.UserRoleAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Insufficient permissions",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update role",
+		})
+	}
+
+	// Privileged roles are granted once a second administrator approves the change
+	if h.roleApprovalUseCase.RequiresApproval(req.Role) {
+		request, err := h.roleApprovalUseCase.Request(c.Context(), actorID, id, entity.RoleChangeKindPrimary, req.Role, 0, req.reason())
+		if err != nil {
+			log.Error().Err(err).Str("id", idParam).Str("role", req.Role).Msg("Failed to request role change")
+			return roleError(c, err, "Failed to update role")
+		}
+		return c.Status(fiber.StatusAccepted).JSON(request)
+	}
+
+	// Update role
+	if err := h.userUseCase.UpdateRole(c.Context(), actorID, id, req.Role, req.reason()); err != nil {
+		log.Error().Err(err).Str("id", idParam).Str("role", req.Role).Msg("Failed to update role")
+
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		case errors.Is(err, usecase.ErrInvalidRole):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid role",
+			})
+		case errors.Is(err, usecase.ErrInvalidReason):
+			return invalidReasonError(c)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update role",
+			})
+		}
+	}
+
+	// Return success response
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"status":    "ok",
-		"timestamp": time.Now().Unix(),
+		"message": "Role updated successfully",
 	})
 }
+
+// UpdateNotificationChannels updates the channels a user prefers to be notified on
+func (h *UserHandler) UpdateNotificationChannels(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Channels []string `json:"channels"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update notification channels request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Update notification channels
+	if err := h.userUseCase.UpdateNotificationChannels(c.Context(), id, req.Channels); err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Failed to update notification channels")
+
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		case errors.Is(err, usecase.ErrInvalidChannel):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid notification channel",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update notification channels",
+			})
+		}
+	}
+
+	// Return success response
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Notification channels updated successfully",
+	})
+}
+
+// UpdateLifecycleEmails opts a user in or out of the lifecycle emails
+func (h *UserHandler) UpdateLifecycleEmails(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Enabled *bool `json:"enabled"`
+	}
+
+	if err := c.BodyParser(&req); err != nil || req.Enabled == nil {
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to parse update lifecycle emails request body")
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body, enabled is required",
+		})
+	}
+
+	if err := h.userUseCase.UpdateLifecycleEmails(c.Context(), id, *req.Enabled); err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Failed to update lifecycle emails")
+
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update lifecycle emails",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Lifecycle emails updated successfully",
+	})
+}
+
+// UpdateVerification updates a user's verification status
+func (h *UserHandler) UpdateVerification(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Parse request body, omitted fields are left unchanged
+	var req struct {
+		EmailVerified *bool `json:"email_verified"`
+		PhoneVerified *bool `json:"phone_verified"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse update verification request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update verification",
+		})
+	}
+
+	// Update verification
+	if err := h.userUseCase.UpdateVerification(c.Context(), actorID, id, req.EmailVerified, req.PhoneVerified); err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Failed to update verification")
+
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update verification",
+			})
+		}
+	}
+
+	// Return success response
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Verification updated successfully",
+	})
+}
+
+// RequirePasswordChange makes the next sign in of a user with a password change it, or lifts the requirement
+func (h *UserHandler) RequirePasswordChange(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		MustChangePassword bool `json:"must_change_password"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse must change password request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update password change requirement",
+		})
+	}
+
+	if err := h.userUseCase.RequirePasswordChange(c.Context(), actorID, id, req.MustChangePassword); err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Failed to update password change requirement")
+
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update password change requirement",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"must_change_password": req.MustChangePassword,
+	})
+}
+
+// AddTags adds tags to a user
+func (h *UserHandler) AddTags(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	// Parse request body
+	var req struct {
+		Tags []string `json:"tags" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse add tags request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	return h.changeTags(c, id, req.Tags, h.userUseCase.AddTags)
+}
+
+// RemoveTag removes a tag from a user
+func (h *UserHandler) RemoveTag(c *fiber.Ctx) error {
+	// Parse user ID from path
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		log.Error().Err(err).Str("id", idParam).Msg("Invalid user ID format")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID format",
+		})
+	}
+
+	return h.changeTags(c, id, []string{c.Params("tag")}, h.userUseCase.RemoveTags)
+}
+
+// changeTags applies a tag change on behalf of the acting user and returns the user's tags
+func (h *UserHandler) changeTags(
+	c *fiber.Ctx,
+	id uuid.UUID,
+	tags []string,
+	change func(ctx context.Context, actorID, id uuid.UUID, tags []string) ([]string, error),
+) error {
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update tags",
+		})
+	}
+
+	// Update tags
+	updated, err := change(c.Context(), actorID, id, tags)
+	if err != nil {
+		log.Error().Err(err).Str("id", id.String()).Strs("tags", tags).Msg("Failed to update tags")
+
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "User not found",
+			})
+		case errors.Is(err, usecase.ErrInvalidTag):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid tag",
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update tags",
+			})
+		}
+	}
+
+	// Return the user's tags
+	if updated == nil {
+		updated = []string{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"tags": updated,
+	})
+}
+
+// HealthCheck is a simple health check endpoint
+func (h *UserHandler) HealthCheck(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":    "ok",
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+// userListFields lists the fields of the users listed, which clients may select with the fields query parameter
+var userListFields = []string{
+	"id", "email", "username", "first_name", "last_name", "display_name", "locale", "phone", "birth_date", "role",
+	"status", "email_verified", "email_reverification_required", "phone_verified", "tags", "org_id", "created_at",
+	"updated_at",
+}
+
+// userFields lists the fields of a user, which clients may select with the fields query parameter
+var userFields = append(slices.Clone(userListFields), "lifecycle_emails_disabled", "must_change_password", "last_active_at")
+
+// parseFields parses a comma-separated fields query parameter, returning nil to select every field and false when
+// a field is not allowed
+func parseFields(query string, allowed []string) (map[string]bool, bool) {
+	if strings.TrimSpace(query) == "" {
+		return nil, true
+	}
+
+	fields := make(map[string]bool)
+	for _, field := range strings.Split(query, ",") {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(allowed, field) {
+			return nil, false
+		}
+		fields[field] = true
+	}
+	return fields, true
+}
+
+// selectFields removes the fields of a response which were not selected, every field is kept when none were
+func selectFields(response fiber.Map, fields map[string]bool) fiber.Map {
+	if fields == nil {
+		return response
+	}
+
+	for field := range response {
+		if !fields[field] {
+			delete(response, field)
+		}
+	}
+	return response
+}
+
+// invalidFieldsError responds to a fields query parameter selecting a field which is not allowed
+func invalidFieldsError(c *fiber.Ctx, allowed []string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Invalid fields, fields are a comma-separated list of " + strings.Join(allowed, ", "),
+		"code":  "INVALID_FIELDS",
+	})
+}
+
+// reasonRequest contains the optional reason of the administrative actions on a user
+type reasonRequest struct {
+	ReasonCode string `json:"reason_code"`
+	Note       string `json:"note"`
+}
+
+// reason returns the submitted reason
+func (r reasonRequest) reason() entity.ActionReason {
+	return entity.ActionReason{
+		Code: r.ReasonCode,
+		Note: r.Note,
+	}
+}
+
+// invalidReasonError responds to an unknown reason code or an invalid note
+func invalidReasonError(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Invalid reason, the reason code must be known and the note at most 500 characters without control characters",
+	})
+}
+
+// profileRequest contains the profile fields of the register and update requests
+type profileRequest struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+
+	// Omitted fields are left unchanged, empty ones are cleared
+	DisplayName *string `json:"display_name"`
+	Locale      *string `json:"locale"`
+	Phone       *string `json:"phone"`
+	BirthDate   *string `json:"birth_date"`
+}
+
+// profile returns the submitted profile
+func (r profileRequest) profile() entity.UserProfile {
+	return entity.UserProfile{
+		FirstName:   r.FirstName,
+		LastName:    r.LastName,
+		DisplayName: r.DisplayName,
+		Locale:      r.Locale,
+		Phone:       r.Phone,
+		BirthDate:   r.BirthDate,
+	}
+}
+
+// profileError responds to an invalid submitted profile, or with the fallback message to any other error
+func profileError(c *fiber.Ctx, err error, fallback string) error {
+	var fieldErr *entity.ProfileFieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("The %s field is %s by your organization", fieldErr.Field, fieldErr.Mode),
+			"code":  "PROFILE_FIELD_" + strings.ToUpper(fieldErr.Mode),
+			"field": fieldErr.Field,
+		})
+	case errors.Is(err, usecase.ErrInvalidDisplayName):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid display name, at most 100 characters without control characters",
+		})
+	case errors.Is(err, usecase.ErrInvalidLocale):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid locale, a BCP 47 language tag such as en-US is expected",
+		})
+	case errors.Is(err, usecase.ErrInvalidPhone):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid phone number, E.164 format such as +14155550123 is expected",
+		})
+	case errors.Is(err, usecase.ErrInvalidBirthDate):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid birth date, a past date formatted as YYYY-MM-DD is expected",
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
+
+// passwordError maps the rejections of a chosen password to responses
+func passwordError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrWeakPassword):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "This password is too easy to guess, please choose a stronger one",
+			"code":  "PASSWORD_TOO_WEAK",
+		})
+	case errors.Is(err, service.ErrBreachedPassword):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "This password appeared in a data breach, please choose another one",
+			"code":  "PASSWORD_BREACHED",
+		})
+	case errors.Is(err, service.ErrPasswordCheckUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "The password could not be checked, please try again later",
+			"code":  "PASSWORD_CHECK_UNAVAILABLE",
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
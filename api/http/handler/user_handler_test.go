@@ -0,0 +1,43 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCancelDeletionRestoresBlockedUser(t *testing.T) {
+	c := newTestClient(t)
+	admin := c.login(adminEmail, adminPassword)
+	userID := c.login(userEmail, userPassword).User.ID
+	userPath := "/api/v1/users/" + userID
+
+	body := map[string]string{"status": entity.UserStatusBlocked, "note": "Chargeback under investigation"}
+	if status := c.do(fiber.MethodPut, userPath+"/status", admin.AccessToken, body, nil); status != fiber.StatusOK {
+		t.Fatalf("block user: got status %d, want %d", status, fiber.StatusOK)
+	}
+	if status := c.do(fiber.MethodDelete, userPath, admin.AccessToken, nil, nil); status != fiber.StatusOK {
+		t.Fatalf("delete user: got status %d, want %d", status, fiber.StatusOK)
+	}
+
+	var restored entity.User
+	status := c.do(fiber.MethodPost, "/api/v1/admin/users/"+userID+"/cancel-deletion", admin.AccessToken, nil, &restored)
+	if status != fiber.StatusOK {
+		t.Fatalf("cancel deletion: got status %d, want %d", status, fiber.StatusOK)
+	}
+	if restored.Status != entity.UserStatusBlocked {
+		t.Errorf("restored status: got %q, want %q", restored.Status, entity.UserStatusBlocked)
+	}
+
+	var stored entity.User
+	if status := c.do(fiber.MethodGet, userPath, admin.AccessToken, nil, &stored); status != fiber.StatusOK {
+		t.Fatalf("get user: got status %d, want %d", status, fiber.StatusOK)
+	}
+	if stored.Status != entity.UserStatusBlocked {
+		t.Errorf("stored status: got %q, want %q", stored.Status, entity.UserStatusBlocked)
+	}
+	if status := c.do(fiber.MethodPost, "/api/v1/admin/users/"+userID+"/cancel-deletion", admin.AccessToken, nil, nil); status != fiber.StatusConflict {
+		t.Errorf("cancel deletion again: got status %d, want %d", status, fiber.StatusConflict)
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// WaitlistHandler handles HTTP requests for the users waiting for their self-registration to be approved
+type WaitlistHandler struct {
+	waitlistUseCase usecase.WaitlistUseCase
+}
+
+// NewWaitlistHandler creates a new WaitlistHandler
+func NewWaitlistHandler(waitlistUseCase usecase.WaitlistUseCase) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistUseCase: waitlistUseCase,
+	}
+}
+
+// RegisterRoutes registers the waitlist routes on the admin group
+func (h *WaitlistHandler) RegisterRoutes(adminGroup fiber.Router) {
+	adminGroup.Post("/waitlist/approve", h.Approve)
+}
+
+// Approve activates a batch of waitlisted users, either the given users or the earliest registered ones
+func (h *WaitlistHandler) Approve(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		UserIDs []uuid.UUID `json:"user_ids"`
+		Count   int         `json:"count"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse waitlist approval request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Validate request
+	if (len(req.UserIDs) == 0) == (req.Count == 0) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Either user_ids or count is required",
+		})
+	}
+
+	// Get acting user from context
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to approve waitlisted users",
+		})
+	}
+
+	var users []*entity.User
+	var err error
+	if req.Count != 0 {
+		users, err = h.waitlistUseCase.ApproveNext(c.Context(), actorID, req.Count)
+	} else {
+		users, err = h.waitlistUseCase.Approve(c.Context(), actorID, req.UserIDs)
+	}
+	if err != nil {
+		log.Error().Err(err).Int("approved", len(users)).Msg("Failed to approve waitlisted users")
+
+		if errors.Is(err, usecase.ErrInvalidWaitlistBatch) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Approve between 1 and 100 users at once",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to approve waitlisted users",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"users": users,
+		"count": len(users),
+	})
+}
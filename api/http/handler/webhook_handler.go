@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// WebhookHandler handles HTTP requests for webhook endpoints and their deliveries
+type WebhookHandler struct {
+	webhookUseCase usecase.WebhookUseCase
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(webhookUseCase usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// RegisterRoutes registers the routes for the webhook handler on the admin group
+func (h *WebhookHandler) RegisterRoutes(adminGroup fiber.Router) {
+	webhookGroup := adminGroup.Group("/webhooks")
+
+	webhookGroup.Get("/endpoints", h.ListEndpoints)
+	webhookGroup.Post("/endpoints", h.CreateEndpoint)
+	webhookGroup.Get("/endpoints/:id", h.GetEndpoint)
+	webhookGroup.Delete("/endpoints/:id", h.DeleteEndpoint)
+	webhookGroup.Post("/endpoints/:id/pause", h.PauseEndpoint)
+	webhookGroup.Post("/endpoints/:id/resume", h.ResumeEndpoint)
+
+	webhookGroup.Get("/deliveries", h.ListDeliveries)
+	webhookGroup.Post("/deliveries/replay", h.Replay)
+	webhookGroup.Get("/deliveries/:id", h.GetDelivery)
+}
+
+// ListEndpoints lists the webhook endpoints
+func (h *WebhookHandler) ListEndpoints(c *fiber.Ctx) error {
+	endpoints, err := h.webhookUseCase.ListEndpoints(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list webhook endpoints")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list webhook endpoints",
+		})
+	}
+
+	if endpoints == nil {
+		endpoints = []*entity.WebhookEndpoint{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"endpoints": endpoints,
+	})
+}
+
+// CreateEndpoint registers a webhook endpoint, its signing secret is only returned here
+func (h *WebhookHandler) CreateEndpoint(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		URL        string   `json:"url" validate:"required,url"`
+		EventTypes []string `json:"event_types" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse create webhook endpoint request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create webhook endpoint",
+		})
+	}
+
+	endpoint, err := h.webhookUseCase.CreateEndpoint(c.Context(), actorID, req.URL, req.EventTypes)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create webhook endpoint")
+		return webhookError(c, err, "Failed to create webhook endpoint")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"endpoint": endpoint,
+		"secret":   endpoint.Secret,
+	})
+}
+
+// GetEndpoint returns a webhook endpoint
+func (h *WebhookHandler) GetEndpoint(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook endpoint ID format",
+		})
+	}
+
+	endpoint, err := h.webhookUseCase.GetEndpoint(c.Context(), id)
+	if err != nil {
+		return webhookError(c, err, "Failed to get webhook endpoint")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(endpoint)
+}
+
+// DeleteEndpoint removes a webhook endpoint along with its deliveries
+func (h *WebhookHandler) DeleteEndpoint(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook endpoint ID format",
+		})
+	}
+
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete webhook endpoint",
+		})
+	}
+
+	if err := h.webhookUseCase.DeleteEndpoint(c.Context(), actorID, id); err != nil {
+		log.Error().Err(err).Str("endpoint_id", id.String()).Msg("Failed to delete webhook endpoint")
+		return webhookError(c, err, "Failed to delete webhook endpoint")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Webhook endpoint deleted successfully",
+	})
+}
+
+// PauseEndpoint stops deliveries to a webhook endpoint, events keep being queued
+func (h *WebhookHandler) PauseEndpoint(c *fiber.Ctx) error {
+	return h.setPaused(c, true)
+}
+
+// ResumeEndpoint resumes deliveries to a webhook endpoint, starting with the events queued while paused
+func (h *WebhookHandler) ResumeEndpoint(c *fiber.Ctx) error {
+	return h.setPaused(c, false)
+}
+
+// setPaused pauses or resumes a webhook endpoint on behalf of the acting user
+func (h *WebhookHandler) setPaused(c *fiber.Ctx, paused bool) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook endpoint ID format",
+		})
+	}
+
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update webhook endpoint",
+		})
+	}
+
+	endpoint, err := h.webhookUseCase.SetEndpointPaused(c.Context(), actorID, id, paused)
+	if err != nil {
+		log.Error().Err(err).Str("endpoint_id", id.String()).Bool("paused", paused).Msg("Failed to update webhook endpoint")
+		return webhookError(c, err, "Failed to update webhook endpoint")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(endpoint)
+}
+
+// ListDeliveries lists the webhook deliveries, newest first.
+// The dead-letter queue is the list of deliveries with the dead status.
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	p, err := parsePagination(c, 50, 100)
+	if err != nil {
+		return invalidCursorError(c)
+	}
+
+	filter := entity.WebhookDeliveryFilter{
+		Status: c.Query("status"),
+		Limit:  p.Limit,
+		Offset: p.Offset(),
+	}
+
+	switch filter.Status {
+	case "", entity.WebhookDeliveryPending, entity.WebhookDeliveryDelivered, entity.WebhookDeliveryDead:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid delivery status",
+		})
+	}
+
+	if endpointID := c.Query("endpoint_id"); endpointID != "" {
+		id, err := uuid.Parse(endpointID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid webhook endpoint ID format",
+			})
+		}
+		filter.EndpointID = id
+	}
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(c.Context(), filter)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list webhook deliveries")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list webhook deliveries",
+		})
+	}
+
+	if deliveries == nil {
+		deliveries = []*entity.WebhookDelivery{}
+	}
+	return c.Status(fiber.StatusOK).JSON(p.uncountedResponse(fiber.Map{
+		"deliveries": deliveries,
+	}, len(deliveries)))
+}
+
+// GetDelivery returns a webhook delivery along with its payload
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook delivery ID format",
+		})
+	}
+
+	delivery, err := h.webhookUseCase.GetDelivery(c.Context(), id)
+	if err != nil {
+		return webhookError(c, err, "Failed to get webhook delivery")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(delivery)
+}
+
+// Replay queues selected deliveries again, typically from the dead-letter queue
+func (h *WebhookHandler) Replay(c *fiber.Ctx) error {
+	// Parse request body
+	var req struct {
+		DeliveryIDs []uuid.UUID `json:"delivery_ids" validate:"required"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Error().Err(err).Msg("Failed to parse replay webhook deliveries request body")
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	actorID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		log.Error().Msg("User ID not found in context")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to replay webhook deliveries",
+		})
+	}
+
+	deliveries, err := h.webhookUseCase.Replay(c.Context(), actorID, req.DeliveryIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to replay webhook deliveries")
+		return webhookError(c, err, "Failed to replay webhook deliveries")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"deliveries": deliveries,
+	})
+}
+
+// webhookError maps webhook use case errors to HTTP responses
+func webhookError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, usecase.ErrWebhookEndpointNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook endpoint not found",
+		})
+	case errors.Is(err, usecase.ErrWebhookDeliveryNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook delivery not found",
+		})
+	case errors.Is(err, usecase.ErrInvalidWebhookURL):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook URL, an http or https URL is required",
+		})
+	case errors.Is(err, usecase.ErrInvalidWebhookEventTypes):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event types, at least one known event type is required",
+		})
+	case errors.Is(err, usecase.ErrInvalidWebhookReplay):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Between 1 and 100 delivery IDs are required",
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": fallback,
+		})
+	}
+}
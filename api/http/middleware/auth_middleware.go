@@ -2,8 +2,13 @@ package middleware
 
 import (
 	"errors"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/chats/go-user-api/internal/domain/entity"
 	"github.com/chats/go-user-api/internal/domain/service"
 	"github.com/chats/go-user-api/internal/domain/usecase"
 	"github.com/gofiber/fiber/v2"
@@ -11,11 +16,19 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// AuthMiddleware creates a middleware to validate access tokens
-func AuthMiddleware(authUseCase usecase.AuthUseCase) fiber.Handler {
+// APIKeyHeader is the header carrying the API key of the requests authenticated by one
+const APIKeyHeader = "X-API-Key"
+
+// AuthMiddleware creates a middleware to validate access tokens, and API keys in place of them unless
+// apiKeyUseCase is nil. The requests of API keys and service accounts are counted against their quota unless
+// quotaUseCase is nil.
+func AuthMiddleware(authUseCase usecase.AuthUseCase, apiKeyUseCase usecase.APIKeyUseCase, quotaUseCase usecase.QuotaUseCase) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get authorization header
 		authHeader := c.Get("Authorization")
+		if authHeader == "" && apiKeyUseCase != nil && c.Get(APIKeyHeader) != "" {
+			return authenticateAPIKey(c, apiKeyUseCase, quotaUseCase)
+		}
 		if authHeader == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "Authorization header is required",
@@ -34,7 +47,7 @@ func AuthMiddleware(authUseCase usecase.AuthUseCase) fiber.Handler {
 		token := parts[1]
 
 		// Validate token
-		userID, err := authUseCase.ValidateToken(c.Context(), token)
+		claims, err := authUseCase.ValidateToken(c.Context(), token)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to validate token")
 
@@ -49,25 +62,199 @@ func AuthMiddleware(authUseCase usecase.AuthUseCase) fiber.Handler {
 			})
 		}
 
-		// Set user ID in context for later use
-		c.Locals("user_id", userID)
+		// Service accounts act within the scopes of their token
+		if claims.ClientID != "" {
+			return authorizeClientToken(c, claims, quotaUseCase)
+		}
+
+		// Set user ID, token, session, role and organization in context for later use
+		c.Locals("user_id", claims.UserID)
+		c.Locals("token_id", claims.TokenID)
+		c.Locals("token_type", claims.TokenType)
+		c.Locals("session_id", claims.SessionID)
+		c.Locals("user_role", claims.Role)
+		if claims.OrgID != nil {
+			c.Locals("org_id", *claims.OrgID)
+		}
+
+		return c.Next()
+	}
+}
+
+// OIDCTokenMiddleware creates a middleware to validate the access tokens issued to OIDC relying parties, for the
+// user info endpoint only. Errors follow RFC 6750 so standard clients understand them.
+func OIDCTokenMiddleware(authUseCase usecase.AuthUseCase) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := strings.CutPrefix(c.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "invalid_request",
+			})
+		}
+
+		claims, err := authUseCase.ValidateOIDCToken(c.Context(), token)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidToken) || errors.Is(err, service.ErrExpiredToken) {
+				c.Set(fiber.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "invalid_token",
+				})
+			}
+
+			log.Error().Err(err).Msg("Failed to validate OIDC access token")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "server_error",
+			})
+		}
 
-		// In a real implementation, you would extract the token ID from the claims as well
-		// For now we'll set a placeholder
-		c.Locals("token_id", uuid.New())
+		// Set user ID, client, token and session in context for later use, no role as the token has none
+		c.Locals("user_id", claims.UserID)
+		c.Locals("client_id", claims.ClientID)
+		c.Locals("token_id", claims.TokenID)
+		c.Locals("token_type", claims.TokenType)
+		c.Locals("session_id", claims.SessionID)
 
 		return c.Next()
 	}
 }
 
+// authenticateAPIKey validates the API key of a request and checks it was granted the scope of the request,
+// read for GET and HEAD requests and write for others. Keys without the admin scope act with the user role
+// whatever the role of their owner. The keys of an organization act in its name, as an org admin of the
+// organization with the admin scope.
+func authenticateAPIKey(c *fiber.Ctx, apiKeyUseCase usecase.APIKeyUseCase, quotaUseCase usecase.QuotaUseCase) error {
+	apiKey, user, err := apiKeyUseCase.Authenticate(c.Context(), c.Get(APIKeyHeader))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidAPIKey) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid or expired API key",
+			})
+		}
+
+		log.Error().Err(err).Msg("Failed to validate API key")
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to validate API key",
+		})
+	}
+
+	scope := requiredScope(c)
+	if !apiKey.HasScope(scope) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "API key lacks the " + scope + " scope",
+			"code":  "API_KEY_SCOPE_MISSING",
+		})
+	}
+
+	if quotaUseCase != nil {
+		usage, err := quotaUseCase.ConsumeAPIKey(c.Context(), apiKey)
+		if errors.Is(err, usecase.ErrQuotaExceeded) {
+			return quotaExceeded(c, usage)
+		}
+		if err != nil {
+			// The request goes through when its quota cannot be checked, like the rate limits
+			log.Error().Err(err).Str("api_key_id", apiKey.ID.String()).Msg("Failed to count API key request against its quota")
+		}
+	}
+
+	if apiKey.IsOrgKey() {
+		return authenticateOrgAPIKey(c, apiKey)
+	}
+
+	role := entity.UserRoleUser
+	if apiKey.HasScope(entity.APIKeyScopeAdmin) {
+		role = user.Role
+	}
+
+	// Set user ID, API key, role and organization in context for later use
+	c.Locals("user_id", user.ID)
+	c.Locals("api_key_id", apiKey.ID)
+	c.Locals("user_role", role)
+	if user.OrgID != nil {
+		c.Locals("org_id", *user.OrgID)
+	}
+
+	return c.Next()
+}
+
+// authenticateOrgAPIKey sets the organization context of a request authenticated by the API key of an
+// organization. The API key ID stands for the user ID so the actions of the key are attributed to it, like the
+// service accounts.
+func authenticateOrgAPIKey(c *fiber.Ctx, apiKey *entity.APIKey) error {
+	role := entity.UserRoleUser
+	if apiKey.HasScope(entity.APIKeyScopeAdmin) {
+		role = entity.UserRoleOrgAdmin
+	}
+
+	c.Locals("user_id", apiKey.ID)
+	c.Locals("api_key_id", apiKey.ID)
+	c.Locals("user_role", role)
+	c.Locals("org_id", *apiKey.OrgID)
+
+	return c.Next()
+}
+
+// authorizeClientToken checks the access token of a service account was granted the scope of the request, like
+// API keys. The service account acts as an administrator with the admin scope.
+func authorizeClientToken(c *fiber.Ctx, claims *service.TokenClaims, quotaUseCase usecase.QuotaUseCase) error {
+	scope := requiredScope(c)
+	if !slices.Contains(claims.Scopes, scope) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Access token lacks the " + scope + " scope",
+			"code":  "TOKEN_SCOPE_MISSING",
+		})
+	}
+
+	if quotaUseCase != nil {
+		usage, err := quotaUseCase.ConsumeServiceAccount(c.Context(), claims.UserID)
+		if errors.Is(err, usecase.ErrQuotaExceeded) {
+			return quotaExceeded(c, usage)
+		}
+		if err != nil {
+			// The request goes through when its quota cannot be checked, like the rate limits
+			log.Error().Err(err).Str("client_id", claims.ClientID).Msg("Failed to count service account request against its quota")
+		}
+	}
+
+	role := entity.UserRoleUser
+	if slices.Contains(claims.Scopes, entity.APIKeyScopeAdmin) {
+		role = entity.UserRoleAdmin
+	}
+
+	// Set service account, client, token, session and role in context for later use, the service account ID
+	// stands for the user ID so its actions are attributed to it
+	c.Locals("user_id", claims.UserID)
+	c.Locals("client_id", claims.ClientID)
+	c.Locals("token_id", claims.TokenID)
+	c.Locals("token_type", claims.TokenType)
+	c.Locals("session_id", claims.SessionID)
+	c.Locals("user_role", role)
+
+	return c.Next()
+}
+
+// quotaExceeded refuses a request of an API key or service account over its quota until the exhausted period
+// resets
+func quotaExceeded(c *fiber.Ctx, usage *entity.QuotaUsage) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(time.Until(usage.ResetsAt).Seconds()))))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+		"error": "The " + usage.Period + " request quota is exceeded",
+		"code":  "QUOTA_EXCEEDED",
+	})
+}
+
+// requiredScope returns the scope of a request, read for GET and HEAD requests and write for others
+func requiredScope(c *fiber.Ctx) string {
+	if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+		return entity.APIKeyScopeRead
+	}
+	return entity.APIKeyScopeWrite
+}
+
 // RoleMiddleware creates a middleware to check user roles
 func RoleMiddleware(roles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// This would typically extract the user role from the token or database
-		// For simplicity, we're just checking if the role was set in the context
-
-		// In a real implementation, you would get the user from the database or token claims
-		// and check their role
+		// The role is set by AuthMiddleware from the access token claims
 		role, ok := c.Locals("user_role").(string)
 		if !ok {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -87,3 +274,26 @@ func RoleMiddleware(roles ...string) fiber.Handler {
 		})
 	}
 }
+
+// SelfOrRoleMiddleware creates a middleware letting users act on themselves, the user targeted by :id,
+// and users with one of the roles act on anyone
+func SelfOrRoleMiddleware(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// The role is set by AuthMiddleware from the access token claims
+		role, _ := c.Locals("user_role").(string)
+		for _, r := range roles {
+			if r == role {
+				return c.Next()
+			}
+		}
+
+		userID, ok := c.Locals("user_id").(uuid.UUID)
+		if id, err := uuid.Parse(c.Params("id")); ok && err == nil && id == userID {
+			return c.Next()
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "You can only manage your own account",
+		})
+	}
+}
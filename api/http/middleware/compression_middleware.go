@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/valyala/fasthttp"
+)
+
+// Content codings negotiated by the compression middleware, in the order preferred among equal weights
+const (
+	EncodingBrotli  = "br"
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+)
+
+// compressionMinLength is the size under which bodies are sent as they are, compressing them rarely pays off
+const compressionMinLength = 200
+
+// skipCompressionKey is the local set by SkipCompression
+const skipCompressionKey = "skip_compression"
+
+// SkipCompression marks the responses of a route as never compressed, such as streaming exports the clients read
+// as they are written
+func SkipCompression(c *fiber.Ctx) error {
+	c.Locals(skipCompressionKey, true)
+	return c.Next()
+}
+
+// CompressionMiddleware compresses the responses with the coding the client weighs highest in Accept-Encoding:
+// Brotli when enabled, gzip or deflate. Responses of the skipped paths or routes, streamed responses, encoded
+// responses, small bodies and content types that do not compress, such as images, are sent as they are.
+func CompressionMiddleware(cfg config.MiddlewareConfig) fiber.Handler {
+	level := cfg.CompressionLevel
+	if level < fasthttp.CompressBestSpeed || level > fasthttp.CompressBestCompression {
+		log.Warn().Int("level", level).Msg("Compression level out of range, using the fastest")
+		level = fasthttp.CompressBestSpeed
+	}
+	brotliLevel := cfg.CompressionBrotliLevel
+	if brotliLevel < fasthttp.CompressBrotliBestSpeed || brotliLevel > fasthttp.CompressBrotliBestCompression {
+		log.Warn().Int("level", brotliLevel).Msg("Brotli level out of range, using the default")
+		brotliLevel = fasthttp.CompressBrotliDefaultCompression
+	}
+
+	encodings := []string{EncodingGzip, EncodingDeflate}
+	if cfg.CompressionBrotli {
+		encodings = append([]string{EncodingBrotli}, encodings...)
+	}
+
+	var skipPaths []string
+	for _, path := range cfg.CompressionSkipPaths {
+		if path = strings.TrimSpace(path); path != "" {
+			skipPaths = append(skipPaths, path)
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		for _, path := range skipPaths {
+			if strings.HasPrefix(c.Path(), path) {
+				return c.Next()
+			}
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		resp := c.Response()
+		if skip, _ := c.Locals(skipCompressionKey).(bool); skip || resp.IsBodyStream() ||
+			len(resp.Header.ContentEncoding()) > 0 || !compressible(string(resp.Header.ContentType())) {
+			return nil
+		}
+
+		// The body depends on Accept-Encoding whether or not this one is compressed
+		c.Vary(fiber.HeaderAcceptEncoding)
+
+		body := resp.Body()
+		if len(body) < compressionMinLength {
+			return nil
+		}
+
+		var compressed []byte
+		encoding := negotiateEncoding(c.Get(fiber.HeaderAcceptEncoding), encodings)
+		switch encoding {
+		case EncodingBrotli:
+			compressed = fasthttp.AppendBrotliBytesLevel(nil, body, brotliLevel)
+		case EncodingGzip:
+			compressed = fasthttp.AppendGzipBytesLevel(nil, body, level)
+		case EncodingDeflate:
+			compressed = fasthttp.AppendDeflateBytesLevel(nil, body, level)
+		default:
+			return nil
+		}
+
+		resp.SetBodyRaw(compressed)
+		resp.Header.Set(fiber.HeaderContentEncoding, encoding)
+		return nil
+	}
+}
+
+// negotiateEncoding returns the coding of the given ones an Accept-Encoding header weighs highest, the first of
+// them among equal weights, or an empty string when the header accepts none of them
+func negotiateEncoding(acceptEncoding string, encodings []string) string {
+	weights := make(map[string]float64)
+	wildcard := -1.0
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			weight = q
+		}
+
+		if name == "*" {
+			wildcard = weight
+		} else {
+			weights[name] = weight
+		}
+	}
+
+	chosen, best := "", 0.0
+	for _, encoding := range encodings {
+		weight, ok := weights[encoding]
+		if !ok {
+			weight = wildcard
+		}
+		if weight > best {
+			chosen, best = encoding, weight
+		}
+	}
+	return chosen
+}
+
+// compressible reports whether a content type is text, JSON or XML, which compress well, unlike images or
+// archives that are compressed already
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case fiber.MIMEApplicationJSON, fiber.MIMEApplicationXML, fiber.MIMEApplicationJavaScript,
+		"application/x-ndjson":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// anonymousClient is the client API calls without an authenticated user are metered under
+const anonymousClient = "anonymous"
+
+// MeteringMiddleware creates a middleware recording billable API calls and active users.
+// It records after the handler chain so the user set by the auth middleware is known.
+func MeteringMiddleware(meteringUseCase usecase.MeteringUseCase) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		if userID, ok := c.Locals("user_id").(uuid.UUID); ok {
+			meteringUseCase.RecordActiveUser(userID)
+			meteringUseCase.RecordAPICall("user:" + userID.String())
+		} else {
+			meteringUseCase.RecordAPICall(anonymousClient)
+		}
+
+		return err
+	}
+}
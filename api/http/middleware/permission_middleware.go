@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// PermissionMiddleware creates a middleware to check the caller is granted a permission, by the role of their
+// access token, the roles assigned to them on top of it or the roles of their teams. API keys and service accounts
+// are limited to the role they act with, so a key without the admin scope never gains the permissions of its owner's
+// assigned or team roles.
+func PermissionMiddleware(roleUseCase usecase.RoleUseCase, permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// The role is set by AuthMiddleware from the access token claims
+		userID, ok := c.Locals("user_id").(uuid.UUID)
+		role, _ := c.Locals("user_role").(string)
+		if !ok || role == "" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Access denied",
+			})
+		}
+
+		roles := []string{role}
+		_, apiKey := c.Locals("api_key_id").(uuid.UUID)
+		_, clientToken := c.Locals("client_id").(string)
+		if !apiKey && !clientToken {
+			granted, err := roleUseCase.GrantedRoles(c.Context(), userID)
+			if err != nil {
+				log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list granted roles")
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "Failed to check permissions",
+				})
+			}
+			roles = append(roles, granted...)
+		}
+
+		granted, err := roleUseCase.HasPermission(c.Context(), roles, permission)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Str("permission", permission).Msg("Failed to check permission")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to check permissions",
+			})
+		}
+		if !granted {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":      "Insufficient permissions",
+				"code":       "PERMISSION_REQUIRED",
+				"permission": permission,
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/infrastructure/querybudget"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// QueryBudgetMiddleware counts the repository queries of each request against the configured budget. A request
+// exceeding it is logged with its queries by call site, the most repeated first, so N+1 patterns such as a user
+// fetched twice stand out. Outside production, FailRequests also answers such requests with 500, once the handler
+// has run, so they fail the tests and manual checks catching them.
+func QueryBudgetMiddleware(cfg config.QueryBudgetConfig, production bool) fiber.Handler {
+	fail := cfg.FailRequests && !production
+
+	return func(c *fiber.Ctx) error {
+		budget := querybudget.New(cfg.MaxQueries, cfg.MaxDuration)
+		querybudget.Attach(c.Context(), budget)
+
+		err := c.Next()
+		if !budget.Exceeded() {
+			return err
+		}
+
+		queries, duration := budget.Queries()
+		log.Warn().
+			Str("method", c.Method()).
+			Str("path", c.Path()).
+			Int("queries", queries).
+			Dur("duration", duration).
+			Int("max_queries", cfg.MaxQueries).
+			Dur("max_duration", cfg.MaxDuration).
+			Strs("calls", budget.Report()).
+			Msg("Request exceeded its query budget")
+
+		if !fail {
+			return err
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Request exceeded its query budget",
+			"code":  "QUERY_BUDGET_EXCEEDED",
+		})
+	}
+}
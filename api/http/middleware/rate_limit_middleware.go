@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/chats/go-user-api/internal/infrastructure/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Rate limit headers sent on every limited response
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+
+	apiPrefix         = "/api/v1/"
+	defaultRouteGroup = "default"
+	authRouteGroup    = "auth"
+)
+
+// RateLimitStatus describes the budget of a caller for a route group
+type RateLimitStatus struct {
+	Route     string `json:"route"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	Reset     int64  `json:"reset"`
+}
+
+// RateLimiter limits requests per caller and route group.
+// Authenticated callers are limited per user, anonymous callers per IP.
+type RateLimiter struct {
+	limiter            ratelimit.Limiter
+	tokenService       service.TokenService
+	enforcementUseCase usecase.EnforcementUseCase
+	config             config.RateLimitConfig
+}
+
+// NewRateLimiter creates a new RateLimiter
+func NewRateLimiter(limiter ratelimit.Limiter, tokenService service.TokenService, enforcementUseCase usecase.EnforcementUseCase, config config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		limiter:            limiter,
+		tokenService:       tokenService,
+		enforcementUseCase: enforcementUseCase,
+		config:             config,
+	}
+}
+
+// Middleware creates a middleware enforcing the budgets and exposing them through X-RateLimit-* headers.
+// Budgets of policies in shadow mode are counted but neither exposed nor enforced.
+func (rl *RateLimiter) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		caller, userID := rl.caller(c)
+		group := routeGroup(c.Path())
+		policy := rl.policy(group)
+		shadow := rl.enforcementUseCase.Mode(policy) == config.PolicyModeShadow
+
+		result, err := rl.limiter.Allow(c.Context(), caller+":"+group, rl.limit(group), rl.config.Window)
+		if err != nil {
+			// Fail open, losing the limiter must not take the API down
+			log.Warn().Err(err).Str("caller", caller).Msg("Failed to apply rate limit")
+			return c.Next()
+		}
+
+		if !shadow {
+			setRateLimitHeaders(c, result)
+		}
+
+		if !result.Allowed && rl.enforcementUseCase.Enforce(c.Context(), policy, caller, userID, result) {
+			log.Warn().Str("caller", caller).Str("route", group).Msg("Rate limit reached")
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(secondsUntilReset(result)))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many requests, please try again later",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// Status returns the current budgets of the caller for every route group of the API
+func (rl *RateLimiter) Status(c *fiber.Ctx) ([]RateLimitStatus, error) {
+	caller, _ := rl.caller(c)
+
+	statuses := []RateLimitStatus{}
+	for _, group := range routeGroups(c.App()) {
+		result, err := rl.limiter.Peek(c.Context(), caller+":"+group, rl.limit(group), rl.config.Window)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, RateLimitStatus{
+			Route:     apiPrefix + group,
+			Limit:     result.Limit,
+			Remaining: result.Remaining,
+			Reset:     result.Reset.Unix(),
+		})
+	}
+
+	return statuses, nil
+}
+
+// caller identifies the caller by user when a valid access token is sent, by IP otherwise.
+// The user is also returned, uuid.Nil for anonymous callers.
+func (rl *RateLimiter) caller(c *fiber.Ctx) (string, uuid.UUID) {
+	parts := strings.Split(c.Get("Authorization"), " ")
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		claims, err := rl.tokenService.ValidateToken(parts[1])
+		if err == nil && claims.TokenType == entity.AccessToken {
+			return "user:" + claims.UserID.String(), claims.UserID
+		}
+	}
+
+	return "ip:" + c.IP(), uuid.Nil
+}
+
+// limit returns the number of requests allowed per window for a route group
+func (rl *RateLimiter) limit(group string) int {
+	if group == authRouteGroup {
+		return rl.config.AuthMax
+	}
+	return rl.config.Max
+}
+
+// policy returns the enforcement policy of a route group
+func (rl *RateLimiter) policy(group string) string {
+	if group == authRouteGroup {
+		return entity.EnforcementPolicyRateLimitAuth
+	}
+	return entity.EnforcementPolicyRateLimit
+}
+
+// routeGroup returns the route group of a path, e.g. "users" for /api/v1/users/:id
+func routeGroup(path string) string {
+	if !strings.HasPrefix(path, apiPrefix) {
+		return defaultRouteGroup
+	}
+
+	group := strings.SplitN(strings.TrimPrefix(path, apiPrefix), "/", 2)[0]
+	if group == "" {
+		return defaultRouteGroup
+	}
+	return group
+}
+
+// routeGroups returns the route groups registered on the app
+func routeGroups(app *fiber.App) []string {
+	seen := map[string]bool{}
+	groups := []string{}
+	for _, route := range app.GetRoutes(true) {
+		group := routeGroup(route.Path)
+		if group == defaultRouteGroup || strings.HasPrefix(group, ":") || seen[group] {
+			continue
+		}
+		seen[group] = true
+		groups = append(groups, group)
+	}
+
+	sort.Strings(groups)
+	return groups
+}
+
+// setRateLimitHeaders exposes the state of a budget to the client
+func setRateLimitHeaders(c *fiber.Ctx, result ratelimit.Result) {
+	c.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+	c.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+	c.Set(HeaderRateLimitReset, strconv.Itoa(secondsUntilReset(result)))
+}
+
+// secondsUntilReset returns the number of seconds until a budget resets, rounded up
+func secondsUntilReset(result ratelimit.Result) int {
+	return int(math.Ceil(result.RetryAfter().Seconds()))
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReadOnlyMiddleware creates a middleware rejecting mutating requests while read-only mode is enabled.
+// Requests whose path starts with one of the exempt prefixes are always let through.
+func ReadOnlyMiddleware(maintenanceUseCase usecase.MaintenanceUseCase, exemptPrefixes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+
+		for _, prefix := range exemptPrefixes {
+			if strings.HasPrefix(c.Path(), prefix) {
+				return c.Next()
+			}
+		}
+
+		if maintenanceUseCase.IsReadOnly(c.Context()) {
+			c.Set(fiber.HeaderRetryAfter, "60")
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "Service is in read-only mode, please try again later",
+				"code":  "READ_ONLY",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/chats/go-user-api/internal/infrastructure/requestcache"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestCacheMiddleware gives each request a cache of the entities it reads, so the repeated lookups of an entity
+// within the request are served from memory
+func RequestCacheMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestcache.Attach(c.Context(), requestcache.New())
+		return c.Next()
+	}
+}
@@ -0,0 +1,261 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// JSON response key naming
+const (
+	JSONNamingSnakeCase = "snake_case"
+	JSONNamingCamelCase = "camelCase"
+)
+
+// JSON response envelopes
+const (
+	EnvelopeNone   = "none"
+	EnvelopeLegacy = "legacy"
+)
+
+// ResponseFormatMiddleware rewrites the JSON responses for the clients of an older user service: the keys in
+// camelCase rather than snake_case, and the body wrapped in the legacy envelope, {"success": true, "data": ...} or
+// {"success": false, "error": {"message": ..., "code": ...}}. Deployments choose the default with HTTP_JSON_NAMING
+// and HTTP_ENVELOPE, and clients override it with the naming and envelope parameters of the JSON media type they
+// accept, e.g. Accept: application/json; naming=camelCase; envelope=legacy. Responses of the skipped paths, such as
+// documents following a standard, are left as they are.
+func ResponseFormatMiddleware(cfg config.HTTPConfig, skipPaths ...string) fiber.Handler {
+	naming, envelope := JSONNamingSnakeCase, EnvelopeNone
+	if n, ok := parseJSONNaming(cfg.JSONNaming); ok {
+		naming = n
+	} else {
+		log.Warn().Str("naming", cfg.JSONNaming).Msg("Unknown JSON naming, responses use snake_case")
+	}
+	if e, ok := parseEnvelope(cfg.Envelope); ok {
+		envelope = e
+	} else {
+		log.Warn().Str("envelope", cfg.Envelope).Msg("Unknown response envelope, responses are not wrapped")
+	}
+
+	return func(c *fiber.Ctx) error {
+		for _, path := range skipPaths {
+			if strings.HasPrefix(c.Path(), path) {
+				return c.Next()
+			}
+		}
+
+		// The body depends on the Accept header, so caches must not serve it to other clients
+		c.Vary(fiber.HeaderAccept)
+		naming, envelope := responseFormat(c.Get(fiber.HeaderAccept), naming, envelope)
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if naming == JSONNamingSnakeCase && envelope == EnvelopeNone {
+			return nil
+		}
+
+		body := c.Response().Body()
+		if len(body) == 0 || !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+
+		var err error
+		if envelope == EnvelopeLegacy {
+			if body, err = legacyEnvelope(body, c.Response().StatusCode()); err != nil {
+				log.Warn().Err(err).Str("path", c.Path()).Msg("Failed to wrap response in legacy envelope")
+				return nil
+			}
+		}
+		if naming == JSONNamingCamelCase {
+			if body, err = camelCaseKeys(body); err != nil {
+				log.Warn().Err(err).Str("path", c.Path()).Msg("Failed to convert response keys to camelCase")
+				return nil
+			}
+		}
+
+		c.Response().SetBody(body)
+		return nil
+	}
+}
+
+// responseFormat returns the naming and envelope requested by the first JSON media range of an Accept header
+// naming either, or the defaults
+func responseFormat(accept, naming, envelope string) (string, string) {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+		switch strings.ToLower(strings.TrimSpace(params[0])) {
+		case fiber.MIMEApplicationJSON, "application/*", "*/*":
+		default:
+			continue
+		}
+
+		requested := false
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(param, "=")
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "naming":
+				if n, ok := parseJSONNaming(value); ok {
+					naming, requested = n, true
+				}
+			case "envelope":
+				if e, ok := parseEnvelope(value); ok {
+					envelope, requested = e, true
+				}
+			}
+		}
+		if requested {
+			break
+		}
+	}
+	return naming, envelope
+}
+
+// parseJSONNaming returns the naming a value stands for, the case of the value aside
+func parseJSONNaming(value string) (string, bool) {
+	switch strings.ToLower(value) {
+	case "snake_case", "snake":
+		return JSONNamingSnakeCase, true
+	case "camelcase", "camel":
+		return JSONNamingCamelCase, true
+	}
+	return "", false
+}
+
+// parseEnvelope returns the envelope a value stands for
+func parseEnvelope(value string) (string, bool) {
+	switch strings.ToLower(value) {
+	case EnvelopeNone:
+		return EnvelopeNone, true
+	case EnvelopeLegacy:
+		return EnvelopeLegacy, true
+	}
+	return "", false
+}
+
+// legacyEnvelope wraps a JSON body in the legacy envelope. Error bodies move into the error object, their error
+// message renamed to message.
+func legacyEnvelope(body []byte, status int) ([]byte, error) {
+	if status < fiber.StatusBadRequest {
+		return json.Marshal(map[string]any{
+			"success": true,
+			"data":    json.RawMessage(body),
+		})
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	if message, ok := fields["error"]; ok {
+		fields["message"] = message
+		delete(fields, "error")
+	}
+
+	return json.Marshal(map[string]any{
+		"success": false,
+		"error":   fields,
+	})
+}
+
+// camelCaseKeys converts the object keys of a JSON document from snake_case to camelCase, leaving the order of
+// the keys and the values as they are
+func camelCaseKeys(body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	// Each container counts its tokens, the even tokens of an object being its keys
+	type container struct {
+		object bool
+		tokens int
+	}
+	var stack []container
+	var out bytes.Buffer
+	out.Grow(len(body))
+
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteRune(rune(delim))
+			continue
+		}
+
+		key := false
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			key = top.object && top.tokens%2 == 0
+			switch {
+			case top.object && !key:
+				out.WriteByte(':')
+			case top.tokens > 0:
+				out.WriteByte(',')
+			}
+			top.tokens++
+		}
+
+		switch value := token.(type) {
+		case json.Delim:
+			stack = append(stack, container{object: value == '{'})
+			out.WriteRune(rune(value))
+		case string:
+			if key {
+				value = camelCase(value)
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(encoded)
+		case json.Number:
+			out.WriteString(value.String())
+		case bool:
+			if value {
+				out.WriteString("true")
+			} else {
+				out.WriteString("false")
+			}
+		case nil:
+			out.WriteString("null")
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// camelCase converts a snake_case name to camelCase, leading underscores included as they are
+func camelCase(name string) string {
+	trimmed := strings.TrimLeft(name, "_")
+	if !strings.Contains(trimmed, "_") {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(name[:len(name)-len(trimmed)])
+	for i, part := range strings.Split(trimmed, "_") {
+		if i == 0 || part == "" {
+			b.WriteString(part)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(part)
+		b.WriteRune(unicode.ToUpper(r))
+		b.WriteString(part[size:])
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ScopedOrgID returns the organization an org admin is limited to, or nil when the caller is not scoped.
+// An org admin without an organization is scoped to uuid.Nil, which matches no user.
+func ScopedOrgID(c *fiber.Ctx) *uuid.UUID {
+	if role, _ := c.Locals("user_role").(string); role != entity.UserRoleOrgAdmin {
+		return nil
+	}
+
+	orgID, _ := c.Locals("org_id").(uuid.UUID)
+	return &orgID
+}
+
+// OrgScopeMiddleware restricts org admins to the members of their organization on routes targeting a user by :id.
+// Platform admins, regular users and users acting on themselves are not restricted here.
+func OrgScopeMiddleware(userUseCase usecase.UserUseCase) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		orgID := ScopedOrgID(c)
+		if orgID == nil {
+			return c.Next()
+		}
+
+		// Malformed IDs are reported by the handler
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Next()
+		}
+		if userID, ok := c.Locals("user_id").(uuid.UUID); ok && userID == id {
+			return c.Next()
+		}
+
+		user, err := userUseCase.GetByID(c.Context(), id)
+		if err != nil {
+			if errors.Is(err, usecase.ErrUserNotFound) {
+				return c.Next()
+			}
+			log.Error().Err(err).Str("id", id.String()).Msg("Failed to resolve organization scope")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to check organization scope",
+			})
+		}
+
+		if user.OrgID == nil || *user.OrgID != *orgID {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "User is outside of your organization",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Throttle creates a middleware limiting the attempts of a sign in or registration per client IP and account to
+// max per RATE_LIMIT_THROTTLE_WINDOW. A brute force on an account is slowed down without affecting the other
+// clients behind the same address, whatever the budget of the auth route group. A max of 0 disables the throttle.
+func (rl *RateLimiter) Throttle(policy string, max int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if max <= 0 {
+			return c.Next()
+		}
+
+		subject := "ip:" + c.IP() + ":account:" + throttleAccount(c.Body())
+		result, err := rl.limiter.Allow(c.Context(), "throttle:"+policy+":"+subject, max, rl.config.ThrottleWindow)
+		if err != nil {
+			// Fail open, like the rate limits
+			log.Warn().Err(err).Str("policy", policy).Msg("Failed to apply throttle")
+			return c.Next()
+		}
+
+		if !result.Allowed && rl.enforcementUseCase.Enforce(c.Context(), policy, subject, uuid.Nil, result) {
+			log.Warn().Str("policy", policy).Str("ip", c.IP()).Msg("Throttle reached")
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(secondsUntilReset(result)))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many attempts, please try again later",
+				"code":  "THROTTLED",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// throttleAccount returns a digest of the account a sign in or registration body targets, so the budgets do not
+// store emails in the cache. Bodies without an account share the budget of their address.
+func throttleAccount(body []byte) string {
+	var req struct {
+		Identifier string `json:"identifier"`
+		Email      string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "-"
+	}
+
+	account := req.Identifier
+	if account == "" {
+		account = req.Email
+	}
+	if account = entity.NormalizeEmail(account); account == "" {
+		return "-"
+	}
+
+	sum := sha256.Sum256([]byte(account))
+	return hex.EncodeToString(sum[:12])
+}
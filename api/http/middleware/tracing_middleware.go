@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"github.com/chats/go-user-api/internal/infrastructure/tracing"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/chats/go-user-api/api/http/middleware"
+
+// TracingMiddleware starts a server span for each request, continuing the trace of the caller when the request
+// carries its trace context. The span is attached to the request context, so the spans of the repositories and of
+// the MongoDB commands run by the handlers are its children.
+func TracingMiddleware() fiber.Handler {
+	tracer := otel.Tracer(tracerName)
+
+	return func(c *fiber.Ctx) error {
+		// The strings of the request are reused once it is answered, while the span is exported later
+		path := utils.CopyString(c.Path())
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+		ctx, span := tracer.Start(ctx, c.Method()+" "+path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Method()),
+				attribute.String("url.path", path),
+				attribute.String("client.address", utils.CopyString(c.IP())),
+			),
+		)
+		defer span.End()
+
+		tracing.Attach(c.Context(), span)
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// The route is only known once matched
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(attribute.String("http.route", route))
+
+		status := c.Response().StatusCode()
+		if fiberErr, ok := err.(*fiber.Error); ok {
+			status = fiberErr.Code
+		} else if err != nil {
+			status = fiber.StatusInternalServerError
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if err != nil {
+			span.RecordError(err)
+		}
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+
+		return err
+	}
+}
+
+// headerCarrier reads and writes the trace context in the headers of a request
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+// Get returns the value of a header
+func (h headerCarrier) Get(key string) string {
+	return utils.CopyString(h.c.Get(key))
+}
+
+// Set sets the value of a header
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+// Keys returns the names of the headers
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h.c.GetReqHeaders()))
+	for key := range h.c.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/repository/inmem"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddlewareParentsRepositorySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	users := repository.NewTracedUserRepository(inmem.NewUserRepository())
+	app := fiber.New()
+	app.Use(TracingMiddleware())
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		// Handlers pass the request context to the usecases, as is
+		users.GetByID(c.Context(), uuid.New())
+		return c.SendStatus(fiber.StatusNotFound)
+	})
+
+	callerTraceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(fiber.MethodGet, "/users/"+uuid.NewString(), nil)
+	req.Header.Set("traceparent", "00-"+callerTraceID+"-00f067aa0ba902b7-01")
+	if _, err := app.Test(req, -1); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want the repository and request spans", len(spans))
+	}
+	query, request := spans[0], spans[1]
+
+	if request.Name() != "GET /users/:id" {
+		t.Errorf("request span name: got %q, want %q", request.Name(), "GET /users/:id")
+	}
+	if request.SpanKind() != trace.SpanKindServer {
+		t.Errorf("request span kind: got %v, want %v", request.SpanKind(), trace.SpanKindServer)
+	}
+	if got := request.SpanContext().TraceID().String(); got != callerTraceID {
+		t.Errorf("request trace: got %s, want the trace of the caller %s", got, callerTraceID)
+	}
+	if query.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("repository span %q is not a child of the request span", query.Name())
+	}
+}
@@ -1,17 +1,16 @@
 package router
 
 import (
-	"time"
-
 	"github.com/chats/go-user-api/api/http/handler"
+	"github.com/chats/go-user-api/api/http/middleware"
 	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
 	"github.com/gofiber/contrib/fiberzerolog"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/etag"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
-	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/rs/zerolog/log"
@@ -22,7 +21,33 @@ func Setup(
 	cfg *config.Config,
 	userHandler *handler.UserHandler,
 	authHandler *handler.AuthHandler,
+	adminHandler *handler.AdminHandler,
+	roleHandler *handler.RoleHandler,
+	organizationHandler *handler.OrganizationHandler,
+	keyHandler *handler.KeyHandler,
+	sessionHandler *handler.SessionHandler,
+	invitationHandler *handler.InvitationHandler,
+	eventHandler *handler.EventHandler,
+	webhookHandler *handler.WebhookHandler,
+	suppressionHandler *handler.SuppressionHandler,
+	oidcHandler *handler.OIDCHandler,
+	deviceHandler *handler.DeviceHandler,
+	referralHandler *handler.ReferralHandler,
+	waitlistHandler *handler.WaitlistHandler,
+	oauthHandler *handler.OAuthHandler,
+	directoryHandler *handler.DirectoryHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	inactivityHandler *handler.InactivityHandler,
+	serviceAccountHandler *handler.ServiceAccountHandler,
+	adminNoteHandler *handler.AdminNoteHandler,
+	teamHandler *handler.TeamHandler,
+	anomalyHandler *handler.AnomalyHandler,
+	scimHandler *handler.SCIMHandler,
 	authMiddleware fiber.Handler,
+	oidcTokenMiddleware fiber.Handler,
+	readOnlyMiddleware fiber.Handler,
+	rateLimiter *middleware.RateLimiter,
+	meteringMiddleware fiber.Handler,
 ) *fiber.App {
 	// Create new Fiber app
 	app := fiber.New(fiber.Config{
@@ -42,6 +67,11 @@ func Setup(
 		app.Use(requestid.New())
 	}
 
+	// Add tracing middleware, the request spans are the parents of the spans of the repositories
+	if cfg.Middleware.EnableTracing {
+		app.Use(middleware.TracingMiddleware())
+	}
+
 	// Add recover middleware
 	if cfg.Middleware.EnableRecover {
 		app.Use(recover.New(recover.Config{
@@ -54,8 +84,8 @@ func Setup(
 		app.Use(cors.New(cors.Config{
 			AllowOrigins:     "*",
 			AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-			AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Request-ID",
-			ExposeHeaders:    "Content-Length, X-Request-ID",
+			AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Request-ID, " + middleware.APIKeyHeader + ", " + cfg.Session.CSRFHeaderName,
+			ExposeHeaders:    "Content-Length, X-Request-ID, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After",
 			AllowCredentials: true,
 			MaxAge:           86400, // 24 hours
 		}))
@@ -68,19 +98,12 @@ func Setup(
 
 	// Add rate limiter middleware
 	if cfg.Middleware.EnableRateLimiter {
-		app.Use(limiter.New(limiter.Config{
-			Max:        100,
-			Expiration: 1 * time.Minute,
-			KeyGenerator: func(c *fiber.Ctx) string {
-				return c.IP()
-			},
-			LimitReached: func(c *fiber.Ctx) error {
-				log.Warn().Str("ip", c.IP()).Msg("Rate limit reached")
-				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-					"error": "Too many requests, please try again later",
-				})
-			},
-		}))
+		app.Use(rateLimiter.Middleware())
+	}
+
+	// Add metering middleware
+	if meteringMiddleware != nil {
+		app.Use(meteringMiddleware)
 	}
 
 	// Add ETag middleware
@@ -90,21 +113,76 @@ func Setup(
 
 	// Add compression middleware
 	if cfg.Middleware.EnableCompression {
-		app.Use(compress.New(compress.Config{
-			Level: compress.LevelBestSpeed,
-		}))
+		app.Use(middleware.CompressionMiddleware(cfg.Middleware))
 	}
 
-	// Setup routes
-	api := app.Group("/api")
-	v1 := api.Group("/v1")
+	// Add request cache middleware, so the users read by a request are read once
+	app.Use(middleware.RequestCacheMiddleware())
+
+	// Add query budget middleware, innermost so it counts the queries of the handlers
+	if cfg.QueryBudget.Enabled {
+		app.Use(middleware.QueryBudgetMiddleware(cfg.QueryBudget, cfg.IsProduction()))
+	}
+
+	// Setup routes, their JSON responses following the naming and envelope negotiated with the client. Event
+	// schemas describe the events as published, so they are left as they are.
+	api := app.Group("/api", middleware.ResponseFormatMiddleware(cfg.HTTP, "/api/v1/events/schemas"))
+	v1 := api.Group("/v1", readOnlyMiddleware)
+
+	// Protect requests authenticated by the session cookie against CSRF, login is exempt
+	// so a stale session cookie never locks a browser out
+	if cfg.Session.CookieMode {
+		v1.Use(middleware.CSRFMiddleware(cfg.Session, "/api/v1/auth/login", "/api/v1/auth/password-change", "/api/v1/auth/passkeys/login/begin", "/api/v1/auth/passkeys/login/finish"))
+	}
 
 	// Register health check route
 	api.Get("/health", userHandler.HealthCheck)
 
+	// Register metrics route
+	if cfg.Metrics.Enabled {
+		app.Get(cfg.Metrics.Path, metrics.Handler())
+	}
+
+	// Throttle the sign in and registration attempts per client IP and account, ahead of their handlers
+	v1.Post("/auth/login", rateLimiter.Throttle(entity.EnforcementPolicyLoginThrottle, cfg.RateLimit.ThrottleLoginMax))
+	v1.Post("/users/register", rateLimiter.Throttle(entity.EnforcementPolicyRegisterThrottle, cfg.RateLimit.ThrottleRegisterMax))
+
 	// Register user/auth routes
-	userHandler.RegisterRoutes(v1, authMiddleware)
+	adminGroup := adminHandler.RegisterRoutes(v1, authMiddleware)
+	userHandler.RegisterRoutes(v1, adminGroup, authMiddleware)
 	authHandler.RegisterRoutes(v1, authMiddleware)
+	deviceHandler.RegisterRoutes(v1, authMiddleware)
+	roleHandler.RegisterRoutes(v1, authMiddleware, adminGroup)
+	organizationHandler.RegisterRoutes(v1, adminGroup, authMiddleware)
+	keyHandler.RegisterRoutes(app, adminGroup)
+	sessionHandler.RegisterRoutes(v1, authMiddleware, adminGroup)
+	invitationHandler.RegisterRoutes(v1, adminGroup)
+	eventHandler.RegisterRoutes(v1)
+	webhookHandler.RegisterRoutes(adminGroup)
+	suppressionHandler.RegisterRoutes(v1, adminGroup)
+	referralHandler.RegisterRoutes(v1, authMiddleware, adminGroup)
+	waitlistHandler.RegisterRoutes(adminGroup)
+	oauthHandler.RegisterRoutes(v1, authMiddleware)
+	directoryHandler.RegisterRoutes(adminGroup)
+	inactivityHandler.RegisterRoutes(adminGroup)
+	adminNoteHandler.RegisterRoutes(adminGroup)
+	teamHandler.RegisterRoutes(v1, authMiddleware, adminGroup)
+	anomalyHandler.RegisterRoutes(adminGroup)
+	if apiKeyHandler != nil {
+		apiKeyHandler.RegisterRoutes(v1, authMiddleware, adminGroup)
+	}
+	if serviceAccountHandler != nil {
+		serviceAccountHandler.RegisterRoutes(v1, adminGroup)
+	}
+	if oidcHandler != nil {
+		oidcHandler.RegisterRoutes(app, authMiddleware, oidcTokenMiddleware)
+	}
+	if scimHandler != nil {
+		scimHandler.RegisterRoutes(app, readOnlyMiddleware, adminGroup)
+	}
+	if cfg.Middleware.EnableRateLimiter {
+		handler.NewRateLimitHandler(rateLimiter).RegisterRoutes(v1)
+	}
 
 	// 404 Handler
 	app.Use(func(c *fiber.Ctx) error {
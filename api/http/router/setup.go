@@ -7,6 +7,11 @@ import (
 	"github.com/chats/go-user-api/internal/domain/repository"
 	"github.com/chats/go-user-api/internal/domain/service"
 	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/chats/go-user-api/internal/infrastructure/eventbus"
+	"github.com/chats/go-user-api/internal/infrastructure/mailer"
+	"github.com/chats/go-user-api/internal/infrastructure/pwned"
+	"github.com/chats/go-user-api/internal/infrastructure/ratelimit"
+	"github.com/chats/go-user-api/internal/infrastructure/secrets"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 )
@@ -16,6 +21,27 @@ func SetupHandlers(
 	cfg *config.Config,
 	userRepo repository.UserRepository,
 	tokenRepo repository.TokenRepository,
+	auditRepo repository.AuditRepository,
+	roleRepo repository.RoleRepository,
+	permissionGroupRepo repository.PermissionGroupRepository,
+	permissionRepo repository.PermissionRepository,
+	roleAssignmentRepo repository.RoleAssignmentRepository,
+	roleChangeRepo repository.RoleChangeRequestRepository,
+	teamRepo repository.TeamRepository,
+	teamMemberRepo repository.TeamMemberRepository,
+	breakGlassRepo repository.BreakGlassRepository,
+	ipDenialRepo repository.IPDenialRepository,
+	loginCountryRepo repository.LoginCountryRepository,
+	limiter ratelimit.Limiter,
+	dedupRepo repository.DedupRepository,
+	suppressionRepo repository.SuppressionRepository,
+	orgRepo repository.OrganizationRepository,
+	statusHistoryRepo repository.StatusHistoryRepository,
+	referralRepo repository.ReferralRepository,
+	passkeyRepo repository.PasskeyRepository,
+	passkeyCeremonyRepo repository.PasskeyCeremonyRepository,
+	oauthIdentityRepo repository.OAuthIdentityRepository,
+	adminNoteRepo repository.AdminNoteRepository,
 ) (*handler.UserHandler, *handler.AuthHandler, fiber.Handler) {
 	// Create token service
 	tokenService, err := service.NewTokenService(cfg.Security)
@@ -23,16 +49,42 @@ func SetupHandlers(
 		log.Fatal().Err(err).Msg("Failed to create token service")
 	}
 
+	// Create notification service
+	notificationService := service.NewNotificationService(mailer.NewMailer(cfg.Mailer), suppressionRepo)
+
+	// Create event service
+	eventService, err := service.NewEventService(eventbus.NewLogBus())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create event service")
+	}
+
+	nameService := service.NewNameService(cfg.Name)
+	passwordHasher, err := service.NewPasswordHasher(cfg.Security)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create password hasher")
+	}
+	passwordService := service.NewPasswordService(pwned.NewChecker(cfg.Password.BreachCheckURL, cfg.App.Name, cfg.Password.BreachCheckTimeout), cfg.Password)
+
 	// Create use cases
-	userUseCase := usecase.NewUserUseCase(userRepo)
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenRepo, tokenService)
+	notificationUseCase := usecase.NewNotificationUseCase(auditRepo, orgRepo, notificationService, nameService, cfg.App.PublicURL, cfg.Branding)
+	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionGroupRepo, userRepo, permissionRepo, roleAssignmentRepo, teamRepo, teamMemberRepo, auditRepo)
+	organizationUseCase := usecase.NewOrganizationUseCase(orgRepo, userRepo, tokenRepo, auditRepo, eventService, cfg.Branding, cfg.Organization, nil)
+	userUseCase := usecase.NewUserUseCase(userRepo, auditRepo, notificationUseCase, service.NewPolicyService(cfg.Policy), passwordService, passwordHasher, roleUseCase, dedupRepo, eventService, orgRepo, organizationUseCase, statusHistoryRepo, tokenRepo, referralRepo, passkeyRepo, oauthIdentityRepo, adminNoteRepo, teamMemberRepo, loginCountryRepo, cfg.Security, cfg.Register, cfg.Deletion)
+	// Security events are not streamed to a SIEM by the legacy setup
+	securityEventUseCase := usecase.NewSecurityEventUseCase(auditRepo, nil, dedupRepo, nil, config.SIEMConfig{})
+	enforcementUseCase := usecase.NewEnforcementUseCase(auditRepo, securityEventUseCase, limiter, cfg.RateLimit, cfg.Lockout, cfg.Reset)
+	anomalyUseCase := usecase.NewAnomalyUseCase(ipDenialRepo, loginCountryRepo, userRepo, auditRepo, limiter, cfg.Anomaly)
+	breakGlassUseCase := usecase.NewBreakGlassUseCase(breakGlassRepo, userRepo, tokenRepo, auditRepo, dedupRepo, notificationUseCase, passwordHasher, secrets.NewStore(cfg.BreakGlass.SecretsDir), cfg.BreakGlass)
+	authUseCase := usecase.NewAuthUseCase(userRepo, tokenRepo, auditRepo, tokenService, passwordService, passwordHasher, notificationUseCase, enforcementUseCase, securityEventUseCase, statusHistoryRepo, passkeyRepo, passkeyCeremonyRepo, service.NewPasskeyService(cfg.Passkey), oauthIdentityRepo, orgRepo, breakGlassUseCase, anomalyUseCase, cfg.Security, cfg.Reset, cfg.Passkey, cfg.OAuth)
+
+	roleApprovalUseCase := usecase.NewRoleApprovalUseCase(roleChangeRepo, userRepo, auditRepo, userUseCase, roleUseCase, cfg.RoleApproval)
 
 	// Create handlers
-	userHandler := handler.NewUserHandler(userUseCase)
-	authHandler := handler.NewAuthHandler(authUseCase)
+	userHandler := handler.NewUserHandler(userUseCase, roleApprovalUseCase, nameService, passwordService, cfg.Register)
+	authHandler := handler.NewAuthHandler(authUseCase, nameService, cfg.Session, cfg.Anomaly)
 
 	// Create auth middleware
-	authMiddleware := middleware.AuthMiddleware(authUseCase)
+	authMiddleware := middleware.AuthMiddleware(authUseCase, nil, nil)
 
 	return userHandler, authHandler, authMiddleware
 }
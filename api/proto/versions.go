@@ -0,0 +1,93 @@
+// Package proto holds the versioned gRPC contracts of the service and the registry of the
+// contract versions it serves. Generated stubs live in one package per contract version.
+package proto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	authv1 "github.com/chats/go-user-api/api/proto/auth/v1"
+	commonv1 "github.com/chats/go-user-api/api/proto/common/v1"
+	userv1 "github.com/chats/go-user-api/api/proto/user/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrUnsupportedVersion is returned for calls to a contract version the service does not serve
+var ErrUnsupportedVersion = errors.New("unsupported contract version")
+
+// VersionStatus enum
+const (
+	VersionStable     = "stable"
+	VersionDeprecated = "deprecated" // Still served, clients should migrate to the next version
+)
+
+// Version is a contract version served by the service
+type Version struct {
+	Package string `json:"package"` // Protobuf package, e.g. user.v1
+	Status  string `json:"status"`
+
+	file protoreflect.FileDescriptor
+}
+
+// versions lists the contract versions served, a version is only removed after being deprecated
+var versions = []Version{
+	{Package: "common.v1", Status: VersionStable, file: commonv1.File_common_v1_common_proto},
+	{Package: "user.v1", Status: VersionStable, file: userv1.File_user_v1_user_proto},
+	{Package: "auth.v1", Status: VersionStable, file: authv1.File_auth_v1_auth_proto},
+}
+
+// SupportedVersions returns the contract versions served
+func SupportedVersions() []Version {
+	return append([]Version(nil), versions...)
+}
+
+// LookupVersion returns the served version of a protobuf package, nil if it is not served
+func LookupVersion(pkg string) *Version {
+	for i := range versions {
+		if versions[i].Package == pkg {
+			version := versions[i]
+			return &version
+		}
+	}
+	return nil
+}
+
+// CheckMethod checks that a gRPC method, e.g. /user.v1.UserService/GetUser, belongs to a served contract version
+func CheckMethod(fullMethod string) (*Version, error) {
+	service := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2)[0]
+	dot := strings.LastIndex(service, ".")
+	if dot < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, fullMethod)
+	}
+
+	version := LookupVersion(service[:dot])
+	if version == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, service[:dot])
+	}
+	return version, nil
+}
+
+// Verify checks that the registry matches the generated stubs linked into the binary:
+// every version is backed by its generated package and declares a versioned package name
+func Verify() error {
+	for _, version := range versions {
+		if version.file == nil {
+			return fmt.Errorf("contract %s has no generated stubs", version.Package)
+		}
+		if got := string(version.file.Package()); got != version.Package {
+			return fmt.Errorf("contract %s is generated with package %s", version.Package, got)
+		}
+		if !isVersionedPackage(version.Package) {
+			return fmt.Errorf("contract package %s is not versioned", version.Package)
+		}
+	}
+	return nil
+}
+
+// isVersionedPackage reports whether a package name ends with a version suffix such as v1 or v2beta1
+func isVersionedPackage(pkg string) bool {
+	parts := strings.Split(pkg, ".")
+	last := parts[len(parts)-1]
+	return len(parts) > 1 && len(last) > 1 && last[0] == 'v' && last[1] >= '1' && last[1] <= '9'
+}
@@ -0,0 +1,158 @@
+// Command breakglass manages the break-glass accounts, the emergency admin accounts whose credentials are sealed in
+// the secrets backend configured with BREAK_GLASS_SECRETS_DIR.
+//
+// Sealing creates the account with a generated password written to the secrets backend only. Rotating replaces the
+// credential and revokes the sessions of the account, which the service also does on its own once a used account
+// reaches BREAK_GLASS_ROTATE_AFTER.
+//
+//	go run ./cmd/breakglass seal -username breakglass-1 -email security@example.com
+//	go run ./cmd/breakglass rotate -username breakglass-1
+//	go run ./cmd/breakglass list
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/domain/usecase"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/chats/go-user-api/internal/infrastructure/mailer"
+	"github.com/chats/go-user-api/internal/infrastructure/secrets"
+	"github.com/chats/go-user-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// usage is printed when the command is missing or unknown
+const usage = `usage: breakglass <command> [flags]
+
+commands:
+  seal -username NAME -email ADDRESS   create a break-glass account and seal its credential
+  rotate -username NAME                replace the credential of a break-glass account and revoke its sessions
+  list                                 list the break-glass accounts`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	username := flags.String("username", "", "username of the break-glass account")
+	email := flags.String("email", "", "email of the break-glass account, a monitored security mailbox")
+	flags.Parse(os.Args[2:])
+
+	// Initialize logger
+	logger.InitLogger()
+
+	if err := run(context.Background(), config.LoadConfig(), os.Args[1], *username, *email); err != nil {
+		log.Fatal().Err(err).Str("command", os.Args[1]).Msg("Failed to manage break-glass accounts")
+		os.Exit(1)
+	}
+}
+
+// run connects to the database and cache of the service and runs a command
+func run(ctx context.Context, cfg *config.Config, command, username, email string) error {
+	switch command {
+	case "seal", "rotate", "list":
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+	if command != "list" && username == "" {
+		return fmt.Errorf("-username is required")
+	}
+	if command == "seal" && email == "" {
+		return fmt.Errorf("-email is required")
+	}
+	if cfg.Database.Type == config.MemoryDB {
+		return fmt.Errorf("the in-memory database keeps no data across processes, configure a persistent database")
+	}
+
+	database, err := db.NewDatabaseFactory().Create(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to create database: %v", err)
+	}
+	if err := database.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %v", err)
+	}
+	defer database.Close(ctx)
+
+	cacheClient, err := cache.NewCacheFactory().Create(cfg.Cache)
+	if err != nil {
+		return fmt.Errorf("failed to create cache: %v", err)
+	}
+	if err := cacheClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to cache: %v", err)
+	}
+	defer cacheClient.Close()
+
+	// Security events are buffered for the service to send to the SIEM, the command sends none itself
+	auditRepo := usecase.NewSecurityEventUseCase(
+		repository.NewAuditRepository(database),
+		repository.NewSecurityEventRepository(database),
+		repository.NewDedupRepository(cacheClient),
+		nil,
+		cfg.SIEM,
+	)
+	notificationUseCase := usecase.NewNotificationUseCase(
+		auditRepo,
+		repository.NewOrganizationRepository(database),
+		service.NewNotificationService(mailer.NewMailer(cfg.Mailer), repository.NewSuppressionRepository(database)),
+		service.NewNameService(cfg.Name),
+		cfg.App.PublicURL,
+		cfg.Branding,
+	)
+	passwordHasher, err := service.NewPasswordHasher(cfg.Security)
+	if err != nil {
+		return fmt.Errorf("failed to create password hasher: %v", err)
+	}
+	breakGlassUseCase := usecase.NewBreakGlassUseCase(
+		repository.NewBreakGlassRepository(database),
+		repository.NewUserRepository(database, cacheClient, cfg.Cache.StaleTTL),
+		// Never caches tokens, but publishes the revoked sessions for the service instances to evict them
+		repository.NewCachedTokenRepository(repository.NewTokenRepository(cacheClient), cacheClient, 0, 0),
+		auditRepo,
+		repository.NewDedupRepository(cacheClient),
+		notificationUseCase,
+		passwordHasher,
+		secrets.NewStore(cfg.BreakGlass.SecretsDir),
+		cfg.BreakGlass,
+	)
+
+	switch command {
+	case "seal":
+		account, err := breakGlassUseCase.Seal(ctx, username, email)
+		if err != nil {
+			return err
+		}
+		log.Info().Str("username", account.Username).Str("secret_name", account.SecretName).Msg("Break-glass account sealed")
+	case "rotate":
+		// Rotations by operators are attributed to the system, like the automatic ones
+		account, err := breakGlassUseCase.Rotate(ctx, uuid.Nil, username)
+		if err != nil {
+			return err
+		}
+		log.Info().Str("username", account.Username).Str("secret_name", account.SecretName).Msg("Break-glass credential rotated")
+	case "list":
+		accounts, err := breakGlassUseCase.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			used := "sealed"
+			if account.UsedAt != nil {
+				used = "used " + account.UsedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s\t%s\t%s\tsealed %s\t%s\n", account.Username, account.UserID, account.SecretName, account.SealedAt.Format(time.RFC3339), used)
+		}
+	}
+	return nil
+}
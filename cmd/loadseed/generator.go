@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	firstNames = []string{
+		"Alice", "Amir", "Ana", "Ben", "Carla", "Chen", "David", "Elena", "Emeka", "Fatima",
+		"George", "Hana", "Ivan", "Jade", "Kenji", "Laura", "Luca", "Maya", "Mohammed", "Nina",
+		"Omar", "Priya", "Quinn", "Rosa", "Sam", "Sofia", "Tariq", "Uma", "Victor", "Yuki",
+	}
+	lastNames = []string{
+		"Adams", "Baker", "Costa", "Dubois", "Evans", "Fischer", "Garcia", "Haddad", "Ito", "Jensen",
+		"Kim", "Lopez", "Martin", "Nakamura", "Okafor", "Patel", "Rossi", "Schmidt", "Silva", "Tanaka",
+		"Usman", "Vargas", "Wang", "Weber", "Xu", "Yilmaz", "Zhang",
+	}
+	emailDomains = []string{"example.com", "example.org", "example.net", "mail.test"}
+	tags         = []string{"beta", "vip", "fraud-review", "newsletter", "churn-risk"}
+)
+
+// userGenerator generates fake users with a realistic mix of statuses, roles, tags and verification
+type userGenerator struct {
+	rand           *rand.Rand
+	hashedPassword string
+	run            string // distinguishes the usernames and emails of separate runs
+	seq            int
+	now            time.Time
+}
+
+// newUserGenerator creates a generator, the same seed generates the same names, roles, statuses and tags
+func newUserGenerator(seed int64, hashedPassword string) *userGenerator {
+	return &userGenerator{
+		rand:           rand.New(rand.NewSource(seed)),
+		hashedPassword: hashedPassword,
+		run:            uuid.NewString()[:8],
+		now:            time.Now(),
+	}
+}
+
+// next generates the next user
+func (g *userGenerator) next() *entity.User {
+	g.seq++
+
+	firstName := pick(g.rand, firstNames)
+	lastName := pick(g.rand, lastNames)
+	username := fmt.Sprintf("%s.%s.%s%d", strings.ToLower(firstName), strings.ToLower(lastName), g.run, g.seq)
+
+	// Spread creation dates over the past year, so sorting and pagination see realistic data
+	createdAt := g.now.Add(-time.Duration(g.rand.Int63n(int64(365 * 24 * time.Hour))))
+
+	user := &entity.User{
+		ID:            uuid.New(),
+		Email:         username + "@" + pick(g.rand, emailDomains),
+		Username:      username,
+		Password:      g.hashedPassword,
+		FirstName:     firstName,
+		LastName:      lastName,
+		Role:          g.role(),
+		Status:        g.status(),
+		EmailVerified: g.rand.Float64() < 0.7,
+		PhoneVerified: g.rand.Float64() < 0.3,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+
+	for _, tag := range tags {
+		if g.rand.Float64() < 0.1 {
+			user.Tags = append(user.Tags, tag)
+		}
+	}
+
+	return user
+}
+
+// status picks a status, most users are active
+func (g *userGenerator) status() string {
+	switch n := g.rand.Float64(); {
+	case n < 0.85:
+		return entity.UserStatusActive
+	case n < 0.95:
+		return entity.UserStatusInactive
+	default:
+		return entity.UserStatusBlocked
+	}
+}
+
+// role picks a role, administrators are rare
+func (g *userGenerator) role() string {
+	switch n := g.rand.Float64(); {
+	case n < 0.9:
+		return entity.UserRoleUser
+	case n < 0.99:
+		return entity.UserRoleMember
+	default:
+		return entity.UserRoleAdmin
+	}
+}
+
+// pick returns a random element of values
+func pick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
@@ -0,0 +1,145 @@
+// Command loadseed fills the configured database with fake users for load testing.
+//
+// Users are inserted in batches through the user repository, so the cache bookkeeping
+// of the service stays consistent. All users share the same password to keep hashing
+// out of the way, bcrypt would otherwise dominate the run.
+//
+//	go run ./cmd/loadseed -n 100000 -batch 1000 -warm
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/chats/go-user-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// warmListPages is the number of list pages fetched when pre-warming the cache
+const warmListPages = 10
+
+func main() {
+	count := flag.Int("n", 10000, "number of users to generate")
+	batchSize := flag.Int("batch", 500, "number of users inserted per batch")
+	password := flag.String("password", "password123", "password shared by the generated users")
+	warm := flag.Bool("warm", false, "pre-warm the cache with the generated users and the first list pages")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed, reuse it to generate the same data")
+	flag.Parse()
+
+	// Initialize logger
+	logger.InitLogger()
+
+	if *count < 1 || *batchSize < 1 {
+		log.Fatal().Msg("-n and -batch must be positive")
+	}
+
+	if err := run(context.Background(), config.LoadConfig(), *count, *batchSize, *password, *warm, *seed); err != nil {
+		log.Fatal().Err(err).Msg("Failed to seed users")
+		os.Exit(1)
+	}
+}
+
+// run generates and inserts the users, then optionally pre-warms the cache
+func run(ctx context.Context, cfg *config.Config, count, batchSize int, password string, warm bool, seed int64) error {
+	if cfg.Database.Type == config.MemoryDB {
+		return fmt.Errorf("the in-memory database keeps no data across processes, configure a persistent database")
+	}
+
+	database, err := db.NewDatabaseFactory().Create(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to create database: %v", err)
+	}
+	if err := database.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %v", err)
+	}
+	defer database.Close(ctx)
+
+	cacheClient, err := cache.NewCacheFactory().Create(cfg.Cache)
+	if err != nil {
+		return fmt.Errorf("failed to create cache: %v", err)
+	}
+	if err := cacheClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to cache: %v", err)
+	}
+	defer cacheClient.Close()
+
+	userRepo := repository.NewUserRepository(database, cacheClient, cfg.Cache.StaleTTL)
+
+	// Hashed like the service would, so seeded users cost as much to sign in as real ones
+	passwordHasher, err := service.NewPasswordHasher(cfg.Security)
+	if err != nil {
+		return fmt.Errorf("failed to create password hasher: %v", err)
+	}
+	hashedPassword, err := passwordHasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %v", err)
+	}
+
+	generator := newUserGenerator(seed, hashedPassword)
+	log.Info().Int("users", count).Int("batch", batchSize).Int64("seed", seed).Msg("Seeding users")
+
+	start := time.Now()
+	var ids []uuid.UUID
+	for inserted := 0; inserted < count; {
+		users := make([]*entity.User, 0, min(batchSize, count-inserted))
+		for len(users) < cap(users) {
+			users = append(users, generator.next())
+		}
+
+		if err := userRepo.CreateMany(ctx, users); err != nil {
+			return err
+		}
+		inserted += len(users)
+
+		if warm {
+			for _, user := range users {
+				ids = append(ids, user.ID)
+			}
+		}
+
+		elapsed := time.Since(start)
+		log.Info().
+			Int("inserted", inserted).
+			Dur("elapsed", elapsed).
+			Float64("users_per_second", float64(inserted)/elapsed.Seconds()).
+			Msg("Batch inserted")
+	}
+
+	if warm {
+		if err := warmCache(ctx, userRepo, ids); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Int("users", count).Dur("elapsed", time.Since(start)).Msg("Seeding completed")
+	return nil
+}
+
+// warmCache reads the users back and fetches the first list pages, so they are served from the cache
+func warmCache(ctx context.Context, userRepo repository.UserRepository, ids []uuid.UUID) error {
+	start := time.Now()
+	for _, id := range ids {
+		if _, err := userRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	for page := 1; page <= warmListPages; page++ {
+		if _, _, err := userRepo.List(ctx, page, 10, entity.UserListOptions{}); err != nil {
+			return err
+		}
+	}
+
+	log.Info().Int("users", len(ids)).Int("pages", warmListPages).Dur("elapsed", time.Since(start)).Msg("Cache warmed")
+	return nil
+}
@@ -1,21 +1,40 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
 	"os"
+	"time"
 
 	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
 	"github.com/chats/go-user-api/pkg/logger"
 	"github.com/chats/go-user-api/server"
 	"github.com/rs/zerolog/log"
 )
 
 func main() {
+	selfTest := flag.Bool("selftest", false, "check the database, cache, tokens, mailer and keys, print a JSON report and exit, non-zero when a check failed")
+	flag.Parse()
+
+	// Keep stdout for the self-test report, everything logged goes to stderr
+	report := os.Stdout
+	if *selfTest {
+		os.Stdout = os.Stderr
+	}
+
 	// Initialize logger
 	logger.InitLogger()
 
 	// Load configuration
 	cfg := config.LoadConfig()
 
+	if *selfTest {
+		os.Exit(runSelfTest(cfg, report))
+	}
+
 	log.Info().Msg("Starting service...")
 
 	// Create and set up server
@@ -32,3 +51,39 @@ func main() {
 	}
 
 }
+
+// runSelfTest sets the server up without serving, prints the self-test report to out and returns the exit code.
+// A failed set up is reported as a failed setup check, so the report stays machine-readable.
+func runSelfTest(cfg *config.Config, out io.Writer) int {
+	s := server.NewServer(cfg)
+
+	var report *entity.SelfTestReport
+	start := time.Now()
+	if err := s.Setup(); err != nil {
+		report = &entity.SelfTestReport{
+			Status:     entity.SelfTestStatusFail,
+			StartedAt:  start,
+			DurationMs: time.Since(start).Milliseconds(),
+			Checks: []*entity.SelfTestCheck{{
+				Name:       "setup",
+				Status:     entity.SelfTestStatusFail,
+				DurationMs: time.Since(start).Milliseconds(),
+				Error:      err.Error(),
+			}},
+		}
+	} else {
+		report = s.SelfTest(context.Background())
+		s.Close()
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Error().Err(err).Msg("Failed to print self-test report")
+		return 1
+	}
+	if !report.Passed() {
+		return 1
+	}
+	return 0
+}
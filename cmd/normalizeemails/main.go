@@ -0,0 +1,169 @@
+// Command normalizeemails lowercases the emails and recovery emails stored before addresses were normalized.
+//
+// Candidates are found with a query on the users collection, then updated through the user repository, so the
+// cache entries and email index keys of the service stay consistent. An address whose lowercase form already
+// belongs to another account is a duplicate created before normalization: it is reported and left unchanged,
+// to be merged or renamed by an administrator.
+//
+//	go run ./cmd/normalizeemails -dry-run
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/chats/go-user-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// errDuplicates is returned when some addresses could not be normalized because of duplicate accounts
+var errDuplicates = errors.New("duplicate accounts left unchanged, see the warnings above")
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the changes without applying them")
+	flag.Parse()
+
+	// Initialize logger
+	logger.InitLogger()
+
+	if err := run(context.Background(), config.LoadConfig(), *dryRun); err != nil {
+		log.Fatal().Err(err).Msg("Failed to normalize emails")
+		os.Exit(1)
+	}
+}
+
+// run normalizes the emails of every user with an uppercase letter or surrounding spaces in an address
+func run(ctx context.Context, cfg *config.Config, dryRun bool) error {
+	if cfg.Database.Type == config.MemoryDB {
+		return fmt.Errorf("the in-memory database keeps no data across processes, configure a persistent database")
+	}
+
+	database, err := db.NewDatabaseFactory().Create(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to create database: %v", err)
+	}
+	if err := database.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %v", err)
+	}
+	defer database.Close(ctx)
+
+	client, ok := database.GetInstance().(*mongo.Client)
+	if !ok {
+		return fmt.Errorf("unsupported database type %s", cfg.Database.Type)
+	}
+
+	cacheClient, err := cache.NewCacheFactory().Create(cfg.Cache)
+	if err != nil {
+		return fmt.Errorf("failed to create cache: %v", err)
+	}
+	if err := cacheClient.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect to cache: %v", err)
+	}
+	defer cacheClient.Close()
+
+	userRepo := repository.NewUserRepository(database, cacheClient, cfg.Cache.StaleTTL)
+
+	ids, err := findCandidates(ctx, client)
+	if err != nil {
+		return err
+	}
+	log.Info().Int("users", len(ids)).Bool("dry_run", dryRun).Msg("Normalizing emails")
+
+	var updated, duplicates int
+	for _, id := range ids {
+		user, err := userRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			continue // Deleted since the query
+		}
+
+		changed, err := normalizeUser(ctx, userRepo, user)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			duplicates++
+			continue
+		}
+
+		if !dryRun {
+			if err := userRepo.Update(ctx, user); err != nil {
+				return err
+			}
+		}
+		updated++
+	}
+
+	log.Info().Int("updated", updated).Int("duplicates", duplicates).Bool("dry_run", dryRun).Msg("Emails normalized")
+	if duplicates > 0 {
+		return errDuplicates
+	}
+	return nil
+}
+
+// findCandidates returns the IDs of the users with an email or recovery email that is not normalized
+func findCandidates(ctx context.Context, client *mongo.Client) ([]uuid.UUID, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	notNormalized := primitive.Regex{Pattern: `[A-Z]|^\s|\s$`}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"email": notNormalized},
+		bson.M{"recovery_email": notNormalized},
+	}}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users to normalize: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var ids []uuid.UUID
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID uuid.UUID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode user ID: %w", err)
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, cursor.Err()
+}
+
+// normalizeUser normalizes the addresses of a user, it reports false and leaves the user unchanged when the
+// normalized email belongs to another account
+func normalizeUser(ctx context.Context, userRepo repository.UserRepository, user *entity.User) (bool, error) {
+	email := entity.NormalizeEmail(user.Email)
+	if email != user.Email {
+		owner, err := userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			return false, err
+		}
+		if owner != nil && owner.ID != user.ID {
+			log.Warn().
+				Str("user_id", user.ID.String()).
+				Str("duplicate_of", owner.ID.String()).
+				Msg("Email differs from another account by case only, left unchanged")
+			return false, nil
+		}
+	}
+
+	user.Email = email
+	user.RecoveryEmail = entity.NormalizeEmail(user.RecoveryEmail)
+	return true, nil
+}
@@ -12,6 +12,8 @@ const (
 	PostgreSQL DatabaseType = "postgresql"
 	// MongoDB database type
 	MongoDB DatabaseType = "mongodb"
+	// MemoryDB keeps data in process memory, for tests and demos
+	MemoryDB DatabaseType = "memory"
 )
 
 // CacheType represents the type of cache
@@ -22,24 +24,73 @@ const (
 	Redis CacheType = "redis"
 	// Memcached cache type
 	Memcached CacheType = "memcached"
+	// MemoryCache keeps data in process memory, for tests and demos
+	MemoryCache CacheType = "memory"
+)
+
+// PolicyMode represents how a rate limit or lockout policy is applied
+type PolicyMode string
+
+const (
+	// PolicyModeEnforce rejects the requests exceeding the policy
+	PolicyModeEnforce PolicyMode = "enforce"
+	// PolicyModeShadow only records the requests exceeding the policy, to tune its thresholds before enforcing it
+	PolicyModeShadow PolicyMode = "shadow"
 )
 
 // Config contains all application configuration
 type Config struct {
-	App        AppConfig
-	HTTP       HTTPConfig
-	GRPC       GRPCConfig
-	Database   DatabaseConfig
-	Cache      CacheConfig
-	Jaeger     JaegerConfig
-	Security   SecurityConfig
-	Middleware MiddlewareConfig
+	App            AppConfig
+	HTTP           HTTPConfig
+	GRPC           GRPCConfig
+	Database       DatabaseConfig
+	Cache          CacheConfig
+	Tracing        TracingConfig
+	Security       SecurityConfig
+	Session        SessionConfig
+	Middleware     MiddlewareConfig
+	Metrics        MetricsConfig
+	Watchdog       WatchdogConfig
+	RateLimit      RateLimitConfig
+	Lockout        LockoutConfig
+	Anomaly        AnomalyConfig
+	Reset          PasswordResetConfig
+	Password       PasswordConfig
+	Metering       MeteringConfig
+	Mailer         MailerConfig
+	Policy         PolicyConfig
+	Invitation     InvitationConfig
+	Register       RegistrationConfig
+	Deletion       DeletionConfig
+	Webhook        WebhookConfig
+	Name           NameConfig
+	OIDC           OIDCConfig
+	Device         DeviceConfig
+	Passkey        PasskeyConfig
+	OAuth          OAuthConfig
+	Directory      DirectoryConfig
+	SCIM           SCIMConfig
+	Lifecycle      LifecycleConfig
+	APIKey         APIKeyConfig
+	Inactivity     InactivityConfig
+	ServiceAccount ServiceAccountConfig
+	Quota          QuotaConfig
+	Branding       BrandingConfig
+	Organization   OrganizationConfig
+	RoleApproval   RoleApprovalConfig
+	RoleGrant      RoleGrantConfig
+	BreakGlass     BreakGlassConfig
+	SIEM           SIEMConfig
+	SelfTest       SelfTestConfig
+	QueryBudget    QueryBudgetConfig
 }
 
 // AppConfig contains general application configuration
 type AppConfig struct {
 	Name        string
 	Environment string
+	ReadOnly    bool
+	PublicURL   string // Base URL of the links sent to users
 }
 
 // HTTPConfig contains HTTP server configuration
@@ -50,10 +101,13 @@ type HTTPConfig struct {
 	IdleTimeout       time.Duration
 	EnablePrefork     bool
 	EnableCompression bool
+	JSONNaming        string // snake_case or camelCase response keys
+	Envelope          string // none or legacy
 }
 
 // GRPCConfig contains gRPC server configuration
 type GRPCConfig struct {
+	Enabled          bool
 	Port             int
 	MaxRecvMsgSize   int
 	MaxSendMsgSize   int
@@ -70,6 +124,9 @@ type CacheConfig struct {
 	Port     int
 	Password string
 	DB       int // For Redis
+
+	// StaleTTL is how long users stay cached to be served stale while the database fails, 0 disables it
+	StaleTTL time.Duration
 }
 
 // DatabaseConfig contains database configuration
@@ -83,19 +140,26 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
-// JaegerConfig contains Jaeger configuration
-type JaegerConfig struct {
-	Host        string
-	Port        int
-	ServiceName string
+// TracingConfig contains the OpenTelemetry tracing configuration, spans are exported over OTLP/HTTP
+type TracingConfig struct {
 	Enabled     bool
+	Endpoint    string  // host:port of the collector, such as Jaeger on 4318
+	Insecure    bool    // Plain HTTP to the collector rather than HTTPS
+	ServiceName string  // Service name of the exported spans
+	SampleRatio float64 // Share of the traces started here sampled, the traces sampled upstream are always kept
 }
 
 // SecurityConfig contains security configuration
 type SecurityConfig struct {
 	JWTSecret          string
 	JWTExpirationHours int
-	BcryptCost         int
+
+	// Password hashing, the hashes of either algorithm verify whichever one hashes the new passwords
+	PasswordHashAlgorithm string // bcrypt or argon2id
+	BcryptCost            int
+	Argon2Memory          int // KiB
+	Argon2Iterations      int
+	Argon2Parallelism     int
 
 	// PASETO related fields
 	PasetoPrivateKey string
@@ -104,6 +168,379 @@ type SecurityConfig struct {
 	// Token expiration settings
 	AccessTokenExpirationMinutes int
 	RefreshTokenExpirationDays   int
+
+	// RememberMeRefreshTokenExpirationDays is the lifetime of the refresh tokens of the logins asking to be
+	// remembered, remember me is ignored at 0
+	RememberMeRefreshTokenExpirationDays int
+
+	// AccessTokenSlidingExpiration extends the expiration of an access token on every request it authenticates,
+	// by AccessTokenExpirationMinutes from the request, until AccessTokenMaxLifetime after it was issued
+	AccessTokenSlidingExpiration bool
+	AccessTokenMaxLifetime       time.Duration
+
+	// CheckUserStatus rejects the access tokens of blocked, inactive and deleted users before they expire
+	CheckUserStatus bool
+
+	// In-process cache of the valid access tokens, disabled with a zero TTL
+	TokenCacheTTL  time.Duration // How long a validated token is trusted without reading the cache again
+	TokenCacheSize int           // Maximum number of tokens kept per instance
+
+	// Concurrent sessions of a user, unlimited at 0. A login beyond the limit is rejected or evicts the session
+	// refreshed least recently.
+	MaxActiveSessions  int
+	SessionLimitAction string // reject or evict_oldest
+
+	// Runtime signing key rotation, disabled when no encryption key is set
+	SigningKeyEncryptionKey   string // Hex-encoded AES key sealing the rotated private keys at rest
+	SigningKeyRefreshInterval time.Duration
+}
+
+// SessionConfig contains the refresh token transport configuration
+type SessionConfig struct {
+	// CookieMode sets the refresh token in an httpOnly cookie instead of the JSON body
+	CookieMode     bool
+	CookieName     string
+	CookieDomain   string
+	CookiePath     string
+	CookieSecure   bool
+	CookieSameSite string // Strict, Lax or None
+
+	// CSRF double-submit token, required on mutating requests authenticated by the cookie
+	CSRFCookieName string
+	CSRFHeaderName string
+}
+
+// MetricsConfig contains Prometheus metrics configuration
+type MetricsConfig struct {
+	Enabled     bool
+	Path        string
+	KPIEnabled  bool          // Export the business metrics aggregated over the users
+	KPIInterval time.Duration // Interval between two aggregations of the business metrics
+}
+
+// WatchdogConfig contains the database and cache liveness watchdog configuration
+type WatchdogConfig struct {
+	Enabled          bool
+	Interval         time.Duration
+	PingTimeout      time.Duration
+	FailureThreshold int
+	MaxBackoff       time.Duration
+}
+
+// RateLimitConfig contains rate limiter configuration
+type RateLimitConfig struct {
+	Max     int
+	AuthMax int
+	Window  time.Duration
+
+	// gRPC budgets, per calling client and per authenticated user
+	GRPCClientMax int
+	GRPCUserMax   int
+
+	// Throttles of the sign in and registration attempts per client IP and account, 0 disables them
+	ThrottleLoginMax    int
+	ThrottleRegisterMax int
+	ThrottleWindow      time.Duration
+
+	// Modes of the HTTP, auth route and gRPC budgets, and of the throttles
+	Mode         PolicyMode
+	AuthMode     PolicyMode
+	GRPCMode     PolicyMode
+	ThrottleMode PolicyMode
+}
+
+// LockoutConfig contains the configuration of the account lockout after failed logins
+type LockoutConfig struct {
+	MaxFailedLogins int           // Failed logins allowed per Window, 0 disables the lockout
+	Window          time.Duration // Period over which failed logins are counted
+	Mode            PolicyMode
+}
+
+// AnomalyConfig contains the thresholds of the automated responses to sign in anomalies
+type AnomalyConfig struct {
+	IPFailedLogins        int           // Failed logins from one IP across accounts per IPFailedLoginWindow before the IP is denied, 0 disables
+	IPFailedLoginWindow   time.Duration // Period over which the failed logins of an IP are counted
+	IPDenialDuration      time.Duration // Time an IP is denied sign in
+	CountryHeader         string        // Header set by the edge proxy with the country of the client, e.g. CF-IPCountry
+	AdminNewCountryStepUp bool          // Require admins signing in with a password from a new country to sign in with a passkey
+}
+
+// PasswordResetConfig contains the configuration of the forgot password flow
+type PasswordResetConfig struct {
+	Expiration  time.Duration // Lifetime of the reset tokens
+	MaxRequests int           // Reset emails per account per Window, 0 disables the limit
+	Window      time.Duration // Period over which reset requests are counted
+	Mode        PolicyMode
+}
+
+// PasswordConfig contains the checks of the passwords users choose, on registration, change, reset and invitation
+type PasswordConfig struct {
+	BreachCheckEnabled  bool          // Reject the passwords found in data breaches by the Have I Been Pwned range API
+	BreachCheckURL      string        // Base URL of the range API, only the first 5 characters of the SHA-1 hash are sent
+	BreachCheckTimeout  time.Duration // Timeout of a range query
+	BreachCheckFailOpen bool          // Accept the password when the range API fails, otherwise reject it until the API recovers
+	BreachCheckMinCount int           // Breaches a password must have appeared in to be rejected
+	MinScore            int           // Strength score from 0 to 4 new passwords must reach, 0 accepts any password
+}
+
+// MeteringConfig contains usage metering configuration
+type MeteringConfig struct {
+	Enabled       bool
+	FlushInterval time.Duration
+}
+
+// MailerConfig contains SMTP configuration, emails are only logged when Host is empty
+type MailerConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+
+	// EventsSecret authenticates the bounces and complaints posted by the email provider, the intake is disabled when empty
+	EventsSecret string
+}
+
+// PolicyConfig lists the actions gated on account verification
+type PolicyConfig struct {
+	EmailVerificationRequired []string
+	PhoneVerificationRequired []string
+}
+
+// RegistrationConfig contains the configuration of self-registration
+type RegistrationConfig struct {
+	// ConcealExistingAccounts answers registrations with the email of an existing account like successful ones,
+	// emailing the owner instead, so registering cannot tell which emails have accounts
+	ConcealExistingAccounts bool
+
+	// Waitlist puts self-registered users on a waitlist until an administrator or the quota job approves them
+	Waitlist bool
+	// WaitlistQuota is the number of waitlisted users the quota job approves every WaitlistInterval, zero to
+	// approve them by hand only
+	WaitlistQuota    int
+	WaitlistInterval time.Duration
+}
+
+// DeletionConfig contains the configuration of the deferred deletion of users
+type DeletionConfig struct {
+	RestorationWindow time.Duration // Delay before deleted users are purged, zero to purge them immediately
+	PurgeEnabled      bool          // Purge the users whose restoration window has elapsed from this instance
+	PurgeInterval     time.Duration // Interval between two passes over the users due for purge
+}
+
+// OIDCConfig contains the configuration of the OpenID Connect provider endpoints
+type OIDCConfig struct {
+	Enabled        bool
+	Issuer         string              // Issuer identifier of the ID tokens, the public URL by default
+	LoginURL       string              // Page of the relying party signing users in and approving authorization requests
+	Clients        map[string][]string // Redirect URIs registered by client ID
+	ClientSecrets  map[string]string   // Secrets of the confidential clients by client ID, other clients are public
+	CodeExpiration time.Duration       // Lifetime of the authorization codes
+}
+
+// DeviceConfig contains the configuration of the device authorization grant of headless clients
+type DeviceConfig struct {
+	VerificationURL string        // Page where users enter the code displayed by the device
+	CodeExpiration  time.Duration // Lifetime of the device and user codes
+	PollInterval    time.Duration // Minimum interval between two polls of the token endpoint by a device
+}
+
+// PasskeyConfig contains the configuration of the passkey (WebAuthn) sign in
+type PasskeyConfig struct {
+	RPID             string        // Domain the passkeys are bound to, the pages running the ceremonies must be on it
+	RPName           string        // Name of the service shown by authenticators
+	Origins          []string      // Origins of the pages running the ceremonies
+	Timeout          time.Duration // Lifetime of the registration and sign in challenges
+	UserVerification string        // required, preferred or discouraged
+}
+
+// OAuthConfig contains the configuration of the sign in with external OAuth2 providers
+type OAuthConfig struct {
+	CallbackURL     string        // Base URL of the callbacks, the provider name and /callback are appended
+	StateExpiration time.Duration // Time a user has to sign in with the provider
+	Google          OAuthProviderConfig
+	GitHub          OAuthProviderConfig
+}
+
+// OAuthProviderConfig contains the credentials of the application registered with an OAuth2 provider,
+// the provider is disabled without a client ID
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// DirectoryConfig contains the configuration of the reconciliation of the users against an upstream directory,
+// the source of truth of the accounts of the corporate email domains
+type DirectoryConfig struct {
+	LDAP              LDAPConfig
+	EmailDomains      []string      // Domains of the emails of the users the directory is authoritative for
+	SyncEnabled       bool          // Reconcile the users against the directory from this instance
+	SyncInterval      time.Duration // Interval between two reconciliations
+	DeactivateRemoved bool          // Deactivate the active users missing or disabled in the directory
+	CreateUsers       bool          // Invite the users of the enabled entries without a user
+	UpdateProfiles    bool          // Copy the names of the entries to their users
+	DryRun            bool          // Report the changes the reconciliation would make without making them
+}
+
+// SCIMConfig contains the SCIM provisioning of groups by the identity provider, driving the roles and
+// organizations of their members
+type SCIMConfig struct {
+	Enabled bool
+	Token   string // Bearer token of the identity provider, SCIM requests are refused without one
+
+	// GroupRoles and GroupOrgs map group display names to the role granted and the ID of the organization joined
+	GroupRoles map[string]string
+	GroupOrgs  map[string]string
+
+	DefaultRole    string   // Role of the users leaving every group granting a role
+	RolePrecedence []string // Roles by precedence, the first one wins when groups grant a user several roles
+}
+
+// LDAPConfig contains the connection to an LDAP or Active Directory server, the directory is disabled without a URL
+type LDAPConfig struct {
+	URL                string // ldap:// or ldaps:// URL of the server
+	StartTLS           bool   // Upgrade ldap:// connections to TLS
+	BindDN             string // DN of the service account searching the directory, anonymous when empty
+	BindPassword       string
+	BaseDN             string // Base of the search for user entries
+	UserFilter         string // Filter selecting the user entries
+	EmailAttribute     string
+	UsernameAttribute  string // Login of the entries, the username of imported users when free
+	FirstNameAttribute string
+	LastNameAttribute  string
+	Timeout            time.Duration // Timeout of the connection and of each request
+}
+
+// LifecycleConfig contains the configuration of the lifecycle emails, sent by rules to the users reaching a stage of
+// their lifecycle
+type LifecycleConfig struct {
+	Enabled             bool          // Run the lifecycle rules from this instance
+	Interval            time.Duration // Interval between two passes of the rules
+	TemplateDir         string        // Directory of the templates overriding the built-in ones, named after the rules
+	WelcomeEnabled      bool          // Send the welcome email
+	WelcomeDelay        time.Duration // Delay of the welcome email after the user is created
+	ReengagementEnabled bool          // Send the re-engagement email
+	ReengagementAfter   time.Duration // Inactivity after which the re-engagement email is sent
+}
+
+// APIKeyConfig contains the configuration of the API keys users call the API with
+type APIKeyConfig struct {
+	Enabled       bool          // Accept the X-API-Key header and let users manage their API keys
+	MaxPerUser    int           // API keys a user may hold at a time
+	MaxPerOrg     int           // API keys an organization may hold at a time
+	MaxLifetime   time.Duration // Longest lifetime of an API key, 0 lets keys never expire
+	DefaultScopes []string      // Scopes of the API keys created without scopes
+}
+
+// InactivityConfig contains the configuration of the inactivity policy, handling the accounts nobody signed in to
+// for a long time
+type InactivityConfig struct {
+	Enabled     bool          // Apply the policy from this instance
+	Interval    time.Duration // Interval between two passes of the policy
+	After       time.Duration // Inactivity after which the policy applies its action
+	WarnBefore  time.Duration // Notice given by email before the action, 0 to act without warning
+	Action      string        // flag or deactivate
+	ExemptRoles []string      // Roles the policy leaves alone
+}
+
+// ServiceAccountConfig contains the configuration of the service accounts of backend services
+type ServiceAccountConfig struct {
+	Enabled bool // Let administrators manage service accounts and issue tokens with the client credentials grant
+}
+
+// QuotaConfig contains the request quotas of API keys and service accounts
+type QuotaConfig struct {
+	Enabled        bool  // Count the requests of API keys and service accounts and refuse those over their quota
+	DefaultDaily   int64 // Requests a day of the keys and service accounts without a quota of their own, unlimited at 0
+	DefaultMonthly int64 // Requests a calendar month of the keys and service accounts without a quota of their own, unlimited at 0
+}
+
+// RoleApprovalConfig contains the configuration of the two-person rule on privileged role changes
+type RoleApprovalConfig struct {
+	Enabled    bool          // Hold privileged role changes until a second administrator approves them
+	Roles      []string      // Roles whose grant needs approval
+	Expiration time.Duration // Time left to decide on a request, 0 lets requests wait forever
+}
+
+// RoleGrantConfig contains the configuration of the roles assigned for a limited time
+type RoleGrantConfig struct {
+	ExpiryEnabled  bool          // Remove the expired role assignments from this instance, reads skip them anyway
+	ExpiryInterval time.Duration // Interval between two passes over the expired role assignments
+}
+
+// BreakGlassConfig contains the configuration of the emergency admin accounts whose credentials are sealed in the
+// secrets backend
+type BreakGlassConfig struct {
+	SecretsDir       string        // Directory the sealed credentials are written to, readable by the operators only
+	NotifyEmails     []string      // Security contacts alerted when a break-glass account signs in
+	RotateAfter      time.Duration // Time after the first sign in when the credential is rotated and the sessions revoked
+	RotationInterval time.Duration // Interval between two passes over the used break-glass accounts
+}
+
+// SIEMConfig contains the configuration of the stream of security events to the SIEM, separate from the application logs
+type SIEMConfig struct {
+	Enabled          bool
+	Sink             string        // syslog or http
+	Format           string        // cef or json
+	SyslogNetwork    string        // udp or tcp
+	SyslogAddress    string        // host:port of the syslog collector
+	HTTPURL          string        // URL the events are posted to
+	HTTPToken        string        // Bearer token of the HTTP collector, optional
+	Timeout          time.Duration // Timeout of a delivery to the sink
+	BatchSize        int           // Maximum number of events delivered at once
+	DispatchInterval time.Duration // Interval between two passes over the buffered events
+}
+
+// SelfTestConfig contains the configuration of the self-test run by --selftest and /admin/selftest
+type SelfTestConfig struct {
+	Timeout time.Duration // Timeout of each check
+}
+
+// QueryBudgetConfig contains the per-request budget of repository queries, catching N+1 patterns
+type QueryBudgetConfig struct {
+	Enabled     bool
+	MaxQueries  int           // Queries per request, unlimited at 0
+	MaxDuration time.Duration // Cumulative time of the queries of a request, unlimited at 0
+
+	// FailRequests answers the requests exceeding the budget with an error outside production, they are only
+	// logged otherwise
+	FailRequests bool
+}
+
+// BrandingConfig contains the default branding of the emails and hosted pages, used for the users outside
+// an organization and the settings an organization leaves empty
+type BrandingConfig struct {
+	ProductName  string // Product name shown in the emails, the application name by default
+	LogoURL      string // HTTPS URL of the logo shown in the HTML emails
+	SupportEmail string // Address users are invited to contact for help
+	PrimaryColor string // Hex color of the header of the HTML emails
+	AccentColor  string // Hex color of the buttons of the HTML emails
+}
+
+// OrganizationConfig contains the plans setting the member limits of organizations
+type OrganizationConfig struct {
+	Plans       map[string]int // Members allowed by plan name, 0 for unlimited
+	DefaultPlan string         // Plan of the organizations without one, no limit when empty or unknown
+}
+
+// InvitationConfig contains the configuration of the invitations of admin-created users
+type InvitationConfig struct {
+	Expiration time.Duration // Lifetime of the invitation links
+}
+
+// WebhookConfig contains the configuration of the delivery of events to webhook endpoints
+type WebhookConfig struct {
+	Enabled          bool
+	DispatchInterval time.Duration // Interval between two passes over the pending deliveries
+	Timeout          time.Duration // Timeout of a delivery attempt
+	MaxAttempts      int           // Attempts before a delivery is moved to the dead-letter queue
+	RetryBackoff     time.Duration // Delay before the first retry, doubled after each failed attempt
+}
+
+// NameConfig contains the name ordering conventions used to format the names of users
+type NameConfig struct {
+	DefaultLocale          string   // Locale of the users who did not set one
+	FamilyNameFirstLocales []string // Languages writing the family name before the given name
 }
 
 type MiddlewareConfig struct {
@@ -115,6 +552,11 @@ type MiddlewareConfig struct {
 	EnableRateLimiter bool
 	EnableETag        bool
 	EnableCompression bool
+
+	CompressionLevel       int      // gzip and deflate level, 1 (fastest) to 9 (smallest)
+	CompressionBrotli      bool     // Brotli for the clients accepting it
+	CompressionBrotliLevel int      // Brotli level, 0 (fastest) to 11 (smallest)
+	CompressionSkipPaths   []string // Path prefixes of the responses never compressed, such as streaming exports
 }
 
 // IsProduction returns true if the environment is production
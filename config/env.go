@@ -63,6 +63,67 @@ func getEnvAsSlice(key, sep string, fallback []string) []string {
 	return strings.Split(valStr, sep)
 }
 
+// getEnvAsClients returns the redirect URIs by client ID of the environment variable,
+// formatted as client1=uri1|uri2,client2=uri3
+func getEnvAsClients(key string) map[string][]string {
+	clients := make(map[string][]string)
+	for _, entry := range getEnvAsSlice(key, ",", nil) {
+		clientID, uris, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || clientID == "" || uris == "" {
+			log.Warn().Str("key", key).Str("entry", entry).Msg("Ignoring malformed OIDC client")
+			continue
+		}
+		clients[clientID] = append(clients[clientID], strings.Split(uris, "|")...)
+	}
+	return clients
+}
+
+// getEnvAsClientSecrets returns the secrets by client ID of the environment variable,
+// formatted as client1=secret1,client2=secret2
+func getEnvAsClientSecrets(key string) map[string]string {
+	secrets := make(map[string]string)
+	for _, entry := range getEnvAsSlice(key, ",", nil) {
+		clientID, secret, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || clientID == "" || secret == "" {
+			log.Warn().Str("key", key).Str("client_id", clientID).Msg("Ignoring malformed OIDC client secret")
+			continue
+		}
+		secrets[clientID] = secret
+	}
+	return secrets
+}
+
+// getEnvAsGroupMappings returns the values by group display name of the environment variable, formatted as
+// Engineering=admin,Support=user
+func getEnvAsGroupMappings(key string) map[string]string {
+	mappings := make(map[string]string)
+	for _, entry := range getEnvAsSlice(key, ",", nil) {
+		group, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		group, value = strings.TrimSpace(group), strings.TrimSpace(value)
+		if !found || group == "" || value == "" {
+			log.Warn().Str("key", key).Str("entry", entry).Msg("Ignoring malformed SCIM group mapping")
+			continue
+		}
+		mappings[group] = value
+	}
+	return mappings
+}
+
+// getEnvAsPlans returns the member limits by plan of the environment variable, formatted as free=10,team=100
+func getEnvAsPlans(key string) map[string]int {
+	plans := make(map[string]int)
+	for _, entry := range getEnvAsSlice(key, ",", nil) {
+		plan, limit, found := strings.Cut(strings.TrimSpace(entry), "=")
+		members, err := strconv.Atoi(limit)
+		if !found || plan == "" || err != nil || members < 0 {
+			log.Warn().Str("key", key).Str("entry", entry).Msg("Ignoring malformed organization plan")
+			continue
+		}
+		plans[plan] = members
+	}
+	return plans
+}
+
 // LoadEnv loads environment variables from .env file
 func LoadEnv() {
 	// Load .env file if it exists
@@ -83,6 +144,8 @@ func LoadConfig() *Config {
 		App: AppConfig{
 			Name:        getEnv("APP_NAME", "go-user-api"),
 			Environment: getEnv("APP_ENV", "development"),
+			ReadOnly:    getEnvAsBool("APP_READ_ONLY", false),
+			PublicURL:   getEnv("APP_PUBLIC_URL", "http://localhost:8080"),
 		},
 		HTTP: HTTPConfig{
 			Port:              getEnvAsInt("HTTP_PORT", 8080),
@@ -91,8 +154,11 @@ func LoadConfig() *Config {
 			IdleTimeout:       getEnvAsDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
 			EnablePrefork:     getEnvAsBool("HTTP_ENABLE_PREFORK", false),
 			EnableCompression: getEnvAsBool("HTTP_ENABLE_COMPRESSION", true),
+			JSONNaming:        getEnv("HTTP_JSON_NAMING", "snake_case"),
+			Envelope:          getEnv("HTTP_ENVELOPE", "none"),
 		},
 		GRPC: GRPCConfig{
+			Enabled:          getEnvAsBool("GRPC_ENABLED", true),
 			Port:             getEnvAsInt("GRPC_PORT", 50051),
 			MaxRecvMsgSize:   getEnvAsInt("GRPC_MAX_RECV_MSG_SIZE", 4*1024*1024), // 4MB
 			MaxSendMsgSize:   getEnvAsInt("GRPC_MAX_SEND_MSG_SIZE", 4*1024*1024), // 4MB
@@ -116,24 +182,50 @@ func LoadConfig() *Config {
 			Port:     getEnvAsInt("CACHE_PORT", 6379),
 			Password: getEnv("CACHE_PASSWORD", ""),
 			DB:       getEnvAsInt("CACHE_DB", 0),
+			StaleTTL: getEnvAsDuration("CACHE_STALE_TTL", 6*time.Hour),
 		},
-		Jaeger: JaegerConfig{
-			Host:        getEnv("JAEGER_HOST", "localhost"),
-			Port:        getEnvAsInt("JAEGER_PORT", 6831),
-			ServiceName: getEnv("JAEGER_SERVICE_NAME", "go-user-api"),
-			Enabled:     getEnvAsBool("JAEGER_ENABLED", true),
+		Tracing: TracingConfig{
+			Enabled:     getEnvAsBool("TRACING_ENABLED", false),
+			Endpoint:    getEnv("TRACING_ENDPOINT", "localhost:4318"),
+			Insecure:    getEnvAsBool("TRACING_INSECURE", true),
+			ServiceName: getEnv("TRACING_SERVICE_NAME", "go-user-api"),
+			SampleRatio: getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
 		},
 		Security: SecurityConfig{
-			JWTSecret:                    getEnv("JWT_SECRET", "your-secret-key"),
-			JWTExpirationHours:           getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
-			BcryptCost:                   getEnvAsInt("BCRYPT_COST", 12),
-			PasetoPrivateKey:             getEnv("PASETO_PRIVATE_KEY", ""),
-			PasetoPublicKey:              getEnv("PASETO_PUBLIC_KEY", ""),
-			AccessTokenExpirationMinutes: getEnvAsInt("ACCESS_TOKEN_EXPIRATION_MINUTES", 15),
-			RefreshTokenExpirationDays:   getEnvAsInt("REFRESH_TOKEN_EXPIRATION_DAYS", 7),
+			JWTSecret:                            getEnv("JWT_SECRET", "your-secret-key"),
+			JWTExpirationHours:                   getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
+			PasswordHashAlgorithm:                getEnv("PASSWORD_HASH_ALGORITHM", "bcrypt"),
+			BcryptCost:                           getEnvAsInt("BCRYPT_COST", 12),
+			Argon2Memory:                         getEnvAsInt("ARGON2_MEMORY", 64*1024),
+			Argon2Iterations:                     getEnvAsInt("ARGON2_ITERATIONS", 3),
+			Argon2Parallelism:                    getEnvAsInt("ARGON2_PARALLELISM", 4),
+			PasetoPrivateKey:                     getEnv("PASETO_PRIVATE_KEY", ""),
+			PasetoPublicKey:                      getEnv("PASETO_PUBLIC_KEY", ""),
+			AccessTokenExpirationMinutes:         getEnvAsInt("ACCESS_TOKEN_EXPIRATION_MINUTES", 15),
+			RefreshTokenExpirationDays:           getEnvAsInt("REFRESH_TOKEN_EXPIRATION_DAYS", 7),
+			RememberMeRefreshTokenExpirationDays: getEnvAsInt("REMEMBER_ME_REFRESH_TOKEN_EXPIRATION_DAYS", 30),
+			AccessTokenSlidingExpiration:         getEnvAsBool("ACCESS_TOKEN_SLIDING_EXPIRATION", false),
+			AccessTokenMaxLifetime:               getEnvAsDuration("ACCESS_TOKEN_MAX_LIFETIME", 12*time.Hour),
+			CheckUserStatus:                      getEnvAsBool("TOKEN_CHECK_USER_STATUS", true),
+			TokenCacheTTL:                        getEnvAsDuration("TOKEN_VALIDATION_CACHE_TTL", 0),
+			TokenCacheSize:                       getEnvAsInt("TOKEN_VALIDATION_CACHE_SIZE", 10000),
+			MaxActiveSessions:                    getEnvAsInt("SESSION_MAX_ACTIVE", 0),
+			SessionLimitAction:                   getEnv("SESSION_LIMIT_ACTION", "evict_oldest"),
+			SigningKeyEncryptionKey:              getEnv("SIGNING_KEY_ENCRYPTION_KEY", ""),
+			SigningKeyRefreshInterval:            getEnvAsDuration("SIGNING_KEY_REFRESH_INTERVAL", time.Minute),
+		},
+		Session: SessionConfig{
+			CookieMode:     getEnvAsBool("SESSION_COOKIE_MODE", false),
+			CookieName:     getEnv("SESSION_COOKIE_NAME", "refresh_token"),
+			CookieDomain:   getEnv("SESSION_COOKIE_DOMAIN", ""),
+			CookiePath:     getEnv("SESSION_COOKIE_PATH", "/api/v1/auth"),
+			CookieSecure:   getEnvAsBool("SESSION_COOKIE_SECURE", true),
+			CookieSameSite: getEnv("SESSION_COOKIE_SAME_SITE", "Strict"),
+			CSRFCookieName: getEnv("SESSION_CSRF_COOKIE_NAME", "csrf_token"),
+			CSRFHeaderName: getEnv("SESSION_CSRF_HEADER_NAME", "X-CSRF-Token"),
 		},
 		Middleware: MiddlewareConfig{
-			EnableTracing:     getEnvAsBool("MIDDLEWARE_TRACING", false),
+			EnableTracing:     getEnvAsBool("MIDDLEWARE_TRACING", getEnvAsBool("TRACING_ENABLED", false)),
 			EnableRequestID:   getEnvAsBool("MIDDLEWARE_REQUEST_ID", false),
 			EnableRecover:     getEnvAsBool("MIDDLEWARE_RECOVER", false),
 			EnableCORS:        getEnvAsBool("MIDDLEWARE_CORS", false),
@@ -141,6 +233,250 @@ func LoadConfig() *Config {
 			EnableRateLimiter: getEnvAsBool("MIDDLEWARE_RATE_LIMITER", false),
 			EnableETag:        getEnvAsBool("MIDDLEWARE_ETAG", false),
 			EnableCompression: getEnvAsBool("MIDDLEWARE_COMPRESSION", false),
+
+			CompressionLevel:       getEnvAsInt("MIDDLEWARE_COMPRESSION_LEVEL", 1),
+			CompressionBrotli:      getEnvAsBool("MIDDLEWARE_COMPRESSION_BROTLI", true),
+			CompressionBrotliLevel: getEnvAsInt("MIDDLEWARE_COMPRESSION_BROTLI_LEVEL", 4),
+			CompressionSkipPaths:   getEnvAsSlice("MIDDLEWARE_COMPRESSION_SKIP_PATHS", ",", nil),
+		},
+		Metrics: MetricsConfig{
+			Enabled:     getEnvAsBool("METRICS_ENABLED", true),
+			Path:        getEnv("METRICS_PATH", "/metrics"),
+			KPIEnabled:  getEnvAsBool("METRICS_KPI_ENABLED", true),
+			KPIInterval: getEnvAsDuration("METRICS_KPI_INTERVAL", 5*time.Minute),
+		},
+		Watchdog: WatchdogConfig{
+			Enabled:          getEnvAsBool("WATCHDOG_ENABLED", true),
+			Interval:         getEnvAsDuration("WATCHDOG_INTERVAL", 10*time.Second),
+			PingTimeout:      getEnvAsDuration("WATCHDOG_PING_TIMEOUT", 2*time.Second),
+			FailureThreshold: getEnvAsInt("WATCHDOG_FAILURE_THRESHOLD", 3),
+			MaxBackoff:       getEnvAsDuration("WATCHDOG_MAX_BACKOFF", 2*time.Minute),
+		},
+		RateLimit: RateLimitConfig{
+			Max:     getEnvAsInt("RATE_LIMIT_MAX", 100),
+			AuthMax: getEnvAsInt("RATE_LIMIT_AUTH_MAX", 20),
+			Window:  getEnvAsDuration("RATE_LIMIT_WINDOW", time.Minute),
+
+			GRPCClientMax: getEnvAsInt("RATE_LIMIT_GRPC_CLIENT_MAX", 1000),
+			GRPCUserMax:   getEnvAsInt("RATE_LIMIT_GRPC_USER_MAX", 100),
+
+			ThrottleLoginMax:    getEnvAsInt("RATE_LIMIT_THROTTLE_LOGIN_MAX", 10),
+			ThrottleRegisterMax: getEnvAsInt("RATE_LIMIT_THROTTLE_REGISTER_MAX", 5),
+			ThrottleWindow:      getEnvAsDuration("RATE_LIMIT_THROTTLE_WINDOW", 15*time.Minute),
+
+			Mode:         PolicyMode(getEnv("RATE_LIMIT_MODE", "enforce")),
+			AuthMode:     PolicyMode(getEnv("RATE_LIMIT_AUTH_MODE", "enforce")),
+			GRPCMode:     PolicyMode(getEnv("RATE_LIMIT_GRPC_MODE", "enforce")),
+			ThrottleMode: PolicyMode(getEnv("RATE_LIMIT_THROTTLE_MODE", "enforce")),
+		},
+		Lockout: LockoutConfig{
+			MaxFailedLogins: getEnvAsInt("LOCKOUT_MAX_FAILED_LOGINS", 5),
+			Window:          getEnvAsDuration("LOCKOUT_WINDOW", 15*time.Minute),
+			Mode:            PolicyMode(getEnv("LOCKOUT_MODE", "shadow")),
+		},
+		Anomaly: AnomalyConfig{
+			IPFailedLogins:        getEnvAsInt("ANOMALY_IP_FAILED_LOGINS", 0),
+			IPFailedLoginWindow:   getEnvAsDuration("ANOMALY_IP_FAILED_LOGIN_WINDOW", 10*time.Minute),
+			IPDenialDuration:      getEnvAsDuration("ANOMALY_IP_DENIAL_DURATION", 30*time.Minute),
+			CountryHeader:         getEnv("ANOMALY_COUNTRY_HEADER", ""),
+			AdminNewCountryStepUp: getEnvAsBool("ANOMALY_ADMIN_NEW_COUNTRY_STEP_UP", false),
+		},
+		Reset: PasswordResetConfig{
+			Expiration:  getEnvAsDuration("PASSWORD_RESET_EXPIRATION", time.Hour),
+			MaxRequests: getEnvAsInt("PASSWORD_RESET_MAX_REQUESTS", 3),
+			Window:      getEnvAsDuration("PASSWORD_RESET_WINDOW", time.Hour),
+			Mode:        PolicyMode(getEnv("PASSWORD_RESET_MODE", "enforce")),
+		},
+		Password: PasswordConfig{
+			BreachCheckEnabled:  getEnvAsBool("PASSWORD_BREACH_CHECK_ENABLED", false),
+			BreachCheckURL:      getEnv("PASSWORD_BREACH_CHECK_URL", "https://api.pwnedpasswords.com"),
+			BreachCheckTimeout:  getEnvAsDuration("PASSWORD_BREACH_CHECK_TIMEOUT", 2*time.Second),
+			BreachCheckFailOpen: getEnvAsBool("PASSWORD_BREACH_CHECK_FAIL_OPEN", true),
+			BreachCheckMinCount: getEnvAsInt("PASSWORD_BREACH_CHECK_MIN_COUNT", 1),
+			MinScore:            getEnvAsInt("PASSWORD_MIN_SCORE", 0),
+		},
+		Metering: MeteringConfig{
+			Enabled:       getEnvAsBool("METERING_ENABLED", true),
+			FlushInterval: getEnvAsDuration("METERING_FLUSH_INTERVAL", 30*time.Second),
+		},
+		Mailer: MailerConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnvAsInt("SMTP_PORT", 587),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "no-reply@example.com"),
+
+			EventsSecret: getEnv("MAILER_EVENTS_SECRET", ""),
+		},
+		Policy: PolicyConfig{
+			EmailVerificationRequired: getEnvAsSlice("POLICY_EMAIL_VERIFICATION_REQUIRED", ",", nil),
+			PhoneVerificationRequired: getEnvAsSlice("POLICY_PHONE_VERIFICATION_REQUIRED", ",", nil),
+		},
+		Register: RegistrationConfig{
+			ConcealExistingAccounts: getEnvAsBool("REGISTRATION_CONCEAL_EXISTING_ACCOUNTS", false),
+			Waitlist:                getEnvAsBool("REGISTRATION_WAITLIST", false),
+			WaitlistQuota:           getEnvAsInt("REGISTRATION_WAITLIST_QUOTA", 0),
+			WaitlistInterval:        getEnvAsDuration("REGISTRATION_WAITLIST_INTERVAL", 24*time.Hour),
+		},
+		Deletion: DeletionConfig{
+			RestorationWindow: getEnvAsDuration("DELETION_RESTORATION_WINDOW", 30*24*time.Hour),
+			PurgeEnabled:      getEnvAsBool("DELETION_PURGE_ENABLED", true),
+			PurgeInterval:     getEnvAsDuration("DELETION_PURGE_INTERVAL", time.Hour),
+		},
+		Invitation: InvitationConfig{
+			Expiration: getEnvAsDuration("INVITATION_EXPIRATION", 72*time.Hour),
+		},
+		Webhook: WebhookConfig{
+			Enabled:          getEnvAsBool("WEBHOOK_ENABLED", true),
+			DispatchInterval: getEnvAsDuration("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second),
+			Timeout:          getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:      getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 5),
+			RetryBackoff:     getEnvAsDuration("WEBHOOK_RETRY_BACKOFF", 30*time.Second),
+		},
+		Name: NameConfig{
+			DefaultLocale:          getEnv("NAME_DEFAULT_LOCALE", "en"),
+			FamilyNameFirstLocales: getEnvAsSlice("NAME_FAMILY_FIRST_LOCALES", ",", []string{"ja", "ko", "zh", "hu", "vi"}),
+		},
+		OIDC: OIDCConfig{
+			Enabled:        getEnvAsBool("OIDC_ENABLED", false),
+			Issuer:         getEnv("OIDC_ISSUER", getEnv("APP_PUBLIC_URL", "http://localhost:8080")),
+			LoginURL:       getEnv("OIDC_LOGIN_URL", ""),
+			Clients:        getEnvAsClients("OIDC_CLIENTS"),
+			ClientSecrets:  getEnvAsClientSecrets("OIDC_CLIENT_SECRETS"),
+			CodeExpiration: getEnvAsDuration("OIDC_CODE_EXPIRATION", time.Minute),
+		},
+		Device: DeviceConfig{
+			VerificationURL: getEnv("DEVICE_VERIFICATION_URL", getEnv("APP_PUBLIC_URL", "http://localhost:8080")+"/device"),
+			CodeExpiration:  getEnvAsDuration("DEVICE_CODE_EXPIRATION", 10*time.Minute),
+			PollInterval:    getEnvAsDuration("DEVICE_POLL_INTERVAL", 5*time.Second),
+		},
+		Passkey: PasskeyConfig{
+			RPID:             getEnv("PASSKEY_RP_ID", "localhost"),
+			RPName:           getEnv("PASSKEY_RP_NAME", getEnv("APP_NAME", "go-user-api")),
+			Origins:          getEnvAsSlice("PASSKEY_ORIGINS", ",", []string{getEnv("APP_PUBLIC_URL", "http://localhost:8080")}),
+			Timeout:          getEnvAsDuration("PASSKEY_TIMEOUT", 5*time.Minute),
+			UserVerification: getEnv("PASSKEY_USER_VERIFICATION", "preferred"),
+		},
+		OAuth: OAuthConfig{
+			CallbackURL:     getEnv("OAUTH_CALLBACK_BASE_URL", getEnv("APP_PUBLIC_URL", "http://localhost:8080")+"/api/v1/auth/oauth"),
+			StateExpiration: getEnvAsDuration("OAUTH_STATE_EXPIRATION", 10*time.Minute),
+			Google: OAuthProviderConfig{
+				ClientID:     getEnv("OAUTH_GOOGLE_CLIENT_ID", ""),
+				ClientSecret: getEnv("OAUTH_GOOGLE_CLIENT_SECRET", ""),
+			},
+			GitHub: OAuthProviderConfig{
+				ClientID:     getEnv("OAUTH_GITHUB_CLIENT_ID", ""),
+				ClientSecret: getEnv("OAUTH_GITHUB_CLIENT_SECRET", ""),
+			},
+		},
+		Directory: DirectoryConfig{
+			LDAP: LDAPConfig{
+				URL:                getEnv("LDAP_URL", ""),
+				StartTLS:           getEnvAsBool("LDAP_START_TLS", false),
+				BindDN:             getEnv("LDAP_BIND_DN", ""),
+				BindPassword:       getEnv("LDAP_BIND_PASSWORD", ""),
+				BaseDN:             getEnv("LDAP_BASE_DN", ""),
+				UserFilter:         getEnv("LDAP_USER_FILTER", "(&(objectClass=person)(mail=*))"),
+				EmailAttribute:     getEnv("LDAP_EMAIL_ATTRIBUTE", "mail"),
+				UsernameAttribute:  getEnv("LDAP_USERNAME_ATTRIBUTE", "uid"),
+				FirstNameAttribute: getEnv("LDAP_FIRST_NAME_ATTRIBUTE", "givenName"),
+				LastNameAttribute:  getEnv("LDAP_LAST_NAME_ATTRIBUTE", "sn"),
+				Timeout:            getEnvAsDuration("LDAP_TIMEOUT", 30*time.Second),
+			},
+			EmailDomains:      getEnvAsSlice("DIRECTORY_EMAIL_DOMAINS", ",", nil),
+			SyncEnabled:       getEnvAsBool("DIRECTORY_SYNC_ENABLED", true),
+			SyncInterval:      getEnvAsDuration("DIRECTORY_SYNC_INTERVAL", time.Hour),
+			DeactivateRemoved: getEnvAsBool("DIRECTORY_SYNC_DEACTIVATE_REMOVED", false),
+			CreateUsers:       getEnvAsBool("DIRECTORY_SYNC_CREATE_USERS", false),
+			UpdateProfiles:    getEnvAsBool("DIRECTORY_SYNC_UPDATE_PROFILES", false),
+			DryRun:            getEnvAsBool("DIRECTORY_SYNC_DRY_RUN", true),
+		},
+		SCIM: SCIMConfig{
+			Enabled:        getEnvAsBool("SCIM_ENABLED", false),
+			Token:          getEnv("SCIM_TOKEN", ""),
+			GroupRoles:     getEnvAsGroupMappings("SCIM_GROUP_ROLES"),
+			GroupOrgs:      getEnvAsGroupMappings("SCIM_GROUP_ORGS"),
+			DefaultRole:    getEnv("SCIM_DEFAULT_ROLE", "user"),
+			RolePrecedence: getEnvAsSlice("SCIM_ROLE_PRECEDENCE", ",", []string{"admin", "org_admin", "member", "user"}),
+		},
+		Lifecycle: LifecycleConfig{
+			Enabled:             getEnvAsBool("LIFECYCLE_ENABLED", true),
+			Interval:            getEnvAsDuration("LIFECYCLE_INTERVAL", 5*time.Minute),
+			TemplateDir:         getEnv("LIFECYCLE_TEMPLATE_DIR", ""),
+			WelcomeEnabled:      getEnvAsBool("LIFECYCLE_WELCOME_ENABLED", false),
+			WelcomeDelay:        getEnvAsDuration("LIFECYCLE_WELCOME_DELAY", 30*time.Minute),
+			ReengagementEnabled: getEnvAsBool("LIFECYCLE_REENGAGEMENT_ENABLED", false),
+			ReengagementAfter:   getEnvAsDuration("LIFECYCLE_REENGAGEMENT_AFTER", 7*24*time.Hour),
+		},
+		APIKey: APIKeyConfig{
+			Enabled:       getEnvAsBool("API_KEYS_ENABLED", true),
+			MaxPerUser:    getEnvAsInt("API_KEYS_MAX_PER_USER", 10),
+			MaxPerOrg:     getEnvAsInt("API_KEYS_MAX_PER_ORG", 25),
+			MaxLifetime:   getEnvAsDuration("API_KEYS_MAX_LIFETIME", 0),
+			DefaultScopes: getEnvAsSlice("API_KEYS_DEFAULT_SCOPES", ",", []string{"read"}),
+		},
+		Inactivity: InactivityConfig{
+			Enabled:     getEnvAsBool("INACTIVITY_POLICY_ENABLED", false),
+			Interval:    getEnvAsDuration("INACTIVITY_POLICY_INTERVAL", time.Hour),
+			After:       getEnvAsDuration("INACTIVITY_POLICY_AFTER", 90*24*time.Hour),
+			WarnBefore:  getEnvAsDuration("INACTIVITY_POLICY_WARN_BEFORE", 7*24*time.Hour),
+			Action:      getEnv("INACTIVITY_POLICY_ACTION", "flag"),
+			ExemptRoles: getEnvAsSlice("INACTIVITY_POLICY_EXEMPT_ROLES", ",", []string{"admin"}),
+		},
+		ServiceAccount: ServiceAccountConfig{
+			Enabled: getEnvAsBool("SERVICE_ACCOUNTS_ENABLED", true),
+		},
+		Quota: QuotaConfig{
+			Enabled:        getEnvAsBool("QUOTA_ENABLED", false),
+			DefaultDaily:   int64(getEnvAsInt("QUOTA_DEFAULT_DAILY", 0)),
+			DefaultMonthly: int64(getEnvAsInt("QUOTA_DEFAULT_MONTHLY", 0)),
+		},
+		Branding: BrandingConfig{
+			ProductName:  getEnv("BRANDING_PRODUCT_NAME", getEnv("APP_NAME", "go-user-api")),
+			LogoURL:      getEnv("BRANDING_LOGO_URL", ""),
+			SupportEmail: getEnv("BRANDING_SUPPORT_EMAIL", ""),
+			PrimaryColor: getEnv("BRANDING_PRIMARY_COLOR", "#1f2937"),
+			AccentColor:  getEnv("BRANDING_ACCENT_COLOR", "#2563eb"),
+		},
+		Organization: OrganizationConfig{
+			Plans:       getEnvAsPlans("ORG_PLANS"),
+			DefaultPlan: getEnv("ORG_DEFAULT_PLAN", ""),
+		},
+		RoleApproval: RoleApprovalConfig{
+			Enabled:    getEnvAsBool("ROLE_APPROVAL_ENABLED", false),
+			Roles:      getEnvAsSlice("ROLE_APPROVAL_ROLES", ",", []string{"admin", "org_admin"}),
+			Expiration: getEnvAsDuration("ROLE_APPROVAL_EXPIRATION", 72*time.Hour),
+		},
+		RoleGrant: RoleGrantConfig{
+			ExpiryEnabled:  getEnvAsBool("ROLE_GRANT_EXPIRY_ENABLED", true),
+			ExpiryInterval: getEnvAsDuration("ROLE_GRANT_EXPIRY_INTERVAL", time.Minute),
+		},
+		BreakGlass: BreakGlassConfig{
+			SecretsDir:       getEnv("BREAK_GLASS_SECRETS_DIR", ""),
+			NotifyEmails:     getEnvAsSlice("BREAK_GLASS_NOTIFY_EMAILS", ",", nil),
+			RotateAfter:      getEnvAsDuration("BREAK_GLASS_ROTATE_AFTER", time.Hour),
+			RotationInterval: getEnvAsDuration("BREAK_GLASS_ROTATION_INTERVAL", time.Minute),
+		},
+		SIEM: SIEMConfig{
+			Enabled:          getEnvAsBool("SIEM_ENABLED", false),
+			Sink:             getEnv("SIEM_SINK", "syslog"),
+			Format:           getEnv("SIEM_FORMAT", "cef"),
+			SyslogNetwork:    getEnv("SIEM_SYSLOG_NETWORK", "udp"),
+			SyslogAddress:    getEnv("SIEM_SYSLOG_ADDRESS", "localhost:514"),
+			HTTPURL:          getEnv("SIEM_HTTP_URL", ""),
+			HTTPToken:        getEnv("SIEM_HTTP_TOKEN", ""),
+			Timeout:          getEnvAsDuration("SIEM_TIMEOUT", 5*time.Second),
+			BatchSize:        getEnvAsInt("SIEM_BATCH_SIZE", 100),
+			DispatchInterval: getEnvAsDuration("SIEM_DISPATCH_INTERVAL", 5*time.Second),
+		},
+		SelfTest: SelfTestConfig{
+			Timeout: getEnvAsDuration("SELFTEST_TIMEOUT", 5*time.Second),
+		},
+		QueryBudget: QueryBudgetConfig{
+			Enabled:      getEnvAsBool("QUERY_BUDGET_ENABLED", false),
+			MaxQueries:   getEnvAsInt("QUERY_BUDGET_MAX_QUERIES", 25),
+			MaxDuration:  getEnvAsDuration("QUERY_BUDGET_MAX_DURATION", 250*time.Millisecond),
+			FailRequests: getEnvAsBool("QUERY_BUDGET_FAIL_REQUESTS", false),
 		},
 	}
 }
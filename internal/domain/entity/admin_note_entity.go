@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminNote is an internal note administrators attach to a user, never shown to the user
+type AdminNote struct {
+	ID        uuid.UUID `json:"id" bson:"_id"`
+	UserID    uuid.UUID `json:"user_id" bson:"user_id"`
+	AuthorID  uuid.UUID `json:"author_id" bson:"author_id"`
+	Text      string    `json:"text" bson:"text"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
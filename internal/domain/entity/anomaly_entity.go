@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+)
+
+// IPDenialReason enum, why an IP address was denied sign in
+const (
+	IPDenialReasonFailedLogins = "failed_logins" // Too many failed logins across accounts
+)
+
+// ClientInfo describes where a sign in comes from
+type ClientInfo struct {
+	IP      string
+	Country string // ISO 3166-1 alpha-2 code set by the edge proxy, empty when unknown
+}
+
+// IPDenial denies sign in from an IP address after an anomaly, until it expires or an administrator lifts it
+type IPDenial struct {
+	IP           string    `json:"ip" bson:"_id"`
+	Reason       string    `json:"reason" bson:"reason"`
+	FailedLogins int       `json:"failed_logins" bson:"failed_logins"` // Failed logins counted when the address was denied
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// IsActive reports whether the denial still applies
+func (d *IPDenial) IsActive(now time.Time) bool {
+	return now.Before(d.ExpiresAt)
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyScope enum, what the requests authenticated by an API key may do
+const (
+	APIKeyScopeRead  = "read"  // GET and HEAD requests
+	APIKeyScopeWrite = "write" // Requests changing data
+	APIKeyScopeAdmin = "admin" // Acting with the role of the owner, or as an org admin for organization keys, other keys act as a regular user
+)
+
+// AllAPIKeyScopes lists every API key scope
+var AllAPIKeyScopes = []string{
+	APIKeyScopeRead,
+	APIKeyScopeWrite,
+	APIKeyScopeAdmin,
+}
+
+// IsValidAPIKeyScope reports whether scope is one of the API key scopes
+func IsValidAPIKeyScope(scope string) bool {
+	return slices.Contains(AllAPIKeyScopes, scope)
+}
+
+// APIKey is a long-lived credential a user gives to scripts and services to call the API on their behalf, or an
+// organization gives to call the API in its own name
+type APIKey struct {
+	ID     uuid.UUID `json:"id" bson:"_id"`
+	UserID uuid.UUID `json:"user_id" bson:"user_id"` // uuid.Nil for the keys of an organization
+	Name   string    `json:"name" bson:"name"`
+
+	// OrgID is the organization of an organization key, which survives the admins who created it
+	OrgID     *uuid.UUID `json:"org_id,omitempty" bson:"org_id,omitempty"`
+	CreatedBy uuid.UUID  `json:"created_by" bson:"created_by"`
+
+	// Prefix is the beginning of the key, telling keys apart without revealing them
+	Prefix string `json:"prefix" bson:"prefix"`
+
+	// Hash is a hash of the key, the key itself is only shown once to its owner
+	Hash string `json:"-" bson:"hash"`
+
+	Scopes     []string   `json:"scopes" bson:"scopes"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+
+	// Quota limits the requests of the key, the default quota applies without it
+	Quota *Quota `json:"quota,omitempty" bson:"quota,omitempty"`
+}
+
+// HasScope reports whether the key was granted a scope
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// IsOrgKey reports whether the key belongs to an organization rather than a user
+func (k *APIKey) IsOrgKey() bool {
+	return k.OrgID != nil
+}
+
+// IsExpired reports whether the key expired at a time
+func (k *APIKey) IsExpired(at time.Time) bool {
+	return k.ExpiresAt != nil && !at.Before(*k.ExpiresAt)
+}
+
+// CreatedAPIKey is returned once when creating an API key, the only time the key is revealed
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction enum
+const (
+	AuditActionUserStatusChanged       = "user.status_changed"
+	AuditActionUserRoleChanged         = "user.role_changed"
+	AuditActionUserRoleAssigned        = "user.role_assigned"
+	AuditActionUserRoleUnassigned      = "user.role_unassigned"
+	AuditActionUserRoleExpired         = "user.role_expired"
+	AuditActionRoleChangeRequested     = "user.role_change_requested"
+	AuditActionRoleChangeApproved      = "user.role_change_approved"
+	AuditActionRoleChangeRejected      = "user.role_change_rejected"
+	AuditActionTeamMemberAdded         = "user.added_to_team"
+	AuditActionTeamMemberRemoved       = "user.removed_from_team"
+	AuditActionBreakGlassSealed        = "user.break_glass_sealed"
+	AuditActionBreakGlassUsed          = "user.break_glass_used"
+	AuditActionBreakGlassRotated       = "user.break_glass_rotated"
+	AuditActionStepUpRequired          = "user.step_up_required"
+	AuditActionLoginCountryTrusted     = "user.login_country_trusted"
+	AuditActionUserDeleted             = "user.deleted"
+	AuditActionUserDeletionCancelled   = "user.deletion_cancelled"
+	AuditActionUserPurged              = "user.purged"
+	AuditActionUserVerificationChanged = "user.verification_changed"
+	AuditActionUserTagsChanged         = "user.tags_changed"
+	AuditActionUserOrgChanged          = "user.organization_changed"
+	AuditActionRecoveryEmailSet        = "user.recovery_email_set"
+	AuditActionRecoveryEmailVerified   = "user.recovery_email_verified"
+	AuditActionRecoveryEmailRemoved    = "user.recovery_email_removed"
+	AuditActionPasswordResetRequested  = "user.password_reset_requested"
+	AuditActionPasswordReset           = "user.password_reset"
+	AuditActionPasswordChangeRequired  = "user.password_change_required"
+	AuditActionPasswordChanged         = "user.password_changed"
+	AuditActionPasskeyAdded            = "user.passkey_added"
+	AuditActionPasskeyRemoved          = "user.passkey_removed"
+	AuditActionOAuthLinked             = "user.oauth_linked"
+	AuditActionOAuthUnlinked           = "user.oauth_unlinked"
+	AuditActionAPIKeyCreated           = "user.api_key_created"
+	AuditActionAPIKeyRevoked           = "user.api_key_revoked"
+	AuditActionAPIKeyQuotaChanged      = "user.api_key_quota_changed"
+	AuditActionSuspiciousActivity      = "user.suspicious_activity_reported"
+	AuditActionAdminNoteAdded          = "user.admin_note_added"
+	AuditActionAdminNoteEdited         = "user.admin_note_edited"
+	AuditActionAdminNoteDeleted        = "user.admin_note_deleted"
+	AuditActionUserInvited             = "user.invited"
+	AuditActionUserImported            = "user.imported"
+	AuditActionInvitationResent        = "user.invitation_resent"
+	AuditActionInvitationAccepted      = "user.invitation_accepted"
+	AuditActionWaitlistApproved        = "user.waitlist_approved"
+	AuditActionNotificationSent        = "notification.sent"
+	AuditActionSigningKeyRotated       = "signing_key.rotated"
+	AuditActionTokenDenied             = "token.denied"
+	AuditActionTokensRevoked           = "token.global_revocation"
+	AuditActionOIDCAuthorized          = "oidc.authorized"
+	AuditActionDeviceAuthorized        = "device.authorized"
+	AuditActionPolicyViolation         = "policy.violation"
+	AuditActionIPDenied                = "ip.denied"
+	AuditActionIPDenialLifted          = "ip.denial_lifted"
+	AuditActionWebhookEndpointCreated  = "webhook.endpoint_created"
+	AuditActionWebhookEndpointDeleted  = "webhook.endpoint_deleted"
+	AuditActionWebhookEndpointPaused   = "webhook.endpoint_paused"
+	AuditActionWebhookEndpointResumed  = "webhook.endpoint_resumed"
+	AuditActionWebhookReplayed         = "webhook.deliveries_replayed"
+	AuditActionEmailSuppressed         = "user.email_suppressed"
+	AuditActionSuppressionRemoved      = "email.suppression_removed"
+	AuditActionOrgProfileFieldsChanged = "organization.profile_fields_changed"
+	AuditActionOrgSelfRegistration     = "organization.self_registration_changed"
+	AuditActionOrgBrandingChanged      = "organization.branding_changed"
+	AuditActionOrgSSOChanged           = "organization.sso_changed"
+	AuditActionOrgAPIKeyCreated        = "organization.api_key_created"
+	AuditActionOrgAPIKeyRevoked        = "organization.api_key_revoked"
+	AuditActionOrgAPIKeyQuotaChanged   = "organization.api_key_quota_changed"
+	AuditActionOrgPlanChanged          = "organization.plan_changed"
+	AuditActionServiceAccountCreated   = "service_account.created"
+	AuditActionServiceAccountRotated   = "service_account.secret_rotated"
+	AuditActionServiceAccountDeleted   = "service_account.deleted"
+	AuditActionServiceAccountRevoked   = "service_account.tokens_revoked"
+	AuditActionServiceAccountQuotaSet  = "service_account.quota_changed"
+)
+
+// AuditEntry records an action performed on a user
+type AuditEntry struct {
+	ID        uuid.UUID         `json:"id" bson:"_id"`
+	Action    string            `json:"action" bson:"action"`
+	ActorID   uuid.UUID         `json:"actor_id" bson:"actor_id"` // uuid.Nil for actions performed by the system
+	TargetID  uuid.UUID         `json:"target_id" bson:"target_id"`
+	Details   map[string]string `json:"details,omitempty" bson:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at" bson:"created_at"`
+}
+
+// NewAuditEntry creates a new audit entry
+func NewAuditEntry(action string, actorID, targetID uuid.UUID, details map[string]string) *AuditEntry {
+	return &AuditEntry{
+		ID:        uuid.New(),
+		Action:    action,
+		ActorID:   actorID,
+		TargetID:  targetID,
+		Details:   details,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ReasonCode enum, why an administrator acted on a user
+const (
+	ReasonCodeSpam           = "spam"
+	ReasonCodeAbuse          = "abuse"
+	ReasonCodeFraud          = "fraud"
+	ReasonCodeSecurity       = "security"
+	ReasonCodeTermsViolation = "terms_violation"
+	ReasonCodeUserRequest    = "user_request"
+	ReasonCodeOther          = "other"
+)
+
+// MaxReasonNoteLength is the maximum number of characters of the note explaining an administrative action
+const MaxReasonNoteLength = 500
+
+// ActionReason explains an administrative action on a user, both parts are optional
+type ActionReason struct {
+	Code string `json:"reason_code,omitempty" bson:"reason_code,omitempty"`
+	Note string `json:"note,omitempty" bson:"note,omitempty"`
+}
+
+// IsValidReasonCode reports whether code is one of the known reason codes
+func IsValidReasonCode(code string) bool {
+	switch code {
+	case ReasonCodeSpam, ReasonCodeAbuse, ReasonCodeFraud, ReasonCodeSecurity, ReasonCodeTermsViolation, ReasonCodeUserRequest, ReasonCodeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize trims the note of a reason and reports whether the reason is valid: an empty or known code, and a note
+// short enough and free of control characters
+func (r ActionReason) Normalize() (ActionReason, bool) {
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Code != "" && !IsValidReasonCode(r.Code) {
+		return r, false
+	}
+	if utf8.RuneCountInString(r.Note) > MaxReasonNoteLength || strings.IndexFunc(r.Note, unicode.IsControl) >= 0 {
+		return r, false
+	}
+	return r, true
+}
+
+// IsZero reports whether the reason has neither a code nor a note
+func (r ActionReason) IsZero() bool {
+	return r.Code == "" && r.Note == ""
+}
+
+// AddTo adds the set parts of the reason to the details of an audit entry
+func (r ActionReason) AddTo(details map[string]string) {
+	if r.Code != "" {
+		details["reason_code"] = r.Code
+	}
+	if r.Note != "" {
+		details["note"] = r.Note
+	}
+}
@@ -14,6 +14,22 @@ const (
 	AccessToken TokenType = "access"
 	// RefreshToken represents a refresh token
 	RefreshToken TokenType = "refresh"
+
+	// OIDCAccessToken represents an access token issued to a relying party by the OIDC provider, only accepted
+	// by the user info endpoint. It is stored and revoked as an access token.
+	OIDCAccessToken TokenType = "oidc_access"
+	// OIDCRefreshToken represents a refresh token issued to a relying party granted offline access, only accepted
+	// by the OIDC token endpoint. It is stored and revoked as a refresh token.
+	OIDCRefreshToken TokenType = "oidc_refresh"
+)
+
+// OneTimeTokenPurpose enum
+const (
+	OneTimeTokenEmailVerification         = "email_verification"
+	OneTimeTokenRecoveryEmailVerification = "recovery_email_verification"
+	OneTimeTokenPasswordReset             = "password_reset"
+	OneTimeTokenInvitation                = "invitation"
+	OneTimeTokenPasswordChange            = "password_change"
 )
 
 // TokenDetails contains the metadata of a token
@@ -22,13 +38,80 @@ type TokenDetails struct {
 	UserID     uuid.UUID `json:"user_id"`
 	TokenType  TokenType `json:"token_type"`
 	Expiration time.Time `json:"expiration"`
+
+	// SessionID pairs the access and refresh tokens of a login, it is kept across refreshes
+	SessionID uuid.UUID `json:"session_id"`
+	// ParentID is the refresh token the token was rotated from, nil for tokens issued at login
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	IssuedAt time.Time  `json:"issued_at"`
+
+	// ClientID is the service account the token was issued to, whose ID is the UserID, empty for users
+	ClientID string `json:"client_id,omitempty"`
+
+	// Persistent marks the tokens of a session the user asked to be remembered, whose refresh tokens last longer.
+	// It is kept across refreshes.
+	Persistent bool `json:"persistent,omitempty"`
+}
+
+// SessionRevocationReason enum
+const (
+	SessionRevokedLogout       = "logout"
+	SessionRevokedTokenReuse   = "refresh_token_reuse"
+	SessionRevokedAdministered = "revoked_by_admin"
+	SessionRevokedReported     = "reported_by_user"
+	SessionRevokedLimit        = "session_limit"
+)
+
+// SessionLimitAction enum, what a login beyond the limit of concurrent sessions does
+const (
+	SessionLimitActionReject      = "reject"       // Refuse the login until a session ends
+	SessionLimitActionEvictOldest = "evict_oldest" // Revoke the sessions refreshed least recently
+)
+
+// Session is the token family of a login: every token issued to it, in issue order
+type Session struct {
+	ID            uuid.UUID       `json:"id"`
+	UserID        uuid.UUID       `json:"user_id"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Tokens        []*TokenDetails `json:"tokens"`
+	RevokedAt     *time.Time      `json:"revoked_at,omitempty"`
+	RevokedReason string          `json:"revoked_reason,omitempty"`
+}
+
+// LastActiveAt returns when a token was last issued to the session, at login or on a refresh
+func (s *Session) LastActiveAt() time.Time {
+	lastActiveAt := s.CreatedAt
+	for _, token := range s.Tokens {
+		if token.IssuedAt.After(lastActiveAt) {
+			lastActiveAt = token.IssuedAt
+		}
+	}
+	return lastActiveAt
+}
+
+// HasToken reports whether a token of the given type was issued to the session
+func (s *Session) HasToken(tokenID uuid.UUID, tokenType TokenType) bool {
+	for _, token := range s.Tokens {
+		if token.TokenID == tokenID && token.TokenType == tokenType {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenState is what validating an access token reads from the token store
+type TokenState struct {
+	Details    *TokenDetails // nil when the token expired or was deleted
+	Denied     bool
+	UserStatus string // Cached status of the user, empty when not cached
 }
 
 // AuthTokens contains both access and refresh tokens
 type AuthTokens struct {
-	AccessToken  string    `json:"access_token"`
-	RefreshToken string    `json:"refresh_token"`
-	ExpiresAt    time.Time `json:"expires_at"`
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
 }
 
 // RefreshTokenRequest is used for refresh token requests
@@ -41,3 +124,33 @@ type LoginResponse struct {
 	User       *User      `json:"user"`
 	AuthTokens AuthTokens `json:"auth_tokens"`
 }
+
+// SecurityOverview summarizes the security settings and sign-in activity of an account for its owner
+type SecurityOverview struct {
+	EmailVerified               bool   `json:"email_verified"`
+	EmailReverificationRequired bool   `json:"email_reverification_required"`
+	PhoneVerified               bool   `json:"phone_verified"`
+	RecoveryEmail               string `json:"recovery_email,omitempty"`
+	RecoveryEmailVerified       bool   `json:"recovery_email_verified"`
+	PasswordResetRequired       bool   `json:"password_reset_required"`
+	MustChangePassword          bool   `json:"must_change_password"`
+
+	LinkedAccounts []LinkedAccount `json:"linked_accounts"`
+	ActiveSessions int             `json:"active_sessions"`
+	RecentLogins   []LoginActivity `json:"recent_logins"`
+}
+
+// LinkedAccount is an account at an external provider the user can sign in with
+type LinkedAccount struct {
+	Provider string    `json:"provider"`
+	Email    string    `json:"email,omitempty"` // Email of the account at the provider when linked
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+// LoginActivity is a sign-in of a user, the start of one of their sessions
+type LoginActivity struct {
+	SessionID    uuid.UUID `json:"session_id"`
+	SignedInAt   time.Time `json:"signed_in_at"`
+	LastActiveAt time.Time `json:"last_active_at"` // Last token issued to the session, at login or refresh
+	Current      bool      `json:"current"`        // The session of the request
+}
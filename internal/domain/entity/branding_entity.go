@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// hexColorPattern matches the #rgb and #rrggbb colors
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Branding is the look of the emails and hosted pages of an organization.
+// Empty settings fall back to the default branding of the service.
+type Branding struct {
+	ProductName  string `json:"product_name" bson:"product_name,omitempty"`
+	LogoURL      string `json:"logo_url" bson:"logo_url,omitempty"`
+	SupportEmail string `json:"support_email" bson:"support_email,omitempty"`
+	PrimaryColor string `json:"primary_color" bson:"primary_color,omitempty"`
+	AccentColor  string `json:"accent_color" bson:"accent_color,omitempty"`
+}
+
+// Valid reports whether the settings can be rendered safely: a product name of at most 100 characters,
+// an absolute HTTPS logo URL, a plain email address and hex colors
+func (b Branding) Valid() bool {
+	if len(b.ProductName) > 100 || strings.ContainsAny(b.ProductName, "\r\n") {
+		return false
+	}
+	if b.LogoURL != "" {
+		logo, err := url.Parse(b.LogoURL)
+		if err != nil || logo.Scheme != "https" || logo.Host == "" {
+			return false
+		}
+	}
+	if b.SupportEmail != "" {
+		address, err := mail.ParseAddress(b.SupportEmail)
+		if err != nil || address.Address != b.SupportEmail {
+			return false
+		}
+	}
+	for _, color := range []string{b.PrimaryColor, b.AccentColor} {
+		if color != "" && !hexColorPattern.MatchString(color) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithDefaults returns the branding with its empty settings taken from defaults
+func (b Branding) WithDefaults(defaults Branding) Branding {
+	if b.ProductName == "" {
+		b.ProductName = defaults.ProductName
+	}
+	if b.LogoURL == "" {
+		b.LogoURL = defaults.LogoURL
+	}
+	if b.SupportEmail == "" {
+		b.SupportEmail = defaults.SupportEmail
+	}
+	if b.PrimaryColor == "" {
+		b.PrimaryColor = defaults.PrimaryColor
+	}
+	if b.AccentColor == "" {
+		b.AccentColor = defaults.AccentColor
+	}
+	return b
+}
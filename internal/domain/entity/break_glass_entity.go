@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BreakGlassAccount is an emergency admin account whose credential is sealed in the secrets backend. Using it
+// raises alerts, and the credential is rotated once the emergency is over.
+type BreakGlassAccount struct {
+	UserID     uuid.UUID  `json:"user_id" bson:"_id"`
+	Username   string     `json:"username" bson:"username"`
+	SecretName string     `json:"secret_name" bson:"secret_name"` // Name of the sealed credential in the secrets backend
+	SealedAt   time.Time  `json:"sealed_at" bson:"sealed_at"`
+	UsedAt     *time.Time `json:"used_at,omitempty" bson:"used_at,omitempty"` // First sign in since sealed, nil while sealed
+}
+
+// IsDueForRotation reports whether the account was used at least after ago, so its credential must be rotated
+func (a *BreakGlassAccount) IsDueForRotation(now time.Time, after time.Duration) bool {
+	return a.UsedAt != nil && !now.Before(a.UsedAt.Add(after))
+}
+
+// BreakGlassCredential is the sealed credential of a break-glass account, as stored in the secrets backend
+type BreakGlassCredential struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Password string    `json:"password"`
+	SealedAt time.Time `json:"sealed_at"`
+}
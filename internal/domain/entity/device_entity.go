@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceAuthorizationStatus enum
+const (
+	DeviceAuthorizationPending  = "pending"
+	DeviceAuthorizationApproved = "approved"
+	DeviceAuthorizationDenied   = "denied"
+)
+
+// DeviceAuthorization is the sign in of a headless device, approved by the user on another device
+// with the user code the device displays (RFC 8628)
+type DeviceAuthorization struct {
+	// ID is a hash of the device code, the device code itself is only known to the device
+	ID        string    `json:"id"`
+	UserCode  string    `json:"user_code"`
+	ClientID  string    `json:"client_id"`
+	Status    string    `json:"status"`
+	UserID    uuid.UUID `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DeviceCodeResponse is returned to a device starting a sign in
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceTokenResponse is returned to a device polling for the tokens of an approved sign in
+type DeviceTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
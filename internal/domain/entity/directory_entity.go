@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DirectoryEntry is a user account of an upstream directory
+type DirectoryEntry struct {
+	// DN identifies the entry in the directory
+	DN string `json:"dn"`
+
+	// Email is the normalized email of the account, users are matched to entries by email
+	Email string `json:"email"`
+
+	// Username is the login of the account, such as its uid or sAMAccountName
+	Username string `json:"username,omitempty"`
+
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+
+	// Disabled is set for accounts disabled in the directory, such as Active Directory accounts flagged
+	// ACCOUNTDISABLE
+	Disabled bool `json:"disabled"`
+}
+
+// DirectoryDrift kind enum, how a user differs from the directory
+const (
+	DirectoryDriftRemoved         = "removed_upstream"  // Active user without an entry in the directory
+	DirectoryDriftDisabled        = "disabled_upstream" // Active user whose entry is disabled
+	DirectoryDriftMissing         = "missing_locally"   // Enabled entry without a user
+	DirectoryDriftProfileMismatch = "profile_mismatch"  // Names of the user differ from the entry
+)
+
+// DirectoryDrift action enum, what the reconciliation did about a drift. The would_ actions are reported by
+// dry runs.
+const (
+	DirectoryActionDeactivated      = "deactivated"
+	DirectoryActionWouldDeactivate  = "would_deactivate"
+	DirectoryActionDeactivateFailed = "deactivate_failed"
+	DirectoryActionCreated          = "created"
+	DirectoryActionWouldCreate      = "would_create"
+	DirectoryActionCreateFailed     = "create_failed"
+	DirectoryActionUpdated          = "updated"
+	DirectoryActionWouldUpdate      = "would_update"
+	DirectoryActionUpdateFailed     = "update_failed"
+)
+
+// DirectoryDrift is a difference between a user and the upstream directory
+type DirectoryDrift struct {
+	Kind   string     `json:"kind"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	Email  string     `json:"email"`
+	DN     string     `json:"dn,omitempty"`
+
+	// Fields lists the profile fields differing from the entry
+	Fields []string `json:"fields,omitempty"`
+
+	// Action is what the reconciliation did about the drift, empty when it is only reported
+	Action string `json:"action,omitempty"`
+
+	// Error tells why the action failed
+	Error string `json:"error,omitempty"`
+}
+
+// DirectoryDriftReport is the outcome of a reconciliation of the users against the upstream directory
+type DirectoryDriftReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+
+	// DryRun is set when the changes were reported without being made
+	DryRun bool `json:"dry_run"`
+
+	// Entries and Users are the numbers of directory entries and users of the directory domains compared
+	Entries int `json:"entries"`
+	Users   int `json:"users"`
+
+	Created     int              `json:"created"`
+	Updated     int              `json:"updated"`
+	Deactivated int              `json:"deactivated"`
+	Drifts      []DirectoryDrift `json:"drifts"`
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType enum, the domain events published to consumers
+const (
+	EventUserCreated       = "user.created"
+	EventUserUpdated       = "user.updated"
+	EventUserDeleted       = "user.deleted"
+	EventUserStatusChanged = "user.status_changed"
+	EventUserRoleChanged   = "user.role_changed"
+	EventUserReferred      = "user.referred"
+	EventUserApproved      = "user.approved"
+
+	EventOrgMemberLimitReached = "organization.member_limit_reached"
+
+	EventQuotaExceeded = "quota.exceeded"
+)
+
+// EventTypes lists the domain event types, each has a schema in the event schema registry
+var EventTypes = []string{
+	EventUserCreated,
+	EventUserUpdated,
+	EventUserDeleted,
+	EventUserStatusChanged,
+	EventUserRoleChanged,
+	EventUserReferred,
+	EventUserApproved,
+	EventOrgMemberLimitReached,
+	EventQuotaExceeded,
+}
+
+// Event is the envelope of a published domain event, Data matches the schema of Type at Version
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// EventSchema is the JSON Schema of the data of an event type at a version
+type EventSchema struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Schema  json.RawMessage `json:"schema"`
+}
+
+// UserCreatedEvent is the data of user.created events
+type UserCreatedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserUpdatedEvent is the data of user.updated events
+type UserUpdatedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserDeletedEvent is the data of user.deleted events
+type UserDeletedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// UserStatusChangedEvent is the data of user.status_changed events
+type UserStatusChangedEvent struct {
+	UserID         uuid.UUID `json:"user_id"`
+	ActorID        uuid.UUID `json:"actor_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+}
+
+// UserRoleChangedEvent is the data of user.role_changed events
+type UserRoleChangedEvent struct {
+	UserID       uuid.UUID `json:"user_id"`
+	ActorID      uuid.UUID `json:"actor_id"`
+	PreviousRole string    `json:"previous_role"`
+	Role         string    `json:"role"`
+}
+
+// UserReferredEvent is the data of user.referred events
+type UserReferredEvent struct {
+	ReferrerID uuid.UUID `json:"referrer_id"`
+	RefereeID  uuid.UUID `json:"referee_id"`
+	Code       string    `json:"code"`
+	ReferredAt time.Time `json:"referred_at"`
+}
+
+// UserApprovedEvent is the data of user.approved events, ActorID is nil when the waitlist quota approved the user
+type UserApprovedEvent struct {
+	UserID       uuid.UUID `json:"user_id"`
+	ActorID      uuid.UUID `json:"actor_id"`
+	WaitlistedAt time.Time `json:"waitlisted_at"`
+	ApprovedAt   time.Time `json:"approved_at"`
+}
+
+// OrgMemberLimitReachedEvent is the data of organization.member_limit_reached events, raised when a new member
+// fills the last seat of an organization
+type OrgMemberLimitReachedEvent struct {
+	OrgID       uuid.UUID `json:"org_id"`
+	Plan        string    `json:"plan"`
+	MemberLimit int       `json:"member_limit"`
+	Members     int64     `json:"members"`
+	ReachedAt   time.Time `json:"reached_at"`
+}
+
+// QuotaExceededEvent is the data of quota.exceeded events, raised by the first request of an API key or service
+// account refused over its quota in a period
+type QuotaExceededEvent struct {
+	SubjectType string    `json:"subject_type"`
+	SubjectID   uuid.UUID `json:"subject_id"`
+	Period      string    `json:"period"`
+	Limit       int64     `json:"limit"`
+	ResetsAt    time.Time `json:"resets_at"`
+	ExceededAt  time.Time `json:"exceeded_at"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// InactivityAction enum, what the inactivity policy does to the users inactive for too long
+const (
+	InactivityActionFlag       = "flag"       // Tag the users UserTagDormant, for administrators to review
+	InactivityActionDeactivate = "deactivate" // Set the users inactive, so they can no longer sign in
+)
+
+// UserTagDormant is the tag of the users flagged by the inactivity policy, removed when they are active again
+const UserTagDormant = "dormant"
+
+// InactivityReport is the outcome of a pass of the inactivity policy
+type InactivityReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Action     string    `json:"action"`
+
+	// Dormant is the number of active users inactive long enough to be warned, exempt roles left out
+	Dormant int `json:"dormant"`
+
+	Warned      int `json:"warned"`
+	Flagged     int `json:"flagged"`
+	Deactivated int `json:"deactivated"`
+	Failed      int `json:"failed"`
+}
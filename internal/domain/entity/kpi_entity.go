@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// UserCount is the number of users with a status and a role
+type UserCount struct {
+	Status string `json:"status" bson:"status"`
+	Role   string `json:"role" bson:"role"`
+	Count  int64  `json:"count" bson:"count"`
+}
+
+// KPISnapshot holds the business metrics aggregated over the users, shared by the instances exporting them
+type KPISnapshot struct {
+	AggregatedAt time.Time   `json:"aggregated_at"`
+	Users        []UserCount `json:"users"`
+	ActiveUsers  int64       `json:"active_users"`
+	DailySignups int64       `json:"daily_signups"` // Users created in the 24 hours before the aggregation
+	PasskeyUsers int64       `json:"passkey_users"` // Users holding at least one passkey
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxDisplayNameLength is the maximum number of characters of a display name
+const MaxDisplayNameLength = 100
+
+// NormalizeName puts a name in Unicode normalization form C and collapses its whitespace, so the same name typed
+// on different keyboards is stored, compared and displayed identically
+func NormalizeName(name string) string {
+	return norm.NFC.String(strings.Join(strings.Fields(name), " "))
+}
+
+// IsValidDisplayName reports whether a normalized display name is short enough and free of control characters
+func IsValidDisplayName(name string) bool {
+	if utf8.RuneCountInString(name) > MaxDisplayNameLength {
+		return false
+	}
+	return strings.IndexFunc(name, unicode.IsControl) < 0
+}
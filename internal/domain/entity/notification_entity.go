@@ -0,0 +1,29 @@
+package entity
+
+// NotificationChannel enum
+const (
+	NotificationChannelEmail = "email"
+
+	// NotificationChannelRecoveryEmail delivers to the verified recovery email, it is used for
+	// password resets and security notifications and cannot be chosen as a preferred channel
+	NotificationChannelRecoveryEmail = "recovery_email"
+)
+
+// Notification is a message sent to a user
+type Notification struct {
+	Subject string
+	Body    string
+
+	// HTMLBody is the HTML alternative of the body sent to email channels, if any
+	HTMLBody string
+}
+
+// IsValidNotificationChannel reports whether channel is one of the supported notification channels
+func IsValidNotificationChannel(channel string) bool {
+	switch channel {
+	case NotificationChannelEmail:
+		return true
+	default:
+		return false
+	}
+}
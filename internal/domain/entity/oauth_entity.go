@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthProfile is the account of a user at an external OAuth2 provider, as returned once they signed in there
+type OAuthProfile struct {
+	Provider      string
+	Subject       string // ID of the account at the provider, stable across email changes
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	Username      string // Login at the provider, suggested as username for new users
+}
+
+// OAuthIdentity links the account of a user at an external OAuth2 provider to a local user
+type OAuthIdentity struct {
+	// ID is the provider and subject joined by a colon, an account at a provider links a single user
+	ID        string    `json:"-" bson:"_id"`
+	Provider  string    `json:"provider" bson:"provider"`
+	Subject   string    `json:"-" bson:"subject"`
+	UserID    uuid.UUID `json:"user_id" bson:"user_id"`
+	Email     string    `json:"email" bson:"email"` // Email of the account at the provider when linked
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// NewOAuthIdentity links the account of a profile to a user
+func NewOAuthIdentity(profile *OAuthProfile, userID uuid.UUID) *OAuthIdentity {
+	return &OAuthIdentity{
+		ID:        OAuthIdentityID(profile.Provider, profile.Subject),
+		Provider:  profile.Provider,
+		Subject:   profile.Subject,
+		UserID:    userID,
+		Email:     NormalizeEmail(profile.Email),
+		CreatedAt: time.Now(),
+	}
+}
+
+// OAuthIdentityID returns the ID of the identity of an account at a provider
+func OAuthIdentityID(provider, subject string) string {
+	return provider + ":" + subject
+}
+
+// OAuthState is a pending sign in with an external provider, the state parameter of the authorization request
+// refers to it
+type OAuthState struct {
+	State        string     `json:"state"`
+	Provider     string     `json:"provider"`
+	CodeVerifier string     `json:"code_verifier"`     // PKCE verifier of the authorization code
+	UserID       *uuid.UUID `json:"user_id,omitempty"` // Signed in user linking the account, nil when signing in
+	ExpiresAt    time.Time  `json:"expires_at"`
+}
+
+// OAuthResult is the outcome of a callback from an external provider: tokens when signing in, or the identity
+// linked to the signed in user who started a link
+type OAuthResult struct {
+	Login  *LoginResponse
+	Linked *OAuthIdentity
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization is a tenant grouping users, administered by its org admins
+type Organization struct {
+	ID   uuid.UUID `json:"id" bson:"_id"`
+	Name string    `json:"name" bson:"name"`
+
+	// ProfileFields are the rules applied to the profile of the members on registration and update
+	ProfileFields ProfileFieldRules `json:"profile_fields,omitempty" bson:"profile_fields,omitempty"`
+
+	// SelfRegistration lets users register directly into the organization
+	SelfRegistration bool `json:"self_registration" bson:"self_registration"`
+
+	// Branding customizes the emails sent to the members and the hosted pages they are linked to
+	Branding Branding `json:"branding" bson:"branding"`
+
+	// SSO requires the members to sign in with a single sign-on provider instead of their password
+	SSO SSOPolicy `json:"sso" bson:"sso"`
+
+	// Plan sets the number of members of the organization, the default plan when empty. MemberLimit overrides
+	// the limit of the plan when set by a platform admin, 0 lifting it.
+	Plan        string `json:"plan,omitempty" bson:"plan,omitempty"`
+	MemberLimit *int   `json:"member_limit,omitempty" bson:"member_limit,omitempty"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// SSOPolicy is the single sign-on policy of an organization
+type SSOPolicy struct {
+	// Required rejects password and passkey sign ins of the members, they sign in with the provider only
+	Required bool `json:"required" bson:"required"`
+
+	// Provider is the name of the provider the members sign in with, such as google
+	Provider string `json:"provider,omitempty" bson:"provider,omitempty"`
+}
+
+// MemberQuota is the number of members of an organization against the limit of its plan
+type MemberQuota struct {
+	OrgID       uuid.UUID `json:"org_id"`
+	Plan        string    `json:"plan,omitempty"`
+	MemberLimit int       `json:"member_limit"` // 0 when unlimited
+	Members     int64     `json:"members"`
+	Overridden  bool      `json:"overridden"` // The limit was set by a platform admin rather than the plan
+}
+
+// Reached reports whether the organization holds as many members as its limit allows, or more
+func (q *MemberQuota) Reached() bool {
+	return q.MemberLimit > 0 && q.Members >= int64(q.MemberLimit)
+}
+
+// NewOrganization creates a new organization
+func NewOrganization(name string) *Organization {
+	now := time.Now()
+	return &Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasskeyCeremony enum, the WebAuthn ceremonies a challenge is issued for
+const (
+	PasskeyCeremonyRegistration = "registration"
+	PasskeyCeremonyLogin        = "login"
+)
+
+// Passkey is a WebAuthn credential a user signs in with instead of a password
+type Passkey struct {
+	// ID is the credential ID chosen by the authenticator, base64url encoded
+	ID         string     `json:"id" bson:"_id"`
+	UserID     uuid.UUID  `json:"user_id" bson:"user_id"`
+	Name       string     `json:"name" bson:"name"`
+	PublicKey  []byte     `json:"public_key" bson:"public_key"` // COSE encoded
+	SignCount  uint32     `json:"sign_count" bson:"sign_count"`
+	AAGUID     uuid.UUID  `json:"aaguid" bson:"aaguid"`
+	Transports []string   `json:"transports,omitempty" bson:"transports,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+}
+
+// PasskeyCeremony is a pending registration or sign in, holding the challenge the authenticator must sign
+type PasskeyCeremony struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      string     `json:"type"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"` // Set for registrations only, sign ins use discoverable passkeys
+	Challenge []byte     `json:"challenge"`
+	ExpiresAt time.Time  `json:"expires_at"`
+}
+
+// PasskeyCeremonyResponse is returned to a client starting a ceremony, Options are passed as is to
+// navigator.credentials.create or navigator.credentials.get once decoded
+type PasskeyCeremonyResponse struct {
+	CeremonyID uuid.UUID `json:"ceremony_id"`
+	Options    any       `json:"public_key"`
+}
+
+// PasskeyCreationOptions are the WebAuthn PublicKeyCredentialCreationOptions of a registration
+type PasskeyCreationOptions struct {
+	Challenge              Base64URL                     `json:"challenge"`
+	RP                     PasskeyRelyingParty           `json:"rp"`
+	User                   PasskeyUser                   `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                         `json:"timeout"`
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                        `json:"attestation"`
+}
+
+// PasskeyRequestOptions are the WebAuthn PublicKeyCredentialRequestOptions of a sign in
+type PasskeyRequestOptions struct {
+	Challenge        Base64URL `json:"challenge"`
+	RPID             string    `json:"rpId"`
+	Timeout          int64     `json:"timeout"`
+	UserVerification string    `json:"userVerification"`
+}
+
+// PasskeyRelyingParty identifies the service to authenticators
+type PasskeyRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PasskeyUser identifies the account of a passkey to authenticators, ID is returned as the user handle
+type PasskeyUser struct {
+	ID          Base64URL `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+// PasskeyCredentialParameter is a public key algorithm accepted for new passkeys
+type PasskeyCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// PasskeyCredentialDescriptor refers to an existing passkey
+type PasskeyCredentialDescriptor struct {
+	Type       string    `json:"type"`
+	ID         Base64URL `json:"id"`
+	Transports []string  `json:"transports,omitempty"`
+}
+
+// PasskeyAuthenticatorSelection states the authenticators accepted for new passkeys
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	RequireResident  bool   `json:"requireResidentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PasskeyAttestation is the response of an authenticator to a registration
+type PasskeyAttestation struct {
+	RawID             Base64URL `json:"rawId"`
+	ClientDataJSON    Base64URL `json:"clientDataJSON"`
+	AttestationObject Base64URL `json:"attestationObject"`
+	Transports        []string  `json:"transports"`
+}
+
+// PasskeyAssertion is the response of an authenticator to a sign in
+type PasskeyAssertion struct {
+	RawID             Base64URL `json:"rawId"`
+	ClientDataJSON    Base64URL `json:"clientDataJSON"`
+	AuthenticatorData Base64URL `json:"authenticatorData"`
+	Signature         Base64URL `json:"signature"`
+	UserHandle        Base64URL `json:"userHandle"`
+}
+
+// Base64URL is binary data exchanged with WebAuthn clients, encoded as unpadded base64url in JSON
+type Base64URL []byte
+
+// MarshalJSON encodes the data as unpadded base64url
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// UnmarshalJSON decodes base64url data, padded or not
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// String returns the data as unpadded base64url, the encoding of passkey IDs
+func (b Base64URL) String() string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,20 @@
+package entity
+
+// Scores of the strength of a password, on the 0 to 4 scale of zxcvbn
+const (
+	PasswordScoreTooGuessable = 0 // Under 10^3 guesses
+	PasswordScoreVeryWeak     = 1 // Under 10^6 guesses
+	PasswordScoreWeak         = 2 // Under 10^8 guesses
+	PasswordScoreGood         = 3 // Under 10^10 guesses
+	PasswordScoreStrong       = 4
+)
+
+// PasswordStrength is the estimated strength of a password, with feedback to make it stronger
+type PasswordStrength struct {
+	Score        int      `json:"score"`
+	GuessesLog10 float64  `json:"guesses_log10"` // Order of magnitude of the guesses needed to find the password
+	Warning      string   `json:"warning,omitempty"`
+	Suggestions  []string `json:"suggestions"`
+	MinScore     int      `json:"min_score"`  // Score the server requires of new passwords
+	Acceptable   bool     `json:"acceptable"` // Whether the score meets MinScore, the breach check is not run
+}
@@ -0,0 +1,32 @@
+package entity
+
+// PolicyAction enum, the actions that can be gated by the policy service
+const (
+	// PolicyActionListed covers appearing in user listings
+	PolicyActionListed = "listed"
+	// PolicyActionUpdateProfile covers updating one's own profile
+	PolicyActionUpdateProfile = "update_profile"
+)
+
+// VerificationRequirement describes the verifications required to perform an action
+type VerificationRequirement struct {
+	Email bool `json:"email"`
+	Phone bool `json:"phone"`
+}
+
+// SatisfiedBy reports whether a user meets the requirement
+func (r VerificationRequirement) SatisfiedBy(user *User) bool {
+	return (!r.Email || user.EmailVerified) && (!r.Phone || user.PhoneVerified)
+}
+
+// EnforcementPolicy enum, the rate limit and lockout policies that can run in shadow mode
+const (
+	EnforcementPolicyRateLimit           = "rate_limit"
+	EnforcementPolicyRateLimitAuth       = "rate_limit_auth"
+	EnforcementPolicyRateLimitGRPCClient = "rate_limit_grpc_client"
+	EnforcementPolicyRateLimitGRPCUser   = "rate_limit_grpc_user"
+	EnforcementPolicyLoginLockout        = "login_lockout"
+	EnforcementPolicyLoginThrottle       = "login_throttle"
+	EnforcementPolicyRegisterThrottle    = "register_throttle"
+	EnforcementPolicyPasswordReset       = "password_reset"
+)
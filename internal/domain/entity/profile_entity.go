@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Profile fields covered by the rules of an organization
+const (
+	ProfileFieldFirstName   = "first_name"
+	ProfileFieldLastName    = "last_name"
+	ProfileFieldDisplayName = "display_name"
+	ProfileFieldLocale      = "locale"
+	ProfileFieldPhone       = "phone"
+	ProfileFieldBirthDate   = "birth_date"
+)
+
+// ProfileFields lists the profile fields covered by the rules of an organization
+var ProfileFields = []string{
+	ProfileFieldFirstName,
+	ProfileFieldLastName,
+	ProfileFieldDisplayName,
+	ProfileFieldLocale,
+	ProfileFieldPhone,
+	ProfileFieldBirthDate,
+}
+
+// phonePattern matches phone numbers in E.164 format
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// phoneSeparators are the characters commonly used to group the digits of a phone number
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// NormalizePhone strips the separators of a phone number and reports whether it is in E.164 format
+func NormalizePhone(phone string) (string, bool) {
+	phone = phoneSeparators.Replace(strings.TrimSpace(phone))
+	return phone, phonePattern.MatchString(phone)
+}
+
+// ProfileFieldMode enum
+const (
+	ProfileFieldRequired = "required" // Must be set on registration and stay set on update
+	ProfileFieldOptional = "optional" // May be set, the mode of the fields without a rule
+	ProfileFieldHidden   = "hidden"   // Not collected, submitting a value is rejected
+)
+
+// ProfileFieldRules maps profile fields to their mode for the members of an organization
+type ProfileFieldRules map[string]string
+
+// Valid reports whether the rules only cover known fields with known modes
+func (r ProfileFieldRules) Valid() bool {
+	for field, mode := range r {
+		if !slices.Contains(ProfileFields, field) {
+			return false
+		}
+		switch mode {
+		case ProfileFieldRequired, ProfileFieldOptional, ProfileFieldHidden:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns a ProfileFieldError for the first field of a submitted profile breaking the rules, in the order
+// of ProfileFields. Required fields are checked on the resulting user, hidden fields on the submitted profile.
+func (r ProfileFieldRules) Check(user *User, profile UserProfile) error {
+	for _, field := range ProfileFields {
+		switch r[field] {
+		case ProfileFieldRequired:
+			if user.ProfileField(field) == "" {
+				return &ProfileFieldError{Field: field, Mode: ProfileFieldRequired}
+			}
+		case ProfileFieldHidden:
+			if profile.Field(field) != "" {
+				return &ProfileFieldError{Field: field, Mode: ProfileFieldHidden}
+			}
+		}
+	}
+	return nil
+}
+
+// ProfileFieldError reports a profile field breaking the rules of the user's organization
+type ProfileFieldError struct {
+	Field string
+	Mode  string
+}
+
+// Error implements the error interface
+func (e *ProfileFieldError) Error() string {
+	return fmt.Sprintf("profile field %s is %s", e.Field, e.Mode)
+}
+
+// UserProfile contains the profile fields submitted on registration or update.
+// The names are always submitted, nil optional fields are left unchanged and empty ones are cleared.
+type UserProfile struct {
+	FirstName   string
+	LastName    string
+	DisplayName *string
+	Locale      *string
+	Phone       *string
+	BirthDate   *string
+}
+
+// Field returns the submitted value of a profile field, empty when it is not submitted
+func (p UserProfile) Field(field string) string {
+	var value *string
+	switch field {
+	case ProfileFieldFirstName:
+		return p.FirstName
+	case ProfileFieldLastName:
+		return p.LastName
+	case ProfileFieldDisplayName:
+		value = p.DisplayName
+	case ProfileFieldLocale:
+		value = p.Locale
+	case ProfileFieldPhone:
+		value = p.Phone
+	case ProfileFieldBirthDate:
+		value = p.BirthDate
+	}
+	if value == nil {
+		return ""
+	}
+	return *value
+}
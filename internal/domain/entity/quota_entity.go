@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotaSubject enum, what a quota applies to
+const (
+	QuotaSubjectAPIKey         = "api_key"
+	QuotaSubjectServiceAccount = "service_account"
+)
+
+// QuotaPeriod enum, the windows requests are counted in, in UTC
+const (
+	QuotaPeriodDaily   = "daily"
+	QuotaPeriodMonthly = "monthly"
+)
+
+// Quota is the number of requests an API key or service account may make a day and a calendar month, unlimited
+// at 0
+type Quota struct {
+	Daily   int64 `json:"daily" bson:"daily"`
+	Monthly int64 `json:"monthly" bson:"monthly"`
+}
+
+// QuotaUsage is the requests counted in the current window of a period
+type QuotaUsage struct {
+	Period   string    `json:"period"`
+	Used     int64     `json:"used"`
+	Limit    int64     `json:"limit"` // 0 for unlimited
+	ResetsAt time.Time `json:"resets_at"`
+}
+
+// Exceeded reports whether the usage went over a limited quota
+func (u *QuotaUsage) Exceeded() bool {
+	return u.Limit > 0 && u.Used > u.Limit
+}
+
+// QuotaReport is the quota of an API key or service account and its usage of the current windows
+type QuotaReport struct {
+	SubjectType string      `json:"subject_type"`
+	SubjectID   uuid.UUID   `json:"subject_id"`
+	Quota       Quota       `json:"quota"`
+	Custom      bool        `json:"custom"` // Whether the quota was set on the subject rather than the default
+	Daily       *QuotaUsage `json:"daily"`
+	Monthly     *QuotaUsage `json:"monthly"`
+}
+
+// SetQuotaRequest sets the quota of an API key or service account, a null quota restores the default one
+type SetQuotaRequest struct {
+	Quota *Quota `json:"quota"`
+}
+
+// QuotaWindow returns the window of a period containing t and the time it resets
+func QuotaWindow(period string, t time.Time) (string, time.Time) {
+	t = t.UTC()
+	if period == QuotaPeriodDaily {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.Format(time.DateOnly), day.AddDate(0, 0, 1)
+	}
+	month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return month.Format(UsagePeriodLayout), month.AddDate(0, 1, 0)
+}
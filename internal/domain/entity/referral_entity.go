@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReferralCode is the code a user shares to refer new users
+type ReferralCode struct {
+	Code      string    `json:"code" bson:"_id"`
+	UserID    uuid.UUID `json:"user_id" bson:"user_id"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// Referral records a user registered with the referral code of another user, a user is referred at most once
+type Referral struct {
+	RefereeID  uuid.UUID `json:"referee_id" bson:"_id"`
+	ReferrerID uuid.UUID `json:"referrer_id" bson:"referrer_id"`
+	Code       string    `json:"code" bson:"code"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+}
+
+// NewReferral creates a new referral of the referee by the owner of the code
+func NewReferral(code *ReferralCode, refereeID uuid.UUID) *Referral {
+	return &Referral{
+		RefereeID:  refereeID,
+		ReferrerID: code.UserID,
+		Code:       code.Code,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// ReferralStats summarizes the referrals of a user
+type ReferralStats struct {
+	Code           string     `json:"code"`
+	Referrals      int64      `json:"referrals"`
+	LastReferralAt *time.Time `json:"last_referral_at,omitempty"`
+}
+
+// ReferrerRanking is an entry of the referral leaderboard
+type ReferrerRanking struct {
+	UserID    uuid.UUID `json:"user_id" bson:"_id"`
+	Username  string    `json:"username,omitempty" bson:"-"`
+	Referrals int64     `json:"referrals" bson:"referrals"`
+}
+
+// NormalizeReferralCode returns a referral code as stored, whatever the case the user typed
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
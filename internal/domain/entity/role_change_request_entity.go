@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoleChangeKind enum, how a role change grants the role
+const (
+	RoleChangeKindPrimary    = "primary"    // Replaces the primary role of the user
+	RoleChangeKindAssignment = "assignment" // Assigns the role on top of the primary role
+)
+
+// RoleChangeStatus enum
+const (
+	RoleChangeStatusPending  = "pending"
+	RoleChangeStatusApproved = "approved"
+	RoleChangeStatusRejected = "rejected"
+	RoleChangeStatusExpired  = "expired"
+)
+
+// RoleChangeRequest is a privileged role change awaiting the approval of a second administrator, it takes effect
+// once approved
+type RoleChangeRequest struct {
+	ID           uuid.UUID    `json:"id" bson:"_id"`
+	UserID       uuid.UUID    `json:"user_id" bson:"user_id"`
+	Kind         string       `json:"kind" bson:"kind"`
+	Role         string       `json:"role" bson:"role"`
+	Duration     string       `json:"duration,omitempty" bson:"duration,omitempty"` // Lifetime of an assignment, empty if it never expires
+	Reason       ActionReason `json:"reason" bson:"reason"`
+	Status       string       `json:"status" bson:"status"`
+	RequestedBy  uuid.UUID    `json:"requested_by" bson:"requested_by"`
+	CreatedAt    time.Time    `json:"created_at" bson:"created_at"`
+	ExpiresAt    *time.Time   `json:"expires_at,omitempty" bson:"expires_at,omitempty"` // nil if the request never expires
+	DecidedBy    *uuid.UUID   `json:"decided_by,omitempty" bson:"decided_by,omitempty"`
+	DecidedAt    *time.Time   `json:"decided_at,omitempty" bson:"decided_at,omitempty"`
+	DecisionNote string       `json:"decision_note,omitempty" bson:"decision_note,omitempty"`
+}
+
+// IsExpired reports whether a pending request outlived its expiration
+func (r *RoleChangeRequest) IsExpired(now time.Time) bool {
+	return r.Status == RoleChangeStatusPending && r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
+
+// AssignmentExpiration returns the expiration of the assignment granted when the request is approved at now, nil if
+// the assignment never expires
+func (r *RoleChangeRequest) AssignmentExpiration(now time.Time) *time.Time {
+	duration, err := time.ParseDuration(r.Duration)
+	if err != nil || duration <= 0 {
+		return nil
+	}
+	expiresAt := now.Add(duration)
+	return &expiresAt
+}
+
+// IsValidRoleChangeStatus reports whether status is one of the known role change request statuses
+func IsValidRoleChangeStatus(status string) bool {
+	switch status {
+	case RoleChangeStatusPending, RoleChangeStatusApproved, RoleChangeStatusRejected, RoleChangeStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
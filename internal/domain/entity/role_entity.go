@@ -0,0 +1,249 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permission enum
+const (
+	PermissionUsersRead      = "users:read"
+	PermissionUsersWrite     = "users:write"
+	PermissionUsersDelete    = "users:delete"
+	PermissionUsersManage    = "users:manage" // status, role, verification and tags
+	PermissionRolesManage    = "roles:manage"
+	PermissionSettingsManage = "settings:manage"
+	PermissionUsageRead      = "usage:read"
+)
+
+// AllPermissions lists every permission known to the service
+var AllPermissions = []string{
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionUsersDelete,
+	PermissionUsersManage,
+	PermissionRolesManage,
+	PermissionSettingsManage,
+	PermissionUsageRead,
+}
+
+// IsValidPermission reports whether permission is one of the known permissions
+func IsValidPermission(permission string) bool {
+	for _, p := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// builtInPermissionDescriptions describes the permissions checked by the service
+var builtInPermissionDescriptions = map[string]string{
+	PermissionUsersRead:      "Read user accounts",
+	PermissionUsersWrite:     "Update user accounts",
+	PermissionUsersDelete:    "Delete user accounts",
+	PermissionUsersManage:    "Manage the status, role, verification and tags of user accounts",
+	PermissionRolesManage:    "Manage roles, permissions and their assignments",
+	PermissionSettingsManage: "Manage the service settings",
+	PermissionUsageRead:      "Read the billable usage",
+}
+
+// Permission is a named capability granted through roles. Built-in permissions are checked by the service, custom
+// permissions by the applications relying on it.
+type Permission struct {
+	Name        string    `json:"name" bson:"_id"`
+	Description string    `json:"description" bson:"description"`
+	BuiltIn     bool      `json:"built_in" bson:"-"` // Built-in permissions are defined in code and cannot be changed
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// BuiltInPermission returns the built-in permission with the given name, or nil if there is none
+func BuiltInPermission(name string) *Permission {
+	description, ok := builtInPermissionDescriptions[name]
+	if !ok {
+		return nil
+	}
+	return &Permission{Name: name, Description: description, BuiltIn: true}
+}
+
+// BuiltInPermissions returns the built-in permissions in the order of AllPermissions
+func BuiltInPermissions() []*Permission {
+	permissions := make([]*Permission, 0, len(AllPermissions))
+	for _, name := range AllPermissions {
+		permissions = append(permissions, BuiltInPermission(name))
+	}
+	return permissions
+}
+
+// permissionNamePattern restricts permission names to a resource and an action, e.g. invoices:approve
+var permissionNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}:[a-z][a-z0-9_-]{0,31}$`)
+
+// IsValidPermissionName reports whether name can be used as a custom permission name
+func IsValidPermissionName(name string) bool {
+	return permissionNamePattern.MatchString(name)
+}
+
+// NewPermission creates a new custom permission
+func NewPermission(name, description string) *Permission {
+	now := time.Now()
+	return &Permission{
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// RoleAssignment assigns a role to a user on top of their primary role, a user may be assigned several roles
+type RoleAssignment struct {
+	UserID     uuid.UUID  `json:"user_id" bson:"user_id"`
+	Role       string     `json:"role" bson:"role"`
+	AssignedBy uuid.UUID  `json:"assigned_by" bson:"assigned_by"`
+	AssignedAt time.Time  `json:"assigned_at" bson:"assigned_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"` // nil if the role is granted for good
+}
+
+// IsExpired reports whether a time-boxed assignment outlived its expiration
+func (a *RoleAssignment) IsExpired(now time.Time) bool {
+	return a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)
+}
+
+// UserPermissions is the resolved permission set of a user, granted by their primary role, assigned roles and the
+// roles of their teams
+type UserPermissions struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Role        string    `json:"role"`
+	Roles       []string  `json:"roles"`      // Roles assigned on top of the primary role
+	Teams       []string  `json:"teams"`      // Teams the user is a member of
+	TeamRoles   []string  `json:"team_roles"` // Roles granted by the teams
+	Permissions []string  `json:"permissions"`
+}
+
+// Role is a named set of permissions assignable to users
+type Role struct {
+	Name        string   `json:"name" bson:"_id"`
+	Description string   `json:"description" bson:"description"`
+	Permissions []string `json:"permissions" bson:"permissions"`
+
+	// Parents are the roles whose permissions the role inherits
+	Parents []string `json:"parents,omitempty" bson:"parents,omitempty"`
+	// Groups are the permission groups whose permissions the role includes
+	Groups []string `json:"groups,omitempty" bson:"groups,omitempty"`
+
+	BuiltIn   bool      `json:"built_in" bson:"-"` // Built-in roles are defined in code and cannot be changed
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// RoleSpec is the definition of a custom role
+type RoleSpec struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+	Parents     []string `json:"parents"`
+	Groups      []string `json:"groups"`
+}
+
+// PermissionGroup is a named set of permissions included by roles
+type PermissionGroup struct {
+	Name        string    `json:"name" bson:"_id"`
+	Description string    `json:"description" bson:"description"`
+	Permissions []string  `json:"permissions" bson:"permissions"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// EffectivePermissions is the resolved permission set of a role
+type EffectivePermissions struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	// Ancestors lists the roles inherited directly or transitively
+	Ancestors []string `json:"ancestors"`
+	// Sources maps each permission to the roles and groups granting it, e.g. "role:support" or "group:billing"
+	Sources map[string][]string `json:"sources"`
+}
+
+// builtInRoles are the roles every deployment starts with
+var builtInRoles = map[string]*Role{
+	UserRoleAdmin: {
+		Name:        UserRoleAdmin,
+		Description: "Platform administrator",
+		Permissions: AllPermissions,
+		BuiltIn:     true,
+	},
+	UserRoleOrgAdmin: {
+		Name:        UserRoleOrgAdmin,
+		Description: "Organization administrator, limited to the members of their organization",
+		Permissions: []string{PermissionUsersRead, PermissionUsersWrite, PermissionUsersDelete, PermissionUsersManage},
+		BuiltIn:     true,
+	},
+	UserRoleUser: {
+		Name:        UserRoleUser,
+		Description: "Regular user",
+		Permissions: []string{PermissionUsersRead},
+		BuiltIn:     true,
+	},
+	UserRoleMember: {
+		Name:        UserRoleMember,
+		Description: "Member",
+		Permissions: []string{PermissionUsersRead},
+		BuiltIn:     true,
+	},
+}
+
+// BuiltInRole returns a copy of the built-in role with the given name, or nil if there is none
+func BuiltInRole(name string) *Role {
+	role, ok := builtInRoles[name]
+	if !ok {
+		return nil
+	}
+	copied := *role
+	copied.Permissions = append([]string(nil), role.Permissions...)
+	return &copied
+}
+
+// BuiltInRoles returns copies of the built-in roles
+func BuiltInRoles() []*Role {
+	return []*Role{
+		BuiltInRole(UserRoleAdmin),
+		BuiltInRole(UserRoleOrgAdmin),
+		BuiltInRole(UserRoleUser),
+		BuiltInRole(UserRoleMember),
+	}
+}
+
+// roleNamePattern restricts role names to short lowercase slugs
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+// IsValidRoleName reports whether name can be used as a role or permission group name
+func IsValidRoleName(name string) bool {
+	return roleNamePattern.MatchString(name)
+}
+
+// NewRole creates a new custom role
+func NewRole(name string, spec RoleSpec) *Role {
+	now := time.Now()
+	return &Role{
+		Name:        name,
+		Description: spec.Description,
+		Permissions: spec.Permissions,
+		Parents:     spec.Parents,
+		Groups:      spec.Groups,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// NewPermissionGroup creates a new permission group
+func NewPermissionGroup(name, description string, permissions []string) *PermissionGroup {
+	now := time.Now()
+	return &PermissionGroup{
+		Name:        name,
+		Description: description,
+		Permissions: permissions,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
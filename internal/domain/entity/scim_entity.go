@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SCIM schema URNs of the resources and messages of RFC 7643 and RFC 7644
+const (
+	SCIMSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIMGroup is a group of the identity provider. Its members are granted the role and join the organization
+// its display name is mapped to.
+type SCIMGroup struct {
+	ID          uuid.UUID   `json:"id" bson:"_id"`
+	DisplayName string      `json:"display_name" bson:"display_name"`
+	ExternalID  string      `json:"external_id,omitempty" bson:"external_id,omitempty"`
+	Members     []uuid.UUID `json:"members" bson:"members"`
+	CreatedAt   time.Time   `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" bson:"updated_at"`
+
+	// Role and OrgID are what the display name is mapped to, set when reporting the groups
+	Role  string     `json:"role,omitempty" bson:"-"`
+	OrgID *uuid.UUID `json:"org_id,omitempty" bson:"-"`
+
+	// Sync is the outcome of the latest sync of the members, nil until the group is synced
+	Sync *SCIMGroupSync `json:"sync,omitempty" bson:"sync,omitempty"`
+}
+
+// SCIMSyncStatus enum, the outcome of the sync of the members of a group
+const (
+	SCIMSyncStatusSynced          = "synced"           // Every member has the role and organization of their groups
+	SCIMSyncStatusPendingApproval = "pending_approval" // Privileged roles wait for the approval of a second administrator
+	SCIMSyncStatusConflict        = "conflict"         // Members are in groups mapped to different organizations
+	SCIMSyncStatusFailed          = "failed"           // Changes could not be made
+)
+
+// SCIMSyncIssue kind enum
+const (
+	SCIMSyncIssueRoleConflict    = "role_conflict"    // Groups grant several roles, resolved by the role precedence
+	SCIMSyncIssueOrgConflict     = "org_conflict"     // Groups are mapped to several organizations, left unchanged
+	SCIMSyncIssuePendingApproval = "pending_approval" // The role is requested from a second administrator
+	SCIMSyncIssueFailed          = "failed"           // The role or organization could not be changed
+)
+
+// SCIMSyncIssue is what kept the sync of a member from applying the role or organization of their groups as is
+type SCIMSyncIssue struct {
+	UserID uuid.UUID `json:"user_id" bson:"user_id"`
+	Kind   string    `json:"kind" bson:"kind"`
+	Detail string    `json:"detail" bson:"detail"`
+}
+
+// SCIMGroupSync is the outcome of the sync of the users a change of a group affected
+type SCIMGroupSync struct {
+	Status   string          `json:"status" bson:"status"`
+	SyncedAt time.Time       `json:"synced_at" bson:"synced_at"`
+	Users    int             `json:"users" bson:"users"`     // Users synced, the members added and removed
+	Changed  int             `json:"changed" bson:"changed"` // Users whose role or organization changed
+	Issues   []SCIMSyncIssue `json:"issues,omitempty" bson:"issues,omitempty"`
+}
+
+// SCIMMeta is the metadata of a SCIM resource
+type SCIMMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+// SCIMMember is a member of a SCIM group, its value is the ID of a user
+type SCIMMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// SCIMGroupResource is a group as exchanged with the identity provider
+type SCIMGroupResource struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []SCIMMember `json:"members"`
+	Meta        *SCIMMeta    `json:"meta,omitempty"`
+}
+
+// SCIMName is the name of a SCIM user
+type SCIMName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMEmail is an email address of a SCIM user
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+// SCIMUserResource is a user as shown to the identity provider, its userName is the email of the user
+type SCIMUserResource struct {
+	Schemas  []string    `json:"schemas"`
+	ID       string      `json:"id"`
+	UserName string      `json:"userName"`
+	Name     SCIMName    `json:"name"`
+	Emails   []SCIMEmail `json:"emails"`
+	Active   bool        `json:"active"`
+	Meta     *SCIMMeta   `json:"meta"`
+}
+
+// SCIMListResponse is a page of SCIM resources, StartIndex counting from 1
+type SCIMListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    any      `json:"Resources"`
+}
+
+// SCIMPatchOperation is an operation of a SCIM PATCH request: add, remove or replace, matched without case
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// SCIMPatchRequest is the body of a SCIM PATCH request
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMError is the body of a SCIM error response, Status being the HTTP status as a string
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventType enum, the events raised by authentication on top of the audited actions
+const (
+	SecurityEventLoginFailed        = "auth.login_failed"
+	SecurityEventAccountLocked      = "auth.account_locked"
+	SecurityEventRefreshTokenReused = "auth.refresh_token_reused"
+)
+
+// Severities of security events, on the 0 to 10 scale of CEF
+const (
+	SecuritySeverityLow    = 3
+	SecuritySeverityMedium = 5
+	SecuritySeverityHigh   = 8
+)
+
+// securityAuditActions maps the audited actions forwarded to the SIEM to their severity
+var securityAuditActions = map[string]int{
+	AuditActionUserStatusChanged:     SecuritySeverityMedium,
+	AuditActionUserRoleChanged:       SecuritySeverityHigh,
+	AuditActionUserRoleAssigned:      SecuritySeverityHigh,
+	AuditActionUserRoleUnassigned:    SecuritySeverityMedium,
+	AuditActionUserRoleExpired:       SecuritySeverityLow,
+	AuditActionRoleChangeRequested:   SecuritySeverityMedium,
+	AuditActionRoleChangeApproved:    SecuritySeverityHigh,
+	AuditActionRoleChangeRejected:    SecuritySeverityMedium,
+	AuditActionTeamMemberAdded:       SecuritySeverityMedium,
+	AuditActionTeamMemberRemoved:     SecuritySeverityLow,
+	AuditActionBreakGlassSealed:      SecuritySeverityMedium,
+	AuditActionBreakGlassUsed:        SecuritySeverityHigh,
+	AuditActionBreakGlassRotated:     SecuritySeverityMedium,
+	AuditActionStepUpRequired:        SecuritySeverityHigh,
+	AuditActionLoginCountryTrusted:   SecuritySeverityMedium,
+	AuditActionPasswordReset:         SecuritySeverityMedium,
+	AuditActionAPIKeyCreated:         SecuritySeverityMedium,
+	AuditActionAPIKeyRevoked:         SecuritySeverityLow,
+	AuditActionSuspiciousActivity:    SecuritySeverityHigh,
+	AuditActionSigningKeyRotated:     SecuritySeverityMedium,
+	AuditActionTokenDenied:           SecuritySeverityHigh,
+	AuditActionTokensRevoked:         SecuritySeverityHigh,
+	AuditActionPolicyViolation:       SecuritySeverityMedium,
+	AuditActionIPDenied:              SecuritySeverityHigh,
+	AuditActionIPDenialLifted:        SecuritySeverityMedium,
+	AuditActionOrgSSOChanged:         SecuritySeverityMedium,
+	AuditActionOrgAPIKeyCreated:      SecuritySeverityMedium,
+	AuditActionOrgAPIKeyRevoked:      SecuritySeverityLow,
+	AuditActionServiceAccountCreated: SecuritySeverityMedium,
+	AuditActionServiceAccountRotated: SecuritySeverityMedium,
+	AuditActionServiceAccountDeleted: SecuritySeverityLow,
+	AuditActionServiceAccountRevoked: SecuritySeverityHigh,
+}
+
+// SecurityEvent is a security-relevant event queued for delivery to the SIEM
+type SecurityEvent struct {
+	ID         uuid.UUID         `json:"id" bson:"_id"`
+	Type       string            `json:"type" bson:"type"`
+	Severity   int               `json:"severity" bson:"severity"`
+	ActorID    uuid.UUID         `json:"actor_id" bson:"actor_id"` // uuid.Nil for events raised by the system or anonymous callers
+	TargetID   uuid.UUID         `json:"target_id" bson:"target_id"`
+	Details    map[string]string `json:"details,omitempty" bson:"details,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at" bson:"occurred_at"`
+}
+
+// NewSecurityEvent creates a new security event
+func NewSecurityEvent(eventType string, severity int, actorID, targetID uuid.UUID, details map[string]string) *SecurityEvent {
+	return &SecurityEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		Severity:   severity,
+		ActorID:    actorID,
+		TargetID:   targetID,
+		Details:    details,
+		OccurredAt: time.Now(),
+	}
+}
+
+// SecurityEventFromAudit returns the security event of an audit entry, or nil if its action is not security-relevant
+func SecurityEventFromAudit(entry *AuditEntry) *SecurityEvent {
+	severity, ok := securityAuditActions[entry.Action]
+	if !ok {
+		return nil
+	}
+	return &SecurityEvent{
+		ID:         entry.ID,
+		Type:       entry.Action,
+		Severity:   severity,
+		ActorID:    entry.ActorID,
+		TargetID:   entry.TargetID,
+		Details:    entry.Details,
+		OccurredAt: entry.CreatedAt,
+	}
+}
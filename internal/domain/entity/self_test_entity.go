@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SelfTestStatus enum, the outcome of a self-test check or report
+const (
+	SelfTestStatusPass = "pass"
+	SelfTestStatusFail = "fail"
+	SelfTestStatusSkip = "skip" // The dependency is not configured, e.g. no SMTP host
+)
+
+// SelfTestProbe is a throwaway record written and read back by the self-test
+type SelfTestProbe struct {
+	ID        uuid.UUID `json:"id" bson:"_id"`
+	Value     string    `json:"value" bson:"value"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// SelfTestCheck is the outcome of a check of the self-test
+type SelfTestCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// SelfTestReport is the machine-readable report of the self-test, failed when any check failed
+type SelfTestReport struct {
+	Status     string           `json:"status"`
+	StartedAt  time.Time        `json:"started_at"`
+	DurationMs int64            `json:"duration_ms"`
+	Checks     []*SelfTestCheck `json:"checks"`
+}
+
+// Passed reports whether no check failed, skipped checks included
+func (r *SelfTestReport) Passed() bool {
+	return r.Status == SelfTestStatusPass
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GrantTypeClientCredentials is the OAuth 2.0 grant of the service accounts, exchanging their client credentials
+// for an access token
+const GrantTypeClientCredentials = "client_credentials"
+
+// ServiceAccount is a backend service calling the API without a human user. It obtains access tokens with its
+// client credentials, granted the API key scopes.
+type ServiceAccount struct {
+	ID       uuid.UUID `json:"id" bson:"_id"`
+	Name     string    `json:"name" bson:"name"`
+	ClientID string    `json:"client_id" bson:"client_id"`
+
+	// SecretHash is a hash of the client secret, the secret itself is only shown once to the administrator
+	SecretHash string `json:"-" bson:"secret_hash"`
+
+	Scopes      []string   `json:"scopes" bson:"scopes"`
+	CreatedBy   uuid.UUID  `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" bson:"updated_at"`
+	LastTokenAt *time.Time `json:"last_token_at,omitempty" bson:"last_token_at,omitempty"` // Last access token issued
+
+	// Quota limits the requests of the service account, the default quota applies without it
+	Quota *Quota `json:"quota,omitempty" bson:"quota,omitempty"`
+}
+
+// HasScope reports whether the service account was granted a scope
+func (a *ServiceAccount) HasScope(scope string) bool {
+	return slices.Contains(a.Scopes, scope)
+}
+
+// CreatedServiceAccount is returned when creating a service account or rotating its secret, the only times the
+// client secret is revealed
+type CreatedServiceAccount struct {
+	*ServiceAccount
+	ClientSecret string `json:"client_secret"`
+}
+
+// ClientTokenRequest is an OAuth 2.0 token request of the client credentials grant. The client credentials may
+// be sent with HTTP Basic authentication instead.
+type ClientTokenRequest struct {
+	GrantType    string `json:"grant_type" form:"grant_type"`
+	ClientID     string `json:"client_id" form:"client_id"`
+	ClientSecret string `json:"client_secret" form:"client_secret"`
+	Scope        string `json:"scope" form:"scope"` // Space separated, all the scopes of the service account if empty
+}
+
+// ClientTokenResponse is the response of the client credentials grant, which issues no refresh token
+type ClientTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+)
+
+// SigningKey is a token signing key generated at runtime
+type SigningKey struct {
+	ID        string    `json:"kid" bson:"_id"`
+	PublicKey []byte    `json:"-" bson:"public_key"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+
+	// EncryptedPrivateKey is the private key sealed with the signing key encryption key
+	EncryptedPrivateKey []byte `json:"-" bson:"encrypted_private_key"`
+}
+
+// JSONWebKey is the public part of a signing key in JWK format (RFC 8037)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+}
+
+// JSONWebKeySet is the set of keys accepted for token verification
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusChange is a transition of the status of a user, kept in the status history of the account
+type StatusChange struct {
+	ID             uuid.UUID `json:"id" bson:"_id"`
+	UserID         uuid.UUID `json:"user_id" bson:"user_id"`
+	PreviousStatus string    `json:"previous_status,omitempty" bson:"previous_status,omitempty"` // Empty when the account is created
+	Status         string    `json:"status" bson:"status"`
+	ActorID        uuid.UUID `json:"actor_id" bson:"actor_id"`
+	ActionReason   `bson:",inline"`
+	CreatedAt      time.Time `json:"created_at" bson:"created_at"`
+}
+
+// NewStatusChange creates a status change of a user made by an actor
+func NewStatusChange(userID, actorID uuid.UUID, previousStatus, status string, reason ActionReason) *StatusChange {
+	return &StatusChange{
+		ID:             uuid.New(),
+		UserID:         userID,
+		PreviousStatus: previousStatus,
+		Status:         status,
+		ActorID:        actorID,
+		ActionReason:   reason,
+		CreatedAt:      time.Now(),
+	}
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+)
+
+// EmailEventType enum, the delivery events reported by the email provider
+const (
+	EmailEventBounce    = "bounce"
+	EmailEventComplaint = "complaint"
+)
+
+// BounceType enum, only permanent bounces suppress an address
+const (
+	BounceTypePermanent = "permanent"
+	BounceTypeTransient = "transient"
+)
+
+// EmailEvent is a bounce or complaint reported by the email provider
+type EmailEvent struct {
+	Type        string `json:"type"`
+	Email       string `json:"email"`
+	BounceType  string `json:"bounce_type,omitempty"` // Permanent when empty
+	Description string `json:"description,omitempty"`
+}
+
+// EmailSuppression is an address no email is sent to anymore
+type EmailSuppression struct {
+	Email       string    `json:"email" bson:"_id"` // Lowercase
+	Reason      string    `json:"reason" bson:"reason"`
+	Description string    `json:"description,omitempty" bson:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// NewEmailSuppression creates a suppression of an address following an email event
+func NewEmailSuppression(event EmailEvent) *EmailSuppression {
+	return &EmailSuppression{
+		Email:       NormalizeEmail(event.Email),
+		Reason:      event.Type,
+		Description: event.Description,
+		CreatedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Team is a named group of users, its members are granted the roles of the team on top of their own
+type Team struct {
+	Name        string    `json:"name" bson:"_id"`
+	Description string    `json:"description" bson:"description"`
+	Roles       []string  `json:"roles" bson:"roles"` // Roles granted to the members
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// TeamSpec is the definition of a team
+type TeamSpec struct {
+	Description string   `json:"description"`
+	Roles       []string `json:"roles"`
+}
+
+// NewTeam creates a new team
+func NewTeam(name string, spec TeamSpec) *Team {
+	now := time.Now()
+	return &Team{
+		Name:        name,
+		Description: spec.Description,
+		Roles:       spec.Roles,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// TeamMember is the membership of a user in a team, a user may be a member of several teams
+type TeamMember struct {
+	Team    string    `json:"team" bson:"team"`
+	UserID  uuid.UUID `json:"user_id" bson:"user_id"`
+	AddedBy uuid.UUID `json:"added_by" bson:"added_by"`
+	AddedAt time.Time `json:"added_at" bson:"added_at"`
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+)
+
+// UsageMetric enum
+const (
+	UsageMetricActiveUsers = "active_users"
+	UsageMetricAPICalls    = "api_calls"
+)
+
+// UsagePeriodLayout is the layout of billing periods, usage is aggregated per calendar month
+const UsagePeriodLayout = "2006-01"
+
+// UsageRecord is an aggregated billable counter of a subject for a period
+type UsageRecord struct {
+	Period    string    `json:"period" bson:"period"`
+	Metric    string    `json:"metric" bson:"metric"`
+	Subject   string    `json:"subject" bson:"subject"`
+	Count     int64     `json:"count" bson:"count"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// UsageReport summarizes the billable usage of a period
+type UsageReport struct {
+	Period      string         `json:"period"`
+	ActiveUsers int64          `json:"active_users"`
+	APICalls    int64          `json:"api_calls"`
+	Clients     []*UsageRecord `json:"clients"`
+}
+
+// UsagePeriod returns the billing period containing t
+func UsagePeriod(t time.Time) string {
+	return t.UTC().Format(UsagePeriodLayout)
+}
@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,10 +17,157 @@ type User struct {
 	Password  string    `json:"-" bson:"password"` // Never expose password in JSON responses
 	FirstName string    `json:"first_name" bson:"first_name"`
 	LastName  string    `json:"last_name" bson:"last_name"`
-	Role      string    `json:"role" bson:"role"`
-	Status    string    `json:"status" bson:"status"`
+
+	// DisplayName is the name the user chose to be addressed by, empty to format it from the first and last names
+	DisplayName string `json:"display_name,omitempty" bson:"display_name,omitempty"`
+
+	// Locale is the BCP 47 language tag of the user, e.g. ja-JP, empty for the default locale
+	Locale string `json:"locale,omitempty" bson:"locale,omitempty"`
+
+	// Phone is the phone number of the user in E.164 format, e.g. +14155550123
+	Phone string `json:"phone,omitempty" bson:"phone,omitempty"`
+
+	// BirthDate is the date of birth of the user formatted as 2006-01-02
+	BirthDate string `json:"birth_date,omitempty" bson:"birth_date,omitempty"`
+
+	Role   string `json:"role" bson:"role"`
+	Status string `json:"status" bson:"status"`
+
+	// OrgID is the organization the user belongs to, nil for users outside of any organization
+	OrgID *uuid.UUID `json:"org_id,omitempty" bson:"org_id,omitempty"`
+
+	EmailVerified bool `json:"email_verified" bson:"email_verified"`
+	PhoneVerified bool `json:"phone_verified" bson:"phone_verified"`
+
+	// EmailReverificationRequired is set when the email bounced or received a complaint, until it is verified again
+	EmailReverificationRequired bool `json:"email_reverification_required" bson:"email_reverification_required"`
+
+	// RecoveryEmail is a secondary address used for password reset and security notifications once verified
+	RecoveryEmail         string `json:"recovery_email,omitempty" bson:"recovery_email,omitempty"`
+	RecoveryEmailVerified bool   `json:"recovery_email_verified" bson:"recovery_email_verified"`
+
+	// NotificationChannels lists the channels the user prefers to be notified on, empty for the defaults
+	NotificationChannels []string `json:"notification_channels,omitempty" bson:"notification_channels,omitempty"`
+
+	// LifecycleEmailsDisabled opts the user out of the lifecycle emails, such as the welcome email
+	LifecycleEmailsDisabled bool `json:"lifecycle_emails_disabled" bson:"lifecycle_emails_disabled"`
+
+	// Tags are admin-managed labels used to segment users, e.g. beta, vip, fraud-review
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+
+	// PasswordResetRequired blocks sign-in until the password is reset, set when the user reports suspicious activity
+	PasswordResetRequired bool `json:"password_reset_required" bson:"password_reset_required"`
+
+	// MustChangePassword makes the next sign in, with a password, a passkey or a provider, change the password
+	// before tokens are issued, set by an administrator
+	MustChangePassword bool `json:"must_change_password" bson:"must_change_password"`
+
+	// TermsAcceptedAt is when the user accepted the terms, nil if they never had to
+	TermsAcceptedAt *time.Time `json:"terms_accepted_at,omitempty" bson:"terms_accepted_at,omitempty"`
+
+	// DeletionRequestedAt and PurgeAt are set while the user is pending deletion. The account is purged at PurgeAt
+	// unless the deletion is cancelled, which restores StatusBeforeDeletion.
+	DeletionRequestedAt  *time.Time `json:"deletion_requested_at,omitempty" bson:"deletion_requested_at,omitempty"`
+	PurgeAt              *time.Time `json:"purge_at,omitempty" bson:"purge_at,omitempty"`
+	StatusBeforeDeletion string     `json:"-" bson:"status_before_deletion,omitempty"`
+
+	// LastActiveAt is when the user last signed in or refreshed their tokens, recorded at most hourly, nil if they
+	// never did
+	LastActiveAt *time.Time `json:"last_active_at,omitempty" bson:"last_active_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+
+	// Stale is set on a user served from the cache past its expiration because the database could not be read
+	Stale bool `json:"-" bson:"-"`
+}
+
+// Clone copies the user, so the copy can be changed without changing the user
+func (u *User) Clone() *User {
+	copied := *u
+	copied.Tags = slices.Clone(u.Tags)
+	copied.NotificationChannels = slices.Clone(u.NotificationChannels)
+	if u.OrgID != nil {
+		orgID := *u.OrgID
+		copied.OrgID = &orgID
+	}
+	return &copied
+}
+
+// ProfileField returns the value of a profile field of the user
+func (u *User) ProfileField(field string) string {
+	switch field {
+	case ProfileFieldFirstName:
+		return u.FirstName
+	case ProfileFieldLastName:
+		return u.LastName
+	case ProfileFieldDisplayName:
+		return u.DisplayName
+	case ProfileFieldLocale:
+		return u.Locale
+	case ProfileFieldPhone:
+		return u.Phone
+	case ProfileFieldBirthDate:
+		return u.BirthDate
+	default:
+		return ""
+	}
+}
+
+// ScheduleDeletion puts the user pending deletion, to be purged once the restoration window has elapsed
+func (u *User) ScheduleDeletion(now time.Time, window time.Duration) {
+	purgeAt := now.Add(window)
+	u.StatusBeforeDeletion = u.Status
+	u.Status = UserStatusPendingDeletion
+	u.DeletionRequestedAt = &now
+	u.PurgeAt = &purgeAt
+	u.UpdatedAt = now
+}
+
+// CancelDeletion restores a user pending deletion to the status they had when they were deleted
+func (u *User) CancelDeletion(now time.Time) {
+	u.Status = u.StatusBeforeDeletion
+	if u.Status == "" {
+		u.Status = UserStatusActive
+	}
+	u.StatusBeforeDeletion = ""
+	u.DeletionRequestedAt = nil
+	u.PurgeAt = nil
+	u.UpdatedAt = now
+}
+
+// LastActivity returns when the user was last active, their creation when they never signed in
+func (u *User) LastActivity() time.Time {
+	if u.LastActiveAt != nil {
+		return *u.LastActiveAt
+	}
+	return u.CreatedAt
+}
+
+// UserListOptions contains the filters applied when listing users
+type UserListOptions struct {
+	Status string
+
+	// Tag only lists the users carrying the tag
+	Tag string
+
+	// Role only lists the users assigned the role
+	Role string
+
+	// OrgID only lists the members of the organization
+	OrgID *uuid.UUID
+
+	// EmailVerifiedOnly and PhoneVerifiedOnly hide the users that are not verified
+	EmailVerifiedOnly bool
+	PhoneVerifiedOnly bool
+
+	// EstimatedCount returns a fast, approximate total based on collection metadata
+	EstimatedCount bool
+}
+
+// Filtered reports whether the options restrict the listed users
+func (o UserListOptions) Filtered() bool {
+	return o.Status != "" || o.Tag != "" || o.Role != "" || o.OrgID != nil || o.EmailVerifiedOnly || o.PhoneVerifiedOnly
 }
 
 // UserStatus enum
@@ -25,28 +175,104 @@ const (
 	UserStatusActive   = "active"
 	UserStatusInactive = "inactive"
 	UserStatusBlocked  = "blocked"
+	UserStatusInvited  = "invited" // Created by an administrator, without a password until the invitation is accepted
+
+	// UserStatusWaitlisted is the status of self-registered users while registrations are waitlisted, until approved
+	UserStatusWaitlisted = "waitlisted"
+
+	// UserStatusPendingDeletion is the status of deleted users until they are purged, when they can still be restored
+	UserStatusPendingDeletion = "pending_deletion"
+
+	// UserStatusDeleted is never stored on a user, it is the cached status rejecting the tokens of deleted users
+	UserStatusDeleted = "deleted"
 )
 
 // UserRole enum
 const (
-	UserRoleAdmin  = "admin"
-	UserRoleUser   = "user"
-	UserRoleMember = "member"
+	UserRoleAdmin    = "admin"
+	UserRoleOrgAdmin = "org_admin" // Administers the members of their organization only
+	UserRoleUser     = "user"
+	UserRoleMember   = "member"
 )
 
+// IsValidUserStatus reports whether status is one of the known user statuses
+func IsValidUserStatus(status string) bool {
+	switch status {
+	case UserStatusActive, UserStatusInactive, UserStatusBlocked, UserStatusInvited, UserStatusWaitlisted, UserStatusPendingDeletion:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeEmail returns the canonical form of an email address, under which addresses are stored, looked up and
+// compared, so User@Example.com and user@example.com are the same account
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsValidUsername reports whether a username can be told apart from an email when signing in
+func IsValidUsername(username string) bool {
+	return !strings.Contains(username, "@")
+}
+
+// userTagPattern restricts tags to short lowercase slugs
+var userTagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// NormalizeUserTag lowercases a tag and reports whether it is valid
+func NormalizeUserTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	return tag, userTagPattern.MatchString(tag)
+}
+
 // NewUser creates a new user with default values
 func NewUser(email, username, password, firstName, lastName string) *User {
 	now := time.Now()
 	return &User{
 		ID:        uuid.New(),
-		Email:     email,
+		Email:     NormalizeEmail(email),
 		Username:  username,
 		Password:  password, // Note: Should be hashed before saving
-		FirstName: firstName,
-		LastName:  lastName,
+		FirstName: NormalizeName(firstName),
+		LastName:  NormalizeName(lastName),
 		Role:      UserRoleUser,
 		Status:    UserStatusActive,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
+
+// NewInvitedUser creates a user invited by an administrator, without a password until the invitation is accepted
+func NewInvitedUser(email, username, firstName, lastName, role string) *User {
+	user := NewUser(email, username, "", firstName, lastName)
+	user.Role = role
+	user.Status = UserStatusInvited
+	return user
+}
+
+// UserImport is a user exported from a legacy system, with the password hash computed there and the scheme it was
+// computed with: bcrypt, argon2id, pbkdf2-sha1, pbkdf2-sha256 or pbkdf2-sha512
+type UserImport struct {
+	Email          string     `json:"email"`
+	Username       string     `json:"username"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	Role           string     `json:"role"`
+	EmailVerified  bool       `json:"email_verified"`
+	OrgID          *uuid.UUID `json:"org_id"`
+	PasswordHash   string     `json:"password_hash"`
+	PasswordScheme string     `json:"password_scheme"`
+}
+
+// UserImportReport is the outcome of an import, the users failing to import reported by their position
+type UserImportReport struct {
+	Imported int                 `json:"imported"`
+	Failed   []UserImportFailure `json:"failed"`
+}
+
+// UserImportFailure tells why a user failed to import
+type UserImportFailure struct {
+	Index int    `json:"index"`
+	Email string `json:"email"`
+	Error string `json:"error"`
+}
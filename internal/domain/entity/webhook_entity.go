@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryStatus enum
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for its next attempt
+	WebhookDeliveryDelivered = "delivered" // Acknowledged by the endpoint
+	WebhookDeliveryDead      = "dead"      // Out of attempts, kept in the dead-letter queue until replayed
+)
+
+// WebhookEndpoint is a URL receiving the domain events it subscribes to
+type WebhookEndpoint struct {
+	ID         uuid.UUID `json:"id" bson:"_id"`
+	URL        string    `json:"url" bson:"url"`
+	Secret     string    `json:"-" bson:"secret"` // Signs the deliveries, only returned when the endpoint is created
+	EventTypes []string  `json:"event_types" bson:"event_types"`
+	Paused     bool      `json:"paused" bson:"paused"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// NewWebhookEndpoint creates a new webhook endpoint
+func NewWebhookEndpoint(url, secret string, eventTypes []string) *WebhookEndpoint {
+	now := time.Now()
+	return &WebhookEndpoint{
+		ID:         uuid.New(),
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Subscribes reports whether the endpoint receives events of a type
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	for _, t := range e.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is the delivery of an event to a webhook endpoint
+type WebhookDelivery struct {
+	ID             uuid.UUID  `json:"id" bson:"_id"`
+	EndpointID     uuid.UUID  `json:"endpoint_id" bson:"endpoint_id"`
+	EventType      string     `json:"event_type" bson:"event_type"`
+	Payload        string     `json:"payload" bson:"payload"` // The serialized event envelope
+	Status         string     `json:"status" bson:"status"`
+	Attempts       int        `json:"attempts" bson:"attempts"`
+	LastStatusCode int        `json:"last_status_code,omitempty" bson:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" bson:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty" bson:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" bson:"updated_at"`
+}
+
+// NewWebhookDelivery creates a delivery of an event to an endpoint, due immediately
+func NewWebhookDelivery(endpointID uuid.UUID, eventType string, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            uuid.New(),
+		EndpointID:    endpointID,
+		EventType:     eventType,
+		Payload:       string(payload),
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// WebhookDeliveryFilter selects the deliveries of a listing, zero fields are not filtered on
+type WebhookDeliveryFilter struct {
+	EndpointID uuid.UUID
+	Status     string
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AdminNoteRepository defines the interface for the internal notes administrators attach to users
+type AdminNoteRepository interface {
+	// Create stores a new note
+	Create(ctx context.Context, note *entity.AdminNote) error
+
+	// GetByID returns a note, nil if unknown
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.AdminNote, error)
+
+	// ListByUser lists the notes on a user, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.AdminNote, error)
+
+	// Update replaces a note
+	Update(ctx context.Context, note *entity.AdminNote) error
+
+	// Delete deletes a note
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByUser deletes the notes on a user
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type adminNoteRepository struct {
+	db db.Database
+}
+
+// NewAdminNoteRepository creates a new AdminNoteRepository
+func NewAdminNoteRepository(db db.Database) AdminNoteRepository {
+	return &adminNoteRepository{
+		db: db,
+	}
+}
+
+// Create stores a new note
+func (r *adminNoteRepository) Create(ctx context.Context, note *entity.AdminNote) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createAdminNoteMongo(ctx, db, note)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByID returns a note, nil if unknown
+func (r *adminNoteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AdminNote, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getAdminNoteMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListByUser lists the notes on a user, newest first
+func (r *adminNoteRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.AdminNote, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listAdminNotesMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update replaces a note
+func (r *adminNoteRepository) Update(ctx context.Context, note *entity.AdminNote) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateAdminNoteMongo(ctx, db, note)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a note
+func (r *adminNoteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteAdminNoteMongo(ctx, db, id)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// DeleteByUser deletes the notes on a user
+func (r *adminNoteRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteAdminNotesByUserMongo(ctx, db, userID)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
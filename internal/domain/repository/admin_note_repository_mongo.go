@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createAdminNoteMongo inserts a note in MongoDB
+func (r *adminNoteRepository) createAdminNoteMongo(ctx context.Context, client *mongo.Client, note *entity.AdminNote) error {
+	collection := client.Database("user_service").Collection("user_admin_notes")
+
+	if _, err := collection.InsertOne(ctx, note); err != nil {
+		log.Error().Err(err).Str("user_id", note.UserID.String()).Msg("Failed to create admin note in MongoDB")
+		return fmt.Errorf("failed to create admin note: %w", err)
+	}
+	return nil
+}
+
+// getAdminNoteMongo gets a note from MongoDB
+func (r *adminNoteRepository) getAdminNoteMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.AdminNote, error) {
+	collection := client.Database("user_service").Collection("user_admin_notes")
+
+	var note entity.AdminNote
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&note)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Note not found
+		}
+		log.Error().Err(err).Str("note_id", id.String()).Msg("Failed to get admin note from MongoDB")
+		return nil, fmt.Errorf("failed to get admin note: %w", err)
+	}
+
+	return &note, nil
+}
+
+// listAdminNotesMongo lists the notes on a user from MongoDB, newest first
+func (r *adminNoteRepository) listAdminNotesMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) ([]*entity.AdminNote, error) {
+	collection := client.Database("user_service").Collection("user_admin_notes")
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list admin notes from MongoDB")
+		return nil, fmt.Errorf("failed to list admin notes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	notes := []*entity.AdminNote{}
+	if err := cursor.All(ctx, &notes); err != nil {
+		log.Error().Err(err).Msg("Failed to decode admin notes from MongoDB")
+		return nil, fmt.Errorf("failed to decode admin notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// updateAdminNoteMongo replaces a note in MongoDB
+func (r *adminNoteRepository) updateAdminNoteMongo(ctx context.Context, client *mongo.Client, note *entity.AdminNote) error {
+	collection := client.Database("user_service").Collection("user_admin_notes")
+
+	if _, err := collection.ReplaceOne(ctx, bson.M{"_id": note.ID}, note); err != nil {
+		log.Error().Err(err).Str("note_id", note.ID.String()).Msg("Failed to update admin note in MongoDB")
+		return fmt.Errorf("failed to update admin note: %w", err)
+	}
+	return nil
+}
+
+// deleteAdminNoteMongo deletes a note from MongoDB
+func (r *adminNoteRepository) deleteAdminNoteMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) error {
+	collection := client.Database("user_service").Collection("user_admin_notes")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Str("note_id", id.String()).Msg("Failed to delete admin note from MongoDB")
+		return fmt.Errorf("failed to delete admin note: %w", err)
+	}
+	return nil
+}
+
+// deleteAdminNotesByUserMongo deletes the notes on a user from MongoDB
+func (r *adminNoteRepository) deleteAdminNotesByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) error {
+	collection := client.Database("user_service").Collection("user_admin_notes")
+
+	if _, err := collection.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete admin notes from MongoDB")
+		return fmt.Errorf("failed to delete admin notes: %w", err)
+	}
+	return nil
+}
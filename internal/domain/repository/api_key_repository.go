@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	apiKeyCacheKeyPrefix = "api_key:"
+	apiKeyCacheTTL       = 5 * time.Minute
+)
+
+// APIKeyRepository defines the interface for the API keys users call the API with
+type APIKeyRepository interface {
+	// Create stores a new API key
+	Create(ctx context.Context, key *entity.APIKey) error
+
+	// GetByHash returns the API key of a key hash, nil if unknown
+	GetByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+
+	// ListByUser returns the API keys of a user, oldest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error)
+
+	// ListByOrg returns the API keys of an organization, oldest first
+	ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error)
+
+	// UpdateUsage stores the last use time of an API key
+	UpdateUsage(ctx context.Context, key *entity.APIKey) error
+
+	// UpdateQuota stores the quota of an API key
+	UpdateQuota(ctx context.Context, key *entity.APIKey) error
+
+	// Delete deletes an API key
+	Delete(ctx context.Context, key *entity.APIKey) error
+}
+
+type apiKeyRepository struct {
+	db    db.Database
+	cache cache.Cache
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository, caching the API keys read on every request they authenticate
+func NewAPIKeyRepository(db db.Database, cache cache.Cache) APIKeyRepository {
+	return &apiKeyRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+// Create stores a new API key
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createAPIKeyMongo(ctx, db, key)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByHash retrieves an API key by key hash
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	if data, err := r.cache.Get(ctx, apiKeyCacheKeyPrefix+hash); err == nil && data != nil {
+		var key entity.APIKey
+		if json.Unmarshal(data, &key) == nil {
+			key.Hash = hash
+			return &key, nil
+		}
+	}
+
+	var key *entity.APIKey
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		key, err = r.getAPIKeyByHashMongo(ctx, db, hash)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+	if err != nil || key == nil {
+		return key, err
+	}
+
+	// The hash is left out of the JSON encoding, it is the cache key
+	if data, err := json.Marshal(key); err == nil {
+		if err := r.cache.Set(ctx, apiKeyCacheKeyPrefix+hash, data, apiKeyCacheTTL); err != nil {
+			log.Warn().Err(err).Str("user_id", key.UserID.String()).Msg("Failed to cache API key")
+		}
+	}
+	return key, nil
+}
+
+// ListByUser retrieves the API keys of a user
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listAPIKeysByUserMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListByOrg retrieves the API keys of an organization
+func (r *apiKeyRepository) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listAPIKeysByOrgMongo(ctx, db, orgID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// UpdateUsage stores the last use time of an API key
+func (r *apiKeyRepository) UpdateUsage(ctx context.Context, key *entity.APIKey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		if err := r.updateAPIKeyUsageMongo(ctx, db, key); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	r.uncacheAPIKey(ctx, key)
+	return nil
+}
+
+// UpdateQuota stores the quota of an API key
+func (r *apiKeyRepository) UpdateQuota(ctx context.Context, key *entity.APIKey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		if err := r.updateAPIKeyQuotaMongo(ctx, db, key); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	r.uncacheAPIKey(ctx, key)
+	return nil
+}
+
+// Delete deletes an API key
+func (r *apiKeyRepository) Delete(ctx context.Context, key *entity.APIKey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		if err := r.deleteAPIKeyMongo(ctx, db, key.ID); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	r.uncacheAPIKey(ctx, key)
+	return nil
+}
+
+// uncacheAPIKey removes an API key from cache, a revoked key stays usable until its cache entry expires otherwise
+func (r *apiKeyRepository) uncacheAPIKey(ctx context.Context, key *entity.APIKey) {
+	if err := r.cache.Delete(ctx, apiKeyCacheKeyPrefix+key.Hash); err != nil {
+		log.Warn().Err(err).Str("user_id", key.UserID.String()).Msg("Failed to delete API key from cache")
+	}
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createAPIKeyMongo inserts an API key in MongoDB
+func (r *apiKeyRepository) createAPIKeyMongo(ctx context.Context, client *mongo.Client, key *entity.APIKey) error {
+	collection := client.Database("user_service").Collection("api_keys")
+
+	if _, err := collection.InsertOne(ctx, key); err != nil {
+		log.Error().Err(err).Str("user_id", key.UserID.String()).Msg("Failed to create API key in MongoDB")
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+// getAPIKeyByHashMongo gets an API key by key hash from MongoDB
+func (r *apiKeyRepository) getAPIKeyByHashMongo(ctx context.Context, client *mongo.Client, hash string) (*entity.APIKey, error) {
+	collection := client.Database("user_service").Collection("api_keys")
+
+	var key entity.APIKey
+	err := collection.FindOne(ctx, bson.M{"hash": hash}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // API key not found
+		}
+		log.Error().Err(err).Msg("Failed to get API key from MongoDB")
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// listAPIKeysByUserMongo lists the API keys of a user from MongoDB, oldest first
+func (r *apiKeyRepository) listAPIKeysByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) ([]*entity.APIKey, error) {
+	collection := client.Database("user_service").Collection("api_keys")
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list API keys from MongoDB")
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := []*entity.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to decode API keys from MongoDB")
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// listAPIKeysByOrgMongo lists the API keys of an organization from MongoDB, oldest first
+func (r *apiKeyRepository) listAPIKeysByOrgMongo(ctx context.Context, client *mongo.Client, orgID uuid.UUID) ([]*entity.APIKey, error) {
+	collection := client.Database("user_service").Collection("api_keys")
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"org_id": orgID}, opts)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to list organization API keys from MongoDB")
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := []*entity.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		log.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to decode organization API keys from MongoDB")
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// updateAPIKeyUsageMongo sets the last use time of an API key in MongoDB
+func (r *apiKeyRepository) updateAPIKeyUsageMongo(ctx context.Context, client *mongo.Client, key *entity.APIKey) error {
+	collection := client.Database("user_service").Collection("api_keys")
+
+	update := bson.M{
+		"$set": bson.M{
+			"last_used_at": key.LastUsedAt,
+		},
+	}
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": key.ID}, update); err != nil {
+		log.Error().Err(err).Str("user_id", key.UserID.String()).Msg("Failed to update API key usage in MongoDB")
+		return fmt.Errorf("failed to update API key usage: %w", err)
+	}
+	return nil
+}
+
+// updateAPIKeyQuotaMongo sets the quota of an API key in MongoDB, removing it restores the default quota
+func (r *apiKeyRepository) updateAPIKeyQuotaMongo(ctx context.Context, client *mongo.Client, key *entity.APIKey) error {
+	collection := client.Database("user_service").Collection("api_keys")
+
+	update := bson.M{"$unset": bson.M{"quota": ""}}
+	if key.Quota != nil {
+		update = bson.M{"$set": bson.M{"quota": key.Quota}}
+	}
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": key.ID}, update); err != nil {
+		log.Error().Err(err).Str("api_key_id", key.ID.String()).Msg("Failed to update API key quota in MongoDB")
+		return fmt.Errorf("failed to update API key quota: %w", err)
+	}
+	return nil
+}
+
+// deleteAPIKeyMongo deletes an API key from MongoDB
+func (r *apiKeyRepository) deleteAPIKeyMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) error {
+	collection := client.Database("user_service").Collection("api_keys")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Str("api_key_id", id.String()).Msg("Failed to delete API key from MongoDB")
+		return fmt.Errorf("failed to delete API key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AuditRepository defines the interface for audit trail repository operations
+type AuditRepository interface {
+	// Create records an audit entry
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+}
+
+type auditRepository struct {
+	db db.Database
+}
+
+// NewAuditRepository creates a new AuditRepository
+func NewAuditRepository(db db.Database) AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+// Create records an audit entry
+func (r *auditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createAuditEntryMongo(ctx, db, entry)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
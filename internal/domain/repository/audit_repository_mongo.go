@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// createAuditEntryMongo records an audit entry in MongoDB
+func (r *auditRepository) createAuditEntryMongo(ctx context.Context, client *mongo.Client, entry *entity.AuditEntry) error {
+	collection := client.Database("user_service").Collection("audit_log")
+	_, err := collection.InsertOne(ctx, entry)
+	if err != nil {
+		log.Error().Err(err).Str("action", entry.Action).Str("target_id", entry.TargetID.String()).Msg("Failed to create audit entry in MongoDB")
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BreakGlassRepository defines the interface for break-glass account repository operations
+type BreakGlassRepository interface {
+	// Save creates or replaces a break-glass account
+	Save(ctx context.Context, account *entity.BreakGlassAccount) error
+
+	// GetByUserID gets the break-glass account of a user, returns nil if the user is not a break-glass account
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.BreakGlassAccount, error)
+
+	// List all break-glass accounts ordered by username
+	List(ctx context.Context) ([]*entity.BreakGlassAccount, error)
+
+	// MarkUsed records the first use of a sealed account, returns false if it was already used since sealed
+	MarkUsed(ctx context.Context, userID uuid.UUID, usedAt time.Time) (bool, error)
+
+	// Delete a break-glass account
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+type breakGlassRepository struct {
+	db db.Database
+}
+
+// NewBreakGlassRepository creates a new BreakGlassRepository
+func NewBreakGlassRepository(db db.Database) BreakGlassRepository {
+	return &breakGlassRepository{
+		db: db,
+	}
+}
+
+// Save creates or replaces a break-glass account
+func (r *breakGlassRepository) Save(ctx context.Context, account *entity.BreakGlassAccount) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.saveBreakGlassAccountMongo(ctx, db, account)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByUserID retrieves the break-glass account of a user
+func (r *breakGlassRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.BreakGlassAccount, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getBreakGlassAccountMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists all break-glass accounts
+func (r *breakGlassRepository) List(ctx context.Context) ([]*entity.BreakGlassAccount, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listBreakGlassAccountsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// MarkUsed records the first use of a sealed account
+func (r *breakGlassRepository) MarkUsed(ctx context.Context, userID uuid.UUID, usedAt time.Time) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.markBreakGlassAccountUsedMongo(ctx, db, userID, usedAt)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a break-glass account
+func (r *breakGlassRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteBreakGlassAccountMongo(ctx, db, userID)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// saveBreakGlassAccountMongo creates or replaces a break-glass account in MongoDB
+func (r *breakGlassRepository) saveBreakGlassAccountMongo(ctx context.Context, client *mongo.Client, account *entity.BreakGlassAccount) error {
+	collection := client.Database("user_service").Collection("break_glass_accounts")
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": account.UserID}, account, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Error().Err(err).Str("user_id", account.UserID.String()).Msg("Failed to save break-glass account in MongoDB")
+		return fmt.Errorf("failed to save break-glass account: %w", err)
+	}
+	return nil
+}
+
+// getBreakGlassAccountMongo gets the break-glass account of a user from MongoDB
+func (r *breakGlassRepository) getBreakGlassAccountMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) (*entity.BreakGlassAccount, error) {
+	collection := client.Database("user_service").Collection("break_glass_accounts")
+
+	var account entity.BreakGlassAccount
+	err := collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&account)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Not a break-glass account
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get break-glass account from MongoDB")
+		return nil, fmt.Errorf("failed to get break-glass account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// listBreakGlassAccountsMongo lists all break-glass accounts from MongoDB
+func (r *breakGlassRepository) listBreakGlassAccountsMongo(ctx context.Context, client *mongo.Client) ([]*entity.BreakGlassAccount, error) {
+	collection := client.Database("user_service").Collection("break_glass_accounts")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "username", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list break-glass accounts from MongoDB")
+		return nil, fmt.Errorf("failed to list break-glass accounts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	accounts := []*entity.BreakGlassAccount{}
+	if err := cursor.All(ctx, &accounts); err != nil {
+		log.Error().Err(err).Msg("Failed to decode break-glass accounts from MongoDB")
+		return nil, fmt.Errorf("failed to decode break-glass accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// markBreakGlassAccountUsedMongo sets the first use of a sealed break-glass account in MongoDB
+func (r *breakGlassRepository) markBreakGlassAccountUsedMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID, usedAt time.Time) (bool, error) {
+	collection := client.Database("user_service").Collection("break_glass_accounts")
+
+	filter := bson.M{"_id": userID, "used_at": bson.M{"$exists": false}}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"used_at": usedAt}})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to mark break-glass account used in MongoDB")
+		return false, fmt.Errorf("failed to mark break-glass account used: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// deleteBreakGlassAccountMongo deletes a break-glass account from MongoDB
+func (r *breakGlassRepository) deleteBreakGlassAccountMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) error {
+	collection := client.Database("user_service").Collection("break_glass_accounts")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete break-glass account from MongoDB")
+		return fmt.Errorf("failed to delete break-glass account: %w", err)
+	}
+
+	return nil
+}
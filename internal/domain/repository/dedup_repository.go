@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const dedupPrefix = "dedup:"
+
+// DedupRepository defines the interface for short-lived claims deduplicating requests across instances
+type DedupRepository interface {
+	// Claim claims a key of a scope for the given window, reporting false if it is already claimed
+	Claim(ctx context.Context, scope, key string, window time.Duration) (bool, error)
+
+	// Release releases a claim before its window ends
+	Release(ctx context.Context, scope, key string) error
+}
+
+type dedupRepository struct {
+	cache cache.Cache
+}
+
+// NewDedupRepository creates a new dedup repository
+func NewDedupRepository(cache cache.Cache) DedupRepository {
+	return &dedupRepository{
+		cache: cache,
+	}
+}
+
+// Claim claims a key of a scope for the given window, reporting false if it is already claimed
+func (r *dedupRepository) Claim(ctx context.Context, scope, key string, window time.Duration) (bool, error) {
+	claimed, err := r.cache.SetNX(ctx, dedupKey(scope, key), []byte("1"), window)
+	if err != nil {
+		log.Error().Err(err).Str("scope", scope).Msg("Failed to claim dedup key")
+		return false, fmt.Errorf("failed to claim dedup key: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// Release releases a claim before its window ends
+func (r *dedupRepository) Release(ctx context.Context, scope, key string) error {
+	if err := r.cache.Delete(ctx, dedupKey(scope, key)); err != nil {
+		log.Error().Err(err).Str("scope", scope).Msg("Failed to release dedup key")
+		return fmt.Errorf("failed to release dedup key: %w", err)
+	}
+
+	return nil
+}
+
+// dedupKey returns the cache key of a claim
+func dedupKey(scope, key string) string {
+	return dedupPrefix + scope + ":" + key
+}
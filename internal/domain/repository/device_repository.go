@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	deviceCodePrefix = "device_code:"
+	userCodePrefix   = "device_user_code:"
+)
+
+// DeviceAuthorizationRepository defines the interface for the pending sign ins of headless devices
+type DeviceAuthorizationRepository interface {
+	// Create stores a new device authorization of a device code until it expires,
+	// reporting false if its user code is already used by another one
+	Create(ctx context.Context, deviceCode string, authorization *entity.DeviceAuthorization) (bool, error)
+
+	// GetByDeviceCode returns the device authorization of a device code, nil if unknown or expired
+	GetByDeviceCode(ctx context.Context, deviceCode string) (*entity.DeviceAuthorization, error)
+
+	// GetByUserCode returns the device authorization of a user code, nil if unknown or expired
+	GetByUserCode(ctx context.Context, userCode string) (*entity.DeviceAuthorization, error)
+
+	// Update stores the changes of a device authorization, keeping its expiration
+	Update(ctx context.Context, authorization *entity.DeviceAuthorization) error
+
+	// Delete deletes a device authorization and its user code
+	Delete(ctx context.Context, authorization *entity.DeviceAuthorization) error
+}
+
+type deviceAuthorizationRepository struct {
+	cache cache.Cache
+}
+
+// NewDeviceAuthorizationRepository creates a new device authorization repository
+func NewDeviceAuthorizationRepository(cache cache.Cache) DeviceAuthorizationRepository {
+	return &deviceAuthorizationRepository{
+		cache: cache,
+	}
+}
+
+// deviceCodeHash returns the hash identifying a device code.
+// Only a hash of the code is stored, so the cache contents cannot be replayed.
+func deviceCodeHash(deviceCode string) string {
+	sum := sha256.Sum256([]byte(deviceCode))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create stores a new device authorization of a device code until it expires, reporting false if its user code is already used
+func (r *deviceAuthorizationRepository) Create(ctx context.Context, deviceCode string, authorization *entity.DeviceAuthorization) (bool, error) {
+	authorization.ID = deviceCodeHash(deviceCode)
+	expiration := time.Until(authorization.ExpiresAt)
+
+	// The user code is claimed first, the codes are short enough for live ones to collide
+	claimed, err := r.cache.SetNX(ctx, userCodePrefix+authorization.UserCode, []byte(authorization.ID), expiration)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to store user code in cache")
+		return false, fmt.Errorf("failed to store user code: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	if err := r.Update(ctx, authorization); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetByDeviceCode returns the device authorization of a device code, nil if unknown or expired
+func (r *deviceAuthorizationRepository) GetByDeviceCode(ctx context.Context, deviceCode string) (*entity.DeviceAuthorization, error) {
+	return r.get(ctx, deviceCodeHash(deviceCode))
+}
+
+// GetByUserCode returns the device authorization of a user code, nil if unknown or expired
+func (r *deviceAuthorizationRepository) GetByUserCode(ctx context.Context, userCode string) (*entity.DeviceAuthorization, error) {
+	id, err := r.cache.Get(ctx, userCodePrefix+userCode)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get user code from cache")
+		return nil, fmt.Errorf("failed to get user code: %w", err)
+	}
+	if id == nil {
+		return nil, nil
+	}
+
+	return r.get(ctx, string(id))
+}
+
+// get returns the device authorization of a device code hash, nil if unknown or expired
+func (r *deviceAuthorizationRepository) get(ctx context.Context, id string) (*entity.DeviceAuthorization, error) {
+	data, err := r.cache.Get(ctx, deviceCodePrefix+id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get device authorization from cache")
+		return nil, fmt.Errorf("failed to get device authorization: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var authorization entity.DeviceAuthorization
+	if err := json.Unmarshal(data, &authorization); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal device authorization: %w", err)
+	}
+
+	return &authorization, nil
+}
+
+// Update stores the changes of a device authorization, keeping its expiration
+func (r *deviceAuthorizationRepository) Update(ctx context.Context, authorization *entity.DeviceAuthorization) error {
+	expiration := time.Until(authorization.ExpiresAt)
+	if expiration <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(authorization)
+	if err != nil {
+		return fmt.Errorf("failed to marshal device authorization: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, deviceCodePrefix+authorization.ID, data, expiration); err != nil {
+		log.Error().Err(err).Str("client_id", authorization.ClientID).Msg("Failed to store device authorization in cache")
+		return fmt.Errorf("failed to store device authorization: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a device authorization and its user code
+func (r *deviceAuthorizationRepository) Delete(ctx context.Context, authorization *entity.DeviceAuthorization) error {
+	if err := r.cache.Delete(ctx, deviceCodePrefix+authorization.ID); err != nil {
+		log.Error().Err(err).Msg("Failed to delete device authorization from cache")
+		return fmt.Errorf("failed to delete device authorization: %w", err)
+	}
+
+	// A leftover user code points to nothing and expires with the authorization
+	if err := r.cache.Delete(ctx, userCodePrefix+authorization.UserCode); err != nil {
+		log.Warn().Err(err).Msg("Failed to delete user code from cache")
+	}
+
+	return nil
+}
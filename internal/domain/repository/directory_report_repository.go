@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const directoryReportKey = "directory:drift_report"
+
+// DirectoryReportRepository defines the interface for the drift report of the latest reconciliation of the users
+// against the upstream directory, shared by all instances
+type DirectoryReportRepository interface {
+	// Store replaces the latest report
+	Store(ctx context.Context, report *entity.DirectoryDriftReport) error
+
+	// Latest returns the latest report, nil if no reconciliation ran yet
+	Latest(ctx context.Context) (*entity.DirectoryDriftReport, error)
+}
+
+type directoryReportRepository struct {
+	cache cache.Cache
+}
+
+// NewDirectoryReportRepository creates a new directory report repository
+func NewDirectoryReportRepository(cache cache.Cache) DirectoryReportRepository {
+	return &directoryReportRepository{
+		cache: cache,
+	}
+}
+
+// Store replaces the latest report, kept without expiration
+func (r *directoryReportRepository) Store(ctx context.Context, report *entity.DirectoryDriftReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal directory drift report: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, directoryReportKey, data, 0); err != nil {
+		log.Error().Err(err).Msg("Failed to store directory drift report in cache")
+		return fmt.Errorf("failed to store directory drift report: %w", err)
+	}
+
+	return nil
+}
+
+// Latest returns the latest report, nil if no reconciliation ran yet
+func (r *directoryReportRepository) Latest(ctx context.Context) (*entity.DirectoryDriftReport, error) {
+	data, err := r.cache.Get(ctx, directoryReportKey)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get directory drift report from cache")
+		return nil, fmt.Errorf("failed to get directory drift report: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var report entity.DirectoryDriftReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal directory drift report: %w", err)
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	inactivityWarningPrefix = "inactivity:warning:"
+	inactivityReportKey     = "inactivity:report"
+)
+
+// InactivityRepository defines the interface for the warnings and latest report of the inactivity policy, shared by
+// all instances
+type InactivityRepository interface {
+	// WarnedAt returns the time a user inactive since a time was warned, zero if they were not
+	WarnedAt(ctx context.Context, userID uuid.UUID, inactiveSince time.Time) (time.Time, error)
+
+	// SetWarnedAt records the time a user inactive since a time was warned, remembered for the retention
+	SetWarnedAt(ctx context.Context, userID uuid.UUID, inactiveSince, at time.Time, retention time.Duration) error
+
+	// StoreReport replaces the latest report
+	StoreReport(ctx context.Context, report *entity.InactivityReport) error
+
+	// LatestReport returns the latest report, nil if the policy never ran
+	LatestReport(ctx context.Context) (*entity.InactivityReport, error)
+}
+
+type inactivityRepository struct {
+	cache cache.Cache
+}
+
+// NewInactivityRepository creates a new inactivity repository
+func NewInactivityRepository(cache cache.Cache) InactivityRepository {
+	return &inactivityRepository{
+		cache: cache,
+	}
+}
+
+// warningKey returns the key of the warning of a user inactive since a time, a user active again is warned anew
+func warningKey(userID uuid.UUID, inactiveSince time.Time) string {
+	return inactivityWarningPrefix + userID.String() + ":" + strconv.FormatInt(inactiveSince.Unix(), 10)
+}
+
+// WarnedAt returns the time a user inactive since a time was warned, zero if they were not
+func (r *inactivityRepository) WarnedAt(ctx context.Context, userID uuid.UUID, inactiveSince time.Time) (time.Time, error) {
+	data, err := r.cache.Get(ctx, warningKey(userID, inactiveSince))
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get inactivity warning from cache")
+		return time.Time{}, fmt.Errorf("failed to get inactivity warning: %w", err)
+	}
+	if data == nil {
+		return time.Time{}, nil
+	}
+
+	var at time.Time
+	if err := at.UnmarshalText(data); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unmarshal inactivity warning: %w", err)
+	}
+	return at, nil
+}
+
+// SetWarnedAt records the time a user inactive since a time was warned
+func (r *inactivityRepository) SetWarnedAt(ctx context.Context, userID uuid.UUID, inactiveSince, at time.Time, retention time.Duration) error {
+	data, err := at.MarshalText()
+	if err != nil {
+		return fmt.Errorf("failed to marshal inactivity warning: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, warningKey(userID, inactiveSince), data, retention); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to store inactivity warning in cache")
+		return fmt.Errorf("failed to store inactivity warning: %w", err)
+	}
+	return nil
+}
+
+// StoreReport replaces the latest report, kept without expiration
+func (r *inactivityRepository) StoreReport(ctx context.Context, report *entity.InactivityReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inactivity report: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, inactivityReportKey, data, 0); err != nil {
+		log.Error().Err(err).Msg("Failed to store inactivity report in cache")
+		return fmt.Errorf("failed to store inactivity report: %w", err)
+	}
+	return nil
+}
+
+// LatestReport returns the latest report, nil if the policy never ran
+func (r *inactivityRepository) LatestReport(ctx context.Context) (*entity.InactivityReport, error) {
+	data, err := r.cache.Get(ctx, inactivityReportKey)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get inactivity report from cache")
+		return nil, fmt.Errorf("failed to get inactivity report: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var report entity.InactivityReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal inactivity report: %w", err)
+	}
+	return &report, nil
+}
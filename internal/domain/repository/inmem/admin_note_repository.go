@@ -0,0 +1,103 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type adminNoteRepository struct {
+	mu    sync.RWMutex
+	notes map[uuid.UUID]*entity.AdminNote
+}
+
+// NewAdminNoteRepository creates a new AdminNoteRepository keeping the notes in memory
+func NewAdminNoteRepository() repository.AdminNoteRepository {
+	return &adminNoteRepository{
+		notes: map[uuid.UUID]*entity.AdminNote{},
+	}
+}
+
+// Create stores a new note
+func (r *adminNoteRepository) Create(ctx context.Context, note *entity.AdminNote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.notes[note.ID]; ok {
+		return fmt.Errorf("failed to create admin note: note %s already exists", note.ID)
+	}
+	copied := *note
+	r.notes[note.ID] = &copied
+	return nil
+}
+
+// GetByID returns a note, nil if unknown
+func (r *adminNoteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AdminNote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	note, ok := r.notes[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *note
+	return &copied, nil
+}
+
+// ListByUser lists the notes on a user, newest first
+func (r *adminNoteRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.AdminNote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes := []*entity.AdminNote{}
+	for _, note := range r.notes {
+		if note.UserID == userID {
+			copied := *note
+			notes = append(notes, &copied)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.After(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+// Update replaces a note
+func (r *adminNoteRepository) Update(ctx context.Context, note *entity.AdminNote) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.notes[note.ID]; !ok {
+		return nil
+	}
+	copied := *note
+	r.notes[note.ID] = &copied
+	return nil
+}
+
+// Delete deletes a note
+func (r *adminNoteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.notes, id)
+	return nil
+}
+
+// DeleteByUser deletes the notes on a user
+func (r *adminNoteRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, note := range r.notes {
+		if note.UserID == userID {
+			delete(r.notes, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type apiKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[uuid.UUID]*entity.APIKey
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository keeping API keys in memory
+func NewAPIKeyRepository() repository.APIKeyRepository {
+	return &apiKeyRepository{
+		keys: map[uuid.UUID]*entity.APIKey{},
+	}
+}
+
+// copyAPIKey returns a copy of an API key sharing nothing with the stored one
+func copyAPIKey(key *entity.APIKey) *entity.APIKey {
+	copied := *key
+	copied.Scopes = slices.Clone(key.Scopes)
+	if key.ExpiresAt != nil {
+		expiresAt := *key.ExpiresAt
+		copied.ExpiresAt = &expiresAt
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt := *key.LastUsedAt
+		copied.LastUsedAt = &lastUsedAt
+	}
+	if key.OrgID != nil {
+		orgID := *key.OrgID
+		copied.OrgID = &orgID
+	}
+	if key.Quota != nil {
+		quota := *key.Quota
+		copied.Quota = &quota
+	}
+	return &copied
+}
+
+// Create stores a new API key
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[key.ID]; ok {
+		return fmt.Errorf("failed to create API key: API key %s already exists", key.ID)
+	}
+	r.keys[key.ID] = copyAPIKey(key)
+	return nil
+}
+
+// GetByHash returns the API key of a key hash, nil if unknown
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			return copyAPIKey(key), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListByUser returns the API keys of a user, oldest first
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := []*entity.APIKey{}
+	for _, key := range r.keys {
+		if key.UserID == userID {
+			keys = append(keys, copyAPIKey(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// ListByOrg returns the API keys of an organization, oldest first
+func (r *apiKeyRepository) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := []*entity.APIKey{}
+	for _, key := range r.keys {
+		if key.OrgID != nil && *key.OrgID == orgID {
+			keys = append(keys, copyAPIKey(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// UpdateUsage stores the last use time of an API key
+func (r *apiKeyRepository) UpdateUsage(ctx context.Context, key *entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.keys[key.ID]
+	if !ok {
+		return nil
+	}
+	stored.LastUsedAt = copyAPIKey(key).LastUsedAt
+	return nil
+}
+
+// UpdateQuota stores the quota of an API key
+func (r *apiKeyRepository) UpdateQuota(ctx context.Context, key *entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.keys[key.ID]
+	if !ok {
+		return nil
+	}
+	stored.Quota = copyAPIKey(key).Quota
+	return nil
+}
+
+// Delete deletes an API key
+func (r *apiKeyRepository) Delete(ctx context.Context, key *entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, key.ID)
+	return nil
+}
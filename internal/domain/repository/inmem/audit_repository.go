@@ -0,0 +1,29 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type auditRepository struct {
+	mu      sync.Mutex
+	entries []*entity.AuditEntry
+}
+
+// NewAuditRepository creates a new AuditRepository keeping the audit trail in memory
+func NewAuditRepository() repository.AuditRepository {
+	return &auditRepository{}
+}
+
+// Create records an audit entry
+func (r *auditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *entry
+	r.entries = append(r.entries, &copied)
+	return nil
+}
@@ -0,0 +1,82 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type breakGlassRepository struct {
+	mu       sync.RWMutex
+	accounts map[uuid.UUID]*entity.BreakGlassAccount
+}
+
+// NewBreakGlassRepository creates a new BreakGlassRepository keeping break-glass accounts in memory
+func NewBreakGlassRepository() repository.BreakGlassRepository {
+	return &breakGlassRepository{
+		accounts: map[uuid.UUID]*entity.BreakGlassAccount{},
+	}
+}
+
+// Save creates or replaces a break-glass account
+func (r *breakGlassRepository) Save(ctx context.Context, account *entity.BreakGlassAccount) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *account
+	r.accounts[account.UserID] = &copied
+	return nil
+}
+
+// GetByUserID gets the break-glass account of a user, returns nil if the user is not a break-glass account
+func (r *breakGlassRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.BreakGlassAccount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if account, ok := r.accounts[userID]; ok {
+		copied := *account
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// List all break-glass accounts ordered by username
+func (r *breakGlassRepository) List(ctx context.Context) ([]*entity.BreakGlassAccount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accounts := make([]*entity.BreakGlassAccount, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		copied := *account
+		accounts = append(accounts, &copied)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Username < accounts[j].Username })
+	return accounts, nil
+}
+
+// MarkUsed records the first use of a sealed account, returns false if it was already used since sealed
+func (r *breakGlassRepository) MarkUsed(ctx context.Context, userID uuid.UUID, usedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	account, ok := r.accounts[userID]
+	if !ok || account.UsedAt != nil {
+		return false, nil
+	}
+	account.UsedAt = &usedAt
+	return true, nil
+}
+
+// Delete a break-glass account
+func (r *breakGlassRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.accounts, userID)
+	return nil
+}
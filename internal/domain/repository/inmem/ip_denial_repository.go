@@ -0,0 +1,73 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type ipDenialRepository struct {
+	mu      sync.RWMutex
+	denials map[string]*entity.IPDenial
+}
+
+// NewIPDenialRepository creates a new IPDenialRepository keeping IP denials in memory
+func NewIPDenialRepository() repository.IPDenialRepository {
+	return &ipDenialRepository{
+		denials: map[string]*entity.IPDenial{},
+	}
+}
+
+// Create denies an IP address, returns false if the address is already denied
+func (r *ipDenialRepository) Create(ctx context.Context, denial *entity.IPDenial) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.denials[denial.IP]; ok && existing.IsActive(denial.CreatedAt) {
+		return false, nil
+	}
+	copied := *denial
+	r.denials[denial.IP] = &copied
+	return true, nil
+}
+
+// GetActive gets the denial of an IP address, returns nil if the address is not denied
+func (r *ipDenialRepository) GetActive(ctx context.Context, ip string, now time.Time) (*entity.IPDenial, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if denial, ok := r.denials[ip]; ok && denial.IsActive(now) {
+		copied := *denial
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// ListActive lists the denied IP addresses, newest first
+func (r *ipDenialRepository) ListActive(ctx context.Context, now time.Time) ([]*entity.IPDenial, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	denials := []*entity.IPDenial{}
+	for _, denial := range r.denials {
+		if denial.IsActive(now) {
+			copied := *denial
+			denials = append(denials, &copied)
+		}
+	}
+	sort.Slice(denials, func(i, j int) bool { return denials[i].CreatedAt.After(denials[j].CreatedAt) })
+	return denials, nil
+}
+
+// Delete lifts the denial of an IP address
+func (r *ipDenialRepository) Delete(ctx context.Context, ip string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.denials, ip)
+	return nil
+}
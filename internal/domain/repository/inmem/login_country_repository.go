@@ -0,0 +1,51 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type loginCountryRepository struct {
+	mu        sync.RWMutex
+	countries map[uuid.UUID][]string
+}
+
+// NewLoginCountryRepository creates a new LoginCountryRepository keeping the login countries in memory
+func NewLoginCountryRepository() repository.LoginCountryRepository {
+	return &loginCountryRepository{
+		countries: map[uuid.UUID][]string{},
+	}
+}
+
+// Add records a country a user signed in from, returns false if it was already recorded
+func (r *loginCountryRepository) Add(ctx context.Context, userID uuid.UUID, country string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.Contains(r.countries[userID], country) {
+		return false, nil
+	}
+	r.countries[userID] = append(r.countries[userID], country)
+	return true, nil
+}
+
+// List the countries a user signed in from
+func (r *loginCountryRepository) List(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string{}, r.countries[userID]...), nil
+}
+
+// DeleteByUser deletes the countries of a user
+func (r *loginCountryRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.countries, userID)
+	return nil
+}
@@ -0,0 +1,88 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type oauthIdentityRepository struct {
+	mu         sync.RWMutex
+	identities map[string]*entity.OAuthIdentity
+}
+
+// NewOAuthIdentityRepository creates a new OAuthIdentityRepository keeping identities in memory
+func NewOAuthIdentityRepository() repository.OAuthIdentityRepository {
+	return &oauthIdentityRepository{
+		identities: map[string]*entity.OAuthIdentity{},
+	}
+}
+
+// Create stores a new identity, ErrOAuthIdentityExists if the account is linked already
+func (r *oauthIdentityRepository) Create(ctx context.Context, identity *entity.OAuthIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.identities[identity.ID]; ok {
+		return repository.ErrOAuthIdentityExists
+	}
+	copied := *identity
+	r.identities[identity.ID] = &copied
+	return nil
+}
+
+// Get returns the identity of an account at a provider, nil if the account is not linked
+func (r *oauthIdentityRepository) Get(ctx context.Context, provider, subject string) (*entity.OAuthIdentity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if identity, ok := r.identities[entity.OAuthIdentityID(provider, subject)]; ok {
+		copied := *identity
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// ListByUser returns the identities of a user, oldest first
+func (r *oauthIdentityRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.OAuthIdentity, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	identities := []*entity.OAuthIdentity{}
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			copied := *identity
+			identities = append(identities, &copied)
+		}
+	}
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].CreatedAt.Before(identities[j].CreatedAt)
+	})
+	return identities, nil
+}
+
+// Delete deletes an identity, unlinking the account from its user
+func (r *oauthIdentityRepository) Delete(ctx context.Context, identity *entity.OAuthIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.identities, identity.ID)
+	return nil
+}
+
+// DeleteByUser deletes the identities of a user
+func (r *oauthIdentityRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, identity := range r.identities {
+		if identity.UserID == userID {
+			delete(r.identities, id)
+		}
+	}
+	return nil
+}
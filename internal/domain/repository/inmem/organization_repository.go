@@ -0,0 +1,79 @@
+package inmem
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type organizationRepository struct {
+	mu   sync.RWMutex
+	orgs map[uuid.UUID]*entity.Organization
+}
+
+// NewOrganizationRepository creates a new OrganizationRepository keeping organizations in memory
+func NewOrganizationRepository() repository.OrganizationRepository {
+	return &organizationRepository{
+		orgs: map[uuid.UUID]*entity.Organization{},
+	}
+}
+
+// Create a new organization
+func (r *organizationRepository) Create(ctx context.Context, org *entity.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.orgs[org.ID] = copyOrganization(org)
+	return nil
+}
+
+// GetByID gets an organization by ID, returns nil if the organization does not exist
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if org, ok := r.orgs[id]; ok {
+		return copyOrganization(org), nil
+	}
+	return nil, nil
+}
+
+// List all organizations ordered by name
+func (r *organizationRepository) List(ctx context.Context) ([]*entity.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgs := make([]*entity.Organization, 0, len(r.orgs))
+	for _, org := range r.orgs {
+		orgs = append(orgs, copyOrganization(org))
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Name < orgs[j].Name })
+	return orgs, nil
+}
+
+// Update the settings of an organization
+func (r *organizationRepository) Update(ctx context.Context, org *entity.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orgs[org.ID]; ok {
+		r.orgs[org.ID] = copyOrganization(org)
+	}
+	return nil
+}
+
+// copyOrganization copies an organization so callers never share the stored value
+func copyOrganization(org *entity.Organization) *entity.Organization {
+	copied := *org
+	copied.ProfileFields = maps.Clone(org.ProfileFields)
+	if org.MemberLimit != nil {
+		limit := *org.MemberLimit
+		copied.MemberLimit = &limit
+	}
+	return &copied
+}
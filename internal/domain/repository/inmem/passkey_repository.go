@@ -0,0 +1,126 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type passkeyRepository struct {
+	mu       sync.RWMutex
+	passkeys map[string]*entity.Passkey
+}
+
+// NewPasskeyRepository creates a new PasskeyRepository keeping passkeys in memory
+func NewPasskeyRepository() repository.PasskeyRepository {
+	return &passkeyRepository{
+		passkeys: map[string]*entity.Passkey{},
+	}
+}
+
+// copyPasskey returns a copy of a passkey sharing nothing with the stored one
+func copyPasskey(passkey *entity.Passkey) *entity.Passkey {
+	copied := *passkey
+	copied.PublicKey = slices.Clone(passkey.PublicKey)
+	copied.Transports = slices.Clone(passkey.Transports)
+	if passkey.LastUsedAt != nil {
+		lastUsedAt := *passkey.LastUsedAt
+		copied.LastUsedAt = &lastUsedAt
+	}
+	return &copied
+}
+
+// Create stores a new passkey
+func (r *passkeyRepository) Create(ctx context.Context, passkey *entity.Passkey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.passkeys[passkey.ID]; ok {
+		return fmt.Errorf("failed to create passkey: passkey %s already exists", passkey.ID)
+	}
+	r.passkeys[passkey.ID] = copyPasskey(passkey)
+	return nil
+}
+
+// GetByID returns a passkey by credential ID, nil if unknown
+func (r *passkeyRepository) GetByID(ctx context.Context, id string) (*entity.Passkey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if passkey, ok := r.passkeys[id]; ok {
+		return copyPasskey(passkey), nil
+	}
+	return nil, nil
+}
+
+// ListByUser returns the passkeys of a user, oldest first
+func (r *passkeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Passkey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	passkeys := []*entity.Passkey{}
+	for _, passkey := range r.passkeys {
+		if passkey.UserID == userID {
+			passkeys = append(passkeys, copyPasskey(passkey))
+		}
+	}
+	sort.Slice(passkeys, func(i, j int) bool {
+		return passkeys[i].CreatedAt.Before(passkeys[j].CreatedAt)
+	})
+	return passkeys, nil
+}
+
+// UpdateUsage stores the signature counter and last use time of a passkey
+func (r *passkeyRepository) UpdateUsage(ctx context.Context, passkey *entity.Passkey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.passkeys[passkey.ID]
+	if !ok {
+		return nil
+	}
+	updated := copyPasskey(passkey)
+	stored.SignCount = updated.SignCount
+	stored.LastUsedAt = updated.LastUsedAt
+	return nil
+}
+
+// Delete deletes a passkey
+func (r *passkeyRepository) Delete(ctx context.Context, passkey *entity.Passkey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.passkeys, passkey.ID)
+	return nil
+}
+
+// DeleteByUser deletes the passkeys of a user
+func (r *passkeyRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, passkey := range r.passkeys {
+		if passkey.UserID == userID {
+			delete(r.passkeys, id)
+		}
+	}
+	return nil
+}
+
+// CountUsers counts the users holding at least one passkey
+func (r *passkeyRepository) CountUsers(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := map[uuid.UUID]bool{}
+	for _, passkey := range r.passkeys {
+		users[passkey.UserID] = true
+	}
+	return int64(len(users)), nil
+}
@@ -0,0 +1,83 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type permissionGroupRepository struct {
+	mu     sync.RWMutex
+	groups map[string]*entity.PermissionGroup
+}
+
+// NewPermissionGroupRepository creates a new PermissionGroupRepository keeping permission groups in memory
+func NewPermissionGroupRepository() repository.PermissionGroupRepository {
+	return &permissionGroupRepository{
+		groups: map[string]*entity.PermissionGroup{},
+	}
+}
+
+// Create a new permission group
+func (r *permissionGroupRepository) Create(ctx context.Context, group *entity.PermissionGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.groups[group.Name] = copyPermissionGroup(group)
+	return nil
+}
+
+// GetByName gets a permission group by name, returns nil if the group does not exist
+func (r *permissionGroupRepository) GetByName(ctx context.Context, name string) (*entity.PermissionGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if group, ok := r.groups[name]; ok {
+		return copyPermissionGroup(group), nil
+	}
+	return nil, nil
+}
+
+// List all permission groups ordered by name
+func (r *permissionGroupRepository) List(ctx context.Context) ([]*entity.PermissionGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	groups := make([]*entity.PermissionGroup, 0, len(r.groups))
+	for _, group := range r.groups {
+		groups = append(groups, copyPermissionGroup(group))
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// Update a permission group
+func (r *permissionGroupRepository) Update(ctx context.Context, group *entity.PermissionGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[group.Name]; ok {
+		r.groups[group.Name] = copyPermissionGroup(group)
+	}
+	return nil
+}
+
+// Delete a permission group
+func (r *permissionGroupRepository) Delete(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.groups, name)
+	return nil
+}
+
+// copyPermissionGroup copies a permission group so callers never share the stored value
+func copyPermissionGroup(group *entity.PermissionGroup) *entity.PermissionGroup {
+	copied := *group
+	copied.Permissions = slices.Clone(group.Permissions)
+	return &copied
+}
@@ -0,0 +1,79 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type permissionRepository struct {
+	mu          sync.RWMutex
+	permissions map[string]*entity.Permission
+}
+
+// NewPermissionRepository creates a new PermissionRepository keeping custom permissions in memory
+func NewPermissionRepository() repository.PermissionRepository {
+	return &permissionRepository{
+		permissions: map[string]*entity.Permission{},
+	}
+}
+
+// Create a new permission
+func (r *permissionRepository) Create(ctx context.Context, permission *entity.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *permission
+	r.permissions[permission.Name] = &copied
+	return nil
+}
+
+// GetByName gets a permission by name, returns nil if the permission does not exist
+func (r *permissionRepository) GetByName(ctx context.Context, name string) (*entity.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if permission, ok := r.permissions[name]; ok {
+		copied := *permission
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// List all permissions ordered by name
+func (r *permissionRepository) List(ctx context.Context) ([]*entity.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permissions := make([]*entity.Permission, 0, len(r.permissions))
+	for _, permission := range r.permissions {
+		copied := *permission
+		permissions = append(permissions, &copied)
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i].Name < permissions[j].Name })
+	return permissions, nil
+}
+
+// Update a permission
+func (r *permissionRepository) Update(ctx context.Context, permission *entity.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.permissions[permission.Name]; ok {
+		copied := *permission
+		r.permissions[permission.Name] = &copied
+	}
+	return nil
+}
+
+// Delete a permission
+func (r *permissionRepository) Delete(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.permissions, name)
+	return nil
+}
@@ -0,0 +1,128 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type referralRepository struct {
+	mu        sync.RWMutex
+	codes     map[string]*entity.ReferralCode
+	referrals map[uuid.UUID]*entity.Referral
+}
+
+// NewReferralRepository creates a new ReferralRepository keeping referral codes and referrals in memory
+func NewReferralRepository() repository.ReferralRepository {
+	return &referralRepository{
+		codes:     map[string]*entity.ReferralCode{},
+		referrals: map[uuid.UUID]*entity.Referral{},
+	}
+}
+
+// CreateCode stores a referral code, returns false if the code is already taken
+func (r *referralRepository) CreateCode(ctx context.Context, code *entity.ReferralCode) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.codes[code.Code]; ok {
+		return false, nil
+	}
+	copied := *code
+	r.codes[code.Code] = &copied
+	return true, nil
+}
+
+// GetCode returns a referral code, nil if unknown
+func (r *referralRepository) GetCode(ctx context.Context, code string) (*entity.ReferralCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if referralCode, ok := r.codes[code]; ok {
+		copied := *referralCode
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// GetCodeByUser returns the referral code of a user, nil if they have none
+func (r *referralRepository) GetCodeByUser(ctx context.Context, userID uuid.UUID) (*entity.ReferralCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var oldest *entity.ReferralCode
+	for _, code := range r.codes {
+		if code.UserID == userID && (oldest == nil || code.CreatedAt.Before(oldest.CreatedAt)) {
+			oldest = code
+		}
+	}
+	if oldest == nil {
+		return nil, nil
+	}
+	copied := *oldest
+	return &copied, nil
+}
+
+// Create records a referral
+func (r *referralRepository) Create(ctx context.Context, referral *entity.Referral) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.referrals[referral.RefereeID]; ok {
+		return fmt.Errorf("failed to create referral: user %s is already referred", referral.RefereeID)
+	}
+	copied := *referral
+	r.referrals[referral.RefereeID] = &copied
+	return nil
+}
+
+// GetStats returns the number of users referred by a user and the time of the last referral, without the code
+func (r *referralRepository) GetStats(ctx context.Context, referrerID uuid.UUID) (*entity.ReferralStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &entity.ReferralStats{}
+	for _, referral := range r.referrals {
+		if referral.ReferrerID != referrerID {
+			continue
+		}
+		stats.Referrals++
+		if stats.LastReferralAt == nil || referral.CreatedAt.After(*stats.LastReferralAt) {
+			createdAt := referral.CreatedAt
+			stats.LastReferralAt = &createdAt
+		}
+	}
+	return stats, nil
+}
+
+// Leaderboard returns the users with the most referrals, most referrals first
+func (r *referralRepository) Leaderboard(ctx context.Context, limit int) ([]*entity.ReferrerRanking, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := map[uuid.UUID]int64{}
+	for _, referral := range r.referrals {
+		counts[referral.ReferrerID]++
+	}
+
+	rankings := make([]*entity.ReferrerRanking, 0, len(counts))
+	for userID, referrals := range counts {
+		rankings = append(rankings, &entity.ReferrerRanking{UserID: userID, Referrals: referrals})
+	}
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].Referrals != rankings[j].Referrals {
+			return rankings[i].Referrals > rankings[j].Referrals
+		}
+		return rankings[i].UserID.String() < rankings[j].UserID.String()
+	})
+
+	if len(rankings) > limit {
+		rankings = rankings[:limit]
+	}
+	return rankings, nil
+}
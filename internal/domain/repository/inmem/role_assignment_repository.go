@@ -0,0 +1,127 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type roleAssignmentRepository struct {
+	mu          sync.RWMutex
+	assignments map[uuid.UUID]map[string]*entity.RoleAssignment
+}
+
+// NewRoleAssignmentRepository creates a new RoleAssignmentRepository keeping role assignments in memory
+func NewRoleAssignmentRepository() repository.RoleAssignmentRepository {
+	return &roleAssignmentRepository{
+		assignments: map[uuid.UUID]map[string]*entity.RoleAssignment{},
+	}
+}
+
+// Assign assigns a role to a user, returns false if the role was already assigned to them
+func (r *roleAssignmentRepository) Assign(ctx context.Context, assignment *entity.RoleAssignment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roles, ok := r.assignments[assignment.UserID]
+	if !ok {
+		roles = map[string]*entity.RoleAssignment{}
+		r.assignments[assignment.UserID] = roles
+	}
+	if _, ok := roles[assignment.Role]; ok {
+		return false, nil
+	}
+	copied := *assignment
+	roles[assignment.Role] = &copied
+	return true, nil
+}
+
+// Unassign removes a role from a user, returns false if the role was not assigned to them
+func (r *roleAssignmentRepository) Unassign(ctx context.Context, userID uuid.UUID, role string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.assignments[userID][role]; !ok {
+		return false, nil
+	}
+	delete(r.assignments[userID], role)
+	return true, nil
+}
+
+// ListByUser lists the roles assigned to a user, ordered by role
+func (r *roleAssignmentRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RoleAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assignments := []*entity.RoleAssignment{}
+	for _, assignment := range r.assignments[userID] {
+		copied := *assignment
+		assignments = append(assignments, &copied)
+	}
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].Role < assignments[j].Role })
+	return assignments, nil
+}
+
+// ListByRole lists the users a role is assigned to, oldest assignment first
+func (r *roleAssignmentRepository) ListByRole(ctx context.Context, role string) ([]*entity.RoleAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assignments := []*entity.RoleAssignment{}
+	for _, roles := range r.assignments {
+		if assignment, ok := roles[role]; ok {
+			copied := *assignment
+			assignments = append(assignments, &copied)
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].AssignedAt.Before(assignments[j].AssignedAt) })
+	return assignments, nil
+}
+
+// DeleteByUser removes every role assigned to a user
+func (r *roleAssignmentRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.assignments, userID)
+	return nil
+}
+
+// ListExpired lists at most limit assignments expired at now, earliest expiration first
+func (r *roleAssignmentRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RoleAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assignments := []*entity.RoleAssignment{}
+	for _, roles := range r.assignments {
+		for _, assignment := range roles {
+			if assignment.IsExpired(now) {
+				copied := *assignment
+				assignments = append(assignments, &copied)
+			}
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].ExpiresAt.Before(*assignments[j].ExpiresAt) })
+	if len(assignments) > limit {
+		assignments = assignments[:limit]
+	}
+	return assignments, nil
+}
+
+// DeleteExpired removes an expired assignment if it still expires at the same time
+func (r *roleAssignmentRepository) DeleteExpired(ctx context.Context, assignment *entity.RoleAssignment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.assignments[assignment.UserID][assignment.Role]
+	if !ok || stored.ExpiresAt == nil || assignment.ExpiresAt == nil || !stored.ExpiresAt.Equal(*assignment.ExpiresAt) {
+		return false, nil
+	}
+	delete(r.assignments[assignment.UserID], assignment.Role)
+	return true, nil
+}
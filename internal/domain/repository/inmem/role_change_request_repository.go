@@ -0,0 +1,82 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type roleChangeRequestRepository struct {
+	mu       sync.RWMutex
+	requests map[uuid.UUID]*entity.RoleChangeRequest
+}
+
+// NewRoleChangeRequestRepository creates a new RoleChangeRequestRepository keeping the requests in memory
+func NewRoleChangeRequestRepository() repository.RoleChangeRequestRepository {
+	return &roleChangeRequestRepository{
+		requests: map[uuid.UUID]*entity.RoleChangeRequest{},
+	}
+}
+
+// Create stores a new request
+func (r *roleChangeRequestRepository) Create(ctx context.Context, request *entity.RoleChangeRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.requests[request.ID]; ok {
+		return fmt.Errorf("failed to create role change request: request %s already exists", request.ID)
+	}
+	copied := *request
+	r.requests[request.ID] = &copied
+	return nil
+}
+
+// GetByID returns a request, nil if unknown
+func (r *roleChangeRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.RoleChangeRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	request, ok := r.requests[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *request
+	return &copied, nil
+}
+
+// List lists the requests with a status, all of them when the status is empty, newest first
+func (r *roleChangeRequestRepository) List(ctx context.Context, status string) ([]*entity.RoleChangeRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	requests := []*entity.RoleChangeRequest{}
+	for _, request := range r.requests {
+		if status == "" || request.Status == status {
+			copied := *request
+			requests = append(requests, &copied)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.After(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// Transition replaces a request if its stored status is still from
+func (r *roleChangeRequestRepository) Transition(ctx context.Context, request *entity.RoleChangeRequest, from string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.requests[request.ID]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	copied := *request
+	r.requests[request.ID] = &copied
+	return true, nil
+}
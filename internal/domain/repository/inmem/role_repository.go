@@ -0,0 +1,85 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type roleRepository struct {
+	mu    sync.RWMutex
+	roles map[string]*entity.Role
+}
+
+// NewRoleRepository creates a new RoleRepository keeping custom roles in memory
+func NewRoleRepository() repository.RoleRepository {
+	return &roleRepository{
+		roles: map[string]*entity.Role{},
+	}
+}
+
+// Create a new role
+func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roles[role.Name] = copyRole(role)
+	return nil
+}
+
+// GetByName gets a role by name, returns nil if the role does not exist
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if role, ok := r.roles[name]; ok {
+		return copyRole(role), nil
+	}
+	return nil, nil
+}
+
+// List all roles ordered by name
+func (r *roleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	roles := make([]*entity.Role, 0, len(r.roles))
+	for _, role := range r.roles {
+		roles = append(roles, copyRole(role))
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, nil
+}
+
+// Update a role
+func (r *roleRepository) Update(ctx context.Context, role *entity.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.roles[role.Name]; ok {
+		r.roles[role.Name] = copyRole(role)
+	}
+	return nil
+}
+
+// Delete a role
+func (r *roleRepository) Delete(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.roles, name)
+	return nil
+}
+
+// copyRole copies a role so callers never share the stored value
+func copyRole(role *entity.Role) *entity.Role {
+	copied := *role
+	copied.Permissions = slices.Clone(role.Permissions)
+	copied.Parents = slices.Clone(role.Parents)
+	copied.Groups = slices.Clone(role.Groups)
+	return &copied
+}
@@ -0,0 +1,109 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type scimGroupRepository struct {
+	mu     sync.RWMutex
+	groups map[uuid.UUID]*entity.SCIMGroup
+}
+
+// NewSCIMGroupRepository creates a new SCIMGroupRepository keeping groups in memory
+func NewSCIMGroupRepository() repository.SCIMGroupRepository {
+	return &scimGroupRepository{
+		groups: map[uuid.UUID]*entity.SCIMGroup{},
+	}
+}
+
+// copySCIMGroup returns a copy of a group sharing nothing with the stored one
+func copySCIMGroup(group *entity.SCIMGroup) *entity.SCIMGroup {
+	copied := *group
+	copied.Members = slices.Clone(group.Members)
+	if group.Sync != nil {
+		sync := *group.Sync
+		sync.Issues = slices.Clone(group.Sync.Issues)
+		copied.Sync = &sync
+	}
+	return &copied
+}
+
+// Create stores a new group
+func (r *scimGroupRepository) Create(ctx context.Context, group *entity.SCIMGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[group.ID]; ok {
+		return fmt.Errorf("failed to create SCIM group: SCIM group %s already exists", group.ID)
+	}
+	r.groups[group.ID] = copySCIMGroup(group)
+	return nil
+}
+
+// GetByID returns a group, nil if unknown
+func (r *scimGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if group, ok := r.groups[id]; ok {
+		return copySCIMGroup(group), nil
+	}
+	return nil, nil
+}
+
+// List returns the groups ordered by display name
+func (r *scimGroupRepository) List(ctx context.Context) ([]*entity.SCIMGroup, error) {
+	return r.list(func(*entity.SCIMGroup) bool { return true }), nil
+}
+
+// ListByMember returns the groups a user is a member of
+func (r *scimGroupRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*entity.SCIMGroup, error) {
+	return r.list(func(group *entity.SCIMGroup) bool {
+		return slices.Contains(group.Members, userID)
+	}), nil
+}
+
+// list returns the groups matching a predicate ordered by display name
+func (r *scimGroupRepository) list(match func(*entity.SCIMGroup) bool) []*entity.SCIMGroup {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	groups := []*entity.SCIMGroup{}
+	for _, group := range r.groups {
+		if match(group) {
+			groups = append(groups, copySCIMGroup(group))
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].DisplayName < groups[j].DisplayName
+	})
+	return groups
+}
+
+// Update replaces a group
+func (r *scimGroupRepository) Update(ctx context.Context, group *entity.SCIMGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.groups[group.ID]; ok {
+		r.groups[group.ID] = copySCIMGroup(group)
+	}
+	return nil
+}
+
+// Delete deletes a group
+func (r *scimGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.groups, id)
+	return nil
+}
@@ -0,0 +1,62 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type securityEventRepository struct {
+	mu     sync.RWMutex
+	events []*entity.SecurityEvent // In the order they were queued
+}
+
+// NewSecurityEventRepository creates a new SecurityEventRepository buffering security events in memory
+func NewSecurityEventRepository() repository.SecurityEventRepository {
+	return &securityEventRepository{}
+}
+
+// Create queues a security event
+func (r *securityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *event
+	r.events = append(r.events, &copied)
+	return nil
+}
+
+// ListPending lists up to limit queued security events, oldest first
+func (r *securityEventRepository) ListPending(ctx context.Context, limit int) ([]*entity.SecurityEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]*entity.SecurityEvent, 0, min(limit, len(r.events)))
+	for _, event := range r.events[:min(limit, len(r.events))] {
+		copied := *event
+		events = append(events, &copied)
+	}
+	return events, nil
+}
+
+// Delete removes delivered security events from the queue
+func (r *securityEventRepository) Delete(ctx context.Context, ids []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	kept := r.events[:0]
+	for _, event := range r.events {
+		if !deleted[event.ID] {
+			kept = append(kept, event)
+		}
+	}
+	r.events = kept
+	return nil
+}
@@ -0,0 +1,58 @@
+package inmem
+
+import (
+	"context"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+)
+
+// Seed creates the users of scripts/mongo-init.js, so the demo mode starts with the same accounts
+func Seed(ctx context.Context, userRepo repository.UserRepository) error {
+	adminPassword, err := utils.HashPassword("admin123", utils.DefaultBcryptCost)
+	if err != nil {
+		return err
+	}
+	testPassword, err := utils.HashPassword("test123", utils.DefaultBcryptCost)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	users := []*entity.User{
+		{
+			ID:        uuid.New(),
+			Email:     "admin@example.com",
+			Username:  "admin",
+			Password:  adminPassword,
+			FirstName: "Admin",
+			LastName:  "User",
+			Role:      entity.UserRoleAdmin,
+			Status:    entity.UserStatusActive,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		{
+			ID:        uuid.New(),
+			Email:     "test@example.com",
+			Username:  "testuser",
+			Password:  testPassword,
+			FirstName: "Test",
+			LastName:  "User",
+			Role:      entity.UserRoleUser,
+			Status:    entity.UserStatusActive,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	}
+
+	for _, user := range users {
+		if err := userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type selfTestRepository struct {
+	mu     sync.RWMutex
+	probes map[uuid.UUID]*entity.SelfTestProbe
+}
+
+// NewSelfTestRepository creates a new SelfTestRepository keeping the self-test probes in memory
+func NewSelfTestRepository() repository.SelfTestRepository {
+	return &selfTestRepository{
+		probes: map[uuid.UUID]*entity.SelfTestProbe{},
+	}
+}
+
+// Write stores a probe
+func (r *selfTestRepository) Write(ctx context.Context, probe *entity.SelfTestProbe) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *probe
+	r.probes[probe.ID] = &copied
+	return nil
+}
+
+// Read gets a probe by ID, returns nil if it is not found
+func (r *selfTestRepository) Read(ctx context.Context, id uuid.UUID) (*entity.SelfTestProbe, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	probe, ok := r.probes[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *probe
+	return &copied, nil
+}
+
+// Delete deletes a probe
+func (r *selfTestRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.probes, id)
+	return nil
+}
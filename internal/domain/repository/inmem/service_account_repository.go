@@ -0,0 +1,113 @@
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type serviceAccountRepository struct {
+	mu       sync.RWMutex
+	accounts map[uuid.UUID]*entity.ServiceAccount
+}
+
+// NewServiceAccountRepository creates a new ServiceAccountRepository keeping service accounts in memory
+func NewServiceAccountRepository() repository.ServiceAccountRepository {
+	return &serviceAccountRepository{
+		accounts: map[uuid.UUID]*entity.ServiceAccount{},
+	}
+}
+
+// copyServiceAccount returns a copy of a service account sharing nothing with the stored one
+func copyServiceAccount(account *entity.ServiceAccount) *entity.ServiceAccount {
+	copied := *account
+	copied.Scopes = slices.Clone(account.Scopes)
+	if account.LastTokenAt != nil {
+		lastTokenAt := *account.LastTokenAt
+		copied.LastTokenAt = &lastTokenAt
+	}
+	if account.Quota != nil {
+		quota := *account.Quota
+		copied.Quota = &quota
+	}
+	return &copied
+}
+
+// Create stores a new service account
+func (r *serviceAccountRepository) Create(ctx context.Context, account *entity.ServiceAccount) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.accounts[account.ID]; ok {
+		return fmt.Errorf("failed to create service account: service account %s already exists", account.ID)
+	}
+	r.accounts[account.ID] = copyServiceAccount(account)
+	return nil
+}
+
+// GetByID returns a service account, nil if unknown
+func (r *serviceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ServiceAccount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.accounts[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyServiceAccount(account), nil
+}
+
+// GetByClientID returns the service account of a client ID, nil if unknown
+func (r *serviceAccountRepository) GetByClientID(ctx context.Context, clientID string) (*entity.ServiceAccount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, account := range r.accounts {
+		if account.ClientID == clientID {
+			return copyServiceAccount(account), nil
+		}
+	}
+	return nil, nil
+}
+
+// List returns the service accounts, oldest first
+func (r *serviceAccountRepository) List(ctx context.Context) ([]*entity.ServiceAccount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accounts := make([]*entity.ServiceAccount, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		accounts = append(accounts, copyServiceAccount(account))
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+	})
+	return accounts, nil
+}
+
+// Update replaces a service account
+func (r *serviceAccountRepository) Update(ctx context.Context, account *entity.ServiceAccount) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.accounts[account.ID]; !ok {
+		return nil
+	}
+	r.accounts[account.ID] = copyServiceAccount(account)
+	return nil
+}
+
+// Delete deletes a service account
+func (r *serviceAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.accounts, id)
+	return nil
+}
@@ -0,0 +1,42 @@
+package inmem
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type signingKeyRepository struct {
+	mu   sync.RWMutex
+	keys []*entity.SigningKey
+}
+
+// NewSigningKeyRepository creates a new SigningKeyRepository keeping signing keys in memory
+func NewSigningKeyRepository() repository.SigningKeyRepository {
+	return &signingKeyRepository{}
+}
+
+// Create stores a new signing key
+func (r *signingKeyRepository) Create(ctx context.Context, key *entity.SigningKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *key
+	r.keys = append(r.keys, &copied)
+	return nil
+}
+
+// List returns all signing keys, oldest first
+func (r *signingKeyRepository) List(ctx context.Context) ([]*entity.SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*entity.SigningKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		copied := *key
+		keys = append(keys, &copied)
+	}
+	return keys, nil
+}
@@ -0,0 +1,47 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type statusHistoryRepository struct {
+	mu      sync.RWMutex
+	changes map[uuid.UUID][]*entity.StatusChange
+}
+
+// NewStatusHistoryRepository creates a new StatusHistoryRepository keeping the status history in memory
+func NewStatusHistoryRepository() repository.StatusHistoryRepository {
+	return &statusHistoryRepository{
+		changes: map[uuid.UUID][]*entity.StatusChange{},
+	}
+}
+
+// Create records a status change
+func (r *statusHistoryRepository) Create(ctx context.Context, change *entity.StatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *change
+	r.changes[change.UserID] = append(r.changes[change.UserID], &copied)
+	return nil
+}
+
+// ListByUser lists the status changes of a user, newest first
+func (r *statusHistoryRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.StatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Changes are appended in chronological order
+	changes := make([]*entity.StatusChange, 0, len(r.changes[userID]))
+	for _, change := range slices.Backward(r.changes[userID]) {
+		copied := *change
+		changes = append(changes, &copied)
+	}
+	return changes, nil
+}
@@ -0,0 +1,67 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type suppressionRepository struct {
+	mu           sync.RWMutex
+	suppressions map[string]*entity.EmailSuppression
+}
+
+// NewSuppressionRepository creates a new SuppressionRepository keeping the suppression list in memory
+func NewSuppressionRepository() repository.SuppressionRepository {
+	return &suppressionRepository{
+		suppressions: map[string]*entity.EmailSuppression{},
+	}
+}
+
+// Add suppresses an address, replacing any existing suppression of it
+func (r *suppressionRepository) Add(ctx context.Context, suppression *entity.EmailSuppression) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *suppression
+	r.suppressions[suppression.Email] = &copied
+	return nil
+}
+
+// Get the suppression of a normalized address, returns nil if the address is not suppressed
+func (r *suppressionRepository) Get(ctx context.Context, email string) (*entity.EmailSuppression, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if suppression, ok := r.suppressions[email]; ok {
+		copied := *suppression
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// List all suppressions, newest first
+func (r *suppressionRepository) List(ctx context.Context) ([]*entity.EmailSuppression, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	suppressions := make([]*entity.EmailSuppression, 0, len(r.suppressions))
+	for _, suppression := range r.suppressions {
+		copied := *suppression
+		suppressions = append(suppressions, &copied)
+	}
+	sort.Slice(suppressions, func(i, j int) bool { return suppressions[i].CreatedAt.After(suppressions[j].CreatedAt) })
+	return suppressions, nil
+}
+
+// Remove the suppression of a normalized address
+func (r *suppressionRepository) Remove(ctx context.Context, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.suppressions, email)
+	return nil
+}
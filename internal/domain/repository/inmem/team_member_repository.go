@@ -0,0 +1,103 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type teamMemberRepository struct {
+	mu      sync.RWMutex
+	members map[string]map[uuid.UUID]*entity.TeamMember
+}
+
+// NewTeamMemberRepository creates a new TeamMemberRepository keeping team memberships in memory
+func NewTeamMemberRepository() repository.TeamMemberRepository {
+	return &teamMemberRepository{
+		members: map[string]map[uuid.UUID]*entity.TeamMember{},
+	}
+}
+
+// Add adds a user to a team, returns false if the user was already a member
+func (r *teamMemberRepository) Add(ctx context.Context, member *entity.TeamMember) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users, ok := r.members[member.Team]
+	if !ok {
+		users = map[uuid.UUID]*entity.TeamMember{}
+		r.members[member.Team] = users
+	}
+	if _, ok := users[member.UserID]; ok {
+		return false, nil
+	}
+	copied := *member
+	users[member.UserID] = &copied
+	return true, nil
+}
+
+// Remove removes a user from a team, returns false if the user was not a member
+func (r *teamMemberRepository) Remove(ctx context.Context, team string, userID uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.members[team][userID]; !ok {
+		return false, nil
+	}
+	delete(r.members[team], userID)
+	return true, nil
+}
+
+// ListByTeam lists the members of a team, oldest membership first
+func (r *teamMemberRepository) ListByTeam(ctx context.Context, team string) ([]*entity.TeamMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := []*entity.TeamMember{}
+	for _, member := range r.members[team] {
+		copied := *member
+		members = append(members, &copied)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].AddedAt.Before(members[j].AddedAt) })
+	return members, nil
+}
+
+// ListByUser lists the teams of a user, ordered by team
+func (r *teamMemberRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.TeamMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := []*entity.TeamMember{}
+	for _, users := range r.members {
+		if member, ok := users[userID]; ok {
+			copied := *member
+			members = append(members, &copied)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Team < members[j].Team })
+	return members, nil
+}
+
+// DeleteByTeam removes every member of a team
+func (r *teamMemberRepository) DeleteByTeam(ctx context.Context, team string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.members, team)
+	return nil
+}
+
+// DeleteByUser removes a user from every team
+func (r *teamMemberRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, users := range r.members {
+		delete(users, userID)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package inmem
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+type teamRepository struct {
+	mu    sync.RWMutex
+	teams map[string]*entity.Team
+}
+
+// NewTeamRepository creates a new TeamRepository keeping teams in memory
+func NewTeamRepository() repository.TeamRepository {
+	return &teamRepository{
+		teams: map[string]*entity.Team{},
+	}
+}
+
+// Create a new team
+func (r *teamRepository) Create(ctx context.Context, team *entity.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.teams[team.Name] = copyTeam(team)
+	return nil
+}
+
+// GetByName gets a team by name, returns nil if the team does not exist
+func (r *teamRepository) GetByName(ctx context.Context, name string) (*entity.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if team, ok := r.teams[name]; ok {
+		return copyTeam(team), nil
+	}
+	return nil, nil
+}
+
+// List all teams ordered by name
+func (r *teamRepository) List(ctx context.Context) ([]*entity.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := make([]*entity.Team, 0, len(r.teams))
+	for _, team := range r.teams {
+		teams = append(teams, copyTeam(team))
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	return teams, nil
+}
+
+// Update a team
+func (r *teamRepository) Update(ctx context.Context, team *entity.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.teams[team.Name]; ok {
+		r.teams[team.Name] = copyTeam(team)
+	}
+	return nil
+}
+
+// Delete a team
+func (r *teamRepository) Delete(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.teams, name)
+	return nil
+}
+
+// copyTeam copies a team so callers never share the stored value
+func copyTeam(team *entity.Team) *entity.Team {
+	copied := *team
+	copied.Roles = slices.Clone(team.Roles)
+	return &copied
+}
@@ -0,0 +1,12 @@
+package inmem
+
+import (
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+)
+
+// NewTokenRepository creates a new TokenRepository keeping tokens in memory. Tokens are stored
+// through an in-memory cache, so sessions, expirations and one-time tokens behave as with Redis.
+func NewTokenRepository() repository.TokenRepository {
+	return repository.NewTokenRepository(cache.NewMemory())
+}
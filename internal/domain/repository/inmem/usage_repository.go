@@ -0,0 +1,70 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+)
+
+// usageKey identifies a usage aggregate
+type usageKey struct {
+	period  string
+	metric  string
+	subject string
+}
+
+type usageRepository struct {
+	mu      sync.Mutex
+	records map[usageKey]*entity.UsageRecord
+}
+
+// NewUsageRepository creates a new UsageRepository keeping usage aggregates in memory
+func NewUsageRepository() repository.UsageRepository {
+	return &usageRepository{
+		records: map[usageKey]*entity.UsageRecord{},
+	}
+}
+
+// Add adds the counts of the records to the stored aggregates
+func (r *usageRepository) Add(ctx context.Context, records []*entity.UsageRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, record := range records {
+		key := usageKey{period: record.Period, metric: record.Metric, subject: record.Subject}
+		stored, ok := r.records[key]
+		if !ok {
+			stored = &entity.UsageRecord{Period: record.Period, Metric: record.Metric, Subject: record.Subject}
+			r.records[key] = stored
+		}
+		stored.Count += record.Count
+		stored.UpdatedAt = now
+	}
+	return nil
+}
+
+// ListByPeriod lists the aggregates of a period by metric, largest counts first
+func (r *usageRepository) ListByPeriod(ctx context.Context, period string) ([]*entity.UsageRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := []*entity.UsageRecord{}
+	for key, stored := range r.records {
+		if key.period == period {
+			copied := *stored
+			records = append(records, &copied)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Metric != records[j].Metric {
+			return records[i].Metric < records[j].Metric
+		}
+		return records[i].Count > records[j].Count
+	})
+	return records, nil
+}
@@ -0,0 +1,344 @@
+// Package inmem provides in-memory implementations of the repositories in package repository.
+// They back the tests and the no-infrastructure demo mode (DB_TYPE=memory), data is lost on restart.
+package inmem
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ErrDuplicateUser is returned when creating a user whose email or username is taken, like the unique indexes of MongoDB
+var ErrDuplicateUser = errors.New("duplicate user")
+
+type userRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]*entity.User
+}
+
+// NewUserRepository creates a new UserRepository keeping users in memory
+func NewUserRepository() repository.UserRepository {
+	return &userRepository{
+		users: map[uuid.UUID]*entity.User{},
+	}
+}
+
+// Create creates a new user
+func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.ID == user.ID || existing.Email == user.Email || existing.Username == user.Username {
+			return ErrDuplicateUser
+		}
+	}
+
+	r.users[user.ID] = user.Clone()
+	return nil
+}
+
+// CreateMany creates users in a single batch, like an unordered MongoDB insert duplicates do not stop the batch
+func (r *userRepository) CreateMany(ctx context.Context, users []*entity.User) error {
+	var firstErr error
+	for _, user := range users {
+		if err := r.Create(ctx, user); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// GetByID retrieves a user by ID
+func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if user, ok := r.users[id]; ok {
+		return user.Clone(), nil
+	}
+	return nil, nil // User not found
+}
+
+// GetByEmail retrieves a user by email
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return r.find(func(user *entity.User) bool { return user.Email == email }), nil
+}
+
+// GetByUsername retrieves a user by username
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return r.find(func(user *entity.User) bool { return user.Username == username }), nil
+}
+
+// GetByRecoveryEmail retrieves a user by verified recovery email
+func (r *userRepository) GetByRecoveryEmail(ctx context.Context, email string) (*entity.User, error) {
+	return r.find(func(user *entity.User) bool {
+		return user.RecoveryEmailVerified && user.RecoveryEmail == email
+	}), nil
+}
+
+// Update updates a user, the password and tags are changed through their dedicated methods
+func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
+	return r.modify(user.ID, func(stored *entity.User) {
+		password, tags, createdAt := stored.Password, stored.Tags, stored.CreatedAt
+		*stored = *user.Clone()
+		stored.Password, stored.Tags, stored.CreatedAt = password, tags, createdAt
+	})
+}
+
+// Delete deletes a user
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, id)
+	return nil
+}
+
+// List retrieves a page of users, newest first
+func (r *userRepository) List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*entity.User
+	for _, user := range r.users {
+		if matchesUserList(user, opts) {
+			matched = append(matched, user)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	total := int64(len(matched))
+	offset := (page - 1) * limit
+	if offset < 0 || offset >= len(matched) {
+		return []*entity.User{}, total, nil
+	}
+	end := min(offset+limit, len(matched))
+
+	users := make([]*entity.User, 0, end-offset)
+	for _, user := range matched[offset:end] {
+		users = append(users, user.Clone())
+	}
+	return users, total, nil
+}
+
+// ListDueForPurge retrieves the users pending deletion whose purge time has passed, the earliest first
+func (r *userRepository) ListDueForPurge(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*entity.User
+	for _, user := range r.users {
+		if user.Status == entity.UserStatusPendingDeletion && user.PurgeAt != nil && !user.PurgeAt.After(before) {
+			due = append(due, user)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].PurgeAt.Before(*due[j].PurgeAt)
+	})
+
+	users := make([]*entity.User, 0, min(limit, len(due)))
+	for _, user := range due[:min(limit, len(due))] {
+		users = append(users, user.Clone())
+	}
+	return users, nil
+}
+
+// ListWaitlisted retrieves the waitlisted users, the earliest registered first
+func (r *userRepository) ListWaitlisted(ctx context.Context, limit int) ([]*entity.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var waitlisted []*entity.User
+	for _, user := range r.users {
+		if user.Status == entity.UserStatusWaitlisted {
+			waitlisted = append(waitlisted, user)
+		}
+	}
+	sort.Slice(waitlisted, func(i, j int) bool {
+		return waitlisted[i].CreatedAt.Before(waitlisted[j].CreatedAt)
+	})
+
+	users := make([]*entity.User, 0, min(limit, len(waitlisted)))
+	for _, user := range waitlisted[:min(limit, len(waitlisted))] {
+		users = append(users, user.Clone())
+	}
+	return users, nil
+}
+
+// RecordActivity records the time a user was last active
+func (r *userRepository) RecordActivity(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.modify(id, func(user *entity.User) {
+		user.LastActiveAt = &at
+	})
+}
+
+// ListCreatedBetween retrieves the users created in [from, to), the earliest created first
+func (r *userRepository) ListCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	return r.listBetween(from, to, limit, func(user *entity.User) time.Time {
+		return user.CreatedAt
+	}), nil
+}
+
+// ListLastActiveBetween retrieves the users last active in [from, to), the least recently active first
+func (r *userRepository) ListLastActiveBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	return r.listBetween(from, to, limit, (*entity.User).LastActivity), nil
+}
+
+// CountByStatusAndRole counts the users by status and role
+func (r *userRepository) CountByStatusAndRole(ctx context.Context) ([]entity.UserCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totals := map[[2]string]int64{}
+	for _, user := range r.users {
+		totals[[2]string{user.Status, user.Role}]++
+	}
+
+	counts := make([]entity.UserCount, 0, len(totals))
+	for key, total := range totals {
+		counts = append(counts, entity.UserCount{Status: key[0], Role: key[1], Count: total})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Status != counts[j].Status {
+			return counts[i].Status < counts[j].Status
+		}
+		return counts[i].Role < counts[j].Role
+	})
+	return counts, nil
+}
+
+// CountCreatedSince counts the users created since a time
+func (r *userRepository) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total int64
+	for _, user := range r.users {
+		if !user.CreatedAt.Before(since) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+// listBetween returns copies of the users whose time of reference is in [from, to), the earliest first
+func (r *userRepository) listBetween(from, to time.Time, limit int, reference func(*entity.User) time.Time) []*entity.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matching []*entity.User
+	for _, user := range r.users {
+		if at := reference(user); !at.Before(from) && at.Before(to) {
+			matching = append(matching, user)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return reference(matching[i]).Before(reference(matching[j]))
+	})
+
+	users := make([]*entity.User, 0, min(limit, len(matching)))
+	for _, user := range matching[:min(limit, len(matching))] {
+		users = append(users, user.Clone())
+	}
+	return users
+}
+
+// ChangePassword changes a user's password
+func (r *userRepository) ChangePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+	return r.modify(id, func(user *entity.User) {
+		user.Password = hashedPassword
+		user.UpdatedAt = time.Now()
+	})
+}
+
+// RehashPassword replaces a user's password hash if it is still the current one
+func (r *userRepository) RehashPassword(ctx context.Context, id uuid.UUID, currentHash, hashedPassword string) error {
+	return r.modify(id, func(user *entity.User) {
+		if user.Password == currentHash {
+			user.Password = hashedPassword
+		}
+	})
+}
+
+// UpdateStatus updates a user's status
+func (r *userRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	return r.modify(id, func(user *entity.User) {
+		user.Status = status
+		user.UpdatedAt = time.Now()
+	})
+}
+
+// AddTags adds tags to a user, tags the user already carries are ignored
+func (r *userRepository) AddTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	return r.modify(id, func(user *entity.User) {
+		for _, tag := range tags {
+			if !slices.Contains(user.Tags, tag) {
+				user.Tags = append(user.Tags, tag)
+			}
+		}
+		user.UpdatedAt = time.Now()
+	})
+}
+
+// RemoveTags removes tags from a user, tags the user does not carry are ignored
+func (r *userRepository) RemoveTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	return r.modify(id, func(user *entity.User) {
+		user.Tags = slices.DeleteFunc(user.Tags, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+		user.UpdatedAt = time.Now()
+	})
+}
+
+// find returns a copy of the first user matching a predicate, nil if there is none
+func (r *userRepository) find(match func(user *entity.User) bool) *entity.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if match(user) {
+			return user.Clone()
+		}
+	}
+	return nil
+}
+
+// modify applies a change to a stored user, updating a missing user is a no-op like in MongoDB
+func (r *userRepository) modify(id uuid.UUID, change func(user *entity.User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, ok := r.users[id]; ok {
+		change(user)
+	}
+	return nil
+}
+
+// matchesUserList reports whether a user passes the filters of a list query
+func matchesUserList(user *entity.User, opts entity.UserListOptions) bool {
+	switch {
+	case opts.Status != "" && user.Status != opts.Status:
+		return false
+	case opts.Role != "" && user.Role != opts.Role:
+		return false
+	case opts.Tag != "" && !slices.Contains(user.Tags, opts.Tag):
+		return false
+	case opts.OrgID != nil && (user.OrgID == nil || *user.OrgID != *opts.OrgID):
+		return false
+	case opts.EmailVerifiedOnly && !user.EmailVerified:
+		return false
+	case opts.PhoneVerifiedOnly && !user.PhoneVerified:
+		return false
+	}
+	return true
+}
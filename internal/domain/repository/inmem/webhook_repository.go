@@ -0,0 +1,177 @@
+package inmem
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type webhookRepository struct {
+	mu         sync.RWMutex
+	endpoints  map[uuid.UUID]*entity.WebhookEndpoint
+	deliveries map[uuid.UUID]*entity.WebhookDelivery
+}
+
+// NewWebhookRepository creates a new WebhookRepository keeping endpoints and deliveries in memory
+func NewWebhookRepository() repository.WebhookRepository {
+	return &webhookRepository{
+		endpoints:  map[uuid.UUID]*entity.WebhookEndpoint{},
+		deliveries: map[uuid.UUID]*entity.WebhookDelivery{},
+	}
+}
+
+// CreateEndpoint creates a new webhook endpoint
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.endpoints[endpoint.ID] = copyEndpoint(endpoint)
+	return nil
+}
+
+// GetEndpoint gets a webhook endpoint by ID, returns nil if the endpoint does not exist
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if endpoint, ok := r.endpoints[id]; ok {
+		return copyEndpoint(endpoint), nil
+	}
+	return nil, nil
+}
+
+// ListEndpoints lists all webhook endpoints ordered by creation date
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	endpoints := make([]*entity.WebhookEndpoint, 0, len(r.endpoints))
+	for _, endpoint := range r.endpoints {
+		endpoints = append(endpoints, copyEndpoint(endpoint))
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].CreatedAt.Before(endpoints[j].CreatedAt) })
+	return endpoints, nil
+}
+
+// UpdateEndpoint updates a webhook endpoint
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.endpoints[endpoint.ID]; ok {
+		r.endpoints[endpoint.ID] = copyEndpoint(endpoint)
+	}
+	return nil
+}
+
+// DeleteEndpoint deletes a webhook endpoint along with its deliveries
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.endpoints, id)
+	for deliveryID, delivery := range r.deliveries {
+		if delivery.EndpointID == id {
+			delete(r.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+// CreateDelivery creates a new webhook delivery
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+// GetDelivery gets a webhook delivery by ID, returns nil if the delivery does not exist
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if delivery, ok := r.deliveries[id]; ok {
+		copied := *delivery
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// UpdateDelivery updates a webhook delivery
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.deliveries[delivery.ID]; ok {
+		copied := *delivery
+		r.deliveries[delivery.ID] = &copied
+	}
+	return nil
+}
+
+// ListDeliveries lists the deliveries matching a filter, newest first
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []*entity.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if filter.EndpointID != uuid.Nil && delivery.EndpointID != filter.EndpointID {
+			continue
+		}
+		if filter.Status != "" && delivery.Status != filter.Status {
+			continue
+		}
+		copied := *delivery
+		deliveries = append(deliveries, &copied)
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) })
+
+	deliveries = deliveries[min(filter.Offset, len(deliveries)):]
+	if filter.Limit > 0 && len(deliveries) > filter.Limit {
+		deliveries = deliveries[:filter.Limit]
+	}
+	return deliveries, nil
+}
+
+// ListDueDeliveries lists the pending deliveries due at a time, oldest due first,
+// leaving out the deliveries of the excluded endpoints
+func (r *webhookRepository) ListDueDeliveries(ctx context.Context, at time.Time, excludedEndpoints []uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	excluded := make(map[uuid.UUID]bool, len(excludedEndpoints))
+	for _, id := range excludedEndpoints {
+		excluded[id] = true
+	}
+
+	var deliveries []*entity.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status != entity.WebhookDeliveryPending || delivery.NextAttemptAt.After(at) || excluded[delivery.EndpointID] {
+			continue
+		}
+		copied := *delivery
+		deliveries = append(deliveries, &copied)
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].NextAttemptAt.Before(deliveries[j].NextAttemptAt) })
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// copyEndpoint copies an endpoint so callers cannot alias the stored event types
+func copyEndpoint(endpoint *entity.WebhookEndpoint) *entity.WebhookEndpoint {
+	copied := *endpoint
+	copied.EventTypes = append([]string(nil), endpoint.EventTypes...)
+	return &copied
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IPDenialRepository defines the interface for IP denial repository operations
+type IPDenialRepository interface {
+	// Create denies an IP address, returns false if the address is already denied
+	Create(ctx context.Context, denial *entity.IPDenial) (bool, error)
+
+	// GetActive gets the denial of an IP address, returns nil if the address is not denied
+	GetActive(ctx context.Context, ip string, now time.Time) (*entity.IPDenial, error)
+
+	// ListActive lists the denied IP addresses, newest first
+	ListActive(ctx context.Context, now time.Time) ([]*entity.IPDenial, error)
+
+	// Delete lifts the denial of an IP address
+	Delete(ctx context.Context, ip string) error
+}
+
+type ipDenialRepository struct {
+	db db.Database
+}
+
+// NewIPDenialRepository creates a new IPDenialRepository
+func NewIPDenialRepository(db db.Database) IPDenialRepository {
+	return &ipDenialRepository{
+		db: db,
+	}
+}
+
+// Create denies an IP address
+func (r *ipDenialRepository) Create(ctx context.Context, denial *entity.IPDenial) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createIPDenialMongo(ctx, db, denial)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// GetActive gets the denial of an IP address
+func (r *ipDenialRepository) GetActive(ctx context.Context, ip string, now time.Time) (*entity.IPDenial, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getActiveIPDenialMongo(ctx, db, ip, now)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListActive lists the denied IP addresses
+func (r *ipDenialRepository) ListActive(ctx context.Context, now time.Time) ([]*entity.IPDenial, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listActiveIPDenialsMongo(ctx, db, now)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Delete lifts the denial of an IP address
+func (r *ipDenialRepository) Delete(ctx context.Context, ip string) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteIPDenialMongo(ctx, db, ip)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
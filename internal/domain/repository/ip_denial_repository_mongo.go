@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createIPDenialMongo denies an IP address in MongoDB, replacing an expired denial of the address
+func (r *ipDenialRepository) createIPDenialMongo(ctx context.Context, client *mongo.Client, denial *entity.IPDenial) (bool, error) {
+	collection := client.Database("user_service").Collection("ip_denials")
+
+	result, err := collection.ReplaceOne(ctx, bson.M{"_id": denial.IP, "expires_at": bson.M{"$lte": denial.CreatedAt}}, denial)
+	if err != nil {
+		log.Error().Err(err).Str("ip", denial.IP).Msg("Failed to replace IP denial in MongoDB")
+		return false, fmt.Errorf("failed to create IP denial: %w", err)
+	}
+	if result.MatchedCount > 0 {
+		return true, nil
+	}
+
+	if _, err := collection.InsertOne(ctx, denial); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil // Already denied
+		}
+		log.Error().Err(err).Str("ip", denial.IP).Msg("Failed to create IP denial in MongoDB")
+		return false, fmt.Errorf("failed to create IP denial: %w", err)
+	}
+	return true, nil
+}
+
+// getActiveIPDenialMongo gets the denial of an IP address from MongoDB
+func (r *ipDenialRepository) getActiveIPDenialMongo(ctx context.Context, client *mongo.Client, ip string, now time.Time) (*entity.IPDenial, error) {
+	collection := client.Database("user_service").Collection("ip_denials")
+
+	var denial entity.IPDenial
+	err := collection.FindOne(ctx, bson.M{"_id": ip, "expires_at": bson.M{"$gt": now}}).Decode(&denial)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Not denied
+		}
+		log.Error().Err(err).Str("ip", ip).Msg("Failed to get IP denial from MongoDB")
+		return nil, fmt.Errorf("failed to get IP denial: %w", err)
+	}
+
+	return &denial, nil
+}
+
+// listActiveIPDenialsMongo lists the denied IP addresses from MongoDB
+func (r *ipDenialRepository) listActiveIPDenialsMongo(ctx context.Context, client *mongo.Client, now time.Time) ([]*entity.IPDenial, error) {
+	collection := client.Database("user_service").Collection("ip_denials")
+
+	cursor, err := collection.Find(ctx, bson.M{"expires_at": bson.M{"$gt": now}}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list IP denials from MongoDB")
+		return nil, fmt.Errorf("failed to list IP denials: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	denials := []*entity.IPDenial{}
+	if err := cursor.All(ctx, &denials); err != nil {
+		log.Error().Err(err).Msg("Failed to decode IP denials from MongoDB")
+		return nil, fmt.Errorf("failed to decode IP denials: %w", err)
+	}
+
+	return denials, nil
+}
+
+// deleteIPDenialMongo deletes the denial of an IP address from MongoDB
+func (r *ipDenialRepository) deleteIPDenialMongo(ctx context.Context, client *mongo.Client, ip string) error {
+	collection := client.Database("user_service").Collection("ip_denials")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": ip})
+	if err != nil {
+		log.Error().Err(err).Str("ip", ip).Msg("Failed to delete IP denial from MongoDB")
+		return fmt.Errorf("failed to delete IP denial: %w", err)
+	}
+
+	return nil
+}
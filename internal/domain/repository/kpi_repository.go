@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const kpiSnapshotKey = "kpi:snapshot"
+
+// KPIRepository defines the interface for the latest snapshot of the business metrics, shared by all instances
+type KPIRepository interface {
+	// StoreSnapshot replaces the latest snapshot
+	StoreSnapshot(ctx context.Context, snapshot *entity.KPISnapshot) error
+
+	// LatestSnapshot returns the latest snapshot, nil if the metrics were never aggregated
+	LatestSnapshot(ctx context.Context) (*entity.KPISnapshot, error)
+}
+
+type kpiRepository struct {
+	cache cache.Cache
+}
+
+// NewKPIRepository creates a new KPI repository
+func NewKPIRepository(cache cache.Cache) KPIRepository {
+	return &kpiRepository{
+		cache: cache,
+	}
+}
+
+// StoreSnapshot replaces the latest snapshot, kept without expiration
+func (r *kpiRepository) StoreSnapshot(ctx context.Context, snapshot *entity.KPISnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal KPI snapshot: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, kpiSnapshotKey, data, 0); err != nil {
+		log.Error().Err(err).Msg("Failed to store KPI snapshot in cache")
+		return fmt.Errorf("failed to store KPI snapshot: %w", err)
+	}
+	return nil
+}
+
+// LatestSnapshot returns the latest snapshot, nil if the metrics were never aggregated
+func (r *kpiRepository) LatestSnapshot(ctx context.Context) (*entity.KPISnapshot, error) {
+	data, err := r.cache.Get(ctx, kpiSnapshotKey)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get KPI snapshot from cache")
+		return nil, fmt.Errorf("failed to get KPI snapshot: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var snapshot entity.KPISnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal KPI snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
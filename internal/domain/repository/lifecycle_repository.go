@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const lifecycleCursorPrefix = "lifecycle:cursor:"
+
+// LifecycleRepository defines the interface for the progress of the lifecycle rules, shared by all instances
+type LifecycleRepository interface {
+	// Cursor returns the time up to which a rule went over the users, zero if it never ran
+	Cursor(ctx context.Context, rule string) (time.Time, error)
+
+	// SetCursor records the time up to which a rule went over the users
+	SetCursor(ctx context.Context, rule string, at time.Time) error
+}
+
+type lifecycleRepository struct {
+	cache cache.Cache
+}
+
+// NewLifecycleRepository creates a new lifecycle repository
+func NewLifecycleRepository(cache cache.Cache) LifecycleRepository {
+	return &lifecycleRepository{
+		cache: cache,
+	}
+}
+
+// Cursor returns the time up to which a rule went over the users, zero if it never ran
+func (r *lifecycleRepository) Cursor(ctx context.Context, rule string) (time.Time, error) {
+	data, err := r.cache.Get(ctx, lifecycleCursorPrefix+rule)
+	if err != nil {
+		log.Error().Err(err).Str("rule", rule).Msg("Failed to get lifecycle cursor from cache")
+		return time.Time{}, fmt.Errorf("failed to get lifecycle cursor: %w", err)
+	}
+	if data == nil {
+		return time.Time{}, nil
+	}
+
+	var at time.Time
+	if err := at.UnmarshalText(data); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unmarshal lifecycle cursor: %w", err)
+	}
+	return at, nil
+}
+
+// SetCursor records the time up to which a rule went over the users, kept without expiration
+func (r *lifecycleRepository) SetCursor(ctx context.Context, rule string, at time.Time) error {
+	data, err := at.MarshalText()
+	if err != nil {
+		return fmt.Errorf("failed to marshal lifecycle cursor: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, lifecycleCursorPrefix+rule, data, 0); err != nil {
+		log.Error().Err(err).Str("rule", rule).Msg("Failed to store lifecycle cursor in cache")
+		return fmt.Errorf("failed to store lifecycle cursor: %w", err)
+	}
+	return nil
+}
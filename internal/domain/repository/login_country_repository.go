@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LoginCountryRepository defines the interface for the countries users signed in from
+type LoginCountryRepository interface {
+	// Add records a country a user signed in from, returns false if it was already recorded
+	Add(ctx context.Context, userID uuid.UUID, country string) (bool, error)
+
+	// List the countries a user signed in from
+	List(ctx context.Context, userID uuid.UUID) ([]string, error)
+
+	// DeleteByUser deletes the countries of a user
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type loginCountryRepository struct {
+	db db.Database
+}
+
+// NewLoginCountryRepository creates a new LoginCountryRepository
+func NewLoginCountryRepository(db db.Database) LoginCountryRepository {
+	return &loginCountryRepository{
+		db: db,
+	}
+}
+
+// Add records a country a user signed in from
+func (r *loginCountryRepository) Add(ctx context.Context, userID uuid.UUID, country string) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.addLoginCountryMongo(ctx, db, userID, country)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// List lists the countries a user signed in from
+func (r *loginCountryRepository) List(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listLoginCountriesMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// DeleteByUser deletes the countries of a user
+func (r *loginCountryRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteLoginCountriesMongo(ctx, db, userID)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// loginCountriesDocument holds the countries a user signed in from
+type loginCountriesDocument struct {
+	UserID    uuid.UUID `bson:"_id"`
+	Countries []string  `bson:"countries"`
+}
+
+// addLoginCountryMongo adds a country to the set of a user in MongoDB
+func (r *loginCountryRepository) addLoginCountryMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID, country string) (bool, error) {
+	collection := client.Database("user_service").Collection("login_countries")
+
+	update := bson.M{"$addToSet": bson.M{"countries": country}}
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": userID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to add login country in MongoDB")
+		return false, fmt.Errorf("failed to add login country: %w", err)
+	}
+	return result.ModifiedCount > 0 || result.UpsertedCount > 0, nil
+}
+
+// listLoginCountriesMongo lists the countries of a user from MongoDB
+func (r *loginCountryRepository) listLoginCountriesMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) ([]string, error) {
+	collection := client.Database("user_service").Collection("login_countries")
+
+	var document loginCountriesDocument
+	err := collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&document)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return []string{}, nil
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get login countries from MongoDB")
+		return nil, fmt.Errorf("failed to get login countries: %w", err)
+	}
+
+	return document.Countries, nil
+}
+
+// deleteLoginCountriesMongo deletes the countries of a user from MongoDB
+func (r *loginCountryRepository) deleteLoginCountriesMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) error {
+	collection := client.Database("user_service").Collection("login_countries")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete login countries from MongoDB")
+		return fmt.Errorf("failed to delete login countries: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrOAuthIdentityExists is returned when creating an identity for an account at a provider already linked
+var ErrOAuthIdentityExists = errors.New("oauth identity already exists")
+
+// OAuthIdentityRepository defines the interface for the links between accounts at external OAuth2 providers
+// and local users
+type OAuthIdentityRepository interface {
+	// Create stores a new identity, ErrOAuthIdentityExists if the account is linked already
+	Create(ctx context.Context, identity *entity.OAuthIdentity) error
+
+	// Get returns the identity of an account at a provider, nil if the account is not linked
+	Get(ctx context.Context, provider, subject string) (*entity.OAuthIdentity, error)
+
+	// ListByUser returns the identities of a user, oldest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.OAuthIdentity, error)
+
+	// Delete deletes an identity, unlinking the account from its user
+	Delete(ctx context.Context, identity *entity.OAuthIdentity) error
+
+	// DeleteByUser deletes the identities of a user
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type oauthIdentityRepository struct {
+	db db.Database
+}
+
+// NewOAuthIdentityRepository creates a new OAuthIdentityRepository
+func NewOAuthIdentityRepository(db db.Database) OAuthIdentityRepository {
+	return &oauthIdentityRepository{
+		db: db,
+	}
+}
+
+// Create stores a new identity
+func (r *oauthIdentityRepository) Create(ctx context.Context, identity *entity.OAuthIdentity) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createOAuthIdentityMongo(ctx, db, identity)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Get retrieves the identity of an account at a provider
+func (r *oauthIdentityRepository) Get(ctx context.Context, provider, subject string) (*entity.OAuthIdentity, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getOAuthIdentityMongo(ctx, db, entity.OAuthIdentityID(provider, subject))
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListByUser retrieves the identities of a user
+func (r *oauthIdentityRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.OAuthIdentity, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listOAuthIdentitiesByUserMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes an identity
+func (r *oauthIdentityRepository) Delete(ctx context.Context, identity *entity.OAuthIdentity) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteOAuthIdentityMongo(ctx, db, identity.ID)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// DeleteByUser deletes the identities of a user
+func (r *oauthIdentityRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteOAuthIdentitiesByUserMongo(ctx, db, userID)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createOAuthIdentityMongo inserts an identity in MongoDB
+func (r *oauthIdentityRepository) createOAuthIdentityMongo(ctx context.Context, client *mongo.Client, identity *entity.OAuthIdentity) error {
+	collection := client.Database("user_service").Collection("oauth_identities")
+
+	if _, err := collection.InsertOne(ctx, identity); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrOAuthIdentityExists
+		}
+		log.Error().Err(err).Str("user_id", identity.UserID.String()).Str("provider", identity.Provider).Msg("Failed to create OAuth identity in MongoDB")
+		return fmt.Errorf("failed to create oauth identity: %w", err)
+	}
+	return nil
+}
+
+// getOAuthIdentityMongo gets an identity by ID from MongoDB
+func (r *oauthIdentityRepository) getOAuthIdentityMongo(ctx context.Context, client *mongo.Client, id string) (*entity.OAuthIdentity, error) {
+	collection := client.Database("user_service").Collection("oauth_identities")
+
+	var identity entity.OAuthIdentity
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&identity)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Account not linked
+		}
+		log.Error().Err(err).Msg("Failed to get OAuth identity from MongoDB")
+		return nil, fmt.Errorf("failed to get oauth identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// listOAuthIdentitiesByUserMongo lists the identities of a user from MongoDB, oldest first
+func (r *oauthIdentityRepository) listOAuthIdentitiesByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) ([]*entity.OAuthIdentity, error) {
+	collection := client.Database("user_service").Collection("oauth_identities")
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list OAuth identities from MongoDB")
+		return nil, fmt.Errorf("failed to list oauth identities: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	identities := []*entity.OAuthIdentity{}
+	if err := cursor.All(ctx, &identities); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to decode OAuth identities from MongoDB")
+		return nil, fmt.Errorf("failed to decode oauth identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// deleteOAuthIdentityMongo deletes an identity from MongoDB
+func (r *oauthIdentityRepository) deleteOAuthIdentityMongo(ctx context.Context, client *mongo.Client, id string) error {
+	collection := client.Database("user_service").Collection("oauth_identities")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Msg("Failed to delete OAuth identity from MongoDB")
+		return fmt.Errorf("failed to delete oauth identity: %w", err)
+	}
+	return nil
+}
+
+// deleteOAuthIdentitiesByUserMongo deletes the identities of a user from MongoDB
+func (r *oauthIdentityRepository) deleteOAuthIdentitiesByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) error {
+	collection := client.Database("user_service").Collection("oauth_identities")
+
+	if _, err := collection.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete OAuth identities of user from MongoDB")
+		return fmt.Errorf("failed to delete oauth identities: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	oauthStatePrefix         = "oauth_state:"
+	consumedOAuthStatePrefix = "oauth_state_consumed:"
+)
+
+// OAuthStateRepository defines the interface for the pending sign ins with external OAuth2 providers
+type OAuthStateRepository interface {
+	// Store stores a state until it expires
+	Store(ctx context.Context, state *entity.OAuthState) error
+
+	// Consume deletes a state and returns it, nil if unknown, expired or already consumed
+	Consume(ctx context.Context, state string) (*entity.OAuthState, error)
+}
+
+type oauthStateRepository struct {
+	cache cache.Cache
+}
+
+// NewOAuthStateRepository creates a new OAuth state repository
+func NewOAuthStateRepository(cache cache.Cache) OAuthStateRepository {
+	return &oauthStateRepository{
+		cache: cache,
+	}
+}
+
+// Store stores a state until it expires
+func (r *oauthStateRepository) Store(ctx context.Context, state *entity.OAuthState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal oauth state: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, oauthStatePrefix+state.State, data, time.Until(state.ExpiresAt)); err != nil {
+		log.Error().Err(err).Str("provider", state.Provider).Msg("Failed to store OAuth state in cache")
+		return fmt.Errorf("failed to store oauth state: %w", err)
+	}
+
+	return nil
+}
+
+// Consume deletes a state and returns it, nil if unknown, expired or already consumed.
+// The state is claimed first, so a callback replayed to several instances at once exchanges the code once.
+func (r *oauthStateRepository) Consume(ctx context.Context, state string) (*entity.OAuthState, error) {
+	data, err := r.cache.Get(ctx, oauthStatePrefix+state)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get OAuth state from cache")
+		return nil, fmt.Errorf("failed to get oauth state: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var pending entity.OAuthState
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal oauth state: %w", err)
+	}
+
+	// The claim lives as long as the state could have
+	claimed, err := r.cache.SetNX(ctx, consumedOAuthStatePrefix+state, []byte("1"), time.Until(pending.ExpiresAt))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim OAuth state")
+		return nil, fmt.Errorf("failed to claim oauth state: %w", err)
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	if err := r.cache.Delete(ctx, oauthStatePrefix+state); err != nil {
+		log.Warn().Err(err).Str("provider", pending.Provider).Msg("Failed to delete OAuth state from cache")
+	}
+
+	return &pending, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	authorizationCodePrefix         = "oidc_code:"
+	consumedAuthorizationCodePrefix = "oidc_code_consumed:"
+	sessionGrantPrefix              = "oidc_session:"
+)
+
+// OIDCRepository defines the interface for the authorization codes and grants of the OpenID Connect provider
+type OIDCRepository interface {
+	// StoreCode stores the grant an authorization code was issued for
+	StoreCode(ctx context.Context, code string, grant *entity.AuthorizationGrant, expiration time.Duration) error
+
+	// ConsumeCode deletes an authorization code and returns its grant, nil if unknown, expired or already consumed
+	ConsumeCode(ctx context.Context, code string) (*entity.AuthorizationGrant, error)
+
+	// StoreSessionGrant stores the grant the tokens of a session were issued for
+	StoreSessionGrant(ctx context.Context, sessionID uuid.UUID, grant *entity.AuthorizationGrant, expiration time.Duration) error
+
+	// GetSessionGrant returns the grant the tokens of a session were issued for, nil if the session was not issued by the provider
+	GetSessionGrant(ctx context.Context, sessionID uuid.UUID) (*entity.AuthorizationGrant, error)
+}
+
+type oidcRepository struct {
+	cache cache.Cache
+}
+
+// NewOIDCRepository creates a new OIDC repository
+func NewOIDCRepository(cache cache.Cache) OIDCRepository {
+	return &oidcRepository{
+		cache: cache,
+	}
+}
+
+// authorizationCodeHash returns the hash identifying an authorization code in the cache.
+// Only a hash of the code is stored, so the cache contents cannot be replayed.
+func authorizationCodeHash(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// StoreCode stores the grant an authorization code was issued for
+func (r *oidcRepository) StoreCode(ctx context.Context, code string, grant *entity.AuthorizationGrant, expiration time.Duration) error {
+	data, err := json.Marshal(grant)
+	if err != nil {
+		return fmt.Errorf("failed to marshal authorization grant: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, authorizationCodePrefix+authorizationCodeHash(code), data, expiration); err != nil {
+		log.Error().Err(err).Str("client_id", grant.ClientID).Msg("Failed to store authorization code in cache")
+		return fmt.Errorf("failed to store authorization code: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeCode deletes an authorization code and returns its grant, nil if unknown, expired or already consumed.
+// The code is claimed first, so concurrent exchanges of the same code on several instances yield a single grant.
+func (r *oidcRepository) ConsumeCode(ctx context.Context, code string) (*entity.AuthorizationGrant, error) {
+	hash := authorizationCodeHash(code)
+
+	data, err := r.cache.Get(ctx, authorizationCodePrefix+hash)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get authorization code from cache")
+		return nil, fmt.Errorf("failed to get authorization code: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	// The claim outlives any code that was still stored
+	claimed, err := r.cache.SetNX(ctx, consumedAuthorizationCodePrefix+hash, []byte("1"), time.Hour)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim authorization code")
+		return nil, fmt.Errorf("failed to claim authorization code: %w", err)
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	if err := r.cache.Delete(ctx, authorizationCodePrefix+hash); err != nil {
+		log.Error().Err(err).Msg("Failed to delete authorization code from cache")
+		return nil, fmt.Errorf("failed to delete authorization code: %w", err)
+	}
+
+	var grant entity.AuthorizationGrant
+	if err := json.Unmarshal(data, &grant); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal authorization grant: %w", err)
+	}
+
+	return &grant, nil
+}
+
+// StoreSessionGrant stores the grant the tokens of a session were issued for
+func (r *oidcRepository) StoreSessionGrant(ctx context.Context, sessionID uuid.UUID, grant *entity.AuthorizationGrant, expiration time.Duration) error {
+	data, err := json.Marshal(grant)
+	if err != nil {
+		return fmt.Errorf("failed to marshal authorization grant: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, sessionGrantPrefix+sessionID.String(), data, expiration); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to store session grant in cache")
+		return fmt.Errorf("failed to store session grant: %w", err)
+	}
+
+	return nil
+}
+
+// GetSessionGrant returns the grant the tokens of a session were issued for, nil if the session was not issued by the provider
+func (r *oidcRepository) GetSessionGrant(ctx context.Context, sessionID uuid.UUID) (*entity.AuthorizationGrant, error) {
+	data, err := r.cache.Get(ctx, sessionGrantPrefix+sessionID.String())
+	if err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to get session grant from cache")
+		return nil, fmt.Errorf("failed to get session grant: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var grant entity.AuthorizationGrant
+	if err := json.Unmarshal(data, &grant); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal authorization grant: %w", err)
+	}
+
+	return &grant, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OrganizationRepository defines the interface for organization repository operations
+type OrganizationRepository interface {
+	// Create a new organization
+	Create(ctx context.Context, org *entity.Organization) error
+
+	// Get an organization by ID, returns nil if the organization does not exist
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+
+	// List all organizations ordered by name
+	List(ctx context.Context) ([]*entity.Organization, error)
+
+	// Update the settings of an organization
+	Update(ctx context.Context, org *entity.Organization) error
+}
+
+type organizationRepository struct {
+	db db.Database
+}
+
+// NewOrganizationRepository creates a new OrganizationRepository
+func NewOrganizationRepository(db db.Database) OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+// Create creates a new organization
+func (r *organizationRepository) Create(ctx context.Context, org *entity.Organization) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createOrganizationMongo(ctx, db, org)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByID retrieves an organization by ID
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getOrganizationByIDMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists all organizations
+func (r *organizationRepository) List(ctx context.Context) ([]*entity.Organization, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listOrganizationsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update updates the settings of an organization
+func (r *organizationRepository) Update(ctx context.Context, org *entity.Organization) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateOrganizationMongo(ctx, db, org)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
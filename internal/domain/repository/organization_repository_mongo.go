@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createOrganizationMongo creates an organization in MongoDB
+func (r *organizationRepository) createOrganizationMongo(ctx context.Context, client *mongo.Client, org *entity.Organization) error {
+	collection := client.Database("user_service").Collection("organizations")
+	_, err := collection.InsertOne(ctx, org)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", org.ID.String()).Msg("Failed to create organization in MongoDB")
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	return nil
+}
+
+// getOrganizationByIDMongo gets an organization by ID from MongoDB
+func (r *organizationRepository) getOrganizationByIDMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.Organization, error) {
+	collection := client.Database("user_service").Collection("organizations")
+
+	var org entity.Organization
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&org)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Organization not found
+		}
+		log.Error().Err(err).Str("org_id", id.String()).Msg("Failed to get organization from MongoDB")
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return &org, nil
+}
+
+// listOrganizationsMongo lists all organizations from MongoDB
+func (r *organizationRepository) listOrganizationsMongo(ctx context.Context, client *mongo.Client) ([]*entity.Organization, error) {
+	collection := client.Database("user_service").Collection("organizations")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list organizations from MongoDB")
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var orgs []*entity.Organization
+	if err := cursor.All(ctx, &orgs); err != nil {
+		log.Error().Err(err).Msg("Failed to decode organizations from MongoDB")
+		return nil, fmt.Errorf("failed to decode organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+// updateOrganizationMongo updates the settings of an organization in MongoDB
+func (r *organizationRepository) updateOrganizationMongo(ctx context.Context, client *mongo.Client, org *entity.Organization) error {
+	collection := client.Database("user_service").Collection("organizations")
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":              org.Name,
+			"profile_fields":    org.ProfileFields,
+			"self_registration": org.SelfRegistration,
+			"branding":          org.Branding,
+			"sso":               org.SSO,
+			"plan":              org.Plan,
+			"member_limit":      org.MemberLimit,
+			"updated_at":        org.UpdatedAt,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": org.ID}, update)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", org.ID.String()).Msg("Failed to update organization in MongoDB")
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	passkeyCeremonyPrefix         = "passkey_ceremony:"
+	consumedPasskeyCeremonyPrefix = "passkey_ceremony_consumed:"
+)
+
+// PasskeyCeremonyRepository defines the interface for the pending passkey registrations and sign ins
+type PasskeyCeremonyRepository interface {
+	// Store stores a ceremony until it expires
+	Store(ctx context.Context, ceremony *entity.PasskeyCeremony) error
+
+	// Consume deletes a ceremony and returns it, nil if unknown, expired or already consumed
+	Consume(ctx context.Context, id uuid.UUID) (*entity.PasskeyCeremony, error)
+}
+
+type passkeyCeremonyRepository struct {
+	cache cache.Cache
+}
+
+// NewPasskeyCeremonyRepository creates a new passkey ceremony repository
+func NewPasskeyCeremonyRepository(cache cache.Cache) PasskeyCeremonyRepository {
+	return &passkeyCeremonyRepository{
+		cache: cache,
+	}
+}
+
+// Store stores a ceremony until it expires
+func (r *passkeyCeremonyRepository) Store(ctx context.Context, ceremony *entity.PasskeyCeremony) error {
+	data, err := json.Marshal(ceremony)
+	if err != nil {
+		return fmt.Errorf("failed to marshal passkey ceremony: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, passkeyCeremonyPrefix+ceremony.ID.String(), data, time.Until(ceremony.ExpiresAt)); err != nil {
+		log.Error().Err(err).Str("ceremony_id", ceremony.ID.String()).Msg("Failed to store passkey ceremony in cache")
+		return fmt.Errorf("failed to store passkey ceremony: %w", err)
+	}
+
+	return nil
+}
+
+// Consume deletes a ceremony and returns it, nil if unknown, expired or already consumed.
+// The ceremony is claimed first, so a response submitted to several instances at once is verified once.
+func (r *passkeyCeremonyRepository) Consume(ctx context.Context, id uuid.UUID) (*entity.PasskeyCeremony, error) {
+	data, err := r.cache.Get(ctx, passkeyCeremonyPrefix+id.String())
+	if err != nil {
+		log.Error().Err(err).Str("ceremony_id", id.String()).Msg("Failed to get passkey ceremony from cache")
+		return nil, fmt.Errorf("failed to get passkey ceremony: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var ceremony entity.PasskeyCeremony
+	if err := json.Unmarshal(data, &ceremony); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal passkey ceremony: %w", err)
+	}
+
+	// The claim lives as long as the ceremony could have
+	claimed, err := r.cache.SetNX(ctx, consumedPasskeyCeremonyPrefix+id.String(), []byte("1"), time.Until(ceremony.ExpiresAt))
+	if err != nil {
+		log.Error().Err(err).Str("ceremony_id", id.String()).Msg("Failed to claim passkey ceremony")
+		return nil, fmt.Errorf("failed to claim passkey ceremony: %w", err)
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	if err := r.cache.Delete(ctx, passkeyCeremonyPrefix+id.String()); err != nil {
+		log.Warn().Err(err).Str("ceremony_id", id.String()).Msg("Failed to delete passkey ceremony from cache")
+	}
+
+	return &ceremony, nil
+}
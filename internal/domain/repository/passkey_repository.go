@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	passkeyCacheKeyPrefix      = "passkey:"
+	userPasskeysCacheKeyPrefix = "passkeys:user:"
+	passkeyCacheTTL            = 30 * time.Minute
+)
+
+// PasskeyRepository defines the interface for the passkeys users sign in with
+type PasskeyRepository interface {
+	// Create stores a new passkey
+	Create(ctx context.Context, passkey *entity.Passkey) error
+
+	// GetByID returns a passkey by credential ID, nil if unknown
+	GetByID(ctx context.Context, id string) (*entity.Passkey, error)
+
+	// ListByUser returns the passkeys of a user, oldest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Passkey, error)
+
+	// UpdateUsage stores the signature counter and last use time of a passkey
+	UpdateUsage(ctx context.Context, passkey *entity.Passkey) error
+
+	// Delete deletes a passkey
+	Delete(ctx context.Context, passkey *entity.Passkey) error
+
+	// DeleteByUser deletes the passkeys of a user
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+
+	// CountUsers returns the number of users holding at least one passkey
+	CountUsers(ctx context.Context) (int64, error)
+}
+
+type passkeyRepository struct {
+	db    db.Database
+	cache cache.Cache
+}
+
+// NewPasskeyRepository creates a new PasskeyRepository, caching the passkeys read at sign in
+func NewPasskeyRepository(db db.Database, cache cache.Cache) PasskeyRepository {
+	return &passkeyRepository{
+		db:    db,
+		cache: cache,
+	}
+}
+
+// Create stores a new passkey
+func (r *passkeyRepository) Create(ctx context.Context, passkey *entity.Passkey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		if err := r.createPasskeyMongo(ctx, db, passkey); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	r.uncacheUserPasskeys(ctx, passkey.UserID)
+	return nil
+}
+
+// GetByID retrieves a passkey by credential ID
+func (r *passkeyRepository) GetByID(ctx context.Context, id string) (*entity.Passkey, error) {
+	var passkey *entity.Passkey
+	if r.getCached(ctx, passkeyCacheKeyPrefix+id, &passkey) {
+		return passkey, nil
+	}
+
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		passkey, err = r.getPasskeyByIDMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+	if err != nil || passkey == nil {
+		return passkey, err
+	}
+
+	r.setCached(ctx, passkeyCacheKeyPrefix+id, passkey)
+	return passkey, nil
+}
+
+// ListByUser retrieves the passkeys of a user
+func (r *passkeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Passkey, error) {
+	var passkeys []*entity.Passkey
+	if r.getCached(ctx, userPasskeysCacheKeyPrefix+userID.String(), &passkeys) {
+		return passkeys, nil
+	}
+
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		passkeys, err = r.listPasskeysByUserMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.setCached(ctx, userPasskeysCacheKeyPrefix+userID.String(), passkeys)
+	return passkeys, nil
+}
+
+// UpdateUsage stores the signature counter and last use time of a passkey
+func (r *passkeyRepository) UpdateUsage(ctx context.Context, passkey *entity.Passkey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		if err := r.updatePasskeyUsageMongo(ctx, db, passkey); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	r.uncachePasskey(ctx, passkey)
+	return nil
+}
+
+// Delete deletes a passkey
+func (r *passkeyRepository) Delete(ctx context.Context, passkey *entity.Passkey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		if err := r.deletePasskeyMongo(ctx, db, passkey.ID); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	r.uncachePasskey(ctx, passkey)
+	return nil
+}
+
+// DeleteByUser deletes the passkeys of a user
+func (r *passkeyRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	passkeys, err := r.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		if err := r.deletePasskeysByUserMongo(ctx, db, userID); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	for _, passkey := range passkeys {
+		r.uncachePasskey(ctx, passkey)
+	}
+	r.uncacheUserPasskeys(ctx, userID)
+	return nil
+}
+
+// CountUsers counts the users holding at least one passkey, straight from the database
+func (r *passkeyRepository) CountUsers(ctx context.Context) (int64, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.countPasskeyUsersMongo(ctx, db)
+	default:
+		return 0, errors.New("unsupported database type")
+	}
+}
+
+// getCached decodes a cached value into target, reporting whether it was cached
+func (r *passkeyRepository) getCached(ctx context.Context, key string, target any) bool {
+	data, err := r.cache.Get(ctx, key)
+	if err != nil || data == nil {
+		return false
+	}
+	return json.Unmarshal(data, target) == nil
+}
+
+// setCached caches a value, the database stays the reference so failures are only logged
+func (r *passkeyRepository) setCached(ctx context.Context, key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	if err := r.cache.Set(ctx, key, data, passkeyCacheTTL); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to cache passkeys")
+	}
+}
+
+// uncachePasskey removes a passkey and the passkey list of its owner from cache
+func (r *passkeyRepository) uncachePasskey(ctx context.Context, passkey *entity.Passkey) {
+	if err := r.cache.Delete(ctx, passkeyCacheKeyPrefix+passkey.ID); err != nil {
+		log.Warn().Err(err).Str("user_id", passkey.UserID.String()).Msg("Failed to delete passkey from cache")
+	}
+	r.uncacheUserPasskeys(ctx, passkey.UserID)
+}
+
+// uncacheUserPasskeys removes the passkey list of a user from cache
+func (r *passkeyRepository) uncacheUserPasskeys(ctx context.Context, userID uuid.UUID) {
+	if err := r.cache.Delete(ctx, userPasskeysCacheKeyPrefix+userID.String()); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to delete passkeys of user from cache")
+	}
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createPasskeyMongo inserts a passkey in MongoDB
+func (r *passkeyRepository) createPasskeyMongo(ctx context.Context, client *mongo.Client, passkey *entity.Passkey) error {
+	collection := client.Database("user_service").Collection("passkeys")
+
+	if _, err := collection.InsertOne(ctx, passkey); err != nil {
+		log.Error().Err(err).Str("user_id", passkey.UserID.String()).Msg("Failed to create passkey in MongoDB")
+		return fmt.Errorf("failed to create passkey: %w", err)
+	}
+	return nil
+}
+
+// getPasskeyByIDMongo gets a passkey by credential ID from MongoDB
+func (r *passkeyRepository) getPasskeyByIDMongo(ctx context.Context, client *mongo.Client, id string) (*entity.Passkey, error) {
+	collection := client.Database("user_service").Collection("passkeys")
+
+	var passkey entity.Passkey
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&passkey)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Passkey not found
+		}
+		log.Error().Err(err).Msg("Failed to get passkey from MongoDB")
+		return nil, fmt.Errorf("failed to get passkey: %w", err)
+	}
+
+	return &passkey, nil
+}
+
+// listPasskeysByUserMongo lists the passkeys of a user from MongoDB, oldest first
+func (r *passkeyRepository) listPasskeysByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) ([]*entity.Passkey, error) {
+	collection := client.Database("user_service").Collection("passkeys")
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list passkeys from MongoDB")
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	passkeys := []*entity.Passkey{}
+	if err := cursor.All(ctx, &passkeys); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to decode passkeys from MongoDB")
+		return nil, fmt.Errorf("failed to decode passkeys: %w", err)
+	}
+
+	return passkeys, nil
+}
+
+// updatePasskeyUsageMongo sets the signature counter and last use time of a passkey in MongoDB
+func (r *passkeyRepository) updatePasskeyUsageMongo(ctx context.Context, client *mongo.Client, passkey *entity.Passkey) error {
+	collection := client.Database("user_service").Collection("passkeys")
+
+	update := bson.M{
+		"$set": bson.M{
+			"sign_count":   passkey.SignCount,
+			"last_used_at": passkey.LastUsedAt,
+		},
+	}
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": passkey.ID}, update); err != nil {
+		log.Error().Err(err).Str("user_id", passkey.UserID.String()).Msg("Failed to update passkey usage in MongoDB")
+		return fmt.Errorf("failed to update passkey usage: %w", err)
+	}
+	return nil
+}
+
+// deletePasskeyMongo deletes a passkey from MongoDB
+func (r *passkeyRepository) deletePasskeyMongo(ctx context.Context, client *mongo.Client, id string) error {
+	collection := client.Database("user_service").Collection("passkeys")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Msg("Failed to delete passkey from MongoDB")
+		return fmt.Errorf("failed to delete passkey: %w", err)
+	}
+	return nil
+}
+
+// deletePasskeysByUserMongo deletes the passkeys of a user from MongoDB
+func (r *passkeyRepository) deletePasskeysByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) error {
+	collection := client.Database("user_service").Collection("passkeys")
+
+	if _, err := collection.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete passkeys of user from MongoDB")
+		return fmt.Errorf("failed to delete passkeys: %w", err)
+	}
+	return nil
+}
+
+// countPasskeyUsersMongo counts the distinct owners of the passkeys in MongoDB
+func (r *passkeyRepository) countPasskeyUsersMongo(ctx context.Context, client *mongo.Client) (int64, error) {
+	collection := client.Database("user_service").Collection("passkeys")
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$user_id"}}},
+		{{Key: "$count", Value: "users"}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count passkey users in MongoDB")
+		return 0, fmt.Errorf("failed to count passkey users: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Users int64 `bson:"users"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		log.Error().Err(err).Msg("Failed to decode passkey user count from MongoDB")
+		return 0, fmt.Errorf("failed to decode passkey user count: %w", err)
+	}
+
+	// No passkey, no document
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Users, nil
+}
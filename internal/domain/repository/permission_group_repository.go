@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PermissionGroupRepository defines the interface for permission group repository operations
+type PermissionGroupRepository interface {
+	// Create a new permission group
+	Create(ctx context.Context, group *entity.PermissionGroup) error
+
+	// Get a permission group by name, returns nil if the group does not exist
+	GetByName(ctx context.Context, name string) (*entity.PermissionGroup, error)
+
+	// List all permission groups ordered by name
+	List(ctx context.Context) ([]*entity.PermissionGroup, error)
+
+	// Update a permission group
+	Update(ctx context.Context, group *entity.PermissionGroup) error
+
+	// Delete a permission group
+	Delete(ctx context.Context, name string) error
+}
+
+type permissionGroupRepository struct {
+	db db.Database
+}
+
+// NewPermissionGroupRepository creates a new PermissionGroupRepository
+func NewPermissionGroupRepository(db db.Database) PermissionGroupRepository {
+	return &permissionGroupRepository{
+		db: db,
+	}
+}
+
+// Create creates a new permission group
+func (r *permissionGroupRepository) Create(ctx context.Context, group *entity.PermissionGroup) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createPermissionGroupMongo(ctx, db, group)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByName retrieves a permission group by name
+func (r *permissionGroupRepository) GetByName(ctx context.Context, name string) (*entity.PermissionGroup, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getPermissionGroupByNameMongo(ctx, db, name)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists all permission groups
+func (r *permissionGroupRepository) List(ctx context.Context) ([]*entity.PermissionGroup, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listPermissionGroupsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update updates a permission group
+func (r *permissionGroupRepository) Update(ctx context.Context, group *entity.PermissionGroup) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updatePermissionGroupMongo(ctx, db, group)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a permission group
+func (r *permissionGroupRepository) Delete(ctx context.Context, name string) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deletePermissionGroupMongo(ctx, db, name)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createPermissionGroupMongo creates a permission group in MongoDB
+func (r *permissionGroupRepository) createPermissionGroupMongo(ctx context.Context, client *mongo.Client, group *entity.PermissionGroup) error {
+	collection := client.Database("user_service").Collection("permission_groups")
+	_, err := collection.InsertOne(ctx, group)
+	if err != nil {
+		log.Error().Err(err).Str("permission_group", group.Name).Msg("Failed to create permission group in MongoDB")
+		return fmt.Errorf("failed to create permission group: %w", err)
+	}
+	return nil
+}
+
+// getPermissionGroupByNameMongo gets a permission group by name from MongoDB
+func (r *permissionGroupRepository) getPermissionGroupByNameMongo(ctx context.Context, client *mongo.Client, name string) (*entity.PermissionGroup, error) {
+	collection := client.Database("user_service").Collection("permission_groups")
+
+	var group entity.PermissionGroup
+	err := collection.FindOne(ctx, bson.M{"_id": name}).Decode(&group)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Permission group not found
+		}
+		log.Error().Err(err).Str("permission_group", name).Msg("Failed to get permission group from MongoDB")
+		return nil, fmt.Errorf("failed to get permission group: %w", err)
+	}
+
+	return &group, nil
+}
+
+// listPermissionGroupsMongo lists all permission groups from MongoDB
+func (r *permissionGroupRepository) listPermissionGroupsMongo(ctx context.Context, client *mongo.Client) ([]*entity.PermissionGroup, error) {
+	collection := client.Database("user_service").Collection("permission_groups")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list permission groups from MongoDB")
+		return nil, fmt.Errorf("failed to list permission groups: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groups []*entity.PermissionGroup
+	if err := cursor.All(ctx, &groups); err != nil {
+		log.Error().Err(err).Msg("Failed to decode permission groups from MongoDB")
+		return nil, fmt.Errorf("failed to decode permission groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// updatePermissionGroupMongo updates a permission group in MongoDB
+func (r *permissionGroupRepository) updatePermissionGroupMongo(ctx context.Context, client *mongo.Client, group *entity.PermissionGroup) error {
+	collection := client.Database("user_service").Collection("permission_groups")
+
+	update := bson.M{
+		"$set": bson.M{
+			"description": group.Description,
+			"permissions": group.Permissions,
+			"updated_at":  group.UpdatedAt,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": group.Name}, update)
+	if err != nil {
+		log.Error().Err(err).Str("permission_group", group.Name).Msg("Failed to update permission group in MongoDB")
+		return fmt.Errorf("failed to update permission group: %w", err)
+	}
+
+	return nil
+}
+
+// deletePermissionGroupMongo deletes a permission group from MongoDB
+func (r *permissionGroupRepository) deletePermissionGroupMongo(ctx context.Context, client *mongo.Client, name string) error {
+	collection := client.Database("user_service").Collection("permission_groups")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		log.Error().Err(err).Str("permission_group", name).Msg("Failed to delete permission group from MongoDB")
+		return fmt.Errorf("failed to delete permission group: %w", err)
+	}
+
+	return nil
+}
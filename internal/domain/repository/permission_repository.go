@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PermissionRepository defines the interface for custom permission repository operations
+type PermissionRepository interface {
+	// Create a new permission
+	Create(ctx context.Context, permission *entity.Permission) error
+
+	// Get a permission by name, returns nil if the permission does not exist
+	GetByName(ctx context.Context, name string) (*entity.Permission, error)
+
+	// List all permissions ordered by name
+	List(ctx context.Context) ([]*entity.Permission, error)
+
+	// Update a permission
+	Update(ctx context.Context, permission *entity.Permission) error
+
+	// Delete a permission
+	Delete(ctx context.Context, name string) error
+}
+
+type permissionRepository struct {
+	db db.Database
+}
+
+// NewPermissionRepository creates a new PermissionRepository
+func NewPermissionRepository(db db.Database) PermissionRepository {
+	return &permissionRepository{
+		db: db,
+	}
+}
+
+// Create creates a new permission
+func (r *permissionRepository) Create(ctx context.Context, permission *entity.Permission) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createPermissionMongo(ctx, db, permission)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByName retrieves a permission by name
+func (r *permissionRepository) GetByName(ctx context.Context, name string) (*entity.Permission, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getPermissionByNameMongo(ctx, db, name)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists all permissions
+func (r *permissionRepository) List(ctx context.Context) ([]*entity.Permission, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listPermissionsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update updates a permission
+func (r *permissionRepository) Update(ctx context.Context, permission *entity.Permission) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updatePermissionMongo(ctx, db, permission)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a permission
+func (r *permissionRepository) Delete(ctx context.Context, name string) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deletePermissionMongo(ctx, db, name)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
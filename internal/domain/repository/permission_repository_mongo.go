@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createPermissionMongo creates a permission in MongoDB
+func (r *permissionRepository) createPermissionMongo(ctx context.Context, client *mongo.Client, permission *entity.Permission) error {
+	collection := client.Database("user_service").Collection("permissions")
+	_, err := collection.InsertOne(ctx, permission)
+	if err != nil {
+		log.Error().Err(err).Str("permission", permission.Name).Msg("Failed to create permission in MongoDB")
+		return fmt.Errorf("failed to create permission: %w", err)
+	}
+	return nil
+}
+
+// getPermissionByNameMongo gets a permission by name from MongoDB
+func (r *permissionRepository) getPermissionByNameMongo(ctx context.Context, client *mongo.Client, name string) (*entity.Permission, error) {
+	collection := client.Database("user_service").Collection("permissions")
+
+	var permission entity.Permission
+	err := collection.FindOne(ctx, bson.M{"_id": name}).Decode(&permission)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Permission not found
+		}
+		log.Error().Err(err).Str("permission", name).Msg("Failed to get permission from MongoDB")
+		return nil, fmt.Errorf("failed to get permission: %w", err)
+	}
+
+	return &permission, nil
+}
+
+// listPermissionsMongo lists all permissions from MongoDB
+func (r *permissionRepository) listPermissionsMongo(ctx context.Context, client *mongo.Client) ([]*entity.Permission, error) {
+	collection := client.Database("user_service").Collection("permissions")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list permissions from MongoDB")
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var permissions []*entity.Permission
+	if err := cursor.All(ctx, &permissions); err != nil {
+		log.Error().Err(err).Msg("Failed to decode permissions from MongoDB")
+		return nil, fmt.Errorf("failed to decode permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// updatePermissionMongo updates a permission in MongoDB
+func (r *permissionRepository) updatePermissionMongo(ctx context.Context, client *mongo.Client, permission *entity.Permission) error {
+	collection := client.Database("user_service").Collection("permissions")
+
+	update := bson.M{
+		"$set": bson.M{
+			"description": permission.Description,
+			"updated_at":  permission.UpdatedAt,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": permission.Name}, update)
+	if err != nil {
+		log.Error().Err(err).Str("permission", permission.Name).Msg("Failed to update permission in MongoDB")
+		return fmt.Errorf("failed to update permission: %w", err)
+	}
+
+	return nil
+}
+
+// deletePermissionMongo deletes a permission from MongoDB
+func (r *permissionRepository) deletePermissionMongo(ctx context.Context, client *mongo.Client, name string) error {
+	collection := client.Database("user_service").Collection("permissions")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		log.Error().Err(err).Str("permission", name).Msg("Failed to delete permission from MongoDB")
+		return fmt.Errorf("failed to delete permission: %w", err)
+	}
+
+	return nil
+}
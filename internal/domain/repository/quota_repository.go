@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const quotaPrefix = "quota:"
+
+// QuotaRepository defines the interface for the request counters of the quotas, shared by the instances
+type QuotaRepository interface {
+	// Increment counts a request of a subject in a window and returns the requests counted in it. The counter
+	// expires after the given expiration, counted from the first request of the window.
+	Increment(ctx context.Context, subject, window string, expiration time.Duration) (int64, error)
+
+	// Get returns the requests of a subject counted in a window, 0 if none
+	Get(ctx context.Context, subject, window string) (int64, error)
+}
+
+type quotaRepository struct {
+	cache cache.Cache
+}
+
+// NewQuotaRepository creates a new quota repository
+func NewQuotaRepository(cache cache.Cache) QuotaRepository {
+	return &quotaRepository{
+		cache: cache,
+	}
+}
+
+// Increment counts a request of a subject in a window and returns the requests counted in it
+func (r *quotaRepository) Increment(ctx context.Context, subject, window string, expiration time.Duration) (int64, error) {
+	count, err := r.cache.Increment(ctx, quotaKey(subject, window), 1, expiration)
+	if err != nil {
+		log.Error().Err(err).Str("subject", subject).Str("window", window).Msg("Failed to increment quota counter")
+		return 0, fmt.Errorf("failed to increment quota counter: %w", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the requests of a subject counted in a window, 0 if none
+func (r *quotaRepository) Get(ctx context.Context, subject, window string) (int64, error) {
+	data, err := r.cache.Get(ctx, quotaKey(subject, window))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get quota counter: %w", err)
+	}
+	if data == nil {
+		return 0, nil
+	}
+
+	count, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse quota counter: %w", err)
+	}
+	return count, nil
+}
+
+// quotaKey returns the cache key of the counter of a subject in a window
+func quotaKey(subject, window string) string {
+	return quotaPrefix + subject + ":" + window
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReferralRepository defines the interface for referral codes and referrals
+type ReferralRepository interface {
+	// CreateCode stores a referral code, returns false if the code is already taken
+	CreateCode(ctx context.Context, code *entity.ReferralCode) (bool, error)
+
+	// GetCode returns a referral code, nil if unknown
+	GetCode(ctx context.Context, code string) (*entity.ReferralCode, error)
+
+	// GetCodeByUser returns the referral code of a user, nil if they have none
+	GetCodeByUser(ctx context.Context, userID uuid.UUID) (*entity.ReferralCode, error)
+
+	// Create records a referral
+	Create(ctx context.Context, referral *entity.Referral) error
+
+	// GetStats returns the number of users referred by a user and the time of the last referral, without the code
+	GetStats(ctx context.Context, referrerID uuid.UUID) (*entity.ReferralStats, error)
+
+	// Leaderboard returns the users with the most referrals, most referrals first
+	Leaderboard(ctx context.Context, limit int) ([]*entity.ReferrerRanking, error)
+}
+
+type referralRepository struct {
+	db db.Database
+}
+
+// NewReferralRepository creates a new ReferralRepository
+func NewReferralRepository(db db.Database) ReferralRepository {
+	return &referralRepository{
+		db: db,
+	}
+}
+
+// CreateCode stores a referral code
+func (r *referralRepository) CreateCode(ctx context.Context, code *entity.ReferralCode) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createCodeMongo(ctx, db, code)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// GetCode retrieves a referral code
+func (r *referralRepository) GetCode(ctx context.Context, code string) (*entity.ReferralCode, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getCodeMongo(ctx, db, code)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// GetCodeByUser retrieves the referral code of a user
+func (r *referralRepository) GetCodeByUser(ctx context.Context, userID uuid.UUID) (*entity.ReferralCode, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getCodeByUserMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Create records a referral
+func (r *referralRepository) Create(ctx context.Context, referral *entity.Referral) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createReferralMongo(ctx, db, referral)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetStats summarizes the referrals of a user
+func (r *referralRepository) GetStats(ctx context.Context, referrerID uuid.UUID) (*entity.ReferralStats, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getStatsMongo(ctx, db, referrerID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Leaderboard ranks the users by referrals
+func (r *referralRepository) Leaderboard(ctx context.Context, limit int) ([]*entity.ReferrerRanking, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.leaderboardMongo(ctx, db, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
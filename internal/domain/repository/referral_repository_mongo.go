@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createCodeMongo inserts a referral code in MongoDB, the code is the document ID so taken codes are rejected
+func (r *referralRepository) createCodeMongo(ctx context.Context, client *mongo.Client, code *entity.ReferralCode) (bool, error) {
+	collection := client.Database("user_service").Collection("referral_codes")
+
+	_, err := collection.InsertOne(ctx, code)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Str("user_id", code.UserID.String()).Msg("Failed to create referral code in MongoDB")
+		return false, fmt.Errorf("failed to create referral code: %w", err)
+	}
+	return true, nil
+}
+
+// getCodeMongo gets a referral code from MongoDB
+func (r *referralRepository) getCodeMongo(ctx context.Context, client *mongo.Client, code string) (*entity.ReferralCode, error) {
+	collection := client.Database("user_service").Collection("referral_codes")
+
+	var referralCode entity.ReferralCode
+	err := collection.FindOne(ctx, bson.M{"_id": code}).Decode(&referralCode)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Code not found
+		}
+		log.Error().Err(err).Msg("Failed to get referral code from MongoDB")
+		return nil, fmt.Errorf("failed to get referral code: %w", err)
+	}
+
+	return &referralCode, nil
+}
+
+// getCodeByUserMongo gets the referral code of a user from MongoDB, the oldest one if concurrent requests
+// created several
+func (r *referralRepository) getCodeByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) (*entity.ReferralCode, error) {
+	collection := client.Database("user_service").Collection("referral_codes")
+
+	var referralCode entity.ReferralCode
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	err := collection.FindOne(ctx, bson.M{"user_id": userID}, opts).Decode(&referralCode)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // User has no code yet
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get referral code of user from MongoDB")
+		return nil, fmt.Errorf("failed to get referral code: %w", err)
+	}
+
+	return &referralCode, nil
+}
+
+// createReferralMongo inserts a referral in MongoDB
+func (r *referralRepository) createReferralMongo(ctx context.Context, client *mongo.Client, referral *entity.Referral) error {
+	collection := client.Database("user_service").Collection("referrals")
+
+	if _, err := collection.InsertOne(ctx, referral); err != nil {
+		log.Error().Err(err).Str("referee_id", referral.RefereeID.String()).Msg("Failed to create referral in MongoDB")
+		return fmt.Errorf("failed to create referral: %w", err)
+	}
+	return nil
+}
+
+// getStatsMongo counts the referrals of a user in MongoDB
+func (r *referralRepository) getStatsMongo(ctx context.Context, client *mongo.Client, referrerID uuid.UUID) (*entity.ReferralStats, error) {
+	collection := client.Database("user_service").Collection("referrals")
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"referrer_id": referrerID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              nil,
+			"referrals":        bson.M{"$sum": 1},
+			"last_referral_at": bson.M{"$max": "$created_at"},
+		}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", referrerID.String()).Msg("Failed to count referrals in MongoDB")
+		return nil, fmt.Errorf("failed to count referrals: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Referrals      int64     `bson:"referrals"`
+		LastReferralAt time.Time `bson:"last_referral_at"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		log.Error().Err(err).Str("user_id", referrerID.String()).Msg("Failed to decode referral counts from MongoDB")
+		return nil, fmt.Errorf("failed to decode referral counts: %w", err)
+	}
+
+	stats := &entity.ReferralStats{}
+	if len(results) > 0 {
+		stats.Referrals = results[0].Referrals
+		stats.LastReferralAt = &results[0].LastReferralAt
+	}
+	return stats, nil
+}
+
+// leaderboardMongo ranks the users by referrals in MongoDB
+func (r *referralRepository) leaderboardMongo(ctx context.Context, client *mongo.Client, limit int) ([]*entity.ReferrerRanking, error) {
+	collection := client.Database("user_service").Collection("referrals")
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$referrer_id", "referrals": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "referrals", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to rank referrers in MongoDB")
+		return nil, fmt.Errorf("failed to rank referrers: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rankings []*entity.ReferrerRanking
+	if err := cursor.All(ctx, &rankings); err != nil {
+		log.Error().Err(err).Msg("Failed to decode referrer rankings from MongoDB")
+		return nil, fmt.Errorf("failed to decode referrer rankings: %w", err)
+	}
+
+	return rankings, nil
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/requestcache"
+	"github.com/google/uuid"
+)
+
+// requestCachedUserRepository decorates a UserRepository with the cache of the request, so a user looked up
+// several times within a request, e.g. by a use case then by the use case it calls, is read once. Users are cached
+// by ID whichever lookup found them, and dropped once changed. Outside requests, calls go straight through.
+type requestCachedUserRepository struct {
+	next UserRepository
+}
+
+// NewRequestCachedUserRepository wraps a UserRepository so the users read within a request are kept for the
+// rest of it
+func NewRequestCachedUserRepository(next UserRepository) UserRepository {
+	return &requestCachedUserRepository{next: next}
+}
+
+// requestCacheKey returns the request cache key of a user
+func requestCacheKey(id uuid.UUID) string {
+	return userCacheKeyPrefix + id.String()
+}
+
+// remember keeps a copy of a user found in the cache of the request, callers changing the user they got do not
+// change the cached one
+func (r *requestCachedUserRepository) remember(ctx context.Context, user *entity.User) {
+	if cache := requestcache.FromContext(ctx); cache != nil && user != nil {
+		cache.Set(requestCacheKey(user.ID), user.Clone())
+	}
+}
+
+// forget drops a user from the cache of the request
+func (r *requestCachedUserRepository) forget(ctx context.Context, id uuid.UUID) {
+	if cache := requestcache.FromContext(ctx); cache != nil {
+		cache.Delete(requestCacheKey(id))
+	}
+}
+
+// Create creates a new user
+func (r *requestCachedUserRepository) Create(ctx context.Context, user *entity.User) error {
+	return r.next.Create(ctx, user)
+}
+
+// CreateMany creates users in a single batch
+func (r *requestCachedUserRepository) CreateMany(ctx context.Context, users []*entity.User) error {
+	return r.next.CreateMany(ctx, users)
+}
+
+// GetByID retrieves a user by ID, from the cache of the request when it was read already
+func (r *requestCachedUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	if cache := requestcache.FromContext(ctx); cache != nil {
+		if cached, ok := cache.Get(requestCacheKey(id)); ok {
+			return cached.(*entity.User).Clone(), nil
+		}
+	}
+
+	user, err := r.next.GetByID(ctx, id)
+	if err == nil {
+		r.remember(ctx, user)
+	}
+	return user, err
+}
+
+// GetByEmail retrieves a user by email
+func (r *requestCachedUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	user, err := r.next.GetByEmail(ctx, email)
+	if err == nil {
+		r.remember(ctx, user)
+	}
+	return user, err
+}
+
+// GetByUsername retrieves a user by username
+func (r *requestCachedUserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	user, err := r.next.GetByUsername(ctx, username)
+	if err == nil {
+		r.remember(ctx, user)
+	}
+	return user, err
+}
+
+// GetByRecoveryEmail retrieves a user by verified recovery email
+func (r *requestCachedUserRepository) GetByRecoveryEmail(ctx context.Context, email string) (*entity.User, error) {
+	user, err := r.next.GetByRecoveryEmail(ctx, email)
+	if err == nil {
+		r.remember(ctx, user)
+	}
+	return user, err
+}
+
+// Update updates user information
+func (r *requestCachedUserRepository) Update(ctx context.Context, user *entity.User) error {
+	r.forget(ctx, user.ID)
+	return r.next.Update(ctx, user)
+}
+
+// Delete deletes a user
+func (r *requestCachedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.forget(ctx, id)
+	return r.next.Delete(ctx, id)
+}
+
+// List lists users with pagination
+func (r *requestCachedUserRepository) List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error) {
+	return r.next.List(ctx, page, limit, opts)
+}
+
+// ChangePassword changes a user's password
+func (r *requestCachedUserRepository) ChangePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+	r.forget(ctx, id)
+	return r.next.ChangePassword(ctx, id, hashedPassword)
+}
+
+// RehashPassword replaces the password hash of a user with a hash of the same password
+func (r *requestCachedUserRepository) RehashPassword(ctx context.Context, id uuid.UUID, currentHash, hashedPassword string) error {
+	r.forget(ctx, id)
+	return r.next.RehashPassword(ctx, id, currentHash, hashedPassword)
+}
+
+// UpdateStatus updates user status
+func (r *requestCachedUserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	r.forget(ctx, id)
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+// AddTags adds tags to a user
+func (r *requestCachedUserRepository) AddTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	r.forget(ctx, id)
+	return r.next.AddTags(ctx, id, tags)
+}
+
+// RemoveTags removes tags from a user
+func (r *requestCachedUserRepository) RemoveTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	r.forget(ctx, id)
+	return r.next.RemoveTags(ctx, id, tags)
+}
+
+// ListDueForPurge lists the users pending deletion whose purge time is before the given time
+func (r *requestCachedUserRepository) ListDueForPurge(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	return r.next.ListDueForPurge(ctx, before, limit)
+}
+
+// ListWaitlisted lists the waitlisted users
+func (r *requestCachedUserRepository) ListWaitlisted(ctx context.Context, limit int) ([]*entity.User, error) {
+	return r.next.ListWaitlisted(ctx, limit)
+}
+
+// RecordActivity records the time a user was last active
+func (r *requestCachedUserRepository) RecordActivity(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.forget(ctx, id)
+	return r.next.RecordActivity(ctx, id, at)
+}
+
+// ListCreatedBetween lists the users created in [from, to)
+func (r *requestCachedUserRepository) ListCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	return r.next.ListCreatedBetween(ctx, from, to, limit)
+}
+
+// ListLastActiveBetween lists the users last active in [from, to)
+func (r *requestCachedUserRepository) ListLastActiveBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	return r.next.ListLastActiveBetween(ctx, from, to, limit)
+}
+
+// CountByStatusAndRole counts the users of every status and role combination in use
+func (r *requestCachedUserRepository) CountByStatusAndRole(ctx context.Context) ([]entity.UserCount, error) {
+	return r.next.CountByStatusAndRole(ctx)
+}
+
+// CountCreatedSince counts the users created since a time
+func (r *requestCachedUserRepository) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	return r.next.CountCreatedSince(ctx, since)
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RoleAssignmentRepository defines the interface for the roles assigned to users on top of their primary role
+type RoleAssignmentRepository interface {
+	// Assign assigns a role to a user, returns false if the role was already assigned to them
+	Assign(ctx context.Context, assignment *entity.RoleAssignment) (bool, error)
+
+	// Unassign removes a role from a user, returns false if the role was not assigned to them
+	Unassign(ctx context.Context, userID uuid.UUID, role string) (bool, error)
+
+	// ListByUser lists the roles assigned to a user, ordered by role
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RoleAssignment, error)
+
+	// ListByRole lists the users a role is assigned to, oldest assignment first
+	ListByRole(ctx context.Context, role string) ([]*entity.RoleAssignment, error)
+
+	// DeleteByUser removes every role assigned to a user
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+
+	// ListExpired lists at most limit assignments expired at now, earliest expiration first
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RoleAssignment, error)
+
+	// DeleteExpired removes an expired assignment if it still expires at the same time, returns false if it was
+	// already removed or granted anew meanwhile
+	DeleteExpired(ctx context.Context, assignment *entity.RoleAssignment) (bool, error)
+}
+
+type roleAssignmentRepository struct {
+	db db.Database
+}
+
+// NewRoleAssignmentRepository creates a new RoleAssignmentRepository
+func NewRoleAssignmentRepository(db db.Database) RoleAssignmentRepository {
+	return &roleAssignmentRepository{
+		db: db,
+	}
+}
+
+// Assign assigns a role to a user
+func (r *roleAssignmentRepository) Assign(ctx context.Context, assignment *entity.RoleAssignment) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.assignRoleMongo(ctx, db, assignment)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// Unassign removes a role from a user
+func (r *roleAssignmentRepository) Unassign(ctx context.Context, userID uuid.UUID, role string) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.unassignRoleMongo(ctx, db, userID, role)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// ListByUser lists the roles assigned to a user
+func (r *roleAssignmentRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RoleAssignment, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listRoleAssignmentsMongo(ctx, db, "user_id", userID, "role")
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListByRole lists the users a role is assigned to
+func (r *roleAssignmentRepository) ListByRole(ctx context.Context, role string) ([]*entity.RoleAssignment, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listRoleAssignmentsMongo(ctx, db, "role", role, "assigned_at")
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// DeleteByUser removes every role assigned to a user
+func (r *roleAssignmentRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteRoleAssignmentsByUserMongo(ctx, db, userID)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// ListExpired lists the expired assignments
+func (r *roleAssignmentRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RoleAssignment, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listExpiredRoleAssignmentsMongo(ctx, db, now, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// DeleteExpired removes an expired assignment
+func (r *roleAssignmentRepository) DeleteExpired(ctx context.Context, assignment *entity.RoleAssignment) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteExpiredRoleAssignmentMongo(ctx, db, assignment)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
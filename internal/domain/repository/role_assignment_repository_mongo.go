@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// assignRoleMongo upserts a role assignment in MongoDB, an existing assignment is left untouched
+func (r *roleAssignmentRepository) assignRoleMongo(ctx context.Context, client *mongo.Client, assignment *entity.RoleAssignment) (bool, error) {
+	collection := client.Database("user_service").Collection("user_roles")
+
+	filter := bson.M{"user_id": assignment.UserID, "role": assignment.Role}
+	update := bson.M{"$setOnInsert": assignment}
+	result, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error().Err(err).Str("user_id", assignment.UserID.String()).Str("role", assignment.Role).Msg("Failed to assign role in MongoDB")
+		return false, fmt.Errorf("failed to assign role: %w", err)
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// unassignRoleMongo deletes a role assignment from MongoDB
+func (r *roleAssignmentRepository) unassignRoleMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID, role string) (bool, error) {
+	collection := client.Database("user_service").Collection("user_roles")
+
+	result, err := collection.DeleteOne(ctx, bson.M{"user_id": userID, "role": role})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("role", role).Msg("Failed to unassign role in MongoDB")
+		return false, fmt.Errorf("failed to unassign role: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// listRoleAssignmentsMongo lists the role assignments matching a field from MongoDB, sorted by another
+func (r *roleAssignmentRepository) listRoleAssignmentsMongo(ctx context.Context, client *mongo.Client, field string, value any, sortField string) ([]*entity.RoleAssignment, error) {
+	collection := client.Database("user_service").Collection("user_roles")
+
+	opts := options.Find().SetSort(bson.D{{Key: sortField, Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{field: value}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list role assignments from MongoDB")
+		return nil, fmt.Errorf("failed to list role assignments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	assignments := []*entity.RoleAssignment{}
+	if err := cursor.All(ctx, &assignments); err != nil {
+		log.Error().Err(err).Msg("Failed to decode role assignments from MongoDB")
+		return nil, fmt.Errorf("failed to decode role assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// deleteRoleAssignmentsByUserMongo deletes the role assignments of a user from MongoDB
+func (r *roleAssignmentRepository) deleteRoleAssignmentsByUserMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) error {
+	collection := client.Database("user_service").Collection("user_roles")
+
+	if _, err := collection.DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete role assignments from MongoDB")
+		return fmt.Errorf("failed to delete role assignments: %w", err)
+	}
+	return nil
+}
+
+// listExpiredRoleAssignmentsMongo lists the role assignments expired at now from MongoDB, earliest expiration first
+func (r *roleAssignmentRepository) listExpiredRoleAssignmentsMongo(ctx context.Context, client *mongo.Client, now time.Time, limit int) ([]*entity.RoleAssignment, error) {
+	collection := client.Database("user_service").Collection("user_roles")
+
+	opts := options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, bson.M{"expires_at": bson.M{"$lte": now}}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list expired role assignments from MongoDB")
+		return nil, fmt.Errorf("failed to list expired role assignments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	assignments := []*entity.RoleAssignment{}
+	if err := cursor.All(ctx, &assignments); err != nil {
+		log.Error().Err(err).Msg("Failed to decode expired role assignments from MongoDB")
+		return nil, fmt.Errorf("failed to decode expired role assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// deleteExpiredRoleAssignmentMongo deletes a role assignment from MongoDB if it still expires at the same time
+func (r *roleAssignmentRepository) deleteExpiredRoleAssignmentMongo(ctx context.Context, client *mongo.Client, assignment *entity.RoleAssignment) (bool, error) {
+	collection := client.Database("user_service").Collection("user_roles")
+
+	filter := bson.M{"user_id": assignment.UserID, "role": assignment.Role, "expires_at": assignment.ExpiresAt}
+	result, err := collection.DeleteOne(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", assignment.UserID.String()).Str("role", assignment.Role).Msg("Failed to delete expired role assignment in MongoDB")
+		return false, fmt.Errorf("failed to delete expired role assignment: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
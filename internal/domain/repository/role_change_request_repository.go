@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RoleChangeRequestRepository defines the interface for the privileged role changes awaiting approval
+type RoleChangeRequestRepository interface {
+	// Create stores a new request
+	Create(ctx context.Context, request *entity.RoleChangeRequest) error
+
+	// GetByID returns a request, nil if unknown
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.RoleChangeRequest, error)
+
+	// List lists the requests with a status, all of them when the status is empty, newest first
+	List(ctx context.Context, status string) ([]*entity.RoleChangeRequest, error)
+
+	// Transition replaces a request if its stored status is still from, and reports whether it was replaced, so
+	// concurrent decisions on a request cannot both succeed
+	Transition(ctx context.Context, request *entity.RoleChangeRequest, from string) (bool, error)
+}
+
+type roleChangeRequestRepository struct {
+	db db.Database
+}
+
+// NewRoleChangeRequestRepository creates a new RoleChangeRequestRepository
+func NewRoleChangeRequestRepository(db db.Database) RoleChangeRequestRepository {
+	return &roleChangeRequestRepository{
+		db: db,
+	}
+}
+
+// Create stores a new request
+func (r *roleChangeRequestRepository) Create(ctx context.Context, request *entity.RoleChangeRequest) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createRoleChangeRequestMongo(ctx, db, request)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByID returns a request, nil if unknown
+func (r *roleChangeRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.RoleChangeRequest, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getRoleChangeRequestMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists the requests with a status, newest first
+func (r *roleChangeRequestRepository) List(ctx context.Context, status string) ([]*entity.RoleChangeRequest, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listRoleChangeRequestsMongo(ctx, db, status)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Transition replaces a request if its stored status is still from
+func (r *roleChangeRequestRepository) Transition(ctx context.Context, request *entity.RoleChangeRequest, from string) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.transitionRoleChangeRequestMongo(ctx, db, request, from)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
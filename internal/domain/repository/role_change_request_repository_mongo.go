@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createRoleChangeRequestMongo inserts a request in MongoDB
+func (r *roleChangeRequestRepository) createRoleChangeRequestMongo(ctx context.Context, client *mongo.Client, request *entity.RoleChangeRequest) error {
+	collection := client.Database("user_service").Collection("role_change_requests")
+
+	if _, err := collection.InsertOne(ctx, request); err != nil {
+		log.Error().Err(err).Str("user_id", request.UserID.String()).Msg("Failed to create role change request in MongoDB")
+		return fmt.Errorf("failed to create role change request: %w", err)
+	}
+	return nil
+}
+
+// getRoleChangeRequestMongo gets a request from MongoDB
+func (r *roleChangeRequestRepository) getRoleChangeRequestMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.RoleChangeRequest, error) {
+	collection := client.Database("user_service").Collection("role_change_requests")
+
+	var request entity.RoleChangeRequest
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&request)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Request not found
+		}
+		log.Error().Err(err).Str("request_id", id.String()).Msg("Failed to get role change request from MongoDB")
+		return nil, fmt.Errorf("failed to get role change request: %w", err)
+	}
+
+	return &request, nil
+}
+
+// listRoleChangeRequestsMongo lists the requests with a status from MongoDB, newest first
+func (r *roleChangeRequestRepository) listRoleChangeRequestsMongo(ctx context.Context, client *mongo.Client, status string) ([]*entity.RoleChangeRequest, error) {
+	collection := client.Database("user_service").Collection("role_change_requests")
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.Error().Err(err).Str("status", status).Msg("Failed to list role change requests from MongoDB")
+		return nil, fmt.Errorf("failed to list role change requests: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	requests := []*entity.RoleChangeRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		log.Error().Err(err).Msg("Failed to decode role change requests from MongoDB")
+		return nil, fmt.Errorf("failed to decode role change requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// transitionRoleChangeRequestMongo replaces a request in MongoDB if its stored status is still from
+func (r *roleChangeRequestRepository) transitionRoleChangeRequestMongo(ctx context.Context, client *mongo.Client, request *entity.RoleChangeRequest, from string) (bool, error) {
+	collection := client.Database("user_service").Collection("role_change_requests")
+
+	result, err := collection.ReplaceOne(ctx, bson.M{"_id": request.ID, "status": from}, request)
+	if err != nil {
+		log.Error().Err(err).Str("request_id", request.ID.String()).Msg("Failed to update role change request in MongoDB")
+		return false, fmt.Errorf("failed to update role change request: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RoleRepository defines the interface for custom role repository operations
+type RoleRepository interface {
+	// Create a new role
+	Create(ctx context.Context, role *entity.Role) error
+
+	// Get a role by name, returns nil if the role does not exist
+	GetByName(ctx context.Context, name string) (*entity.Role, error)
+
+	// List all roles ordered by name
+	List(ctx context.Context) ([]*entity.Role, error)
+
+	// Update a role
+	Update(ctx context.Context, role *entity.Role) error
+
+	// Delete a role
+	Delete(ctx context.Context, name string) error
+}
+
+type roleRepository struct {
+	db db.Database
+}
+
+// NewRoleRepository creates a new RoleRepository
+func NewRoleRepository(db db.Database) RoleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
+
+// Create creates a new role
+func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createRoleMongo(ctx, db, role)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByName retrieves a role by name
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getRoleByNameMongo(ctx, db, name)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists all roles
+func (r *roleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listRolesMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update updates a role
+func (r *roleRepository) Update(ctx context.Context, role *entity.Role) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateRoleMongo(ctx, db, role)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a role
+func (r *roleRepository) Delete(ctx context.Context, name string) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteRoleMongo(ctx, db, name)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
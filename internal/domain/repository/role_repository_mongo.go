@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createRoleMongo creates a role in MongoDB
+func (r *roleRepository) createRoleMongo(ctx context.Context, client *mongo.Client, role *entity.Role) error {
+	collection := client.Database("user_service").Collection("roles")
+	_, err := collection.InsertOne(ctx, role)
+	if err != nil {
+		log.Error().Err(err).Str("role", role.Name).Msg("Failed to create role in MongoDB")
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	return nil
+}
+
+// getRoleByNameMongo gets a role by name from MongoDB
+func (r *roleRepository) getRoleByNameMongo(ctx context.Context, client *mongo.Client, name string) (*entity.Role, error) {
+	collection := client.Database("user_service").Collection("roles")
+
+	var role entity.Role
+	err := collection.FindOne(ctx, bson.M{"_id": name}).Decode(&role)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Role not found
+		}
+		log.Error().Err(err).Str("role", name).Msg("Failed to get role from MongoDB")
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return &role, nil
+}
+
+// listRolesMongo lists all roles from MongoDB
+func (r *roleRepository) listRolesMongo(ctx context.Context, client *mongo.Client) ([]*entity.Role, error) {
+	collection := client.Database("user_service").Collection("roles")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list roles from MongoDB")
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var roles []*entity.Role
+	if err := cursor.All(ctx, &roles); err != nil {
+		log.Error().Err(err).Msg("Failed to decode roles from MongoDB")
+		return nil, fmt.Errorf("failed to decode roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// updateRoleMongo updates a role in MongoDB
+func (r *roleRepository) updateRoleMongo(ctx context.Context, client *mongo.Client, role *entity.Role) error {
+	collection := client.Database("user_service").Collection("roles")
+
+	update := bson.M{
+		"$set": bson.M{
+			"description": role.Description,
+			"permissions": role.Permissions,
+			"parents":     role.Parents,
+			"groups":      role.Groups,
+			"updated_at":  role.UpdatedAt,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": role.Name}, update)
+	if err != nil {
+		log.Error().Err(err).Str("role", role.Name).Msg("Failed to update role in MongoDB")
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return nil
+}
+
+// deleteRoleMongo deletes a role from MongoDB
+func (r *roleRepository) deleteRoleMongo(ctx context.Context, client *mongo.Client, name string) error {
+	collection := client.Database("user_service").Collection("roles")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		log.Error().Err(err).Str("role", name).Msg("Failed to delete role from MongoDB")
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SCIMGroupRepository defines the interface for the groups provisioned by the identity provider
+type SCIMGroupRepository interface {
+	// Create stores a new group
+	Create(ctx context.Context, group *entity.SCIMGroup) error
+
+	// GetByID returns a group, nil if unknown
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error)
+
+	// List returns the groups ordered by display name
+	List(ctx context.Context) ([]*entity.SCIMGroup, error)
+
+	// ListByMember returns the groups a user is a member of
+	ListByMember(ctx context.Context, userID uuid.UUID) ([]*entity.SCIMGroup, error)
+
+	// Update replaces a group
+	Update(ctx context.Context, group *entity.SCIMGroup) error
+
+	// Delete deletes a group
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type scimGroupRepository struct {
+	db db.Database
+}
+
+// NewSCIMGroupRepository creates a new SCIMGroupRepository
+func NewSCIMGroupRepository(db db.Database) SCIMGroupRepository {
+	return &scimGroupRepository{
+		db: db,
+	}
+}
+
+// Create stores a new group
+func (r *scimGroupRepository) Create(ctx context.Context, group *entity.SCIMGroup) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createSCIMGroupMongo(ctx, db, group)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByID retrieves a group by ID
+func (r *scimGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getSCIMGroupMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List retrieves the groups
+func (r *scimGroupRepository) List(ctx context.Context) ([]*entity.SCIMGroup, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listSCIMGroupsMongo(ctx, db, nil)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListByMember retrieves the groups of a user
+func (r *scimGroupRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*entity.SCIMGroup, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listSCIMGroupsMongo(ctx, db, &userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update replaces a group
+func (r *scimGroupRepository) Update(ctx context.Context, group *entity.SCIMGroup) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateSCIMGroupMongo(ctx, db, group)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a group
+func (r *scimGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteSCIMGroupMongo(ctx, db, id)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
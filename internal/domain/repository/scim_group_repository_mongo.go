@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createSCIMGroupMongo creates a group in MongoDB
+func (r *scimGroupRepository) createSCIMGroupMongo(ctx context.Context, client *mongo.Client, group *entity.SCIMGroup) error {
+	collection := client.Database("user_service").Collection("scim_groups")
+
+	if _, err := collection.InsertOne(ctx, group); err != nil {
+		log.Error().Err(err).Str("scim_group_id", group.ID.String()).Msg("Failed to create SCIM group in MongoDB")
+		return fmt.Errorf("failed to create SCIM group: %w", err)
+	}
+	return nil
+}
+
+// getSCIMGroupMongo gets a group by ID from MongoDB
+func (r *scimGroupRepository) getSCIMGroupMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.SCIMGroup, error) {
+	collection := client.Database("user_service").Collection("scim_groups")
+
+	var group entity.SCIMGroup
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&group); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		log.Error().Err(err).Str("scim_group_id", id.String()).Msg("Failed to get SCIM group from MongoDB")
+		return nil, fmt.Errorf("failed to get SCIM group: %w", err)
+	}
+	return &group, nil
+}
+
+// listSCIMGroupsMongo lists the groups from MongoDB ordered by display name, those of a member when given
+func (r *scimGroupRepository) listSCIMGroupsMongo(ctx context.Context, client *mongo.Client, memberID *uuid.UUID) ([]*entity.SCIMGroup, error) {
+	collection := client.Database("user_service").Collection("scim_groups")
+
+	filter := bson.M{}
+	if memberID != nil {
+		filter["members"] = *memberID
+	}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "display_name", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list SCIM groups from MongoDB")
+		return nil, fmt.Errorf("failed to list SCIM groups: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := []*entity.SCIMGroup{}
+	if err := cursor.All(ctx, &groups); err != nil {
+		log.Error().Err(err).Msg("Failed to decode SCIM groups from MongoDB")
+		return nil, fmt.Errorf("failed to decode SCIM groups: %w", err)
+	}
+	return groups, nil
+}
+
+// updateSCIMGroupMongo replaces a group in MongoDB
+func (r *scimGroupRepository) updateSCIMGroupMongo(ctx context.Context, client *mongo.Client, group *entity.SCIMGroup) error {
+	collection := client.Database("user_service").Collection("scim_groups")
+
+	if _, err := collection.ReplaceOne(ctx, bson.M{"_id": group.ID}, group); err != nil {
+		log.Error().Err(err).Str("scim_group_id", group.ID.String()).Msg("Failed to update SCIM group in MongoDB")
+		return fmt.Errorf("failed to update SCIM group: %w", err)
+	}
+	return nil
+}
+
+// deleteSCIMGroupMongo deletes a group from MongoDB
+func (r *scimGroupRepository) deleteSCIMGroupMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) error {
+	collection := client.Database("user_service").Collection("scim_groups")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Str("scim_group_id", id.String()).Msg("Failed to delete SCIM group from MongoDB")
+		return fmt.Errorf("failed to delete SCIM group: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SecurityEventRepository defines the interface for the buffer of security events awaiting delivery to the SIEM
+type SecurityEventRepository interface {
+	// Create queues a security event
+	Create(ctx context.Context, event *entity.SecurityEvent) error
+
+	// ListPending lists up to limit queued security events, oldest first
+	ListPending(ctx context.Context, limit int) ([]*entity.SecurityEvent, error)
+
+	// Delete removes delivered security events from the queue
+	Delete(ctx context.Context, ids []uuid.UUID) error
+}
+
+type securityEventRepository struct {
+	db db.Database
+}
+
+// NewSecurityEventRepository creates a new SecurityEventRepository
+func NewSecurityEventRepository(db db.Database) SecurityEventRepository {
+	return &securityEventRepository{
+		db: db,
+	}
+}
+
+// Create queues a security event
+func (r *securityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createSecurityEventMongo(ctx, db, event)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// ListPending lists queued security events
+func (r *securityEventRepository) ListPending(ctx context.Context, limit int) ([]*entity.SecurityEvent, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listPendingSecurityEventsMongo(ctx, db, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Delete removes delivered security events
+func (r *securityEventRepository) Delete(ctx context.Context, ids []uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteSecurityEventsMongo(ctx, db, ids)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
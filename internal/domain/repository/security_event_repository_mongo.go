@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createSecurityEventMongo queues a security event in MongoDB
+func (r *securityEventRepository) createSecurityEventMongo(ctx context.Context, client *mongo.Client, event *entity.SecurityEvent) error {
+	collection := client.Database("user_service").Collection("security_events")
+
+	_, err := collection.InsertOne(ctx, event)
+	if err != nil {
+		log.Error().Err(err).Str("event_id", event.ID.String()).Msg("Failed to create security event in MongoDB")
+		return fmt.Errorf("failed to create security event: %w", err)
+	}
+	return nil
+}
+
+// listPendingSecurityEventsMongo lists queued security events from MongoDB, oldest first
+func (r *securityEventRepository) listPendingSecurityEventsMongo(ctx context.Context, client *mongo.Client, limit int) ([]*entity.SecurityEvent, error) {
+	collection := client.Database("user_service").Collection("security_events")
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list security events from MongoDB")
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	events := []*entity.SecurityEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		log.Error().Err(err).Msg("Failed to decode security events from MongoDB")
+		return nil, fmt.Errorf("failed to decode security events: %w", err)
+	}
+
+	return events, nil
+}
+
+// deleteSecurityEventsMongo deletes delivered security events from MongoDB
+func (r *securityEventRepository) deleteSecurityEventsMongo(ctx context.Context, client *mongo.Client, ids []uuid.UUID) error {
+	collection := client.Database("user_service").Collection("security_events")
+
+	_, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		log.Error().Err(err).Int("count", len(ids)).Msg("Failed to delete security events from MongoDB")
+		return fmt.Errorf("failed to delete security events: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	selfTestProbePrefix = "selftest:"
+
+	// Probes left behind by an interrupted self-test expire on their own
+	selfTestProbeExpiration = time.Minute
+)
+
+type selfTestCacheRepository struct {
+	cache cache.Cache
+}
+
+// NewSelfTestCacheRepository creates a new SelfTestRepository storing the probes in the cache
+func NewSelfTestCacheRepository(cache cache.Cache) SelfTestRepository {
+	return &selfTestCacheRepository{
+		cache: cache,
+	}
+}
+
+// Write stores a probe
+func (r *selfTestCacheRepository) Write(ctx context.Context, probe *entity.SelfTestProbe) error {
+	data, err := json.Marshal(probe)
+	if err != nil {
+		return fmt.Errorf("failed to marshal self-test probe: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, selfTestProbePrefix+probe.ID.String(), data, selfTestProbeExpiration); err != nil {
+		log.Error().Err(err).Str("probe_id", probe.ID.String()).Msg("Failed to write self-test probe to cache")
+		return fmt.Errorf("failed to write self-test probe: %w", err)
+	}
+	return nil
+}
+
+// Read gets a probe by ID, returns nil if it is not found
+func (r *selfTestCacheRepository) Read(ctx context.Context, id uuid.UUID) (*entity.SelfTestProbe, error) {
+	data, err := r.cache.Get(ctx, selfTestProbePrefix+id.String())
+	if err != nil {
+		log.Error().Err(err).Str("probe_id", id.String()).Msg("Failed to read self-test probe from cache")
+		return nil, fmt.Errorf("failed to read self-test probe: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var probe entity.SelfTestProbe
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal self-test probe: %w", err)
+	}
+	return &probe, nil
+}
+
+// Delete deletes a probe
+func (r *selfTestCacheRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.cache.Delete(ctx, selfTestProbePrefix+id.String()); err != nil {
+		log.Error().Err(err).Str("probe_id", id.String()).Msg("Failed to delete self-test probe from cache")
+		return fmt.Errorf("failed to delete self-test probe: %w", err)
+	}
+	return nil
+}
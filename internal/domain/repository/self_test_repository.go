@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SelfTestRepository defines the interface for the probes written and read back by the self-test
+type SelfTestRepository interface {
+	// Write stores a probe
+	Write(ctx context.Context, probe *entity.SelfTestProbe) error
+
+	// Read gets a probe by ID, returns nil if it is not found
+	Read(ctx context.Context, id uuid.UUID) (*entity.SelfTestProbe, error)
+
+	// Delete deletes a probe
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type selfTestRepository struct {
+	db db.Database
+}
+
+// NewSelfTestRepository creates a new SelfTestRepository storing the probes in the database
+func NewSelfTestRepository(db db.Database) SelfTestRepository {
+	return &selfTestRepository{
+		db: db,
+	}
+}
+
+// Write stores a probe
+func (r *selfTestRepository) Write(ctx context.Context, probe *entity.SelfTestProbe) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.writeSelfTestProbeMongo(ctx, db, probe)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Read gets a probe by ID
+func (r *selfTestRepository) Read(ctx context.Context, id uuid.UUID) (*entity.SelfTestProbe, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.readSelfTestProbeMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a probe
+func (r *selfTestRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteSelfTestProbeMongo(ctx, db, id)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
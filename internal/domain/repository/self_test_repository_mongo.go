@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// writeSelfTestProbeMongo stores a probe in MongoDB
+func (r *selfTestRepository) writeSelfTestProbeMongo(ctx context.Context, client *mongo.Client, probe *entity.SelfTestProbe) error {
+	collection := client.Database("user_service").Collection("self_test_probes")
+
+	if _, err := collection.InsertOne(ctx, probe); err != nil {
+		log.Error().Err(err).Str("probe_id", probe.ID.String()).Msg("Failed to write self-test probe to MongoDB")
+		return fmt.Errorf("failed to write self-test probe: %w", err)
+	}
+	return nil
+}
+
+// readSelfTestProbeMongo gets a probe from MongoDB
+func (r *selfTestRepository) readSelfTestProbeMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.SelfTestProbe, error) {
+	collection := client.Database("user_service").Collection("self_test_probes")
+
+	var probe entity.SelfTestProbe
+	if err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&probe); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		log.Error().Err(err).Str("probe_id", id.String()).Msg("Failed to read self-test probe from MongoDB")
+		return nil, fmt.Errorf("failed to read self-test probe: %w", err)
+	}
+	return &probe, nil
+}
+
+// deleteSelfTestProbeMongo deletes a probe from MongoDB
+func (r *selfTestRepository) deleteSelfTestProbeMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) error {
+	collection := client.Database("user_service").Collection("self_test_probes")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Str("probe_id", id.String()).Msg("Failed to delete self-test probe from MongoDB")
+		return fmt.Errorf("failed to delete self-test probe: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ServiceAccountRepository defines the interface for the service accounts of backend services
+type ServiceAccountRepository interface {
+	// Create stores a new service account
+	Create(ctx context.Context, account *entity.ServiceAccount) error
+
+	// GetByID returns a service account, nil if unknown
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ServiceAccount, error)
+
+	// GetByClientID returns the service account of a client ID, nil if unknown
+	GetByClientID(ctx context.Context, clientID string) (*entity.ServiceAccount, error)
+
+	// List returns the service accounts, oldest first
+	List(ctx context.Context) ([]*entity.ServiceAccount, error)
+
+	// Update replaces a service account
+	Update(ctx context.Context, account *entity.ServiceAccount) error
+
+	// Delete deletes a service account
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type serviceAccountRepository struct {
+	db db.Database
+}
+
+// NewServiceAccountRepository creates a new ServiceAccountRepository
+func NewServiceAccountRepository(db db.Database) ServiceAccountRepository {
+	return &serviceAccountRepository{
+		db: db,
+	}
+}
+
+// Create stores a new service account
+func (r *serviceAccountRepository) Create(ctx context.Context, account *entity.ServiceAccount) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createServiceAccountMongo(ctx, db, account)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByID retrieves a service account by ID
+func (r *serviceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ServiceAccount, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getServiceAccountMongo(ctx, db, "_id", id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// GetByClientID retrieves a service account by client ID
+func (r *serviceAccountRepository) GetByClientID(ctx context.Context, clientID string) (*entity.ServiceAccount, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getServiceAccountMongo(ctx, db, "client_id", clientID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List retrieves the service accounts
+func (r *serviceAccountRepository) List(ctx context.Context) ([]*entity.ServiceAccount, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listServiceAccountsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update replaces a service account
+func (r *serviceAccountRepository) Update(ctx context.Context, account *entity.ServiceAccount) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateServiceAccountMongo(ctx, db, account)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a service account
+func (r *serviceAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteServiceAccountMongo(ctx, db, id)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
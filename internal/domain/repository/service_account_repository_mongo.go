@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createServiceAccountMongo inserts a service account in MongoDB
+func (r *serviceAccountRepository) createServiceAccountMongo(ctx context.Context, client *mongo.Client, account *entity.ServiceAccount) error {
+	collection := client.Database("user_service").Collection("service_accounts")
+
+	if _, err := collection.InsertOne(ctx, account); err != nil {
+		log.Error().Err(err).Str("service_account_id", account.ID.String()).Msg("Failed to create service account in MongoDB")
+		return fmt.Errorf("failed to create service account: %w", err)
+	}
+	return nil
+}
+
+// getServiceAccountMongo gets the service account matching a field from MongoDB
+func (r *serviceAccountRepository) getServiceAccountMongo(ctx context.Context, client *mongo.Client, field string, value any) (*entity.ServiceAccount, error) {
+	collection := client.Database("user_service").Collection("service_accounts")
+
+	var account entity.ServiceAccount
+	err := collection.FindOne(ctx, bson.M{field: value}).Decode(&account)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Service account not found
+		}
+		log.Error().Err(err).Msg("Failed to get service account from MongoDB")
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// listServiceAccountsMongo lists the service accounts from MongoDB, oldest first
+func (r *serviceAccountRepository) listServiceAccountsMongo(ctx context.Context, client *mongo.Client) ([]*entity.ServiceAccount, error) {
+	collection := client.Database("user_service").Collection("service_accounts")
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list service accounts from MongoDB")
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	accounts := []*entity.ServiceAccount{}
+	if err := cursor.All(ctx, &accounts); err != nil {
+		log.Error().Err(err).Msg("Failed to decode service accounts from MongoDB")
+		return nil, fmt.Errorf("failed to decode service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// updateServiceAccountMongo replaces a service account in MongoDB
+func (r *serviceAccountRepository) updateServiceAccountMongo(ctx context.Context, client *mongo.Client, account *entity.ServiceAccount) error {
+	collection := client.Database("user_service").Collection("service_accounts")
+
+	if _, err := collection.ReplaceOne(ctx, bson.M{"_id": account.ID}, account); err != nil {
+		log.Error().Err(err).Str("service_account_id", account.ID.String()).Msg("Failed to update service account in MongoDB")
+		return fmt.Errorf("failed to update service account: %w", err)
+	}
+	return nil
+}
+
+// deleteServiceAccountMongo deletes a service account from MongoDB
+func (r *serviceAccountRepository) deleteServiceAccountMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) error {
+	collection := client.Database("user_service").Collection("service_accounts")
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Str("service_account_id", id.String()).Msg("Failed to delete service account from MongoDB")
+		return fmt.Errorf("failed to delete service account: %w", err)
+	}
+	return nil
+}
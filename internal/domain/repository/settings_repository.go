@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/rs/zerolog/log"
+)
+
+const settingsPrefix = "settings:"
+
+// SettingsRepository defines the interface for runtime settings shared by all instances
+type SettingsRepository interface {
+	// GetSetting retrieves a setting, reporting whether it has been set
+	GetSetting(ctx context.Context, key string) (string, bool, error)
+
+	// SetSetting stores a setting without expiration
+	SetSetting(ctx context.Context, key, value string) error
+}
+
+type settingsRepository struct {
+	cache cache.Cache
+}
+
+// NewSettingsRepository creates a new settings repository
+func NewSettingsRepository(cache cache.Cache) SettingsRepository {
+	return &settingsRepository{
+		cache: cache,
+	}
+}
+
+// GetSetting retrieves a setting, reporting whether it has been set
+func (r *settingsRepository) GetSetting(ctx context.Context, key string) (string, bool, error) {
+	data, err := r.cache.Get(ctx, settingsPrefix+key)
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("Failed to get setting")
+		return "", false, fmt.Errorf("failed to get setting: %w", err)
+	}
+	if data == nil {
+		return "", false, nil
+	}
+
+	return string(data), true, nil
+}
+
+// SetSetting stores a setting without expiration
+func (r *settingsRepository) SetSetting(ctx context.Context, key, value string) error {
+	if err := r.cache.Set(ctx, settingsPrefix+key, []byte(value), 0); err != nil {
+		log.Error().Err(err).Str("key", key).Msg("Failed to set setting")
+		return fmt.Errorf("failed to set setting: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SigningKeyRepository defines the interface for token signing key repository operations
+type SigningKeyRepository interface {
+	// Create stores a new signing key
+	Create(ctx context.Context, key *entity.SigningKey) error
+
+	// List returns all signing keys, oldest first
+	List(ctx context.Context) ([]*entity.SigningKey, error)
+}
+
+type signingKeyRepository struct {
+	db db.Database
+}
+
+// NewSigningKeyRepository creates a new SigningKeyRepository
+func NewSigningKeyRepository(db db.Database) SigningKeyRepository {
+	return &signingKeyRepository{
+		db: db,
+	}
+}
+
+// Create stores a new signing key
+func (r *signingKeyRepository) Create(ctx context.Context, key *entity.SigningKey) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createSigningKeyMongo(ctx, db, key)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// List returns all signing keys, oldest first
+func (r *signingKeyRepository) List(ctx context.Context) ([]*entity.SigningKey, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listSigningKeysMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createSigningKeyMongo stores a signing key in MongoDB
+func (r *signingKeyRepository) createSigningKeyMongo(ctx context.Context, client *mongo.Client, key *entity.SigningKey) error {
+	collection := client.Database("user_service").Collection("signing_keys")
+	_, err := collection.InsertOne(ctx, key)
+	if err != nil {
+		log.Error().Err(err).Str("kid", key.ID).Msg("Failed to create signing key in MongoDB")
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+	return nil
+}
+
+// listSigningKeysMongo lists all signing keys from MongoDB, oldest first
+func (r *signingKeyRepository) listSigningKeysMongo(ctx context.Context, client *mongo.Client) ([]*entity.SigningKey, error) {
+	collection := client.Database("user_service").Collection("signing_keys")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list signing keys from MongoDB")
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*entity.SigningKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		log.Error().Err(err).Msg("Failed to decode signing keys from MongoDB")
+		return nil, fmt.Errorf("failed to decode signing keys: %w", err)
+	}
+
+	return keys, nil
+}
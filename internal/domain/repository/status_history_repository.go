@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StatusHistoryRepository defines the interface for the status history of user accounts
+type StatusHistoryRepository interface {
+	// Create records a status change
+	Create(ctx context.Context, change *entity.StatusChange) error
+
+	// ListByUser lists the status changes of a user, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.StatusChange, error)
+}
+
+type statusHistoryRepository struct {
+	db db.Database
+}
+
+// NewStatusHistoryRepository creates a new StatusHistoryRepository
+func NewStatusHistoryRepository(db db.Database) StatusHistoryRepository {
+	return &statusHistoryRepository{
+		db: db,
+	}
+}
+
+// Create records a status change
+func (r *statusHistoryRepository) Create(ctx context.Context, change *entity.StatusChange) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createStatusChangeMongo(ctx, db, change)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// ListByUser lists the status changes of a user
+func (r *statusHistoryRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.StatusChange, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listStatusChangesMongo(ctx, db, userID)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createStatusChangeMongo inserts a status change in MongoDB
+func (r *statusHistoryRepository) createStatusChangeMongo(ctx context.Context, client *mongo.Client, change *entity.StatusChange) error {
+	collection := client.Database("user_service").Collection("user_status_history")
+
+	_, err := collection.InsertOne(ctx, change)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", change.UserID.String()).Msg("Failed to create status change in MongoDB")
+		return fmt.Errorf("failed to create status change: %w", err)
+	}
+	return nil
+}
+
+// listStatusChangesMongo lists the status changes of a user from MongoDB
+func (r *statusHistoryRepository) listStatusChangesMongo(ctx context.Context, client *mongo.Client, userID uuid.UUID) ([]*entity.StatusChange, error) {
+	collection := client.Database("user_service").Collection("user_status_history")
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list status changes from MongoDB")
+		return nil, fmt.Errorf("failed to list status changes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var changes []*entity.StatusChange
+	if err := cursor.All(ctx, &changes); err != nil {
+		log.Error().Err(err).Msg("Failed to decode status changes from MongoDB")
+		return nil, fmt.Errorf("failed to decode status changes: %w", err)
+	}
+
+	return changes, nil
+}
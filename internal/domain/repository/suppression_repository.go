@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SuppressionRepository defines the interface for email suppression list operations
+type SuppressionRepository interface {
+	// Add suppresses an address, replacing any existing suppression of it
+	Add(ctx context.Context, suppression *entity.EmailSuppression) error
+
+	// Get the suppression of a normalized address, returns nil if the address is not suppressed
+	Get(ctx context.Context, email string) (*entity.EmailSuppression, error)
+
+	// List all suppressions, newest first
+	List(ctx context.Context) ([]*entity.EmailSuppression, error)
+
+	// Remove the suppression of a normalized address
+	Remove(ctx context.Context, email string) error
+}
+
+type suppressionRepository struct {
+	db db.Database
+}
+
+// NewSuppressionRepository creates a new SuppressionRepository
+func NewSuppressionRepository(db db.Database) SuppressionRepository {
+	return &suppressionRepository{
+		db: db,
+	}
+}
+
+// Add suppresses an address
+func (r *suppressionRepository) Add(ctx context.Context, suppression *entity.EmailSuppression) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.addSuppressionMongo(ctx, db, suppression)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Get retrieves the suppression of an address
+func (r *suppressionRepository) Get(ctx context.Context, email string) (*entity.EmailSuppression, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getSuppressionMongo(ctx, db, email)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists all suppressions
+func (r *suppressionRepository) List(ctx context.Context) ([]*entity.EmailSuppression, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listSuppressionsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Remove removes the suppression of an address
+func (r *suppressionRepository) Remove(ctx context.Context, email string) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.removeSuppressionMongo(ctx, db, email)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// addSuppressionMongo upserts a suppression in MongoDB
+func (r *suppressionRepository) addSuppressionMongo(ctx context.Context, client *mongo.Client, suppression *entity.EmailSuppression) error {
+	collection := client.Database("user_service").Collection("email_suppressions")
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": suppression.Email}, suppression, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Error().Err(err).Str("reason", suppression.Reason).Msg("Failed to add email suppression in MongoDB")
+		return fmt.Errorf("failed to add email suppression: %w", err)
+	}
+	return nil
+}
+
+// getSuppressionMongo gets the suppression of an address from MongoDB
+func (r *suppressionRepository) getSuppressionMongo(ctx context.Context, client *mongo.Client, email string) (*entity.EmailSuppression, error) {
+	collection := client.Database("user_service").Collection("email_suppressions")
+
+	var suppression entity.EmailSuppression
+	err := collection.FindOne(ctx, bson.M{"_id": email}).Decode(&suppression)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Address not suppressed
+		}
+		log.Error().Err(err).Msg("Failed to get email suppression from MongoDB")
+		return nil, fmt.Errorf("failed to get email suppression: %w", err)
+	}
+
+	return &suppression, nil
+}
+
+// listSuppressionsMongo lists all suppressions from MongoDB
+func (r *suppressionRepository) listSuppressionsMongo(ctx context.Context, client *mongo.Client) ([]*entity.EmailSuppression, error) {
+	collection := client.Database("user_service").Collection("email_suppressions")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list email suppressions from MongoDB")
+		return nil, fmt.Errorf("failed to list email suppressions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var suppressions []*entity.EmailSuppression
+	if err := cursor.All(ctx, &suppressions); err != nil {
+		log.Error().Err(err).Msg("Failed to decode email suppressions from MongoDB")
+		return nil, fmt.Errorf("failed to decode email suppressions: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+// removeSuppressionMongo removes the suppression of an address from MongoDB
+func (r *suppressionRepository) removeSuppressionMongo(ctx context.Context, client *mongo.Client, email string) error {
+	collection := client.Database("user_service").Collection("email_suppressions")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": email})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to remove email suppression from MongoDB")
+		return fmt.Errorf("failed to remove email suppression: %w", err)
+	}
+
+	return nil
+}
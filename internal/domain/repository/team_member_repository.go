@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TeamMemberRepository defines the interface for the memberships of users in teams
+type TeamMemberRepository interface {
+	// Add adds a user to a team, returns false if the user was already a member
+	Add(ctx context.Context, member *entity.TeamMember) (bool, error)
+
+	// Remove removes a user from a team, returns false if the user was not a member
+	Remove(ctx context.Context, team string, userID uuid.UUID) (bool, error)
+
+	// ListByTeam lists the members of a team, oldest membership first
+	ListByTeam(ctx context.Context, team string) ([]*entity.TeamMember, error)
+
+	// ListByUser lists the teams of a user, ordered by team
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.TeamMember, error)
+
+	// DeleteByTeam removes every member of a team
+	DeleteByTeam(ctx context.Context, team string) error
+
+	// DeleteByUser removes a user from every team
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type teamMemberRepository struct {
+	db db.Database
+}
+
+// NewTeamMemberRepository creates a new TeamMemberRepository
+func NewTeamMemberRepository(db db.Database) TeamMemberRepository {
+	return &teamMemberRepository{
+		db: db,
+	}
+}
+
+// Add adds a user to a team
+func (r *teamMemberRepository) Add(ctx context.Context, member *entity.TeamMember) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.addTeamMemberMongo(ctx, db, member)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// Remove removes a user from a team
+func (r *teamMemberRepository) Remove(ctx context.Context, team string, userID uuid.UUID) (bool, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.removeTeamMemberMongo(ctx, db, team, userID)
+	default:
+		return false, errors.New("unsupported database type")
+	}
+}
+
+// ListByTeam lists the members of a team
+func (r *teamMemberRepository) ListByTeam(ctx context.Context, team string) ([]*entity.TeamMember, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listTeamMembersMongo(ctx, db, "team", team, "added_at")
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListByUser lists the teams of a user
+func (r *teamMemberRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.TeamMember, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listTeamMembersMongo(ctx, db, "user_id", userID, "team")
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// DeleteByTeam removes every member of a team
+func (r *teamMemberRepository) DeleteByTeam(ctx context.Context, team string) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteTeamMembersMongo(ctx, db, "team", team)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// DeleteByUser removes a user from every team
+func (r *teamMemberRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteTeamMembersMongo(ctx, db, "user_id", userID)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// addTeamMemberMongo upserts a team membership in MongoDB, an existing membership is left untouched
+func (r *teamMemberRepository) addTeamMemberMongo(ctx context.Context, client *mongo.Client, member *entity.TeamMember) (bool, error) {
+	collection := client.Database("user_service").Collection("team_members")
+
+	filter := bson.M{"team": member.Team, "user_id": member.UserID}
+	update := bson.M{"$setOnInsert": member}
+	result, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error().Err(err).Str("team", member.Team).Str("user_id", member.UserID.String()).Msg("Failed to add team member in MongoDB")
+		return false, fmt.Errorf("failed to add team member: %w", err)
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+// removeTeamMemberMongo deletes a team membership from MongoDB
+func (r *teamMemberRepository) removeTeamMemberMongo(ctx context.Context, client *mongo.Client, team string, userID uuid.UUID) (bool, error) {
+	collection := client.Database("user_service").Collection("team_members")
+
+	result, err := collection.DeleteOne(ctx, bson.M{"team": team, "user_id": userID})
+	if err != nil {
+		log.Error().Err(err).Str("team", team).Str("user_id", userID.String()).Msg("Failed to remove team member in MongoDB")
+		return false, fmt.Errorf("failed to remove team member: %w", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// listTeamMembersMongo lists the team memberships matching a field from MongoDB, sorted by another
+func (r *teamMemberRepository) listTeamMembersMongo(ctx context.Context, client *mongo.Client, field string, value any, sortField string) ([]*entity.TeamMember, error) {
+	collection := client.Database("user_service").Collection("team_members")
+
+	opts := options.Find().SetSort(bson.D{{Key: sortField, Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{field: value}, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list team members from MongoDB")
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	members := []*entity.TeamMember{}
+	if err := cursor.All(ctx, &members); err != nil {
+		log.Error().Err(err).Msg("Failed to decode team members from MongoDB")
+		return nil, fmt.Errorf("failed to decode team members: %w", err)
+	}
+
+	return members, nil
+}
+
+// deleteTeamMembersMongo deletes the team memberships matching a field from MongoDB
+func (r *teamMemberRepository) deleteTeamMembersMongo(ctx context.Context, client *mongo.Client, field string, value any) error {
+	collection := client.Database("user_service").Collection("team_members")
+
+	if _, err := collection.DeleteMany(ctx, bson.M{field: value}); err != nil {
+		log.Error().Err(err).Str(field, fmt.Sprint(value)).Msg("Failed to delete team members from MongoDB")
+		return fmt.Errorf("failed to delete team members: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TeamRepository defines the interface for team repository operations
+type TeamRepository interface {
+	// Create a new team
+	Create(ctx context.Context, team *entity.Team) error
+
+	// Get a team by name, returns nil if the team does not exist
+	GetByName(ctx context.Context, name string) (*entity.Team, error)
+
+	// List all teams ordered by name
+	List(ctx context.Context) ([]*entity.Team, error)
+
+	// Update a team
+	Update(ctx context.Context, team *entity.Team) error
+
+	// Delete a team
+	Delete(ctx context.Context, name string) error
+}
+
+type teamRepository struct {
+	db db.Database
+}
+
+// NewTeamRepository creates a new TeamRepository
+func NewTeamRepository(db db.Database) TeamRepository {
+	return &teamRepository{
+		db: db,
+	}
+}
+
+// Create creates a new team
+func (r *teamRepository) Create(ctx context.Context, team *entity.Team) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createTeamMongo(ctx, db, team)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetByName retrieves a team by name
+func (r *teamRepository) GetByName(ctx context.Context, name string) (*entity.Team, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getTeamByNameMongo(ctx, db, name)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// List lists all teams
+func (r *teamRepository) List(ctx context.Context) ([]*entity.Team, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listTeamsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// Update updates a team
+func (r *teamRepository) Update(ctx context.Context, team *entity.Team) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateTeamMongo(ctx, db, team)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// Delete deletes a team
+func (r *teamRepository) Delete(ctx context.Context, name string) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteTeamMongo(ctx, db, name)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
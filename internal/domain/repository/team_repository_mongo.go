@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createTeamMongo creates a team in MongoDB
+func (r *teamRepository) createTeamMongo(ctx context.Context, client *mongo.Client, team *entity.Team) error {
+	collection := client.Database("user_service").Collection("teams")
+	_, err := collection.InsertOne(ctx, team)
+	if err != nil {
+		log.Error().Err(err).Str("team", team.Name).Msg("Failed to create team in MongoDB")
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return nil
+}
+
+// getTeamByNameMongo gets a team by name from MongoDB
+func (r *teamRepository) getTeamByNameMongo(ctx context.Context, client *mongo.Client, name string) (*entity.Team, error) {
+	collection := client.Database("user_service").Collection("teams")
+
+	var team entity.Team
+	err := collection.FindOne(ctx, bson.M{"_id": name}).Decode(&team)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Team not found
+		}
+		log.Error().Err(err).Str("team", name).Msg("Failed to get team from MongoDB")
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	return &team, nil
+}
+
+// listTeamsMongo lists all teams from MongoDB
+func (r *teamRepository) listTeamsMongo(ctx context.Context, client *mongo.Client) ([]*entity.Team, error) {
+	collection := client.Database("user_service").Collection("teams")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list teams from MongoDB")
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	teams := []*entity.Team{}
+	if err := cursor.All(ctx, &teams); err != nil {
+		log.Error().Err(err).Msg("Failed to decode teams from MongoDB")
+		return nil, fmt.Errorf("failed to decode teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+// updateTeamMongo updates a team in MongoDB
+func (r *teamRepository) updateTeamMongo(ctx context.Context, client *mongo.Client, team *entity.Team) error {
+	collection := client.Database("user_service").Collection("teams")
+
+	update := bson.M{
+		"$set": bson.M{
+			"description": team.Description,
+			"roles":       team.Roles,
+			"updated_at":  team.UpdatedAt,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": team.Name}, update)
+	if err != nil {
+		log.Error().Err(err).Str("team", team.Name).Msg("Failed to update team in MongoDB")
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+
+	return nil
+}
+
+// deleteTeamMongo deletes a team from MongoDB
+func (r *teamRepository) deleteTeamMongo(ctx context.Context, client *mongo.Client, name string) error {
+	collection := client.Database("user_service").Collection("teams")
+
+	_, err := collection.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		log.Error().Err(err).Str("team", name).Msg("Failed to delete team from MongoDB")
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	return nil
+}
@@ -2,8 +2,13 @@ package repository
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/chats/go-user-api/internal/domain/entity"
@@ -16,24 +21,87 @@ const (
 	accessTokenPrefix  = "access_token:"
 	refreshTokenPrefix = "refresh_token:"
 	userTokensPrefix   = "user_tokens:"
+	sessionPrefix      = "session:"
+	oneTimeTokenPrefix = "one_time_token:"
+	userStatusPrefix   = "user_status:"
+	denylistPrefix     = "token_denylist:"
+	consumedPrefix     = "refresh_token_consumed:"
+
+	revocationCutoffKey = "token_revocation_cutoff"
+
+	sessionHistoryPrefix = "session_history:"
+	revokedSessionPrefix = "session_revoked:"
 )
 
+// ErrSessionRevoked is returned when storing a token in a session revoked meanwhile
+var ErrSessionRevoked = errors.New("session revoked")
+
+// maxSessionHistory caps the tokens kept in a session history, the oldest are dropped first
+const maxSessionHistory = 200
+
 // TokenRepository defines the interface for token repository operations
 type TokenRepository interface {
-	// StoreAccessToken stores an access token with expiration
+	// StoreAccessToken stores an access token with expiration, ErrSessionRevoked if its session was revoked
 	StoreAccessToken(ctx context.Context, details *entity.TokenDetails) error
 
-	// StoreRefreshToken stores a refresh token with expiration
+	// StoreRefreshToken stores a refresh token with expiration, ErrSessionRevoked if its session was revoked
 	StoreRefreshToken(ctx context.Context, details *entity.TokenDetails) error
 
 	// GetToken retrieves token details by token ID and type
 	GetToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) (*entity.TokenDetails, error)
 
+	// GetAccessTokenState retrieves the details, denylisting and user status of an access token in a single round trip
+	GetAccessTokenState(ctx context.Context, tokenID, userID uuid.UUID) (*entity.TokenState, error)
+
 	// DeleteToken deletes a token
 	DeleteToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) error
 
+	// ConsumeRefreshToken marks a refresh token as rotated until the expiration, reporting whether this call marked
+	// it, so of concurrent rotations of the same token only one succeeds
+	ConsumeRefreshToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) (bool, error)
+
+	// ExtendAccessToken stores the new expiration of a live access token and extends its keys to match, reporting
+	// whether the token was still live. A token deleted or expired meanwhile is not stored again.
+	ExtendAccessToken(ctx context.Context, details *entity.TokenDetails) (bool, error)
+
+	// DeleteSession deletes the access and refresh tokens of a session
+	DeleteSession(ctx context.Context, sessionID uuid.UUID) error
+
+	// GetSession returns the history of a session, nil if unknown or expired
+	GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error)
+
+	// RevokeSession deletes the tokens of a session and records the revocation in its history
+	RevokeSession(ctx context.Context, sessionID uuid.UUID, reason string) error
+
 	// DeleteUserTokens deletes all tokens for a user
 	DeleteUserTokens(ctx context.Context, userID uuid.UUID) error
+
+	// ListUserSessions returns the sessions of a user holding a live token, in no particular order
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error)
+
+	// StoreOneTimeToken stores a single-use token issued to a user for a purpose
+	StoreOneTimeToken(ctx context.Context, purpose, token string, userID uuid.UUID, expiration time.Duration) error
+
+	// ConsumeOneTimeToken deletes a single-use token and returns the user it was issued to, uuid.Nil if unknown
+	ConsumeOneTimeToken(ctx context.Context, purpose, token string) (uuid.UUID, error)
+
+	// StoreUserStatus caches the status of a user, checked when validating their tokens
+	StoreUserStatus(ctx context.Context, userID uuid.UUID, status string, expiration time.Duration) error
+
+	// GetUserStatus returns the cached status of a user, empty if not cached
+	GetUserStatus(ctx context.Context, userID uuid.UUID) (string, error)
+
+	// DenyToken denylists a token ID until the expiration, whatever the type of the token
+	DenyToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) error
+
+	// IsTokenDenied reports whether a token ID is denylisted
+	IsTokenDenied(ctx context.Context, tokenID uuid.UUID) (bool, error)
+
+	// StoreRevocationCutoff rejects every token issued before the cutoff until the expiration
+	StoreRevocationCutoff(ctx context.Context, cutoff time.Time, expiration time.Duration) error
+
+	// GetRevocationCutoff returns the time before which tokens were issued are rejected, zero if none
+	GetRevocationCutoff(ctx context.Context) (time.Time, error)
 }
 
 type tokenRepository struct {
@@ -79,20 +147,123 @@ func (r *tokenRepository) storeToken(ctx context.Context, details *entity.TokenD
 		return fmt.Errorf("failed to store token: %w", err)
 	}
 
-	// Add token to user's tokens set
-	userTokensKey := fmt.Sprintf("%s%s", userTokensPrefix, details.UserID.String())
-	userTokenData := fmt.Sprintf("%s:%s", string(details.TokenType), details.TokenID.String())
-
-	// For simplicity, we're using a string value here
-	// In a real implementation, you might want to use Redis SET or HASH
-	err = r.cache.Set(ctx, userTokensKey+":"+userTokenData, []byte("1"), expiration)
+	// Add token to the user's tokens set, which lives as long as the user's longest-lived token
+	err = r.cache.AddToSet(ctx, userTokensKey(details.UserID), userTokenMember(details.TokenType, details.TokenID), expiration)
 	if err != nil {
 		log.Warn().Err(err).Str("user_id", details.UserID.String()).Msg("Failed to add token to user tokens")
 	}
 
+	// Record the token as the session's current token of its type
+	if details.SessionID != uuid.Nil {
+		err = r.cache.Set(ctx, sessionKey(details.SessionID, details.TokenType), []byte(details.TokenID.String()), expiration)
+		if err != nil {
+			log.Error().Err(err).Str("session_id", details.SessionID.String()).Msg("Failed to add token to session")
+			return fmt.Errorf("failed to add token to session: %w", err)
+		}
+
+		if err := r.appendSessionHistory(ctx, details); err != nil {
+			log.Warn().Err(err).Str("session_id", details.SessionID.String()).Msg("Failed to record token in session history")
+		}
+
+		return r.checkSessionRevoked(ctx, details)
+	}
+
+	return nil
+}
+
+// checkSessionRevoked deletes a token just stored if its session was revoked meanwhile, e.g. by a refresh token
+// reuse detected while the token was rotated. Either the revocation is marked before the check, or the token was
+// stored before the revocation deleted the tokens of the session. The revocation is recorded again in the history,
+// in case it was overwritten by the token appended to it.
+func (r *tokenRepository) checkSessionRevoked(ctx context.Context, details *entity.TokenDetails) error {
+	reason, err := r.cache.Get(ctx, revokedSessionPrefix+details.SessionID.String())
+	if err != nil {
+		log.Error().Err(err).Str("session_id", details.SessionID.String()).Msg("Failed to get session revocation from cache")
+		return fmt.Errorf("failed to get session revocation: %w", err)
+	}
+	if reason == nil {
+		return nil
+	}
+
+	if err := r.DeleteToken(ctx, details.TokenID, details.TokenType); err != nil {
+		return err
+	}
+	if err := r.DeleteSession(ctx, details.SessionID); err != nil {
+		return err
+	}
+	if err := r.recordRevocation(ctx, details.SessionID, string(reason)); err != nil {
+		return err
+	}
+	return ErrSessionRevoked
+}
+
+// appendSessionHistory records an issued token in the history of its session,
+// the history lives as long as the longest-lived token of the session
+func (r *tokenRepository) appendSessionHistory(ctx context.Context, details *entity.TokenDetails) error {
+	session, err := r.GetSession(ctx, details.SessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		session = &entity.Session{
+			ID:        details.SessionID,
+			UserID:    details.UserID,
+			CreatedAt: details.IssuedAt,
+		}
+	}
+
+	session.Tokens = append(session.Tokens, details)
+	if len(session.Tokens) > maxSessionHistory {
+		session.Tokens = session.Tokens[len(session.Tokens)-maxSessionHistory:]
+	}
+
+	return r.storeSession(ctx, session)
+}
+
+// storeSession stores the history of a session until its last token expires
+func (r *tokenRepository) storeSession(ctx context.Context, session *entity.Session) error {
+	expiration := sessionExpiration(session)
+	if expiration <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	if err := r.cache.Set(ctx, sessionHistoryPrefix+session.ID.String(), data, expiration); err != nil {
+		return fmt.Errorf("failed to store session: %w", err)
+	}
 	return nil
 }
 
+// sessionExpiration returns the time left until the last token of a session expires
+func sessionExpiration(session *entity.Session) time.Duration {
+	var expiresAt time.Time
+	for _, token := range session.Tokens {
+		if token.Expiration.After(expiresAt) {
+			expiresAt = token.Expiration
+		}
+	}
+	return time.Until(expiresAt)
+}
+
+// userTokensKey returns the key of the set of the tokens issued to a user
+func userTokensKey(userID uuid.UUID) string {
+	return userTokensPrefix + userID.String()
+}
+
+// userTokenMember returns the member identifying a token in the set of its user's tokens
+func userTokenMember(tokenType entity.TokenType, tokenID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", string(tokenType), tokenID.String())
+}
+
+// sessionKey returns the key holding the ID of the current token of a type in a session
+func sessionKey(sessionID uuid.UUID, tokenType entity.TokenType) string {
+	return fmt.Sprintf("%s%s:%s", sessionPrefix, sessionID.String(), string(tokenType))
+}
+
 // GetToken retrieves token details by token ID and type
 func (r *tokenRepository) GetToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) (*entity.TokenDetails, error) {
 	// Determine prefix based on token type
@@ -128,6 +299,33 @@ func (r *tokenRepository) GetToken(ctx context.Context, tokenID uuid.UUID, token
 	return &details, nil
 }
 
+// GetAccessTokenState retrieves the details, denylisting and user status of an access token in a single round trip
+func (r *tokenRepository) GetAccessTokenState(ctx context.Context, tokenID, userID uuid.UUID) (*entity.TokenState, error) {
+	tokenKey := accessTokenPrefix + tokenID.String()
+	denylistKey := denylistPrefix + tokenID.String()
+	statusKey := userStatusPrefix + userID.String()
+
+	values, err := r.cache.GetMulti(ctx, []string{tokenKey, denylistKey, statusKey})
+	if err != nil {
+		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to get access token state from cache")
+		return nil, fmt.Errorf("failed to get access token state: %w", err)
+	}
+
+	state := &entity.TokenState{
+		Denied:     values[denylistKey] != nil,
+		UserStatus: string(values[statusKey]),
+	}
+	if data := values[tokenKey]; data != nil {
+		var details entity.TokenDetails
+		if err := json.Unmarshal(data, &details); err != nil {
+			log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to unmarshal token details")
+			return nil, fmt.Errorf("failed to unmarshal token details: %w", err)
+		}
+		state.Details = &details
+	}
+	return state, nil
+}
+
 // DeleteToken deletes a token
 func (r *tokenRepository) DeleteToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) error {
 	// Determine prefix based on token type
@@ -156,11 +354,7 @@ func (r *tokenRepository) DeleteToken(ctx context.Context, tokenID uuid.UUID, to
 	}
 
 	// Remove from user tokens set
-	userTokensKey := fmt.Sprintf("%s%s", userTokensPrefix, token.UserID.String())
-	userTokenData := fmt.Sprintf("%s:%s", string(tokenType), tokenID.String())
-
-	// Delete from user tokens
-	err = r.cache.Delete(ctx, userTokensKey+":"+userTokenData)
+	err = r.cache.RemoveFromSet(ctx, userTokensKey(token.UserID), userTokenMember(tokenType, tokenID))
 	if err != nil {
 		log.Warn().Err(err).Str("user_id", token.UserID.String()).Msg("Failed to remove token from user tokens")
 	}
@@ -168,23 +362,366 @@ func (r *tokenRepository) DeleteToken(ctx context.Context, tokenID uuid.UUID, to
 	return nil
 }
 
-// DeleteUserTokens deletes all tokens for a user
+// ConsumeRefreshToken marks a refresh token as rotated
+func (r *tokenRepository) ConsumeRefreshToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) (bool, error) {
+	consumed, err := r.cache.SetNX(ctx, consumedPrefix+tokenID.String(), []byte("1"), expiration)
+	if err != nil {
+		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to mark refresh token consumed in cache")
+		return false, fmt.Errorf("failed to consume refresh token: %w", err)
+	}
+	return consumed, nil
+}
+
+// ExtendAccessToken stores the new expiration of a live access token
+func (r *tokenRepository) ExtendAccessToken(ctx context.Context, details *entity.TokenDetails) (bool, error) {
+	data, err := json.Marshal(details)
+	if err != nil {
+		log.Error().Err(err).Str("token_id", details.TokenID.String()).Msg("Failed to marshal token details")
+		return false, fmt.Errorf("failed to marshal token details: %w", err)
+	}
+
+	expiration := time.Until(details.Expiration)
+
+	// Only replace the token while it exists, so a token revoked since it was validated stays revoked
+	extended, err := r.cache.SetXX(ctx, accessTokenPrefix+details.TokenID.String(), data, expiration)
+	if err != nil {
+		log.Error().Err(err).Str("token_id", details.TokenID.String()).Msg("Failed to extend token in cache")
+		return false, fmt.Errorf("failed to extend token: %w", err)
+	}
+	if !extended {
+		return false, nil
+	}
+
+	err = r.cache.AddToSet(ctx, userTokensKey(details.UserID), userTokenMember(details.TokenType, details.TokenID), expiration)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", details.UserID.String()).Msg("Failed to extend user tokens")
+	}
+
+	// The session must keep pointing at the token for as long as it lives, or deleting the session would miss it.
+	// Once refreshed, the session points at the new token and is left alone.
+	if details.SessionID != uuid.Nil {
+		key := sessionKey(details.SessionID, details.TokenType)
+		current, err := r.cache.Get(ctx, key)
+		if err != nil {
+			log.Error().Err(err).Str("session_id", details.SessionID.String()).Msg("Failed to get session token from cache")
+			return true, fmt.Errorf("failed to extend session: %w", err)
+		}
+		if string(current) == details.TokenID.String() {
+			if _, err := r.cache.SetXX(ctx, key, current, expiration); err != nil {
+				log.Error().Err(err).Str("session_id", details.SessionID.String()).Msg("Failed to extend session in cache")
+				return true, fmt.Errorf("failed to extend session: %w", err)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// DeleteSession deletes the access and refresh tokens of a session
+func (r *tokenRepository) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	for _, tokenType := range []entity.TokenType{entity.AccessToken, entity.RefreshToken} {
+		key := sessionKey(sessionID, tokenType)
+
+		data, err := r.cache.Get(ctx, key)
+		if err != nil {
+			log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to get session token from cache")
+			return fmt.Errorf("failed to get session token: %w", err)
+		}
+		if data == nil {
+			continue // Token expired or already deleted
+		}
+
+		tokenID, err := uuid.ParseBytes(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse session token ID: %w", err)
+		}
+
+		if err := r.DeleteToken(ctx, tokenID, tokenType); err != nil {
+			return err
+		}
+
+		if err := r.cache.Delete(ctx, key); err != nil {
+			log.Warn().Err(err).Str("session_id", sessionID.String()).Msg("Failed to remove token from session")
+		}
+	}
+
+	return nil
+}
+
+// GetSession returns the history of a session, nil if unknown or expired
+func (r *tokenRepository) GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	data, err := r.cache.Get(ctx, sessionHistoryPrefix+sessionID.String())
+	if err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to get session from cache")
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if data == nil {
+		return nil, nil // Session not found
+	}
+
+	var session entity.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to unmarshal session")
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// RevokeSession deletes the tokens of a session and records the revocation in its history. The revocation is
+// marked first, so the tokens of the session being stored meanwhile are deleted too.
+func (r *tokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	if err := r.markRevocation(ctx, sessionID, reason); err != nil {
+		return err
+	}
+
+	if err := r.DeleteSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	return r.recordRevocation(ctx, sessionID, reason)
+}
+
+// markRevocation marks a session revoked until its last token expires, the tokens stored in the session are
+// rotated from those
+func (r *tokenRepository) markRevocation(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	session, err := r.GetSession(ctx, sessionID)
+	if err != nil || session == nil {
+		return err
+	}
+
+	expiration := sessionExpiration(session)
+	if expiration <= 0 {
+		return nil
+	}
+
+	if err := r.cache.Set(ctx, revokedSessionPrefix+sessionID.String(), []byte(reason), expiration); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to mark session revoked in cache")
+		return fmt.Errorf("failed to mark session revoked: %w", err)
+	}
+	return nil
+}
+
+// recordRevocation records the revocation of a session in its history, so its refresh tokens presented again are
+// not taken for leaked ones
+func (r *tokenRepository) recordRevocation(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	session, err := r.GetSession(ctx, sessionID)
+	if err != nil || session == nil || session.RevokedAt != nil {
+		return err
+	}
+
+	now := time.Now()
+	session.RevokedAt = &now
+	session.RevokedReason = reason
+	if err := r.storeSession(ctx, session); err != nil {
+		log.Warn().Err(err).Str("session_id", sessionID.String()).Msg("Failed to record session revocation")
+	}
+
+	return nil
+}
+
+// DeleteUserTokens deletes all tokens for a user, on every device, and records the revocation of their sessions.
+// Only the members read are removed from the set, so tokens issued meanwhile stay tracked.
 func (r *tokenRepository) DeleteUserTokens(ctx context.Context, userID uuid.UUID) error {
-	// For a more robust implementation, you would use Redis SCAN to get all user tokens
-	// and then delete them in batch
+	key := userTokensKey(userID)
 
-	// Here we're using a simplistic approach
-	userTokensKey := fmt.Sprintf("%s%s:*", userTokensPrefix, userID.String())
-	log.Debug().Str("user_id", userID.String()).Str("key", userTokensKey).Msg("Deleting all user tokens")
+	members, err := r.cache.GetSetMembers(ctx, key)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get user tokens from cache")
+		return fmt.Errorf("failed to get user tokens: %w", err)
+	}
 
-	// In a real implementation, you would get all keys matching the pattern
-	// and delete them all
+	var sessionIDs []uuid.UUID
+	for _, member := range members {
+		tokenType, tokenID, ok := strings.Cut(member, ":")
+		if !ok {
+			log.Warn().Str("user_id", userID.String()).Str("member", member).Msg("Ignoring malformed user token")
+			continue
+		}
+
+		prefix := refreshTokenPrefix
+		if entity.TokenType(tokenType) == entity.AccessToken {
+			prefix = accessTokenPrefix
+		}
+
+		// Tokens that already expired are gone, so their session is left as it is
+		if id, err := uuid.Parse(tokenID); err == nil {
+			details, err := r.GetToken(ctx, id, entity.TokenType(tokenType))
+			if err != nil {
+				return err
+			}
+			if details != nil && details.SessionID != uuid.Nil && !slices.Contains(sessionIDs, details.SessionID) {
+				sessionIDs = append(sessionIDs, details.SessionID)
+			}
+		}
+
+		// Deleting the tokens that already expired is a no-op
+		if err := r.cache.Delete(ctx, prefix+tokenID); err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Str("token_id", tokenID).Msg("Failed to delete token from cache")
+			return fmt.Errorf("failed to delete token: %w", err)
+		}
+	}
 
-	// For simplicity, we'll use Clear method which is non-ideal
-	// In production, you'd implement a method to delete by pattern
-	log.Warn().Str("user_id", userID.String()).Msg("Deleting all user tokens - this is a simplified implementation")
+	if err := r.cache.RemoveFromSet(ctx, key, members...); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to remove tokens from user tokens")
+	}
+	for _, sessionID := range sessionIDs {
+		if err := r.recordRevocation(ctx, sessionID, entity.SessionRevokedLogout); err != nil {
+			log.Warn().Err(err).Str("session_id", sessionID.String()).Msg("Failed to record session revocation")
+		}
+	}
 
-	// In a real implementation with Redis, you would use SCAN and DEL
-	// Here we'll just return nil
+	log.Debug().Str("user_id", userID.String()).Int("tokens", len(members)).Msg("Deleted all user tokens")
 	return nil
 }
+
+// ListUserSessions returns the sessions of a user holding a live token, in no particular order
+func (r *tokenRepository) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
+	members, err := r.cache.GetSetMembers(ctx, userTokensKey(userID))
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get user tokens from cache")
+		return nil, fmt.Errorf("failed to get user tokens: %w", err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var sessions []*entity.Session
+	for _, member := range members {
+		tokenType, id, ok := strings.Cut(member, ":")
+		if !ok {
+			continue
+		}
+		tokenID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+
+		// Expired tokens are gone from the cache but may linger in the set
+		details, err := r.GetToken(ctx, tokenID, entity.TokenType(tokenType))
+		if err != nil {
+			return nil, err
+		}
+		if details == nil || details.SessionID == uuid.Nil || seen[details.SessionID] {
+			continue
+		}
+		seen[details.SessionID] = true
+
+		session, err := r.GetSession(ctx, details.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session != nil && session.RevokedAt == nil {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// oneTimeTokenKey returns the cache key of a single-use token.
+// Only a hash of the token is stored, so the cache contents cannot be replayed.
+func oneTimeTokenKey(purpose, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("%s%s:%s", oneTimeTokenPrefix, purpose, hex.EncodeToString(sum[:]))
+}
+
+// StoreOneTimeToken stores a single-use token issued to a user for a purpose
+func (r *tokenRepository) StoreOneTimeToken(ctx context.Context, purpose, token string, userID uuid.UUID, expiration time.Duration) error {
+	if err := r.cache.Set(ctx, oneTimeTokenKey(purpose, token), []byte(userID.String()), expiration); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("purpose", purpose).Msg("Failed to store one-time token in cache")
+		return fmt.Errorf("failed to store one-time token: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeOneTimeToken deletes a single-use token and returns the user it was issued to, uuid.Nil if unknown
+func (r *tokenRepository) ConsumeOneTimeToken(ctx context.Context, purpose, token string) (uuid.UUID, error) {
+	key := oneTimeTokenKey(purpose, token)
+
+	data, err := r.cache.Get(ctx, key)
+	if err != nil {
+		log.Error().Err(err).Str("purpose", purpose).Msg("Failed to get one-time token from cache")
+		return uuid.Nil, fmt.Errorf("failed to get one-time token: %w", err)
+	}
+	if data == nil {
+		return uuid.Nil, nil
+	}
+
+	if err := r.cache.Delete(ctx, key); err != nil {
+		log.Error().Err(err).Str("purpose", purpose).Msg("Failed to delete one-time token from cache")
+		return uuid.Nil, fmt.Errorf("failed to delete one-time token: %w", err)
+	}
+
+	userID, err := uuid.ParseBytes(data)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to parse one-time token: %w", err)
+	}
+
+	return userID, nil
+}
+
+// StoreUserStatus caches the status of a user
+func (r *tokenRepository) StoreUserStatus(ctx context.Context, userID uuid.UUID, status string, expiration time.Duration) error {
+	if err := r.cache.Set(ctx, userStatusPrefix+userID.String(), []byte(status), expiration); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to store user status in cache")
+		return fmt.Errorf("failed to store user status: %w", err)
+	}
+	return nil
+}
+
+// GetUserStatus returns the cached status of a user
+func (r *tokenRepository) GetUserStatus(ctx context.Context, userID uuid.UUID) (string, error) {
+	data, err := r.cache.Get(ctx, userStatusPrefix+userID.String())
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get user status from cache")
+		return "", fmt.Errorf("failed to get user status: %w", err)
+	}
+	return string(data), nil
+}
+
+// DenyToken denylists a token ID
+func (r *tokenRepository) DenyToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) error {
+	if err := r.cache.Set(ctx, denylistPrefix+tokenID.String(), []byte("1"), expiration); err != nil {
+		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to denylist token in cache")
+		return fmt.Errorf("failed to denylist token: %w", err)
+	}
+	return nil
+}
+
+// IsTokenDenied reports whether a token ID is denylisted
+func (r *tokenRepository) IsTokenDenied(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	data, err := r.cache.Get(ctx, denylistPrefix+tokenID.String())
+	if err != nil {
+		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to get token denylist from cache")
+		return false, fmt.Errorf("failed to get token denylist: %w", err)
+	}
+	return data != nil, nil
+}
+
+// StoreRevocationCutoff rejects every token issued before the cutoff
+func (r *tokenRepository) StoreRevocationCutoff(ctx context.Context, cutoff time.Time, expiration time.Duration) error {
+	if err := r.cache.Set(ctx, revocationCutoffKey, []byte(cutoff.UTC().Format(time.RFC3339Nano)), expiration); err != nil {
+		log.Error().Err(err).Msg("Failed to store revocation cutoff in cache")
+		return fmt.Errorf("failed to store revocation cutoff: %w", err)
+	}
+	return nil
+}
+
+// GetRevocationCutoff returns the time before which tokens were issued are rejected
+func (r *tokenRepository) GetRevocationCutoff(ctx context.Context) (time.Time, error) {
+	data, err := r.cache.Get(ctx, revocationCutoffKey)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get revocation cutoff from cache")
+		return time.Time{}, fmt.Errorf("failed to get revocation cutoff: %w", err)
+	}
+	if data == nil {
+		return time.Time{}, nil
+	}
+
+	cutoff, err := time.Parse(time.RFC3339Nano, string(data))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse revocation cutoff: %w", err)
+	}
+	return cutoff, nil
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// tokenInvalidationChannel is the channel the instances publish the invalidated tokens to
+	tokenInvalidationChannel = "token_invalidation"
+
+	// tokenInvalidationRetry is the delay before subscribing again after the subscription failed
+	tokenInvalidationRetry = time.Second
+)
+
+// tokenInvalidation is published when tokens stop being valid, it matches on any of its non-nil IDs
+type tokenInvalidation struct {
+	TokenID   uuid.UUID `json:"token_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// matches reports whether the invalidation applies to a cached token
+func (i tokenInvalidation) matches(tokenID uuid.UUID, token *validatedToken) bool {
+	return (i.TokenID != uuid.Nil && i.TokenID == tokenID) ||
+		(i.SessionID != uuid.Nil && i.SessionID == token.state.Details.SessionID) ||
+		(i.UserID != uuid.Nil && i.UserID == token.state.Details.UserID)
+}
+
+// validatedToken is the state of a valid access token kept in process memory
+type validatedToken struct {
+	state     entity.TokenState
+	expiresAt time.Time
+}
+
+// CachedTokenRepository is a TokenRepository keeping the state of valid access tokens in process memory for a
+// short time, so validating the same token again skips the cache round trip. Logouts, revocations, denylisting and
+// status changes evict the tokens on every instance through the invalidations published on the cache.
+type CachedTokenRepository interface {
+	TokenRepository
+
+	// Subscribe evicts the tokens invalidated by any instance until the context is cancelled. States are only
+	// served from memory while subscribed, so a lost subscription cannot keep a revoked token valid.
+	Subscribe(ctx context.Context)
+}
+
+type cachedTokenRepository struct {
+	TokenRepository
+
+	cache cache.Cache
+	ttl   time.Duration
+	size  int
+
+	mu     sync.Mutex
+	tokens map[uuid.UUID]*validatedToken
+	// generation changes on every eviction, so a state read before an invalidation is not cached after it
+	generation uint64
+	subscribed atomic.Bool
+}
+
+// NewCachedTokenRepository wraps a TokenRepository, keeping up to size valid access tokens for ttl
+func NewCachedTokenRepository(next TokenRepository, cache cache.Cache, ttl time.Duration, size int) CachedTokenRepository {
+	return &cachedTokenRepository{
+		TokenRepository: next,
+		cache:           cache,
+		ttl:             ttl,
+		size:            size,
+		tokens:          map[uuid.UUID]*validatedToken{},
+	}
+}
+
+// GetAccessTokenState retrieves the state of an access token from memory, or from the cache when unknown
+func (r *cachedTokenRepository) GetAccessTokenState(ctx context.Context, tokenID, userID uuid.UUID) (*entity.TokenState, error) {
+	subscribed := r.subscribed.Load()
+
+	r.mu.Lock()
+	now := time.Now()
+	if token, ok := r.tokens[tokenID]; subscribed && ok && now.Before(token.expiresAt) {
+		state := token.state
+		r.mu.Unlock()
+		metrics.TokenValidationCache.WithLabelValues("hit").Inc()
+		return &state, nil
+	}
+	generation := r.generation
+	r.mu.Unlock()
+	metrics.TokenValidationCache.WithLabelValues("miss").Inc()
+
+	state, err := r.TokenRepository.GetAccessTokenState(ctx, tokenID, userID)
+	if err != nil || !subscribed || state.Details == nil || state.Denied {
+		return state, err
+	}
+
+	expiresAt := now.Add(r.ttl)
+	if state.Details.Expiration.Before(expiresAt) {
+		expiresAt = state.Details.Expiration
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation != generation {
+		return state, nil // Invalidated meanwhile, the state may be stale
+	}
+	if len(r.tokens) >= r.size {
+		r.evictExpired(now)
+	}
+	if len(r.tokens) < r.size {
+		r.tokens[tokenID] = &validatedToken{state: *state, expiresAt: expiresAt}
+	}
+	return state, nil
+}
+
+// ExtendAccessToken extends an access token, updating its state in memory so it is not served with the former
+// expiration. Other instances catch up once their copy expires.
+func (r *cachedTokenRepository) ExtendAccessToken(ctx context.Context, details *entity.TokenDetails) (bool, error) {
+	extended, err := r.TokenRepository.ExtendAccessToken(ctx, details)
+	if !extended {
+		return extended, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token, ok := r.tokens[details.TokenID]; ok {
+		extendedDetails := *details
+		token.state.Details = &extendedDetails
+	}
+	return extended, err
+}
+
+// DeleteToken deletes a token and evicts it on every instance
+func (r *cachedTokenRepository) DeleteToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) error {
+	err := r.TokenRepository.DeleteToken(ctx, tokenID, tokenType)
+	r.invalidate(ctx, tokenInvalidation{TokenID: tokenID})
+	return err
+}
+
+// DeleteSession deletes the tokens of a session and evicts them on every instance
+func (r *cachedTokenRepository) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	err := r.TokenRepository.DeleteSession(ctx, sessionID)
+	r.invalidate(ctx, tokenInvalidation{SessionID: sessionID})
+	return err
+}
+
+// RevokeSession revokes a session and evicts its tokens on every instance
+func (r *cachedTokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	err := r.TokenRepository.RevokeSession(ctx, sessionID, reason)
+	r.invalidate(ctx, tokenInvalidation{SessionID: sessionID})
+	return err
+}
+
+// DeleteUserTokens deletes the tokens of a user and evicts them on every instance
+func (r *cachedTokenRepository) DeleteUserTokens(ctx context.Context, userID uuid.UUID) error {
+	err := r.TokenRepository.DeleteUserTokens(ctx, userID)
+	r.invalidate(ctx, tokenInvalidation{UserID: userID})
+	return err
+}
+
+// StoreUserStatus caches the status of a user and evicts their tokens on every instance
+func (r *cachedTokenRepository) StoreUserStatus(ctx context.Context, userID uuid.UUID, status string, expiration time.Duration) error {
+	err := r.TokenRepository.StoreUserStatus(ctx, userID, status, expiration)
+	r.invalidate(ctx, tokenInvalidation{UserID: userID})
+	return err
+}
+
+// DenyToken denylists a token ID and evicts it on every instance
+func (r *cachedTokenRepository) DenyToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) error {
+	err := r.TokenRepository.DenyToken(ctx, tokenID, expiration)
+	r.invalidate(ctx, tokenInvalidation{TokenID: tokenID})
+	return err
+}
+
+// Subscribe evicts the tokens invalidated by any instance until the context is cancelled
+func (r *cachedTokenRepository) Subscribe(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := r.cache.Subscribe(ctx, tokenInvalidationChannel)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to subscribe to token invalidations")
+			select {
+			case <-ctx.Done():
+			case <-time.After(tokenInvalidationRetry):
+			}
+			continue
+		}
+
+		// Invalidations published while unsubscribed were missed
+		r.flush()
+		r.subscribed.Store(true)
+		for message := range messages {
+			var invalidation tokenInvalidation
+			if err := json.Unmarshal(message, &invalidation); err != nil {
+				log.Warn().Err(err).Msg("Ignoring malformed token invalidation")
+				continue
+			}
+			r.evict(invalidation)
+		}
+		r.subscribed.Store(false)
+		r.flush()
+
+		if ctx.Err() == nil {
+			log.Warn().Msg("Lost the subscription to token invalidations, subscribing again")
+		}
+	}
+}
+
+// invalidate evicts tokens locally, then on the other instances
+func (r *cachedTokenRepository) invalidate(ctx context.Context, invalidation tokenInvalidation) {
+	r.evict(invalidation)
+
+	data, err := json.Marshal(invalidation)
+	if err != nil {
+		return
+	}
+	if err := r.cache.Publish(ctx, tokenInvalidationChannel, data); err != nil {
+		// The other instances keep the tokens until they expire from memory, at most the configured TTL
+		log.Warn().Err(err).Msg("Failed to publish token invalidation")
+	}
+}
+
+// evict removes the tokens an invalidation applies to
+func (r *cachedTokenRepository) evict(invalidation tokenInvalidation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	for tokenID, token := range r.tokens {
+		if invalidation.matches(tokenID, token) {
+			delete(r.tokens, tokenID)
+		}
+	}
+}
+
+// flush removes every token
+func (r *cachedTokenRepository) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	r.tokens = map[uuid.UUID]*validatedToken{}
+}
+
+// evictExpired removes the expired tokens, the caller holds the lock
+func (r *cachedTokenRepository) evictExpired(now time.Time) {
+	for tokenID, token := range r.tokens {
+		if !now.Before(token.expiresAt) {
+			delete(r.tokens, tokenID)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/google/uuid"
+)
+
+// subscriptionCache is an in-memory cache whose subscriptions can be dropped, as when the connection to Redis is
+// lost, and refused until restored
+type subscriptionCache struct {
+	cache.Cache
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	refused bool
+}
+
+// Subscribe subscribes to a channel until dropped, failing while refused
+func (c *subscriptionCache) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refused {
+		return nil, errors.New("connection refused")
+	}
+	ctx, c.cancel = context.WithCancel(ctx)
+	return c.Cache.Subscribe(ctx, channel)
+}
+
+// drop ends the current subscription, the next ones fail when refused
+func (c *subscriptionCache) drop(refused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.refused = refused
+	c.cancel()
+}
+
+// tokenCacheTest is an instance keeping validated tokens in memory, over a token store shared with the other
+// instances through a cache, as they share Redis
+type tokenCacheTest struct {
+	tokens *cachedTokenRepository
+	// store is the shared store, reading and writing it leaves the tokens in memory alone
+	store TokenRepository
+}
+
+// newTokenCacheTest creates an instance over a shared cache, subscribed to the invalidations until the test ends
+func newTokenCacheTest(t *testing.T, shared cache.Cache, size int) *tokenCacheTest {
+	t.Helper()
+
+	store := NewTokenRepository(shared)
+	tokens := NewCachedTokenRepository(store, shared, time.Minute, size).(*cachedTokenRepository)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go tokens.Subscribe(ctx)
+	waitSubscribed(t, tokens, true)
+
+	return &tokenCacheTest{tokens: tokens, store: store}
+}
+
+// waitSubscribed waits until an instance is subscribed to the invalidations, or no longer is
+func waitSubscribed(t *testing.T, tokens *cachedTokenRepository, subscribed bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for tokens.subscribed.Load() != subscribed {
+		if time.Now().After(deadline) {
+			t.Fatalf("subscribed still %v", !subscribed)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// issueAccessToken stores an access token of a new session in a shared store
+func issueAccessToken(t *testing.T, store TokenRepository, userID uuid.UUID) *entity.TokenDetails {
+	t.Helper()
+
+	now := time.Now()
+	details := &entity.TokenDetails{
+		TokenID:    uuid.New(),
+		UserID:     userID,
+		TokenType:  entity.AccessToken,
+		SessionID:  uuid.New(),
+		IssuedAt:   now,
+		Expiration: now.Add(15 * time.Minute),
+	}
+	if err := store.StoreAccessToken(context.Background(), details); err != nil {
+		t.Fatalf("failed to store access token: %v", err)
+	}
+	return details
+}
+
+// isValid reports whether an instance finds an access token live
+func (tt *tokenCacheTest) isValid(t *testing.T, details *entity.TokenDetails) bool {
+	t.Helper()
+
+	state, err := tt.tokens.GetAccessTokenState(context.Background(), details.TokenID, details.UserID)
+	if err != nil {
+		t.Fatalf("failed to get access token state: %v", err)
+	}
+	return state.Details != nil && !state.Denied
+}
+
+// deleteFromStore deletes a token from the shared store without invalidating it, so only an instance serving it
+// from memory still finds it valid
+func (tt *tokenCacheTest) deleteFromStore(t *testing.T, details *entity.TokenDetails) {
+	t.Helper()
+
+	if err := tt.store.DeleteToken(context.Background(), details.TokenID, details.TokenType); err != nil {
+		t.Fatalf("failed to delete token: %v", err)
+	}
+}
+
+func TestCachedTokenRepositoryServesValidatedTokensFromMemory(t *testing.T) {
+	tt := newTokenCacheTest(t, cache.NewMemory(), 10)
+	token := issueAccessToken(t, tt.store, uuid.New())
+
+	if !tt.isValid(t, token) {
+		t.Fatal("issued token rejected")
+	}
+	tt.deleteFromStore(t, token)
+
+	if !tt.isValid(t, token) {
+		t.Error("validated token not served from memory")
+	}
+}
+
+func TestCachedTokenRepositoryEvictsOnEveryInstance(t *testing.T) {
+	ctx := context.Background()
+	shared := cache.NewMemory()
+	revoking := newTokenCacheTest(t, shared, 10)
+	other := newTokenCacheTest(t, shared, 10)
+
+	userID := uuid.New()
+	tests := []struct {
+		name       string
+		invalidate func(token *entity.TokenDetails) error
+	}{
+		{"delete token", func(token *entity.TokenDetails) error {
+			return revoking.tokens.DeleteToken(ctx, token.TokenID, token.TokenType)
+		}},
+		{"revoke session", func(token *entity.TokenDetails) error {
+			return revoking.tokens.RevokeSession(ctx, token.SessionID, entity.SessionRevokedLogout)
+		}},
+		{"delete user tokens", func(token *entity.TokenDetails) error {
+			return revoking.tokens.DeleteUserTokens(ctx, token.UserID)
+		}},
+		{"deny token", func(token *entity.TokenDetails) error {
+			return revoking.tokens.DenyToken(ctx, token.TokenID, time.Hour)
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := issueAccessToken(t, revoking.store, userID)
+			if !revoking.isValid(t, token) || !other.isValid(t, token) {
+				t.Fatal("issued token rejected")
+			}
+
+			if err := tc.invalidate(token); err != nil {
+				t.Fatalf("failed to invalidate token: %v", err)
+			}
+
+			if revoking.isValid(t, token) {
+				t.Error("token still valid on the revoking instance")
+			}
+			// The invalidation reaches the other instance asynchronously
+			deadline := time.Now().Add(5 * time.Second)
+			for other.isValid(t, token) {
+				if time.Now().After(deadline) {
+					t.Fatal("token still valid on the other instance")
+				}
+				time.Sleep(time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestCachedTokenRepositoryStopsServingWhenSubscriptionLost(t *testing.T) {
+	shared := &subscriptionCache{Cache: cache.NewMemory()}
+	tt := newTokenCacheTest(t, shared, 10)
+	token := issueAccessToken(t, tt.store, uuid.New())
+	if !tt.isValid(t, token) {
+		t.Fatal("issued token rejected")
+	}
+
+	// Invalidations published while unsubscribed are missed, so nothing is served from memory meanwhile
+	shared.drop(true)
+	waitSubscribed(t, tt.tokens, false)
+	tt.deleteFromStore(t, token)
+	if tt.isValid(t, token) {
+		t.Error("token served from memory while unsubscribed")
+	}
+}
+
+func TestCachedTokenRepositoryForgetsTokensAcrossLostSubscription(t *testing.T) {
+	shared := &subscriptionCache{Cache: cache.NewMemory()}
+	tt := newTokenCacheTest(t, shared, 10)
+	token := issueAccessToken(t, tt.store, uuid.New())
+	if !tt.isValid(t, token) {
+		t.Fatal("issued token rejected")
+	}
+
+	// The token was revoked while the subscription was lost, the tokens validated before are not trusted again
+	tt.tokens.subscribed.Store(false)
+	tt.deleteFromStore(t, token)
+	shared.drop(false)
+	waitSubscribed(t, tt.tokens, true)
+
+	if tt.isValid(t, token) {
+		t.Error("token validated before the subscription was lost served from memory")
+	}
+}
+
+func TestCachedTokenRepositoryKeepsAtMostSizeTokens(t *testing.T) {
+	tt := newTokenCacheTest(t, cache.NewMemory(), 1)
+	userID := uuid.New()
+	first := issueAccessToken(t, tt.store, userID)
+	second := issueAccessToken(t, tt.store, userID)
+	if !tt.isValid(t, first) || !tt.isValid(t, second) {
+		t.Fatal("issued token rejected")
+	}
+
+	tt.deleteFromStore(t, first)
+	tt.deleteFromStore(t, second)
+
+	if !tt.isValid(t, first) {
+		t.Error("first token not served from memory")
+	}
+	if tt.isValid(t, second) {
+		t.Error("token served from memory beyond the size")
+	}
+}
+
+// invalidatingStore is a TokenRepository running a function while reading the state of an access token
+type invalidatingStore struct {
+	TokenRepository
+	during func()
+}
+
+// GetAccessTokenState reads the state of an access token, running the function before returning it
+func (s *invalidatingStore) GetAccessTokenState(ctx context.Context, tokenID, userID uuid.UUID) (*entity.TokenState, error) {
+	state, err := s.TokenRepository.GetAccessTokenState(ctx, tokenID, userID)
+	if s.during != nil {
+		s.during()
+	}
+	return state, err
+}
+
+func TestCachedTokenRepositoryDoesNotCacheStateReadBeforeInvalidation(t *testing.T) {
+	ctx := context.Background()
+	shared := cache.NewMemory()
+	store := &invalidatingStore{TokenRepository: NewTokenRepository(shared)}
+	tokens := NewCachedTokenRepository(store, shared, time.Minute, 10).(*cachedTokenRepository)
+	subscribeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go tokens.Subscribe(subscribeCtx)
+	waitSubscribed(t, tokens, true)
+
+	token := issueAccessToken(t, store.TokenRepository, uuid.New())
+	// The token is deleted by another instance once its state is read, but before it is kept in memory
+	store.during = func() {
+		store.during = nil
+		if err := tokens.DeleteToken(ctx, token.TokenID, token.TokenType); err != nil {
+			t.Errorf("failed to delete token: %v", err)
+		}
+	}
+
+	if state, err := tokens.GetAccessTokenState(ctx, token.TokenID, token.UserID); err != nil || state.Details == nil {
+		t.Fatalf("state read before the deletion: got %+v (error %v), want the live token", state, err)
+	}
+	if state, err := tokens.GetAccessTokenState(ctx, token.TokenID, token.UserID); err != nil || state.Details != nil {
+		t.Errorf("state after the deletion: got %+v (error %v), want no token", state, err)
+	}
+}
@@ -0,0 +1,2142 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/querybudget"
+	"github.com/chats/go-user-api/internal/infrastructure/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/chats/go-user-api/internal/domain/repository"
+
+// repositoryPackage is the path of the package, whose frames are skipped to find the call site of an operation
+const repositoryPackage = tracerName
+
+const (
+	dbSystemMongoDB = "mongodb"
+	dbSystemRedis   = "redis"
+
+	usersCollection    = "users"
+	tokensCollection   = "tokens"
+	settingsCollection = "settings"
+	dedupCollection    = "dedup"
+	quotaCollection    = "quota"
+	usageCollection    = "usage"
+	auditCollection    = "audit_log"
+	rolesCollection    = "roles"
+
+	permissionGroupsCollection  = "permission_groups"
+	organizationsCollection     = "organizations"
+	signingKeysCollection       = "signing_keys"
+	webhookEndpointsCollection  = "webhook_endpoints"
+	webhookDeliveriesCollection = "webhook_deliveries"
+	suppressionsCollection      = "email_suppressions"
+	statusHistoryCollection     = "user_status_history"
+	oidcCollection              = "oidc"
+	deviceCollection            = "device_authorizations"
+	referralCodesCollection     = "referral_codes"
+	referralsCollection         = "referrals"
+	passkeysCollection          = "passkeys"
+	passkeyCeremoniesCollection = "passkey_ceremonies"
+	oauthIdentitiesCollection   = "oauth_identities"
+	oauthStatesCollection       = "oauth_states"
+	directoryReportsCollection  = "directory_reports"
+	lifecycleCollection         = "lifecycle"
+	apiKeysCollection           = "api_keys"
+	inactivityCollection        = "inactivity"
+	serviceAccountsCollection   = "service_accounts"
+	kpiCollection               = "kpi"
+	adminNotesCollection        = "user_admin_notes"
+	permissionsCollection       = "permissions"
+	roleAssignmentsCollection   = "user_roles"
+	roleChangeRequestCollection = "role_change_requests"
+	teamsCollection             = "teams"
+	teamMembersCollection       = "team_members"
+	breakGlassCollection        = "break_glass_accounts"
+	securityEventsCollection    = "security_events"
+	ipDenialsCollection         = "ip_denials"
+	loginCountriesCollection    = "login_countries"
+	selfTestProbesCollection    = "self_test_probes"
+	scimGroupsCollection        = "scim_groups"
+)
+
+// startSpan starts a child span for a repository operation.
+// Within a request, the span is a child of the span of the request. The returned context carries it down to the
+// database driver. Within a request with a query budget, the operation is counted against it when the span ends,
+// along with the call site of the repository.
+func startSpan(ctx context.Context, system, collection, operation string) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(tracing.Context(ctx), collection+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", system),
+			attribute.String("db.collection", collection),
+			attribute.String("db.operation", operation),
+		),
+	)
+
+	budget := querybudget.FromContext(ctx)
+	if budget == nil {
+		return ctx, span
+	}
+	return ctx, &budgetedSpan{
+		Span:      span,
+		budget:    budget,
+		site:      querybudget.CallSite(repositoryPackage),
+		operation: collection + "." + operation,
+		start:     time.Now(),
+	}
+}
+
+// budgetedSpan counts the operation of a span against the query budget of its request when it ends
+type budgetedSpan struct {
+	trace.Span
+	budget    *querybudget.Budget
+	site      string
+	operation string
+	start     time.Time
+}
+
+// End records the operation in the budget and ends the span
+func (s *budgetedSpan) End(options ...trace.SpanEndOption) {
+	s.budget.Record(s.site, s.operation, time.Since(s.start))
+	s.Span.End(options...)
+}
+
+// endSpan records the result of a repository operation and ends its span
+func endSpan(span trace.Span, resultCount int, err error) {
+	if err != nil {
+		resultCount = 0
+	}
+	span.SetAttributes(attribute.Int("db.result_count", resultCount))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// countOf returns 1 for a found entity and 0 otherwise
+func countOf[T any](v *T) int {
+	if v == nil {
+		return 0
+	}
+	return 1
+}
+
+// tracedUserRepository decorates a UserRepository with tracing spans
+type tracedUserRepository struct {
+	next UserRepository
+}
+
+// NewTracedUserRepository wraps a UserRepository so every call is recorded as a span
+func NewTracedUserRepository(next UserRepository) UserRepository {
+	return &tracedUserRepository{next: next}
+}
+
+// Create creates a new user
+func (r *tracedUserRepository) Create(ctx context.Context, user *entity.User) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "create")
+	err := r.next.Create(ctx, user)
+	endSpan(span, 1, err)
+	return err
+}
+
+// CreateMany creates users in a single batch
+func (r *tracedUserRepository) CreateMany(ctx context.Context, users []*entity.User) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "create_many")
+	err := r.next.CreateMany(ctx, users)
+	endSpan(span, len(users), err)
+	return err
+}
+
+// GetByID retrieves a user by ID
+func (r *tracedUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "get_by_id")
+	user, err := r.next.GetByID(ctx, id)
+	endSpan(span, countOf(user), err)
+	return user, err
+}
+
+// GetByEmail retrieves a user by email
+func (r *tracedUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "get_by_email")
+	user, err := r.next.GetByEmail(ctx, email)
+	endSpan(span, countOf(user), err)
+	return user, err
+}
+
+// GetByUsername retrieves a user by username
+func (r *tracedUserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "get_by_username")
+	user, err := r.next.GetByUsername(ctx, username)
+	endSpan(span, countOf(user), err)
+	return user, err
+}
+
+// GetByRecoveryEmail retrieves a user by verified recovery email
+func (r *tracedUserRepository) GetByRecoveryEmail(ctx context.Context, email string) (*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "get_by_recovery_email")
+	user, err := r.next.GetByRecoveryEmail(ctx, email)
+	endSpan(span, countOf(user), err)
+	return user, err
+}
+
+// Update updates user information
+func (r *tracedUserRepository) Update(ctx context.Context, user *entity.User) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "update")
+	err := r.next.Update(ctx, user)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a user
+func (r *tracedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "delete")
+	err := r.next.Delete(ctx, id)
+	endSpan(span, 1, err)
+	return err
+}
+
+// List retrieves a list of users with pagination
+func (r *tracedUserRepository) List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "list")
+	span.SetAttributes(
+		attribute.Int("db.page", page),
+		attribute.Int("db.limit", limit),
+		attribute.String("db.filter.status", opts.Status),
+	)
+	users, total, err := r.next.List(ctx, page, limit, opts)
+	span.SetAttributes(attribute.Int64("db.total", total))
+	endSpan(span, len(users), err)
+	return users, total, err
+}
+
+// ChangePassword changes a user's password
+func (r *tracedUserRepository) ChangePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "change_password")
+	err := r.next.ChangePassword(ctx, id, hashedPassword)
+	endSpan(span, 1, err)
+	return err
+}
+
+// RehashPassword replaces a user's password hash if it is still the current one
+func (r *tracedUserRepository) RehashPassword(ctx context.Context, id uuid.UUID, currentHash, hashedPassword string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "rehash_password")
+	err := r.next.RehashPassword(ctx, id, currentHash, hashedPassword)
+	endSpan(span, 1, err)
+	return err
+}
+
+// UpdateStatus updates a user's status
+func (r *tracedUserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "update_status")
+	err := r.next.UpdateStatus(ctx, id, status)
+	endSpan(span, 1, err)
+	return err
+}
+
+// AddTags adds tags to a user
+func (r *tracedUserRepository) AddTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "add_tags")
+	err := r.next.AddTags(ctx, id, tags)
+	endSpan(span, 1, err)
+	return err
+}
+
+// RemoveTags removes tags from a user
+func (r *tracedUserRepository) RemoveTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "remove_tags")
+	err := r.next.RemoveTags(ctx, id, tags)
+	endSpan(span, 1, err)
+	return err
+}
+
+func (r *tracedUserRepository) ListDueForPurge(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "list_due_for_purge")
+	span.SetAttributes(attribute.Int("db.limit", limit))
+	users, err := r.next.ListDueForPurge(ctx, before, limit)
+	endSpan(span, len(users), err)
+	return users, err
+}
+
+func (r *tracedUserRepository) ListWaitlisted(ctx context.Context, limit int) ([]*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "list_waitlisted")
+	span.SetAttributes(attribute.Int("db.limit", limit))
+	users, err := r.next.ListWaitlisted(ctx, limit)
+	endSpan(span, len(users), err)
+	return users, err
+}
+
+// RecordActivity records the time a user was last active
+func (r *tracedUserRepository) RecordActivity(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "record_activity")
+	err := r.next.RecordActivity(ctx, id, at)
+	endSpan(span, 1, err)
+	return err
+}
+
+func (r *tracedUserRepository) ListCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "list_created_between")
+	span.SetAttributes(attribute.Int("db.limit", limit))
+	users, err := r.next.ListCreatedBetween(ctx, from, to, limit)
+	endSpan(span, len(users), err)
+	return users, err
+}
+
+func (r *tracedUserRepository) ListLastActiveBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "list_last_active_between")
+	span.SetAttributes(attribute.Int("db.limit", limit))
+	users, err := r.next.ListLastActiveBetween(ctx, from, to, limit)
+	endSpan(span, len(users), err)
+	return users, err
+}
+
+func (r *tracedUserRepository) CountByStatusAndRole(ctx context.Context) ([]entity.UserCount, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "count_by_status_and_role")
+	counts, err := r.next.CountByStatusAndRole(ctx)
+	endSpan(span, len(counts), err)
+	return counts, err
+}
+
+func (r *tracedUserRepository) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usersCollection, "count_created_since")
+	total, err := r.next.CountCreatedSince(ctx, since)
+	endSpan(span, 1, err)
+	return total, err
+}
+
+// tracedTokenRepository decorates a TokenRepository with tracing spans
+type tracedTokenRepository struct {
+	next TokenRepository
+}
+
+// NewTracedTokenRepository wraps a TokenRepository so every call is recorded as a span
+func NewTracedTokenRepository(next TokenRepository) TokenRepository {
+	return &tracedTokenRepository{next: next}
+}
+
+// StoreAccessToken stores an access token with expiration
+func (r *tracedTokenRepository) StoreAccessToken(ctx context.Context, details *entity.TokenDetails) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "store_access_token")
+	err := r.next.StoreAccessToken(ctx, details)
+	endSpan(span, 1, err)
+	return err
+}
+
+// StoreRefreshToken stores a refresh token with expiration
+func (r *tracedTokenRepository) StoreRefreshToken(ctx context.Context, details *entity.TokenDetails) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "store_refresh_token")
+	err := r.next.StoreRefreshToken(ctx, details)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetToken retrieves token details by token ID and type
+func (r *tracedTokenRepository) GetToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) (*entity.TokenDetails, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "get_token")
+	span.SetAttributes(attribute.String("token.type", string(tokenType)))
+	details, err := r.next.GetToken(ctx, tokenID, tokenType)
+	endSpan(span, countOf(details), err)
+	return details, err
+}
+
+// GetAccessTokenState retrieves the details, denylisting and user status of an access token
+func (r *tracedTokenRepository) GetAccessTokenState(ctx context.Context, tokenID, userID uuid.UUID) (*entity.TokenState, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "get_access_token_state")
+	state, err := r.next.GetAccessTokenState(ctx, tokenID, userID)
+	endSpan(span, countOf(state), err)
+	return state, err
+}
+
+// DeleteToken deletes a token
+func (r *tracedTokenRepository) DeleteToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "delete_token")
+	span.SetAttributes(attribute.String("token.type", string(tokenType)))
+	err := r.next.DeleteToken(ctx, tokenID, tokenType)
+	endSpan(span, 1, err)
+	return err
+}
+
+// ConsumeRefreshToken marks a refresh token as rotated
+func (r *tracedTokenRepository) ConsumeRefreshToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "consume_refresh_token")
+	consumed, err := r.next.ConsumeRefreshToken(ctx, tokenID, expiration)
+	resultCount := 0
+	if consumed {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return consumed, err
+}
+
+// ExtendAccessToken stores the new expiration of a live access token
+func (r *tracedTokenRepository) ExtendAccessToken(ctx context.Context, details *entity.TokenDetails) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "extend_access_token")
+	extended, err := r.next.ExtendAccessToken(ctx, details)
+	resultCount := 0
+	if extended {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return extended, err
+}
+
+// DeleteSession deletes the access and refresh tokens of a session
+func (r *tracedTokenRepository) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "delete_session")
+	err := r.next.DeleteSession(ctx, sessionID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// GetSession returns the history of a session, nil if unknown or expired
+func (r *tracedTokenRepository) GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "get_session")
+	session, err := r.next.GetSession(ctx, sessionID)
+	endSpan(span, countOf(session), err)
+	return session, err
+}
+
+// RevokeSession deletes the tokens of a session and records the revocation in its history
+func (r *tracedTokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "revoke_session")
+	span.SetAttributes(attribute.String("session.revoked_reason", reason))
+	err := r.next.RevokeSession(ctx, sessionID, reason)
+	endSpan(span, 0, err)
+	return err
+}
+
+// DeleteUserTokens deletes all tokens for a user
+func (r *tracedTokenRepository) DeleteUserTokens(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "delete_user_tokens")
+	err := r.next.DeleteUserTokens(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// ListUserSessions returns the sessions of a user holding a live token
+func (r *tracedTokenRepository) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "list_user_sessions")
+	sessions, err := r.next.ListUserSessions(ctx, userID)
+	endSpan(span, len(sessions), err)
+	return sessions, err
+}
+
+// StoreUserStatus caches the status of a user
+func (r *tracedTokenRepository) StoreUserStatus(ctx context.Context, userID uuid.UUID, status string, expiration time.Duration) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "store_user_status")
+	err := r.next.StoreUserStatus(ctx, userID, status, expiration)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetUserStatus returns the cached status of a user
+func (r *tracedTokenRepository) GetUserStatus(ctx context.Context, userID uuid.UUID) (string, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "get_user_status")
+	status, err := r.next.GetUserStatus(ctx, userID)
+	resultCount := 0
+	if status != "" {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return status, err
+}
+
+// DenyToken denylists a token ID
+func (r *tracedTokenRepository) DenyToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "deny_token")
+	err := r.next.DenyToken(ctx, tokenID, expiration)
+	endSpan(span, 1, err)
+	return err
+}
+
+// IsTokenDenied reports whether a token ID is denylisted
+func (r *tracedTokenRepository) IsTokenDenied(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "is_token_denied")
+	denied, err := r.next.IsTokenDenied(ctx, tokenID)
+	resultCount := 0
+	if denied {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return denied, err
+}
+
+// StoreRevocationCutoff rejects every token issued before the cutoff
+func (r *tracedTokenRepository) StoreRevocationCutoff(ctx context.Context, cutoff time.Time, expiration time.Duration) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "store_revocation_cutoff")
+	err := r.next.StoreRevocationCutoff(ctx, cutoff, expiration)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetRevocationCutoff returns the time before which tokens were issued are rejected
+func (r *tracedTokenRepository) GetRevocationCutoff(ctx context.Context) (time.Time, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "get_revocation_cutoff")
+	cutoff, err := r.next.GetRevocationCutoff(ctx)
+	resultCount := 0
+	if !cutoff.IsZero() {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return cutoff, err
+}
+
+// StoreOneTimeToken stores a single-use token issued to a user for a purpose
+func (r *tracedTokenRepository) StoreOneTimeToken(ctx context.Context, purpose, token string, userID uuid.UUID, expiration time.Duration) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "store_one_time_token")
+	span.SetAttributes(attribute.String("token.purpose", purpose))
+	err := r.next.StoreOneTimeToken(ctx, purpose, token, userID, expiration)
+	endSpan(span, 1, err)
+	return err
+}
+
+// ConsumeOneTimeToken deletes a single-use token and returns the user it was issued to, uuid.Nil if unknown
+func (r *tracedTokenRepository) ConsumeOneTimeToken(ctx context.Context, purpose, token string) (uuid.UUID, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, tokensCollection, "consume_one_time_token")
+	span.SetAttributes(attribute.String("token.purpose", purpose))
+	userID, err := r.next.ConsumeOneTimeToken(ctx, purpose, token)
+	resultCount := 0
+	if userID != uuid.Nil {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return userID, err
+}
+
+// tracedSettingsRepository decorates a SettingsRepository with tracing spans
+type tracedSettingsRepository struct {
+	next SettingsRepository
+}
+
+// NewTracedSettingsRepository wraps a SettingsRepository so every call is recorded as a span
+func NewTracedSettingsRepository(next SettingsRepository) SettingsRepository {
+	return &tracedSettingsRepository{next: next}
+}
+
+// GetSetting retrieves a setting, reporting whether it has been set
+func (r *tracedSettingsRepository) GetSetting(ctx context.Context, key string) (string, bool, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, settingsCollection, "get_setting")
+	span.SetAttributes(attribute.String("setting.key", key))
+	value, found, err := r.next.GetSetting(ctx, key)
+	resultCount := 0
+	if found {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return value, found, err
+}
+
+// SetSetting stores a setting without expiration
+func (r *tracedSettingsRepository) SetSetting(ctx context.Context, key, value string) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, settingsCollection, "set_setting")
+	span.SetAttributes(attribute.String("setting.key", key))
+	err := r.next.SetSetting(ctx, key, value)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedDedupRepository decorates a DedupRepository with tracing spans
+type tracedDedupRepository struct {
+	next DedupRepository
+}
+
+// NewTracedDedupRepository wraps a DedupRepository so every call is recorded as a span
+func NewTracedDedupRepository(next DedupRepository) DedupRepository {
+	return &tracedDedupRepository{next: next}
+}
+
+// Claim claims a key of a scope for the given window, reporting false if it is already claimed
+func (r *tracedDedupRepository) Claim(ctx context.Context, scope, key string, window time.Duration) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, dedupCollection, "claim")
+	span.SetAttributes(attribute.String("dedup.scope", scope))
+	claimed, err := r.next.Claim(ctx, scope, key, window)
+	resultCount := 0
+	if claimed {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return claimed, err
+}
+
+// Release releases a claim before its window ends
+func (r *tracedDedupRepository) Release(ctx context.Context, scope, key string) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, dedupCollection, "release")
+	span.SetAttributes(attribute.String("dedup.scope", scope))
+	err := r.next.Release(ctx, scope, key)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedQuotaRepository decorates a QuotaRepository with tracing spans
+type tracedQuotaRepository struct {
+	next QuotaRepository
+}
+
+// NewTracedQuotaRepository wraps a QuotaRepository so every call is recorded as a span
+func NewTracedQuotaRepository(next QuotaRepository) QuotaRepository {
+	return &tracedQuotaRepository{next: next}
+}
+
+// Increment counts a request of a subject in a window and returns the requests counted in it
+func (r *tracedQuotaRepository) Increment(ctx context.Context, subject, window string, expiration time.Duration) (int64, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, quotaCollection, "increment")
+	span.SetAttributes(attribute.String("quota.window", window))
+	count, err := r.next.Increment(ctx, subject, window, expiration)
+	endSpan(span, 1, err)
+	return count, err
+}
+
+// Get returns the requests of a subject counted in a window, 0 if none
+func (r *tracedQuotaRepository) Get(ctx context.Context, subject, window string) (int64, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, quotaCollection, "get")
+	span.SetAttributes(attribute.String("quota.window", window))
+	count, err := r.next.Get(ctx, subject, window)
+	endSpan(span, 1, err)
+	return count, err
+}
+
+// tracedOIDCRepository decorates an OIDCRepository with tracing spans
+type tracedOIDCRepository struct {
+	next OIDCRepository
+}
+
+// NewTracedOIDCRepository wraps an OIDCRepository so every call is recorded as a span
+func NewTracedOIDCRepository(next OIDCRepository) OIDCRepository {
+	return &tracedOIDCRepository{next: next}
+}
+
+// StoreCode stores the grant an authorization code was issued for
+func (r *tracedOIDCRepository) StoreCode(ctx context.Context, code string, grant *entity.AuthorizationGrant, expiration time.Duration) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, oidcCollection, "store_code")
+	err := r.next.StoreCode(ctx, code, grant, expiration)
+	endSpan(span, 1, err)
+	return err
+}
+
+// ConsumeCode deletes an authorization code and returns its grant, nil if unknown, expired or already consumed
+func (r *tracedOIDCRepository) ConsumeCode(ctx context.Context, code string) (*entity.AuthorizationGrant, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, oidcCollection, "consume_code")
+	grant, err := r.next.ConsumeCode(ctx, code)
+	resultCount := 0
+	if grant != nil {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return grant, err
+}
+
+// StoreSessionGrant stores the grant the tokens of a session were issued for
+func (r *tracedOIDCRepository) StoreSessionGrant(ctx context.Context, sessionID uuid.UUID, grant *entity.AuthorizationGrant, expiration time.Duration) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, oidcCollection, "store_session_grant")
+	err := r.next.StoreSessionGrant(ctx, sessionID, grant, expiration)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetSessionGrant returns the grant the tokens of a session were issued for, nil if the session was not issued by the provider
+func (r *tracedOIDCRepository) GetSessionGrant(ctx context.Context, sessionID uuid.UUID) (*entity.AuthorizationGrant, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, oidcCollection, "get_session_grant")
+	grant, err := r.next.GetSessionGrant(ctx, sessionID)
+	resultCount := 0
+	if grant != nil {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return grant, err
+}
+
+// tracedDeviceAuthorizationRepository decorates a DeviceAuthorizationRepository with tracing spans
+type tracedDeviceAuthorizationRepository struct {
+	next DeviceAuthorizationRepository
+}
+
+// NewTracedDeviceAuthorizationRepository wraps a DeviceAuthorizationRepository so every call is recorded as a span
+func NewTracedDeviceAuthorizationRepository(next DeviceAuthorizationRepository) DeviceAuthorizationRepository {
+	return &tracedDeviceAuthorizationRepository{next: next}
+}
+
+// Create stores a new device authorization of a device code until it expires, reporting false if its user code is already used
+func (r *tracedDeviceAuthorizationRepository) Create(ctx context.Context, deviceCode string, authorization *entity.DeviceAuthorization) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, deviceCollection, "create")
+	created, err := r.next.Create(ctx, deviceCode, authorization)
+	resultCount := 0
+	if created {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return created, err
+}
+
+// GetByDeviceCode returns the device authorization of a device code, nil if unknown or expired
+func (r *tracedDeviceAuthorizationRepository) GetByDeviceCode(ctx context.Context, deviceCode string) (*entity.DeviceAuthorization, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, deviceCollection, "get_by_device_code")
+	authorization, err := r.next.GetByDeviceCode(ctx, deviceCode)
+	resultCount := 0
+	if authorization != nil {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return authorization, err
+}
+
+// GetByUserCode returns the device authorization of a user code, nil if unknown or expired
+func (r *tracedDeviceAuthorizationRepository) GetByUserCode(ctx context.Context, userCode string) (*entity.DeviceAuthorization, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, deviceCollection, "get_by_user_code")
+	authorization, err := r.next.GetByUserCode(ctx, userCode)
+	resultCount := 0
+	if authorization != nil {
+		resultCount = 1
+	}
+	endSpan(span, resultCount, err)
+	return authorization, err
+}
+
+// Update stores the changes of a device authorization, keeping its expiration
+func (r *tracedDeviceAuthorizationRepository) Update(ctx context.Context, authorization *entity.DeviceAuthorization) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, deviceCollection, "update")
+	err := r.next.Update(ctx, authorization)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a device authorization and its user code
+func (r *tracedDeviceAuthorizationRepository) Delete(ctx context.Context, authorization *entity.DeviceAuthorization) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, deviceCollection, "delete")
+	err := r.next.Delete(ctx, authorization)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedUsageRepository decorates a UsageRepository with tracing spans
+type tracedUsageRepository struct {
+	next UsageRepository
+}
+
+// NewTracedUsageRepository wraps a UsageRepository so every call is recorded as a span
+func NewTracedUsageRepository(next UsageRepository) UsageRepository {
+	return &tracedUsageRepository{next: next}
+}
+
+// Add adds the counts of the records to the stored aggregates
+func (r *tracedUsageRepository) Add(ctx context.Context, records []*entity.UsageRecord) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usageCollection, "add")
+	err := r.next.Add(ctx, records)
+	endSpan(span, len(records), err)
+	return err
+}
+
+// ListByPeriod lists the aggregates of a period
+func (r *tracedUsageRepository) ListByPeriod(ctx context.Context, period string) ([]*entity.UsageRecord, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, usageCollection, "list_by_period")
+	span.SetAttributes(attribute.String("usage.period", period))
+	records, err := r.next.ListByPeriod(ctx, period)
+	endSpan(span, len(records), err)
+	return records, err
+}
+
+// tracedAuditRepository decorates an AuditRepository with tracing spans
+type tracedAuditRepository struct {
+	next AuditRepository
+}
+
+// NewTracedAuditRepository wraps an AuditRepository so every call is recorded as a span
+func NewTracedAuditRepository(next AuditRepository) AuditRepository {
+	return &tracedAuditRepository{next: next}
+}
+
+// Create records an audit entry
+func (r *tracedAuditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, auditCollection, "create")
+	span.SetAttributes(attribute.String("audit.action", entry.Action))
+	err := r.next.Create(ctx, entry)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedRoleRepository decorates a RoleRepository with tracing spans
+type tracedRoleRepository struct {
+	next RoleRepository
+}
+
+// NewTracedRoleRepository wraps a RoleRepository so every call is recorded as a span
+func NewTracedRoleRepository(next RoleRepository) RoleRepository {
+	return &tracedRoleRepository{next: next}
+}
+
+// Create creates a new role
+func (r *tracedRoleRepository) Create(ctx context.Context, role *entity.Role) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, rolesCollection, "create")
+	err := r.next.Create(ctx, role)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByName retrieves a role by name
+func (r *tracedRoleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, rolesCollection, "get_by_name")
+	role, err := r.next.GetByName(ctx, name)
+	endSpan(span, countOf(role), err)
+	return role, err
+}
+
+// List lists all roles
+func (r *tracedRoleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, rolesCollection, "list")
+	roles, err := r.next.List(ctx)
+	endSpan(span, len(roles), err)
+	return roles, err
+}
+
+// Update updates a role
+func (r *tracedRoleRepository) Update(ctx context.Context, role *entity.Role) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, rolesCollection, "update")
+	err := r.next.Update(ctx, role)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a role
+func (r *tracedRoleRepository) Delete(ctx context.Context, name string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, rolesCollection, "delete")
+	err := r.next.Delete(ctx, name)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedPermissionGroupRepository decorates a PermissionGroupRepository with tracing spans
+type tracedPermissionGroupRepository struct {
+	next PermissionGroupRepository
+}
+
+// NewTracedPermissionGroupRepository wraps a PermissionGroupRepository so every call is recorded as a span
+func NewTracedPermissionGroupRepository(next PermissionGroupRepository) PermissionGroupRepository {
+	return &tracedPermissionGroupRepository{next: next}
+}
+
+// Create creates a new permission group
+func (r *tracedPermissionGroupRepository) Create(ctx context.Context, group *entity.PermissionGroup) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionGroupsCollection, "create")
+	err := r.next.Create(ctx, group)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByName retrieves a permission group by name
+func (r *tracedPermissionGroupRepository) GetByName(ctx context.Context, name string) (*entity.PermissionGroup, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionGroupsCollection, "get_by_name")
+	group, err := r.next.GetByName(ctx, name)
+	endSpan(span, countOf(group), err)
+	return group, err
+}
+
+// List lists all permission groups
+func (r *tracedPermissionGroupRepository) List(ctx context.Context) ([]*entity.PermissionGroup, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionGroupsCollection, "list")
+	groups, err := r.next.List(ctx)
+	endSpan(span, len(groups), err)
+	return groups, err
+}
+
+// Update updates a permission group
+func (r *tracedPermissionGroupRepository) Update(ctx context.Context, group *entity.PermissionGroup) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionGroupsCollection, "update")
+	err := r.next.Update(ctx, group)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a permission group
+func (r *tracedPermissionGroupRepository) Delete(ctx context.Context, name string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionGroupsCollection, "delete")
+	err := r.next.Delete(ctx, name)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedOrganizationRepository decorates an OrganizationRepository with tracing spans
+type tracedOrganizationRepository struct {
+	next OrganizationRepository
+}
+
+// NewTracedOrganizationRepository wraps an OrganizationRepository so every call is recorded as a span
+func NewTracedOrganizationRepository(next OrganizationRepository) OrganizationRepository {
+	return &tracedOrganizationRepository{next: next}
+}
+
+// Create creates a new organization
+func (r *tracedOrganizationRepository) Create(ctx context.Context, org *entity.Organization) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, organizationsCollection, "create")
+	err := r.next.Create(ctx, org)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByID retrieves an organization by ID
+func (r *tracedOrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, organizationsCollection, "get_by_id")
+	org, err := r.next.GetByID(ctx, id)
+	endSpan(span, countOf(org), err)
+	return org, err
+}
+
+// List lists all organizations
+func (r *tracedOrganizationRepository) List(ctx context.Context) ([]*entity.Organization, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, organizationsCollection, "list")
+	orgs, err := r.next.List(ctx)
+	endSpan(span, len(orgs), err)
+	return orgs, err
+}
+
+// Update updates the settings of an organization
+func (r *tracedOrganizationRepository) Update(ctx context.Context, org *entity.Organization) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, organizationsCollection, "update")
+	err := r.next.Update(ctx, org)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedSigningKeyRepository decorates a SigningKeyRepository with tracing spans
+type tracedSigningKeyRepository struct {
+	next SigningKeyRepository
+}
+
+// NewTracedSigningKeyRepository wraps a SigningKeyRepository so every call is recorded as a span
+func NewTracedSigningKeyRepository(next SigningKeyRepository) SigningKeyRepository {
+	return &tracedSigningKeyRepository{next: next}
+}
+
+// Create stores a new signing key
+func (r *tracedSigningKeyRepository) Create(ctx context.Context, key *entity.SigningKey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, signingKeysCollection, "create")
+	err := r.next.Create(ctx, key)
+	endSpan(span, 1, err)
+	return err
+}
+
+// List returns all signing keys, oldest first
+func (r *tracedSigningKeyRepository) List(ctx context.Context) ([]*entity.SigningKey, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, signingKeysCollection, "list")
+	keys, err := r.next.List(ctx)
+	endSpan(span, len(keys), err)
+	return keys, err
+}
+
+// tracedWebhookRepository decorates a WebhookRepository with tracing spans
+type tracedWebhookRepository struct {
+	next WebhookRepository
+}
+
+// NewTracedWebhookRepository wraps a WebhookRepository so every call is recorded as a span
+func NewTracedWebhookRepository(next WebhookRepository) WebhookRepository {
+	return &tracedWebhookRepository{next: next}
+}
+
+// CreateEndpoint creates a new webhook endpoint
+func (r *tracedWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookEndpointsCollection, "create")
+	err := r.next.CreateEndpoint(ctx, endpoint)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetEndpoint retrieves a webhook endpoint by ID
+func (r *tracedWebhookRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookEndpointsCollection, "get_by_id")
+	endpoint, err := r.next.GetEndpoint(ctx, id)
+	endSpan(span, countOf(endpoint), err)
+	return endpoint, err
+}
+
+// ListEndpoints lists all webhook endpoints
+func (r *tracedWebhookRepository) ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookEndpointsCollection, "list")
+	endpoints, err := r.next.ListEndpoints(ctx)
+	endSpan(span, len(endpoints), err)
+	return endpoints, err
+}
+
+// UpdateEndpoint updates a webhook endpoint
+func (r *tracedWebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookEndpointsCollection, "update")
+	err := r.next.UpdateEndpoint(ctx, endpoint)
+	endSpan(span, 1, err)
+	return err
+}
+
+// DeleteEndpoint deletes a webhook endpoint along with its deliveries
+func (r *tracedWebhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookEndpointsCollection, "delete")
+	err := r.next.DeleteEndpoint(ctx, id)
+	endSpan(span, 1, err)
+	return err
+}
+
+// CreateDelivery creates a new webhook delivery
+func (r *tracedWebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookDeliveriesCollection, "create")
+	err := r.next.CreateDelivery(ctx, delivery)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetDelivery retrieves a webhook delivery by ID
+func (r *tracedWebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookDeliveriesCollection, "get_by_id")
+	delivery, err := r.next.GetDelivery(ctx, id)
+	endSpan(span, countOf(delivery), err)
+	return delivery, err
+}
+
+// UpdateDelivery updates a webhook delivery
+func (r *tracedWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookDeliveriesCollection, "update")
+	err := r.next.UpdateDelivery(ctx, delivery)
+	endSpan(span, 1, err)
+	return err
+}
+
+// ListDeliveries lists the deliveries matching a filter
+func (r *tracedWebhookRepository) ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookDeliveriesCollection, "list")
+	deliveries, err := r.next.ListDeliveries(ctx, filter)
+	endSpan(span, len(deliveries), err)
+	return deliveries, err
+}
+
+// ListDueDeliveries lists the pending deliveries due at a time
+func (r *tracedWebhookRepository) ListDueDeliveries(ctx context.Context, at time.Time, excludedEndpoints []uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, webhookDeliveriesCollection, "list_due")
+	deliveries, err := r.next.ListDueDeliveries(ctx, at, excludedEndpoints, limit)
+	endSpan(span, len(deliveries), err)
+	return deliveries, err
+}
+
+// tracedSuppressionRepository decorates a SuppressionRepository with tracing spans
+type tracedSuppressionRepository struct {
+	next SuppressionRepository
+}
+
+// NewTracedSuppressionRepository wraps a SuppressionRepository so every call is recorded as a span
+func NewTracedSuppressionRepository(next SuppressionRepository) SuppressionRepository {
+	return &tracedSuppressionRepository{next: next}
+}
+
+// Add suppresses an address
+func (r *tracedSuppressionRepository) Add(ctx context.Context, suppression *entity.EmailSuppression) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, suppressionsCollection, "upsert")
+	err := r.next.Add(ctx, suppression)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Get retrieves the suppression of an address
+func (r *tracedSuppressionRepository) Get(ctx context.Context, email string) (*entity.EmailSuppression, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, suppressionsCollection, "get_by_id")
+	suppression, err := r.next.Get(ctx, email)
+	endSpan(span, countOf(suppression), err)
+	return suppression, err
+}
+
+// List lists all suppressions
+func (r *tracedSuppressionRepository) List(ctx context.Context) ([]*entity.EmailSuppression, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, suppressionsCollection, "list")
+	suppressions, err := r.next.List(ctx)
+	endSpan(span, len(suppressions), err)
+	return suppressions, err
+}
+
+// Remove removes the suppression of an address
+func (r *tracedSuppressionRepository) Remove(ctx context.Context, email string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, suppressionsCollection, "delete")
+	err := r.next.Remove(ctx, email)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedStatusHistoryRepository decorates a StatusHistoryRepository with tracing spans
+type tracedStatusHistoryRepository struct {
+	next StatusHistoryRepository
+}
+
+// NewTracedStatusHistoryRepository wraps a StatusHistoryRepository so every call is recorded as a span
+func NewTracedStatusHistoryRepository(next StatusHistoryRepository) StatusHistoryRepository {
+	return &tracedStatusHistoryRepository{next: next}
+}
+
+// Create records a status change
+func (r *tracedStatusHistoryRepository) Create(ctx context.Context, change *entity.StatusChange) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, statusHistoryCollection, "create")
+	err := r.next.Create(ctx, change)
+	endSpan(span, 1, err)
+	return err
+}
+
+// ListByUser lists the status changes of a user
+func (r *tracedStatusHistoryRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.StatusChange, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, statusHistoryCollection, "list")
+	changes, err := r.next.ListByUser(ctx, userID)
+	endSpan(span, len(changes), err)
+	return changes, err
+}
+
+// tracedReferralRepository decorates a ReferralRepository with tracing spans
+type tracedReferralRepository struct {
+	next ReferralRepository
+}
+
+// NewTracedReferralRepository wraps a ReferralRepository so every call is recorded as a span
+func NewTracedReferralRepository(next ReferralRepository) ReferralRepository {
+	return &tracedReferralRepository{next: next}
+}
+
+// CreateCode stores a referral code
+func (r *tracedReferralRepository) CreateCode(ctx context.Context, code *entity.ReferralCode) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, referralCodesCollection, "insert")
+	created, err := r.next.CreateCode(ctx, code)
+	endSpan(span, 1, err)
+	return created, err
+}
+
+// GetCode retrieves a referral code
+func (r *tracedReferralRepository) GetCode(ctx context.Context, code string) (*entity.ReferralCode, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, referralCodesCollection, "get_by_id")
+	referralCode, err := r.next.GetCode(ctx, code)
+	endSpan(span, countOf(referralCode), err)
+	return referralCode, err
+}
+
+// GetCodeByUser retrieves the referral code of a user
+func (r *tracedReferralRepository) GetCodeByUser(ctx context.Context, userID uuid.UUID) (*entity.ReferralCode, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, referralCodesCollection, "get_by_user")
+	referralCode, err := r.next.GetCodeByUser(ctx, userID)
+	endSpan(span, countOf(referralCode), err)
+	return referralCode, err
+}
+
+// Create records a referral
+func (r *tracedReferralRepository) Create(ctx context.Context, referral *entity.Referral) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, referralsCollection, "insert")
+	err := r.next.Create(ctx, referral)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetStats summarizes the referrals of a user
+func (r *tracedReferralRepository) GetStats(ctx context.Context, referrerID uuid.UUID) (*entity.ReferralStats, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, referralsCollection, "aggregate")
+	stats, err := r.next.GetStats(ctx, referrerID)
+	endSpan(span, countOf(stats), err)
+	return stats, err
+}
+
+// Leaderboard ranks the users by referrals
+func (r *tracedReferralRepository) Leaderboard(ctx context.Context, limit int) ([]*entity.ReferrerRanking, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, referralsCollection, "aggregate")
+	rankings, err := r.next.Leaderboard(ctx, limit)
+	endSpan(span, len(rankings), err)
+	return rankings, err
+}
+
+// tracedPasskeyRepository decorates a PasskeyRepository with tracing spans
+type tracedPasskeyRepository struct {
+	next PasskeyRepository
+}
+
+// NewTracedPasskeyRepository wraps a PasskeyRepository so every call is recorded as a span
+func NewTracedPasskeyRepository(next PasskeyRepository) PasskeyRepository {
+	return &tracedPasskeyRepository{next: next}
+}
+
+// Create stores a new passkey
+func (r *tracedPasskeyRepository) Create(ctx context.Context, passkey *entity.Passkey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, passkeysCollection, "insert")
+	err := r.next.Create(ctx, passkey)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByID retrieves a passkey by credential ID
+func (r *tracedPasskeyRepository) GetByID(ctx context.Context, id string) (*entity.Passkey, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, passkeysCollection, "get_by_id")
+	passkey, err := r.next.GetByID(ctx, id)
+	endSpan(span, countOf(passkey), err)
+	return passkey, err
+}
+
+// ListByUser retrieves the passkeys of a user
+func (r *tracedPasskeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Passkey, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, passkeysCollection, "list_by_user")
+	passkeys, err := r.next.ListByUser(ctx, userID)
+	endSpan(span, len(passkeys), err)
+	return passkeys, err
+}
+
+// UpdateUsage stores the signature counter and last use time of a passkey
+func (r *tracedPasskeyRepository) UpdateUsage(ctx context.Context, passkey *entity.Passkey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, passkeysCollection, "update_usage")
+	err := r.next.UpdateUsage(ctx, passkey)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a passkey
+func (r *tracedPasskeyRepository) Delete(ctx context.Context, passkey *entity.Passkey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, passkeysCollection, "delete")
+	err := r.next.Delete(ctx, passkey)
+	endSpan(span, 1, err)
+	return err
+}
+
+// DeleteByUser deletes the passkeys of a user
+func (r *tracedPasskeyRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, passkeysCollection, "delete_by_user")
+	err := r.next.DeleteByUser(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// CountUsers counts the users holding at least one passkey
+func (r *tracedPasskeyRepository) CountUsers(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, passkeysCollection, "count_users")
+	total, err := r.next.CountUsers(ctx)
+	endSpan(span, 1, err)
+	return total, err
+}
+
+// tracedPasskeyCeremonyRepository decorates a PasskeyCeremonyRepository with tracing spans
+type tracedPasskeyCeremonyRepository struct {
+	next PasskeyCeremonyRepository
+}
+
+// NewTracedPasskeyCeremonyRepository wraps a PasskeyCeremonyRepository so every call is recorded as a span
+func NewTracedPasskeyCeremonyRepository(next PasskeyCeremonyRepository) PasskeyCeremonyRepository {
+	return &tracedPasskeyCeremonyRepository{next: next}
+}
+
+// Store stores a ceremony until it expires
+func (r *tracedPasskeyCeremonyRepository) Store(ctx context.Context, ceremony *entity.PasskeyCeremony) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, passkeyCeremoniesCollection, "store")
+	err := r.next.Store(ctx, ceremony)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Consume deletes a ceremony and returns it, nil if unknown, expired or already consumed
+func (r *tracedPasskeyCeremonyRepository) Consume(ctx context.Context, id uuid.UUID) (*entity.PasskeyCeremony, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, passkeyCeremoniesCollection, "consume")
+	ceremony, err := r.next.Consume(ctx, id)
+	endSpan(span, countOf(ceremony), err)
+	return ceremony, err
+}
+
+// tracedOAuthIdentityRepository decorates an OAuthIdentityRepository with tracing spans
+type tracedOAuthIdentityRepository struct {
+	next OAuthIdentityRepository
+}
+
+// NewTracedOAuthIdentityRepository wraps an OAuthIdentityRepository so every call is recorded as a span
+func NewTracedOAuthIdentityRepository(next OAuthIdentityRepository) OAuthIdentityRepository {
+	return &tracedOAuthIdentityRepository{next: next}
+}
+
+// Create stores a new identity
+func (r *tracedOAuthIdentityRepository) Create(ctx context.Context, identity *entity.OAuthIdentity) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, oauthIdentitiesCollection, "insert")
+	err := r.next.Create(ctx, identity)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Get retrieves the identity of an account at a provider
+func (r *tracedOAuthIdentityRepository) Get(ctx context.Context, provider, subject string) (*entity.OAuthIdentity, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, oauthIdentitiesCollection, "get")
+	identity, err := r.next.Get(ctx, provider, subject)
+	endSpan(span, countOf(identity), err)
+	return identity, err
+}
+
+// ListByUser retrieves the identities of a user
+func (r *tracedOAuthIdentityRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.OAuthIdentity, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, oauthIdentitiesCollection, "list_by_user")
+	identities, err := r.next.ListByUser(ctx, userID)
+	endSpan(span, len(identities), err)
+	return identities, err
+}
+
+// Delete deletes an identity
+func (r *tracedOAuthIdentityRepository) Delete(ctx context.Context, identity *entity.OAuthIdentity) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, oauthIdentitiesCollection, "delete")
+	err := r.next.Delete(ctx, identity)
+	endSpan(span, 1, err)
+	return err
+}
+
+// DeleteByUser deletes the identities of a user
+func (r *tracedOAuthIdentityRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, oauthIdentitiesCollection, "delete_by_user")
+	err := r.next.DeleteByUser(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// tracedOAuthStateRepository decorates an OAuthStateRepository with tracing spans
+type tracedOAuthStateRepository struct {
+	next OAuthStateRepository
+}
+
+// NewTracedOAuthStateRepository wraps an OAuthStateRepository so every call is recorded as a span
+func NewTracedOAuthStateRepository(next OAuthStateRepository) OAuthStateRepository {
+	return &tracedOAuthStateRepository{next: next}
+}
+
+// Store stores a state until it expires
+func (r *tracedOAuthStateRepository) Store(ctx context.Context, state *entity.OAuthState) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, oauthStatesCollection, "store")
+	err := r.next.Store(ctx, state)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Consume deletes a state and returns it, nil if unknown, expired or already consumed
+func (r *tracedOAuthStateRepository) Consume(ctx context.Context, state string) (*entity.OAuthState, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, oauthStatesCollection, "consume")
+	pending, err := r.next.Consume(ctx, state)
+	endSpan(span, countOf(pending), err)
+	return pending, err
+}
+
+// tracedDirectoryReportRepository decorates a DirectoryReportRepository with tracing spans
+type tracedDirectoryReportRepository struct {
+	next DirectoryReportRepository
+}
+
+// NewTracedDirectoryReportRepository wraps a DirectoryReportRepository so every call is recorded as a span
+func NewTracedDirectoryReportRepository(next DirectoryReportRepository) DirectoryReportRepository {
+	return &tracedDirectoryReportRepository{next: next}
+}
+
+// Store replaces the latest report
+func (r *tracedDirectoryReportRepository) Store(ctx context.Context, report *entity.DirectoryDriftReport) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, directoryReportsCollection, "store")
+	err := r.next.Store(ctx, report)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Latest returns the latest report, nil if no reconciliation ran yet
+func (r *tracedDirectoryReportRepository) Latest(ctx context.Context) (*entity.DirectoryDriftReport, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, directoryReportsCollection, "latest")
+	report, err := r.next.Latest(ctx)
+	endSpan(span, countOf(report), err)
+	return report, err
+}
+
+// tracedLifecycleRepository decorates a LifecycleRepository with tracing spans
+type tracedLifecycleRepository struct {
+	next LifecycleRepository
+}
+
+// NewTracedLifecycleRepository wraps a LifecycleRepository so every call is recorded as a span
+func NewTracedLifecycleRepository(next LifecycleRepository) LifecycleRepository {
+	return &tracedLifecycleRepository{next: next}
+}
+
+// Cursor returns the time up to which a rule went over the users
+func (r *tracedLifecycleRepository) Cursor(ctx context.Context, rule string) (time.Time, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, lifecycleCollection, "cursor")
+	at, err := r.next.Cursor(ctx, rule)
+	endSpan(span, 1, err)
+	return at, err
+}
+
+// SetCursor records the time up to which a rule went over the users
+func (r *tracedLifecycleRepository) SetCursor(ctx context.Context, rule string, at time.Time) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, lifecycleCollection, "set_cursor")
+	err := r.next.SetCursor(ctx, rule, at)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedAPIKeyRepository decorates an APIKeyRepository with tracing spans
+type tracedAPIKeyRepository struct {
+	next APIKeyRepository
+}
+
+// NewTracedAPIKeyRepository wraps an APIKeyRepository so every call is recorded as a span
+func NewTracedAPIKeyRepository(next APIKeyRepository) APIKeyRepository {
+	return &tracedAPIKeyRepository{next: next}
+}
+
+// Create stores a new API key
+func (r *tracedAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, apiKeysCollection, "insert")
+	err := r.next.Create(ctx, key)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByHash retrieves an API key by key hash
+func (r *tracedAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, apiKeysCollection, "get_by_hash")
+	key, err := r.next.GetByHash(ctx, hash)
+	endSpan(span, countOf(key), err)
+	return key, err
+}
+
+// ListByUser retrieves the API keys of a user
+func (r *tracedAPIKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, apiKeysCollection, "list_by_user")
+	keys, err := r.next.ListByUser(ctx, userID)
+	endSpan(span, len(keys), err)
+	return keys, err
+}
+
+// ListByOrg retrieves the API keys of an organization
+func (r *tracedAPIKeyRepository) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, apiKeysCollection, "list_by_org")
+	keys, err := r.next.ListByOrg(ctx, orgID)
+	endSpan(span, len(keys), err)
+	return keys, err
+}
+
+// UpdateUsage stores the last use time of an API key
+func (r *tracedAPIKeyRepository) UpdateUsage(ctx context.Context, key *entity.APIKey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, apiKeysCollection, "update_usage")
+	err := r.next.UpdateUsage(ctx, key)
+	endSpan(span, 1, err)
+	return err
+}
+
+// UpdateQuota stores the quota of an API key
+func (r *tracedAPIKeyRepository) UpdateQuota(ctx context.Context, key *entity.APIKey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, apiKeysCollection, "update_quota")
+	err := r.next.UpdateQuota(ctx, key)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes an API key
+func (r *tracedAPIKeyRepository) Delete(ctx context.Context, key *entity.APIKey) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, apiKeysCollection, "delete")
+	err := r.next.Delete(ctx, key)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedInactivityRepository decorates an InactivityRepository with tracing spans
+type tracedInactivityRepository struct {
+	next InactivityRepository
+}
+
+// NewTracedInactivityRepository wraps an InactivityRepository so every call is recorded as a span
+func NewTracedInactivityRepository(next InactivityRepository) InactivityRepository {
+	return &tracedInactivityRepository{next: next}
+}
+
+// WarnedAt returns the time a user inactive since a time was warned
+func (r *tracedInactivityRepository) WarnedAt(ctx context.Context, userID uuid.UUID, inactiveSince time.Time) (time.Time, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, inactivityCollection, "warned_at")
+	at, err := r.next.WarnedAt(ctx, userID, inactiveSince)
+	endSpan(span, 1, err)
+	return at, err
+}
+
+// SetWarnedAt records the time a user inactive since a time was warned
+func (r *tracedInactivityRepository) SetWarnedAt(ctx context.Context, userID uuid.UUID, inactiveSince, at time.Time, retention time.Duration) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, inactivityCollection, "set_warned_at")
+	err := r.next.SetWarnedAt(ctx, userID, inactiveSince, at, retention)
+	endSpan(span, 1, err)
+	return err
+}
+
+// StoreReport replaces the latest report
+func (r *tracedInactivityRepository) StoreReport(ctx context.Context, report *entity.InactivityReport) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, inactivityCollection, "store_report")
+	err := r.next.StoreReport(ctx, report)
+	endSpan(span, 1, err)
+	return err
+}
+
+// LatestReport returns the latest report
+func (r *tracedInactivityRepository) LatestReport(ctx context.Context) (*entity.InactivityReport, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, inactivityCollection, "latest_report")
+	report, err := r.next.LatestReport(ctx)
+	endSpan(span, countOf(report), err)
+	return report, err
+}
+
+// tracedServiceAccountRepository decorates a ServiceAccountRepository with tracing spans
+type tracedServiceAccountRepository struct {
+	next ServiceAccountRepository
+}
+
+// NewTracedServiceAccountRepository wraps a ServiceAccountRepository so every call is recorded as a span
+func NewTracedServiceAccountRepository(next ServiceAccountRepository) ServiceAccountRepository {
+	return &tracedServiceAccountRepository{next: next}
+}
+
+// Create stores a new service account
+func (r *tracedServiceAccountRepository) Create(ctx context.Context, account *entity.ServiceAccount) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, serviceAccountsCollection, "insert")
+	err := r.next.Create(ctx, account)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByID retrieves a service account by ID
+func (r *tracedServiceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ServiceAccount, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, serviceAccountsCollection, "get_by_id")
+	account, err := r.next.GetByID(ctx, id)
+	endSpan(span, countOf(account), err)
+	return account, err
+}
+
+// GetByClientID retrieves a service account by client ID
+func (r *tracedServiceAccountRepository) GetByClientID(ctx context.Context, clientID string) (*entity.ServiceAccount, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, serviceAccountsCollection, "get_by_client_id")
+	account, err := r.next.GetByClientID(ctx, clientID)
+	endSpan(span, countOf(account), err)
+	return account, err
+}
+
+// List retrieves the service accounts
+func (r *tracedServiceAccountRepository) List(ctx context.Context) ([]*entity.ServiceAccount, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, serviceAccountsCollection, "list")
+	accounts, err := r.next.List(ctx)
+	endSpan(span, len(accounts), err)
+	return accounts, err
+}
+
+// Update replaces a service account
+func (r *tracedServiceAccountRepository) Update(ctx context.Context, account *entity.ServiceAccount) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, serviceAccountsCollection, "update")
+	err := r.next.Update(ctx, account)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a service account
+func (r *tracedServiceAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, serviceAccountsCollection, "delete")
+	err := r.next.Delete(ctx, id)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedKPIRepository decorates a KPIRepository with tracing spans
+type tracedKPIRepository struct {
+	next KPIRepository
+}
+
+// NewTracedKPIRepository wraps a KPIRepository so every call is recorded as a span
+func NewTracedKPIRepository(next KPIRepository) KPIRepository {
+	return &tracedKPIRepository{next: next}
+}
+
+// StoreSnapshot replaces the latest snapshot
+func (r *tracedKPIRepository) StoreSnapshot(ctx context.Context, snapshot *entity.KPISnapshot) error {
+	ctx, span := startSpan(ctx, dbSystemRedis, kpiCollection, "store_snapshot")
+	err := r.next.StoreSnapshot(ctx, snapshot)
+	endSpan(span, 1, err)
+	return err
+}
+
+// LatestSnapshot returns the latest snapshot
+func (r *tracedKPIRepository) LatestSnapshot(ctx context.Context) (*entity.KPISnapshot, error) {
+	ctx, span := startSpan(ctx, dbSystemRedis, kpiCollection, "latest_snapshot")
+	snapshot, err := r.next.LatestSnapshot(ctx)
+	endSpan(span, countOf(snapshot), err)
+	return snapshot, err
+}
+
+// tracedAdminNoteRepository decorates an AdminNoteRepository with tracing spans
+type tracedAdminNoteRepository struct {
+	next AdminNoteRepository
+}
+
+// NewTracedAdminNoteRepository wraps an AdminNoteRepository so every call is recorded as a span
+func NewTracedAdminNoteRepository(next AdminNoteRepository) AdminNoteRepository {
+	return &tracedAdminNoteRepository{next: next}
+}
+
+// Create stores a new note
+func (r *tracedAdminNoteRepository) Create(ctx context.Context, note *entity.AdminNote) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, adminNotesCollection, "create")
+	err := r.next.Create(ctx, note)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByID returns a note
+func (r *tracedAdminNoteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AdminNote, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, adminNotesCollection, "get_by_id")
+	note, err := r.next.GetByID(ctx, id)
+	endSpan(span, countOf(note), err)
+	return note, err
+}
+
+// ListByUser lists the notes on a user
+func (r *tracedAdminNoteRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.AdminNote, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, adminNotesCollection, "list_by_user")
+	notes, err := r.next.ListByUser(ctx, userID)
+	endSpan(span, len(notes), err)
+	return notes, err
+}
+
+// Update replaces a note
+func (r *tracedAdminNoteRepository) Update(ctx context.Context, note *entity.AdminNote) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, adminNotesCollection, "update")
+	err := r.next.Update(ctx, note)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a note
+func (r *tracedAdminNoteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, adminNotesCollection, "delete")
+	err := r.next.Delete(ctx, id)
+	endSpan(span, 1, err)
+	return err
+}
+
+// DeleteByUser deletes the notes on a user
+func (r *tracedAdminNoteRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, adminNotesCollection, "delete_by_user")
+	err := r.next.DeleteByUser(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// tracedPermissionRepository decorates a PermissionRepository with tracing spans
+type tracedPermissionRepository struct {
+	next PermissionRepository
+}
+
+// NewTracedPermissionRepository wraps a PermissionRepository so every call is recorded as a span
+func NewTracedPermissionRepository(next PermissionRepository) PermissionRepository {
+	return &tracedPermissionRepository{next: next}
+}
+
+// Create creates a new permission
+func (r *tracedPermissionRepository) Create(ctx context.Context, permission *entity.Permission) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionsCollection, "create")
+	err := r.next.Create(ctx, permission)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByName retrieves a permission by name
+func (r *tracedPermissionRepository) GetByName(ctx context.Context, name string) (*entity.Permission, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionsCollection, "get_by_name")
+	permission, err := r.next.GetByName(ctx, name)
+	endSpan(span, countOf(permission), err)
+	return permission, err
+}
+
+// List lists all permissions
+func (r *tracedPermissionRepository) List(ctx context.Context) ([]*entity.Permission, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionsCollection, "list")
+	permissions, err := r.next.List(ctx)
+	endSpan(span, len(permissions), err)
+	return permissions, err
+}
+
+// Update updates a permission
+func (r *tracedPermissionRepository) Update(ctx context.Context, permission *entity.Permission) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionsCollection, "update")
+	err := r.next.Update(ctx, permission)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a permission
+func (r *tracedPermissionRepository) Delete(ctx context.Context, name string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, permissionsCollection, "delete")
+	err := r.next.Delete(ctx, name)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedRoleAssignmentRepository decorates a RoleAssignmentRepository with tracing spans
+type tracedRoleAssignmentRepository struct {
+	next RoleAssignmentRepository
+}
+
+// NewTracedRoleAssignmentRepository wraps a RoleAssignmentRepository so every call is recorded as a span
+func NewTracedRoleAssignmentRepository(next RoleAssignmentRepository) RoleAssignmentRepository {
+	return &tracedRoleAssignmentRepository{next: next}
+}
+
+// Assign assigns a role to a user
+func (r *tracedRoleAssignmentRepository) Assign(ctx context.Context, assignment *entity.RoleAssignment) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleAssignmentsCollection, "assign")
+	assigned, err := r.next.Assign(ctx, assignment)
+	endSpan(span, 1, err)
+	return assigned, err
+}
+
+// Unassign removes a role from a user
+func (r *tracedRoleAssignmentRepository) Unassign(ctx context.Context, userID uuid.UUID, role string) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleAssignmentsCollection, "unassign")
+	unassigned, err := r.next.Unassign(ctx, userID, role)
+	endSpan(span, 1, err)
+	return unassigned, err
+}
+
+// ListByUser lists the roles assigned to a user
+func (r *tracedRoleAssignmentRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RoleAssignment, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleAssignmentsCollection, "list_by_user")
+	assignments, err := r.next.ListByUser(ctx, userID)
+	endSpan(span, len(assignments), err)
+	return assignments, err
+}
+
+// ListByRole lists the users a role is assigned to
+func (r *tracedRoleAssignmentRepository) ListByRole(ctx context.Context, role string) ([]*entity.RoleAssignment, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleAssignmentsCollection, "list_by_role")
+	assignments, err := r.next.ListByRole(ctx, role)
+	endSpan(span, len(assignments), err)
+	return assignments, err
+}
+
+// DeleteByUser removes every role assigned to a user
+func (r *tracedRoleAssignmentRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleAssignmentsCollection, "delete_by_user")
+	err := r.next.DeleteByUser(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// ListExpired lists the expired assignments
+func (r *tracedRoleAssignmentRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RoleAssignment, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleAssignmentsCollection, "list_expired")
+	assignments, err := r.next.ListExpired(ctx, now, limit)
+	endSpan(span, len(assignments), err)
+	return assignments, err
+}
+
+// DeleteExpired removes an expired assignment
+func (r *tracedRoleAssignmentRepository) DeleteExpired(ctx context.Context, assignment *entity.RoleAssignment) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleAssignmentsCollection, "delete_expired")
+	deleted, err := r.next.DeleteExpired(ctx, assignment)
+	endSpan(span, 1, err)
+	return deleted, err
+}
+
+// tracedRoleChangeRequestRepository decorates a RoleChangeRequestRepository with tracing spans
+type tracedRoleChangeRequestRepository struct {
+	next RoleChangeRequestRepository
+}
+
+// NewTracedRoleChangeRequestRepository wraps a RoleChangeRequestRepository so every call is recorded as a span
+func NewTracedRoleChangeRequestRepository(next RoleChangeRequestRepository) RoleChangeRequestRepository {
+	return &tracedRoleChangeRequestRepository{next: next}
+}
+
+// Create stores a new request
+func (r *tracedRoleChangeRequestRepository) Create(ctx context.Context, request *entity.RoleChangeRequest) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleChangeRequestCollection, "create")
+	err := r.next.Create(ctx, request)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByID returns a request
+func (r *tracedRoleChangeRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.RoleChangeRequest, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleChangeRequestCollection, "get_by_id")
+	request, err := r.next.GetByID(ctx, id)
+	endSpan(span, countOf(request), err)
+	return request, err
+}
+
+// List lists the requests with a status
+func (r *tracedRoleChangeRequestRepository) List(ctx context.Context, status string) ([]*entity.RoleChangeRequest, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleChangeRequestCollection, "list")
+	requests, err := r.next.List(ctx, status)
+	endSpan(span, len(requests), err)
+	return requests, err
+}
+
+// Transition replaces a request if its stored status is still from
+func (r *tracedRoleChangeRequestRepository) Transition(ctx context.Context, request *entity.RoleChangeRequest, from string) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, roleChangeRequestCollection, "transition")
+	replaced, err := r.next.Transition(ctx, request, from)
+	endSpan(span, 1, err)
+	return replaced, err
+}
+
+// tracedTeamRepository decorates a TeamRepository with tracing spans
+type tracedTeamRepository struct {
+	next TeamRepository
+}
+
+// NewTracedTeamRepository wraps a TeamRepository so every call is recorded as a span
+func NewTracedTeamRepository(next TeamRepository) TeamRepository {
+	return &tracedTeamRepository{next: next}
+}
+
+// Create creates a new team
+func (r *tracedTeamRepository) Create(ctx context.Context, team *entity.Team) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamsCollection, "create")
+	err := r.next.Create(ctx, team)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByName retrieves a team by name
+func (r *tracedTeamRepository) GetByName(ctx context.Context, name string) (*entity.Team, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamsCollection, "get_by_name")
+	team, err := r.next.GetByName(ctx, name)
+	endSpan(span, countOf(team), err)
+	return team, err
+}
+
+// List lists all teams
+func (r *tracedTeamRepository) List(ctx context.Context) ([]*entity.Team, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamsCollection, "list")
+	teams, err := r.next.List(ctx)
+	endSpan(span, len(teams), err)
+	return teams, err
+}
+
+// Update updates a team
+func (r *tracedTeamRepository) Update(ctx context.Context, team *entity.Team) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamsCollection, "update")
+	err := r.next.Update(ctx, team)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a team
+func (r *tracedTeamRepository) Delete(ctx context.Context, name string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamsCollection, "delete")
+	err := r.next.Delete(ctx, name)
+	endSpan(span, 1, err)
+	return err
+}
+
+// tracedTeamMemberRepository decorates a TeamMemberRepository with tracing spans
+type tracedTeamMemberRepository struct {
+	next TeamMemberRepository
+}
+
+// NewTracedTeamMemberRepository wraps a TeamMemberRepository so every call is recorded as a span
+func NewTracedTeamMemberRepository(next TeamMemberRepository) TeamMemberRepository {
+	return &tracedTeamMemberRepository{next: next}
+}
+
+// Add adds a user to a team
+func (r *tracedTeamMemberRepository) Add(ctx context.Context, member *entity.TeamMember) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamMembersCollection, "add")
+	added, err := r.next.Add(ctx, member)
+	endSpan(span, 1, err)
+	return added, err
+}
+
+// Remove removes a user from a team
+func (r *tracedTeamMemberRepository) Remove(ctx context.Context, team string, userID uuid.UUID) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamMembersCollection, "remove")
+	removed, err := r.next.Remove(ctx, team, userID)
+	endSpan(span, 1, err)
+	return removed, err
+}
+
+// ListByTeam lists the members of a team
+func (r *tracedTeamMemberRepository) ListByTeam(ctx context.Context, team string) ([]*entity.TeamMember, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamMembersCollection, "list_by_team")
+	members, err := r.next.ListByTeam(ctx, team)
+	endSpan(span, len(members), err)
+	return members, err
+}
+
+// ListByUser lists the teams of a user
+func (r *tracedTeamMemberRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.TeamMember, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamMembersCollection, "list_by_user")
+	members, err := r.next.ListByUser(ctx, userID)
+	endSpan(span, len(members), err)
+	return members, err
+}
+
+// DeleteByTeam removes every member of a team
+func (r *tracedTeamMemberRepository) DeleteByTeam(ctx context.Context, team string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamMembersCollection, "delete_by_team")
+	err := r.next.DeleteByTeam(ctx, team)
+	endSpan(span, 0, err)
+	return err
+}
+
+// DeleteByUser removes a user from every team
+func (r *tracedTeamMemberRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, teamMembersCollection, "delete_by_user")
+	err := r.next.DeleteByUser(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// tracedBreakGlassRepository decorates a BreakGlassRepository with tracing spans
+type tracedBreakGlassRepository struct {
+	next BreakGlassRepository
+}
+
+// NewTracedBreakGlassRepository wraps a BreakGlassRepository so every call is recorded as a span
+func NewTracedBreakGlassRepository(next BreakGlassRepository) BreakGlassRepository {
+	return &tracedBreakGlassRepository{next: next}
+}
+
+// Save creates or replaces a break-glass account
+func (r *tracedBreakGlassRepository) Save(ctx context.Context, account *entity.BreakGlassAccount) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, breakGlassCollection, "save")
+	err := r.next.Save(ctx, account)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByUserID retrieves the break-glass account of a user
+func (r *tracedBreakGlassRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.BreakGlassAccount, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, breakGlassCollection, "get_by_user_id")
+	account, err := r.next.GetByUserID(ctx, userID)
+	endSpan(span, countOf(account), err)
+	return account, err
+}
+
+// List lists all break-glass accounts
+func (r *tracedBreakGlassRepository) List(ctx context.Context) ([]*entity.BreakGlassAccount, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, breakGlassCollection, "list")
+	accounts, err := r.next.List(ctx)
+	endSpan(span, len(accounts), err)
+	return accounts, err
+}
+
+// MarkUsed records the first use of a sealed account
+func (r *tracedBreakGlassRepository) MarkUsed(ctx context.Context, userID uuid.UUID, usedAt time.Time) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, breakGlassCollection, "mark_used")
+	marked, err := r.next.MarkUsed(ctx, userID, usedAt)
+	endSpan(span, 1, err)
+	return marked, err
+}
+
+// Delete deletes a break-glass account
+func (r *tracedBreakGlassRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, breakGlassCollection, "delete")
+	err := r.next.Delete(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// tracedSecurityEventRepository decorates a SecurityEventRepository with tracing spans
+type tracedSecurityEventRepository struct {
+	next SecurityEventRepository
+}
+
+// NewTracedSecurityEventRepository wraps a SecurityEventRepository so every call is recorded as a span
+func NewTracedSecurityEventRepository(next SecurityEventRepository) SecurityEventRepository {
+	return &tracedSecurityEventRepository{next: next}
+}
+
+// Create queues a security event
+func (r *tracedSecurityEventRepository) Create(ctx context.Context, event *entity.SecurityEvent) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, securityEventsCollection, "create")
+	err := r.next.Create(ctx, event)
+	endSpan(span, 1, err)
+	return err
+}
+
+// ListPending lists queued security events
+func (r *tracedSecurityEventRepository) ListPending(ctx context.Context, limit int) ([]*entity.SecurityEvent, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, securityEventsCollection, "list_pending")
+	events, err := r.next.ListPending(ctx, limit)
+	endSpan(span, len(events), err)
+	return events, err
+}
+
+// Delete removes delivered security events
+func (r *tracedSecurityEventRepository) Delete(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, securityEventsCollection, "delete")
+	err := r.next.Delete(ctx, ids)
+	endSpan(span, len(ids), err)
+	return err
+}
+
+// tracedIPDenialRepository decorates an IPDenialRepository with tracing spans
+type tracedIPDenialRepository struct {
+	next IPDenialRepository
+}
+
+// NewTracedIPDenialRepository wraps an IPDenialRepository so every call is recorded as a span
+func NewTracedIPDenialRepository(next IPDenialRepository) IPDenialRepository {
+	return &tracedIPDenialRepository{next: next}
+}
+
+// Create denies an IP address
+func (r *tracedIPDenialRepository) Create(ctx context.Context, denial *entity.IPDenial) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, ipDenialsCollection, "create")
+	created, err := r.next.Create(ctx, denial)
+	endSpan(span, 1, err)
+	return created, err
+}
+
+// GetActive gets the denial of an IP address
+func (r *tracedIPDenialRepository) GetActive(ctx context.Context, ip string, now time.Time) (*entity.IPDenial, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, ipDenialsCollection, "get_active")
+	denial, err := r.next.GetActive(ctx, ip, now)
+	endSpan(span, countOf(denial), err)
+	return denial, err
+}
+
+// ListActive lists the denied IP addresses
+func (r *tracedIPDenialRepository) ListActive(ctx context.Context, now time.Time) ([]*entity.IPDenial, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, ipDenialsCollection, "list_active")
+	denials, err := r.next.ListActive(ctx, now)
+	endSpan(span, len(denials), err)
+	return denials, err
+}
+
+// Delete lifts the denial of an IP address
+func (r *tracedIPDenialRepository) Delete(ctx context.Context, ip string) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, ipDenialsCollection, "delete")
+	err := r.next.Delete(ctx, ip)
+	endSpan(span, 0, err)
+	return err
+}
+
+// tracedLoginCountryRepository decorates a LoginCountryRepository with tracing spans
+type tracedLoginCountryRepository struct {
+	next LoginCountryRepository
+}
+
+// NewTracedLoginCountryRepository wraps a LoginCountryRepository so every call is recorded as a span
+func NewTracedLoginCountryRepository(next LoginCountryRepository) LoginCountryRepository {
+	return &tracedLoginCountryRepository{next: next}
+}
+
+// Add records a country a user signed in from
+func (r *tracedLoginCountryRepository) Add(ctx context.Context, userID uuid.UUID, country string) (bool, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, loginCountriesCollection, "add")
+	added, err := r.next.Add(ctx, userID, country)
+	endSpan(span, 1, err)
+	return added, err
+}
+
+// List lists the countries a user signed in from
+func (r *tracedLoginCountryRepository) List(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, loginCountriesCollection, "list")
+	countries, err := r.next.List(ctx, userID)
+	endSpan(span, len(countries), err)
+	return countries, err
+}
+
+// DeleteByUser deletes the countries of a user
+func (r *tracedLoginCountryRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, loginCountriesCollection, "delete_by_user")
+	err := r.next.DeleteByUser(ctx, userID)
+	endSpan(span, 0, err)
+	return err
+}
+
+// tracedSelfTestRepository decorates a SelfTestRepository with tracing spans
+type tracedSelfTestRepository struct {
+	next   SelfTestRepository
+	system string
+}
+
+// NewTracedSelfTestRepository wraps a SelfTestRepository storing the probes in the database so every call is
+// recorded as a span
+func NewTracedSelfTestRepository(next SelfTestRepository) SelfTestRepository {
+	return &tracedSelfTestRepository{next: next, system: dbSystemMongoDB}
+}
+
+// NewTracedSelfTestCacheRepository wraps a SelfTestRepository storing the probes in the cache so every call is
+// recorded as a span
+func NewTracedSelfTestCacheRepository(next SelfTestRepository) SelfTestRepository {
+	return &tracedSelfTestRepository{next: next, system: dbSystemRedis}
+}
+
+// Write stores a probe
+func (r *tracedSelfTestRepository) Write(ctx context.Context, probe *entity.SelfTestProbe) error {
+	ctx, span := startSpan(ctx, r.system, selfTestProbesCollection, "write")
+	err := r.next.Write(ctx, probe)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Read gets a probe by ID
+func (r *tracedSelfTestRepository) Read(ctx context.Context, id uuid.UUID) (*entity.SelfTestProbe, error) {
+	ctx, span := startSpan(ctx, r.system, selfTestProbesCollection, "read")
+	probe, err := r.next.Read(ctx, id)
+	endSpan(span, countOf(probe), err)
+	return probe, err
+}
+
+// Delete deletes a probe
+func (r *tracedSelfTestRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, r.system, selfTestProbesCollection, "delete")
+	err := r.next.Delete(ctx, id)
+	endSpan(span, 0, err)
+	return err
+}
+
+// tracedSCIMGroupRepository decorates a SCIMGroupRepository with tracing spans
+type tracedSCIMGroupRepository struct {
+	next SCIMGroupRepository
+}
+
+// NewTracedSCIMGroupRepository wraps a SCIMGroupRepository so every call is recorded as a span
+func NewTracedSCIMGroupRepository(next SCIMGroupRepository) SCIMGroupRepository {
+	return &tracedSCIMGroupRepository{next: next}
+}
+
+// Create stores a new group
+func (r *tracedSCIMGroupRepository) Create(ctx context.Context, group *entity.SCIMGroup) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, scimGroupsCollection, "create")
+	err := r.next.Create(ctx, group)
+	endSpan(span, 1, err)
+	return err
+}
+
+// GetByID returns a group, nil if unknown
+func (r *tracedSCIMGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, scimGroupsCollection, "get_by_id")
+	group, err := r.next.GetByID(ctx, id)
+	endSpan(span, countOf(group), err)
+	return group, err
+}
+
+// List returns the groups ordered by display name
+func (r *tracedSCIMGroupRepository) List(ctx context.Context) ([]*entity.SCIMGroup, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, scimGroupsCollection, "list")
+	groups, err := r.next.List(ctx)
+	endSpan(span, len(groups), err)
+	return groups, err
+}
+
+// ListByMember returns the groups a user is a member of
+func (r *tracedSCIMGroupRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*entity.SCIMGroup, error) {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, scimGroupsCollection, "list_by_member")
+	groups, err := r.next.ListByMember(ctx, userID)
+	endSpan(span, len(groups), err)
+	return groups, err
+}
+
+// Update replaces a group
+func (r *tracedSCIMGroupRepository) Update(ctx context.Context, group *entity.SCIMGroup) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, scimGroupsCollection, "update")
+	err := r.next.Update(ctx, group)
+	endSpan(span, 1, err)
+	return err
+}
+
+// Delete deletes a group
+func (r *tracedSCIMGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, dbSystemMongoDB, scimGroupsCollection, "delete")
+	err := r.next.Delete(ctx, id)
+	endSpan(span, 0, err)
+	return err
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UsageRepository defines the interface for usage metering repository operations
+type UsageRepository interface {
+	// Add adds the counts of the records to the stored aggregates
+	Add(ctx context.Context, records []*entity.UsageRecord) error
+
+	// ListByPeriod lists the aggregates of a period
+	ListByPeriod(ctx context.Context, period string) ([]*entity.UsageRecord, error)
+}
+
+type usageRepository struct {
+	db db.Database
+}
+
+// NewUsageRepository creates a new UsageRepository
+func NewUsageRepository(db db.Database) UsageRepository {
+	return &usageRepository{
+		db: db,
+	}
+}
+
+// Add adds the counts of the records to the stored aggregates
+func (r *usageRepository) Add(ctx context.Context, records []*entity.UsageRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.addUsageMongo(ctx, db, records)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// ListByPeriod lists the aggregates of a period
+func (r *usageRepository) ListByPeriod(ctx context.Context, period string) ([]*entity.UsageRecord, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listUsageByPeriodMongo(ctx, db, period)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// addUsageMongo upserts usage aggregates in MongoDB in a single bulk write
+func (r *usageRepository) addUsageMongo(ctx context.Context, client *mongo.Client, records []*entity.UsageRecord) error {
+	collection := client.Database("user_service").Collection("usage")
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(records))
+	for _, record := range records {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"period":  record.Period,
+				"metric":  record.Metric,
+				"subject": record.Subject,
+			}).
+			SetUpdate(bson.M{
+				"$inc": bson.M{"count": record.Count},
+				"$set": bson.M{"updated_at": now},
+			}).
+			SetUpsert(true))
+	}
+
+	if _, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		log.Error().Err(err).Int("records", len(records)).Msg("Failed to add usage in MongoDB")
+		return fmt.Errorf("failed to add usage: %w", err)
+	}
+
+	return nil
+}
+
+// listUsageByPeriodMongo lists the usage aggregates of a period from MongoDB
+func (r *usageRepository) listUsageByPeriodMongo(ctx context.Context, client *mongo.Client, period string) ([]*entity.UsageRecord, error) {
+	collection := client.Database("user_service").Collection("usage")
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "metric", Value: 1}, {Key: "count", Value: -1}})
+	cursor, err := collection.Find(ctx, bson.M{"period": period}, findOptions)
+	if err != nil {
+		log.Error().Err(err).Str("period", period).Msg("Failed to list usage from MongoDB")
+		return nil, fmt.Errorf("failed to list usage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	records := []*entity.UsageRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		log.Error().Err(err).Str("period", period).Msg("Failed to decode usage from MongoDB")
+		return nil, fmt.Errorf("failed to decode usage: %w", err)
+	}
+
+	return records, nil
+}
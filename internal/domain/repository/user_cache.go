@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	userEmailIndexPrefix    = "user:email:"
+	userUsernameIndexPrefix = "user:username:"
+)
+
+// cachedUser is the cache representation of a user.
+// It keeps the password hash and the status to restore a deletion to, which are hidden from the JSON
+// representation of the entity.
+type cachedUser struct {
+	*entity.User
+	Password             string `json:"password"`
+	StatusBeforeDeletion string `json:"status_before_deletion,omitempty"`
+}
+
+// newCachedUser returns the cache representation of a user, without the password hash unless withPassword
+func newCachedUser(user *entity.User, withPassword bool) cachedUser {
+	cached := cachedUser{User: user, StatusBeforeDeletion: user.StatusBeforeDeletion}
+	if withPassword {
+		cached.Password = user.Password
+	}
+	return cached
+}
+
+// user returns the cached user with its hidden fields restored
+func (c cachedUser) user() *entity.User {
+	c.User.Password = c.Password
+	c.User.StatusBeforeDeletion = c.StatusBeforeDeletion
+	return c.User
+}
+
+// userCacheKey returns the cache key of a user
+func userCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("%s%s", userCacheKeyPrefix, id.String())
+}
+
+// userStaleCacheKey returns the cache key of the copy of a user kept past the expiration of its cache entry
+func userStaleCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("%s%s", userStaleCacheKeyPrefix, id.String())
+}
+
+// getCachedUser returns a user from cache without falling back to the database
+func (r *userRepository) getCachedUser(ctx context.Context, id uuid.UUID) *entity.User {
+	return r.getUserFromCache(ctx, userCacheKey(id))
+}
+
+// getStaleUser returns the copy of a user kept past the expiration of its cache entry, marked stale
+func (r *userRepository) getStaleUser(ctx context.Context, id uuid.UUID) *entity.User {
+	if r.staleTTL <= 0 {
+		return nil
+	}
+
+	user := r.getUserFromCache(ctx, userStaleCacheKey(id))
+	if user == nil {
+		return nil
+	}
+	user.Stale = true
+	metrics.UserCacheStaleReads.Inc()
+
+	return user
+}
+
+// getUserFromCache decodes the user cached under a key
+func (r *userRepository) getUserFromCache(ctx context.Context, key string) *entity.User {
+	data, err := r.cache.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil
+	}
+
+	cached := cachedUser{User: &entity.User{}}
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil
+	}
+
+	return cached.user()
+}
+
+// cacheUser stores a user and its email/username index keys in cache
+func (r *userRepository) cacheUser(ctx context.Context, user *entity.User) {
+	data, err := json.Marshal(newCachedUser(user, true))
+	if err != nil {
+		return
+	}
+
+	if err := r.cache.Set(ctx, userCacheKey(user.ID), data, userCacheTTL); err != nil {
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to cache user")
+		return
+	}
+	if r.staleTTL > 0 {
+		if err := r.cache.Set(ctx, userStaleCacheKey(user.ID), data, r.staleTTL); err != nil {
+			log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to cache stale copy of user")
+		}
+	}
+
+	id := []byte(user.ID.String())
+	if err := r.cache.Set(ctx, userEmailIndexPrefix+user.Email, id, userCacheTTL); err != nil {
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to cache user email index")
+	}
+	if err := r.cache.Set(ctx, userUsernameIndexPrefix+user.Username, id, userCacheTTL); err != nil {
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to cache user username index")
+	}
+}
+
+// uncacheUser removes a user and its stale copy from cache, so it is neither served nor served stale once changed
+func (r *userRepository) uncacheUser(ctx context.Context, id uuid.UUID) error {
+	return errors.Join(r.cache.Delete(ctx, userCacheKey(id)), r.cache.Delete(ctx, userStaleCacheKey(id)))
+}
+
+// uncacheUserIndexes removes the email/username index keys of the cached version of a user
+func (r *userRepository) uncacheUserIndexes(ctx context.Context, id uuid.UUID) {
+	user := r.getCachedUser(ctx, id)
+	if user == nil {
+		return
+	}
+
+	for _, key := range []string{userEmailIndexPrefix + user.Email, userUsernameIndexPrefix + user.Username} {
+		if err := r.cache.Delete(ctx, key); err != nil {
+			log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to delete user index from cache")
+		}
+	}
+}
+
+// getUserByIndex resolves a user through an index key.
+// Stale index entries pointing to a user that no longer matches are dropped.
+func (r *userRepository) getUserByIndex(ctx context.Context, key string, matches func(*entity.User) bool) *entity.User {
+	data, err := r.cache.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil
+	}
+
+	id, err := uuid.ParseBytes(data)
+	if err == nil {
+		user, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil
+		}
+		if user != nil && matches(user) {
+			return user
+		}
+	}
+
+	if err := r.cache.Delete(ctx, key); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to drop stale user index")
+	}
+	return nil
+}
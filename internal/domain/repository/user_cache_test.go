@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/google/uuid"
+)
+
+// pendingDeletionUser returns a blocked user scheduled for deletion
+func pendingDeletionUser() *entity.User {
+	user := &entity.User{
+		ID:       uuid.New(),
+		Email:    "jane@example.com",
+		Username: "jane",
+		Password: "hash",
+		Status:   entity.UserStatusBlocked,
+	}
+	user.ScheduleDeletion(time.Now(), time.Hour)
+	return user
+}
+
+func TestCachedUserKeepsHiddenFields(t *testing.T) {
+	r := &userRepository{cache: cache.NewMemory()}
+	user := pendingDeletionUser()
+
+	r.cacheUser(context.Background(), user)
+
+	cached := r.getCachedUser(context.Background(), user.ID)
+	if cached == nil {
+		t.Fatal("user not cached")
+	}
+	if cached.Password != user.Password {
+		t.Errorf("password: got %q, want %q", cached.Password, user.Password)
+	}
+	if cached.StatusBeforeDeletion != entity.UserStatusBlocked {
+		t.Errorf("status before deletion: got %q, want %q", cached.StatusBeforeDeletion, entity.UserStatusBlocked)
+	}
+}
+
+func TestCachedUserListKeepsStatusBeforeDeletion(t *testing.T) {
+	r := &userRepository{cache: cache.NewMemory()}
+	user := pendingDeletionUser()
+
+	r.setCachedUserList(context.Background(), "users", []*entity.User{user})
+
+	users, ok := r.getCachedUserList(context.Background(), "users")
+	if !ok || len(users) != 1 {
+		t.Fatalf("got %d cached users, want 1", len(users))
+	}
+	if users[0].StatusBeforeDeletion != entity.UserStatusBlocked {
+		t.Errorf("status before deletion: got %q, want %q", users[0].StatusBeforeDeletion, entity.UserStatusBlocked)
+	}
+	if users[0].Password != "" {
+		t.Error("password hash cached in a list")
+	}
+}
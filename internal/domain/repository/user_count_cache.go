@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	userCountCacheKeyPrefix = "users:count:"
+	userCountCacheTTL       = 5 * time.Minute
+)
+
+// getCachedUserCount returns the cached total for a list filter
+func (r *userRepository) getCachedUserCount(ctx context.Context, opts entity.UserListOptions) (int64, bool) {
+	data, err := r.cache.Get(ctx, userCountCacheKeyPrefix+userListTag(opts))
+	if err != nil || data == nil {
+		return 0, false
+	}
+
+	total, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return total, true
+}
+
+// setCachedUserCount caches the total for a list filter
+func (r *userRepository) setCachedUserCount(ctx context.Context, opts entity.UserListOptions, total int64) {
+	key := userCountCacheKeyPrefix + userListTag(opts)
+	if err := r.cache.Set(ctx, key, []byte(strconv.FormatInt(total, 10)), userCountCacheTTL); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to cache user count")
+	}
+}
+
+// adjustUserCount adds delta to a cached total, dropping it if there was nothing cached to adjust
+func (r *userRepository) adjustUserCount(ctx context.Context, tag string, delta int64) {
+	key := userCountCacheKeyPrefix + tag
+	total, err := r.cache.Increment(ctx, key, delta, userCountCacheTTL)
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to adjust cached user count")
+		return
+	}
+
+	// Getting delta back means the counter was just created (or was zero),
+	// so it doesn't hold a real total and must be recounted
+	if total == delta {
+		if err := r.cache.Delete(ctx, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to drop cached user count")
+		}
+	}
+}
+
+// invalidateUserCounts drops the cached totals of the unfiltered list and all status filters
+func (r *userRepository) invalidateUserCounts(ctx context.Context) {
+	key := userCountCacheKeyPrefix + userListTagAll
+	if err := r.cache.Delete(ctx, key); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to invalidate cached user count")
+	}
+	r.invalidateUserStatusCounts(ctx)
+}
+
+// invalidateUserStatusCounts drops the cached totals of all status filters
+func (r *userRepository) invalidateUserStatusCounts(ctx context.Context) {
+	for _, status := range allUserStatuses {
+		key := userCountCacheKeyPrefix + userListTagStatus + status
+		if err := r.cache.Delete(ctx, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to invalidate cached user count")
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	userListCacheKeyPrefix = "users:list:"
+	userListTagKeyPrefix   = "users:list:tag:"
+	userListCacheTTL       = 30 * time.Second
+
+	// Only the first pages are cached, deeper pages are rarely polled
+	userListCacheMaxPage = 3
+
+	userListTagAll    = "all"
+	userListTagStatus = "status:"
+)
+
+// userListTag returns the invalidation tag a list query depends on
+func userListTag(opts entity.UserListOptions) string {
+	if opts.Status != "" {
+		return userListTagStatus + opts.Status
+	}
+	return userListTagAll
+}
+
+// userListCacheable reports whether the results of a list query can be cached.
+// Only the status filter is tracked by the invalidation tags, other filters are always served from the database.
+func userListCacheable(opts entity.UserListOptions) bool {
+	return opts.OrgID == nil && opts.Role == "" && opts.Tag == "" && !opts.EmailVerifiedOnly && !opts.PhoneVerifiedOnly
+}
+
+// userListTagVersion returns the current version of a tag, initializing it when missing
+func (r *userRepository) userListTagVersion(ctx context.Context, tag string) (string, error) {
+	tagKey := userListTagKeyPrefix + tag
+	data, err := r.cache.Get(ctx, tagKey)
+	if err != nil {
+		return "", err
+	}
+	if data != nil {
+		return string(data), nil
+	}
+
+	version := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := r.cache.Set(ctx, tagKey, []byte(version), 0); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// userListCacheKey builds the cache key of a list query, or returns an empty string if the query is not cacheable
+func (r *userRepository) userListCacheKey(ctx context.Context, page, limit int, opts entity.UserListOptions) string {
+	if page > userListCacheMaxPage || !userListCacheable(opts) {
+		return ""
+	}
+
+	tag := userListTag(opts)
+	version, err := r.userListTagVersion(ctx, tag)
+	if err != nil {
+		log.Warn().Err(err).Str("tag", tag).Msg("Failed to get user list cache tag version")
+		return ""
+	}
+
+	return fmt.Sprintf("%s%s:%s:%d:%d", userListCacheKeyPrefix, tag, version, page, limit)
+}
+
+// getCachedUserList returns a cached list query page
+func (r *userRepository) getCachedUserList(ctx context.Context, key string) ([]*entity.User, bool) {
+	data, err := r.cache.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil, false
+	}
+
+	var cached []cachedUser
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false
+	}
+
+	users := make([]*entity.User, len(cached))
+	for i, user := range cached {
+		if user.User == nil {
+			return nil, false
+		}
+		users[i] = user.user()
+	}
+	return users, true
+}
+
+// setCachedUserList caches a list query page, without the password hashes
+func (r *userRepository) setCachedUserList(ctx context.Context, key string, users []*entity.User) {
+	cached := make([]cachedUser, len(users))
+	for i, user := range users {
+		cached[i] = newCachedUser(user, false)
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+
+	if err := r.cache.Set(ctx, key, data, userListCacheTTL); err != nil {
+		log.Warn().Err(err).Msg("Failed to cache user list")
+	}
+}
+
+// invalidateUserLists drops the cached list queries depending on the unfiltered list and the given statuses.
+// Cached entries are not deleted one by one, bumping the tag version makes them unreachable until they expire.
+func (r *userRepository) invalidateUserLists(ctx context.Context, statuses ...string) {
+	tags := []string{userListTagAll}
+	for _, status := range statuses {
+		tags = append(tags, userListTagStatus+status)
+	}
+
+	version := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+	for _, tag := range tags {
+		if err := r.cache.Set(ctx, userListTagKeyPrefix+tag, version, 0); err != nil {
+			log.Warn().Err(err).Str("tag", tag).Msg("Failed to invalidate user list cache")
+		}
+	}
+}
+
+// allUserStatuses lists the statuses used as list cache tags
+var allUserStatuses = []string{
+	entity.UserStatusActive,
+	entity.UserStatusInactive,
+	entity.UserStatusBlocked,
+	entity.UserStatusInvited,
+	entity.UserStatusWaitlisted,
+	entity.UserStatusPendingDeletion,
+}
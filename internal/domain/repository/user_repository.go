@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -11,11 +10,13 @@ import (
 	"github.com/chats/go-user-api/internal/infrastructure/cache"
 	"github.com/chats/go-user-api/internal/infrastructure/db"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 const userCacheKeyPrefix = "user:"
+const userStaleCacheKeyPrefix = "user:stale:"
 const userCacheTTL = 30 * time.Minute
 
 // UserRepository defines the interface for user repository operations
@@ -23,6 +24,9 @@ type UserRepository interface {
 	// Create a new user
 	Create(ctx context.Context, user *entity.User) error
 
+	// Create users in a single batch
+	CreateMany(ctx context.Context, users []*entity.User) error
+
 	// Get a user by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 
@@ -32,6 +36,9 @@ type UserRepository interface {
 	// Get a user by username
 	GetByUsername(ctx context.Context, username string) (*entity.User, error)
 
+	// Get a user by verified recovery email
+	GetByRecoveryEmail(ctx context.Context, email string) (*entity.User, error)
+
 	// Update user information
 	Update(ctx context.Context, user *entity.User) error
 
@@ -39,25 +46,60 @@ type UserRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// List users with pagination
-	List(ctx context.Context, page, limit int) ([]*entity.User, int64, error)
+	List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error)
 
 	// Change user password
 	ChangePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error
 
+	// Replace the password hash of a user with a hash of the same password, unless the password changed since the
+	// current hash was read
+	RehashPassword(ctx context.Context, id uuid.UUID, currentHash, hashedPassword string) error
+
 	// Update user status
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+
+	// Add tags to a user, tags the user already carries are ignored
+	AddTags(ctx context.Context, id uuid.UUID, tags []string) error
+
+	// Remove tags from a user, tags the user does not carry are ignored
+	RemoveTags(ctx context.Context, id uuid.UUID, tags []string) error
+
+	// List the users pending deletion whose purge time is before the given time, the earliest first
+	ListDueForPurge(ctx context.Context, before time.Time, limit int) ([]*entity.User, error)
+
+	// List the waitlisted users, the earliest registered first
+	ListWaitlisted(ctx context.Context, limit int) ([]*entity.User, error)
+
+	// Record the time a user was last active
+	RecordActivity(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// List the users created in [from, to), the earliest created first
+	ListCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error)
+
+	// List the users last active in [from, to), counting the creation of those never active, the least recently
+	// active first
+	ListLastActiveBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error)
+
+	// Count the users of every status and role combination in use
+	CountByStatusAndRole(ctx context.Context) ([]entity.UserCount, error)
+
+	// Count the users created since a time
+	CountCreatedSince(ctx context.Context, since time.Time) (int64, error)
 }
 
 type userRepository struct {
-	db    db.Database
-	cache cache.Cache
+	db       db.Database
+	cache    cache.Cache
+	staleTTL time.Duration
 }
 
-// NewUserRepository creates a new UserRepository
-func NewUserRepository(db db.Database, cache cache.Cache) UserRepository {
+// NewUserRepository creates a new UserRepository. Users are also kept in the cache for staleTTL, served by GetByID
+// past their expiration while the database fails; a staleTTL of 0 disables it.
+func NewUserRepository(db db.Database, cache cache.Cache, staleTTL time.Duration) UserRepository {
 	return &userRepository{
-		db:    db,
-		cache: cache,
+		db:       db,
+		cache:    cache,
+		staleTTL: staleTTL,
 	}
 }
 
@@ -65,26 +107,54 @@ func NewUserRepository(db db.Database, cache cache.Cache) UserRepository {
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	// Get the appropriate instance based on the database type
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	return r.createUserPostgres(ctx, db, user)
+	case *pgxpool.Pool:
+		if err := r.createUserPostgres(ctx, db, user); err != nil {
+			return err
+		}
 	case *mongo.Client:
-		return r.createUserMongo(ctx, db, user)
+		if err := r.createUserMongo(ctx, db, user); err != nil {
+			return err
+		}
 	default:
 		return errors.New("unsupported database type")
 	}
+
+	r.invalidateUserLists(ctx, user.Status)
+	r.adjustUserCount(ctx, userListTagAll, 1)
+	r.adjustUserCount(ctx, userListTagStatus+user.Status, 1)
+
+	return nil
+}
+
+// CreateMany creates users in a single batch
+func (r *userRepository) CreateMany(ctx context.Context, users []*entity.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	// Get the appropriate instance based on the database type
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		err = r.createUsersPostgres(ctx, db, users)
+	case *mongo.Client:
+		err = r.createUsersMongo(ctx, db, users)
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	// A failed batch may be partially inserted, so cached lists and totals are dropped rather than adjusted
+	r.invalidateUserLists(ctx, allUserStatuses...)
+	r.invalidateUserCounts(ctx)
+
+	return err
 }
 
 // GetByID retrieves a user by ID
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	// Try to get from cache first
-	cacheKey := fmt.Sprintf("%s%s", userCacheKeyPrefix, id.String())
-	cachedData, err := r.cache.Get(ctx, cacheKey)
-	if err == nil && cachedData != nil {
-		var user entity.User
-		if err := json.Unmarshal(cachedData, &user); err == nil {
-			return &user, nil
-		}
-		// If unmarshal fails, continue to get from database
+	if user := r.getCachedUser(ctx, id); user != nil {
+		return user, nil
 	}
 
 	// Get from database
@@ -92,8 +162,8 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Use
 	var dbErr error
 
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	user, dbErr = r.getUserByIDPostgres(ctx, db, id)
+	case *pgxpool.Pool:
+		user, dbErr = r.getUserByIDPostgres(ctx, db, id)
 	case *mongo.Client:
 		user, dbErr = r.getUserByIDMongo(ctx, db, id)
 	default:
@@ -101,17 +171,18 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Use
 	}
 
 	if dbErr != nil {
+		// Keep the read paths up through short database incidents with the copy kept past the expiration
+		if user := r.getStaleUser(ctx, id); user != nil {
+			log.Warn().Err(dbErr).Str("user_id", id.String()).Msg("Database unavailable, serving stale cached user")
+			return user, nil
+		}
 		//return nil, dbErr
 		return nil, fmt.Errorf("repository.GetByID: %w", dbErr)
 	}
 
 	// If user found, cache it
 	if user != nil {
-		if userData, err := json.Marshal(user); err == nil {
-			if err := r.cache.Set(ctx, cacheKey, userData, userCacheTTL); err != nil {
-				log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to cache user")
-			}
-		}
+		r.cacheUser(ctx, user)
 	}
 
 	return user, nil
@@ -119,37 +190,93 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Use
 
 // GetByEmail retrieves a user by email
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	// Try to resolve through the email index first
+	if user := r.getUserByIndex(ctx, userEmailIndexPrefix+email, func(u *entity.User) bool {
+		return u.Email == email
+	}); user != nil {
+		return user, nil
+	}
+
 	// Get from database
+	var user *entity.User
+	var err error
+
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	return r.getUserByEmailPostgres(ctx, db, email)
+	case *pgxpool.Pool:
+		user, err = r.getUserByEmailPostgres(ctx, db, email)
 	case *mongo.Client:
-		return r.getUserByEmailMongo(ctx, db, email)
+		user, err = r.getUserByEmailMongo(ctx, db, email)
 	default:
 		return nil, errors.New("unsupported database type")
 	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil {
+		r.cacheUser(ctx, user)
+	}
+
+	return user, nil
 }
 
 // GetByUsername retrieves a user by username
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	// Try to resolve through the username index first
+	if user := r.getUserByIndex(ctx, userUsernameIndexPrefix+username, func(u *entity.User) bool {
+		return u.Username == username
+	}); user != nil {
+		return user, nil
+	}
+
 	// Get from database
+	var user *entity.User
+	var err error
+
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		user, err = r.getUserByUsernamePostgres(ctx, db, username)
+	case *mongo.Client:
+		user, err = r.getUserByUsernameMongo(ctx, db, username)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil {
+		r.cacheUser(ctx, user)
+	}
+
+	return user, nil
+}
+
+// GetByRecoveryEmail retrieves a user by verified recovery email.
+// Lookups are rare, they back password resets only, so they are not indexed in the cache.
+func (r *userRepository) GetByRecoveryEmail(ctx context.Context, email string) (*entity.User, error) {
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	return r.getUserByUsernamePostgres(ctx, db, username)
+	case *pgxpool.Pool:
+		return r.getUserByRecoveryEmailPostgres(ctx, db, email)
 	case *mongo.Client:
-		return r.getUserByUsernameMongo(ctx, db, username)
+		return r.getUserByRecoveryEmailMongo(ctx, db, email)
 	default:
 		return nil, errors.New("unsupported database type")
 	}
 }
 
-// Update updates user information
+// Update updates user information. The status counts are dropped when the status changed, or when the previous
+// status is not cached.
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
+	previous := r.getCachedUser(ctx, user.ID)
+
 	// Update database
 	var err error
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	err = r.updateUserPostgres(ctx, db, user)
+	case *pgxpool.Pool:
+		err = r.updateUserPostgres(ctx, db, user)
 	case *mongo.Client:
 		err = r.updateUserMongo(ctx, db, user)
 	default:
@@ -160,12 +287,13 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 		return err
 	}
 
-	// Update cache
-	cacheKey := fmt.Sprintf("%s%s", userCacheKeyPrefix, user.ID.String())
-	if userData, err := json.Marshal(user); err == nil {
-		if err := r.cache.Set(ctx, cacheKey, userData, userCacheTTL); err != nil {
-			log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to update user in cache")
-		}
+	// Update cache, dropping the index keys of the previous email/username
+	r.uncacheUserIndexes(ctx, user.ID)
+	r.cacheUser(ctx, user)
+
+	r.invalidateUserLists(ctx, allUserStatuses...)
+	if previous == nil || previous.Status != user.Status {
+		r.invalidateUserStatusCounts(ctx)
 	}
 
 	return nil
@@ -177,8 +305,8 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	// Delete from database
 	var err error
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	err = r.deleteUserPostgres(ctx, db, id)
+	case *pgxpool.Pool:
+		err = r.deleteUserPostgres(ctx, db, id)
 	case *mongo.Client:
 		err = r.deleteUserMongo(ctx, db, id)
 	default:
@@ -190,28 +318,98 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	// Delete from cache
-	cacheKey := fmt.Sprintf("%s%s", userCacheKeyPrefix, id.String())
-	if err := r.cache.Delete(ctx, cacheKey); err != nil {
+	r.uncacheUserIndexes(ctx, id)
+	if err := r.uncacheUser(ctx, id); err != nil {
 		log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to delete user from cache")
 	}
 
+	r.invalidateUserLists(ctx, allUserStatuses...)
+	r.adjustUserCount(ctx, userListTagAll, -1)
+	r.invalidateUserStatusCounts(ctx)
+
 	return nil
 }
 
 // List retrieves a list of users with pagination
-func (r *userRepository) List(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
+func (r *userRepository) List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error) {
+	total, err := r.count(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Try to get the first pages from cache
+	cacheKey := r.userListCacheKey(ctx, page, limit, opts)
+	if cacheKey != "" {
+		if users, ok := r.getCachedUserList(ctx, cacheKey); ok {
+			return users, total, nil
+		}
+	}
+
 	// Calculate offset
 	offset := (page - 1) * limit
 
 	// Get from database
+	var users []*entity.User
+
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	return r.listUsersPostgres(ctx, db, limit, offset)
+	case *pgxpool.Pool:
+		users, err = r.listUsersPostgres(ctx, db, limit, offset, opts)
 	case *mongo.Client:
-		return r.listUsersMongo(ctx, db, limit, offset)
+		users, err = r.listUsersMongo(ctx, db, limit, offset, opts)
 	default:
 		return nil, 0, errors.New("unsupported database type")
 	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if cacheKey != "" {
+		r.setCachedUserList(ctx, cacheKey, users)
+	}
+
+	return users, total, nil
+}
+
+// count returns the total number of users matching a list filter
+func (r *userRepository) count(ctx context.Context, opts entity.UserListOptions) (int64, error) {
+	// Estimated totals only make sense for the whole collection
+	estimated := opts.EstimatedCount && !opts.Filtered()
+	cacheable := !estimated && userListCacheable(opts)
+
+	if cacheable {
+		if total, ok := r.getCachedUserCount(ctx, opts); ok {
+			return total, nil
+		}
+	}
+
+	var total int64
+	var err error
+
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		if estimated {
+			return r.estimatedCountUsersPostgres(ctx, db)
+		}
+		total, err = r.countUsersPostgres(ctx, db, opts)
+	case *mongo.Client:
+		if estimated {
+			return r.estimatedCountUsersMongo(ctx, db)
+		}
+		total, err = r.countUsersMongo(ctx, db, opts)
+	default:
+		return 0, errors.New("unsupported database type")
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if cacheable {
+		r.setCachedUserCount(ctx, opts, total)
+	}
+
+	return total, nil
 }
 
 // ChangePassword changes a user's password
@@ -219,8 +417,8 @@ func (r *userRepository) ChangePassword(ctx context.Context, id uuid.UUID, hashe
 	// Update database
 	var err error
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	err = r.changePasswordPostgres(ctx, db, id, hashedPassword)
+	case *pgxpool.Pool:
+		err = r.changePasswordPostgres(ctx, db, id, hashedPassword)
 	case *mongo.Client:
 		err = r.changePasswordMongo(ctx, db, id, hashedPassword)
 	default:
@@ -232,21 +430,45 @@ func (r *userRepository) ChangePassword(ctx context.Context, id uuid.UUID, hashe
 	}
 
 	// Invalidate cache
-	cacheKey := fmt.Sprintf("%s%s", userCacheKeyPrefix, id.String())
-	if err := r.cache.Delete(ctx, cacheKey); err != nil {
+	if err := r.uncacheUser(ctx, id); err != nil {
 		log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to invalidate user cache after password change")
 	}
 
 	return nil
 }
 
+// RehashPassword replaces the password hash of a user, unless the password changed since the current hash was read
+func (r *userRepository) RehashPassword(ctx context.Context, id uuid.UUID, currentHash, hashedPassword string) error {
+	// Update database
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		err = r.rehashPasswordPostgres(ctx, db, id, currentHash, hashedPassword)
+	case *mongo.Client:
+		err = r.rehashPasswordMongo(ctx, db, id, currentHash, hashedPassword)
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	if err := r.uncacheUser(ctx, id); err != nil {
+		log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to invalidate user cache after password rehash")
+	}
+
+	return nil
+}
+
 // UpdateStatus updates a user's status
 func (r *userRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
 	// Update database
 	var err error
 	switch db := r.db.GetInstance().(type) {
-	//case *pgxpool.Pool:
-	//	err = r.updateStatusPostgres(ctx, db, id, status)
+	case *pgxpool.Pool:
+		err = r.updateStatusPostgres(ctx, db, id, status)
 	case *mongo.Client:
 		err = r.updateStatusMongo(ctx, db, id, status)
 	default:
@@ -258,10 +480,170 @@ func (r *userRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 	}
 
 	// Invalidate cache
-	cacheKey := fmt.Sprintf("%s%s", userCacheKeyPrefix, id.String())
-	if err := r.cache.Delete(ctx, cacheKey); err != nil {
+	if err := r.uncacheUser(ctx, id); err != nil {
 		log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to invalidate user cache after status update")
 	}
 
+	r.invalidateUserLists(ctx, allUserStatuses...)
+	r.invalidateUserStatusCounts(ctx)
+
 	return nil
 }
+
+// AddTags adds tags to a user
+func (r *userRepository) AddTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	// Update database
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		err = r.addTagsPostgres(ctx, db, id, tags)
+	case *mongo.Client:
+		err = r.addTagsMongo(ctx, db, id, tags)
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	if err := r.uncacheUser(ctx, id); err != nil {
+		log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to invalidate user cache after adding tags")
+	}
+
+	// Cached list pages embed the user's tags
+	r.invalidateUserLists(ctx, allUserStatuses...)
+
+	return nil
+}
+
+// RemoveTags removes tags from a user
+func (r *userRepository) RemoveTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	// Update database
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		err = r.removeTagsPostgres(ctx, db, id, tags)
+	case *mongo.Client:
+		err = r.removeTagsMongo(ctx, db, id, tags)
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	if err := r.uncacheUser(ctx, id); err != nil {
+		log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to invalidate user cache after removing tags")
+	}
+
+	// Cached list pages embed the user's tags
+	r.invalidateUserLists(ctx, allUserStatuses...)
+
+	return nil
+}
+
+// ListDueForPurge retrieves the users pending deletion whose purge time has passed.
+// The purge job reads them straight from the database, so they are not cached.
+func (r *userRepository) ListDueForPurge(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		return r.listDueForPurgePostgres(ctx, db, before, limit)
+	case *mongo.Client:
+		return r.listDueForPurgeMongo(ctx, db, before, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListWaitlisted retrieves the waitlisted users in registration order.
+// Approvals read them straight from the database, so they are not cached.
+func (r *userRepository) ListWaitlisted(ctx context.Context, limit int) ([]*entity.User, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		return r.listWaitlistedPostgres(ctx, db, limit)
+	case *mongo.Client:
+		return r.listWaitlistedMongo(ctx, db, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// RecordActivity records the time a user was last active
+func (r *userRepository) RecordActivity(ctx context.Context, id uuid.UUID, at time.Time) error {
+	// Update database
+	var err error
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		err = r.recordActivityPostgres(ctx, db, id, at)
+	case *mongo.Client:
+		err = r.recordActivityMongo(ctx, db, id, at)
+	default:
+		return errors.New("unsupported database type")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	if err := r.uncacheUser(ctx, id); err != nil {
+		log.Warn().Err(err).Str("user_id", id.String()).Msg("Failed to invalidate user cache after recording activity")
+	}
+
+	return nil
+}
+
+// ListCreatedBetween retrieves the users created in a time range in creation order.
+// The lifecycle rules read them straight from the database, so they are not cached.
+func (r *userRepository) ListCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		return r.listCreatedBetweenPostgres(ctx, db, from, to, limit)
+	case *mongo.Client:
+		return r.listCreatedBetweenMongo(ctx, db, from, to, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListLastActiveBetween retrieves the users last active in a time range, the least recently active first.
+// The lifecycle rules read them straight from the database, so they are not cached.
+func (r *userRepository) ListLastActiveBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		return r.listLastActiveBetweenPostgres(ctx, db, from, to, limit)
+	case *mongo.Client:
+		return r.listLastActiveBetweenMongo(ctx, db, from, to, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// CountByStatusAndRole counts the users by status and role.
+// The business metrics aggregate them straight from the database, so they are not cached.
+func (r *userRepository) CountByStatusAndRole(ctx context.Context) ([]entity.UserCount, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		return r.countByStatusAndRolePostgres(ctx, db)
+	case *mongo.Client:
+		return r.countByStatusAndRoleMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// CountCreatedSince counts the users created since a time
+func (r *userRepository) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *pgxpool.Pool:
+		return r.countCreatedSincePostgres(ctx, db, since)
+	case *mongo.Client:
+		return r.countCreatedSinceMongo(ctx, db, since)
+	default:
+		return 0, errors.New("unsupported database type")
+	}
+}
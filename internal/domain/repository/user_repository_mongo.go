@@ -24,6 +24,22 @@ func (r *userRepository) createUserMongo(ctx context.Context, client *mongo.Clie
 	return nil
 }
 
+// createUsersMongo creates users in MongoDB in a single unordered insert
+func (r *userRepository) createUsersMongo(ctx context.Context, client *mongo.Client, users []*entity.User) error {
+	collection := client.Database("user_service").Collection("users")
+
+	documents := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		documents = append(documents, user)
+	}
+
+	if _, err := collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false)); err != nil {
+		log.Error().Err(err).Int("users", len(users)).Msg("Failed to create users in MongoDB")
+		return fmt.Errorf("failed to create users: %w", err)
+	}
+	return nil
+}
+
 // getUserByIDMongo gets a user by ID from MongoDB
 func (r *userRepository) getUserByIDMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.User, error) {
 	collection := client.Database("user_service").Collection("users")
@@ -60,6 +76,24 @@ func (r *userRepository) getUserByEmailMongo(ctx context.Context, client *mongo.
 	return &user, nil
 }
 
+// getUserByRecoveryEmailMongo gets a user by verified recovery email from MongoDB
+func (r *userRepository) getUserByRecoveryEmailMongo(ctx context.Context, client *mongo.Client, email string) (*entity.User, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	var user entity.User
+	err := collection.FindOne(ctx, bson.M{"recovery_email": email, "recovery_email_verified": true}).Decode(&user)
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // User not found
+		}
+		log.Error().Err(err).Str("recovery_email", email).Msg("Failed to get user by recovery email from MongoDB")
+		return nil, fmt.Errorf("failed to get user by recovery email: %w", err)
+	}
+
+	return &user, nil
+}
+
 // getUserByUsernameMongo gets a user by username from MongoDB
 func (r *userRepository) getUserByUsernameMongo(ctx context.Context, client *mongo.Client, username string) (*entity.User, error) {
 	collection := client.Database("user_service").Collection("users")
@@ -90,7 +124,29 @@ func (r *userRepository) updateUserMongo(ctx context.Context, client *mongo.Clie
 			"last_name":  user.LastName,
 			"role":       user.Role,
 			"status":     user.Status,
+			"org_id":     user.OrgID,
 			"updated_at": user.UpdatedAt,
+
+			"display_name": user.DisplayName,
+			"locale":       user.Locale,
+			"phone":        user.Phone,
+			"birth_date":   user.BirthDate,
+
+			"email_verified":                user.EmailVerified,
+			"email_reverification_required": user.EmailReverificationRequired,
+			"phone_verified":                user.PhoneVerified,
+			"notification_channels":         user.NotificationChannels,
+			"lifecycle_emails_disabled":     user.LifecycleEmailsDisabled,
+
+			"recovery_email":          user.RecoveryEmail,
+			"recovery_email_verified": user.RecoveryEmailVerified,
+			"terms_accepted_at":       user.TermsAcceptedAt,
+			"password_reset_required": user.PasswordResetRequired,
+			"must_change_password":    user.MustChangePassword,
+
+			"deletion_requested_at":  user.DeletionRequestedAt,
+			"purge_at":               user.PurgeAt,
+			"status_before_deletion": user.StatusBeforeDeletion,
 		},
 	}
 
@@ -116,17 +172,34 @@ func (r *userRepository) deleteUserMongo(ctx context.Context, client *mongo.Clie
 	return nil
 }
 
+// userListFilterMongo builds the MongoDB filter of a list query
+func userListFilterMongo(opts entity.UserListOptions) bson.M {
+	filter := bson.M{}
+	if opts.Status != "" {
+		filter["status"] = opts.Status
+	}
+	if opts.OrgID != nil {
+		filter["org_id"] = *opts.OrgID
+	}
+	if opts.Role != "" {
+		filter["role"] = opts.Role
+	}
+	if opts.Tag != "" {
+		filter["tags"] = opts.Tag
+	}
+	if opts.EmailVerifiedOnly {
+		filter["email_verified"] = true
+	}
+	if opts.PhoneVerifiedOnly {
+		filter["phone_verified"] = true
+	}
+	return filter
+}
+
 // listUsersMongo lists users from MongoDB
-func (r *userRepository) listUsersMongo(ctx context.Context, client *mongo.Client, limit, offset int) ([]*entity.User, int64, error) {
+func (r *userRepository) listUsersMongo(ctx context.Context, client *mongo.Client, limit, offset int, opts entity.UserListOptions) ([]*entity.User, error) {
 	collection := client.Database("user_service").Collection("users")
 
-	// Get total count
-	total, countErr := collection.CountDocuments(ctx, bson.M{})
-	if countErr != nil {
-		log.Error().Err(countErr).Msg("Failed to count users in MongoDB")
-		return nil, 0, fmt.Errorf("failed to count users: %w", countErr)
-	}
-
 	// Set options for pagination and sorting
 	findOptions := options.Find().
 		SetLimit(int64(limit)).
@@ -134,20 +207,88 @@ func (r *userRepository) listUsersMongo(ctx context.Context, client *mongo.Clien
 		SetSort(bson.D{{Key: "created_at", Value: -1}})
 
 	// Find users
-	cursor, err := collection.Find(ctx, bson.M{}, findOptions)
+	cursor, err := collection.Find(ctx, userListFilterMongo(opts), findOptions)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list users from MongoDB")
-		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
 	defer cursor.Close(ctx)
 
 	var users []*entity.User
 	if err := cursor.All(ctx, &users); err != nil {
 		log.Error().Err(err).Msg("Failed to decode users from MongoDB")
-		return nil, 0, fmt.Errorf("failed to decode users: %w", err)
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
+// countUsersMongo counts the users matching a list query in MongoDB
+func (r *userRepository) countUsersMongo(ctx context.Context, client *mongo.Client, opts entity.UserListOptions) (int64, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	total, err := collection.CountDocuments(ctx, userListFilterMongo(opts))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count users in MongoDB")
+		return 0, fmt.Errorf("failed to count users: %w", err)
 	}
 
-	return users, total, nil
+	return total, nil
+}
+
+// estimatedCountUsersMongo returns the approximate number of users from MongoDB collection metadata
+func (r *userRepository) estimatedCountUsersMongo(ctx context.Context, client *mongo.Client) (int64, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	total, err := collection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to estimate user count in MongoDB")
+		return 0, fmt.Errorf("failed to estimate user count: %w", err)
+	}
+
+	return total, nil
+}
+
+// countByStatusAndRoleMongo counts the users by status and role in MongoDB
+func (r *userRepository) countByStatusAndRoleMongo(ctx context.Context, client *mongo.Client) ([]entity.UserCount, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"status": "$status", "role": "$role"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "status": "$_id.status", "role": "$_id.role", "count": 1}}},
+		{{Key: "$sort", Value: bson.D{{Key: "status", Value: 1}, {Key: "role", Value: 1}}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count users by status and role in MongoDB")
+		return nil, fmt.Errorf("failed to count users by status and role: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var counts []entity.UserCount
+	if err := cursor.All(ctx, &counts); err != nil {
+		log.Error().Err(err).Msg("Failed to decode user counts from MongoDB")
+		return nil, fmt.Errorf("failed to decode user counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// countCreatedSinceMongo counts the users created since a time in MongoDB
+func (r *userRepository) countCreatedSinceMongo(ctx context.Context, client *mongo.Client, since time.Time) (int64, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	total, err := collection.CountDocuments(ctx, bson.M{"created_at": bson.M{"$gte": since}})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count users by creation in MongoDB")
+		return 0, fmt.Errorf("failed to count users by creation: %w", err)
+	}
+
+	return total, nil
 }
 
 // changePasswordMongo changes a user's password in MongoDB
@@ -170,6 +311,26 @@ func (r *userRepository) changePasswordMongo(ctx context.Context, client *mongo.
 	return nil
 }
 
+// rehashPasswordMongo replaces a user's password hash in MongoDB if it is still the current one. The password
+// is the same, so the update time is left alone.
+func (r *userRepository) rehashPasswordMongo(ctx context.Context, client *mongo.Client, id uuid.UUID, currentHash, hashedPassword string) error {
+	collection := client.Database("user_service").Collection("users")
+
+	update := bson.M{
+		"$set": bson.M{
+			"password": hashedPassword,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id, "password": currentHash}, update)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to rehash password in MongoDB")
+		return fmt.Errorf("failed to rehash password: %w", err)
+	}
+
+	return nil
+}
+
 // updateStatusMongo updates a user's status in MongoDB
 func (r *userRepository) updateStatusMongo(ctx context.Context, client *mongo.Client, id uuid.UUID, status string) error {
 	collection := client.Database("user_service").Collection("users")
@@ -189,3 +350,164 @@ func (r *userRepository) updateStatusMongo(ctx context.Context, client *mongo.Cl
 
 	return nil
 }
+
+// addTagsMongo adds tags to a user in MongoDB
+func (r *userRepository) addTagsMongo(ctx context.Context, client *mongo.Client, id uuid.UUID, tags []string) error {
+	collection := client.Database("user_service").Collection("users")
+
+	update := bson.M{
+		"$addToSet": bson.M{"tags": bson.M{"$each": tags}},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to add tags in MongoDB")
+		return fmt.Errorf("failed to add tags: %w", err)
+	}
+
+	return nil
+}
+
+// removeTagsMongo removes tags from a user in MongoDB
+func (r *userRepository) removeTagsMongo(ctx context.Context, client *mongo.Client, id uuid.UUID, tags []string) error {
+	collection := client.Database("user_service").Collection("users")
+
+	update := bson.M{
+		"$pull": bson.M{"tags": bson.M{"$in": tags}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to remove tags in MongoDB")
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
+
+	return nil
+}
+
+// listDueForPurgeMongo lists the users due for purge from MongoDB
+func (r *userRepository) listDueForPurgeMongo(ctx context.Context, client *mongo.Client, before time.Time, limit int) ([]*entity.User, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	filter := bson.M{
+		"status":   entity.UserStatusPendingDeletion,
+		"purge_at": bson.M{"$lte": before},
+	}
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "purge_at", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users due for purge from MongoDB")
+		return nil, fmt.Errorf("failed to list users due for purge: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entity.User
+	if err := cursor.All(ctx, &users); err != nil {
+		log.Error().Err(err).Msg("Failed to decode users due for purge from MongoDB")
+		return nil, fmt.Errorf("failed to decode users due for purge: %w", err)
+	}
+
+	return users, nil
+}
+
+// listWaitlistedMongo lists the waitlisted users from MongoDB
+func (r *userRepository) listWaitlistedMongo(ctx context.Context, client *mongo.Client, limit int) ([]*entity.User, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	filter := bson.M{"status": entity.UserStatusWaitlisted}
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list waitlisted users from MongoDB")
+		return nil, fmt.Errorf("failed to list waitlisted users: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entity.User
+	if err := cursor.All(ctx, &users); err != nil {
+		log.Error().Err(err).Msg("Failed to decode waitlisted users from MongoDB")
+		return nil, fmt.Errorf("failed to decode waitlisted users: %w", err)
+	}
+
+	return users, nil
+}
+
+// recordActivityMongo records the time a user was last active in MongoDB, leaving updated_at untouched as the
+// profile did not change
+func (r *userRepository) recordActivityMongo(ctx context.Context, client *mongo.Client, id uuid.UUID, at time.Time) error {
+	collection := client.Database("user_service").Collection("users")
+
+	update := bson.M{
+		"$set": bson.M{"last_active_at": at},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to record activity in MongoDB")
+		return fmt.Errorf("failed to record activity: %w", err)
+	}
+
+	return nil
+}
+
+// listCreatedBetweenMongo lists the users created in a time range from MongoDB
+func (r *userRepository) listCreatedBetweenMongo(ctx context.Context, client *mongo.Client, from, to time.Time, limit int) ([]*entity.User, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	filter := bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}
+	findOptions := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users by creation from MongoDB")
+		return nil, fmt.Errorf("failed to list users by creation: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entity.User
+	if err := cursor.All(ctx, &users); err != nil {
+		log.Error().Err(err).Msg("Failed to decode users by creation from MongoDB")
+		return nil, fmt.Errorf("failed to decode users by creation: %w", err)
+	}
+
+	return users, nil
+}
+
+// listLastActiveBetweenMongo lists the users last active in a time range from MongoDB. Users never active are
+// compared by creation, which a find cannot sort on, hence the aggregation.
+func (r *userRepository) listLastActiveBetweenMongo(ctx context.Context, client *mongo.Client, from, to time.Time, limit int) ([]*entity.User, error) {
+	collection := client.Database("user_service").Collection("users")
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$addFields", Value: bson.M{"last_activity": bson.M{"$ifNull": bson.A{"$last_active_at", "$created_at"}}}}},
+		{{Key: "$match", Value: bson.M{"last_activity": bson.M{"$gte": from, "$lt": to}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "last_activity", Value: 1}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+		{{Key: "$project", Value: bson.M{"last_activity": 0}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users by activity from MongoDB")
+		return nil, fmt.Errorf("failed to list users by activity: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entity.User
+	if err := cursor.All(ctx, &users); err != nil {
+		log.Error().Err(err).Msg("Failed to decode users by activity from MongoDB")
+		return nil, fmt.Errorf("failed to decode users by activity: %w", err)
+	}
+
+	return users, nil
+}
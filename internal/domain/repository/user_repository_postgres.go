@@ -0,0 +1,530 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// userColumnsPostgres lists the columns of the users table, in the order scanned by scanUserPostgres
+const userColumnsPostgres = `id, email, username, password, first_name, last_name, display_name, locale, phone,
+	birth_date, role, status, org_id, email_verified, phone_verified, email_reverification_required, recovery_email,
+	recovery_email_verified, notification_channels, tags, password_reset_required, must_change_password,
+	terms_accepted_at, deletion_requested_at, purge_at, status_before_deletion, lifecycle_emails_disabled,
+	last_active_at, created_at, updated_at`
+
+// userValuesPostgres returns the values of a user in the order of userColumnsPostgres
+func userValuesPostgres(user *entity.User) []any {
+	return []any{
+		user.ID, user.Email, user.Username, user.Password, user.FirstName, user.LastName, user.DisplayName,
+		user.Locale, user.Phone, user.BirthDate, user.Role, user.Status, user.OrgID, user.EmailVerified,
+		user.PhoneVerified, user.EmailReverificationRequired, user.RecoveryEmail, user.RecoveryEmailVerified,
+		user.NotificationChannels, user.Tags, user.PasswordResetRequired, user.MustChangePassword, user.TermsAcceptedAt,
+		user.DeletionRequestedAt, user.PurgeAt, user.StatusBeforeDeletion, user.LifecycleEmailsDisabled,
+		user.LastActiveAt, user.CreatedAt, user.UpdatedAt,
+	}
+}
+
+// scanUserPostgres scans a row selected with userColumnsPostgres
+func scanUserPostgres(row pgx.Row) (*entity.User, error) {
+	var user entity.User
+	err := row.Scan(
+		&user.ID, &user.Email, &user.Username, &user.Password, &user.FirstName, &user.LastName, &user.DisplayName,
+		&user.Locale, &user.Phone, &user.BirthDate, &user.Role, &user.Status, &user.OrgID, &user.EmailVerified,
+		&user.PhoneVerified, &user.EmailReverificationRequired, &user.RecoveryEmail, &user.RecoveryEmailVerified,
+		&user.NotificationChannels, &user.Tags, &user.PasswordResetRequired, &user.MustChangePassword, &user.TermsAcceptedAt,
+		&user.DeletionRequestedAt, &user.PurgeAt, &user.StatusBeforeDeletion, &user.LifecycleEmailsDisabled,
+		&user.LastActiveAt, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// createUserPostgres creates a user in PostgreSQL
+func (r *userRepository) createUserPostgres(ctx context.Context, pool *pgxpool.Pool, user *entity.User) error {
+	query := `INSERT INTO users (` + userColumnsPostgres + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22,
+			$23, $24, $25, $26, $27, $28, $29, $30)`
+
+	_, err := pool.Exec(ctx, query, userValuesPostgres(user)...)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to create user in PostgreSQL")
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	return nil
+}
+
+// createUsersPostgres creates users in PostgreSQL with a single COPY
+func (r *userRepository) createUsersPostgres(ctx context.Context, pool *pgxpool.Pool, users []*entity.User) error {
+	columns := strings.Split(userColumnsPostgres, ",")
+	for i, column := range columns {
+		columns[i] = strings.TrimSpace(column)
+	}
+
+	rows := pgx.CopyFromSlice(len(users), func(i int) ([]any, error) {
+		return userValuesPostgres(users[i]), nil
+	})
+
+	if _, err := pool.CopyFrom(ctx, pgx.Identifier{"users"}, columns, rows); err != nil {
+		log.Error().Err(err).Int("users", len(users)).Msg("Failed to create users in PostgreSQL")
+		return fmt.Errorf("failed to create users: %w", err)
+	}
+	return nil
+}
+
+// getUserPostgres gets the user matching a condition from PostgreSQL, nil if there is none
+func (r *userRepository) getUserPostgres(ctx context.Context, pool *pgxpool.Pool, condition string, args ...any) (*entity.User, error) {
+	query := `SELECT ` + userColumnsPostgres + ` FROM users WHERE ` + condition
+
+	user, err := scanUserPostgres(pool.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil // User not found
+	}
+	return user, err
+}
+
+// getUserByIDPostgres gets a user by ID from PostgreSQL
+func (r *userRepository) getUserByIDPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID) (*entity.User, error) {
+	user, err := r.getUserPostgres(ctx, pool, "id = $1", id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to get user from PostgreSQL")
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// getUserByEmailPostgres gets a user by email from PostgreSQL
+func (r *userRepository) getUserByEmailPostgres(ctx context.Context, pool *pgxpool.Pool, email string) (*entity.User, error) {
+	user, err := r.getUserPostgres(ctx, pool, "email = $1", email)
+	if err != nil {
+		log.Error().Err(err).Str("email", email).Msg("Failed to get user by email from PostgreSQL")
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+	return user, nil
+}
+
+// getUserByRecoveryEmailPostgres gets a user by verified recovery email from PostgreSQL
+func (r *userRepository) getUserByRecoveryEmailPostgres(ctx context.Context, pool *pgxpool.Pool, email string) (*entity.User, error) {
+	user, err := r.getUserPostgres(ctx, pool, "recovery_email = $1 AND recovery_email_verified", email)
+	if err != nil {
+		log.Error().Err(err).Str("recovery_email", email).Msg("Failed to get user by recovery email from PostgreSQL")
+		return nil, fmt.Errorf("failed to get user by recovery email: %w", err)
+	}
+	return user, nil
+}
+
+// getUserByUsernamePostgres gets a user by username from PostgreSQL
+func (r *userRepository) getUserByUsernamePostgres(ctx context.Context, pool *pgxpool.Pool, username string) (*entity.User, error) {
+	user, err := r.getUserPostgres(ctx, pool, "username = $1", username)
+	if err != nil {
+		log.Error().Err(err).Str("username", username).Msg("Failed to get user by username from PostgreSQL")
+		return nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+	return user, nil
+}
+
+// updateUserPostgres updates a user in PostgreSQL, leaving the password and tags to their dedicated updates
+func (r *userRepository) updateUserPostgres(ctx context.Context, pool *pgxpool.Pool, user *entity.User) error {
+	query := `
+		UPDATE users
+		SET email = $1, username = $2, first_name = $3, last_name = $4, role = $5, status = $6, org_id = $7,
+		    updated_at = $8, display_name = $9, locale = $10, phone = $11, birth_date = $12, email_verified = $13,
+		    email_reverification_required = $14, phone_verified = $15, notification_channels = $16,
+		    recovery_email = $17, recovery_email_verified = $18, terms_accepted_at = $19,
+		    password_reset_required = $20, deletion_requested_at = $21, purge_at = $22, status_before_deletion = $23,
+		    lifecycle_emails_disabled = $24, must_change_password = $25
+		WHERE id = $26
+	`
+
+	_, err := pool.Exec(ctx, query,
+		user.Email, user.Username, user.FirstName, user.LastName, user.Role, user.Status, user.OrgID,
+		user.UpdatedAt, user.DisplayName, user.Locale, user.Phone, user.BirthDate, user.EmailVerified,
+		user.EmailReverificationRequired, user.PhoneVerified, user.NotificationChannels,
+		user.RecoveryEmail, user.RecoveryEmailVerified, user.TermsAcceptedAt,
+		user.PasswordResetRequired, user.DeletionRequestedAt, user.PurgeAt, user.StatusBeforeDeletion,
+		user.LifecycleEmailsDisabled, user.MustChangePassword, user.ID,
+	)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to update user in PostgreSQL")
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+// deleteUserPostgres deletes a user from PostgreSQL
+func (r *userRepository) deleteUserPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID) error {
+	_, err := pool.Exec(ctx, "DELETE FROM users WHERE id = $1", id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to delete user from PostgreSQL")
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
+
+// userListFilterPostgres builds the WHERE clause of a list query and its arguments
+func userListFilterPostgres(opts entity.UserListOptions) (string, []any) {
+	var conditions []string
+	var args []any
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if opts.Status != "" {
+		add("status = $%d", opts.Status)
+	}
+	if opts.OrgID != nil {
+		add("org_id = $%d", *opts.OrgID)
+	}
+	if opts.Role != "" {
+		add("role = $%d", opts.Role)
+	}
+	if opts.Tag != "" {
+		add("tags @> ARRAY[$%d::text]", opts.Tag)
+	}
+	if opts.EmailVerifiedOnly {
+		conditions = append(conditions, "email_verified")
+	}
+	if opts.PhoneVerifiedOnly {
+		conditions = append(conditions, "phone_verified")
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// listUsersPostgres lists users from PostgreSQL
+func (r *userRepository) listUsersPostgres(ctx context.Context, pool *pgxpool.Pool, limit, offset int, opts entity.UserListOptions) ([]*entity.User, error) {
+	where, args := userListFilterPostgres(opts)
+	query := fmt.Sprintf(`SELECT %s FROM users%s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
+		userColumnsPostgres, where, len(args)+1, len(args)+2)
+
+	rows, err := pool.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users from PostgreSQL")
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user, err := scanUserPostgres(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to scan user row from PostgreSQL")
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to list users from PostgreSQL")
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+// listDueForPurgePostgres lists the users due for purge from PostgreSQL
+func (r *userRepository) listDueForPurgePostgres(ctx context.Context, pool *pgxpool.Pool, before time.Time, limit int) ([]*entity.User, error) {
+	query := `SELECT ` + userColumnsPostgres + ` FROM users
+		WHERE status = $1 AND purge_at <= $2
+		ORDER BY purge_at
+		LIMIT $3`
+
+	rows, err := pool.Query(ctx, query, entity.UserStatusPendingDeletion, before, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users due for purge from PostgreSQL")
+		return nil, fmt.Errorf("failed to list users due for purge: %w", err)
+	}
+
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user, err := scanUserPostgres(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to scan user row from PostgreSQL")
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to list users due for purge from PostgreSQL")
+		return nil, fmt.Errorf("failed to list users due for purge: %w", err)
+	}
+
+	return users, nil
+}
+
+// listWaitlistedPostgres lists the waitlisted users from PostgreSQL
+func (r *userRepository) listWaitlistedPostgres(ctx context.Context, pool *pgxpool.Pool, limit int) ([]*entity.User, error) {
+	query := `SELECT ` + userColumnsPostgres + ` FROM users
+		WHERE status = $1
+		ORDER BY created_at
+		LIMIT $2`
+
+	rows, err := pool.Query(ctx, query, entity.UserStatusWaitlisted, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list waitlisted users from PostgreSQL")
+		return nil, fmt.Errorf("failed to list waitlisted users: %w", err)
+	}
+
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user, err := scanUserPostgres(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to scan user row from PostgreSQL")
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to list waitlisted users from PostgreSQL")
+		return nil, fmt.Errorf("failed to list waitlisted users: %w", err)
+	}
+
+	return users, nil
+}
+
+// recordActivityPostgres records the time a user was last active in PostgreSQL, leaving updated_at untouched as
+// the profile did not change
+func (r *userRepository) recordActivityPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE users
+		SET last_active_at = $1
+		WHERE id = $2
+	`
+
+	_, err := pool.Exec(ctx, query, at, id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to record activity in PostgreSQL")
+		return fmt.Errorf("failed to record activity: %w", err)
+	}
+
+	return nil
+}
+
+// listCreatedBetweenPostgres lists the users created in a time range from PostgreSQL
+func (r *userRepository) listCreatedBetweenPostgres(ctx context.Context, pool *pgxpool.Pool, from, to time.Time, limit int) ([]*entity.User, error) {
+	query := `SELECT ` + userColumnsPostgres + ` FROM users
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at
+		LIMIT $3`
+
+	return r.queryUsersPostgres(ctx, pool, "by creation", query, from, to, limit)
+}
+
+// listLastActiveBetweenPostgres lists the users last active in a time range from PostgreSQL
+func (r *userRepository) listLastActiveBetweenPostgres(ctx context.Context, pool *pgxpool.Pool, from, to time.Time, limit int) ([]*entity.User, error) {
+	query := `SELECT ` + userColumnsPostgres + ` FROM users
+		WHERE COALESCE(last_active_at, created_at) >= $1 AND COALESCE(last_active_at, created_at) < $2
+		ORDER BY COALESCE(last_active_at, created_at)
+		LIMIT $3`
+
+	return r.queryUsersPostgres(ctx, pool, "by activity", query, from, to, limit)
+}
+
+// queryUsersPostgres runs a query selecting userColumnsPostgres, what names the listed users in errors
+func (r *userRepository) queryUsersPostgres(ctx context.Context, pool *pgxpool.Pool, what, query string, args ...any) ([]*entity.User, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users " + what + " from PostgreSQL")
+		return nil, fmt.Errorf("failed to list users %s: %w", what, err)
+	}
+
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user, err := scanUserPostgres(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to scan user row from PostgreSQL")
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to list users " + what + " from PostgreSQL")
+		return nil, fmt.Errorf("failed to list users %s: %w", what, err)
+	}
+
+	return users, nil
+}
+
+// countUsersPostgres counts the users matching a list query in PostgreSQL
+func (r *userRepository) countUsersPostgres(ctx context.Context, pool *pgxpool.Pool, opts entity.UserListOptions) (int64, error) {
+	where, args := userListFilterPostgres(opts)
+
+	var total int64
+	if err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM users"+where, args...).Scan(&total); err != nil {
+		log.Error().Err(err).Msg("Failed to count users in PostgreSQL")
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return total, nil
+}
+
+// estimatedCountUsersPostgres returns the approximate number of users from the PostgreSQL planner statistics,
+// falling back to an exact count while the table has never been analyzed
+func (r *userRepository) estimatedCountUsersPostgres(ctx context.Context, pool *pgxpool.Pool) (int64, error) {
+	var total int64
+	err := pool.QueryRow(ctx, "SELECT reltuples::bigint FROM pg_class WHERE oid = 'users'::regclass").Scan(&total)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to estimate user count in PostgreSQL")
+		return 0, fmt.Errorf("failed to estimate user count: %w", err)
+	}
+
+	if total < 0 {
+		return r.countUsersPostgres(ctx, pool, entity.UserListOptions{})
+	}
+	return total, nil
+}
+
+// countByStatusAndRolePostgres counts the users by status and role in PostgreSQL
+func (r *userRepository) countByStatusAndRolePostgres(ctx context.Context, pool *pgxpool.Pool) ([]entity.UserCount, error) {
+	rows, err := pool.Query(ctx, "SELECT status, role, COUNT(*) FROM users GROUP BY status, role ORDER BY status, role")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count users by status and role in PostgreSQL")
+		return nil, fmt.Errorf("failed to count users by status and role: %w", err)
+	}
+
+	defer rows.Close()
+
+	var counts []entity.UserCount
+	for rows.Next() {
+		var count entity.UserCount
+		if err := rows.Scan(&count.Status, &count.Role, &count.Count); err != nil {
+			log.Error().Err(err).Msg("Failed to scan user count row from PostgreSQL")
+			return nil, fmt.Errorf("failed to scan user count row: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("Failed to count users by status and role in PostgreSQL")
+		return nil, fmt.Errorf("failed to count users by status and role: %w", err)
+	}
+
+	return counts, nil
+}
+
+// countCreatedSincePostgres counts the users created since a time in PostgreSQL
+func (r *userRepository) countCreatedSincePostgres(ctx context.Context, pool *pgxpool.Pool, since time.Time) (int64, error) {
+	var total int64
+	if err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM users WHERE created_at >= $1", since).Scan(&total); err != nil {
+		log.Error().Err(err).Msg("Failed to count users by creation in PostgreSQL")
+		return 0, fmt.Errorf("failed to count users by creation: %w", err)
+	}
+
+	return total, nil
+}
+
+// changePasswordPostgres changes a user's password in PostgreSQL
+func (r *userRepository) changePasswordPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID, hashedPassword string) error {
+	query := `
+		UPDATE users
+		SET password = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	_, err := pool.Exec(ctx, query, hashedPassword, time.Now(), id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to change password in PostgreSQL")
+		return fmt.Errorf("failed to change password: %w", err)
+	}
+
+	return nil
+}
+
+// rehashPasswordPostgres replaces a user's password hash in PostgreSQL if it is still the current one. The
+// password is the same, so the update time is left alone.
+func (r *userRepository) rehashPasswordPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID, currentHash, hashedPassword string) error {
+	query := `
+		UPDATE users
+		SET password = $1
+		WHERE id = $2 AND password = $3
+	`
+
+	_, err := pool.Exec(ctx, query, hashedPassword, id, currentHash)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to rehash password in PostgreSQL")
+		return fmt.Errorf("failed to rehash password: %w", err)
+	}
+
+	return nil
+}
+
+// updateStatusPostgres updates a user's status in PostgreSQL
+func (r *userRepository) updateStatusPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID, status string) error {
+	query := `
+		UPDATE users
+		SET status = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	_, err := pool.Exec(ctx, query, status, time.Now(), id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to update status in PostgreSQL")
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// addTagsPostgres adds tags to a user in PostgreSQL
+func (r *userRepository) addTagsPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID, tags []string) error {
+	query := `
+		UPDATE users
+		SET tags = ARRAY(
+		        SELECT tag FROM unnest(COALESCE(tags, '{}') || $1::text[]) WITH ORDINALITY AS t(tag, n)
+		        GROUP BY tag ORDER BY MIN(n)
+		    ),
+		    updated_at = $2
+		WHERE id = $3
+	`
+
+	_, err := pool.Exec(ctx, query, tags, time.Now(), id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to add tags in PostgreSQL")
+		return fmt.Errorf("failed to add tags: %w", err)
+	}
+
+	return nil
+}
+
+// removeTagsPostgres removes tags from a user in PostgreSQL
+func (r *userRepository) removeTagsPostgres(ctx context.Context, pool *pgxpool.Pool, id uuid.UUID, tags []string) error {
+	query := `
+		UPDATE users
+		SET tags = ARRAY(SELECT tag FROM unnest(tags) AS tag WHERE tag <> ALL($1::text[])),
+		    updated_at = $2
+		WHERE id = $3
+	`
+
+	_, err := pool.Exec(ctx, query, tags, time.Now(), id)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", id.String()).Msg("Failed to remove tags in PostgreSQL")
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
+
+	return nil
+}
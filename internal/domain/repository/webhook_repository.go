@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WebhookRepository defines the interface for webhook endpoint and delivery repository operations
+type WebhookRepository interface {
+	// CreateEndpoint creates a new webhook endpoint
+	CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+
+	// GetEndpoint gets a webhook endpoint by ID, returns nil if the endpoint does not exist
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error)
+
+	// ListEndpoints lists all webhook endpoints ordered by creation date
+	ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error)
+
+	// UpdateEndpoint updates a webhook endpoint
+	UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+
+	// DeleteEndpoint deletes a webhook endpoint along with its deliveries
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	// CreateDelivery creates a new webhook delivery
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// GetDelivery gets a webhook delivery by ID, returns nil if the delivery does not exist
+	GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+
+	// UpdateDelivery updates a webhook delivery
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// ListDeliveries lists the deliveries matching a filter, newest first
+	ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+
+	// ListDueDeliveries lists the pending deliveries due at a time, oldest due first,
+	// leaving out the deliveries of the excluded endpoints
+	ListDueDeliveries(ctx context.Context, at time.Time, excludedEndpoints []uuid.UUID, limit int) ([]*entity.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	db db.Database
+}
+
+// NewWebhookRepository creates a new WebhookRepository
+func NewWebhookRepository(db db.Database) WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// CreateEndpoint creates a new webhook endpoint
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createEndpointMongo(ctx, db, endpoint)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetEndpoint retrieves a webhook endpoint by ID
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getEndpointMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListEndpoints lists all webhook endpoints
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listEndpointsMongo(ctx, db)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// UpdateEndpoint updates a webhook endpoint
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateEndpointMongo(ctx, db, endpoint)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// DeleteEndpoint deletes a webhook endpoint along with its deliveries
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.deleteEndpointMongo(ctx, db, id)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// CreateDelivery creates a new webhook delivery
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.createDeliveryMongo(ctx, db, delivery)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// GetDelivery retrieves a webhook delivery by ID
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.getDeliveryMongo(ctx, db, id)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// UpdateDelivery updates a webhook delivery
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.updateDeliveryMongo(ctx, db, delivery)
+	default:
+		return errors.New("unsupported database type")
+	}
+}
+
+// ListDeliveries lists the deliveries matching a filter
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listDeliveriesMongo(ctx, db, filter)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
+
+// ListDueDeliveries lists the pending deliveries due at a time
+func (r *webhookRepository) ListDueDeliveries(ctx context.Context, at time.Time, excludedEndpoints []uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	switch db := r.db.GetInstance().(type) {
+	case *mongo.Client:
+		return r.listDueDeliveriesMongo(ctx, db, at, excludedEndpoints, limit)
+	default:
+		return nil, errors.New("unsupported database type")
+	}
+}
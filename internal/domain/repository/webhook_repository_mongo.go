@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// createEndpointMongo creates a webhook endpoint in MongoDB
+func (r *webhookRepository) createEndpointMongo(ctx context.Context, client *mongo.Client, endpoint *entity.WebhookEndpoint) error {
+	collection := client.Database("user_service").Collection("webhook_endpoints")
+	_, err := collection.InsertOne(ctx, endpoint)
+	if err != nil {
+		log.Error().Err(err).Str("endpoint_id", endpoint.ID.String()).Msg("Failed to create webhook endpoint in MongoDB")
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// getEndpointMongo gets a webhook endpoint by ID from MongoDB
+func (r *webhookRepository) getEndpointMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	collection := client.Database("user_service").Collection("webhook_endpoints")
+
+	var endpoint entity.WebhookEndpoint
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&endpoint)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Endpoint not found
+		}
+		log.Error().Err(err).Str("endpoint_id", id.String()).Msg("Failed to get webhook endpoint from MongoDB")
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	return &endpoint, nil
+}
+
+// listEndpointsMongo lists all webhook endpoints from MongoDB
+func (r *webhookRepository) listEndpointsMongo(ctx context.Context, client *mongo.Client) ([]*entity.WebhookEndpoint, error) {
+	collection := client.Database("user_service").Collection("webhook_endpoints")
+
+	cursor, err := collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list webhook endpoints from MongoDB")
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var endpoints []*entity.WebhookEndpoint
+	if err := cursor.All(ctx, &endpoints); err != nil {
+		log.Error().Err(err).Msg("Failed to decode webhook endpoints from MongoDB")
+		return nil, fmt.Errorf("failed to decode webhook endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// updateEndpointMongo updates a webhook endpoint in MongoDB
+func (r *webhookRepository) updateEndpointMongo(ctx context.Context, client *mongo.Client, endpoint *entity.WebhookEndpoint) error {
+	collection := client.Database("user_service").Collection("webhook_endpoints")
+
+	update := bson.M{
+		"$set": bson.M{
+			"url":         endpoint.URL,
+			"event_types": endpoint.EventTypes,
+			"paused":      endpoint.Paused,
+			"updated_at":  endpoint.UpdatedAt,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": endpoint.ID}, update)
+	if err != nil {
+		log.Error().Err(err).Str("endpoint_id", endpoint.ID.String()).Msg("Failed to update webhook endpoint in MongoDB")
+		return fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// deleteEndpointMongo deletes a webhook endpoint and its deliveries from MongoDB
+func (r *webhookRepository) deleteEndpointMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) error {
+	database := client.Database("user_service")
+
+	if _, err := database.Collection("webhook_endpoints").DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		log.Error().Err(err).Str("endpoint_id", id.String()).Msg("Failed to delete webhook endpoint from MongoDB")
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	if _, err := database.Collection("webhook_deliveries").DeleteMany(ctx, bson.M{"endpoint_id": id}); err != nil {
+		log.Error().Err(err).Str("endpoint_id", id.String()).Msg("Failed to delete webhook deliveries from MongoDB")
+		return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// createDeliveryMongo creates a webhook delivery in MongoDB
+func (r *webhookRepository) createDeliveryMongo(ctx context.Context, client *mongo.Client, delivery *entity.WebhookDelivery) error {
+	collection := client.Database("user_service").Collection("webhook_deliveries")
+	_, err := collection.InsertOne(ctx, delivery)
+	if err != nil {
+		log.Error().Err(err).Str("delivery_id", delivery.ID.String()).Msg("Failed to create webhook delivery in MongoDB")
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// getDeliveryMongo gets a webhook delivery by ID from MongoDB
+func (r *webhookRepository) getDeliveryMongo(ctx context.Context, client *mongo.Client, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	collection := client.Database("user_service").Collection("webhook_deliveries")
+
+	var delivery entity.WebhookDelivery
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil // Delivery not found
+		}
+		log.Error().Err(err).Str("delivery_id", id.String()).Msg("Failed to get webhook delivery from MongoDB")
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// updateDeliveryMongo updates a webhook delivery in MongoDB
+func (r *webhookRepository) updateDeliveryMongo(ctx context.Context, client *mongo.Client, delivery *entity.WebhookDelivery) error {
+	collection := client.Database("user_service").Collection("webhook_deliveries")
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":           delivery.Status,
+			"attempts":         delivery.Attempts,
+			"last_status_code": delivery.LastStatusCode,
+			"last_error":       delivery.LastError,
+			"next_attempt_at":  delivery.NextAttemptAt,
+			"delivered_at":     delivery.DeliveredAt,
+			"updated_at":       delivery.UpdatedAt,
+		},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": delivery.ID}, update)
+	if err != nil {
+		log.Error().Err(err).Str("delivery_id", delivery.ID.String()).Msg("Failed to update webhook delivery in MongoDB")
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// listDeliveriesMongo lists the webhook deliveries matching a filter from MongoDB
+func (r *webhookRepository) listDeliveriesMongo(ctx context.Context, client *mongo.Client, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	collection := client.Database("user_service").Collection("webhook_deliveries")
+
+	query := bson.M{}
+	if filter.EndpointID != uuid.Nil {
+		query["endpoint_id"] = filter.EndpointID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		opts.SetSkip(int64(filter.Offset))
+	}
+
+	return r.findDeliveriesMongo(ctx, collection, query, opts)
+}
+
+// listDueDeliveriesMongo lists the pending webhook deliveries due at a time from MongoDB
+func (r *webhookRepository) listDueDeliveriesMongo(ctx context.Context, client *mongo.Client, at time.Time, excludedEndpoints []uuid.UUID, limit int) ([]*entity.WebhookDelivery, error) {
+	collection := client.Database("user_service").Collection("webhook_deliveries")
+
+	query := bson.M{
+		"status":          entity.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": at},
+	}
+	if len(excludedEndpoints) > 0 {
+		query["endpoint_id"] = bson.M{"$nin": excludedEndpoints}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).SetLimit(int64(limit))
+
+	return r.findDeliveriesMongo(ctx, collection, query, opts)
+}
+
+// findDeliveriesMongo runs a query on the webhook deliveries collection
+func (r *webhookRepository) findDeliveriesMongo(ctx context.Context, collection *mongo.Collection, query bson.M, opts *options.FindOptions) ([]*entity.WebhookDelivery, error) {
+	cursor, err := collection.Find(ctx, query, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list webhook deliveries from MongoDB")
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*entity.WebhookDelivery
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		log.Error().Err(err).Msg("Failed to decode webhook deliveries from MongoDB")
+		return nil, fmt.Errorf("failed to decode webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
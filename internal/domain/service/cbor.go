@@ -0,0 +1,126 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxCBORDepth bounds the nesting of decoded CBOR items, attestation objects and COSE keys nest two levels at most
+const maxCBORDepth = 8
+
+var errCBORTruncated = errors.New("truncated CBOR data")
+
+// decodeCBOR decodes the first CBOR item of data, the subset WebAuthn uses: integers, byte and text strings,
+// arrays, maps and simple values, with definite lengths. Integers decode to int64, maps to map[any]any.
+// It returns the item and the number of bytes it spans.
+func decodeCBOR(data []byte) (any, int, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (any, int, error) {
+	if depth > maxCBORDepth {
+		return nil, 0, errors.New("CBOR data nested too deeply")
+	}
+	if len(data) == 0 {
+		return nil, 0, errCBORTruncated
+	}
+
+	major, info := data[0]>>5, data[0]&0x1f
+	arg, n, err := cborArgument(data, info)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch major {
+	case 0: // unsigned integer
+		if arg > math.MaxInt64 {
+			return nil, 0, errors.New("CBOR integer overflows int64")
+		}
+		return int64(arg), n, nil
+	case 1: // negative integer
+		if arg > math.MaxInt64 {
+			return nil, 0, errors.New("CBOR integer overflows int64")
+		}
+		return -1 - int64(arg), n, nil
+	case 2, 3: // byte and text strings
+		if arg > uint64(len(data)-n) {
+			return nil, 0, errCBORTruncated
+		}
+		value := data[n : n+int(arg)]
+		if major == 3 {
+			return string(value), n + int(arg), nil
+		}
+		return value, n + int(arg), nil
+	case 4: // array
+		if arg > uint64(len(data)) {
+			return nil, 0, errCBORTruncated
+		}
+		items := make([]any, 0, arg)
+		for range arg {
+			item, size, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, item)
+			n += size
+		}
+		return items, n, nil
+	case 5: // map
+		if arg > uint64(len(data)) {
+			return nil, 0, errCBORTruncated
+		}
+		entries := make(map[any]any, arg)
+		for range arg {
+			key, size, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += size
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, 0, errors.New("unsupported CBOR map key")
+			}
+
+			value, size, err := decodeCBORItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += size
+			entries[key] = value
+		}
+		return entries, n, nil
+	case 7: // simple values
+		switch info {
+		case 20:
+			return false, n, nil
+		case 21:
+			return true, n, nil
+		case 22, 23:
+			return nil, n, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("unsupported CBOR item 0x%02x", data[0])
+}
+
+// cborArgument returns the argument of a CBOR item head and the size of the head
+func cborArgument(data []byte, info byte) (uint64, int, error) {
+	switch {
+	case info < 24:
+		return uint64(info), 1, nil
+	case info == 24 && len(data) >= 2:
+		return uint64(data[1]), 2, nil
+	case info == 25 && len(data) >= 3:
+		return uint64(binary.BigEndian.Uint16(data[1:])), 3, nil
+	case info == 26 && len(data) >= 5:
+		return uint64(binary.BigEndian.Uint32(data[1:])), 5, nil
+	case info == 27 && len(data) >= 9:
+		return binary.BigEndian.Uint64(data[1:]), 9, nil
+	case info > 27:
+		return 0, 0, errors.New("indefinite length CBOR items are not supported")
+	}
+	return 0, 0, errCBORTruncated
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// schemaFiles holds the event schemas, one file per event type and version named <type>.v<version>.json
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// schemaFileName matches the name of a schema file
+var schemaFileName = regexp.MustCompile(`^(.+)\.v([1-9][0-9]*)\.json$`)
+
+// jsonSchema is the subset of JSON Schema used by the event schemas
+type jsonSchema struct {
+	Type                 string                 `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	Format               string                 `json:"format"`
+	MinLength            int                    `json:"minLength"`
+}
+
+// compiledSchema is an event schema ready for validation
+type compiledSchema struct {
+	entity.EventSchema
+	root *jsonSchema
+}
+
+// loadSchemas parses the embedded event schemas, keyed by event type and sorted by version
+func loadSchemas() (map[string][]*compiledSchema, error) {
+	entries, err := schemaFiles.ReadDir("schemas")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event schemas: %w", err)
+	}
+
+	schemas := make(map[string][]*compiledSchema)
+	for _, entry := range entries {
+		match := schemaFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid event schema file name %q", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[2])
+
+		data, err := schemaFiles.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event schema %s: %w", entry.Name(), err)
+		}
+
+		var root jsonSchema
+		if err := json.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("failed to parse event schema %s: %w", entry.Name(), err)
+		}
+
+		schemas[match[1]] = append(schemas[match[1]], &compiledSchema{
+			EventSchema: entity.EventSchema{Type: match[1], Version: version, Schema: data},
+			root:        &root,
+		})
+	}
+
+	for _, versions := range schemas {
+		sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	}
+	return schemas, nil
+}
+
+// validate checks a decoded JSON value against the schema, returning the first violation
+func (s *jsonSchema) validate(value interface{}, at string) error {
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		return fmt.Errorf("%s: value is not one of the allowed values", at)
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object", at)
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", at, name)
+			}
+		}
+		for name, property := range object {
+			propertySchema, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fmt.Errorf("%s: unexpected property %q", at, name)
+				}
+				continue
+			}
+			if err := propertySchema.validate(property, at+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an array", at)
+		}
+		if s.Items != nil {
+			for i, item := range array {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string", at)
+		}
+		if utf8.RuneCountInString(str) < s.MinLength {
+			return fmt.Errorf("%s: shorter than %d characters", at, s.MinLength)
+		}
+		if err := validateFormat(s.Format, str); err != nil {
+			return fmt.Errorf("%s: %w", at, err)
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return fmt.Errorf("%s: expected an integer", at)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected a number", at)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean", at)
+		}
+	}
+
+	return nil
+}
+
+// validateFormat checks a string against a JSON Schema format, unknown formats are not checked
+func validateFormat(format, value string) error {
+	switch format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			return fmt.Errorf("invalid uuid")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("invalid date-time")
+		}
+	case "email":
+		if _, err := mail.ParseAddress(value); err != nil || strings.ContainsAny(value, "<>") {
+			return fmt.Errorf("invalid email")
+		}
+	}
+	return nil
+}
+
+// enumContains reports whether value is one of the enum values
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/eventbus"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUnknownEventType is returned when publishing an event type without a schema
+	ErrUnknownEventType = errors.New("unknown event type")
+
+	// ErrInvalidEvent is returned when the data of an event does not match its schema
+	ErrInvalidEvent = errors.New("event does not match its schema")
+)
+
+// EventService validates domain events against their schemas and publishes them
+type EventService interface {
+	// Publish validates data against the latest schema of the event type and publishes the event
+	Publish(ctx context.Context, eventType string, data interface{}) error
+
+	// Schemas returns every version of every event schema, sorted by type and version
+	Schemas() []entity.EventSchema
+
+	// Schema returns the schema of an event type at a version, reporting whether it exists
+	Schema(eventType string, version int) (entity.EventSchema, bool)
+}
+
+type eventService struct {
+	bus     eventbus.Bus
+	schemas map[string][]*compiledSchema
+}
+
+// NewEventService creates a new event service from the embedded schemas
+func NewEventService(bus eventbus.Bus) (EventService, error) {
+	schemas, err := loadSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventService{
+		bus:     bus,
+		schemas: schemas,
+	}, nil
+}
+
+// Publish validates data against the latest schema of the event type and publishes the event
+func (s *eventService) Publish(ctx context.Context, eventType string, data interface{}) error {
+	versions, ok := s.schemas[eventType]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+	schema := versions[len(versions)-1]
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	// Validate the payload as consumers will decode it, so a drifting struct cannot break them
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("failed to decode event data: %w", err)
+	}
+	if err := schema.root.validate(decoded, "data"); err != nil {
+		return fmt.Errorf("%w: %s v%d: %v", ErrInvalidEvent, eventType, schema.Version, err)
+	}
+
+	payload, err := json.Marshal(&entity.Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		Version:    schema.Version,
+		OccurredAt: time.Now().UTC(),
+		Data:       raw,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := s.bus.Publish(ctx, eventType, payload); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	return nil
+}
+
+// Schemas returns every version of every event schema, sorted by type and version
+func (s *eventService) Schemas() []entity.EventSchema {
+	eventTypes := make([]string, 0, len(s.schemas))
+	for eventType := range s.schemas {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	schemas := []entity.EventSchema{}
+	for _, eventType := range eventTypes {
+		for _, schema := range s.schemas[eventType] {
+			schemas = append(schemas, schema.EventSchema)
+		}
+	}
+	return schemas
+}
+
+// Schema returns the schema of an event type at a version, reporting whether it exists
+func (s *eventService) Schema(eventType string, version int) (entity.EventSchema, bool) {
+	for _, schema := range s.schemas[eventType] {
+		if schema.Version == version {
+			return schema.EventSchema, true
+		}
+	}
+	return entity.EventSchema{}, false
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"golang.org/x/text/language"
+)
+
+// NameService formats the names of users following the naming conventions of their locale
+type NameService interface {
+	// DisplayName returns the name a user is addressed by: their display name if set, otherwise their full name
+	DisplayName(user *entity.User) string
+
+	// FullName returns the first and last names of a user in the order of their locale
+	FullName(user *entity.User) string
+}
+
+type nameService struct {
+	defaultLocale   string
+	familyNameFirst map[string]bool
+}
+
+// NewNameService creates a new name service from the configured name ordering conventions
+func NewNameService(cfg config.NameConfig) NameService {
+	familyNameFirst := make(map[string]bool, len(cfg.FamilyNameFirstLocales))
+	for _, locale := range cfg.FamilyNameFirstLocales {
+		familyNameFirst[baseLanguage(locale)] = true
+	}
+
+	return &nameService{
+		defaultLocale:   cfg.DefaultLocale,
+		familyNameFirst: familyNameFirst,
+	}
+}
+
+// DisplayName returns the name a user is addressed by, falling back to their username when they have no name
+func (s *nameService) DisplayName(user *entity.User) string {
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	if name := s.FullName(user); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+// FullName returns the first and last names of a user in the order of their locale
+func (s *nameService) FullName(user *entity.User) string {
+	locale := user.Locale
+	if locale == "" {
+		locale = s.defaultLocale
+	}
+
+	first, second := user.FirstName, user.LastName
+	if s.familyNameFirst[baseLanguage(locale)] {
+		first, second = user.LastName, user.FirstName
+	}
+	if first == "" || second == "" {
+		return first + second
+	}
+
+	// Names written in scripts without word spacing, e.g. 山田太郎 or 홍길동, are not separated
+	if unspacedScript(first) && unspacedScript(second) {
+		return first + second
+	}
+	return first + " " + second
+}
+
+// baseLanguage returns the lowercase language subtag of a locale, e.g. ja for ja-JP
+func baseLanguage(locale string) string {
+	base, _ := language.Make(strings.TrimSpace(locale)).Base()
+	return base.String()
+}
+
+// unspacedScript reports whether a name is only written in scripts that do not separate names with a space
+func unspacedScript(name string) bool {
+	for _, r := range name {
+		if !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/mailer"
+)
+
+var (
+	// ErrUnsupportedChannel is returned when a notification channel is not supported
+	ErrUnsupportedChannel = errors.New("unsupported notification channel")
+
+	// ErrEmailSuppressed is returned when emailing an address on the suppression list
+	ErrEmailSuppressed = errors.New("email address is suppressed")
+)
+
+// NotificationService delivers notifications to users
+type NotificationService interface {
+	// Send delivers a notification to a user on a channel
+	Send(ctx context.Context, user *entity.User, channel string, notification *entity.Notification) error
+
+	// SendEmail emails a notification to an address that is not a user's, such as a security contact
+	SendEmail(ctx context.Context, to string, notification *entity.Notification) error
+}
+
+type notificationService struct {
+	mailer          mailer.Mailer
+	suppressionRepo repository.SuppressionRepository
+}
+
+// NewNotificationService creates a new notification service.
+// Emails to the addresses of the suppression list are not sent.
+func NewNotificationService(mailer mailer.Mailer, suppressionRepo repository.SuppressionRepository) NotificationService {
+	return &notificationService{
+		mailer:          mailer,
+		suppressionRepo: suppressionRepo,
+	}
+}
+
+// Send delivers a notification to a user on a channel
+func (s *notificationService) Send(ctx context.Context, user *entity.User, channel string, notification *entity.Notification) error {
+	switch channel {
+	case entity.NotificationChannelEmail:
+		return s.sendEmail(ctx, user.Email, notification)
+	case entity.NotificationChannelRecoveryEmail:
+		if user.RecoveryEmail == "" {
+			return ErrUnsupportedChannel
+		}
+		return s.sendEmail(ctx, user.RecoveryEmail, notification)
+	default:
+		return ErrUnsupportedChannel
+	}
+}
+
+// SendEmail emails a notification to an address unless it is suppressed
+func (s *notificationService) SendEmail(ctx context.Context, to string, notification *entity.Notification) error {
+	return s.sendEmail(ctx, to, notification)
+}
+
+// sendEmail emails a notification unless the address is suppressed
+func (s *notificationService) sendEmail(ctx context.Context, to string, notification *entity.Notification) error {
+	suppression, err := s.suppressionRepo.Get(ctx, entity.NormalizeEmail(to))
+	if err != nil {
+		return fmt.Errorf("failed to check email suppression: %w", err)
+	}
+	if suppression != nil {
+		return fmt.Errorf("%w: %s", ErrEmailSuppressed, suppression.Reason)
+	}
+
+	return s.mailer.Send(ctx, to, notification.Subject, notification.Body, notification.HTMLBody)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPasskey is returned when the response of an authenticator to a ceremony does not verify
+var ErrInvalidPasskey = errors.New("invalid passkey")
+
+// COSE algorithms accepted for passkeys, ES256 is supported by every authenticator and RS256 by Windows Hello
+const (
+	coseAlgES256 = -7
+	coseAlgRS256 = -257
+)
+
+// UserVerification enum, whether authenticators must verify the user with a PIN or biometrics
+const (
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+)
+
+// Authenticator data flags
+const (
+	flagUserPresent      = 0x01
+	flagUserVerified     = 0x04
+	flagAttestedCredData = 0x40
+)
+
+// passkeyChallengeSize is the size of the challenges in bytes, WebAuthn requires at least 16
+const passkeyChallengeSize = 32
+
+// PasskeyService verifies the responses of authenticators to the WebAuthn ceremonies of passkeys
+type PasskeyService interface {
+	// NewChallenge returns a random challenge for an authenticator to sign
+	NewChallenge() ([]byte, error)
+
+	// CreationOptions returns the options of the registration of a passkey by a user, excluding their passkeys
+	CreationOptions(challenge []byte, user *entity.User, passkeys []*entity.Passkey) *entity.PasskeyCreationOptions
+
+	// RequestOptions returns the options of a sign in with a discoverable passkey
+	RequestOptions(challenge []byte) *entity.PasskeyRequestOptions
+
+	// VerifyRegistration verifies the response to a registration challenge and returns the new passkey,
+	// without its owner and name
+	VerifyRegistration(challenge []byte, attestation *entity.PasskeyAttestation) (*entity.Passkey, error)
+
+	// VerifyAssertion verifies the response of a passkey to a sign in challenge and returns the new signature counter
+	VerifyAssertion(challenge []byte, passkey *entity.Passkey, assertion *entity.PasskeyAssertion) (uint32, error)
+}
+
+type passkeyService struct {
+	rpID             string
+	rpName           string
+	rpIDHash         [32]byte
+	origins          []string
+	timeoutMillis    int64
+	userVerification string
+}
+
+// NewPasskeyService creates a new passkey service for the configured relying party
+func NewPasskeyService(cfg config.PasskeyConfig) PasskeyService {
+	rpName := cfg.RPName
+	if rpName == "" {
+		rpName = cfg.RPID
+	}
+
+	userVerification := cfg.UserVerification
+	switch userVerification {
+	case UserVerificationRequired, UserVerificationPreferred, UserVerificationDiscouraged:
+	default:
+		userVerification = UserVerificationPreferred
+	}
+
+	return &passkeyService{
+		rpID:             cfg.RPID,
+		rpName:           rpName,
+		rpIDHash:         sha256.Sum256([]byte(cfg.RPID)),
+		origins:          cfg.Origins,
+		timeoutMillis:    cfg.Timeout.Milliseconds(),
+		userVerification: userVerification,
+	}
+}
+
+// NewChallenge returns a random challenge for an authenticator to sign
+func (s *passkeyService) NewChallenge() ([]byte, error) {
+	challenge := make([]byte, passkeyChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// CreationOptions returns the options of the registration of a discoverable passkey, so users sign in without
+// typing their email first. The user handle is the user ID.
+func (s *passkeyService) CreationOptions(challenge []byte, user *entity.User, passkeys []*entity.Passkey) *entity.PasskeyCreationOptions {
+	exclude := make([]entity.PasskeyCredentialDescriptor, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		id, err := base64.RawURLEncoding.DecodeString(passkey.ID)
+		if err != nil {
+			continue
+		}
+		exclude = append(exclude, entity.PasskeyCredentialDescriptor{
+			Type:       "public-key",
+			ID:         id,
+			Transports: passkey.Transports,
+		})
+	}
+
+	return &entity.PasskeyCreationOptions{
+		Challenge: challenge,
+		RP: entity.PasskeyRelyingParty{
+			ID:   s.rpID,
+			Name: s.rpName,
+		},
+		User: entity.PasskeyUser{
+			ID:          user.ID[:],
+			Name:        user.Email,
+			DisplayName: user.Username,
+		},
+		PubKeyCredParams: []entity.PasskeyCredentialParameter{
+			{Type: "public-key", Alg: coseAlgES256},
+			{Type: "public-key", Alg: coseAlgRS256},
+		},
+		Timeout:            s.timeoutMillis,
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: entity.PasskeyAuthenticatorSelection{
+			ResidentKey:      "required",
+			RequireResident:  true,
+			UserVerification: s.userVerification,
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns the options of a sign in with a discoverable passkey
+func (s *passkeyService) RequestOptions(challenge []byte) *entity.PasskeyRequestOptions {
+	return &entity.PasskeyRequestOptions{
+		Challenge:        challenge,
+		RPID:             s.rpID,
+		Timeout:          s.timeoutMillis,
+		UserVerification: s.userVerification,
+	}
+}
+
+// VerifyRegistration verifies the response to a registration challenge and returns the new passkey.
+// Attestation is not requested, so the attestation statement is not checked whatever its format.
+func (s *passkeyService) VerifyRegistration(challenge []byte, attestation *entity.PasskeyAttestation) (*entity.Passkey, error) {
+	if err := s.verifyClientData(attestation.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	object, _, err := decodeCBOR(attestation.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed attestation object: %v", ErrInvalidPasskey, err)
+	}
+	fields, ok := object.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed attestation object", ErrInvalidPasskey)
+	}
+	authData, ok := fields["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing authenticator data", ErrInvalidPasskey)
+	}
+
+	flags, signCount, err := s.verifyAuthenticatorData(authData)
+	if err != nil {
+		return nil, err
+	}
+	if flags&flagAttestedCredData == 0 {
+		return nil, fmt.Errorf("%w: missing attested credential data", ErrInvalidPasskey)
+	}
+
+	// Attested credential data: AAGUID, credential ID length and credential ID, then the COSE public key
+	data := authData[37:]
+	if len(data) < 18 {
+		return nil, fmt.Errorf("%w: truncated attested credential data", ErrInvalidPasskey)
+	}
+	aaguid, _ := uuid.FromBytes(data[:16])
+	idLength := int(binary.BigEndian.Uint16(data[16:18]))
+	data = data[18:]
+	if len(data) < idLength {
+		return nil, fmt.Errorf("%w: truncated credential ID", ErrInvalidPasskey)
+	}
+	credentialID := data[:idLength]
+	if !bytes.Equal(credentialID, attestation.RawID) {
+		return nil, fmt.Errorf("%w: credential ID mismatch", ErrInvalidPasskey)
+	}
+
+	// Extensions may follow the key, so only the bytes of the key itself are kept
+	_, keyLength, err := decodeCBOR(data[idLength:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed public key: %v", ErrInvalidPasskey, err)
+	}
+	publicKey := slices.Clone(data[idLength : idLength+keyLength])
+	if _, _, err := parseCOSEKey(publicKey); err != nil {
+		return nil, err
+	}
+
+	return &entity.Passkey{
+		ID:         base64.RawURLEncoding.EncodeToString(credentialID),
+		PublicKey:  publicKey,
+		SignCount:  signCount,
+		AAGUID:     aaguid,
+		Transports: attestation.Transports,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of a passkey to a sign in challenge and returns the new signature counter.
+// A counter that does not increase reveals a cloned authenticator, unless the authenticator keeps no counter.
+func (s *passkeyService) VerifyAssertion(challenge []byte, passkey *entity.Passkey, assertion *entity.PasskeyAssertion) (uint32, error) {
+	if err := s.verifyClientData(assertion.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	_, signCount, err := s.verifyAuthenticatorData(assertion.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	algorithm, publicKey, err := parseCOSEKey(passkey.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(assertion.ClientDataJSON)
+	digest := sha256.Sum256(append(slices.Clone(assertion.AuthenticatorData), clientDataHash[:]...))
+
+	switch algorithm {
+	case coseAlgES256:
+		if !ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), digest[:], assertion.Signature) {
+			return 0, fmt.Errorf("%w: signature mismatch", ErrInvalidPasskey)
+		}
+	case coseAlgRS256:
+		if err := rsa.VerifyPKCS1v15(publicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], assertion.Signature); err != nil {
+			return 0, fmt.Errorf("%w: signature mismatch", ErrInvalidPasskey)
+		}
+	}
+
+	if (signCount != 0 || passkey.SignCount != 0) && signCount <= passkey.SignCount {
+		return 0, fmt.Errorf("%w: signature counter did not increase, the authenticator may be cloned", ErrInvalidPasskey)
+	}
+
+	return signCount, nil
+}
+
+// verifyClientData checks the client data was collected by one of the configured origins for the ceremony
+// type and challenge
+func (s *passkeyService) verifyClientData(clientDataJSON []byte, ceremonyType string, challenge []byte) error {
+	var clientData struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return fmt.Errorf("%w: malformed client data", ErrInvalidPasskey)
+	}
+
+	if clientData.Type != ceremonyType {
+		return fmt.Errorf("%w: unexpected ceremony type %q", ErrInvalidPasskey, clientData.Type)
+	}
+	expected := base64.RawURLEncoding.EncodeToString(challenge)
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(expected)) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrInvalidPasskey)
+	}
+	if !slices.Contains(s.origins, clientData.Origin) {
+		return fmt.Errorf("%w: unexpected origin %q", ErrInvalidPasskey, clientData.Origin)
+	}
+
+	return nil
+}
+
+// verifyAuthenticatorData checks the authenticator data is scoped to the relying party and the user was present,
+// and verified when required. It returns the flags and the signature counter.
+func (s *passkeyService) verifyAuthenticatorData(authData []byte) (byte, uint32, error) {
+	if len(authData) < 37 {
+		return 0, 0, fmt.Errorf("%w: truncated authenticator data", ErrInvalidPasskey)
+	}
+
+	if subtle.ConstantTimeCompare(authData[:32], s.rpIDHash[:]) != 1 {
+		return 0, 0, fmt.Errorf("%w: relying party ID mismatch", ErrInvalidPasskey)
+	}
+
+	flags := authData[32]
+	if flags&flagUserPresent == 0 {
+		return 0, 0, fmt.Errorf("%w: user not present", ErrInvalidPasskey)
+	}
+	if s.userVerification == UserVerificationRequired && flags&flagUserVerified == 0 {
+		return 0, 0, fmt.Errorf("%w: user not verified", ErrInvalidPasskey)
+	}
+
+	return flags, binary.BigEndian.Uint32(authData[33:37]), nil
+}
+
+// parseCOSEKey returns the algorithm and public key of a COSE encoded key, ES256 and RS256 keys only
+func parseCOSEKey(data []byte) (int64, crypto.PublicKey, error) {
+	decoded, _, err := decodeCBOR(data)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: malformed public key: %v", ErrInvalidPasskey, err)
+	}
+	key, ok := decoded.(map[any]any)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: malformed public key", ErrInvalidPasskey)
+	}
+
+	// COSE key parameters: 1 key type, 3 algorithm, then -1 curve, -2 x and -3 y for EC2 keys,
+	// -1 modulus and -2 exponent for RSA keys
+	algorithm, _ := key[int64(3)].(int64)
+	switch algorithm {
+	case coseAlgES256:
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		if key[int64(1)] != int64(2) || key[int64(-1)] != int64(1) || len(x) != 32 || len(y) != 32 {
+			return 0, nil, fmt.Errorf("%w: malformed ES256 public key", ErrInvalidPasskey)
+		}
+
+		// Reject points off the curve before using the key
+		point := append(append([]byte{0x04}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return 0, nil, fmt.Errorf("%w: invalid ES256 public key", ErrInvalidPasskey)
+		}
+		return algorithm, &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	case coseAlgRS256:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if key[int64(1)] != int64(3) || len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return 0, nil, fmt.Errorf("%w: malformed RS256 public key", ErrInvalidPasskey)
+		}
+
+		return algorithm, &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	}
+
+	return 0, nil, fmt.Errorf("%w: unsupported algorithm %d", ErrInvalidPasskey, algorithm)
+}
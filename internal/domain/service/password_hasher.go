@@ -0,0 +1,193 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hash algorithms
+const (
+	PasswordHashBcrypt   = "bcrypt"
+	PasswordHashArgon2id = "argon2id"
+)
+
+// Password hash schemes of the legacy systems users are imported from, besides bcrypt and Argon2id
+const (
+	PasswordHashPBKDF2SHA1   = "pbkdf2-sha1"
+	PasswordHashPBKDF2SHA256 = "pbkdf2-sha256"
+	PasswordHashPBKDF2SHA512 = "pbkdf2-sha512"
+)
+
+var (
+	// ErrUnsupportedPasswordScheme is returned when importing a hash of an unknown scheme
+	ErrUnsupportedPasswordScheme = errors.New("unsupported password hash scheme")
+
+	// ErrInvalidPasswordHash is returned when importing a hash that does not decode as its scheme
+	ErrInvalidPasswordHash = errors.New("invalid password hash")
+)
+
+// PasswordHasher hashes the passwords of users with the configured algorithm
+type PasswordHasher interface {
+	// Hash hashes a password with the configured algorithm and parameters
+	Hash(password string) (string, error)
+
+	// Verify compares a password with a hash of either algorithm, so the passwords hashed before the algorithm or
+	// its parameters changed keep working
+	Verify(password, hash string) bool
+
+	// NeedsRehash reports whether a hash was computed with another algorithm or other parameters than configured,
+	// so the password it was verified against should be hashed again
+	NeedsRehash(hash string) bool
+
+	// Import checks a hash computed by another system with the given scheme and returns it as stored, tagged with
+	// its scheme, so it verifies the password it was computed from and is replaced on the next sign in
+	Import(scheme, hash string) (string, error)
+}
+
+type passwordHasher struct {
+	algorithm  string
+	bcryptCost int
+	argon2     *utils.Argon2Params
+}
+
+// NewPasswordHasher creates a new password hasher, failing on an unknown algorithm or out of range parameters
+func NewPasswordHasher(cfg config.SecurityConfig) (PasswordHasher, error) {
+	h := &passwordHasher{algorithm: cfg.PasswordHashAlgorithm}
+
+	switch cfg.PasswordHashAlgorithm {
+	case PasswordHashBcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		h.bcryptCost = cfg.BcryptCost
+	case PasswordHashArgon2id:
+		if cfg.Argon2Iterations < 1 {
+			return nil, fmt.Errorf("argon2 iterations must be at least 1")
+		}
+		if cfg.Argon2Parallelism < 1 || cfg.Argon2Parallelism > math.MaxUint8 {
+			return nil, fmt.Errorf("argon2 parallelism must be between 1 and %d", math.MaxUint8)
+		}
+		if cfg.Argon2Memory < 8*cfg.Argon2Parallelism || cfg.Argon2Memory > math.MaxUint32 {
+			return nil, fmt.Errorf("argon2 memory must be at least 8 KiB per degree of parallelism")
+		}
+		h.argon2 = utils.DefaultArgon2Params()
+		h.argon2.Memory = uint32(cfg.Argon2Memory)
+		h.argon2.Iterations = uint32(cfg.Argon2Iterations)
+		h.argon2.Parallelism = uint8(cfg.Argon2Parallelism)
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q, expected %s or %s", cfg.PasswordHashAlgorithm, PasswordHashBcrypt, PasswordHashArgon2id)
+	}
+
+	return h, nil
+}
+
+// Hash hashes a password with the configured algorithm
+func (h *passwordHasher) Hash(password string) (string, error) {
+	if h.algorithm == PasswordHashArgon2id {
+		return utils.HashPasswordArgon2(password, h.argon2)
+	}
+	return utils.HashPassword(password, h.bcryptCost)
+}
+
+// Verify compares a password with a hash of either algorithm
+func (h *passwordHasher) Verify(password, hash string) bool {
+	return utils.CheckPasswordHash(password, hash)
+}
+
+// NeedsRehash reports whether a hash was computed with another algorithm or other parameters than configured.
+// Lowered parameters count too, so the hashes follow the configuration whichever way it changed. Imported PBKDF2
+// hashes are always replaced. Unreadable hashes are left alone, they never verify anyway.
+func (h *passwordHasher) NeedsRehash(hash string) bool {
+	if utils.IsPBKDF2Hash(hash) {
+		_, err := utils.DecodePBKDF2Hash(hash)
+		return err == nil
+	}
+
+	argon2Hash := strings.HasPrefix(hash, "$argon2id$")
+	if h.algorithm != PasswordHashArgon2id {
+		if argon2Hash {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err == nil && cost != h.bcryptCost
+	}
+
+	if !argon2Hash {
+		_, err := bcrypt.Cost([]byte(hash))
+		return err == nil
+	}
+	p, err := utils.Argon2HashParams(hash)
+	if err != nil {
+		return false
+	}
+	return p.Memory != h.argon2.Memory || p.Iterations != h.argon2.Iterations || p.Parallelism != h.argon2.Parallelism ||
+		p.SaltLength != h.argon2.SaltLength || p.KeyLength != h.argon2.KeyLength
+}
+
+// Import checks a hash computed by another system. Bcrypt and Argon2id hashes are stored as they are. PBKDF2 hashes
+// are accepted in the modular crypt format of passlib, $pbkdf2-sha256$<iterations>$<salt>$<key>, or the format of
+// Django, pbkdf2_sha256$<iterations>$<salt>$<key>, and stored in the former.
+func (h *passwordHasher) Import(scheme, hash string) (string, error) {
+	switch strings.ToLower(scheme) {
+	case PasswordHashBcrypt:
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return "", ErrInvalidPasswordHash
+		}
+		return hash, nil
+	case PasswordHashArgon2id:
+		if !strings.HasPrefix(hash, "$argon2id$") {
+			return "", ErrInvalidPasswordHash
+		}
+		if _, err := utils.Argon2HashParams(hash); err != nil {
+			return "", ErrInvalidPasswordHash
+		}
+		return hash, nil
+	case PasswordHashPBKDF2SHA1:
+		return importPBKDF2(utils.PBKDF2SHA1, hash)
+	case PasswordHashPBKDF2SHA256:
+		return importPBKDF2(utils.PBKDF2SHA256, hash)
+	case PasswordHashPBKDF2SHA512:
+		return importPBKDF2(utils.PBKDF2SHA512, hash)
+	default:
+		return "", ErrUnsupportedPasswordScheme
+	}
+}
+
+// importPBKDF2 decodes a PBKDF2 hash of a digest in the format of passlib or Django and encodes it in the former
+func importPBKDF2(digest, hash string) (string, error) {
+	var decoded *utils.PBKDF2Hash
+	if utils.IsPBKDF2Hash(hash) {
+		var err error
+		if decoded, err = utils.DecodePBKDF2Hash(hash); err != nil {
+			return "", ErrInvalidPasswordHash
+		}
+	} else {
+		// Django keeps the salt as the text it generated and the key in standard base64
+		parts := strings.Split(hash, "$")
+		if len(parts) != 4 || parts[0] != "pbkdf2_"+digest || parts[2] == "" {
+			return "", ErrInvalidPasswordHash
+		}
+		iterations, err := strconv.Atoi(parts[1])
+		if err != nil || iterations < 1 {
+			return "", ErrInvalidPasswordHash
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[3])
+		if err != nil || len(key) == 0 {
+			return "", ErrInvalidPasswordHash
+		}
+		decoded = &utils.PBKDF2Hash{Digest: digest, Iterations: iterations, Salt: []byte(parts[2]), Key: key}
+	}
+
+	if decoded.Digest != digest {
+		return "", ErrInvalidPasswordHash
+	}
+	return utils.EncodePBKDF2Hash(decoded), nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/chats/go-user-api/internal/infrastructure/pwned"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrWeakPassword is returned when a password does not reach the minimum strength score
+	ErrWeakPassword = errors.New("password too weak")
+
+	// ErrBreachedPassword is returned when a password appeared in a data breach
+	ErrBreachedPassword = errors.New("password appeared in a data breach")
+
+	// ErrPasswordCheckUnavailable is returned when a password cannot be checked and the check fails closed
+	ErrPasswordCheckUnavailable = errors.New("password check unavailable")
+)
+
+// PasswordService checks the passwords users choose before they are hashed
+type PasswordService interface {
+	// Check returns ErrWeakPassword when a password does not reach the minimum strength score, ErrBreachedPassword
+	// when it appeared in a data breach, or ErrPasswordCheckUnavailable when it cannot be checked and the check
+	// fails closed. The user inputs are the email, username and names of the user, guessed first.
+	Check(ctx context.Context, password string, userInputs ...string) error
+
+	// Estimate scores how hard a password is to guess, with feedback to make it stronger, and whether the score
+	// meets the minimum of Check
+	Estimate(password string, userInputs ...string) *entity.PasswordStrength
+}
+
+type passwordService struct {
+	checker pwned.Checker
+	config  config.PasswordConfig
+}
+
+// NewPasswordService creates a new password service. The checker is only used when the breach check is enabled.
+func NewPasswordService(checker pwned.Checker, cfg config.PasswordConfig) PasswordService {
+	return &passwordService{
+		checker: checker,
+		config:  cfg,
+	}
+}
+
+// Check returns an error when a password is too weak or appeared in a data breach
+func (s *passwordService) Check(ctx context.Context, password string, userInputs ...string) error {
+	if password == "" {
+		return nil
+	}
+	if !s.Estimate(password, userInputs...).Acceptable {
+		return ErrWeakPassword
+	}
+	if !s.config.BreachCheckEnabled {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.BreachCheckTimeout)
+	defer cancel()
+
+	count, err := s.checker.Count(ctx, password)
+	if err != nil {
+		metrics.PasswordBreachChecks.WithLabelValues("error").Inc()
+		if s.config.BreachCheckFailOpen {
+			log.Warn().Err(err).Msg("Failed to check password against breaches, accepting it")
+			return nil
+		}
+		log.Error().Err(err).Msg("Failed to check password against breaches")
+		return ErrPasswordCheckUnavailable
+	}
+
+	if count >= max(s.config.BreachCheckMinCount, 1) {
+		metrics.PasswordBreachChecks.WithLabelValues("breached").Inc()
+		return ErrBreachedPassword
+	}
+	metrics.PasswordBreachChecks.WithLabelValues("clean").Inc()
+	return nil
+}
+
+// Estimate scores how hard a password is to guess
+func (s *passwordService) Estimate(password string, userInputs ...string) *entity.PasswordStrength {
+	strength := estimateStrength(password, userInputs)
+	strength.MinScore = s.config.MinScore
+	strength.Acceptable = strength.Score >= s.config.MinScore
+	return strength
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+// Patterns a part of a password can be guessed with
+const (
+	patternDictionary = "dictionary"
+	patternUserInput  = "user_input"
+	patternSequence   = "sequence"
+	patternRepeat     = "repeat"
+	patternKeyboard   = "keyboard"
+	patternYear       = "year"
+)
+
+// maxStrengthLength is the number of runes of a password estimated, the other runes are ignored so long passwords
+// do not cost the estimation quadratic time. Passwords this long are strong unless built of patterns.
+const maxStrengthLength = 100
+
+// Thresholds of the scores, in log10 of the guesses, like zxcvbn: a score is reached from 10^threshold guesses
+var scoreThresholds = [...]float64{3, 6, 8, 10}
+
+// keyboardRows are the rows of a QWERTY keyboard, runs along them are guessed as keyboard patterns
+var keyboardRows = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+// l33tSubstitutions maps the characters commonly substituted for letters to the letters, 1 is tried as both i and l
+var l33tSubstitutions = []map[rune]rune{
+	{'4': 'a', '@': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '9': 'g', '1': 'i', '!': 'i', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z'},
+	{'4': 'a', '@': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '9': 'g', '1': 'l', '|': 'l', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z'},
+}
+
+//go:embed passwords/common.txt
+var commonPasswordList string
+
+// commonPasswords ranks the most common passwords and words, 1 for the most common
+var commonPasswords = rankWords(strings.Fields(commonPasswordList))
+
+// strengthMatch is a part of a password found by a pattern
+type strengthMatch struct {
+	pattern   string
+	i, j      int     // Indexes of the first and last runes of the part
+	guesses   float64 // log10 of the guesses needed to find the part
+	rank      int     // Rank of the word in its dictionary
+	reversed  bool
+	l33t      bool
+	uppercase bool
+}
+
+// estimateStrength estimates how hard a password is to guess, zxcvbn style: the parts of the password found by
+// patterns, common passwords, the user inputs, sequences, repeats, keyboard rows and years, are guessed with
+// the guesses of their pattern, the other characters by brute force, and the cheapest split of the password wins
+func estimateStrength(password string, userInputs []string) *entity.PasswordStrength {
+	runes := []rune(password)
+	if len(runes) > maxStrengthLength {
+		runes = runes[:maxStrengthLength]
+	}
+	matches := findMatches(runes, rankUserInputs(userInputs), true)
+
+	// best[k] is the log10 of the guesses of the cheapest split of the first k runes, last[k] its last match
+	n := len(runes)
+	best := make([]float64, n+1)
+	last := make([]*strengthMatch, n+1)
+	for k := 1; k <= n; k++ {
+		best[k] = best[k-1] + 1 // Brute force, 10 guesses per character
+		for _, m := range matches {
+			if m.j+1 == k && best[m.i]+m.guesses < best[k] {
+				best[k] = best[m.i] + m.guesses
+				last[k] = m
+			}
+		}
+	}
+
+	var sequence []*strengthMatch
+	for k := n; k > 0; {
+		if m := last[k]; m != nil {
+			sequence = append(sequence, m)
+			k = m.i
+		} else {
+			k--
+		}
+	}
+
+	guesses := best[n]
+	score := 0
+	for _, threshold := range scoreThresholds {
+		if guesses >= threshold {
+			score++
+		}
+	}
+
+	warning, suggestions := strengthFeedback(score, n, sequence)
+	return &entity.PasswordStrength{
+		Score:        score,
+		GuessesLog10: math.Round(guesses*100) / 100,
+		Warning:      warning,
+		Suggestions:  suggestions,
+	}
+}
+
+// findMatches returns the parts of a password found by every pattern, repeats only when asked, so the repeated
+// parts are not searched for repeats again
+func findMatches(runes []rune, userInputs map[string]int, repeats bool) []*strengthMatch {
+	var matches []*strengthMatch
+	matches = append(matches, dictionaryMatches(runes, commonPasswords, patternDictionary)...)
+	matches = append(matches, dictionaryMatches(runes, userInputs, patternUserInput)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, keyboardMatches(runes)...)
+	matches = append(matches, yearMatches(runes)...)
+	if repeats {
+		matches = append(matches, repeatMatches(runes, userInputs)...)
+	}
+	return matches
+}
+
+// dictionaryMatches finds the words of a dictionary in a password, whatever their case, reversed or with l33t
+// substitutions
+func dictionaryMatches(runes []rune, dictionary map[string]int, pattern string) []*strengthMatch {
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		return nil // Case mapping changed the length, the indexes would not line up
+	}
+
+	var matches []*strengthMatch
+	for i := 0; i < len(lower); i++ {
+		for j := i + 2; j < len(lower); j++ {
+			word := lower[i : j+1]
+			match := &strengthMatch{pattern: pattern, i: i, j: j}
+			if rank, ok := dictionary[string(word)]; ok {
+				match.rank = rank
+			} else if rank, ok := dictionary[reverse(word)]; ok {
+				match.rank, match.reversed = rank, true
+			} else if rank, ok := l33tRank(word, dictionary); ok {
+				match.rank, match.l33t = rank, true
+			} else {
+				continue
+			}
+
+			match.guesses = math.Log10(float64(match.rank))
+			if match.reversed {
+				match.guesses += math.Log10(2)
+			}
+			if match.l33t {
+				match.guesses += math.Log10(2)
+			}
+			if variations := uppercaseVariations(runes[i : j+1]); variations > 1 {
+				match.uppercase = true
+				match.guesses += math.Log10(variations)
+			}
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// l33tRank returns the rank of a word once its l33t substitutions are reverted
+func l33tRank(word []rune, dictionary map[string]int) (int, bool) {
+	for _, substitutions := range l33tSubstitutions {
+		reverted := make([]rune, len(word))
+		substituted := false
+		for k, r := range word {
+			if letter, ok := substitutions[r]; ok {
+				reverted[k], substituted = letter, true
+			} else {
+				reverted[k] = r
+			}
+		}
+		if !substituted {
+			return 0, false
+		}
+		if rank, ok := dictionary[string(reverted)]; ok {
+			return rank, true
+		}
+	}
+	return 0, false
+}
+
+// uppercaseVariations returns the number of ways a word could have been capitalized like this part, 1 for lower
+// case. Capitalizing the first or last letter, or every letter, only doubles the guesses.
+func uppercaseVariations(part []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range part {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	if lower == 0 || (upper == 1 && (unicode.IsUpper(part[0]) || unicode.IsUpper(part[len(part)-1]))) {
+		return 2
+	}
+
+	variations := 0.0
+	for k := 1; k <= min(upper, lower); k++ {
+		variations += binomial(upper+lower, k)
+	}
+	return variations
+}
+
+// sequenceMatches finds the runs of at least 3 characters of the same class going up or down one at a time,
+// such as abc or 6543
+func sequenceMatches(runes []rune) []*strengthMatch {
+	var matches []*strengthMatch
+	for i := 0; i < len(runes)-2; {
+		delta := runes[i+1] - runes[i]
+		if (delta != 1 && delta != -1) || characterClass(runes[i]) != characterClass(runes[i+1]) {
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j+1 < len(runes) && runes[j+1]-runes[j] == delta && characterClass(runes[j+1]) == characterClass(runes[i]) {
+			j++
+		}
+		if j-i+1 >= 3 {
+			base := 26.0
+			switch {
+			case strings.ContainsRune("aAzZ019", runes[i]):
+				base = 4 // Obvious starts
+			case unicode.IsDigit(runes[i]):
+				base = 10
+			}
+			guesses := math.Log10(base * float64(j-i+1))
+			if delta < 0 {
+				guesses += math.Log10(2)
+			}
+			matches = append(matches, &strengthMatch{pattern: patternSequence, i: i, j: j, guesses: guesses})
+		}
+		i = j
+	}
+	return matches
+}
+
+// repeatMatches finds the parts repeated at least twice in a row, such as aaa or abcabc, the longest from each
+// position. The repeated part is guessed like a password of its own.
+func repeatMatches(runes []rune, userInputs map[string]int) []*strengthMatch {
+	var matches []*strengthMatch
+	for i := 0; i < len(runes); i++ {
+		bestSize, bestCount := 0, 0
+		for size := 1; i+2*size <= len(runes); size++ {
+			count := 1
+			for i+(count+1)*size <= len(runes) && string(runes[i+count*size:i+(count+1)*size]) == string(runes[i:i+size]) {
+				count++
+			}
+			// A single character must repeat at least 3 times, longer parts twice
+			if count < 2 || (size == 1 && count < 3) {
+				continue
+			}
+			if size*count > bestSize*bestCount {
+				bestSize, bestCount = size, count
+			}
+		}
+		if bestCount == 0 {
+			continue
+		}
+
+		base := repeatedPartGuesses(runes[i:i+bestSize], userInputs)
+		matches = append(matches, &strengthMatch{
+			pattern: patternRepeat,
+			i:       i,
+			j:       i + bestCount*bestSize - 1,
+			guesses: base + math.Log10(float64(bestCount)),
+		})
+	}
+	return matches
+}
+
+// repeatedPartGuesses returns the log10 of the guesses of a repeated part, with the patterns of whole passwords
+func repeatedPartGuesses(part []rune, userInputs map[string]int) float64 {
+	if len(part) == 1 {
+		return math.Log10(characterCardinality(part[0]))
+	}
+
+	best := make([]float64, len(part)+1)
+	matches := findMatches(part, userInputs, false)
+	for k := 1; k <= len(part); k++ {
+		best[k] = best[k-1] + 1
+		for _, m := range matches {
+			if m.j+1 == k {
+				best[k] = min(best[k], best[m.i]+m.guesses)
+			}
+		}
+	}
+	return best[len(part)]
+}
+
+// keyboardMatches finds the runs of at least 4 adjacent keys along a row of the keyboard, either way
+func keyboardMatches(runes []rune) []*strengthMatch {
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		return nil
+	}
+
+	var matches []*strengthMatch
+	for _, row := range keyboardRows {
+		for _, keys := range []string{row, reverse([]rune(row))} {
+			for i := 0; i < len(lower); i++ {
+				j := i
+				for j+1 < len(lower) && strings.Contains(keys, string(lower[i:j+2])) {
+					j++
+				}
+				if j-i+1 >= 4 {
+					guesses := math.Log10(47 * float64(j-i+1))
+					if variations := uppercaseVariations(runes[i : j+1]); variations > 1 {
+						guesses += math.Log10(variations)
+					}
+					matches = append(matches, &strengthMatch{pattern: patternKeyboard, i: i, j: j, guesses: guesses})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// yearMatches finds the years from 1900 to 2099, the closer to now the easier to guess
+func yearMatches(runes []rune) []*strengthMatch {
+	var matches []*strengthMatch
+	now := time.Now().Year()
+	for i := 0; i+4 <= len(runes); i++ {
+		year := 0
+		for _, r := range runes[i : i+4] {
+			if r < '0' || r > '9' {
+				year = -1
+				break
+			}
+			year = year*10 + int(r-'0')
+		}
+		if year < 1900 || year > 2099 {
+			continue
+		}
+		guesses := math.Log10(math.Max(math.Abs(float64(year-now)), 20))
+		matches = append(matches, &strengthMatch{pattern: patternYear, i: i, j: i + 3, guesses: guesses})
+	}
+	return matches
+}
+
+// strengthFeedback returns a warning about the longest guessable part of a password and suggestions to make it
+// stronger. Passwords hard enough to guess get no feedback.
+func strengthFeedback(score, length int, sequence []*strengthMatch) (string, []string) {
+	if length == 0 {
+		return "", []string{
+			"Use a few words, avoid common phrases",
+			"No need for symbols, digits, or uppercase letters",
+		}
+	}
+	if score > 2 {
+		return "", []string{}
+	}
+
+	var longest *strengthMatch
+	for _, m := range sequence {
+		if longest == nil || m.j-m.i > longest.j-longest.i {
+			longest = m
+		}
+	}
+
+	extra := "Add another word or two. Uncommon words are better."
+	if longest == nil {
+		return "", []string{extra}
+	}
+
+	warning := ""
+	suggestions := []string{extra}
+	switch longest.pattern {
+	case patternDictionary:
+		switch {
+		case longest.i == 0 && longest.j == length-1 && longest.rank <= 10:
+			warning = "This is a top-10 common password"
+		case longest.i == 0 && longest.j == length-1 && longest.rank <= 100:
+			warning = "This is a top-100 common password"
+		case longest.i == 0 && longest.j == length-1:
+			warning = "This is a very common password"
+		default:
+			warning = "This is similar to a commonly used password"
+		}
+		if longest.uppercase {
+			suggestions = append(suggestions, "Capitalization doesn't help very much")
+		}
+		if longest.reversed {
+			suggestions = append(suggestions, "Reversed words aren't much harder to guess")
+		}
+		if longest.l33t {
+			suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+		}
+	case patternUserInput:
+		warning = "Your name, username or email is easy to guess"
+		suggestions = append(suggestions, "Avoid your name, username or email in your password")
+	case patternSequence:
+		warning = "Sequences like abc or 6543 are easy to guess"
+		suggestions = append(suggestions, "Avoid sequences")
+	case patternRepeat:
+		warning = `Repeats like "aaa" or "abcabc" are easy to guess`
+		suggestions = append(suggestions, "Avoid repeated words and characters")
+	case patternKeyboard:
+		warning = "Straight rows of keys are easy to guess"
+		suggestions = append(suggestions, "Use a longer keyboard pattern with more turns")
+	case patternYear:
+		warning = "Recent years are easy to guess"
+		suggestions = append(suggestions, "Avoid recent years", "Avoid years that are associated with you")
+	}
+	return warning, suggestions
+}
+
+// rankUserInputs ranks the inputs of a user, such as their email, username and names, along with their words
+func rankUserInputs(inputs []string) map[string]int {
+	var words []string
+	for _, input := range inputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" {
+			continue
+		}
+		words = append(words, input)
+		words = append(words, strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	return rankWords(words)
+}
+
+// rankWords ranks words by their position, 1 for the first, keeping the best rank of duplicates
+func rankWords(words []string) map[string]int {
+	ranks := make(map[string]int, len(words))
+	for k, word := range words {
+		if _, ok := ranks[word]; !ok && len([]rune(word)) >= 3 {
+			ranks[word] = k + 1
+		}
+	}
+	return ranks
+}
+
+// characterClass returns the class of a character, sequences do not cross classes
+func characterClass(r rune) int {
+	switch {
+	case unicode.IsLower(r):
+		return 1
+	case unicode.IsUpper(r):
+		return 2
+	case unicode.IsDigit(r):
+		return 3
+	default:
+		return 0
+	}
+}
+
+// characterCardinality returns the number of characters of the class of a character
+func characterCardinality(r rune) float64 {
+	switch {
+	case unicode.IsLower(r), unicode.IsUpper(r):
+		return 26
+	case unicode.IsDigit(r):
+		return 10
+	default:
+		return 33
+	}
+}
+
+// binomial returns the number of ways to choose k items among n
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+// reverse returns the runes in reverse order as a string
+func reverse(runes []rune) string {
+	reversed := make([]rune, len(runes))
+	for k, r := range runes {
+		reversed[len(runes)-1-k] = r
+	}
+	return string(reversed)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+var (
+	// ErrVerificationRequired is returned when a user must verify their account to perform an action
+	ErrVerificationRequired = errors.New("verification required")
+)
+
+// PolicyService decides which actions require a verified account
+type PolicyService interface {
+	// Requirement returns the verifications required to perform an action
+	Requirement(action string) entity.VerificationRequirement
+
+	// Check returns ErrVerificationRequired when a user does not meet the requirement of an action
+	Check(user *entity.User, action string) error
+
+	// Policies returns the requirement of every gated action
+	Policies() map[string]entity.VerificationRequirement
+}
+
+type policyService struct {
+	policies map[string]entity.VerificationRequirement
+}
+
+// NewPolicyService creates a new policy service from the configured gated actions
+func NewPolicyService(cfg config.PolicyConfig) PolicyService {
+	policies := make(map[string]entity.VerificationRequirement)
+	for _, action := range cfg.EmailVerificationRequired {
+		requirement := policies[action]
+		requirement.Email = true
+		policies[action] = requirement
+	}
+	for _, action := range cfg.PhoneVerificationRequired {
+		requirement := policies[action]
+		requirement.Phone = true
+		policies[action] = requirement
+	}
+
+	return &policyService{
+		policies: policies,
+	}
+}
+
+// Requirement returns the verifications required to perform an action
+func (s *policyService) Requirement(action string) entity.VerificationRequirement {
+	return s.policies[action]
+}
+
+// Check returns ErrVerificationRequired when a user does not meet the requirement of an action
+func (s *policyService) Check(user *entity.User, action string) error {
+	if !s.Requirement(action).SatisfiedBy(user) {
+		return ErrVerificationRequired
+	}
+	return nil
+}
+
+// Policies returns the requirement of every gated action
+func (s *policyService) Policies() map[string]entity.VerificationRequirement {
+	policies := make(map[string]entity.VerificationRequirement, len(s.policies))
+	for action, requirement := range s.policies {
+		policies[action] = requirement
+	}
+	return policies
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/chats/go-user-api/config"
@@ -20,31 +24,71 @@ var (
 	ErrExpiredToken = errors.New("token is expired")
 )
 
+// configuredKeyID is the key ID of the signing key from the configuration
+const configuredKeyID = "key-1"
+
 // TokenClaims represents the claims in a token
 type TokenClaims struct {
 	TokenID   uuid.UUID        `json:"jti"`
 	UserID    uuid.UUID        `json:"sub"`
 	TokenType entity.TokenType `json:"type"`
+	SessionID uuid.UUID        `json:"sid"`
+
+	// Role and OrgID scope what the user may administer, as of the time the token was issued
+	Role  string     `json:"role,omitempty"`
+	OrgID *uuid.UUID `json:"org,omitempty"`
+
+	// IssuedAt is checked against the revocation cutoff, zero in tokens issued before it was recorded
+	IssuedAt time.Time `json:"iat"`
+
+	// ClientID and Scopes are set in the tokens of service accounts, the scopes limiting what they may do, and in
+	// the tokens of OIDC relying parties, the scopes limiting the claims released to them
+	ClientID string   `json:"cid,omitempty"`
+	Scopes   []string `json:"scope,omitempty"`
 }
 
 // TokenService handles token operations
 type TokenService interface {
-	// GenerateTokens generates new access and refresh tokens
-	GenerateTokens(userID uuid.UUID) (*entity.AuthTokens, *entity.TokenDetails, *entity.TokenDetails, error)
+	// GenerateTokens generates new access and refresh tokens for a user, paired by the session ID. The refresh token
+	// of a persistent session lasts as long as configured for remember me.
+	GenerateTokens(user *entity.User, sessionID uuid.UUID, persistent bool) (*entity.AuthTokens, *entity.TokenDetails, *entity.TokenDetails, error)
+
+	// GenerateClientToken generates an access token for a service account, granted the scopes
+	GenerateClientToken(account *entity.ServiceAccount, scopes []string, sessionID uuid.UUID) (string, *entity.TokenDetails, error)
+
+	// GenerateOIDCTokens generates the tokens of a user issued to an OIDC relying party, granted the scopes. The
+	// refresh token is only generated with offline access, its details are nil otherwise.
+	GenerateOIDCTokens(user *entity.User, clientID string, scopes []string, sessionID uuid.UUID) (*entity.AuthTokens, *entity.TokenDetails, *entity.TokenDetails, error)
 
 	// ValidateToken validates a token and returns its claims
 	ValidateToken(token string) (*TokenClaims, error)
 
-	// GetPublicKey returns the public key for token verification
+	// GetPublicKey returns the public key of the active signing key
 	GetPublicKey() []byte
+
+	// AddSigningKey adds a key used to verify tokens, and to sign new tokens when activate is set
+	AddSigningKey(keyID string, privateKey ed25519.PrivateKey, activate bool)
+
+	// PublicKeys returns the public keys accepted for token verification by key ID
+	PublicKeys() map[string]ed25519.PublicKey
+
+	// SignJWT signs claims as a JWT with the active signing key, for relying parties verifying it against the key set
+	SignJWT(claims interface{}) (string, error)
 }
 
 type tokenService struct {
 	secretKey       string
-	publicKey       ed25519.PublicKey
-	privateKey      ed25519.PrivateKey
 	accessDuration  time.Duration
 	refreshDuration time.Duration
+
+	// persistentRefreshDuration is the lifetime of the refresh tokens of persistent sessions, remember me is
+	// ignored at 0
+	persistentRefreshDuration time.Duration
+
+	mu          sync.RWMutex
+	activeKeyID string
+	privateKey  ed25519.PrivateKey
+	publicKeys  map[string]ed25519.PublicKey
 }
 
 // NewTokenService creates a new token service
@@ -54,75 +98,197 @@ func NewTokenService(cfg config.SecurityConfig) (TokenService, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode private key: %w", err)
 	}
+	if len(privateKeyBytes) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("private key must be %d bytes", ed25519.PrivateKeySize)
+	}
 
 	// For Ed25519, the private key contains the public key in the second half
 	privateKey := ed25519.PrivateKey(privateKeyBytes)
 	publicKey := privateKey.Public().(ed25519.PublicKey)
 
 	return &tokenService{
-		secretKey:       cfg.JWTSecret,
-		publicKey:       publicKey,
-		privateKey:      privateKey,
-		accessDuration:  time.Duration(cfg.AccessTokenExpirationMinutes) * time.Minute,
-		refreshDuration: time.Duration(cfg.RefreshTokenExpirationDays) * 24 * time.Hour,
+		secretKey:                 cfg.JWTSecret,
+		accessDuration:            time.Duration(cfg.AccessTokenExpirationMinutes) * time.Minute,
+		refreshDuration:           time.Duration(cfg.RefreshTokenExpirationDays) * 24 * time.Hour,
+		persistentRefreshDuration: time.Duration(cfg.RememberMeRefreshTokenExpirationDays) * 24 * time.Hour,
+		activeKeyID:               configuredKeyID,
+		privateKey:                privateKey,
+		publicKeys:                map[string]ed25519.PublicKey{configuredKeyID: publicKey},
 	}, nil
 }
 
-// GenerateTokens generates new access and refresh tokens
-func (s *tokenService) GenerateTokens(userID uuid.UUID) (*entity.AuthTokens, *entity.TokenDetails, *entity.TokenDetails, error) {
+// GenerateTokens generates new access and refresh tokens for a user, paired by the session ID
+func (s *tokenService) GenerateTokens(user *entity.User, sessionID uuid.UUID, persistent bool) (*entity.AuthTokens, *entity.TokenDetails, *entity.TokenDetails, error) {
+	refreshDuration := s.refreshDuration
+	if persistent && s.persistentRefreshDuration > 0 {
+		refreshDuration = s.persistentRefreshDuration
+	} else {
+		persistent = false
+	}
+
 	// Create token details
+	now := time.Now()
 	accessTokenDetails := &entity.TokenDetails{
 		TokenID:    uuid.New(),
-		UserID:     userID,
+		UserID:     user.ID,
 		TokenType:  entity.AccessToken,
-		Expiration: time.Now().Add(s.accessDuration),
+		Expiration: now.Add(s.accessDuration),
+		SessionID:  sessionID,
+		IssuedAt:   now,
+		Persistent: persistent,
 	}
 
 	refreshTokenDetails := &entity.TokenDetails{
 		TokenID:    uuid.New(),
-		UserID:     userID,
+		UserID:     user.ID,
 		TokenType:  entity.RefreshToken,
-		Expiration: time.Now().Add(s.refreshDuration),
+		Expiration: now.Add(refreshDuration),
+		SessionID:  sessionID,
+		IssuedAt:   now,
+		Persistent: persistent,
 	}
 
 	// Create new PASETO tokens
-	accessToken, err := s.createToken(accessTokenDetails)
+	accessToken, err := s.createToken(accessTokenDetails, user)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create access token: %w", err)
 	}
 
-	refreshToken, err := s.createToken(refreshTokenDetails)
+	refreshToken, err := s.createToken(refreshTokenDetails, user)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
 	return &entity.AuthTokens{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    accessTokenDetails.Expiration,
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresAt:        accessTokenDetails.Expiration,
+		RefreshExpiresAt: refreshTokenDetails.Expiration,
 	}, accessTokenDetails, refreshTokenDetails, nil
 }
 
-// createToken creates a new PASETO token
-func (s *tokenService) createToken(details *entity.TokenDetails) (string, error) {
-	// Create a new PASETO token (v2.local for symmetric encryption or v2.public for asymmetric)
-	v2 := paseto.NewV2()
+// GenerateClientToken generates an access token for a service account, lasting as long as the access tokens of
+// users. The token has no role, the scopes decide what the service account may do.
+func (s *tokenService) GenerateClientToken(account *entity.ServiceAccount, scopes []string, sessionID uuid.UUID) (string, *entity.TokenDetails, error) {
+	now := time.Now()
+	details := &entity.TokenDetails{
+		TokenID:    uuid.New(),
+		UserID:     account.ID,
+		TokenType:  entity.AccessToken,
+		Expiration: now.Add(s.accessDuration),
+		SessionID:  sessionID,
+		IssuedAt:   now,
+		ClientID:   account.ClientID,
+	}
 
-	// Create footer (optional)
-	footer := map[string]interface{}{
-		"kid": "key-1", // Key ID for key rotation
+	token, err := s.signToken(TokenClaims{
+		TokenID:   details.TokenID,
+		UserID:    details.UserID,
+		TokenType: details.TokenType,
+		SessionID: details.SessionID,
+		IssuedAt:  details.IssuedAt,
+		ClientID:  details.ClientID,
+		Scopes:    scopes,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create access token: %w", err)
+	}
+
+	return token, details, nil
+}
+
+// GenerateOIDCTokens generates the tokens of a user issued to an OIDC relying party. The tokens carry the client
+// and scopes but no role, so they grant none of the powers of the user, and their types keep them from being
+// accepted anywhere but the user info and token endpoints of the provider.
+func (s *tokenService) GenerateOIDCTokens(user *entity.User, clientID string, scopes []string, sessionID uuid.UUID) (*entity.AuthTokens, *entity.TokenDetails, *entity.TokenDetails, error) {
+	now := time.Now()
+	accessDetails := &entity.TokenDetails{
+		TokenID:    uuid.New(),
+		UserID:     user.ID,
+		TokenType:  entity.AccessToken,
+		Expiration: now.Add(s.accessDuration),
+		SessionID:  sessionID,
+		IssuedAt:   now,
+		ClientID:   clientID,
+	}
+
+	accessToken, err := s.signOIDCToken(accessDetails, entity.OIDCAccessToken, scopes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create access token: %w", err)
+	}
+	tokens := &entity.AuthTokens{
+		AccessToken: accessToken,
+		ExpiresAt:   accessDetails.Expiration,
+	}
+
+	if !slices.Contains(scopes, entity.OIDCScopeOfflineAccess) {
+		return tokens, accessDetails, nil, nil
+	}
+
+	refreshDetails := &entity.TokenDetails{
+		TokenID:    uuid.New(),
+		UserID:     user.ID,
+		TokenType:  entity.RefreshToken,
+		Expiration: now.Add(s.refreshDuration),
+		SessionID:  sessionID,
+		IssuedAt:   now,
+		ClientID:   clientID,
 	}
 
+	refreshToken, err := s.signOIDCToken(refreshDetails, entity.OIDCRefreshToken, scopes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	tokens.RefreshToken = refreshToken
+	tokens.RefreshExpiresAt = refreshDetails.Expiration
+
+	return tokens, accessDetails, refreshDetails, nil
+}
+
+// signOIDCToken signs a token issued to an OIDC relying party, of the OIDC type matching its stored type
+func (s *tokenService) signOIDCToken(details *entity.TokenDetails, tokenType entity.TokenType, scopes []string) (string, error) {
+	return s.signToken(TokenClaims{
+		TokenID:   details.TokenID,
+		UserID:    details.UserID,
+		TokenType: tokenType,
+		SessionID: details.SessionID,
+		IssuedAt:  details.IssuedAt,
+		ClientID:  details.ClientID,
+		Scopes:    scopes,
+	})
+}
+
+// createToken creates a new PASETO token
+func (s *tokenService) createToken(details *entity.TokenDetails, user *entity.User) (string, error) {
 	// Create claims
-	claims := TokenClaims{
+	return s.signToken(TokenClaims{
 		TokenID:   details.TokenID,
 		UserID:    details.UserID,
 		TokenType: details.TokenType,
+		SessionID: details.SessionID,
+		Role:      user.Role,
+		OrgID:     user.OrgID,
+		IssuedAt:  details.IssuedAt,
+	})
+}
+
+// signToken signs claims as a PASETO token with the active signing key
+func (s *tokenService) signToken(claims TokenClaims) (string, error) {
+	// Create a new PASETO token (v2.local for symmetric encryption or v2.public for asymmetric)
+	v2 := paseto.NewV2()
+
+	s.mu.RLock()
+	keyID, privateKey := s.activeKeyID, s.privateKey
+	s.mu.RUnlock()
+
+	// Create footer, the key ID selects the verification key
+	footer := map[string]interface{}{
+		"kid": keyID,
 	}
 
 	// Sign token with claims
 	// For v2.public we use asymmetric encryption (ed25519)
-	token, err := v2.Sign(s.privateKey, claims, footer)
+	token, err := v2.Sign(privateKey, claims, footer)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -136,8 +302,21 @@ func (s *tokenService) ValidateToken(token string) (*TokenClaims, error) {
 	var claims TokenClaims
 	var footer map[string]interface{}
 
+	// Select the verification key from the footer key ID
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+	keyID, _ := footer["kid"].(string)
+
+	s.mu.RLock()
+	publicKey, ok := s.publicKeys[keyID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
 	// Verify token and extract claims
-	err := v2.Verify(token, s.publicKey, &claims, &footer)
+	err := v2.Verify(token, publicKey, &claims, nil)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
@@ -145,7 +324,59 @@ func (s *tokenService) ValidateToken(token string) (*TokenClaims, error) {
 	return &claims, nil
 }
 
-// GetPublicKey returns the public key for token verification
+// GetPublicKey returns the public key of the active signing key
 func (s *tokenService) GetPublicKey() []byte {
-	return s.publicKey
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.publicKeys[s.activeKeyID]
+}
+
+// AddSigningKey adds a key used to verify tokens, and to sign new tokens when activate is set
+func (s *tokenService) AddSigningKey(keyID string, privateKey ed25519.PrivateKey, activate bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.publicKeys[keyID] = privateKey.Public().(ed25519.PublicKey)
+	if activate {
+		s.activeKeyID = keyID
+		s.privateKey = privateKey
+	}
+}
+
+// PublicKeys returns the public keys accepted for token verification by key ID
+func (s *tokenService) PublicKeys() map[string]ed25519.PublicKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make(map[string]ed25519.PublicKey, len(s.publicKeys))
+	for keyID, publicKey := range s.publicKeys {
+		keys[keyID] = publicKey
+	}
+	return keys
+}
+
+// SignJWT signs claims as a JWT with the active signing key, for relying parties verifying it against the key set
+func (s *tokenService) SignJWT(claims interface{}) (string, error) {
+	s.mu.RLock()
+	keyID, privateKey := s.activeKeyID, s.privateKey
+	s.mu.RUnlock()
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "EdDSA",
+		"typ": "JWT",
+		"kid": keyID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT header: %w", err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature := ed25519.Sign(privateKey, []byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
 }
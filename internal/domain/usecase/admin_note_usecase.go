@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrAdminNoteNotFound is returned when acting on an unknown note, or a note on another user
+	ErrAdminNoteNotFound = errors.New("admin note not found")
+
+	// ErrInvalidAdminNote is returned when writing an empty or overly long note
+	ErrInvalidAdminNote = errors.New("invalid admin note")
+
+	// ErrAdminNoteNotAuthor is returned when editing a note written by another administrator
+	ErrAdminNoteNotAuthor = errors.New("admin note written by another administrator")
+)
+
+// maxAdminNoteLength caps the length of notes, in characters
+const maxAdminNoteLength = 4000
+
+// AdminNoteUseCase defines the use case for the internal notes administrators attach to users
+type AdminNoteUseCase interface {
+	// Create writes a note on a user on behalf of an administrator, its author
+	Create(ctx context.Context, actorID, userID uuid.UUID, text string) (*entity.AdminNote, error)
+
+	// List returns the notes on a user, newest first
+	List(ctx context.Context, userID uuid.UUID) ([]*entity.AdminNote, error)
+
+	// Update replaces the text of a note, only its author may
+	Update(ctx context.Context, actorID, userID, noteID uuid.UUID, text string) (*entity.AdminNote, error)
+
+	// Delete deletes a note on behalf of any administrator
+	Delete(ctx context.Context, actorID, userID, noteID uuid.UUID) error
+}
+
+// adminNoteUseCase implements AdminNoteUseCase interface
+type adminNoteUseCase struct {
+	adminNoteRepo repository.AdminNoteRepository
+	userRepo      repository.UserRepository
+	auditRepo     repository.AuditRepository
+}
+
+// NewAdminNoteUseCase creates a new AdminNoteUseCase
+func NewAdminNoteUseCase(
+	adminNoteRepo repository.AdminNoteRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+) AdminNoteUseCase {
+	return &adminNoteUseCase{
+		adminNoteRepo: adminNoteRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// normalizeAdminNote trims a note, ErrInvalidAdminNote when empty or too long
+func normalizeAdminNote(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxAdminNoteLength {
+		return "", ErrInvalidAdminNote
+	}
+	return text, nil
+}
+
+// Create writes a note on an existing user
+func (uc *adminNoteUseCase) Create(ctx context.Context, actorID, userID uuid.UUID, text string) (*entity.AdminNote, error) {
+	text, err := normalizeAdminNote(text)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	now := time.Now()
+	note := &entity.AdminNote{
+		ID:        uuid.New(),
+		UserID:    userID,
+		AuthorID:  actorID,
+		Text:      text,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.adminNoteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionAdminNoteAdded, actorID, note)
+	return note, nil
+}
+
+// List returns the notes on a user, newest first
+func (uc *adminNoteUseCase) List(ctx context.Context, userID uuid.UUID) ([]*entity.AdminNote, error) {
+	return uc.adminNoteRepo.ListByUser(ctx, userID)
+}
+
+// Update replaces the text of a note, the notes of an administrator keep saying what they wrote
+func (uc *adminNoteUseCase) Update(ctx context.Context, actorID, userID, noteID uuid.UUID, text string) (*entity.AdminNote, error) {
+	text, err := normalizeAdminNote(text)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := uc.get(ctx, userID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if note.AuthorID != actorID {
+		return nil, ErrAdminNoteNotAuthor
+	}
+
+	note.Text = text
+	note.UpdatedAt = time.Now()
+	if err := uc.adminNoteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionAdminNoteEdited, actorID, note)
+	return note, nil
+}
+
+// Delete deletes a note, the audit trail records who deleted it
+func (uc *adminNoteUseCase) Delete(ctx context.Context, actorID, userID, noteID uuid.UUID) error {
+	note, err := uc.get(ctx, userID, noteID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.adminNoteRepo.Delete(ctx, note.ID); err != nil {
+		return err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionAdminNoteDeleted, actorID, note)
+	return nil
+}
+
+// get returns a note on a user, ErrAdminNoteNotFound if unknown or on another user
+func (uc *adminNoteUseCase) get(ctx context.Context, userID, noteID uuid.UUID) (*entity.AdminNote, error) {
+	note, err := uc.adminNoteRepo.GetByID(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if note == nil || note.UserID != userID {
+		return nil, ErrAdminNoteNotFound
+	}
+	return note, nil
+}
+
+// recordAction records an action on a note in the audit trail, the user is the target. The text is left out, so
+// it does not outlive the note or the user in the audit trail.
+func (uc *adminNoteUseCase) recordAction(ctx context.Context, action string, actorID uuid.UUID, note *entity.AdminNote) {
+	entry := entity.NewAuditEntry(action, actorID, note.UserID, map[string]string{
+		"note_id":   note.ID.String(),
+		"author_id": note.AuthorID.String(),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("note_id", note.ID.String()).Msg("Failed to record admin note action in audit trail")
+	}
+}
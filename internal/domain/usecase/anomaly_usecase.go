@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/ratelimit"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrIPDenied is returned when signing in from an IP address denied after an anomaly
+	ErrIPDenied = errors.New("IP address denied")
+
+	// ErrStepUpRequired is returned when an admin signs in with a password from a new country
+	ErrStepUpRequired = errors.New("step-up authentication required")
+
+	ErrIPDenialNotFound = errors.New("IP denial not found")
+	ErrInvalidIP        = errors.New("invalid IP address")
+	ErrInvalidCountry   = errors.New("invalid country")
+)
+
+// AnomalyUseCase defines the use case of the automated responses to sign in anomalies. Every response is audited
+// and can be reversed by an administrator.
+type AnomalyUseCase interface {
+	// CheckClient returns ErrIPDenied when the IP address of the client is denied sign in
+	CheckClient(ctx context.Context, client entity.ClientInfo) error
+
+	// RecordFailedLogin counts a failed login from the IP address of the client, whatever the account, and denies
+	// the address sign in once it reaches the threshold
+	RecordFailedLogin(ctx context.Context, client entity.ClientInfo)
+
+	// CheckCountry returns ErrStepUpRequired when an admin signs in with a password from a country they never
+	// signed in from. The country of any other sign in is remembered.
+	CheckCountry(ctx context.Context, user *entity.User, client entity.ClientInfo) error
+
+	// RememberCountry remembers the country of a sign in that needs no step-up, such as a sign in with a passkey
+	RememberCountry(ctx context.Context, user *entity.User, client entity.ClientInfo)
+
+	// ListIPDenials returns the denied IP addresses, newest first
+	ListIPDenials(ctx context.Context) ([]*entity.IPDenial, error)
+
+	// LiftIPDenial lifts the denial of an IP address and forgets its failed logins, performed by an administrator
+	LiftIPDenial(ctx context.Context, actorID uuid.UUID, ip string) error
+
+	// ListLoginCountries returns the countries a user signed in from
+	ListLoginCountries(ctx context.Context, userID uuid.UUID) ([]string, error)
+
+	// TrustLoginCountry lets a user sign in from a country without a step-up, performed by an administrator
+	TrustLoginCountry(ctx context.Context, actorID, userID uuid.UUID, country string) error
+}
+
+// anomalyUseCase implements AnomalyUseCase interface
+type anomalyUseCase struct {
+	ipDenialRepo     repository.IPDenialRepository
+	loginCountryRepo repository.LoginCountryRepository
+	userRepo         repository.UserRepository
+	auditRepo        repository.AuditRepository
+	limiter          ratelimit.Limiter
+	config           config.AnomalyConfig
+}
+
+// NewAnomalyUseCase creates a new AnomalyUseCase
+func NewAnomalyUseCase(
+	ipDenialRepo repository.IPDenialRepository,
+	loginCountryRepo repository.LoginCountryRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	limiter ratelimit.Limiter,
+	config config.AnomalyConfig,
+) AnomalyUseCase {
+	return &anomalyUseCase{
+		ipDenialRepo:     ipDenialRepo,
+		loginCountryRepo: loginCountryRepo,
+		userRepo:         userRepo,
+		auditRepo:        auditRepo,
+		limiter:          limiter,
+		config:           config,
+	}
+}
+
+// CheckClient returns ErrIPDenied when the IP address of the client is denied sign in
+func (uc *anomalyUseCase) CheckClient(ctx context.Context, client entity.ClientInfo) error {
+	if client.IP == "" {
+		return nil
+	}
+
+	denial, err := uc.ipDenialRepo.GetActive(ctx, client.IP, time.Now())
+	if err != nil {
+		// Fail open, losing the database must not deny everyone
+		log.Warn().Err(err).Str("ip", client.IP).Msg("Failed to check IP denial")
+		return nil
+	}
+	if denial != nil {
+		return ErrIPDenied
+	}
+	return nil
+}
+
+// RecordFailedLogin counts a failed login from the IP address of the client
+func (uc *anomalyUseCase) RecordFailedLogin(ctx context.Context, client entity.ClientInfo) {
+	if uc.config.IPFailedLogins <= 0 || client.IP == "" {
+		return
+	}
+
+	result, err := uc.limiter.Allow(ctx, ipFailedLoginKey(client.IP), uc.config.IPFailedLogins, uc.config.IPFailedLoginWindow)
+	if err != nil {
+		log.Warn().Err(err).Str("ip", client.IP).Msg("Failed to record failed login of IP")
+		return
+	}
+	if result.Allowed {
+		return
+	}
+
+	now := time.Now()
+	denial := &entity.IPDenial{
+		IP:           client.IP,
+		Reason:       entity.IPDenialReasonFailedLogins,
+		FailedLogins: result.Count,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(uc.config.IPDenialDuration),
+	}
+	created, err := uc.ipDenialRepo.Create(ctx, denial)
+	if err != nil {
+		log.Error().Err(err).Str("ip", client.IP).Msg("Failed to deny IP")
+		return
+	}
+	if !created {
+		return // Denied by a concurrent failed login
+	}
+
+	log.Warn().Str("ip", client.IP).Int("failed_logins", result.Count).Time("expires_at", denial.ExpiresAt).Msg("IP denied sign in after too many failed logins")
+	entry := entity.NewAuditEntry(entity.AuditActionIPDenied, uuid.Nil, uuid.Nil, map[string]string{
+		"ip":            client.IP,
+		"reason":        denial.Reason,
+		"failed_logins": strconv.Itoa(denial.FailedLogins),
+		"expires_at":    denial.ExpiresAt.Format(time.RFC3339),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Failed to record IP denial in audit trail")
+	}
+}
+
+// CheckCountry returns ErrStepUpRequired when an admin signs in with a password from a new country
+func (uc *anomalyUseCase) CheckCountry(ctx context.Context, user *entity.User, client entity.ClientInfo) error {
+	country := normalizeCountry(client.Country)
+	if !uc.config.AdminNewCountryStepUp || country == "" {
+		return nil
+	}
+	if user.Role != entity.UserRoleAdmin && user.Role != entity.UserRoleOrgAdmin {
+		uc.RememberCountry(ctx, user, client)
+		return nil
+	}
+
+	countries, err := uc.loginCountryRepo.List(ctx, user.ID)
+	if err != nil {
+		// Fail open, like the lockout
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to list login countries")
+		return nil
+	}
+	// The first country is trusted, the admins signing in before the step-up was enabled included
+	if len(countries) == 0 || slices.Contains(countries, country) {
+		uc.RememberCountry(ctx, user, client)
+		return nil
+	}
+
+	log.Warn().Str("user_id", user.ID.String()).Str("country", country).Msg("Admin sign in from a new country requires a step-up")
+	entry := entity.NewAuditEntry(entity.AuditActionStepUpRequired, uuid.Nil, user.ID, map[string]string{
+		"country": country,
+		"ip":      client.IP,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Failed to record step-up in audit trail")
+	}
+	return ErrStepUpRequired
+}
+
+// RememberCountry remembers the country of a sign in that needs no step-up
+func (uc *anomalyUseCase) RememberCountry(ctx context.Context, user *entity.User, client entity.ClientInfo) {
+	country := normalizeCountry(client.Country)
+	if !uc.config.AdminNewCountryStepUp || country == "" {
+		return
+	}
+
+	if _, err := uc.loginCountryRepo.Add(ctx, user.ID, country); err != nil {
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to remember login country")
+	}
+}
+
+// ListIPDenials returns the denied IP addresses, newest first
+func (uc *anomalyUseCase) ListIPDenials(ctx context.Context) ([]*entity.IPDenial, error) {
+	return uc.ipDenialRepo.ListActive(ctx, time.Now())
+}
+
+// LiftIPDenial lifts the denial of an IP address and forgets its failed logins
+func (uc *anomalyUseCase) LiftIPDenial(ctx context.Context, actorID uuid.UUID, ip string) error {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ErrInvalidIP
+	}
+	ip = parsed.String()
+
+	denial, err := uc.ipDenialRepo.GetActive(ctx, ip, time.Now())
+	if err != nil {
+		return err
+	}
+	if denial == nil {
+		return ErrIPDenialNotFound
+	}
+
+	if err := uc.ipDenialRepo.Delete(ctx, ip); err != nil {
+		return err
+	}
+	// Otherwise the next failed login would deny the address again
+	if err := uc.limiter.Reset(ctx, ipFailedLoginKey(ip), uc.config.IPFailedLoginWindow); err != nil {
+		log.Warn().Err(err).Str("ip", ip).Msg("Failed to forget failed logins of IP")
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionIPDenialLifted, actorID, uuid.Nil, map[string]string{
+		"ip":     ip,
+		"reason": denial.Reason,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Failed to record IP denial lift in audit trail")
+	}
+
+	return nil
+}
+
+// ListLoginCountries returns the countries a user signed in from
+func (uc *anomalyUseCase) ListLoginCountries(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return uc.loginCountryRepo.List(ctx, userID)
+}
+
+// TrustLoginCountry lets a user sign in from a country without a step-up
+func (uc *anomalyUseCase) TrustLoginCountry(ctx context.Context, actorID, userID uuid.UUID, country string) error {
+	country = normalizeCountry(country)
+	if country == "" {
+		return ErrInvalidCountry
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	added, err := uc.loginCountryRepo.Add(ctx, userID, country)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return nil
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionLoginCountryTrusted, actorID, userID, map[string]string{
+		"country": country,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Failed to record trusted login country in audit trail")
+	}
+
+	return nil
+}
+
+// normalizeCountry returns the upper-case ISO 3166-1 alpha-2 code of a country, or an empty string for the
+// unknown or invalid countries
+func normalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' || country == "XX" {
+		return ""
+	}
+	return country
+}
+
+// ipFailedLoginKey identifies the budget of failed logins of an IP address in the limiter
+func ipFailedLoginKey(ip string) string {
+	return "anomaly:ip:" + ip
+}
@@ -0,0 +1,383 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrInvalidAPIKey is returned when authenticating with an unknown or expired API key, or the API key of a
+	// user who may not sign in
+	ErrInvalidAPIKey = errors.New("invalid API key")
+
+	// ErrAPIKeyNotFound is returned when revoking an unknown API key
+	ErrAPIKeyNotFound = errors.New("API key not found")
+
+	// ErrTooManyAPIKeys is returned when creating an API key for a user or organization holding the maximum
+	// number of API keys
+	ErrTooManyAPIKeys = errors.New("too many API keys")
+
+	// ErrInvalidAPIKeyName is returned when naming an API key with an overly long name
+	ErrInvalidAPIKeyName = errors.New("invalid API key name")
+
+	// ErrInvalidAPIKeyScope is returned when creating an API key with an unknown scope
+	ErrInvalidAPIKeyScope = errors.New("invalid API key scope")
+
+	// ErrInvalidAPIKeyExpiration is returned when creating an API key expiring in the past or beyond the
+	// maximum lifetime
+	ErrInvalidAPIKeyExpiration = errors.New("invalid API key expiration")
+)
+
+const (
+	// apiKeyPrefix starts every API key, so leaked keys are easy to recognize and scan for
+	apiKeyPrefix = "uak_"
+
+	// apiKeyDisplayLength is the length of the beginning of the keys stored to tell them apart
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+
+	// maxAPIKeyNameLength caps the length of API key names, in characters
+	maxAPIKeyNameLength = 64
+
+	// apiKeyUsageResolution is how often the last use time of an API key is stored, keys authenticating every
+	// request are not written to on every request
+	apiKeyUsageResolution = 5 * time.Minute
+)
+
+// APIKeyUseCase defines the use case for the API keys users call the API with
+type APIKeyUseCase interface {
+	// Create creates an API key of a user and returns it along with the key, which is not revealed again.
+	// Keys are created with the default scopes when none are given.
+	Create(ctx context.Context, userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*entity.CreatedAPIKey, error)
+
+	// List returns the API keys of a user, oldest first
+	List(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error)
+
+	// Revoke deletes an API key of a user on behalf of an actor
+	Revoke(ctx context.Context, actorID, userID, id uuid.UUID) error
+
+	// CreateForOrg creates an API key of an organization on behalf of an actor, like Create
+	CreateForOrg(ctx context.Context, actorID, orgID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*entity.CreatedAPIKey, error)
+
+	// ListForOrg returns the API keys of an organization, oldest first
+	ListForOrg(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error)
+
+	// RevokeForOrg deletes an API key of an organization on behalf of an actor
+	RevokeForOrg(ctx context.Context, actorID, orgID, id uuid.UUID) error
+
+	// Authenticate returns the API key of a key and its owner, nil for the keys of an organization
+	Authenticate(ctx context.Context, key string) (*entity.APIKey, *entity.User, error)
+}
+
+// apiKeyUseCase implements APIKeyUseCase interface
+type apiKeyUseCase struct {
+	apiKeyRepo          repository.APIKeyRepository
+	userRepo            repository.UserRepository
+	orgRepo             repository.OrganizationRepository
+	auditRepo           repository.AuditRepository
+	notificationUseCase NotificationUseCase
+
+	maxPerUser    int
+	maxPerOrg     int
+	maxLifetime   time.Duration
+	defaultScopes []string
+}
+
+// NewAPIKeyUseCase creates a new APIKeyUseCase
+func NewAPIKeyUseCase(
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	orgRepo repository.OrganizationRepository,
+	auditRepo repository.AuditRepository,
+	notificationUseCase NotificationUseCase,
+	apiKeyCfg config.APIKeyConfig,
+) APIKeyUseCase {
+	return &apiKeyUseCase{
+		apiKeyRepo:          apiKeyRepo,
+		userRepo:            userRepo,
+		orgRepo:             orgRepo,
+		auditRepo:           auditRepo,
+		notificationUseCase: notificationUseCase,
+		maxPerUser:          apiKeyCfg.MaxPerUser,
+		maxPerOrg:           apiKeyCfg.MaxPerOrg,
+		maxLifetime:         apiKeyCfg.MaxLifetime,
+		defaultScopes:       apiKeyCfg.DefaultScopes,
+	}
+}
+
+// apiKeyHash returns the hash identifying an API key.
+// Only a hash of the key is stored, so the database contents cannot be used to call the API.
+func apiKeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create creates an API key of a user, expiring after the maximum lifetime when one is configured
+func (uc *apiKeyUseCase) Create(ctx context.Context, userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*entity.CreatedAPIKey, error) {
+	created, err := uc.newAPIKey(name, scopes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	keys, err := uc.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) >= uc.maxPerUser {
+		return nil, ErrTooManyAPIKeys
+	}
+
+	created.UserID = userID
+	created.CreatedBy = userID
+	if err := uc.apiKeyRepo.Create(ctx, created.APIKey); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionAPIKeyCreated, userID, user, created.APIKey)
+	return created, nil
+}
+
+// CreateForOrg creates an API key of an organization, expiring after the maximum lifetime when one is configured
+func (uc *apiKeyUseCase) CreateForOrg(ctx context.Context, actorID, orgID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*entity.CreatedAPIKey, error) {
+	created, err := uc.newAPIKey(name, scopes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := uc.ListForOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) >= uc.maxPerOrg {
+		return nil, ErrTooManyAPIKeys
+	}
+
+	created.OrgID = &orgID
+	created.CreatedBy = actorID
+	if err := uc.apiKeyRepo.Create(ctx, created.APIKey); err != nil {
+		return nil, err
+	}
+
+	uc.recordOrgAction(ctx, entity.AuditActionOrgAPIKeyCreated, actorID, created.APIKey)
+	return created, nil
+}
+
+// newAPIKey validates the name, scopes and expiration of a new API key and generates its key, the owner is left
+// for the caller to set
+func (uc *apiKeyUseCase) newAPIKey(name string, scopes []string, expiresAt *time.Time) (*entity.CreatedAPIKey, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxAPIKeyNameLength {
+		return nil, ErrInvalidAPIKeyName
+	}
+
+	if len(scopes) == 0 {
+		scopes = uc.defaultScopes
+	}
+	scopes = slices.Compact(slices.Sorted(slices.Values(scopes)))
+	for _, scope := range scopes {
+		if !entity.IsValidAPIKeyScope(scope) {
+			return nil, ErrInvalidAPIKeyScope
+		}
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrInvalidAPIKeyExpiration
+	}
+	if uc.maxLifetime > 0 {
+		latest := now.Add(uc.maxLifetime)
+		if expiresAt == nil {
+			expiresAt = &latest
+		} else if expiresAt.After(latest) {
+			return nil, ErrInvalidAPIKeyExpiration
+		}
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := &entity.APIKey{
+		ID:        uuid.New(),
+		Name:      cmp.Or(name, "API key"),
+		Prefix:    key[:apiKeyDisplayLength],
+		Hash:      apiKeyHash(key),
+		Scopes:    scopes,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	return &entity.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// List returns the API keys of a user, oldest first
+func (uc *apiKeyUseCase) List(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	return uc.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+// Revoke deletes an API key of a user. API keys of other users are reported as not found.
+func (uc *apiKeyUseCase) Revoke(ctx context.Context, actorID, userID, id uuid.UUID) error {
+	keys, err := uc.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	index := slices.IndexFunc(keys, func(key *entity.APIKey) bool {
+		return key.ID == id
+	})
+	if index < 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := uc.apiKeyRepo.Delete(ctx, keys[index]); err != nil {
+		return err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionAPIKeyRevoked, actorID, user, keys[index])
+	return nil
+}
+
+// ListForOrg returns the API keys of an organization, oldest first
+func (uc *apiKeyUseCase) ListForOrg(ctx context.Context, orgID uuid.UUID) ([]*entity.APIKey, error) {
+	org, err := uc.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	return uc.apiKeyRepo.ListByOrg(ctx, orgID)
+}
+
+// RevokeForOrg deletes an API key of an organization. API keys of users and other organizations are reported as
+// not found.
+func (uc *apiKeyUseCase) RevokeForOrg(ctx context.Context, actorID, orgID, id uuid.UUID) error {
+	keys, err := uc.ListForOrg(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	index := slices.IndexFunc(keys, func(key *entity.APIKey) bool {
+		return key.ID == id
+	})
+	if index < 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	if err := uc.apiKeyRepo.Delete(ctx, keys[index]); err != nil {
+		return err
+	}
+
+	uc.recordOrgAction(ctx, entity.AuditActionOrgAPIKeyRevoked, actorID, keys[index])
+	return nil
+}
+
+// Authenticate returns the API key of a key and its owner. The key must not be expired and its owner must be
+// an active user allowed to sign in, otherwise ErrInvalidAPIKey is returned. The keys of an organization have no
+// owner, their organization must exist.
+func (uc *apiKeyUseCase) Authenticate(ctx context.Context, key string) (*entity.APIKey, *entity.User, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := uc.apiKeyRepo.GetByHash(ctx, apiKeyHash(key))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if apiKey == nil || apiKey.IsExpired(now) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	var user *entity.User
+	if apiKey.IsOrgKey() {
+		org, err := uc.orgRepo.GetByID(ctx, *apiKey.OrgID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if org == nil {
+			return nil, nil, ErrInvalidAPIKey
+		}
+	} else {
+		user, err = uc.userRepo.GetByID(ctx, apiKey.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if user == nil || user.Status != entity.UserStatusActive || user.PasswordResetRequired {
+			return nil, nil, ErrInvalidAPIKey
+		}
+	}
+
+	// The request goes through even when the usage cannot be stored, it is stored again on a later request
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyUsageResolution {
+		apiKey.LastUsedAt = &now
+		if err := uc.apiKeyRepo.UpdateUsage(ctx, apiKey); err != nil {
+			log.Error().Err(err).Str("api_key_id", apiKey.ID.String()).Msg("Failed to update API key usage")
+		}
+	}
+
+	return apiKey, user, nil
+}
+
+// recordAction records an action on an API key in the audit trail and notifies its owner
+func (uc *apiKeyUseCase) recordAction(ctx context.Context, action string, actorID uuid.UUID, user *entity.User, apiKey *entity.APIKey) {
+	details := map[string]string{
+		"api_key_id":     apiKey.ID.String(),
+		"api_key_name":   apiKey.Name,
+		"api_key_prefix": apiKey.Prefix,
+		"scopes":         strings.Join(apiKey.Scopes, ", "),
+	}
+
+	entry := entity.NewAuditEntry(action, actorID, user.ID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("user_id", user.ID.String()).Msg("Failed to record API key action in audit trail")
+	}
+
+	uc.notificationUseCase.NotifyAdminAction(ctx, action, actorID, user, details)
+}
+
+// recordOrgAction records an action on an API key of an organization in the audit trail, there is no owner to
+// notify
+func (uc *apiKeyUseCase) recordOrgAction(ctx context.Context, action string, actorID uuid.UUID, apiKey *entity.APIKey) {
+	details := map[string]string{
+		"org_id":         apiKey.OrgID.String(),
+		"api_key_id":     apiKey.ID.String(),
+		"api_key_name":   apiKey.Name,
+		"api_key_prefix": apiKey.Prefix,
+		"scopes":         strings.Join(apiKey.Scopes, ", "),
+	}
+
+	entry := entity.NewAuditEntry(action, actorID, uuid.Nil, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("org_id", details["org_id"]).Msg("Failed to record organization API key action in audit trail")
+	}
+}
@@ -1,10 +1,19 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
+	"github.com/chats/go-user-api/config"
 	"github.com/chats/go-user-api/internal/domain/entity"
 	"github.com/chats/go-user-api/internal/domain/repository"
 	"github.com/chats/go-user-api/internal/domain/service"
@@ -19,15 +28,170 @@ var (
 
 	// ErrRefreshTokenExpired is returned when a refresh token is expired
 	ErrRefreshTokenExpired = errors.New("refresh token expired")
+
+	// ErrSessionLimitReached is returned when signing in beyond the limit of concurrent sessions, which rejects
+	// the login rather than evicting a session
+	ErrSessionLimitReached = errors.New("too many active sessions")
+
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+
+	// ErrSessionNotFound is returned when a session is unknown or expired
+	ErrSessionNotFound = errors.New("session not found")
+
+	// ErrInvalidVerificationToken is returned when an email verification token is unknown or expired
+	ErrInvalidVerificationToken = errors.New("invalid verification token")
+
+	// ErrAlreadyVerified is returned when requesting the verification of a verified email
+	ErrAlreadyVerified = errors.New("email already verified")
+
+	// ErrInvalidRecoveryEmail is returned when a recovery email is malformed or is the primary email
+	ErrInvalidRecoveryEmail = errors.New("invalid recovery email")
+
+	// ErrRecoveryEmailNotSet is returned when removing the recovery email of a user without one
+	ErrRecoveryEmailNotSet = errors.New("recovery email not set")
+
+	// ErrInvalidResetToken is returned when a password reset token is unknown or expired
+	ErrInvalidResetToken = errors.New("invalid password reset token")
+
+	// ErrPasswordResetRequired is returned when signing in to an account that must reset its password first
+	ErrPasswordResetRequired = errors.New("password reset required")
+
+	// ErrAccountBlocked is returned, wrapped in an AccountBlockedError, when signing in to a blocked account
+	ErrAccountBlocked = errors.New("account blocked")
+
+	// ErrAccountInactive is returned when signing in to a deactivated account
+	ErrAccountInactive = errors.New("account inactive")
+
+	// ErrAccountWaitlisted is returned when signing in to an account waiting on the waitlist
+	ErrAccountWaitlisted = errors.New("account waitlisted")
+
+	// ErrInvalidCutoff is returned when revoking the tokens issued before a time in the future
+	ErrInvalidCutoff = errors.New("revocation cutoff in the future")
+
+	// ErrPasskeyCeremonyNotFound is returned when finishing a passkey ceremony that is unknown, expired, already
+	// finished or started for another user
+	ErrPasskeyCeremonyNotFound = errors.New("passkey ceremony not found")
+
+	// ErrPasskeyNotFound is returned when signing in with or deleting an unknown passkey
+	ErrPasskeyNotFound = errors.New("passkey not found")
+
+	// ErrTooManyPasskeys is returned when registering a passkey for a user with maxPasskeys passkeys
+	ErrTooManyPasskeys = errors.New("too many passkeys")
+
+	// ErrInvalidPasskeyName is returned when naming a passkey with an overly long name
+	ErrInvalidPasskeyName = errors.New("invalid passkey name")
+
+	// ErrSSORequired is returned, wrapped in an SSORequiredError, when a member of an organization requiring
+	// single sign-on signs in by other means than its provider
+	ErrSSORequired = errors.New("single sign-on required")
+
+	// ErrPasswordChangeRequired is returned, wrapped in a PasswordChangeRequiredError, when a user an
+	// administrator required to change their password signs in
+	ErrPasswordChangeRequired = errors.New("password change required")
+
+	// ErrInvalidPasswordChangeToken is returned when a password change token is unknown or expired
+	ErrInvalidPasswordChangeToken = errors.New("invalid password change token")
+
+	// ErrPasswordUnchanged is returned when changing a required password to the same password
+	ErrPasswordUnchanged = errors.New("password unchanged")
+)
+
+// AccountBlockedError reports a sign in to a blocked account along with the reason of the latest block
+type AccountBlockedError struct {
+	Reason entity.ActionReason
+}
+
+// Error implements the error interface
+func (e *AccountBlockedError) Error() string {
+	return ErrAccountBlocked.Error()
+}
+
+// Unwrap makes the error match ErrAccountBlocked
+func (e *AccountBlockedError) Unwrap() error {
+	return ErrAccountBlocked
+}
+
+// SSORequiredError reports a sign in that the organization of the user requires to go through a single sign-on
+// provider, along with where to start it
+type SSORequiredError struct {
+	Provider string
+	LoginURL string
+}
+
+// Error implements the error interface
+func (e *SSORequiredError) Error() string {
+	return ErrSSORequired.Error()
+}
+
+// Unwrap makes the error match ErrSSORequired
+func (e *SSORequiredError) Unwrap() error {
+	return ErrSSORequired
+}
+
+// PasswordChangeRequiredError reports a sign in that must change the password before tokens are issued, along
+// with the token to change it with
+type PasswordChangeRequiredError struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// Error implements the error interface
+func (e *PasswordChangeRequiredError) Error() string {
+	return ErrPasswordChangeRequired.Error()
+}
+
+// Unwrap makes the error match ErrPasswordChangeRequired
+func (e *PasswordChangeRequiredError) Unwrap() error {
+	return ErrPasswordChangeRequired
+}
+
+const (
+	// emailVerificationExpiration is the lifetime of email and recovery email verification tokens
+	emailVerificationExpiration = 24 * time.Hour
+
+	// maxRecentLogins caps the sign-ins listed in the security overview of a user
+	maxRecentLogins = 10
+
+	// maxPasskeys caps the passkeys of a user
+	maxPasskeys = 10
+
+	// maxPasskeyNameLength caps the length of passkey names, in characters
+	maxPasskeyNameLength = 64
+
+	// revocationCutoffRefreshInterval is how long the revocation cutoff is cached in process,
+	// cutoffs set by other instances apply within this interval
+	revocationCutoffRefreshInterval = 5 * time.Second
+
+	// activityResolution is how often the activity of a user is recorded, tokens refreshed more often are not
+	activityResolution = time.Hour
+
+	// slidingExpirationResolution is how far the expiration of an access token must move before it is extended, so
+	// a burst of requests extends it once
+	slidingExpirationResolution = time.Minute
+
+	// passwordChangeExpiration is the lifetime of the tokens changing a password required to change at sign in
+	passwordChangeExpiration = 10 * time.Minute
 )
 
 // AuthUseCase defines the use case for authentication operations
 type AuthUseCase interface {
-	// Login authenticates a user and returns tokens
-	Login(ctx context.Context, email, password string) (*entity.LoginResponse, error)
+	// Login authenticates a user by email or username and returns tokens. Admins signing in from a new country
+	// may be required to step up by signing in with a passkey. Users required to change their password get a
+	// PasswordChangeRequiredError instead of tokens.
+	Login(ctx context.Context, identifier, password string, rememberMe bool, client entity.ClientInfo) (*entity.LoginResponse, error)
 
-	// Logout invalidates a user's tokens
-	Logout(ctx context.Context, tokenID uuid.UUID) error
+	// ChangeRequiredPassword changes the password of a user required to change it at sign in, with the token of
+	// the PasswordChangeRequiredError, and returns the tokens of the sign in
+	ChangeRequiredPassword(ctx context.Context, token, newPassword string) (*entity.LoginResponse, error)
+
+	// StartSession returns tokens for a user authenticated with an external provider, after the same status
+	// checks as a password sign in. Users required to change their password get a PasswordChangeRequiredError.
+	StartSession(ctx context.Context, user *entity.User, provider string) (*entity.LoginResponse, error)
+
+	// Logout invalidates the access and refresh tokens of a session, or the access token alone when it was
+	// issued before sessions were tracked
+	Logout(ctx context.Context, sessionID, tokenID uuid.UUID) error
 
 	// RefreshToken refreshes the access token using a refresh token
 	RefreshToken(ctx context.Context, refreshToken string) (*entity.AuthTokens, error)
@@ -35,48 +199,405 @@ type AuthUseCase interface {
 	// LogoutAll invalidates all of a user's tokens
 	LogoutAll(ctx context.Context, userID uuid.UUID) error
 
-	// ValidateToken validates a token and returns the user ID
-	ValidateToken(ctx context.Context, token string) (uuid.UUID, error)
+	// ValidateToken validates an access token and returns its claims
+	ValidateToken(ctx context.Context, token string) (*service.TokenClaims, error)
+
+	// ValidateOIDCToken validates an access token issued to an OIDC relying party and returns its claims
+	ValidateOIDCToken(ctx context.Context, token string) (*service.TokenClaims, error)
+
+	// RequestEmailVerification emails a verification token to a user
+	RequestEmailVerification(ctx context.Context, userID uuid.UUID) error
+
+	// ConfirmEmailVerification marks the email of the user a verification token was issued to as verified
+	ConfirmEmailVerification(ctx context.Context, token string) error
+
+	// SetRecoveryEmail sets the recovery email of a user and emails it a verification token.
+	// The address is not used until it is verified.
+	SetRecoveryEmail(ctx context.Context, userID uuid.UUID, email string) error
+
+	// ConfirmRecoveryEmail marks the recovery email a verification token was issued to as verified
+	ConfirmRecoveryEmail(ctx context.Context, token string) error
+
+	// RemoveRecoveryEmail removes the recovery email of a user
+	RemoveRecoveryEmail(ctx context.Context, userID uuid.UUID) error
+
+	// RequestPasswordReset emails a password reset token to the account with the given email or verified
+	// recovery email. Unknown addresses are ignored, so the outcome does not reveal which accounts exist.
+	RequestPasswordReset(ctx context.Context, email string) error
+
+	// ResetPassword sets a new password with a password reset token and signs the user out everywhere
+	ResetPassword(ctx context.Context, token, newPassword string) error
+
+	// GetSession returns a session and the rotation history of its tokens
+	GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error)
+
+	// RevokeSession invalidates the access and refresh tokens of a session on behalf of an administrator
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+
+	// GetSecurityOverview returns the security settings and active sessions of a user, sessionID is the session
+	// of the caller, flagged as current
+	GetSecurityOverview(ctx context.Context, userID, sessionID uuid.UUID) (*entity.SecurityOverview, error)
+
+	// ReportActivity revokes a session of the user that they did not start and records the report,
+	// optionally requiring a password reset before the account can be signed in to again
+	ReportActivity(ctx context.Context, userID, sessionID uuid.UUID, forcePasswordReset bool) error
+
+	// DenyToken immediately rejects an access or refresh token by ID on behalf of an administrator
+	DenyToken(ctx context.Context, actorID, tokenID uuid.UUID) error
+
+	// RevokeTokensIssuedBefore rejects every token of every user issued before the cutoff on behalf of an
+	// administrator, and returns the cutoff in force: an earlier cutoff than the current one changes nothing
+	RevokeTokensIssuedBefore(ctx context.Context, actorID uuid.UUID, cutoff time.Time) (time.Time, error)
+
+	// BeginPasskeyRegistration starts the registration of a passkey by a user and returns the options to pass
+	// to the authenticator
+	BeginPasskeyRegistration(ctx context.Context, userID uuid.UUID) (*entity.PasskeyCeremonyResponse, error)
+
+	// FinishPasskeyRegistration verifies the response of the authenticator to a registration and stores the
+	// new passkey under the given name
+	FinishPasskeyRegistration(ctx context.Context, userID, ceremonyID uuid.UUID, name string, attestation *entity.PasskeyAttestation) (*entity.Passkey, error)
+
+	// BeginPasskeyLogin starts a sign in with a passkey and returns the options to pass to the authenticator
+	BeginPasskeyLogin(ctx context.Context) (*entity.PasskeyCeremonyResponse, error)
+
+	// FinishPasskeyLogin verifies the response of a passkey to a sign in and returns tokens for its owner
+	FinishPasskeyLogin(ctx context.Context, ceremonyID uuid.UUID, assertion *entity.PasskeyAssertion, client entity.ClientInfo) (*entity.LoginResponse, error)
+
+	// ListPasskeys returns the passkeys of a user, oldest first
+	ListPasskeys(ctx context.Context, userID uuid.UUID) ([]*entity.Passkey, error)
+
+	// DeletePasskey deletes a passkey of a user, ErrLastCredential when it is the only way they can sign in
+	DeletePasskey(ctx context.Context, userID uuid.UUID, id string) error
 }
 
 type authUseCase struct {
-	userRepo     repository.UserRepository
-	tokenRepo    repository.TokenRepository
-	tokenService service.TokenService
+	userRepo             repository.UserRepository
+	tokenRepo            repository.TokenRepository
+	auditRepo            repository.AuditRepository
+	tokenService         service.TokenService
+	passwordService      service.PasswordService
+	passwordHasher       service.PasswordHasher
+	dummyPasswordHash    func() string
+	notificationUseCase  NotificationUseCase
+	enforcementUseCase   EnforcementUseCase
+	securityEventUseCase SecurityEventUseCase
+	statusHistoryRepo    repository.StatusHistoryRepository
+	passkeyRepo          repository.PasskeyRepository
+	passkeyCeremonyRepo  repository.PasskeyCeremonyRepository
+	passkeyService       service.PasskeyService
+	oauthIdentityRepo    repository.OAuthIdentityRepository
+	orgRepo              repository.OrganizationRepository
+	breakGlassUseCase    BreakGlassUseCase
+	anomalyUseCase       AnomalyUseCase
+	checkUserStatus      bool
+
+	// tokenLifetime is the lifetime of the longest-lived tokens, after which denylist entries are useless
+	tokenLifetime time.Duration
+
+	// slidingExpiration extends access tokens on use by accessDuration, until maxAccessLifetime after issuance
+	slidingExpiration bool
+	accessDuration    time.Duration
+	maxAccessLifetime time.Duration
+
+	// resetExpiration is the lifetime of password reset tokens
+	resetExpiration time.Duration
+
+	// passkeyTimeout is the lifetime of passkey ceremonies
+	passkeyTimeout time.Duration
+
+	// oauthURL is the base URL of the sign in routes of the external providers
+	oauthURL string
+
+	// maxSessions is the number of concurrent sessions of a user, unlimited at 0, and sessionLimitAction what a
+	// login beyond it does
+	maxSessions        int
+	sessionLimitAction string
+
+	// revocationMu guards the revocation cutoff cached in process
+	revocationMu       sync.Mutex
+	revocationCutoff   time.Time
+	revocationLoadedAt time.Time
+}
+
+// newDummyPasswordHash returns the hash checked when no account matches a login identifier, it is hashed once on
+// first use with the configured algorithm so unknown identifiers cost as much as known ones
+func newDummyPasswordHash(passwordHasher service.PasswordHasher) func() string {
+	return sync.OnceValue(func() string {
+		hash, err := passwordHasher.Hash("dummy password for unknown identifiers")
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to hash dummy password")
+		}
+		return hash
+	})
 }
 
 // NewAuthUseCase creates a new AuthUseCase
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
 	tokenRepo repository.TokenRepository,
+	auditRepo repository.AuditRepository,
 	tokenService service.TokenService,
+	passwordService service.PasswordService,
+	passwordHasher service.PasswordHasher,
+	notificationUseCase NotificationUseCase,
+	enforcementUseCase EnforcementUseCase,
+	securityEventUseCase SecurityEventUseCase,
+	statusHistoryRepo repository.StatusHistoryRepository,
+	passkeyRepo repository.PasskeyRepository,
+	passkeyCeremonyRepo repository.PasskeyCeremonyRepository,
+	passkeyService service.PasskeyService,
+	oauthIdentityRepo repository.OAuthIdentityRepository,
+	orgRepo repository.OrganizationRepository,
+	breakGlassUseCase BreakGlassUseCase,
+	anomalyUseCase AnomalyUseCase,
+	securityCfg config.SecurityConfig,
+	passwordResetCfg config.PasswordResetConfig,
+	passkeyCfg config.PasskeyConfig,
+	oauthCfg config.OAuthConfig,
 ) AuthUseCase {
 	return &authUseCase{
-		userRepo:     userRepo,
-		tokenRepo:    tokenRepo,
-		tokenService: tokenService,
+		userRepo:             userRepo,
+		tokenRepo:            tokenRepo,
+		auditRepo:            auditRepo,
+		tokenService:         tokenService,
+		passwordService:      passwordService,
+		passwordHasher:       passwordHasher,
+		dummyPasswordHash:    newDummyPasswordHash(passwordHasher),
+		notificationUseCase:  notificationUseCase,
+		enforcementUseCase:   enforcementUseCase,
+		securityEventUseCase: securityEventUseCase,
+		statusHistoryRepo:    statusHistoryRepo,
+		passkeyRepo:          passkeyRepo,
+		passkeyCeremonyRepo:  passkeyCeremonyRepo,
+		passkeyService:       passkeyService,
+		oauthIdentityRepo:    oauthIdentityRepo,
+		orgRepo:              orgRepo,
+		breakGlassUseCase:    breakGlassUseCase,
+		anomalyUseCase:       anomalyUseCase,
+		checkUserStatus:      securityCfg.CheckUserStatus,
+		tokenLifetime:        time.Duration(max(securityCfg.RefreshTokenExpirationDays, securityCfg.RememberMeRefreshTokenExpirationDays)) * 24 * time.Hour,
+		slidingExpiration:    securityCfg.AccessTokenSlidingExpiration,
+		accessDuration:       time.Duration(securityCfg.AccessTokenExpirationMinutes) * time.Minute,
+		maxAccessLifetime:    securityCfg.AccessTokenMaxLifetime,
+		resetExpiration:      passwordResetCfg.Expiration,
+		passkeyTimeout:       passkeyCfg.Timeout,
+		oauthURL:             oauthCfg.CallbackURL,
+		maxSessions:          securityCfg.MaxActiveSessions,
+		sessionLimitAction:   sessionLimitAction(securityCfg.SessionLimitAction),
+	}
+}
+
+// sessionLimitAction returns the action of a login beyond the session limit, evicting unless rejecting is set
+func sessionLimitAction(action string) string {
+	if action == entity.SessionLimitActionReject {
+		return entity.SessionLimitActionReject
 	}
+	return entity.SessionLimitActionEvictOldest
 }
 
-// Login authenticates a user and returns tokens
-func (uc *authUseCase) Login(ctx context.Context, email, password string) (*entity.LoginResponse, error) {
+// Login authenticates a user by email or username and returns tokens
+func (uc *authUseCase) Login(ctx context.Context, identifier, password string, rememberMe bool, client entity.ClientInfo) (*entity.LoginResponse, error) {
+	// Refuse any sign in from addresses denied after too many failed logins
+	if err := uc.anomalyUseCase.CheckClient(ctx, client); err != nil {
+		return nil, err
+	}
+
 	// Authenticate user
-	user, err := uc.userRepo.GetByEmail(ctx, email)
+	user, err := uc.lookupIdentifier(ctx, identifier)
 	if err != nil {
 		return nil, err
 	}
 
 	if user == nil {
+		// Hash the password anyway, so unknown identifiers take as long to reject as wrong passwords
+		uc.passwordHasher.Verify(password, uc.dummyPasswordHash())
+		uc.anomalyUseCase.RecordFailedLogin(ctx, client)
 		return nil, ErrInvalidCredentials
 	}
 
-	// Verify password - using the utils function
-	if !utils.CheckPasswordHash(password, user.Password) {
+	// Refuse to check passwords of accounts with too many recent failures
+	if err := uc.enforcementUseCase.CheckLockout(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	// Verify password
+	if !uc.passwordHasher.Verify(password, user.Password) {
+		uc.enforcementUseCase.RecordFailedLogin(ctx, user.ID)
+		uc.anomalyUseCase.RecordFailedLogin(ctx, client)
 		return nil, ErrInvalidCredentials
 	}
+	uc.enforcementUseCase.ClearFailedLogins(ctx, user.ID)
+	uc.rehashPassword(ctx, user, password)
+
+	// Only tell the status of the account to whoever knows its password
+	if err := uc.checkSignIn(ctx, user, ""); err != nil {
+		return nil, err
+	}
+	if err := uc.anomalyUseCase.CheckCountry(ctx, user, client); err != nil {
+		return nil, err
+	}
+
+	return uc.startSession(ctx, user, rememberMe)
+}
+
+// requirePasswordChange issues the token changing the password of a user required to change it at sign in
+func (uc *authUseCase) requirePasswordChange(ctx context.Context, user *entity.User) error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate password change token: %w", err)
+	}
+
+	if err := uc.tokenRepo.StoreOneTimeToken(ctx, entity.OneTimeTokenPasswordChange, token, user.ID, passwordChangeExpiration); err != nil {
+		return err
+	}
+
+	return &PasswordChangeRequiredError{
+		Token:     token,
+		ExpiresAt: time.Now().Add(passwordChangeExpiration),
+	}
+}
+
+// ChangeRequiredPassword changes the password of a user required to change it at sign in and starts a session
+func (uc *authUseCase) ChangeRequiredPassword(ctx context.Context, token, newPassword string) (*entity.LoginResponse, error) {
+	// Check the password first, so a rejected password does not consume the token
+	if err := uc.passwordService.Check(ctx, newPassword); err != nil {
+		return nil, err
+	}
+
+	userID, err := uc.tokenRepo.ConsumeOneTimeToken(ctx, entity.OneTimeTokenPasswordChange, token)
+	if err != nil {
+		return nil, err
+	}
+	if userID == uuid.Nil {
+		return nil, ErrInvalidPasswordChangeToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidPasswordChangeToken
+	}
+
+	// The account may have been blocked or put under SSO since the sign in
+	if err := uc.checkSignIn(ctx, user, ""); err != nil {
+		return nil, err
+	}
+	if uc.passwordHasher.Verify(newPassword, user.Password) {
+		return nil, ErrPasswordUnchanged
+	}
+
+	hashedPassword, err := uc.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.ChangePassword(ctx, user.ID, hashedPassword); err != nil {
+		return nil, err
+	}
 
-	// Generate tokens
-	tokens, accessDetails, refreshDetails, err := uc.tokenService.GenerateTokens(user.ID)
+	// Update caches the user as passed, so it must carry the new password
+	if user.MustChangePassword {
+		user.Password = hashedPassword
+		user.MustChangePassword = false
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionPasswordChanged, user, nil)
+	return uc.startSession(ctx, user, false)
+}
+
+// rehashPassword hashes a verified password again when its hash was computed with another algorithm or other
+// parameters than configured, so the stored hashes follow the configuration as users sign in. The sign in goes
+// on whatever happens, the next one retries.
+func (uc *authUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	if !uc.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := uc.passwordHasher.Hash(password)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to rehash password")
+		return
+	}
+	// Only replaces the hash just verified, a password changed meanwhile is kept
+	if err := uc.userRepo.RehashPassword(ctx, user.ID, user.Password, hashedPassword); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to store rehashed password")
+		return
+	}
+	log.Info().Str("user_id", user.ID.String()).Msg("Password rehashed with the configured parameters")
+}
+
+// StartSession returns tokens for a user authenticated with an external provider
+func (uc *authUseCase) StartSession(ctx context.Context, user *entity.User, provider string) (*entity.LoginResponse, error) {
+	if err := uc.checkSignIn(ctx, user, provider); err != nil {
+		return nil, err
+	}
+	return uc.startSession(ctx, user, false)
+}
+
+// checkSignIn checks an authenticated user may sign in, provider is the external provider the user authenticated
+// with, empty for passwords and passkeys
+func (uc *authUseCase) checkSignIn(ctx context.Context, user *entity.User, provider string) error {
+	switch user.Status {
+	case entity.UserStatusBlocked:
+		return &AccountBlockedError{Reason: uc.latestBlockReason(ctx, user.ID)}
+	case entity.UserStatusInactive:
+		return ErrAccountInactive
+	case entity.UserStatusWaitlisted:
+		return ErrAccountWaitlisted
+	}
+
+	if err := uc.checkSSO(ctx, user, provider); err != nil {
+		return err
+	}
+
+	// The account may be in the hands of whoever the user reported, only a reset lifts the block
+	if user.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
+
+	return nil
+}
+
+// checkSSO checks a member of an organization requiring single sign-on authenticated with its provider
+func (uc *authUseCase) checkSSO(ctx context.Context, user *entity.User, provider string) error {
+	if user.OrgID == nil {
+		return nil
+	}
+
+	org, err := uc.orgRepo.GetByID(ctx, *user.OrgID)
+	if err != nil {
+		return err
+	}
+	if org == nil || !org.SSO.Required || org.SSO.Provider == provider {
+		return nil
+	}
+
+	return &SSORequiredError{
+		Provider: org.SSO.Provider,
+		LoginURL: uc.oauthURL + "/" + org.SSO.Provider + "/login",
+	}
+}
+
+// startSession issues the tokens of a new session of an authenticated user, persistent when they asked to be
+// remembered. Users required to change their password get a PasswordChangeRequiredError instead, whatever they
+// signed in with.
+func (uc *authUseCase) startSession(ctx context.Context, user *entity.User, persistent bool) (*entity.LoginResponse, error) {
+	// Only a full sign in learns the password must change, the change token standing in for the tokens
+	if user.MustChangePassword {
+		return nil, uc.requirePasswordChange(ctx, user)
+	}
+
+	if err := limitSessions(ctx, uc.tokenRepo, user.ID, uc.maxSessions, uc.sessionLimitAction); err != nil {
+		return nil, err
+	}
+
+	// Generate tokens for a new session
+	tokens, accessDetails, refreshDetails, err := uc.tokenService.GenerateTokens(user, uuid.New(), persistent)
 	if err != nil {
 		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to generate tokens")
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
@@ -92,6 +613,8 @@ func (uc *authUseCase) Login(ctx context.Context, email, password string) (*enti
 		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to store refresh token")
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
+	uc.recordActivity(ctx, user)
+	uc.breakGlassUseCase.RecordUse(ctx, user)
 
 	return &entity.LoginResponse{
 		User:       user,
@@ -99,12 +622,78 @@ func (uc *authUseCase) Login(ctx context.Context, email, password string) (*enti
 	}, nil
 }
 
-// Logout invalidates a user's token
-func (uc *authUseCase) Logout(ctx context.Context, tokenID uuid.UUID) error {
-	// Delete access token
-	if err := uc.tokenRepo.DeleteToken(ctx, tokenID, entity.AccessToken); err != nil {
-		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to delete access token")
-		return fmt.Errorf("failed to delete access token: %w", err)
+// limitSessions makes room for a new session of a user at the limit of concurrent sessions, unlimited at 0,
+// rejecting the login or revoking the sessions refreshed least recently. Failing to list the sessions lets the
+// login through, like the other limits.
+func limitSessions(ctx context.Context, tokenRepo repository.TokenRepository, userID uuid.UUID, maxSessions int, action string) error {
+	if maxSessions <= 0 {
+		return nil
+	}
+
+	sessions, err := tokenRepo.ListUserSessions(ctx, userID)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to check session limit")
+		return nil
+	}
+	if len(sessions) < maxSessions {
+		return nil
+	}
+	if action == entity.SessionLimitActionReject {
+		return ErrSessionLimitReached
+	}
+
+	slices.SortFunc(sessions, func(a, b *entity.Session) int {
+		return a.LastActiveAt().Compare(b.LastActiveAt())
+	})
+	for _, session := range sessions[:len(sessions)-maxSessions+1] {
+		if err := tokenRepo.RevokeSession(ctx, session.ID, entity.SessionRevokedLimit); err != nil {
+			return fmt.Errorf("failed to evict session: %w", err)
+		}
+		log.Info().Str("user_id", userID.String()).Str("session_id", session.ID.String()).Msg("Session evicted by session limit")
+	}
+	return nil
+}
+
+// latestBlockReason returns the reason of the latest block of a user from their status history.
+// The sign in is rejected either way, so failures are logged and give an empty reason.
+func (uc *authUseCase) latestBlockReason(ctx context.Context, userID uuid.UUID) entity.ActionReason {
+	changes, err := uc.statusHistoryRepo.ListByUser(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get the reason of the block")
+		return entity.ActionReason{}
+	}
+	for _, change := range changes {
+		if change.Status == entity.UserStatusBlocked {
+			return change.ActionReason
+		}
+	}
+	return entity.ActionReason{}
+}
+
+// lookupIdentifier returns the user signing in with an email or a username, nil if no account matches.
+// Usernames cannot contain an @, so an identifier containing one is an email.
+func (uc *authUseCase) lookupIdentifier(ctx context.Context, identifier string) (*entity.User, error) {
+	identifier = strings.TrimSpace(identifier)
+	if strings.Contains(identifier, "@") {
+		return uc.userRepo.GetByEmail(ctx, entity.NormalizeEmail(identifier))
+	}
+	return uc.userRepo.GetByUsername(ctx, identifier)
+}
+
+// Logout invalidates the access and refresh tokens of a session
+func (uc *authUseCase) Logout(ctx context.Context, sessionID, tokenID uuid.UUID) error {
+	if sessionID == uuid.Nil {
+		if err := uc.tokenRepo.DeleteToken(ctx, tokenID, entity.AccessToken); err != nil {
+			log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to delete access token")
+			return fmt.Errorf("failed to delete access token: %w", err)
+		}
+		return nil
+	}
+
+	// Delete the session's tokens, so the refresh token cannot revive it
+	if err := uc.tokenRepo.RevokeSession(ctx, sessionID, entity.SessionRevokedLogout); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to delete session tokens")
+		return fmt.Errorf("failed to delete session tokens: %w", err)
 	}
 
 	return nil
@@ -123,6 +712,15 @@ func (uc *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*
 		return nil, ErrInvalidRefreshToken
 	}
 
+	// Reject the tokens revoked by an administrator
+	revoked, err := uc.isRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+
 	// Get token from Redis to verify it hasn't been revoked
 	tokenDetails, err := uc.tokenRepo.GetToken(ctx, claims.TokenID, entity.RefreshToken)
 	if err != nil {
@@ -131,23 +729,59 @@ func (uc *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*
 	}
 
 	if tokenDetails == nil {
+		return nil, uc.checkRefreshTokenReuse(ctx, claims)
+	}
+
+	// Claim the rotation, so a token presented twice at once is rotated once and the other presentation is a reuse
+	consumed, err := uc.tokenRepo.ConsumeRefreshToken(ctx, claims.TokenID, time.Until(tokenDetails.Expiration))
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, uc.checkRefreshTokenReuse(ctx, claims)
+	}
+
+	// Reload the user so role, organization and status changes are reflected in the new tokens
+	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.PasswordResetRequired || user.Status != entity.UserStatusActive {
 		return nil, ErrInvalidRefreshToken
 	}
 
-	// Generate new tokens
-	tokens, accessDetails, refreshDetails, err := uc.tokenService.GenerateTokens(claims.UserID)
+	// Generate new tokens, continuing the session of the refresh token
+	sessionID := tokenDetails.SessionID
+	if sessionID == uuid.Nil {
+		sessionID = uuid.New() // Refresh token issued before sessions were tracked
+	}
+	tokens, accessDetails, refreshDetails, err := uc.tokenService.GenerateTokens(user, sessionID, tokenDetails.Persistent)
 	if err != nil {
 		log.Error().Err(err).Str("user_id", claims.UserID.String()).Msg("Failed to generate new tokens")
 		return nil, fmt.Errorf("failed to generate new tokens: %w", err)
 	}
+	accessDetails.ParentID = &claims.TokenID
+	refreshDetails.ParentID = &claims.TokenID
+
+	// Revoke the tokens being replaced, including the access token still valid
+	if err := uc.tokenRepo.DeleteSession(ctx, sessionID); err != nil {
+		log.Warn().Err(err).Str("session_id", sessionID.String()).Msg("Failed to delete replaced session tokens")
+	}
 
 	// Store new tokens in Redis
+	// The session is revoked meanwhile when the token is reused at once, the new tokens are then deleted
 	if err := uc.tokenRepo.StoreAccessToken(ctx, accessDetails); err != nil {
+		if errors.Is(err, repository.ErrSessionRevoked) {
+			return nil, ErrInvalidRefreshToken
+		}
 		log.Error().Err(err).Str("user_id", claims.UserID.String()).Msg("Failed to store new access token")
 		return nil, fmt.Errorf("failed to store new access token: %w", err)
 	}
 
 	if err := uc.tokenRepo.StoreRefreshToken(ctx, refreshDetails); err != nil {
+		if errors.Is(err, repository.ErrSessionRevoked) {
+			return nil, ErrInvalidRefreshToken
+		}
 		log.Error().Err(err).Str("user_id", claims.UserID.String()).Msg("Failed to store new refresh token")
 		return nil, fmt.Errorf("failed to store new refresh token: %w", err)
 	}
@@ -156,10 +790,64 @@ func (uc *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*
 	if err := uc.tokenRepo.DeleteToken(ctx, claims.TokenID, entity.RefreshToken); err != nil {
 		log.Warn().Err(err).Str("token_id", claims.TokenID.String()).Msg("Failed to delete old refresh token")
 	}
+	uc.recordActivity(ctx, user)
 
 	return tokens, nil
 }
 
+// recordActivity records that a user is active, at most once per activityResolution. The tokens are issued
+// either way, so failures are logged.
+func (uc *authUseCase) recordActivity(ctx context.Context, user *entity.User) {
+	now := time.Now()
+	if user.LastActiveAt != nil && now.Sub(*user.LastActiveAt) < activityResolution {
+		return
+	}
+	if err := uc.userRepo.RecordActivity(ctx, user.ID, now); err != nil {
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to record user activity")
+		return
+	}
+	user.LastActiveAt = &now
+
+	// Users flagged by the inactivity policy are no longer dormant
+	if slices.Contains(user.Tags, entity.UserTagDormant) {
+		if err := uc.userRepo.RemoveTags(ctx, user.ID, []string{entity.UserTagDormant}); err != nil {
+			log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to remove dormant tag of active user")
+		}
+	}
+}
+
+// checkRefreshTokenReuse tells a revoked refresh token from one that was already rotated. A rotated
+// token presented again means it leaked, so the whole session is revoked and the thief and the
+// legitimate client both have to log in again.
+func (uc *authUseCase) checkRefreshTokenReuse(ctx context.Context, claims *service.TokenClaims) error {
+	if claims.SessionID == uuid.Nil {
+		return ErrInvalidRefreshToken
+	}
+
+	session, err := uc.tokenRepo.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.RevokedAt != nil || !session.HasToken(claims.TokenID, entity.RefreshToken) {
+		return ErrInvalidRefreshToken
+	}
+
+	log.Warn().
+		Str("session_id", claims.SessionID.String()).
+		Str("token_id", claims.TokenID.String()).
+		Str("user_id", claims.UserID.String()).
+		Msg("Rotated refresh token reused, revoking session")
+
+	if err := uc.tokenRepo.RevokeSession(ctx, claims.SessionID, entity.SessionRevokedTokenReuse); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	uc.securityEventUseCase.Record(ctx, entity.NewSecurityEvent(entity.SecurityEventRefreshTokenReused, entity.SecuritySeverityHigh, uuid.Nil, claims.UserID, map[string]string{
+		"session_id": claims.SessionID.String(),
+		"token_id":   claims.TokenID.String(),
+	}))
+	return ErrRefreshTokenReused
+}
+
 // LogoutAll invalidates all of a user's tokens
 func (uc *authUseCase) LogoutAll(ctx context.Context, userID uuid.UUID) error {
 	// Delete all user tokens from Redis
@@ -171,29 +859,824 @@ func (uc *authUseCase) LogoutAll(ctx context.Context, userID uuid.UUID) error {
 	return nil
 }
 
-// ValidateToken validates a token and returns the user ID
-func (uc *authUseCase) ValidateToken(ctx context.Context, token string) (uuid.UUID, error) {
+// ValidateToken validates an access token and returns its claims. The tokens issued to OIDC relying parties are
+// rejected, they do not act for the user.
+func (uc *authUseCase) ValidateToken(ctx context.Context, token string) (*service.TokenClaims, error) {
+	return uc.validateAccessToken(ctx, token, entity.AccessToken)
+}
+
+// ValidateOIDCToken validates an access token issued to an OIDC relying party and returns its claims
+func (uc *authUseCase) ValidateOIDCToken(ctx context.Context, token string) (*service.TokenClaims, error) {
+	return uc.validateAccessToken(ctx, token, entity.OIDCAccessToken)
+}
+
+// validateAccessToken validates an access token of the given type, checking it was not revoked
+func (uc *authUseCase) validateAccessToken(ctx context.Context, token string, tokenType entity.TokenType) (*service.TokenClaims, error) {
 	// Validate token
 	claims, err := uc.tokenService.ValidateToken(token)
 	if err != nil {
-		return uuid.Nil, service.ErrInvalidToken
+		return nil, service.ErrInvalidToken
 	}
 
-	// Verify it's an access token
-	if claims.TokenType != entity.AccessToken {
-		return uuid.Nil, service.ErrInvalidToken
+	// Verify it's an access token of the expected type
+	if claims.TokenType != tokenType {
+		return nil, service.ErrInvalidToken
 	}
 
-	// Get token from Redis to verify it hasn't been revoked
-	tokenDetails, err := uc.tokenRepo.GetToken(ctx, claims.TokenID, entity.AccessToken)
+	// Reject the tokens issued before the revocation cutoff
+	cutoff, err := uc.cachedRevocationCutoff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !cutoff.IsZero() && claims.IssuedAt.Before(cutoff) {
+		return nil, service.ErrInvalidToken
+	}
+
+	// Get the token, its denylisting and the status of its user at once, to verify it hasn't been revoked
+	state, err := uc.tokenRepo.GetAccessTokenState(ctx, claims.TokenID, claims.UserID)
 	if err != nil {
 		log.Error().Err(err).Str("token_id", claims.TokenID.String()).Msg("Failed to get access token")
-		return uuid.Nil, fmt.Errorf("failed to get access token: %w", err)
+		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
 
-	if tokenDetails == nil {
-		return uuid.Nil, service.ErrInvalidToken
+	if state.Details == nil || state.Denied {
+		return nil, service.ErrInvalidToken
+	}
+
+	// Reject the tokens issued before the user was blocked, deactivated or deleted
+	if uc.checkUserStatus && state.UserStatus != "" && state.UserStatus != entity.UserStatusActive {
+		return nil, service.ErrInvalidToken
+	}
+
+	if uc.slidingExpiration {
+		uc.extendAccessToken(ctx, state.Details)
+	}
+
+	return claims, nil
+}
+
+// extendAccessToken slides the expiration of a user's access token to accessDuration from now, capped at
+// maxAccessLifetime after it was issued. The request is authenticated either way, so failures are logged.
+func (uc *authUseCase) extendAccessToken(ctx context.Context, details *entity.TokenDetails) {
+	// Services and OIDC relying parties request new tokens rather than keeping one alive
+	if details.ClientID != "" {
+		return
+	}
+
+	expiration := time.Now().Add(uc.accessDuration)
+	if maxExpiration := details.IssuedAt.Add(uc.maxAccessLifetime); expiration.After(maxExpiration) {
+		expiration = maxExpiration
+	}
+	if expiration.Sub(details.Expiration) < slidingExpirationResolution {
+		return
+	}
+
+	extended := *details
+	extended.Expiration = expiration
+	if _, err := uc.tokenRepo.ExtendAccessToken(ctx, &extended); err != nil {
+		log.Warn().Err(err).Str("token_id", details.TokenID.String()).Msg("Failed to extend access token")
+	}
+}
+
+// RequestEmailVerification emails a verification token to a user
+func (uc *authUseCase) RequestEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return ErrAlreadyVerified
+	}
+
+	return sendEmailVerification(ctx, uc.tokenRepo, uc.notificationUseCase, user)
+}
+
+// sendEmailVerification issues an email verification token and emails it to the user
+func sendEmailVerification(ctx context.Context, tokenRepo repository.TokenRepository, notificationUseCase NotificationUseCase, user *entity.User) error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	if err := tokenRepo.StoreOneTimeToken(ctx, entity.OneTimeTokenEmailVerification, token, user.ID, emailVerificationExpiration); err != nil {
+		return err
+	}
+
+	if err := notificationUseCase.SendEmailVerification(ctx, user, token); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send email verification")
+		return fmt.Errorf("failed to send email verification: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailVerification marks the email of the user a verification token was issued to as verified
+func (uc *authUseCase) ConfirmEmailVerification(ctx context.Context, token string) error {
+	userID, err := uc.tokenRepo.ConsumeOneTimeToken(ctx, entity.OneTimeTokenEmailVerification, token)
+	if err != nil {
+		return err
+	}
+	if userID == uuid.Nil {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidVerificationToken
+	}
+
+	user.EmailVerified = true
+	user.EmailReverificationRequired = false
+	user.UpdatedAt = time.Now()
+
+	return uc.userRepo.Update(ctx, user)
+}
+
+// SetRecoveryEmail sets the recovery email of a user and emails it a verification token
+func (uc *authUseCase) SetRecoveryEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	email = entity.NormalizeEmail(email)
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return ErrInvalidRecoveryEmail
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if email == entity.NormalizeEmail(user.Email) {
+		return ErrInvalidRecoveryEmail
+	}
+
+	// A new address must be verified again before it can be used
+	user.RecoveryEmail = email
+	user.RecoveryEmailVerified = false
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionRecoveryEmailSet, user, map[string]string{
+		"recovery_email": email,
+	})
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	if err := uc.tokenRepo.StoreOneTimeToken(ctx, entity.OneTimeTokenRecoveryEmailVerification, token, user.ID, emailVerificationExpiration); err != nil {
+		return err
+	}
+
+	if err := uc.notificationUseCase.SendRecoveryEmailVerification(ctx, user, token); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send recovery email verification")
+		return fmt.Errorf("failed to send recovery email verification: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmRecoveryEmail marks the recovery email a verification token was issued to as verified
+func (uc *authUseCase) ConfirmRecoveryEmail(ctx context.Context, token string) error {
+	userID, err := uc.tokenRepo.ConsumeOneTimeToken(ctx, entity.OneTimeTokenRecoveryEmailVerification, token)
+	if err != nil {
+		return err
+	}
+	if userID == uuid.Nil {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.RecoveryEmail == "" {
+		return ErrInvalidVerificationToken
+	}
+
+	user.RecoveryEmailVerified = true
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionRecoveryEmailVerified, user, map[string]string{
+		"recovery_email": user.RecoveryEmail,
+	})
+	return nil
+}
+
+// RemoveRecoveryEmail removes the recovery email of a user
+func (uc *authUseCase) RemoveRecoveryEmail(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.RecoveryEmail == "" {
+		return ErrRecoveryEmailNotSet
+	}
+
+	removed := user.RecoveryEmail
+	user.RecoveryEmail = ""
+	user.RecoveryEmailVerified = false
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionRecoveryEmailRemoved, user, map[string]string{
+		"recovery_email": removed,
+	})
+	return nil
+}
+
+// RequestPasswordReset emails a password reset token to the account with the given email or verified recovery email
+func (uc *authUseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	email = entity.NormalizeEmail(email)
+	channel := entity.NotificationChannelEmail
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		channel = entity.NotificationChannelRecoveryEmail
+		if user, err = uc.userRepo.GetByRecoveryEmail(ctx, email); err != nil {
+			return err
+		}
+	}
+
+	// Inactive and blocked accounts cannot be recovered by their owner
+	if user == nil || user.Status != entity.UserStatusActive {
+		log.Info().Str("email", email).Msg("Ignoring password reset request for unknown or inactive account")
+		return nil
+	}
+
+	// Limit the reset emails of an account, answering like any other request
+	if !uc.enforcementUseCase.AllowPasswordReset(ctx, user.ID) {
+		log.Info().Str("user_id", user.ID.String()).Msg("Ignoring password reset request over the limit of the account")
+		return nil
+	}
+
+	// Send in the background, so the response takes as long and succeeds whether or not an account matched
+	runInBackground(ctx, "password_reset", func(ctx context.Context) error {
+		if err := uc.sendPasswordReset(ctx, user, channel); err != nil {
+			return err
+		}
+
+		uc.recordAccountAction(ctx, entity.AuditActionPasswordResetRequested, user, map[string]string{
+			"channel": channel,
+		})
+		return nil
+	})
+	return nil
+}
+
+// sendPasswordReset issues a password reset token and sends it to the user on the given channel
+func (uc *authUseCase) sendPasswordReset(ctx context.Context, user *entity.User, channel string) error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	if err := uc.tokenRepo.StoreOneTimeToken(ctx, entity.OneTimeTokenPasswordReset, token, user.ID, uc.resetExpiration); err != nil {
+		return err
+	}
+
+	if err := uc.notificationUseCase.SendPasswordReset(ctx, user, channel, token); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Str("channel", channel).Msg("Failed to send password reset")
+		return fmt.Errorf("failed to send password reset: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with a password reset token and signs the user out everywhere
+func (uc *authUseCase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	// Check the password first, so a rejected password does not consume the token
+	if err := uc.passwordService.Check(ctx, newPassword); err != nil {
+		return err
+	}
+
+	userID, err := uc.tokenRepo.ConsumeOneTimeToken(ctx, entity.OneTimeTokenPasswordReset, token)
+	if err != nil {
+		return err
+	}
+	if userID == uuid.Nil {
+		return ErrInvalidResetToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := uc.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := uc.userRepo.ChangePassword(ctx, user.ID, hashedPassword); err != nil {
+		return err
+	}
+
+	// The new password lifts the block put in place by a suspicious activity report. Update caches the user as
+	// passed, so it must carry the new password.
+	if user.PasswordResetRequired {
+		user.Password = hashedPassword
+		user.PasswordResetRequired = false
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	// Whoever held the old password must not keep a session
+	if err := uc.LogoutAll(ctx, user.ID); err != nil {
+		return err
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionPasswordReset, user, nil)
+	return nil
+}
+
+// recordAccountAction records an action performed by a user on their own account in the audit trail and notifies them.
+// The action has already been applied, so failures are logged rather than returned.
+func (uc *authUseCase) recordAccountAction(ctx context.Context, action string, user *entity.User, details map[string]string) {
+	entry := entity.NewAuditEntry(action, user.ID, user.ID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("user_id", user.ID.String()).Msg("Failed to record account action in audit trail")
+	}
+
+	uc.notificationUseCase.NotifyAdminAction(ctx, action, user.ID, user, details)
+}
+
+// GetSession returns a session and the rotation history of its tokens
+func (uc *authUseCase) GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	session, err := uc.tokenRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+// RevokeSession invalidates the access and refresh tokens of a session on behalf of an administrator
+func (uc *authUseCase) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	if _, err := uc.GetSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	if err := uc.tokenRepo.RevokeSession(ctx, sessionID, entity.SessionRevokedAdministered); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to revoke session")
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSecurityOverview returns the security settings and active sessions of a user.
+// Sign-ins are only known while their session is active, ended sessions are left out.
+func (uc *authUseCase) GetSecurityOverview(ctx context.Context, userID, sessionID uuid.UUID) (*entity.SecurityOverview, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	sessions, err := uc.tokenRepo.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	logins := make([]entity.LoginActivity, 0, len(sessions))
+	for _, session := range sessions {
+		logins = append(logins, entity.LoginActivity{
+			SessionID:    session.ID,
+			SignedInAt:   session.CreatedAt,
+			LastActiveAt: session.LastActiveAt(),
+			Current:      session.ID == sessionID,
+		})
+	}
+	slices.SortFunc(logins, func(a, b entity.LoginActivity) int {
+		return b.SignedInAt.Compare(a.SignedInAt)
+	})
+
+	identities, err := uc.oauthIdentityRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]entity.LinkedAccount, 0, len(identities))
+	for _, identity := range identities {
+		accounts = append(accounts, entity.LinkedAccount{
+			Provider: identity.Provider,
+			Email:    identity.Email,
+			LinkedAt: identity.CreatedAt,
+		})
+	}
+
+	return &entity.SecurityOverview{
+		EmailVerified:               user.EmailVerified,
+		EmailReverificationRequired: user.EmailReverificationRequired,
+		PhoneVerified:               user.PhoneVerified,
+		RecoveryEmail:               user.RecoveryEmail,
+		RecoveryEmailVerified:       user.RecoveryEmailVerified,
+		PasswordResetRequired:       user.PasswordResetRequired,
+		MustChangePassword:          user.MustChangePassword,
+		LinkedAccounts:              accounts,
+		ActiveSessions:              len(sessions),
+		RecentLogins:                logins[:min(len(logins), maxRecentLogins)],
+	}, nil
+}
+
+// ReportActivity revokes a session of the user that they did not start and records the report,
+// optionally requiring a password reset before the account can be signed in to again
+func (uc *authUseCase) ReportActivity(ctx context.Context, userID, sessionID uuid.UUID, forcePasswordReset bool) error {
+	// Users can only report their own sessions, other sessions are reported as unknown
+	session, err := uc.tokenRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := uc.tokenRepo.RevokeSession(ctx, sessionID, entity.SessionRevokedReported); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to revoke reported session")
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	log.Warn().
+		Str("session_id", sessionID.String()).
+		Str("user_id", userID.String()).
+		Bool("force_password_reset", forcePasswordReset).
+		Msg("Suspicious activity reported, session revoked")
+
+	if forcePasswordReset {
+		// Block sign-in and token refreshes until the password is reset, then email the reset link
+		user.PasswordResetRequired = true
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		if err := uc.LogoutAll(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := uc.sendPasswordReset(ctx, user, entity.NotificationChannelEmail); err != nil {
+			return err
+		}
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionSuspiciousActivity, user, map[string]string{
+		"session_id":           sessionID.String(),
+		"session_created_at":   session.CreatedAt.Format(time.RFC3339),
+		"force_password_reset": strconv.FormatBool(forcePasswordReset),
+	})
+	return nil
+}
+
+// DenyToken immediately rejects an access or refresh token by ID on behalf of an administrator
+func (uc *authUseCase) DenyToken(ctx context.Context, actorID, tokenID uuid.UUID) error {
+	// Find the owner of the token for the audit trail, it may already be expired or deleted
+	targetID := uuid.Nil
+	for _, tokenType := range []entity.TokenType{entity.AccessToken, entity.RefreshToken} {
+		details, err := uc.tokenRepo.GetToken(ctx, tokenID, tokenType)
+		if err != nil {
+			return err
+		}
+		if details != nil {
+			targetID = details.UserID
+		}
+	}
+
+	if err := uc.tokenRepo.DenyToken(ctx, tokenID, uc.tokenLifetime); err != nil {
+		return err
+	}
+
+	// Deleting the stored token also stops it from being refreshed or listed in the session
+	for _, tokenType := range []entity.TokenType{entity.AccessToken, entity.RefreshToken} {
+		if err := uc.tokenRepo.DeleteToken(ctx, tokenID, tokenType); err != nil {
+			log.Warn().Err(err).Str("token_id", tokenID.String()).Msg("Failed to delete denylisted token")
+		}
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionTokenDenied, actorID, targetID, map[string]string{
+		"token_id": tokenID.String(),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("token_id", tokenID.String()).Msg("Failed to record token denial in audit trail")
+	}
+
+	log.Warn().Str("token_id", tokenID.String()).Str("actor_id", actorID.String()).Msg("Token denylisted")
+	return nil
+}
+
+// RevokeTokensIssuedBefore rejects every token of every user issued before the cutoff
+func (uc *authUseCase) RevokeTokensIssuedBefore(ctx context.Context, actorID uuid.UUID, cutoff time.Time) (time.Time, error) {
+	if cutoff.After(time.Now()) {
+		return time.Time{}, ErrInvalidCutoff
+	}
+
+	current, err := uc.tokenRepo.GetRevocationCutoff(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !cutoff.After(current) {
+		return current, nil
+	}
+
+	// Tokens issued before the cutoff have all expired once the longest token lifetime has elapsed
+	if err := uc.tokenRepo.StoreRevocationCutoff(ctx, cutoff, uc.tokenLifetime); err != nil {
+		return time.Time{}, err
+	}
+
+	uc.revocationMu.Lock()
+	uc.revocationCutoff, uc.revocationLoadedAt = cutoff, time.Now()
+	uc.revocationMu.Unlock()
+
+	entry := entity.NewAuditEntry(entity.AuditActionTokensRevoked, actorID, uuid.Nil, map[string]string{
+		"issued_before": cutoff.UTC().Format(time.RFC3339Nano),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Failed to record token revocation in audit trail")
+	}
+
+	log.Warn().Time("issued_before", cutoff).Str("actor_id", actorID.String()).Msg("Tokens revoked for all users")
+	return cutoff, nil
+}
+
+// isRevoked reports whether a token was issued before the revocation cutoff or denylisted
+func (uc *authUseCase) isRevoked(ctx context.Context, claims *service.TokenClaims) (bool, error) {
+	cutoff, err := uc.cachedRevocationCutoff(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !cutoff.IsZero() && claims.IssuedAt.Before(cutoff) {
+		return true, nil
+	}
+
+	return uc.tokenRepo.IsTokenDenied(ctx, claims.TokenID)
+}
+
+// cachedRevocationCutoff returns the revocation cutoff, loading it at most once per refresh interval
+func (uc *authUseCase) cachedRevocationCutoff(ctx context.Context) (time.Time, error) {
+	uc.revocationMu.Lock()
+	defer uc.revocationMu.Unlock()
+
+	if time.Since(uc.revocationLoadedAt) < revocationCutoffRefreshInterval {
+		return uc.revocationCutoff, nil
+	}
+
+	cutoff, err := uc.tokenRepo.GetRevocationCutoff(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	uc.revocationCutoff, uc.revocationLoadedAt = cutoff, time.Now()
+	return cutoff, nil
+}
+
+// BeginPasskeyRegistration starts the registration of a passkey by a user, excluding the authenticators already
+// holding one of their passkeys
+func (uc *authUseCase) BeginPasskeyRegistration(ctx context.Context, userID uuid.UUID) (*entity.PasskeyCeremonyResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	passkeys, err := uc.passkeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(passkeys) >= maxPasskeys {
+		return nil, ErrTooManyPasskeys
+	}
+
+	ceremony, err := uc.startPasskeyCeremony(ctx, entity.PasskeyCeremonyRegistration, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PasskeyCeremonyResponse{
+		CeremonyID: ceremony.ID,
+		Options:    uc.passkeyService.CreationOptions(ceremony.Challenge, user, passkeys),
+	}, nil
+}
+
+// FinishPasskeyRegistration verifies the response of the authenticator to a registration and stores the new passkey
+func (uc *authUseCase) FinishPasskeyRegistration(ctx context.Context, userID, ceremonyID uuid.UUID, name string, attestation *entity.PasskeyAttestation) (*entity.Passkey, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxPasskeyNameLength {
+		return nil, ErrInvalidPasskeyName
+	}
+
+	ceremony, err := uc.passkeyCeremonyRepo.Consume(ctx, ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony == nil || ceremony.Type != entity.PasskeyCeremonyRegistration || ceremony.UserID == nil || *ceremony.UserID != userID {
+		return nil, ErrPasskeyCeremonyNotFound
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	passkey, err := uc.passkeyService.VerifyRegistration(ceremony.Challenge, attestation)
+	if err != nil {
+		return nil, err
+	}
+
+	// The credential IDs are chosen by authenticators, one already registered is refused rather than reassigned
+	existing, err := uc.passkeyRepo.GetByID(ctx, passkey.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: passkey already registered", service.ErrInvalidPasskey)
+	}
+
+	passkey.UserID = userID
+	passkey.Name = name
+	if passkey.Name == "" {
+		passkey.Name = "Passkey"
+	}
+	passkey.CreatedAt = time.Now()
+	if err := uc.passkeyRepo.Create(ctx, passkey); err != nil {
+		return nil, err
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionPasskeyAdded, user, map[string]string{
+		"passkey_id":   passkey.ID,
+		"passkey_name": passkey.Name,
+	})
+	return passkey, nil
+}
+
+// BeginPasskeyLogin starts a sign in with a discoverable passkey, the authenticator tells whose passkey it is
+func (uc *authUseCase) BeginPasskeyLogin(ctx context.Context) (*entity.PasskeyCeremonyResponse, error) {
+	ceremony, err := uc.startPasskeyCeremony(ctx, entity.PasskeyCeremonyLogin, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PasskeyCeremonyResponse{
+		CeremonyID: ceremony.ID,
+		Options:    uc.passkeyService.RequestOptions(ceremony.Challenge),
+	}, nil
+}
+
+// FinishPasskeyLogin verifies the response of a passkey to a sign in and returns tokens for its owner.
+// The passkey replaces the password, so the owner goes through the same status checks as a password sign in.
+func (uc *authUseCase) FinishPasskeyLogin(ctx context.Context, ceremonyID uuid.UUID, assertion *entity.PasskeyAssertion, client entity.ClientInfo) (*entity.LoginResponse, error) {
+	if err := uc.anomalyUseCase.CheckClient(ctx, client); err != nil {
+		return nil, err
+	}
+
+	ceremony, err := uc.passkeyCeremonyRepo.Consume(ctx, ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+	if ceremony == nil || ceremony.Type != entity.PasskeyCeremonyLogin {
+		return nil, ErrPasskeyCeremonyNotFound
+	}
+
+	passkey, err := uc.passkeyRepo.GetByID(ctx, assertion.RawID.String())
+	if err != nil {
+		return nil, err
+	}
+	if passkey == nil {
+		return nil, ErrPasskeyNotFound
+	}
+
+	// The user handle, when returned, is the ID of the user the passkey was registered for
+	if len(assertion.UserHandle) > 0 && !bytes.Equal(assertion.UserHandle, passkey.UserID[:]) {
+		return nil, fmt.Errorf("%w: user handle mismatch", service.ErrInvalidPasskey)
+	}
+
+	signCount, err := uc.passkeyService.VerifyAssertion(ceremony.Challenge, passkey, assertion)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", passkey.UserID.String()).Msg("Passkey sign in rejected")
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, passkey.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrPasskeyNotFound
+	}
+	if err := uc.checkSignIn(ctx, user, ""); err != nil {
+		return nil, err
+	}
+
+	// The sign in succeeds even when the usage cannot be stored, the counter is checked again next time
+	now := time.Now()
+	passkey.SignCount = signCount
+	passkey.LastUsedAt = &now
+	if err := uc.passkeyRepo.UpdateUsage(ctx, passkey); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to update passkey usage")
+	}
+
+	// A passkey is the step-up of the admins signing in from a new country
+	uc.anomalyUseCase.RememberCountry(ctx, user, client)
+
+	return uc.startSession(ctx, user, false)
+}
+
+// ListPasskeys returns the passkeys of a user, oldest first
+func (uc *authUseCase) ListPasskeys(ctx context.Context, userID uuid.UUID) ([]*entity.Passkey, error) {
+	return uc.passkeyRepo.ListByUser(ctx, userID)
+}
+
+// DeletePasskey deletes a passkey of a user. Passkeys of other users are reported as not found.
+func (uc *authUseCase) DeletePasskey(ctx context.Context, userID uuid.UUID, id string) error {
+	passkey, err := uc.passkeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if passkey == nil || passkey.UserID != userID {
+		return ErrPasskeyNotFound
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	credentials, err := countCredentials(ctx, uc.passkeyRepo, uc.oauthIdentityRepo, user)
+	if err != nil {
+		return err
+	}
+	if credentials <= 1 {
+		return ErrLastCredential
+	}
+
+	if err := uc.passkeyRepo.Delete(ctx, passkey); err != nil {
+		return err
+	}
+
+	uc.recordAccountAction(ctx, entity.AuditActionPasskeyRemoved, user, map[string]string{
+		"passkey_id":   passkey.ID,
+		"passkey_name": passkey.Name,
+	})
+	return nil
+}
+
+// startPasskeyCeremony stores a new ceremony with a fresh challenge, userID is set for registrations
+func (uc *authUseCase) startPasskeyCeremony(ctx context.Context, ceremonyType string, userID *uuid.UUID) (*entity.PasskeyCeremony, error) {
+	challenge, err := uc.passkeyService.NewChallenge()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate passkey challenge: %w", err)
+	}
+
+	ceremony := &entity.PasskeyCeremony{
+		ID:        uuid.New(),
+		Type:      ceremonyType,
+		UserID:    userID,
+		Challenge: challenge,
+		ExpiresAt: time.Now().Add(uc.passkeyTimeout),
+	}
+	if err := uc.passkeyCeremonyRepo.Store(ctx, ceremony); err != nil {
+		return nil, err
 	}
 
-	return claims.UserID, nil
+	return ceremony, nil
 }
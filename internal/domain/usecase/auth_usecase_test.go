@@ -0,0 +1,486 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/repository/inmem"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testPassword is the password of the user of an authTest
+const testPassword = "correct horse battery staple"
+
+// authTest is an AuthUseCase issuing real tokens into an in-memory token store and signing in users of an
+// in-memory user store behind a cache, its other dependencies mocked
+type authTest struct {
+	uc             AuthUseCase
+	users          *cachingUserRepository
+	tokens         repository.TokenRepository
+	hasher         service.PasswordHasher
+	securityEvents *mocks.MockSecurityEventUseCase
+	user           *entity.User
+}
+
+// newAuthTest creates an AuthUseCase for an active user with testPassword. No security event is expected unless
+// set on securityEvents, a refresh token of a revoked session presented again is not a reuse.
+func newAuthTest(t *testing.T) *authTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+	securityCfg := config.SecurityConfig{
+		PasetoPrivateKey:             hex.EncodeToString(privateKey),
+		AccessTokenExpirationMinutes: 15,
+		RefreshTokenExpirationDays:   7,
+		PasswordHashAlgorithm:        service.PasswordHashBcrypt,
+		BcryptCost:                   bcrypt.MinCost,
+	}
+	tokenService, err := service.NewTokenService(securityCfg)
+	if err != nil {
+		t.Fatalf("failed to create token service: %v", err)
+	}
+	hasher, err := service.NewPasswordHasher(securityCfg)
+	if err != nil {
+		t.Fatalf("failed to create password hasher: %v", err)
+	}
+	hash, err := hasher.Hash(testPassword)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	// Recently active, so signing in does not record the activity again
+	now := time.Now()
+	user := &entity.User{
+		ID:           uuid.New(),
+		Email:        "jane@example.com",
+		Username:     "jane",
+		Password:     hash,
+		Role:         entity.UserRoleUser,
+		Status:       entity.UserStatusActive,
+		LastActiveAt: &now,
+	}
+	users := newCachingUserRepository()
+	if err := users.Create(context.Background(), user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	tokens := inmem.NewTokenRepository()
+
+	passwordService := mocks.NewMockPasswordService(ctrl)
+	passwordService.EXPECT().Check(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	auditRepo := mocks.NewMockAuditRepository(ctrl)
+	auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	enforcementUseCase := mocks.NewMockEnforcementUseCase(ctrl)
+	enforcementUseCase.EXPECT().CheckLockout(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	enforcementUseCase.EXPECT().RecordFailedLogin(gomock.Any(), gomock.Any()).AnyTimes()
+	enforcementUseCase.EXPECT().ClearFailedLogins(gomock.Any(), gomock.Any()).AnyTimes()
+	anomalyUseCase := mocks.NewMockAnomalyUseCase(ctrl)
+	anomalyUseCase.EXPECT().CheckClient(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	anomalyUseCase.EXPECT().CheckCountry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	anomalyUseCase.EXPECT().RecordFailedLogin(gomock.Any(), gomock.Any()).AnyTimes()
+	breakGlassUseCase := mocks.NewMockBreakGlassUseCase(ctrl)
+	breakGlassUseCase.EXPECT().RecordUse(gomock.Any(), gomock.Any()).AnyTimes()
+	notificationUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	notificationUseCase.EXPECT().NotifyAdminAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	securityEvents := mocks.NewMockSecurityEventUseCase(ctrl)
+
+	uc := NewAuthUseCase(
+		users,
+		tokens,
+		auditRepo,
+		tokenService,
+		passwordService,
+		hasher,
+		notificationUseCase,
+		enforcementUseCase,
+		securityEvents,
+		mocks.NewMockStatusHistoryRepository(ctrl),
+		mocks.NewMockPasskeyRepository(ctrl),
+		mocks.NewMockPasskeyCeremonyRepository(ctrl),
+		mocks.NewMockPasskeyService(ctrl),
+		mocks.NewMockOAuthIdentityRepository(ctrl),
+		mocks.NewMockOrganizationRepository(ctrl),
+		breakGlassUseCase,
+		anomalyUseCase,
+		securityCfg,
+		config.PasswordResetConfig{},
+		config.PasskeyConfig{},
+		config.OAuthConfig{},
+	)
+
+	return &authTest{uc: uc, users: users, tokens: tokens, hasher: hasher, securityEvents: securityEvents, user: user}
+}
+
+// cachingUserRepository is an in-memory user store behind a cache behaving like the one of
+// repository.userRepository: lookups are served from the cache, Update caches the user as passed, password
+// included, and the other writes drop the cached user.
+type cachingUserRepository struct {
+	repository.UserRepository
+	mu     sync.Mutex
+	cached map[uuid.UUID]*entity.User
+}
+
+// newCachingUserRepository creates an empty cachingUserRepository
+func newCachingUserRepository() *cachingUserRepository {
+	return &cachingUserRepository{
+		UserRepository: inmem.NewUserRepository(),
+		cached:         map[uuid.UUID]*entity.User{},
+	}
+}
+
+// GetByID returns the cached user, caching it from the store when missing
+func (r *cachingUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, ok := r.cached[id]; ok {
+		return user.Clone(), nil
+	}
+	user, err := r.UserRepository.GetByID(ctx, id)
+	if err != nil || user == nil {
+		return user, err
+	}
+	r.cached[id] = user.Clone()
+	return user, nil
+}
+
+// GetByEmail resolves the user in the store and returns it through the cache
+func (r *cachingUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	user, err := r.UserRepository.GetByEmail(ctx, email)
+	if err != nil || user == nil {
+		return user, err
+	}
+	return r.GetByID(ctx, user.ID)
+}
+
+// GetByUsername resolves the user in the store and returns it through the cache
+func (r *cachingUserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	user, err := r.UserRepository.GetByUsername(ctx, username)
+	if err != nil || user == nil {
+		return user, err
+	}
+	return r.GetByID(ctx, user.ID)
+}
+
+// Update stores the user, its password aside, and caches it as passed
+func (r *cachingUserRepository) Update(ctx context.Context, user *entity.User) error {
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cached[user.ID] = user.Clone()
+	return nil
+}
+
+// ChangePassword stores the password and drops the cached user
+func (r *cachingUserRepository) ChangePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+	defer r.uncache(id)
+	return r.UserRepository.ChangePassword(ctx, id, hashedPassword)
+}
+
+// UpdateStatus stores the status and drops the cached user
+func (r *cachingUserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	defer r.uncache(id)
+	return r.UserRepository.UpdateStatus(ctx, id, status)
+}
+
+// expire drops every cached user, as if their cache entries expired
+func (r *cachingUserRepository) expire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.cached)
+}
+
+// uncache drops a cached user
+func (r *cachingUserRepository) uncache(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cached, id)
+}
+
+// login signs in with the email of the user and a password
+func (at *authTest) login(password string) (*entity.LoginResponse, error) {
+	return at.uc.Login(context.Background(), at.user.Email, password, false, entity.ClientInfo{})
+}
+
+// signIn starts a session of the user and returns its tokens
+func (at *authTest) signIn(t *testing.T) *entity.AuthTokens {
+	t.Helper()
+
+	resp, err := at.uc.StartSession(context.Background(), at.user, "")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	return &resp.AuthTokens
+}
+
+// logout logs out of the session of an access token, like the logout endpoint
+func (at *authTest) logout(t *testing.T, accessToken string) {
+	t.Helper()
+	ctx := context.Background()
+
+	claims, err := at.uc.ValidateToken(ctx, accessToken)
+	if err != nil {
+		t.Fatalf("access token rejected before logout: %v", err)
+	}
+	if err := at.uc.Logout(ctx, claims.SessionID, claims.TokenID); err != nil {
+		t.Fatalf("failed to log out: %v", err)
+	}
+}
+
+// assertRevoked fails unless both tokens are rejected
+func (at *authTest) assertRevoked(t *testing.T, tokens *entity.AuthTokens) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := at.uc.ValidateToken(ctx, tokens.AccessToken); !errors.Is(err, service.ErrInvalidToken) {
+		t.Errorf("access token: got error %v, want %v", err, service.ErrInvalidToken)
+	}
+	if _, err := at.uc.RefreshToken(ctx, tokens.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("refresh token: got error %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
+
+// assertValid fails unless the access token is accepted
+func (at *authTest) assertValid(t *testing.T, tokens *entity.AuthTokens) {
+	t.Helper()
+
+	if _, err := at.uc.ValidateToken(context.Background(), tokens.AccessToken); err != nil {
+		t.Errorf("access token rejected: %v", err)
+	}
+}
+
+func TestLogoutRevokesSessionTokens(t *testing.T) {
+	at := newAuthTest(t)
+	tokens := at.signIn(t)
+
+	at.logout(t, tokens.AccessToken)
+
+	at.assertRevoked(t, tokens)
+}
+
+func TestLogoutAfterRefreshRevokesRotatedTokens(t *testing.T) {
+	at := newAuthTest(t)
+	original := at.signIn(t)
+
+	rotated, err := at.uc.RefreshToken(context.Background(), original.RefreshToken)
+	if err != nil {
+		t.Fatalf("failed to refresh tokens: %v", err)
+	}
+	at.assertValid(t, rotated)
+
+	at.logout(t, rotated.AccessToken)
+
+	at.assertRevoked(t, rotated)
+	at.assertRevoked(t, original)
+}
+
+func TestLogoutLeavesOtherDevicesSignedIn(t *testing.T) {
+	at := newAuthTest(t)
+	laptop := at.signIn(t)
+	phone := at.signIn(t)
+
+	at.logout(t, laptop.AccessToken)
+
+	at.assertRevoked(t, laptop)
+	at.assertValid(t, phone)
+	if _, err := at.uc.RefreshToken(context.Background(), phone.RefreshToken); err != nil {
+		t.Errorf("refresh token of the other device rejected: %v", err)
+	}
+}
+
+func TestLogoutAllRevokesEveryDevice(t *testing.T) {
+	at := newAuthTest(t)
+	laptop := at.signIn(t)
+	phone := at.signIn(t)
+	tablet, err := at.uc.RefreshToken(context.Background(), at.signIn(t).RefreshToken)
+	if err != nil {
+		t.Fatalf("failed to refresh tokens: %v", err)
+	}
+
+	if err := at.uc.LogoutAll(context.Background(), at.user.ID); err != nil {
+		t.Fatalf("failed to log out everywhere: %v", err)
+	}
+
+	at.assertRevoked(t, laptop)
+	at.assertRevoked(t, phone)
+	at.assertRevoked(t, tablet)
+}
+
+// expectTokenReuse expects a single refresh token reuse to be raised for the user
+func (at *authTest) expectTokenReuse() {
+	at.securityEvents.EXPECT().Record(gomock.Any(), gomock.Cond(func(event *entity.SecurityEvent) bool {
+		return event.Type == entity.SecurityEventRefreshTokenReused && event.TargetID == at.user.ID
+	})).Times(1)
+}
+
+// sessionOf returns the session of a valid access token
+func (at *authTest) sessionOf(t *testing.T, accessToken string) uuid.UUID {
+	t.Helper()
+
+	claims, err := at.uc.ValidateToken(context.Background(), accessToken)
+	if err != nil {
+		t.Fatalf("access token rejected: %v", err)
+	}
+	return claims.SessionID
+}
+
+// assertSessionRevoked fails unless a session was revoked for a reason
+func (at *authTest) assertSessionRevoked(t *testing.T, sessionID uuid.UUID, reason string) {
+	t.Helper()
+
+	session, err := at.tokens.GetSession(context.Background(), sessionID)
+	if err != nil || session == nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if session.RevokedAt == nil || session.RevokedReason != reason {
+		t.Errorf("session revoked at %v for %q, want revoked for %q", session.RevokedAt, session.RevokedReason, reason)
+	}
+}
+
+func TestRefreshTokenReuseRevokesSession(t *testing.T) {
+	at := newAuthTest(t)
+	ctx := context.Background()
+	original := at.signIn(t)
+	other := at.signIn(t)
+	sessionID := at.sessionOf(t, original.AccessToken)
+
+	rotated, err := at.uc.RefreshToken(ctx, original.RefreshToken)
+	if err != nil {
+		t.Fatalf("failed to refresh tokens: %v", err)
+	}
+
+	at.expectTokenReuse()
+	if _, err := at.uc.RefreshToken(ctx, original.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("rotated refresh token: got error %v, want %v", err, ErrRefreshTokenReused)
+	}
+
+	// Both the thief and the legitimate client are signed out, the other sessions are left alone
+	at.assertSessionRevoked(t, sessionID, entity.SessionRevokedTokenReuse)
+	at.assertRevoked(t, rotated)
+	at.assertValid(t, other)
+}
+
+func TestConcurrentRefreshKeepsNoTokens(t *testing.T) {
+	at := newAuthTest(t)
+	tokens := at.signIn(t)
+	sessionID := at.sessionOf(t, tokens.AccessToken)
+
+	// The presentation losing the rotation is a reuse, whichever it is. The winner either returns tokens revoked
+	// with the session, or stores them once the session is revoked and is rejected.
+	at.expectTokenReuse()
+	type result struct {
+		tokens *entity.AuthTokens
+		err    error
+	}
+	const presentations = 2
+	results := make(chan result, presentations)
+	var wg sync.WaitGroup
+	for range presentations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rotated, err := at.uc.RefreshToken(context.Background(), tokens.RefreshToken)
+			results <- result{rotated, err}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var reused int
+	for result := range results {
+		switch {
+		case result.err == nil:
+			at.assertRevoked(t, result.tokens)
+		case errors.Is(result.err, ErrRefreshTokenReused):
+			reused++
+		case !errors.Is(result.err, ErrInvalidRefreshToken):
+			t.Errorf("unexpected error: %v", result.err)
+		}
+	}
+	if reused != 1 {
+		t.Errorf("got %d reuses, want 1", reused)
+	}
+	at.assertSessionRevoked(t, sessionID, entity.SessionRevokedTokenReuse)
+	if sessions, err := at.tokens.ListUserSessions(context.Background(), at.user.ID); err != nil || len(sessions) != 0 {
+		t.Errorf("got %d sessions holding live tokens (error %v), want none", len(sessions), err)
+	}
+}
+
+func TestStartSessionRequiresPasswordChange(t *testing.T) {
+	at := newAuthTest(t)
+	ctx := context.Background()
+
+	at.user.MustChangePassword = true
+	if err := at.users.Update(ctx, at.user); err != nil {
+		t.Fatalf("failed to require password change: %v", err)
+	}
+
+	resp, err := at.uc.StartSession(ctx, at.user, "google")
+
+	var changeErr *PasswordChangeRequiredError
+	if !errors.As(err, &changeErr) {
+		t.Fatalf("got error %v, want a PasswordChangeRequiredError", err)
+	}
+	if resp != nil {
+		t.Errorf("tokens issued to a user required to change their password")
+	}
+	if changeErr.Token == "" {
+		t.Fatal("no password change token")
+	}
+
+	if _, err := at.uc.ChangeRequiredPassword(ctx, changeErr.Token, "new "+testPassword); err != nil {
+		t.Fatalf("failed to change required password: %v", err)
+	}
+
+	if _, err := at.login(testPassword); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("former password: got error %v, want %v", err, ErrInvalidCredentials)
+	}
+	if _, err := at.login("new " + testPassword); err != nil {
+		t.Errorf("new password rejected: %v", err)
+	}
+}
+
+func TestResetPasswordLiftsSuspiciousActivityBlock(t *testing.T) {
+	at := newAuthTest(t)
+	ctx := context.Background()
+
+	at.user.PasswordResetRequired = true
+	if err := at.users.Update(ctx, at.user); err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+	if _, err := at.login(testPassword); !errors.Is(err, ErrPasswordResetRequired) {
+		t.Fatalf("blocked user: got error %v, want %v", err, ErrPasswordResetRequired)
+	}
+
+	if err := at.tokens.StoreOneTimeToken(ctx, entity.OneTimeTokenPasswordReset, "reset-token", at.user.ID, time.Hour); err != nil {
+		t.Fatalf("failed to store reset token: %v", err)
+	}
+	if err := at.uc.ResetPassword(ctx, "reset-token", "new "+testPassword); err != nil {
+		t.Fatalf("failed to reset password: %v", err)
+	}
+
+	if _, err := at.login(testPassword); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("former password: got error %v, want %v", err, ErrInvalidCredentials)
+	}
+	if _, err := at.login("new " + testPassword); err != nil {
+		t.Errorf("new password rejected: %v", err)
+	}
+}
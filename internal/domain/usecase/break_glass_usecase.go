@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/infrastructure/secrets"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrBreakGlassAccountNotFound is returned when rotating the credential of an unknown break-glass account
+	ErrBreakGlassAccountNotFound = errors.New("break-glass account not found")
+
+	// ErrBreakGlassAccountExists is returned when sealing a break-glass account under the username of another one
+	ErrBreakGlassAccountExists = errors.New("break-glass account already exists")
+)
+
+const (
+	// breakGlassRotationDedupScope is the dedup scope of credential rotations, keyed by user ID, so instances never
+	// rotate a credential concurrently and leave a sealed secret that does not match the password
+	breakGlassRotationDedupScope = "break_glass_rotation"
+
+	// breakGlassPasswordBytes is the entropy of the generated passwords, hex-encoded below the bcrypt limit
+	breakGlassPasswordBytes = 32
+)
+
+// BreakGlassUseCase defines the use case for the emergency admin accounts whose credentials are sealed in the
+// secrets backend
+type BreakGlassUseCase interface {
+	// Seal creates a break-glass admin account and writes its credential to the secrets backend, on behalf of an
+	// operator
+	Seal(ctx context.Context, username, email string) (*entity.BreakGlassAccount, error)
+
+	// Rotate replaces the credential of a break-glass account in the secrets backend and revokes its sessions
+	Rotate(ctx context.Context, actorID uuid.UUID, username string) (*entity.BreakGlassAccount, error)
+
+	// List returns the break-glass accounts ordered by username
+	List(ctx context.Context) ([]*entity.BreakGlassAccount, error)
+
+	// RecordUse raises the alerts of a sign in when the user is a break-glass account, other users are ignored
+	RecordUse(ctx context.Context, user *entity.User)
+
+	// RotateUsed rotates the credentials of the break-glass accounts used at least the configured time ago
+	RotateUsed(ctx context.Context) error
+
+	// RunRotation rotates the credentials of the used break-glass accounts at every interval until the context is
+	// cancelled
+	RunRotation(ctx context.Context, interval time.Duration)
+}
+
+// breakGlassUseCase implements BreakGlassUseCase interface
+type breakGlassUseCase struct {
+	accountRepo         repository.BreakGlassRepository
+	userRepo            repository.UserRepository
+	tokenRepo           repository.TokenRepository
+	auditRepo           repository.AuditRepository
+	dedupRepo           repository.DedupRepository
+	notificationUseCase NotificationUseCase
+	passwordHasher      service.PasswordHasher
+	secretStore         secrets.Store
+	breakGlassCfg       config.BreakGlassConfig
+}
+
+// NewBreakGlassUseCase creates a new BreakGlassUseCase
+func NewBreakGlassUseCase(
+	accountRepo repository.BreakGlassRepository,
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	auditRepo repository.AuditRepository,
+	dedupRepo repository.DedupRepository,
+	notificationUseCase NotificationUseCase,
+	passwordHasher service.PasswordHasher,
+	secretStore secrets.Store,
+	breakGlassCfg config.BreakGlassConfig,
+) BreakGlassUseCase {
+	return &breakGlassUseCase{
+		accountRepo:         accountRepo,
+		userRepo:            userRepo,
+		tokenRepo:           tokenRepo,
+		auditRepo:           auditRepo,
+		dedupRepo:           dedupRepo,
+		notificationUseCase: notificationUseCase,
+		passwordHasher:      passwordHasher,
+		secretStore:         secretStore,
+		breakGlassCfg:       breakGlassCfg,
+	}
+}
+
+// Seal creates an active admin account with a generated password. The credential is sealed before the account is
+// created, and the account is removed if it cannot be flagged as break-glass, so no admin account escapes the alerts.
+func (uc *breakGlassUseCase) Seal(ctx context.Context, username, email string) (*entity.BreakGlassAccount, error) {
+	if !entity.IsValidUsername(username) {
+		return nil, ErrInvalidUsername
+	}
+
+	existingUser, err := uc.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser != nil {
+		account, err := uc.accountRepo.GetByUserID(ctx, existingUser.ID)
+		if err != nil {
+			return nil, err
+		}
+		if account != nil {
+			return nil, ErrBreakGlassAccountExists
+		}
+		return nil, ErrUsernameAlreadyExists
+	}
+	email = entity.NormalizeEmail(email)
+	existingUser, err = uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	user := entity.NewUser(email, username, "", "Break-glass", username)
+	user.Role = entity.UserRoleAdmin
+	user.EmailVerified = true
+
+	account := &entity.BreakGlassAccount{
+		UserID:     user.ID,
+		Username:   username,
+		SecretName: "break-glass-" + username,
+	}
+	if err := uc.seal(ctx, user, account); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := uc.accountRepo.Save(ctx, account); err != nil {
+		if deleteErr := uc.userRepo.Delete(context.WithoutCancel(ctx), user.ID); deleteErr != nil {
+			log.Error().Err(deleteErr).Str("user_id", user.ID.String()).Msg("Failed to delete break-glass account left unflagged")
+		}
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionBreakGlassSealed, uuid.Nil, account, nil)
+	return account, nil
+}
+
+// Rotate replaces the credential of a break-glass account found by username
+func (uc *breakGlassUseCase) Rotate(ctx context.Context, actorID uuid.UUID, username string) (*entity.BreakGlassAccount, error) {
+	user, err := uc.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrBreakGlassAccountNotFound
+	}
+	account, err := uc.accountRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrBreakGlassAccountNotFound
+	}
+
+	if err := uc.rotate(ctx, actorID, account, "manual"); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// List returns the break-glass accounts ordered by username
+func (uc *breakGlassUseCase) List(ctx context.Context) ([]*entity.BreakGlassAccount, error) {
+	return uc.accountRepo.List(ctx)
+}
+
+// RecordUse logs, audits and emails every sign in of a break-glass account. The first sign in since the credential
+// was sealed starts the countdown to its rotation. Alerts never fail the sign in, so failures are logged.
+func (uc *breakGlassUseCase) RecordUse(ctx context.Context, user *entity.User) {
+	account, err := uc.accountRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to check for a break-glass account")
+		return
+	}
+	if account == nil {
+		return
+	}
+
+	now := time.Now()
+	firstUse, err := uc.accountRepo.MarkUsed(ctx, user.ID, now)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to mark break-glass account used")
+	}
+	usedAt := now
+	if account.UsedAt != nil {
+		usedAt = *account.UsedAt
+	}
+	rotateAt := usedAt.Add(uc.breakGlassCfg.RotateAfter)
+
+	log.Error().
+		Str("alert", entity.AuditActionBreakGlassUsed).
+		Str("user_id", user.ID.String()).
+		Str("username", account.Username).
+		Time("rotate_at", rotateAt).
+		Msg("Break-glass account signed in")
+	uc.recordAction(ctx, entity.AuditActionBreakGlassUsed, user.ID, account, map[string]string{
+		"first_use": fmt.Sprint(firstUse),
+		"rotate_at": rotateAt.UTC().Format(time.RFC3339),
+	})
+	runInBackground(ctx, "break_glass_alert", func(ctx context.Context) error {
+		return uc.notificationUseCase.SendBreakGlassAlert(ctx, user, uc.breakGlassCfg.NotifyEmails, now, rotateAt)
+	})
+}
+
+// RotateUsed rotates the credentials of the accounts due for rotation, one failure does not hold back the others
+func (uc *breakGlassUseCase) RotateUsed(ctx context.Context) error {
+	accounts, err := uc.accountRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var errs []error
+	for _, account := range accounts {
+		if !account.IsDueForRotation(now, uc.breakGlassCfg.RotateAfter) {
+			continue
+		}
+		if err := uc.rotate(ctx, uuid.Nil, account, "used"); err != nil {
+			errs = append(errs, fmt.Errorf("failed to rotate break-glass account %s: %w", account.Username, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunRotation rotates the credentials of the used break-glass accounts at every interval until the context is
+// cancelled
+func (uc *breakGlassUseCase) RunRotation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := uc.RotateUsed(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to rotate break-glass credentials")
+		}
+	}
+}
+
+// rotate seals a new credential for an account, then sets it as the password of the account and revokes its
+// sessions. A rotation failing after the credential is sealed is retried by the next one, which seals yet another.
+func (uc *breakGlassUseCase) rotate(ctx context.Context, actorID uuid.UUID, account *entity.BreakGlassAccount, reason string) error {
+	// Claim the rotation, any other instance rotating the same account skips it. Fail closed, a sealed credential
+	// not matching the password would lock the operators out.
+	claimed, err := uc.dedupRepo.Claim(ctx, breakGlassRotationDedupScope, account.UserID.String(), time.Minute)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+	defer func() {
+		if err := uc.dedupRepo.Release(context.WithoutCancel(ctx), breakGlassRotationDedupScope, account.UserID.String()); err != nil {
+			log.Warn().Err(err).Str("user_id", account.UserID.String()).Msg("Failed to release break-glass rotation")
+		}
+	}()
+
+	user, err := uc.userRepo.GetByID(ctx, account.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		// The user was purged, there is no credential left to rotate
+		return uc.accountRepo.Delete(ctx, account.UserID)
+	}
+
+	if err := uc.seal(ctx, user, account); err != nil {
+		return err
+	}
+	// Update leaves the password alone, it is stored on its own
+	if err := uc.userRepo.ChangePassword(ctx, user.ID, user.Password); err != nil {
+		return err
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to revoke the sessions of a rotated break-glass account")
+	}
+	if err := uc.accountRepo.Save(ctx, account); err != nil {
+		return err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionBreakGlassRotated, actorID, account, map[string]string{"reason": reason})
+	return nil
+}
+
+// seal generates a new password for a user and writes it to the secrets backend, the user and account are updated
+// but not stored
+func (uc *breakGlassUseCase) seal(ctx context.Context, user *entity.User, account *entity.BreakGlassAccount) error {
+	password, err := utils.GenerateRandomToken(breakGlassPasswordBytes)
+	if err != nil {
+		return fmt.Errorf("failed to generate break-glass password: %w", err)
+	}
+	hash, err := uc.passwordHasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash break-glass password: %w", err)
+	}
+
+	now := time.Now()
+	secret, err := json.Marshal(&entity.BreakGlassCredential{
+		UserID:   user.ID,
+		Username: user.Username,
+		Password: password,
+		SealedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode break-glass credential: %w", err)
+	}
+	if err := uc.secretStore.Put(ctx, account.SecretName, secret); err != nil {
+		return fmt.Errorf("failed to seal break-glass credential: %w", err)
+	}
+
+	user.Password = hash
+	user.UpdatedAt = now
+	account.SealedAt = now
+	account.UsedAt = nil
+	return nil
+}
+
+// recordAction records an action on a break-glass account in the audit trail, the account is the target
+func (uc *breakGlassUseCase) recordAction(ctx context.Context, action string, actorID uuid.UUID, account *entity.BreakGlassAccount, details map[string]string) {
+	if details == nil {
+		details = map[string]string{}
+	}
+	details["username"] = account.Username
+	details["secret_name"] = account.SecretName
+
+	entry := entity.NewAuditEntry(action, actorID, account.UserID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("user_id", account.UserID.String()).Msg("Failed to record break-glass action in audit trail")
+	}
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/repository/inmem"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/secrets"
+	"github.com/chats/go-user-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// breakGlassTest is a BreakGlassUseCase sealing credentials into a temporary directory, for the users signing in
+// through an authTest
+type breakGlassTest struct {
+	*authTest
+	uc         BreakGlassUseCase
+	accounts   repository.BreakGlassRepository
+	secretsDir string
+}
+
+// newBreakGlassTest creates a BreakGlassUseCase rotating the credentials used an hour ago
+func newBreakGlassTest(t *testing.T) *breakGlassTest {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	at := newAuthTest(t)
+
+	auditRepo := mocks.NewMockAuditRepository(ctrl)
+	auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	accounts := inmem.NewBreakGlassRepository()
+	secretsDir := t.TempDir()
+	uc := NewBreakGlassUseCase(
+		accounts,
+		at.users,
+		at.tokens,
+		auditRepo,
+		repository.NewDedupRepository(cache.NewMemory()),
+		mocks.NewMockNotificationUseCase(ctrl),
+		at.hasher,
+		secrets.NewStore(secretsDir),
+		config.BreakGlassConfig{RotateAfter: time.Hour},
+	)
+
+	return &breakGlassTest{authTest: at, uc: uc, accounts: accounts, secretsDir: secretsDir}
+}
+
+// credential reads the credential sealed for an account
+func (bt *breakGlassTest) credential(t *testing.T, account *entity.BreakGlassAccount) *entity.BreakGlassCredential {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(bt.secretsDir, account.SecretName))
+	if err != nil {
+		t.Fatalf("failed to read sealed credential: %v", err)
+	}
+	var credential entity.BreakGlassCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		t.Fatalf("failed to decode sealed credential: %v", err)
+	}
+	return &credential
+}
+
+// login signs in with a credential
+func (bt *breakGlassTest) login(credential *entity.BreakGlassCredential) (*entity.LoginResponse, error) {
+	return bt.authTest.uc.Login(context.Background(), credential.Username, credential.Password, false, entity.ClientInfo{})
+}
+
+func TestRotateUsedReplacesBreakGlassCredential(t *testing.T) {
+	bt := newBreakGlassTest(t)
+	ctx := context.Background()
+
+	account, err := bt.uc.Seal(ctx, "breakglass-1", "breakglass-1@example.com")
+	if err != nil {
+		t.Fatalf("failed to seal break-glass account: %v", err)
+	}
+	used := bt.credential(t, account)
+	session, err := bt.login(used)
+	if err != nil {
+		t.Fatalf("sealed credential rejected: %v", err)
+	}
+	if _, err := bt.accounts.MarkUsed(ctx, account.UserID, time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatalf("failed to mark account used: %v", err)
+	}
+
+	if err := bt.uc.RotateUsed(ctx); err != nil {
+		t.Fatalf("failed to rotate used accounts: %v", err)
+	}
+
+	rotated := bt.credential(t, account)
+	if rotated.Password == used.Password {
+		t.Fatal("credential not replaced")
+	}
+	bt.assertRevoked(t, &session.AuthTokens)
+
+	// Once the cached account expires, only the stored password counts
+	for _, expired := range []bool{false, true} {
+		if expired {
+			bt.users.expire()
+		}
+		if _, err := bt.login(used); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("used credential (cache expired: %v): got error %v, want %v", expired, err, ErrInvalidCredentials)
+		}
+		if _, err := bt.login(rotated); err != nil {
+			t.Errorf("rotated credential rejected (cache expired: %v): %v", expired, err)
+		}
+	}
+}
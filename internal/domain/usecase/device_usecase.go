@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrDeviceInvalidRequest is returned when a device request misses its client ID or device code
+	ErrDeviceInvalidRequest = errors.New("invalid device request")
+
+	// ErrDeviceUnsupportedGrantType is returned when polling with another grant type than the device code
+	ErrDeviceUnsupportedGrantType = errors.New("unsupported grant type")
+
+	// ErrDeviceCodeNotFound is returned when a user code is unknown, expired or already approved or denied
+	ErrDeviceCodeNotFound = errors.New("device code not found")
+
+	// ErrDeviceAuthorizationPending is returned when polling before the user approved or denied the sign in
+	ErrDeviceAuthorizationPending = errors.New("authorization pending")
+
+	// ErrDeviceSlowDown is returned when polling faster than the poll interval
+	ErrDeviceSlowDown = errors.New("polling too fast")
+
+	// ErrDeviceAccessDenied is returned when polling for a sign in the user denied
+	ErrDeviceAccessDenied = errors.New("access denied")
+
+	// ErrDeviceCodeExpired is returned when polling with an unknown or expired device code
+	ErrDeviceCodeExpired = errors.New("device code expired")
+)
+
+const (
+	// deviceGrantType is the grant type of the token polls (RFC 8628)
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// userCodeAlphabet leaves out vowels, so user codes never spell words, and lookalike characters
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+
+	// maxClientIDLength bounds the client ID displayed to the user approving the sign in
+	maxClientIDLength = 64
+
+	// userCodeAttempts bounds the user codes drawn before giving up on collisions
+	userCodeAttempts = 5
+
+	devicePollScope = "device_poll"
+)
+
+// DeviceUseCase defines the use case for the device authorization grant, signing in headless clients
+// such as CLIs and TVs without them handling the password of the user
+type DeviceUseCase interface {
+	// RequestCode starts the sign in of a device, returning the device code it polls with
+	// and the user code the user enters on the verification page
+	RequestCode(ctx context.Context, clientID string) (*entity.DeviceCodeResponse, error)
+
+	// GetPending returns the pending sign in of a user code, for the user to check the client before approving it
+	GetPending(ctx context.Context, userCode string) (*entity.DeviceAuthorization, error)
+
+	// Approve approves the pending sign in of a user code as the user
+	Approve(ctx context.Context, userID uuid.UUID, userCode string) error
+
+	// Deny denies the pending sign in of a user code
+	Deny(ctx context.Context, userID uuid.UUID, userCode string) error
+
+	// PollToken returns the tokens of an approved sign in once, or why they are not issued yet
+	PollToken(ctx context.Context, grantType, deviceCode, clientID string) (*entity.DeviceTokenResponse, error)
+}
+
+// deviceUseCase implements DeviceUseCase interface
+type deviceUseCase struct {
+	deviceRepo   repository.DeviceAuthorizationRepository
+	userRepo     repository.UserRepository
+	tokenRepo    repository.TokenRepository
+	auditRepo    repository.AuditRepository
+	dedupRepo    repository.DedupRepository
+	tokenService service.TokenService
+	config       config.DeviceConfig
+
+	// maxSessions and sessionLimitAction limit the concurrent sessions of a user, like the other sign ins
+	maxSessions        int
+	sessionLimitAction string
+}
+
+// NewDeviceUseCase creates a new DeviceUseCase
+func NewDeviceUseCase(
+	deviceRepo repository.DeviceAuthorizationRepository,
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	auditRepo repository.AuditRepository,
+	dedupRepo repository.DedupRepository,
+	tokenService service.TokenService,
+	cfg config.DeviceConfig,
+	securityCfg config.SecurityConfig,
+) DeviceUseCase {
+	return &deviceUseCase{
+		deviceRepo:         deviceRepo,
+		userRepo:           userRepo,
+		tokenRepo:          tokenRepo,
+		auditRepo:          auditRepo,
+		dedupRepo:          dedupRepo,
+		tokenService:       tokenService,
+		config:             cfg,
+		maxSessions:        securityCfg.MaxActiveSessions,
+		sessionLimitAction: sessionLimitAction(securityCfg.SessionLimitAction),
+	}
+}
+
+// RequestCode starts the sign in of a device
+func (uc *deviceUseCase) RequestCode(ctx context.Context, clientID string) (*entity.DeviceCodeResponse, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" || len(clientID) > maxClientIDLength {
+		return nil, ErrDeviceInvalidRequest
+	}
+
+	deviceCode, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate device code: %w", err)
+	}
+
+	now := time.Now()
+	authorization := &entity.DeviceAuthorization{
+		ClientID:  clientID,
+		Status:    entity.DeviceAuthorizationPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(uc.config.CodeExpiration),
+	}
+
+	var created bool
+	for attempt := 0; attempt < userCodeAttempts && !created; attempt++ {
+		authorization.UserCode, err = generateUserCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate user code: %w", err)
+		}
+
+		created, err = uc.deviceRepo.Create(ctx, deviceCode, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !created {
+		return nil, errors.New("failed to draw an unused user code")
+	}
+
+	userCode := formatUserCode(authorization.UserCode)
+	return &entity.DeviceCodeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         uc.config.VerificationURL,
+		VerificationURIComplete: uc.config.VerificationURL + "?" + url.Values{"user_code": {userCode}}.Encode(),
+		ExpiresIn:               int(uc.config.CodeExpiration.Seconds()),
+		Interval:                int(uc.config.PollInterval.Seconds()),
+	}, nil
+}
+
+// GetPending returns the pending sign in of a user code
+func (uc *deviceUseCase) GetPending(ctx context.Context, userCode string) (*entity.DeviceAuthorization, error) {
+	authorization, err := uc.deviceRepo.GetByUserCode(ctx, normalizeUserCode(userCode))
+	if err != nil {
+		return nil, err
+	}
+	if authorization == nil || authorization.Status != entity.DeviceAuthorizationPending {
+		return nil, ErrDeviceCodeNotFound
+	}
+
+	authorization.UserCode = formatUserCode(authorization.UserCode)
+	return authorization, nil
+}
+
+// Approve approves the pending sign in of a user code as the user
+func (uc *deviceUseCase) Approve(ctx context.Context, userID uuid.UUID, userCode string) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.Status != entity.UserStatusActive {
+		return ErrAccountInactive
+	}
+	if user.PasswordResetRequired {
+		return ErrPasswordResetRequired
+	}
+
+	authorization, err := uc.decide(ctx, userID, userCode, entity.DeviceAuthorizationApproved)
+	if err != nil {
+		return err
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionDeviceAuthorized, userID, userID, map[string]string{
+		"client_id": authorization.ClientID,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to record device authorization in audit log")
+	}
+
+	return nil
+}
+
+// Deny denies the pending sign in of a user code
+func (uc *deviceUseCase) Deny(ctx context.Context, userID uuid.UUID, userCode string) error {
+	_, err := uc.decide(ctx, userID, userCode, entity.DeviceAuthorizationDenied)
+	return err
+}
+
+// decide records the decision of the user on the pending sign in of a user code
+func (uc *deviceUseCase) decide(ctx context.Context, userID uuid.UUID, userCode, status string) (*entity.DeviceAuthorization, error) {
+	authorization, err := uc.deviceRepo.GetByUserCode(ctx, normalizeUserCode(userCode))
+	if err != nil {
+		return nil, err
+	}
+	if authorization == nil || authorization.Status != entity.DeviceAuthorizationPending {
+		return nil, ErrDeviceCodeNotFound
+	}
+
+	authorization.Status = status
+	authorization.UserID = userID
+	if err := uc.deviceRepo.Update(ctx, authorization); err != nil {
+		return nil, err
+	}
+
+	return authorization, nil
+}
+
+// PollToken returns the tokens of an approved sign in once, or why they are not issued yet
+func (uc *deviceUseCase) PollToken(ctx context.Context, grantType, deviceCode, clientID string) (*entity.DeviceTokenResponse, error) {
+	if grantType != deviceGrantType {
+		return nil, ErrDeviceUnsupportedGrantType
+	}
+	if deviceCode == "" {
+		return nil, ErrDeviceInvalidRequest
+	}
+
+	authorization, err := uc.deviceRepo.GetByDeviceCode(ctx, deviceCode)
+	if err != nil {
+		return nil, err
+	}
+	if authorization == nil || (clientID != "" && clientID != authorization.ClientID) {
+		return nil, ErrDeviceCodeExpired
+	}
+
+	// A poll claims the interval on all instances, so devices polling faster are slowed down
+	// and concurrent polls cannot both receive the tokens
+	claimed, err := uc.dedupRepo.Claim(ctx, devicePollScope, authorization.ID, uc.config.PollInterval)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrDeviceSlowDown
+	}
+
+	switch authorization.Status {
+	case entity.DeviceAuthorizationPending:
+		return nil, ErrDeviceAuthorizationPending
+	case entity.DeviceAuthorizationDenied:
+		if err := uc.deviceRepo.Delete(ctx, authorization); err != nil {
+			log.Warn().Err(err).Msg("Failed to delete denied device authorization")
+		}
+		return nil, ErrDeviceAccessDenied
+	}
+
+	// The authorization is deleted before the tokens are issued, so they are issued once
+	if err := uc.deviceRepo.Delete(ctx, authorization); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, authorization.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != entity.UserStatusActive || user.PasswordResetRequired {
+		return nil, ErrDeviceAccessDenied
+	}
+	if err := limitSessions(ctx, uc.tokenRepo, user.ID, uc.maxSessions, uc.sessionLimitAction); err != nil {
+		return nil, err
+	}
+
+	tokens, accessDetails, refreshDetails, err := uc.tokenService.GenerateTokens(user, uuid.New(), false)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to generate tokens")
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+
+	if err := uc.tokenRepo.StoreAccessToken(ctx, accessDetails); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to store access token")
+		return nil, fmt.Errorf("failed to store access token: %w", err)
+	}
+
+	if err := uc.tokenRepo.StoreRefreshToken(ctx, refreshDetails); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to store refresh token")
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &entity.DeviceTokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(tokens.ExpiresAt).Seconds()),
+	}, nil
+}
+
+// generateUserCode draws a random user code
+func generateUserCode() (string, error) {
+	code := make([]byte, userCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(userCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// formatUserCode splits a user code in two halves for users to read it, e.g. BDFG-HJKL
+func formatUserCode(code string) string {
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+// normalizeUserCode returns a user code as stored, whatever the case and separators the user typed
+func normalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+}
@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/directory"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrDirectoryNotConfigured is returned when reconciling without a directory or its email domains
+	ErrDirectoryNotConfigured = errors.New("directory not configured")
+
+	// ErrDirectoryEmpty is returned when the directory has no user entries, which rather tells a wrong base DN or
+	// filter than an empty company, so no user is deactivated
+	ErrDirectoryEmpty = errors.New("directory returned no entries")
+
+	// ErrDirectoryUnavailable is returned when the entries of the directory cannot be read
+	ErrDirectoryUnavailable = errors.New("directory unavailable")
+
+	// ErrDirectorySyncRunning is returned when a reconciliation is already running on any instance
+	ErrDirectorySyncRunning = errors.New("directory sync already running")
+)
+
+const (
+	// directoryPageSize is the number of users read at a time while reconciling
+	directoryPageSize = 100
+
+	// directorySyncDedupScope is the dedup scope of the reconciliation job, keyed by interval, so instances
+	// reconcile once per interval between them
+	directorySyncDedupScope = "directory_sync"
+
+	// directorySyncLockScope is the dedup scope of the lock held while a reconciliation runs, so scheduled and
+	// manual runs do not overlap
+	directorySyncLockScope = "directory_sync_run"
+
+	// directorySyncLockTimeout bounds the lock of a reconciliation, in case its instance stops before releasing it
+	directorySyncLockTimeout = 15 * time.Minute
+)
+
+// DirectoryUseCase defines the use case for the reconciliation of the users against the upstream directory
+type DirectoryUseCase interface {
+	// Reconcile compares the users of the directory email domains against the directory and stores and returns
+	// the drift report. When enabled, it invites the users of the enabled entries without a user, copies the names
+	// of the entries to their users and deactivates the active users missing or disabled upstream. A dry run only
+	// reports these changes, as do all runs when the configuration asks for dry runs.
+	Reconcile(ctx context.Context, actorID uuid.UUID, dryRun bool) (*entity.DirectoryDriftReport, error)
+
+	// LatestReport returns the drift report of the latest reconciliation, nil if none ran yet
+	LatestReport(ctx context.Context) (*entity.DirectoryDriftReport, error)
+
+	// RunReconciliation reconciles the users at every interval until the context is cancelled
+	RunReconciliation(ctx context.Context, interval time.Duration)
+}
+
+// directoryUseCase implements DirectoryUseCase interface
+type directoryUseCase struct {
+	directory         directory.Directory
+	userRepo          repository.UserRepository
+	userUseCase       UserUseCase
+	invitationUseCase InvitationUseCase
+	reportRepo        repository.DirectoryReportRepository
+	dedupRepo         repository.DedupRepository
+
+	// domains are the normalized email domains the directory is authoritative for
+	domains []string
+
+	deactivateRemoved bool
+	createUsers       bool
+	updateProfiles    bool
+	dryRun            bool
+}
+
+// NewDirectoryUseCase creates a new DirectoryUseCase, dir is nil when no directory is configured
+func NewDirectoryUseCase(
+	dir directory.Directory,
+	userRepo repository.UserRepository,
+	userUseCase UserUseCase,
+	invitationUseCase InvitationUseCase,
+	reportRepo repository.DirectoryReportRepository,
+	dedupRepo repository.DedupRepository,
+	directoryCfg config.DirectoryConfig,
+) DirectoryUseCase {
+	var domains []string
+	for _, domain := range directoryCfg.EmailDomains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	return &directoryUseCase{
+		directory:         dir,
+		userRepo:          userRepo,
+		userUseCase:       userUseCase,
+		invitationUseCase: invitationUseCase,
+		reportRepo:        reportRepo,
+		dedupRepo:         dedupRepo,
+		domains:           domains,
+		deactivateRemoved: directoryCfg.DeactivateRemoved,
+		createUsers:       directoryCfg.CreateUsers,
+		updateProfiles:    directoryCfg.UpdateProfiles,
+		dryRun:            directoryCfg.DryRun,
+	}
+}
+
+// Reconcile compares the users of the directory email domains against the directory
+func (uc *directoryUseCase) Reconcile(ctx context.Context, actorID uuid.UUID, dryRun bool) (*entity.DirectoryDriftReport, error) {
+	if uc.directory == nil || len(uc.domains) == 0 {
+		return nil, ErrDirectoryNotConfigured
+	}
+
+	// Fail open like the scheduled claim, overlapping runs find the changes of each other already made
+	locked, err := uc.dedupRepo.Claim(ctx, directorySyncLockScope, "", directorySyncLockTimeout)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to lock directory reconciliation")
+	} else if !locked {
+		return nil, ErrDirectorySyncRunning
+	} else {
+		defer func() {
+			if err := uc.dedupRepo.Release(context.WithoutCancel(ctx), directorySyncLockScope, ""); err != nil {
+				log.Warn().Err(err).Msg("Failed to unlock directory reconciliation")
+			}
+		}()
+	}
+
+	dryRun = dryRun || uc.dryRun
+	report := &entity.DirectoryDriftReport{
+		StartedAt: time.Now(),
+		DryRun:    dryRun && (uc.deactivateRemoved || uc.createUsers || uc.updateProfiles),
+		Drifts:    []entity.DirectoryDrift{},
+	}
+
+	entries, err := uc.directory.Entries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDirectoryUnavailable, err)
+	}
+	upstream := make(map[string]entity.DirectoryEntry, len(entries))
+	for _, entry := range entries {
+		if uc.inScope(entry.Email) {
+			upstream[entry.Email] = entry
+		}
+	}
+	if len(upstream) == 0 {
+		return nil, ErrDirectoryEmpty
+	}
+	report.Entries = len(upstream)
+
+	users, err := uc.scopedUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Users = len(users)
+
+	local := make(map[string]bool, len(users))
+	for _, user := range users {
+		local[user.Email] = true
+
+		entry, ok := upstream[user.Email]
+		switch {
+		case !ok || entry.Disabled:
+			// Only active users can still sign in, the others are already off
+			if user.Status != entity.UserStatusActive {
+				continue
+			}
+			drift := entity.DirectoryDrift{
+				Kind:   entity.DirectoryDriftRemoved,
+				UserID: &user.ID,
+				Email:  user.Email,
+			}
+			if ok {
+				drift.Kind = entity.DirectoryDriftDisabled
+				drift.DN = entry.DN
+			}
+			uc.deactivate(ctx, actorID, user, &drift, dryRun)
+			if drift.Action == entity.DirectoryActionDeactivated {
+				report.Deactivated++
+			}
+			report.Drifts = append(report.Drifts, drift)
+		default:
+			if fields := profileDrift(user, entry); len(fields) > 0 {
+				drift := entity.DirectoryDrift{
+					Kind:   entity.DirectoryDriftProfileMismatch,
+					UserID: &user.ID,
+					Email:  user.Email,
+					DN:     entry.DN,
+					Fields: fields,
+				}
+				uc.updateProfile(ctx, user, entry, &drift, dryRun)
+				if drift.Action == entity.DirectoryActionUpdated {
+					report.Updated++
+				}
+				report.Drifts = append(report.Drifts, drift)
+			}
+		}
+	}
+
+	for email, entry := range upstream {
+		if !local[email] && !entry.Disabled {
+			drift := entity.DirectoryDrift{
+				Kind:  entity.DirectoryDriftMissing,
+				Email: email,
+				DN:    entry.DN,
+			}
+			uc.create(ctx, actorID, entry, &drift, dryRun)
+			if drift.Action == entity.DirectoryActionCreated {
+				report.Created++
+			}
+			report.Drifts = append(report.Drifts, drift)
+		}
+	}
+
+	slices.SortFunc(report.Drifts, func(a, b entity.DirectoryDrift) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Email, b.Email))
+	})
+	report.FinishedAt = time.Now()
+
+	if err := uc.reportRepo.Store(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// LatestReport returns the drift report of the latest reconciliation
+func (uc *directoryUseCase) LatestReport(ctx context.Context) (*entity.DirectoryDriftReport, error) {
+	return uc.reportRepo.Latest(ctx)
+}
+
+// RunReconciliation reconciles the users at every interval until the context is cancelled
+func (uc *directoryUseCase) RunReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Claim the interval, the instances ticking within it skip their turn. Fail open, reconciling twice
+		// finds the users deactivated by the first pass already inactive.
+		window := strconv.FormatInt(time.Now().Truncate(interval).Unix(), 10)
+		claimed, err := uc.dedupRepo.Claim(ctx, directorySyncDedupScope, window, interval)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to claim directory reconciliation")
+		} else if !claimed {
+			continue
+		}
+
+		report, err := uc.Reconcile(ctx, uuid.Nil, false)
+		if err != nil {
+			if errors.Is(err, ErrDirectorySyncRunning) {
+				log.Info().Msg("Skipped directory reconciliation, one is already running")
+				continue
+			}
+			log.Error().Err(err).Msg("Failed to reconcile users against the directory")
+			continue
+		}
+		log.Info().
+			Int("entries", report.Entries).
+			Int("users", report.Users).
+			Int("drifts", len(report.Drifts)).
+			Int("created", report.Created).
+			Int("updated", report.Updated).
+			Int("deactivated", report.Deactivated).
+			Bool("dry_run", report.DryRun).
+			Msg("Reconciled users against the directory")
+	}
+}
+
+// scopedUsers returns the users of the directory email domains, except those pending deletion
+func (uc *directoryUseCase) scopedUsers(ctx context.Context) ([]*entity.User, error) {
+	var scoped []*entity.User
+	for page := 1; ; page++ {
+		users, total, err := uc.userRepo.List(ctx, page, directoryPageSize, entity.UserListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if user.Status != entity.UserStatusPendingDeletion && uc.inScope(user.Email) {
+				scoped = append(scoped, user)
+			}
+		}
+		if len(users) < directoryPageSize || int64(page*directoryPageSize) >= total {
+			return scoped, nil
+		}
+	}
+}
+
+// inScope reports whether the directory is authoritative for an email
+func (uc *directoryUseCase) inScope(email string) bool {
+	_, domain, ok := strings.Cut(email, "@")
+	return ok && slices.Contains(uc.domains, domain)
+}
+
+// deactivate deactivates a user who drifted from the directory when enabled, recording the action taken on the
+// drift
+func (uc *directoryUseCase) deactivate(ctx context.Context, actorID uuid.UUID, user *entity.User, drift *entity.DirectoryDrift, dryRun bool) {
+	if !uc.deactivateRemoved {
+		return
+	}
+	if dryRun {
+		drift.Action = entity.DirectoryActionWouldDeactivate
+		return
+	}
+
+	note := "Removed from the upstream directory"
+	if drift.Kind == entity.DirectoryDriftDisabled {
+		note = "Disabled in the upstream directory"
+	}
+	reason := entity.ActionReason{Code: entity.ReasonCodeOther, Note: note}
+	if err := uc.userUseCase.UpdateStatus(ctx, actorID, user.ID, entity.UserStatusInactive, reason); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to deactivate user removed from the directory")
+		drift.Action, drift.Error = entity.DirectoryActionDeactivateFailed, err.Error()
+		return
+	}
+	drift.Action = entity.DirectoryActionDeactivated
+}
+
+// updateProfile copies the names of a directory entry to its user when enabled, recording the action taken on the
+// drift
+func (uc *directoryUseCase) updateProfile(ctx context.Context, user *entity.User, entry entity.DirectoryEntry, drift *entity.DirectoryDrift, dryRun bool) {
+	if !uc.updateProfiles {
+		return
+	}
+	if dryRun {
+		drift.Action = entity.DirectoryActionWouldUpdate
+		return
+	}
+
+	// Names the entry leaves empty are kept
+	profile := entity.UserProfile{
+		FirstName: cmp.Or(entry.FirstName, user.FirstName),
+		LastName:  cmp.Or(entry.LastName, user.LastName),
+	}
+	if _, err := uc.userUseCase.Update(ctx, user.ID, profile); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to update user from the directory")
+		drift.Action, drift.Error = entity.DirectoryActionUpdateFailed, err.Error()
+		return
+	}
+	drift.Action = entity.DirectoryActionUpdated
+}
+
+// create invites the user of a directory entry without a user when enabled, recording the action taken on the
+// drift. The invitation lets them set a password, the entry keeps deciding whether they may sign in.
+func (uc *directoryUseCase) create(ctx context.Context, actorID uuid.UUID, entry entity.DirectoryEntry, drift *entity.DirectoryDrift, dryRun bool) {
+	if !uc.createUsers {
+		return
+	}
+	if dryRun {
+		drift.Action = entity.DirectoryActionWouldCreate
+		return
+	}
+
+	username, err := freeUsername(ctx, uc.userRepo, entry.Username, entry.Email)
+	if err == nil {
+		var user *entity.User
+		user, err = uc.invitationUseCase.Invite(ctx, actorID, entry.Email, username, entry.FirstName, entry.LastName, "", nil)
+		// The user exists even if the invitation email failed, it can be resent
+		if user != nil && (err == nil || errors.Is(err, ErrInvitationNotSent)) {
+			if err != nil {
+				log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send invitation to user imported from the directory")
+			}
+			drift.UserID = &user.ID
+			drift.Action = entity.DirectoryActionCreated
+			return
+		}
+	}
+	log.Error().Err(err).Str("dn", entry.DN).Msg("Failed to create user from the directory")
+	drift.Action, drift.Error = entity.DirectoryActionCreateFailed, err.Error()
+}
+
+// profileDrift returns the profile fields of a user differing from their directory entry, fields the entry
+// leaves empty are not compared
+func profileDrift(user *entity.User, entry entity.DirectoryEntry) []string {
+	var fields []string
+	if entry.FirstName != "" && entry.FirstName != user.FirstName {
+		fields = append(fields, entity.ProfileFieldFirstName)
+	}
+	if entry.LastName != "" && entry.LastName != user.LastName {
+		fields = append(fields, entity.ProfileFieldLastName)
+	}
+	return fields
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/chats/go-user-api/internal/infrastructure/ratelimit"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrAccountLocked is returned when signing in to an account with too many recent failed logins
+	ErrAccountLocked = errors.New("account locked")
+)
+
+// violationAuditInterval is the minimum interval between two audit entries of a shadowed policy for the same subject,
+// so a caller hammering an endpoint does not flood the audit trail
+const violationAuditInterval = time.Minute
+
+// EnforcementUseCase defines the use case applying rate limit and lockout policies
+type EnforcementUseCase interface {
+	// Mode returns how a policy is applied
+	Mode(policy string) config.PolicyMode
+
+	// Enforce records a request of subject exceeding a policy and reports whether it must be rejected.
+	// userID is the user behind the subject, uuid.Nil for anonymous callers.
+	Enforce(ctx context.Context, policy, subject string, userID uuid.UUID, result ratelimit.Result) bool
+
+	// CheckLockout returns ErrAccountLocked when the user has too many recent failed logins and the lockout is enforced
+	CheckLockout(ctx context.Context, userID uuid.UUID) error
+
+	// RecordFailedLogin counts a failed login of the user towards the lockout and reports it to the SIEM
+	RecordFailedLogin(ctx context.Context, userID uuid.UUID)
+
+	// ClearFailedLogins forgets the failed logins of the user after a successful login
+	ClearFailedLogins(ctx context.Context, userID uuid.UUID)
+
+	// AllowPasswordReset counts a password reset request of the user and reports whether a reset email may be sent
+	AllowPasswordReset(ctx context.Context, userID uuid.UUID) bool
+}
+
+// enforcementUseCase implements EnforcementUseCase interface
+type enforcementUseCase struct {
+	auditRepo            repository.AuditRepository
+	securityEventUseCase SecurityEventUseCase
+	limiter              ratelimit.Limiter
+	rateLimit            config.RateLimitConfig
+	lockout              config.LockoutConfig
+	passwordReset        config.PasswordResetConfig
+}
+
+// NewEnforcementUseCase creates a new EnforcementUseCase
+func NewEnforcementUseCase(
+	auditRepo repository.AuditRepository,
+	securityEventUseCase SecurityEventUseCase,
+	limiter ratelimit.Limiter,
+	rateLimit config.RateLimitConfig,
+	lockout config.LockoutConfig,
+	passwordReset config.PasswordResetConfig,
+) EnforcementUseCase {
+	return &enforcementUseCase{
+		auditRepo:            auditRepo,
+		securityEventUseCase: securityEventUseCase,
+		limiter:              limiter,
+		rateLimit:            rateLimit,
+		lockout:              lockout,
+		passwordReset:        passwordReset,
+	}
+}
+
+// Mode returns how a policy is applied
+func (uc *enforcementUseCase) Mode(policy string) config.PolicyMode {
+	var mode config.PolicyMode
+	switch policy {
+	case entity.EnforcementPolicyRateLimit:
+		mode = uc.rateLimit.Mode
+	case entity.EnforcementPolicyRateLimitAuth:
+		mode = uc.rateLimit.AuthMode
+	case entity.EnforcementPolicyRateLimitGRPCClient, entity.EnforcementPolicyRateLimitGRPCUser:
+		mode = uc.rateLimit.GRPCMode
+	case entity.EnforcementPolicyLoginThrottle, entity.EnforcementPolicyRegisterThrottle:
+		mode = uc.rateLimit.ThrottleMode
+	case entity.EnforcementPolicyLoginLockout:
+		mode = uc.lockout.Mode
+	case entity.EnforcementPolicyPasswordReset:
+		mode = uc.passwordReset.Mode
+	}
+
+	// Anything but an explicit shadow mode is enforced, a typo must not disable a policy
+	if mode == config.PolicyModeShadow {
+		return config.PolicyModeShadow
+	}
+	return config.PolicyModeEnforce
+}
+
+// Enforce records a request of subject exceeding a policy and reports whether it must be rejected
+func (uc *enforcementUseCase) Enforce(ctx context.Context, policy, subject string, userID uuid.UUID, result ratelimit.Result) bool {
+	mode := uc.Mode(policy)
+	metrics.PolicyViolations.WithLabelValues(policy, string(mode)).Inc()
+
+	if mode == config.PolicyModeEnforce {
+		return true
+	}
+
+	log.Warn().
+		Str("policy", policy).
+		Str("subject", subject).
+		Int("limit", result.Limit).
+		Int("count", result.Count).
+		Msg("Policy would have rejected the request, shadow mode")
+
+	// Record the first violation of each interval only
+	throttle, err := uc.limiter.Allow(ctx, "violation:"+policy+":"+subject, 1, violationAuditInterval)
+	if err != nil {
+		log.Warn().Err(err).Str("policy", policy).Msg("Failed to throttle policy violation audit entries")
+		return false
+	}
+	if !throttle.Allowed {
+		return false
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionPolicyViolation, uuid.Nil, userID, map[string]string{
+		"policy":  policy,
+		"mode":    string(mode),
+		"subject": subject,
+		"limit":   strconv.Itoa(result.Limit),
+		"count":   strconv.Itoa(result.Count),
+		"reset":   result.Reset.Format(time.RFC3339),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("policy", policy).Msg("Failed to record policy violation in audit trail")
+	}
+
+	return false
+}
+
+// CheckLockout returns ErrAccountLocked when the user has too many recent failed logins and the lockout is enforced
+func (uc *enforcementUseCase) CheckLockout(ctx context.Context, userID uuid.UUID) error {
+	if uc.lockout.MaxFailedLogins <= 0 {
+		return nil
+	}
+
+	result, err := uc.limiter.Peek(ctx, lockoutKey(userID), uc.lockout.MaxFailedLogins, uc.lockout.Window)
+	if err != nil {
+		// Fail open, losing the limiter must not lock everyone out
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to check account lockout")
+		return nil
+	}
+	if result.Allowed {
+		return nil
+	}
+
+	if uc.Enforce(ctx, entity.EnforcementPolicyLoginLockout, "user:"+userID.String(), userID, result) {
+		uc.securityEventUseCase.Record(ctx, entity.NewSecurityEvent(entity.SecurityEventAccountLocked, entity.SecuritySeverityHigh, uuid.Nil, userID, map[string]string{
+			"failed_logins": strconv.Itoa(result.Count),
+		}))
+		return ErrAccountLocked
+	}
+	return nil
+}
+
+// RecordFailedLogin counts a failed login of the user towards the lockout
+func (uc *enforcementUseCase) RecordFailedLogin(ctx context.Context, userID uuid.UUID) {
+	uc.securityEventUseCase.Record(ctx, entity.NewSecurityEvent(entity.SecurityEventLoginFailed, entity.SecuritySeverityLow, uuid.Nil, userID, nil))
+
+	if uc.lockout.MaxFailedLogins <= 0 {
+		return
+	}
+
+	if _, err := uc.limiter.Allow(ctx, lockoutKey(userID), uc.lockout.MaxFailedLogins, uc.lockout.Window); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to record failed login")
+	}
+}
+
+// ClearFailedLogins forgets the failed logins of the user after a successful login
+func (uc *enforcementUseCase) ClearFailedLogins(ctx context.Context, userID uuid.UUID) {
+	if uc.lockout.MaxFailedLogins <= 0 {
+		return
+	}
+
+	if err := uc.limiter.Reset(ctx, lockoutKey(userID), uc.lockout.Window); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to clear failed logins")
+	}
+}
+
+// AllowPasswordReset counts a password reset request of the user and reports whether a reset email may be sent
+func (uc *enforcementUseCase) AllowPasswordReset(ctx context.Context, userID uuid.UUID) bool {
+	if uc.passwordReset.MaxRequests <= 0 {
+		return true
+	}
+
+	result, err := uc.limiter.Allow(ctx, "password_reset:user:"+userID.String(), uc.passwordReset.MaxRequests, uc.passwordReset.Window)
+	if err != nil {
+		// Fail open, losing the limiter must not prevent account recovery
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to count password reset request")
+		return true
+	}
+	if result.Allowed {
+		return true
+	}
+
+	return !uc.Enforce(ctx, entity.EnforcementPolicyPasswordReset, "user:"+userID.String(), userID, result)
+}
+
+// lockoutKey returns the limiter key counting the failed logins of a user
+func lockoutKey(userID uuid.UUID) string {
+	return "lockout:user:" + userID.String()
+}
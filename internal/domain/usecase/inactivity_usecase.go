@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrInactivityPolicyRunning is returned when the inactivity policy is already being applied on any instance
+var ErrInactivityPolicyRunning = errors.New("inactivity policy already running")
+
+const (
+	// inactivityPageSize is the number of users read at a time while applying the policy
+	inactivityPageSize = 100
+
+	// inactivityDedupScope is the dedup scope of the inactivity job, keyed by interval, so instances apply the
+	// policy once per interval between them
+	inactivityDedupScope = "inactivity"
+
+	// inactivityLockScope is the dedup scope of the lock held while the policy is applied, so scheduled and manual
+	// runs do not overlap
+	inactivityLockScope = "inactivity_run"
+
+	// inactivityLockTimeout bounds the lock of a run, in case its instance stops before releasing it
+	inactivityLockTimeout = 15 * time.Minute
+)
+
+// InactivityUseCase defines the use case for the inactivity policy, flagging or deactivating the accounts nobody
+// signed in to for a long time after warning their users
+type InactivityUseCase interface {
+	// Enforce warns the active users about to reach the inactivity limit, applies the action of the policy to those
+	// who reached it and were given notice, then stores and returns the report of the run
+	Enforce(ctx context.Context, actorID uuid.UUID) (*entity.InactivityReport, error)
+
+	// LatestReport returns the report of the latest run, nil if the policy never ran
+	LatestReport(ctx context.Context) (*entity.InactivityReport, error)
+
+	// RunPolicy enforces the policy at every interval until the context is cancelled
+	RunPolicy(ctx context.Context, interval time.Duration)
+}
+
+// inactivityUseCase implements InactivityUseCase interface
+type inactivityUseCase struct {
+	userRepo            repository.UserRepository
+	inactivityRepo      repository.InactivityRepository
+	dedupRepo           repository.DedupRepository
+	userUseCase         UserUseCase
+	notificationUseCase NotificationUseCase
+
+	after       time.Duration
+	warnBefore  time.Duration
+	action      string
+	exemptRoles []string
+}
+
+// NewInactivityUseCase creates a new InactivityUseCase. The warning cannot come before the inactivity starts, so
+// the notice is capped at the inactivity limit.
+func NewInactivityUseCase(
+	userRepo repository.UserRepository,
+	inactivityRepo repository.InactivityRepository,
+	dedupRepo repository.DedupRepository,
+	userUseCase UserUseCase,
+	notificationUseCase NotificationUseCase,
+	inactivityCfg config.InactivityConfig,
+) InactivityUseCase {
+	action := entity.InactivityActionFlag
+	if inactivityCfg.Action == entity.InactivityActionDeactivate {
+		action = entity.InactivityActionDeactivate
+	}
+
+	return &inactivityUseCase{
+		userRepo:            userRepo,
+		inactivityRepo:      inactivityRepo,
+		dedupRepo:           dedupRepo,
+		userUseCase:         userUseCase,
+		notificationUseCase: notificationUseCase,
+		after:               inactivityCfg.After,
+		warnBefore:          min(inactivityCfg.WarnBefore, inactivityCfg.After),
+		action:              action,
+		exemptRoles:         inactivityCfg.ExemptRoles,
+	}
+}
+
+// Enforce goes over the active users inactive for longer than the limit minus the notice, the least recently
+// active first
+func (uc *inactivityUseCase) Enforce(ctx context.Context, actorID uuid.UUID) (*entity.InactivityReport, error) {
+	// Fail open like the scheduled claim, overlapping runs find the users already warned and acted on
+	locked, err := uc.dedupRepo.Claim(ctx, inactivityLockScope, "", inactivityLockTimeout)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to lock inactivity policy")
+	} else if !locked {
+		return nil, ErrInactivityPolicyRunning
+	} else {
+		defer func() {
+			if err := uc.dedupRepo.Release(context.WithoutCancel(ctx), inactivityLockScope, ""); err != nil {
+				log.Warn().Err(err).Msg("Failed to unlock inactivity policy")
+			}
+		}()
+	}
+
+	now := time.Now()
+	report := &entity.InactivityReport{
+		StartedAt: now,
+		Action:    uc.action,
+	}
+
+	// A policy without a limit acts on nobody
+	from, to := time.Time{}, time.Time{}
+	if uc.after > 0 {
+		to = now.Add(-(uc.after - uc.warnBefore))
+	}
+	for from.Before(to) {
+		users, err := uc.userRepo.ListLastActiveBetween(ctx, from, to, inactivityPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if user.Status != entity.UserStatusActive || slices.Contains(uc.exemptRoles, user.Role) {
+				continue
+			}
+			report.Dormant++
+			if err := uc.enforce(ctx, actorID, user, now, report); err != nil {
+				return nil, err
+			}
+		}
+		if len(users) < inactivityPageSize {
+			break
+		}
+
+		// The next page starts at the last user, applying the policy twice to them changes nothing. Times are
+		// stored to the millisecond, a page of users sharing one moves on past it.
+		next := users[len(users)-1].LastActivity()
+		if !next.After(from) {
+			next = from.Add(time.Millisecond)
+		}
+		from = next
+	}
+	report.FinishedAt = time.Now()
+
+	if err := uc.inactivityRepo.StoreReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// enforce warns a dormant user unless they were already, then applies the action once they reached the limit and
+// their notice expired. Users inactive for longer than the limit when first warned still get the full notice.
+// Failed warnings and actions are counted in the report and tried again by the next run.
+func (uc *inactivityUseCase) enforce(ctx context.Context, actorID uuid.UUID, user *entity.User, now time.Time, report *entity.InactivityReport) error {
+	if uc.action == entity.InactivityActionFlag && slices.Contains(user.Tags, entity.UserTagDormant) {
+		return nil
+	}
+
+	inactiveSince := user.LastActivity()
+	deadline := inactiveSince.Add(uc.after)
+	if uc.warnBefore > 0 {
+		warnedAt, err := uc.inactivityRepo.WarnedAt(ctx, user.ID, inactiveSince)
+		if err != nil {
+			return err
+		}
+		if warnedAt.IsZero() {
+			if notice := now.Add(uc.warnBefore); deadline.Before(notice) {
+				deadline = notice
+			}
+			if err := uc.notificationUseCase.SendInactivityWarning(ctx, user, uc.action, deadline); err != nil {
+				log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send inactivity warning")
+				report.Failed++
+				return nil
+			}
+			report.Warned++
+			// The warning is kept past the deadline, a user active again is warned anew
+			return uc.inactivityRepo.SetWarnedAt(ctx, user.ID, inactiveSince, now, uc.after+uc.warnBefore)
+		}
+		if notice := warnedAt.Add(uc.warnBefore); deadline.Before(notice) {
+			deadline = notice
+		}
+	}
+	if now.Before(deadline) {
+		return nil
+	}
+
+	switch uc.action {
+	case entity.InactivityActionDeactivate:
+		reason := entity.ActionReason{Code: entity.ReasonCodeOther, Note: "Inactive for " + uc.after.String()}
+		if err := uc.userUseCase.UpdateStatus(ctx, actorID, user.ID, entity.UserStatusInactive, reason); err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to deactivate inactive user")
+			report.Failed++
+			return nil
+		}
+		report.Deactivated++
+	default:
+		if _, err := uc.userUseCase.AddTags(ctx, actorID, user.ID, []string{entity.UserTagDormant}); err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to flag inactive user")
+			report.Failed++
+			return nil
+		}
+		report.Flagged++
+	}
+	return nil
+}
+
+// LatestReport returns the report of the latest run
+func (uc *inactivityUseCase) LatestReport(ctx context.Context) (*entity.InactivityReport, error) {
+	return uc.inactivityRepo.LatestReport(ctx)
+}
+
+// RunPolicy enforces the policy at every interval until the context is cancelled
+func (uc *inactivityUseCase) RunPolicy(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Claim the interval, the instances ticking within it skip their turn. Fail open, the warnings sent are
+		// remembered and acted on users are left out of the next runs.
+		window := strconv.FormatInt(time.Now().Truncate(interval).Unix(), 10)
+		claimed, err := uc.dedupRepo.Claim(ctx, inactivityDedupScope, window, interval)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to claim inactivity policy")
+		} else if !claimed {
+			continue
+		}
+
+		report, err := uc.Enforce(ctx, uuid.Nil)
+		if err != nil {
+			if errors.Is(err, ErrInactivityPolicyRunning) {
+				log.Info().Msg("Skipped inactivity policy, a run is already in progress")
+				continue
+			}
+			log.Error().Err(err).Msg("Failed to apply inactivity policy")
+			continue
+		}
+		log.Info().
+			Str("action", report.Action).
+			Int("dormant", report.Dormant).
+			Int("warned", report.Warned).
+			Int("flagged", report.Flagged).
+			Int("deactivated", report.Deactivated).
+			Int("failed", report.Failed).
+			Msg("Applied inactivity policy")
+	}
+}
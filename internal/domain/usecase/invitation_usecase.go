@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrInvalidInvitationToken is returned when an invitation token is unknown or expired
+	ErrInvalidInvitationToken = errors.New("invalid invitation token")
+
+	// ErrNotInvited is returned when resending the invitation of a user who already accepted it
+	ErrNotInvited = errors.New("user is not invited")
+
+	// ErrTermsNotAccepted is returned when accepting an invitation without accepting the terms
+	ErrTermsNotAccepted = errors.New("terms not accepted")
+
+	// ErrInvitationNotSent is returned along with the created user when the invitation email failed
+	ErrInvitationNotSent = errors.New("invitation not sent")
+)
+
+// InvitationUseCase defines the use case for users created by administrators
+type InvitationUseCase interface {
+	// Invite creates a user without a password and emails them an invitation to set it.
+	// If only the email fails, the user is returned with ErrInvitationNotSent and the invitation can be resent.
+	// The user joins the organization when set, within its member limit.
+	Invite(ctx context.Context, actorID uuid.UUID, email, username, firstName, lastName, role string, orgID *uuid.UUID) (*entity.User, error)
+
+	// Resend emails a new invitation to a user who has not accepted theirs yet
+	Resend(ctx context.Context, actorID, id uuid.UUID) error
+
+	// Accept sets the password of an invited user and activates the account, the terms must be accepted
+	Accept(ctx context.Context, token, password string, acceptTerms bool) (*entity.User, error)
+}
+
+// invitationUseCase implements InvitationUseCase interface
+type invitationUseCase struct {
+	userRepo            repository.UserRepository
+	tokenRepo           repository.TokenRepository
+	auditRepo           repository.AuditRepository
+	notificationUseCase NotificationUseCase
+	roleUseCase         RoleUseCase
+	organizationUseCase OrganizationUseCase
+	eventService        service.EventService
+	passwordService     service.PasswordService
+	passwordHasher      service.PasswordHasher
+	statusHistoryRepo   repository.StatusHistoryRepository
+	expiration          time.Duration
+}
+
+// NewInvitationUseCase creates a new InvitationUseCase
+func NewInvitationUseCase(
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	auditRepo repository.AuditRepository,
+	notificationUseCase NotificationUseCase,
+	roleUseCase RoleUseCase,
+	organizationUseCase OrganizationUseCase,
+	eventService service.EventService,
+	passwordService service.PasswordService,
+	passwordHasher service.PasswordHasher,
+	statusHistoryRepo repository.StatusHistoryRepository,
+	cfg config.InvitationConfig,
+) InvitationUseCase {
+	return &invitationUseCase{
+		userRepo:            userRepo,
+		tokenRepo:           tokenRepo,
+		auditRepo:           auditRepo,
+		notificationUseCase: notificationUseCase,
+		roleUseCase:         roleUseCase,
+		organizationUseCase: organizationUseCase,
+		eventService:        eventService,
+		passwordService:     passwordService,
+		passwordHasher:      passwordHasher,
+		statusHistoryRepo:   statusHistoryRepo,
+		expiration:          cfg.Expiration,
+	}
+}
+
+// Invite creates a user without a password and emails them an invitation to set it
+func (uc *invitationUseCase) Invite(ctx context.Context, actorID uuid.UUID, email, username, firstName, lastName, role string, orgID *uuid.UUID) (*entity.User, error) {
+	if !entity.IsValidUsername(username) {
+		return nil, ErrInvalidUsername
+	}
+
+	if role == "" {
+		role = entity.UserRoleUser
+	}
+
+	// Validate role against the built-in and custom roles
+	if _, err := uc.roleUseCase.GetRole(ctx, role); err != nil {
+		if errors.Is(err, ErrRoleNotFound) {
+			return nil, ErrInvalidRole
+		}
+		return nil, err
+	}
+
+	// Check if email already exists
+	email = entity.NormalizeEmail(email)
+	existingUser, err := uc.userRepo.GetByEmail(ctx, email)
+	if err == nil && existingUser != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	// Check if username already exists
+	existingUser, err = uc.userRepo.GetByUsername(ctx, username)
+	if err == nil && existingUser != nil {
+		return nil, ErrUsernameAlreadyExists
+	}
+
+	var org *entity.Organization
+	if orgID != nil {
+		if org, err = uc.organizationUseCase.GetOrganization(ctx, *orgID); err != nil {
+			return nil, err
+		}
+		if err := uc.organizationUseCase.CheckMemberLimit(ctx, org); err != nil {
+			return nil, err
+		}
+	}
+
+	user := entity.NewInvitedUser(email, username, firstName, lastName, role)
+	if org != nil {
+		user.OrgID = &org.ID
+	}
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, actorID, "", user.Status, entity.ActionReason{}))
+	uc.recordAction(ctx, entity.AuditActionUserInvited, actorID, user, map[string]string{
+		"role": role,
+	})
+	publishEvent(ctx, uc.eventService, entity.EventUserCreated, userCreatedEvent(user))
+	if org != nil {
+		uc.organizationUseCase.MemberAdded(ctx, org)
+	}
+
+	// The account exists even if the email fails, the invitation can be resent
+	if err := uc.send(ctx, user); err != nil {
+		return user, fmt.Errorf("%w: %v", ErrInvitationNotSent, err)
+	}
+
+	return user, nil
+}
+
+// Resend emails a new invitation to a user who has not accepted theirs yet
+func (uc *invitationUseCase) Resend(ctx context.Context, actorID, id uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.Status != entity.UserStatusInvited {
+		return ErrNotInvited
+	}
+
+	if err := uc.send(ctx, user); err != nil {
+		return err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionInvitationResent, actorID, user, nil)
+	return nil
+}
+
+// Accept sets the password of an invited user and activates the account, the terms must be accepted
+func (uc *invitationUseCase) Accept(ctx context.Context, token, password string, acceptTerms bool) (*entity.User, error) {
+	// Check the terms and the password first, so a refusal does not consume the token
+	if !acceptTerms {
+		return nil, ErrTermsNotAccepted
+	}
+	if err := uc.passwordService.Check(ctx, password); err != nil {
+		return nil, err
+	}
+
+	userID, err := uc.tokenRepo.ConsumeOneTimeToken(ctx, entity.OneTimeTokenInvitation, token)
+	if err != nil {
+		return nil, err
+	}
+	if userID == uuid.Nil {
+		return nil, ErrInvalidInvitationToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// An administrator may have blocked or deleted the account since the invitation was sent
+	if user == nil || user.Status != entity.UserStatusInvited {
+		return nil, ErrInvalidInvitationToken
+	}
+
+	hashedPassword, err := uc.passwordHasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.ChangePassword(ctx, user.ID, hashedPassword); err != nil {
+		return nil, err
+	}
+
+	// The invitation reached the user's mailbox, which verifies the email
+	now := time.Now()
+	user.Password = hashedPassword
+	user.Status = entity.UserStatusActive
+	user.EmailVerified = true
+	user.TermsAcceptedAt = &now
+	user.UpdatedAt = now
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, user.ID, entity.UserStatusInvited, user.Status, entity.ActionReason{}))
+	uc.recordAction(ctx, entity.AuditActionInvitationAccepted, user.ID, user, nil)
+	publishEvent(ctx, uc.eventService, entity.EventUserStatusChanged, &entity.UserStatusChangedEvent{
+		UserID:         user.ID,
+		ActorID:        user.ID,
+		PreviousStatus: entity.UserStatusInvited,
+		Status:         user.Status,
+	})
+	return user, nil
+}
+
+// send issues an invitation token and emails it to the user
+func (uc *invitationUseCase) send(ctx context.Context, user *entity.User) error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	if err := uc.tokenRepo.StoreOneTimeToken(ctx, entity.OneTimeTokenInvitation, token, user.ID, uc.expiration); err != nil {
+		return err
+	}
+
+	if err := uc.notificationUseCase.SendInvitation(ctx, user, token, time.Now().Add(uc.expiration)); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send invitation")
+		return fmt.Errorf("failed to send invitation: %w", err)
+	}
+
+	return nil
+}
+
+// recordAction records an invitation action in the audit trail.
+// The action has already been applied, so failures are logged rather than returned.
+func (uc *invitationUseCase) recordAction(ctx context.Context, action string, actorID uuid.UUID, user *entity.User, details map[string]string) {
+	entry := entity.NewAuditEntry(action, actorID, user.ID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("user_id", user.ID.String()).Msg("Failed to record invitation in audit trail")
+	}
+}
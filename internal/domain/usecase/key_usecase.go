@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrKeyRotationDisabled is returned when rotating signing keys without SIGNING_KEY_ENCRYPTION_KEY
+	ErrKeyRotationDisabled = errors.New("signing key rotation is disabled")
+)
+
+// KeyUseCase defines the use case for token signing keys
+type KeyUseCase interface {
+	// Enabled reports whether runtime key rotation is configured
+	Enabled() bool
+
+	// Rotate generates a new signing key and starts signing with it, the actor must confirm their password
+	Rotate(ctx context.Context, actorID uuid.UUID, password string) (string, error)
+
+	// Sync loads the stored signing keys into the token service and activates the newest one
+	Sync(ctx context.Context) error
+
+	// Run syncs the signing keys periodically so keys rotated by other instances are picked up
+	Run(ctx context.Context, interval time.Duration)
+
+	// KeySet returns the public keys accepted for token verification
+	KeySet() *entity.JSONWebKeySet
+}
+
+// keyUseCase implements KeyUseCase interface
+type keyUseCase struct {
+	signingKeyRepo     repository.SigningKeyRepository
+	userRepo           repository.UserRepository
+	auditRepo          repository.AuditRepository
+	tokenService       service.TokenService
+	passwordHasher     service.PasswordHasher
+	enforcementUseCase EnforcementUseCase
+	encryptionKey      []byte
+}
+
+// NewKeyUseCase creates a new KeyUseCase
+func NewKeyUseCase(
+	signingKeyRepo repository.SigningKeyRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	tokenService service.TokenService,
+	passwordHasher service.PasswordHasher,
+	enforcementUseCase EnforcementUseCase,
+	cfg config.SecurityConfig,
+) (KeyUseCase, error) {
+	var encryptionKey []byte
+	if cfg.SigningKeyEncryptionKey != "" {
+		key, err := hex.DecodeString(cfg.SigningKeyEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signing key encryption key: %w", err)
+		}
+		if len(key) != 32 {
+			return nil, errors.New("signing key encryption key must be 32 bytes")
+		}
+		encryptionKey = key
+	}
+
+	return &keyUseCase{
+		signingKeyRepo:     signingKeyRepo,
+		userRepo:           userRepo,
+		auditRepo:          auditRepo,
+		tokenService:       tokenService,
+		passwordHasher:     passwordHasher,
+		enforcementUseCase: enforcementUseCase,
+		encryptionKey:      encryptionKey,
+	}, nil
+}
+
+// Enabled reports whether runtime key rotation is configured
+func (uc *keyUseCase) Enabled() bool {
+	return uc.encryptionKey != nil
+}
+
+// Rotate generates a new signing key and starts signing with it, the actor must confirm their password
+func (uc *keyUseCase) Rotate(ctx context.Context, actorID uuid.UUID, password string) (string, error) {
+	if !uc.Enabled() {
+		return "", ErrKeyRotationDisabled
+	}
+
+	// Re-authenticate the actor, a stolen access token alone must not be enough to rotate keys
+	actor, err := uc.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return "", err
+	}
+	if actor == nil {
+		return "", ErrInvalidCredentials
+	}
+
+	// Confirming the password counts towards the lockout like a sign in, so it cannot be guessed here instead
+	if err := uc.enforcementUseCase.CheckLockout(ctx, actor.ID); err != nil {
+		return "", err
+	}
+	if !uc.passwordHasher.Verify(password, actor.Password) {
+		uc.enforcementUseCase.RecordFailedLogin(ctx, actor.ID)
+		return "", ErrInvalidCredentials
+	}
+	uc.enforcementUseCase.ClearFailedLogins(ctx, actor.ID)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	// Keys go to the signing key repository rather than the secrets backend, which is write-only and local to the
+	// instance: every instance must read the private key to sign with it after a sync
+	encryptedPrivateKey, err := utils.Encrypt(uc.encryptionKey, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	key := &entity.SigningKey{
+		ID:                  uuid.New().String(),
+		PublicKey:           publicKey,
+		EncryptedPrivateKey: encryptedPrivateKey,
+		CreatedAt:           time.Now(),
+	}
+
+	// Persist the key before signing with it, so tokens never outlive the key verifying them
+	if err := uc.signingKeyRepo.Create(ctx, key); err != nil {
+		return "", err
+	}
+	uc.tokenService.AddSigningKey(key.ID, privateKey, true)
+
+	entry := entity.NewAuditEntry(entity.AuditActionSigningKeyRotated, actorID, uuid.Nil, map[string]string{
+		"kid": key.ID,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("kid", key.ID).Msg("Failed to record signing key rotation in audit trail")
+	}
+
+	log.Info().Str("kid", key.ID).Str("actor_id", actorID.String()).Msg("Signing key rotated")
+	return key.ID, nil
+}
+
+// Sync loads the stored signing keys into the token service and activates the newest one
+func (uc *keyUseCase) Sync(ctx context.Context) error {
+	if !uc.Enabled() {
+		return nil
+	}
+
+	keys, err := uc.signingKeyRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		privateKey, err := utils.Decrypt(uc.encryptionKey, key.EncryptedPrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt signing key %s: %w", key.ID, err)
+		}
+		// Keys are listed oldest first, the last one is the active key
+		uc.tokenService.AddSigningKey(key.ID, ed25519.PrivateKey(privateKey), i == len(keys)-1)
+	}
+
+	return nil
+}
+
+// Run syncs the signing keys periodically so keys rotated by other instances are picked up
+func (uc *keyUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.Sync(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to sync signing keys")
+			}
+		}
+	}
+}
+
+// KeySet returns the public keys accepted for token verification
+func (uc *keyUseCase) KeySet() *entity.JSONWebKeySet {
+	publicKeys := uc.tokenService.PublicKeys()
+
+	keyIDs := make([]string, 0, len(publicKeys))
+	for keyID := range publicKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+
+	set := &entity.JSONWebKeySet{Keys: make([]entity.JSONWebKey, 0, len(keyIDs))}
+	for _, keyID := range keyIDs {
+		set.Keys = append(set.Keys, entity.JSONWebKey{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(publicKeys[keyID]),
+			KeyID:     keyID,
+			Use:       "sig",
+			Algorithm: "EdDSA",
+		})
+	}
+	return set
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// kpiDedupScope is the dedup scope of the KPI aggregation, keyed by interval, so instances aggregate once per
+	// interval between them
+	kpiDedupScope = "kpi"
+
+	// kpiSignupWindow is the period the daily signups are counted over
+	kpiSignupWindow = 24 * time.Hour
+)
+
+// KPIUseCase defines the use case for the business metrics, aggregated over the users by one instance and exported
+// by all of them
+type KPIUseCase interface {
+	// Aggregate counts the users and stores the snapshot shared by the instances
+	Aggregate(ctx context.Context) (*entity.KPISnapshot, error)
+
+	// RunExport refreshes the exported metrics at every interval until the context is cancelled, aggregating them
+	// again once the shared snapshot is older than the interval
+	RunExport(ctx context.Context, interval time.Duration)
+}
+
+// kpiUseCase implements KPIUseCase interface
+type kpiUseCase struct {
+	userRepo    repository.UserRepository
+	passkeyRepo repository.PasskeyRepository
+	kpiRepo     repository.KPIRepository
+	dedupRepo   repository.DedupRepository
+}
+
+// NewKPIUseCase creates a new KPIUseCase
+func NewKPIUseCase(
+	userRepo repository.UserRepository,
+	passkeyRepo repository.PasskeyRepository,
+	kpiRepo repository.KPIRepository,
+	dedupRepo repository.DedupRepository,
+) KPIUseCase {
+	return &kpiUseCase{
+		userRepo:    userRepo,
+		passkeyRepo: passkeyRepo,
+		kpiRepo:     kpiRepo,
+		dedupRepo:   dedupRepo,
+	}
+}
+
+// Aggregate counts the users by status and role, the signups of the last day and the users holding a passkey
+func (uc *kpiUseCase) Aggregate(ctx context.Context) (*entity.KPISnapshot, error) {
+	now := time.Now()
+	users, err := uc.userRepo.CountByStatusAndRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signups, err := uc.userRepo.CountCreatedSince(ctx, now.Add(-kpiSignupWindow))
+	if err != nil {
+		return nil, err
+	}
+	passkeyUsers, err := uc.passkeyRepo.CountUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &entity.KPISnapshot{
+		AggregatedAt: now,
+		Users:        users,
+		DailySignups: signups,
+		PasskeyUsers: passkeyUsers,
+	}
+	for _, count := range users {
+		if count.Status == entity.UserStatusActive {
+			snapshot.ActiveUsers += count.Count
+		}
+	}
+
+	if err := uc.kpiRepo.StoreSnapshot(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// RunExport refreshes the exported metrics right away, then at every interval until the context is cancelled
+func (uc *kpiUseCase) RunExport(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		uc.refresh(ctx, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh exports the shared snapshot, aggregated again first when older than the interval. The instance claiming
+// the interval aggregates, the others keep exporting the previous snapshot until the next tick.
+func (uc *kpiUseCase) refresh(ctx context.Context, interval time.Duration) {
+	snapshot, err := uc.kpiRepo.LatestSnapshot(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to get KPI snapshot")
+	}
+
+	if snapshot == nil || time.Since(snapshot.AggregatedAt) >= interval {
+		// Fail open, aggregating twice only costs the queries
+		window := strconv.FormatInt(time.Now().Truncate(interval).Unix(), 10)
+		claimed, err := uc.dedupRepo.Claim(ctx, kpiDedupScope, window, interval)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to claim KPI aggregation")
+		}
+		if err != nil || claimed {
+			aggregated, err := uc.Aggregate(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to aggregate KPIs")
+			} else {
+				snapshot = aggregated
+			}
+		}
+	}
+
+	if snapshot != nil {
+		exportKPIs(snapshot)
+	}
+}
+
+// exportKPIs sets the business gauges from a snapshot, the combinations of status and role gone since the previous
+// snapshot are dropped
+func exportKPIs(snapshot *entity.KPISnapshot) {
+	metrics.BusinessUsers.Reset()
+	for _, count := range snapshot.Users {
+		metrics.BusinessUsers.WithLabelValues(count.Status, count.Role).Set(float64(count.Count))
+	}
+	metrics.BusinessActiveUsers.Set(float64(snapshot.ActiveUsers))
+	metrics.BusinessDailySignups.Set(float64(snapshot.DailySignups))
+	metrics.BusinessPasskeyUsers.Set(float64(snapshot.PasskeyUsers))
+
+	adoption := 0.0
+	if snapshot.ActiveUsers > 0 {
+		adoption = float64(snapshot.PasskeyUsers) / float64(snapshot.ActiveUsers)
+	}
+	metrics.BusinessPasskeyAdoption.Set(adoption)
+	metrics.BusinessAggregatedAt.Set(float64(snapshot.AggregatedAt.Unix()))
+}
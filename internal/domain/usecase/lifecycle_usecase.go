@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/rs/zerolog/log"
+)
+
+// Lifecycle rule names, the names of their template files in the template directory
+const (
+	lifecycleRuleWelcome      = "welcome"      // On user.created, once the welcome delay elapsed
+	lifecycleRuleReengagement = "reengagement" // Once a user has been inactive for the re-engagement period
+)
+
+const (
+	// lifecyclePageSize is the number of users read at a time while applying a rule
+	lifecyclePageSize = 100
+
+	// lifecycleMaxCatchUp bounds how far back a rule goes after instances were stopped, older users are skipped
+	// rather than emailed late
+	lifecycleMaxCatchUp = 24 * time.Hour
+
+	// lifecycleDedupScope is the dedup scope of the lifecycle job, keyed by interval, so instances apply the rules
+	// once per interval between them
+	lifecycleDedupScope = "lifecycle"
+
+	// lifecycleSentDedupScope is the dedup scope of the emails sent, keyed by rule, user and time of reference, so
+	// users listed again by overlapping passes are emailed once
+	lifecycleSentDedupScope = "lifecycle_sent"
+
+	// lifecycleSentRetention is how long the emails sent are remembered, longer than a pass can go back
+	lifecycleSentRetention = 2 * lifecycleMaxCatchUp
+)
+
+// defaultLifecycleTemplates are the built-in templates of the lifecycle rules. Each defines a subject and a body
+// template, rendered with the User, their Name, the ProductName and a sign in Link.
+var defaultLifecycleTemplates = map[string]string{
+	lifecycleRuleWelcome: `{{define "subject"}}Welcome to {{.ProductName}}{{end}}` +
+		"{{define \"body\"}}Hello {{.Name}},\n\n" +
+		"Welcome to {{.ProductName}}, we are glad to have you on board. Sign in any time by opening the link below:\n\n" +
+		"{{.Link}}\n\n" +
+		"If you have any question, please contact support.\n{{end}}",
+	lifecycleRuleReengagement: `{{define "subject"}}We miss you at {{.ProductName}}{{end}}` +
+		"{{define \"body\"}}Hello {{.Name}},\n\n" +
+		"It has been a while since you last signed in to {{.ProductName}}. Your account is waiting for you:\n\n" +
+		"{{.Link}}\n\n" +
+		"You can turn off these emails in your notification settings.\n{{end}}",
+}
+
+// LifecycleUseCase defines the use case for the lifecycle emails, sent by rules to the users reaching a stage of
+// their lifecycle
+type LifecycleUseCase interface {
+	// RunRules applies the enabled rules at every interval until the context is cancelled
+	RunRules(ctx context.Context, interval time.Duration)
+}
+
+// lifecycleRule emails the active users whose time of reference is delay ago
+type lifecycleRule struct {
+	name  string
+	delay time.Duration
+
+	// list lists the users whose time of reference is in [from, to), the earliest first
+	list func(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error)
+
+	// reference returns the time of reference of a user
+	reference func(user *entity.User) time.Time
+
+	tmpl *template.Template
+}
+
+// lifecycleUseCase implements LifecycleUseCase interface
+type lifecycleUseCase struct {
+	userRepo            repository.UserRepository
+	lifecycleRepo       repository.LifecycleRepository
+	dedupRepo           repository.DedupRepository
+	notificationUseCase NotificationUseCase
+
+	// rules are the enabled rules
+	rules []lifecycleRule
+}
+
+// NewLifecycleUseCase creates a new LifecycleUseCase applying the rules enabled by lifecycleCfg. It fails when a
+// template of the template directory cannot be read or parsed.
+func NewLifecycleUseCase(
+	userRepo repository.UserRepository,
+	lifecycleRepo repository.LifecycleRepository,
+	dedupRepo repository.DedupRepository,
+	notificationUseCase NotificationUseCase,
+	lifecycleCfg config.LifecycleConfig,
+) (LifecycleUseCase, error) {
+	uc := &lifecycleUseCase{
+		userRepo:            userRepo,
+		lifecycleRepo:       lifecycleRepo,
+		dedupRepo:           dedupRepo,
+		notificationUseCase: notificationUseCase,
+	}
+
+	if lifecycleCfg.WelcomeEnabled {
+		tmpl, err := lifecycleTemplate(lifecycleRuleWelcome, lifecycleCfg.TemplateDir)
+		if err != nil {
+			return nil, err
+		}
+		uc.rules = append(uc.rules, lifecycleRule{
+			name:  lifecycleRuleWelcome,
+			delay: lifecycleCfg.WelcomeDelay,
+			list:  userRepo.ListCreatedBetween,
+			reference: func(user *entity.User) time.Time {
+				return user.CreatedAt
+			},
+			tmpl: tmpl,
+		})
+	}
+	if lifecycleCfg.ReengagementEnabled {
+		tmpl, err := lifecycleTemplate(lifecycleRuleReengagement, lifecycleCfg.TemplateDir)
+		if err != nil {
+			return nil, err
+		}
+		uc.rules = append(uc.rules, lifecycleRule{
+			name:      lifecycleRuleReengagement,
+			delay:     lifecycleCfg.ReengagementAfter,
+			list:      userRepo.ListLastActiveBetween,
+			reference: (*entity.User).LastActivity,
+			tmpl:      tmpl,
+		})
+	}
+
+	return uc, nil
+}
+
+// lifecycleTemplate returns the template of a rule, read from the template directory when it holds one
+func lifecycleTemplate(rule, dir string) (*template.Template, error) {
+	text := defaultLifecycleTemplates[rule]
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, rule+".tmpl"))
+		switch {
+		case err == nil:
+			text = string(data)
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("failed to read %s template: %w", rule, err)
+		}
+	}
+
+	tmpl, err := template.New(rule).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", rule, err)
+	}
+	for _, name := range []string{"subject", "body"} {
+		if tmpl.Lookup(name) == nil {
+			return nil, fmt.Errorf("%s template does not define %q", rule, name)
+		}
+	}
+	return tmpl, nil
+}
+
+// RunRules applies the enabled rules at every interval until the context is cancelled
+func (uc *lifecycleUseCase) RunRules(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Claim the interval, the instances ticking within it skip their turn. Fail open, the emails sent are
+		// remembered so passes overlapping do not send them twice.
+		window := strconv.FormatInt(time.Now().Truncate(interval).Unix(), 10)
+		claimed, err := uc.dedupRepo.Claim(ctx, lifecycleDedupScope, window, interval)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to claim lifecycle rules")
+		} else if !claimed {
+			continue
+		}
+
+		now := time.Now()
+		for _, rule := range uc.rules {
+			sent, err := uc.apply(ctx, rule, now, interval)
+			if err != nil {
+				log.Error().Err(err).Str("rule", rule.name).Int("sent", sent).Msg("Failed to apply lifecycle rule")
+				continue
+			}
+			if sent > 0 {
+				log.Info().Str("rule", rule.name).Int("sent", sent).Msg("Sent lifecycle emails")
+			}
+		}
+	}
+}
+
+// apply emails the users whose time of reference is between the cursor of the rule and delay ago, and moves the
+// cursor up to delay ago. A failed pass leaves the cursor, the next pass goes over the same users again.
+func (uc *lifecycleUseCase) apply(ctx context.Context, rule lifecycleRule, now time.Time, interval time.Duration) (int, error) {
+	to := now.Add(-rule.delay)
+	from, err := uc.lifecycleRepo.Cursor(ctx, rule.name)
+	if err != nil {
+		return 0, err
+	}
+	// The first pass starts an interval back rather than emailing every existing user
+	if from.IsZero() {
+		from = to.Add(-interval)
+	}
+	if earliest := to.Add(-lifecycleMaxCatchUp); from.Before(earliest) {
+		from = earliest
+	}
+
+	sent := 0
+	for from.Before(to) {
+		users, err := rule.list(ctx, from, to, lifecyclePageSize)
+		if err != nil {
+			return sent, err
+		}
+		for _, user := range users {
+			ok, err := uc.send(ctx, rule, user)
+			if err != nil {
+				return sent, err
+			}
+			if ok {
+				sent++
+			}
+		}
+		if len(users) < lifecyclePageSize {
+			break
+		}
+
+		// The next page starts at the last user, whom the sent emails keep from being emailed twice. Times are
+		// stored to the millisecond, a page of users sharing one moves on past it.
+		next := rule.reference(users[len(users)-1])
+		if !next.After(from) {
+			next = from.Add(time.Millisecond)
+		}
+		from = next
+	}
+
+	return sent, uc.lifecycleRepo.SetCursor(ctx, rule.name, to)
+}
+
+// send emails a user the email of a rule, unless they are not active, opted out or were already sent it, and
+// reports whether it was sent
+func (uc *lifecycleUseCase) send(ctx context.Context, rule lifecycleRule, user *entity.User) (bool, error) {
+	if user.Status != entity.UserStatusActive || user.LifecycleEmailsDisabled {
+		return false, nil
+	}
+
+	key := rule.name + ":" + user.ID.String() + ":" + strconv.FormatInt(rule.reference(user).Unix(), 10)
+	claimed, err := uc.dedupRepo.Claim(ctx, lifecycleSentDedupScope, key, lifecycleSentRetention)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	if err := uc.notificationUseCase.SendLifecycleEmail(ctx, rule.name, user, rule.tmpl); err != nil {
+		log.Error().Err(err).Str("rule", rule.name).Str("user_id", user.ID.String()).Msg("Failed to send lifecycle email")
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	readOnlySettingKey = "read_only"
+
+	// The read-only flag is re-read from the shared settings at most this often
+	readOnlyRefreshInterval = 5 * time.Second
+)
+
+// MaintenanceUseCase defines the use case for operational switches
+type MaintenanceUseCase interface {
+	// IsReadOnly reports whether mutating requests must be rejected
+	IsReadOnly(ctx context.Context) bool
+
+	// SetReadOnly enables or disables read-only mode on all instances
+	SetReadOnly(ctx context.Context, enabled bool) error
+}
+
+// maintenanceUseCase implements MaintenanceUseCase interface
+type maintenanceUseCase struct {
+	settingsRepo repository.SettingsRepository
+
+	mu        sync.Mutex
+	readOnly  bool
+	refreshed time.Time
+}
+
+// NewMaintenanceUseCase creates a new MaintenanceUseCase.
+// readOnly is used until an administrator toggles the mode at runtime.
+func NewMaintenanceUseCase(settingsRepo repository.SettingsRepository, readOnly bool) MaintenanceUseCase {
+	return &maintenanceUseCase{
+		settingsRepo: settingsRepo,
+		readOnly:     readOnly,
+	}
+}
+
+// IsReadOnly reports whether mutating requests must be rejected.
+// The last known value is kept when the shared settings cannot be read, e.g. during a cache outage.
+func (uc *maintenanceUseCase) IsReadOnly(ctx context.Context) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if time.Since(uc.refreshed) < readOnlyRefreshInterval {
+		return uc.readOnly
+	}
+	uc.refreshed = time.Now()
+
+	value, found, err := uc.settingsRepo.GetSetting(ctx, readOnlySettingKey)
+	if err != nil || !found {
+		return uc.readOnly
+	}
+
+	readOnly, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warn().Str("value", value).Msg("Ignoring invalid read-only setting")
+		return uc.readOnly
+	}
+	uc.readOnly = readOnly
+
+	return uc.readOnly
+}
+
+// SetReadOnly enables or disables read-only mode on all instances
+func (uc *maintenanceUseCase) SetReadOnly(ctx context.Context, enabled bool) error {
+	if err := uc.settingsRepo.SetSetting(ctx, readOnlySettingKey, strconv.FormatBool(enabled)); err != nil {
+		return err
+	}
+
+	uc.mu.Lock()
+	uc.readOnly = enabled
+	uc.refreshed = time.Now()
+	uc.mu.Unlock()
+
+	log.Warn().Bool("enabled", enabled).Msg("Read-only mode changed")
+	return nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidPeriod = errors.New("invalid period")
+)
+
+// MeteringUseCase defines the use case for billable usage metering
+type MeteringUseCase interface {
+	// RecordActiveUser records activity of a user for the monthly active users count
+	RecordActiveUser(userID uuid.UUID)
+
+	// RecordAPICall records an API call made by a client
+	RecordAPICall(client string)
+
+	// Flush writes the buffered usage to the aggregated store
+	Flush(ctx context.Context) error
+
+	// Run flushes the buffered usage periodically until the context is cancelled
+	Run(ctx context.Context, interval time.Duration)
+
+	// Report returns the aggregated usage of a period (YYYY-MM)
+	Report(ctx context.Context, period string) (*entity.UsageReport, error)
+}
+
+// usageKey identifies a buffered usage counter
+type usageKey struct {
+	period  string
+	metric  string
+	subject string
+}
+
+// meteringUseCase implements MeteringUseCase interface.
+// Events are buffered in memory and aggregated so the hot path never touches the database.
+type meteringUseCase struct {
+	usageRepo repository.UsageRepository
+
+	mu     sync.Mutex
+	buffer map[usageKey]int64
+}
+
+// NewMeteringUseCase creates a new MeteringUseCase
+func NewMeteringUseCase(usageRepo repository.UsageRepository) MeteringUseCase {
+	return &meteringUseCase{
+		usageRepo: usageRepo,
+		buffer:    make(map[usageKey]int64),
+	}
+}
+
+// RecordActiveUser records activity of a user for the monthly active users count
+func (uc *meteringUseCase) RecordActiveUser(userID uuid.UUID) {
+	uc.record(entity.UsageMetricActiveUsers, userID.String())
+}
+
+// RecordAPICall records an API call made by a client
+func (uc *meteringUseCase) RecordAPICall(client string) {
+	uc.record(entity.UsageMetricAPICalls, client)
+}
+
+// record increments a buffered counter of the current period
+func (uc *meteringUseCase) record(metric, subject string) {
+	key := usageKey{
+		period:  entity.UsagePeriod(time.Now()),
+		metric:  metric,
+		subject: subject,
+	}
+
+	uc.mu.Lock()
+	uc.buffer[key]++
+	uc.mu.Unlock()
+}
+
+// Flush writes the buffered usage to the aggregated store.
+// Counters that cannot be written are put back in the buffer for the next flush.
+func (uc *meteringUseCase) Flush(ctx context.Context) error {
+	uc.mu.Lock()
+	buffer := uc.buffer
+	uc.buffer = make(map[usageKey]int64)
+	uc.mu.Unlock()
+
+	if len(buffer) == 0 {
+		return nil
+	}
+
+	records := make([]*entity.UsageRecord, 0, len(buffer))
+	for key, count := range buffer {
+		records = append(records, &entity.UsageRecord{
+			Period:  key.period,
+			Metric:  key.metric,
+			Subject: key.subject,
+			Count:   count,
+		})
+	}
+
+	if err := uc.usageRepo.Add(ctx, records); err != nil {
+		uc.mu.Lock()
+		for key, count := range buffer {
+			uc.buffer[key] += count
+		}
+		uc.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Run flushes the buffered usage periodically until the context is cancelled
+func (uc *meteringUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.Flush(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to flush usage")
+			}
+		}
+	}
+}
+
+// Report returns the aggregated usage of a period (YYYY-MM)
+func (uc *meteringUseCase) Report(ctx context.Context, period string) (*entity.UsageReport, error) {
+	if _, err := time.Parse(entity.UsagePeriodLayout, period); err != nil {
+		return nil, ErrInvalidPeriod
+	}
+
+	records, err := uc.usageRepo.ListByPeriod(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.UsageReport{
+		Period:  period,
+		Clients: []*entity.UsageRecord{},
+	}
+	for _, record := range records {
+		switch record.Metric {
+		case entity.UsageMetricActiveUsers:
+			report.ActiveUsers++
+		case entity.UsageMetricAPICalls:
+			report.APICalls += record.Count
+			report.Clients = append(report.Clients, record)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,649 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// emailVerificationTemplate is the body of the email verification message
+var emailVerificationTemplate = template.Must(template.New("email_verification").Parse(
+	"Hello {{.Name}},\n\n" +
+		"Please confirm your email address by opening the link below:\n\n" +
+		"{{.Link}}\n\n" +
+		"If you did not create an account, you can ignore this email.\n"))
+
+// recoveryEmailVerificationTemplate is the body of the recovery email verification message
+var recoveryEmailVerificationTemplate = template.Must(template.New("recovery_email_verification").Parse(
+	"Hello {{.Name}},\n\n" +
+		"Please confirm this address as the recovery email of your account by opening the link below:\n\n" +
+		"{{.Link}}\n\n" +
+		"If you did not request this, you can ignore this email.\n"))
+
+// passwordResetTemplate is the body of the password reset message
+var passwordResetTemplate = template.Must(template.New("password_reset").Parse(
+	"Hello {{.Name}},\n\n" +
+		"A password reset was requested for your account. Choose a new password by opening the link below:\n\n" +
+		"{{.Link}}\n\n" +
+		"If you did not request a password reset, you can ignore this email.\n"))
+
+// invitationTemplate is the body of the invitation message
+var invitationTemplate = template.Must(template.New("invitation").Parse(
+	"Hello {{.Name}},\n\n" +
+		"An account was created for you. Choose your password and accept the terms by opening the link below:\n\n" +
+		"{{.Link}}\n\n" +
+		"The link expires on {{.ExpiresAt.Format \"2006-01-02 15:04 MST\"}}.\n"))
+
+// accountExistsTemplate is the body of the message sent when registering with the email of an existing account
+var accountExistsTemplate = template.Must(template.New("account_exists").Parse(
+	"Hello {{.Name}},\n\n" +
+		"Someone tried to create an account with this email address, which already has one. " +
+		"You can sign in with it, or choose a new password if you forgot yours:\n\n" +
+		"{{.Link}}\n\n" +
+		"If you did not try to register, you can ignore this email.\n"))
+
+// waitlistApprovedTemplate is the body of the message sent when a waitlisted user is approved
+var waitlistApprovedTemplate = template.Must(template.New("waitlist_approved").Parse(
+	"Hello {{.Name}},\n\n" +
+		"Good news, you are off the waitlist: your account is now active. Sign in by opening the link below:\n\n" +
+		"{{.Link}}\n"))
+
+// inactivityWarningTemplate is the body of the notice emailed before the inactivity policy acts on an account
+var inactivityWarningTemplate = template.Must(template.New("inactivity_warning").Parse(
+	"Hello {{.Name}},\n\n" +
+		"You have not signed in since {{.LastActivity.Format \"January 2, 2006\"}}. " +
+		"{{if eq .Action \"deactivate\"}}To keep your account active, please sign in before {{.Deadline.Format \"January 2, 2006\"}}, " +
+		"otherwise it will be deactivated.{{else}}Unless you sign in before {{.Deadline.Format \"January 2, 2006\"}}, " +
+		"your account will be flagged as dormant for review.{{end}}\n\n" +
+		"{{.Link}}\n"))
+
+// breakGlassAlertTemplate is the body of the alert emailed to the security contacts when a break-glass account is
+// used
+var breakGlassAlertTemplate = template.Must(template.New("break_glass_alert").Parse(
+	"The break-glass account {{.Username}} signed in on {{.UsedAt.Format \"2006-01-02 15:04:05 MST\"}}.\n\n" +
+		"Its credential will be rotated and its sessions revoked on {{.RotateAt.Format \"2006-01-02 15:04:05 MST\"}}.\n\n" +
+		"If this sign in is not part of a declared incident, investigate immediately.\n"))
+
+// brandedEmailTemplate is the HTML alternative of the emails, laid out with the branding of the recipient's
+// organization. The paragraphs of the text body are kept, the paragraph holding the link becomes a button.
+var brandedEmailTemplate = htmltemplate.Must(htmltemplate.New("branded_email").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>{{.Subject}}</title></head>
+<body style="margin:0;padding:24px;background:#f3f4f6;font-family:Helvetica,Arial,sans-serif;color:#111827">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="max-width:560px;margin:0 auto;background:#ffffff">
+<tr><td style="padding:20px 24px;background:{{.Brand.PrimaryColor}}">
+{{- if .Brand.LogoURL}}<img src="{{.Brand.LogoURL}}" alt="{{.Brand.ProductName}}" height="32">
+{{- else}}<span style="font-size:20px;font-weight:bold;color:#ffffff">{{.Brand.ProductName}}</span>{{end -}}
+</td></tr>
+<tr><td style="padding:24px;font-size:15px;line-height:1.5">
+{{- range .Paragraphs}}
+{{- if and $.Link (eq . $.Link)}}
+<p><a href="{{$.Link}}" style="display:inline-block;padding:12px 20px;background:{{$.Brand.AccentColor}};color:#ffffff;text-decoration:none;font-weight:bold">{{$.Action}}</a></p>
+<p style="font-size:12px;color:#6b7280;word-break:break-all">{{$.Link}}</p>
+{{- else}}
+<p>{{.}}</p>
+{{- end}}
+{{- end}}
+</td></tr>
+<tr><td style="padding:16px 24px;font-size:12px;color:#6b7280;border-top:1px solid #e5e7eb">
+{{.Brand.ProductName}}{{if .Brand.SupportEmail}} &middot; Need help? Contact <a href="mailto:{{.Brand.SupportEmail}}" style="color:{{.Brand.AccentColor}}">{{.Brand.SupportEmail}}</a>{{end}}
+</td></tr>
+</table>
+</body>
+</html>
+`))
+
+// notificationTimeout bounds the delivery of the notifications of an action
+const notificationTimeout = 30 * time.Second
+
+// requestIDKey is the key of the request ID in the request context, set by the request ID middleware
+const requestIDKey = "requestid"
+
+// backgroundRequestIDKey is the key of the ID of the request that started a background task in its context
+type backgroundRequestIDKey struct{}
+
+// detach returns a context for work outliving the request of ctx, bounded by notificationTimeout. The request
+// context must not be kept: fasthttp recycles it for the next request, and it carries the query budget and cache
+// of the request. Only the trace span and the request ID are carried over, so the work is still attributed to the
+// request. It must be called before the request completes.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+		detached = context.WithValue(detached, backgroundRequestIDKey{}, requestID)
+	}
+	return context.WithTimeout(detached, notificationTimeout)
+}
+
+// runInBackground runs a task after the request that triggered it has completed, bounded by notificationTimeout.
+// The response does not depend on the outcome, so failures are logged.
+func runInBackground(ctx context.Context, task string, fn func(ctx context.Context) error) {
+	ctx, cancel := detach(ctx)
+	go func() {
+		defer cancel()
+		if err := fn(ctx); err != nil {
+			requestID, _ := ctx.Value(backgroundRequestIDKey{}).(string)
+			log.Error().Err(err).Str("task", task).Str("request_id", requestID).Msg("Background task failed")
+		}
+	}()
+}
+
+// NotificationUseCase defines the use case for user notifications
+type NotificationUseCase interface {
+	// NotifyAdminAction notifies a user of an administrative action performed on their account.
+	// Delivery happens in the background and never fails the action itself.
+	NotifyAdminAction(ctx context.Context, action string, actorID uuid.UUID, user *entity.User, details map[string]string)
+
+	// SendEmailVerification emails a verification token to a user
+	SendEmailVerification(ctx context.Context, user *entity.User, token string) error
+
+	// SendRecoveryEmailVerification emails a verification token to the recovery email of a user
+	SendRecoveryEmailVerification(ctx context.Context, user *entity.User, token string) error
+
+	// SendPasswordReset sends a password reset token to a user on the email or recovery email channel
+	SendPasswordReset(ctx context.Context, user *entity.User, channel, token string) error
+
+	// SendInvitation emails an invitation token to a user created by an administrator
+	SendInvitation(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error
+
+	// SendAccountExists emails a user that someone tried to register with their email
+	SendAccountExists(ctx context.Context, user *entity.User) error
+
+	// SendWaitlistApproved emails a waitlisted user that their account was activated
+	SendWaitlistApproved(ctx context.Context, user *entity.User) error
+
+	// SendInactivityWarning emails a user that the inactivity policy will take an action on their account unless they
+	// sign in before a deadline
+	SendInactivityWarning(ctx context.Context, user *entity.User, action string, deadline time.Time) error
+
+	// SendBreakGlassAlert emails the address of a break-glass account and the security contacts that it signed in,
+	// and when its credential will be rotated. Failed deliveries are logged.
+	SendBreakGlassAlert(ctx context.Context, user *entity.User, contacts []string, usedAt, rotateAt time.Time) error
+
+	// SendLifecycleEmail sends the email of a lifecycle rule to a user on their preferred channels, rendered from the
+	// subject and body templates of tmpl. It fails when the email cannot be rendered, failed deliveries are logged.
+	SendLifecycleEmail(ctx context.Context, rule string, user *entity.User, tmpl *template.Template) error
+}
+
+// adminActionPolicy describes how a user is notified of an administrative action
+type adminActionPolicy struct {
+	// channels are used when the user has no preferred channels
+	channels []string
+	subject  string
+	body     *template.Template
+}
+
+// adminActionPolicies is the policy table of administrative actions users are notified of,
+// along with the security events of their account. Actions without an entry are not notified.
+var adminActionPolicies = map[string]adminActionPolicy{
+	entity.AuditActionUserStatusChanged: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "Your account status has changed",
+		body: template.Must(template.New(entity.AuditActionUserStatusChanged).Parse(
+			"Hello {{.Name}},\n\n" +
+				"An administrator changed the status of your account to {{index .Details \"status\"}}.\n\n" +
+				"If you did not expect this change, please contact support.\n")),
+	},
+	entity.AuditActionUserRoleChanged: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "Your account role has changed",
+		body: template.Must(template.New(entity.AuditActionUserRoleChanged).Parse(
+			"Hello {{.Name}},\n\n" +
+				"An administrator changed the role of your account to {{index .Details \"role\"}}.\n\n" +
+				"If you did not expect this change, please contact support.\n")),
+	},
+	entity.AuditActionRecoveryEmailSet: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "Your recovery email has changed",
+		body: template.Must(template.New(entity.AuditActionRecoveryEmailSet).Parse(
+			"Hello {{.Name}},\n\n" +
+				"The recovery email of your account was changed to {{index .Details \"recovery_email\"}}.\n\n" +
+				"If you did not make this change, please reset your password and contact support.\n")),
+	},
+	entity.AuditActionRecoveryEmailRemoved: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "Your recovery email was removed",
+		body: template.Must(template.New(entity.AuditActionRecoveryEmailRemoved).Parse(
+			"Hello {{.Name}},\n\n" +
+				"The recovery email of your account was removed.\n\n" +
+				"If you did not make this change, please reset your password and contact support.\n")),
+	},
+	entity.AuditActionPasswordReset: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "Your password was reset",
+		body: template.Must(template.New(entity.AuditActionPasswordReset).Parse(
+			"Hello {{.Name}},\n\n" +
+				"The password of your account was reset and all sessions were signed out.\n\n" +
+				"If you did not reset your password, please contact support.\n")),
+	},
+	entity.AuditActionPasswordChanged: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "Your password was changed",
+		body: template.Must(template.New(entity.AuditActionPasswordChanged).Parse(
+			"Hello {{.Name}},\n\n" +
+				"The password of your account was changed at sign in, as an administrator required.\n\n" +
+				"If you did not change your password, please reset it and contact support.\n")),
+	},
+	entity.AuditActionPasskeyAdded: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "A passkey was added to your account",
+		body: template.Must(template.New(entity.AuditActionPasskeyAdded).Parse(
+			"Hello {{.Name}},\n\n" +
+				"The passkey {{index .Details \"passkey_name\"}} was added to your account, it can now be used to sign in.\n\n" +
+				"If you did not add this passkey, please remove it, reset your password and contact support.\n")),
+	},
+	entity.AuditActionPasskeyRemoved: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "A passkey was removed from your account",
+		body: template.Must(template.New(entity.AuditActionPasskeyRemoved).Parse(
+			"Hello {{.Name}},\n\n" +
+				"The passkey {{index .Details \"passkey_name\"}} was removed from your account.\n\n" +
+				"If you did not remove this passkey, please reset your password and contact support.\n")),
+	},
+	entity.AuditActionAPIKeyCreated: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "An API key was created for your account",
+		body: template.Must(template.New(entity.AuditActionAPIKeyCreated).Parse(
+			"Hello {{.Name}},\n\n" +
+				"The API key {{index .Details \"api_key_name\"}} ({{index .Details \"api_key_prefix\"}}...) was created for your account " +
+				"with the scopes {{index .Details \"scopes\"}}, it can now be used to call the API on your behalf.\n\n" +
+				"If you did not create this API key, please revoke it, reset your password and contact support.\n")),
+	},
+	entity.AuditActionOAuthLinked: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "A sign in method was linked to your account",
+		body: template.Must(template.New(entity.AuditActionOAuthLinked).Parse(
+			"Hello {{.Name}},\n\n" +
+				"Your {{index .Details \"provider\"}} account {{index .Details \"provider_email\"}} was linked to your account, " +
+				"it can now be used to sign in.\n\n" +
+				"If you did not sign in with {{index .Details \"provider\"}}, please reset your password and contact support.\n")),
+	},
+	entity.AuditActionOAuthUnlinked: {
+		channels: []string{entity.NotificationChannelEmail},
+		subject:  "A sign in method was removed from your account",
+		body: template.Must(template.New(entity.AuditActionOAuthUnlinked).Parse(
+			"Hello {{.Name}},\n\n" +
+				"Your {{index .Details \"provider\"}} account {{index .Details \"provider_email\"}} was unlinked from your account, " +
+				"it can no longer be used to sign in.\n\n" +
+				"If you did not unlink it, please reset your password and contact support.\n")),
+	},
+}
+
+// notificationUseCase implements NotificationUseCase interface
+type notificationUseCase struct {
+	auditRepo           repository.AuditRepository
+	orgRepo             repository.OrganizationRepository
+	notificationService service.NotificationService
+	nameService         service.NameService
+	publicURL           string
+	branding            entity.Branding
+}
+
+// NewNotificationUseCase creates a new NotificationUseCase.
+// publicURL is the base URL of the links sent to users, emails follow the branding of the user's organization
+// completed with brandingConfig.
+func NewNotificationUseCase(
+	auditRepo repository.AuditRepository,
+	orgRepo repository.OrganizationRepository,
+	notificationService service.NotificationService,
+	nameService service.NameService,
+	publicURL string,
+	brandingConfig config.BrandingConfig,
+) NotificationUseCase {
+	return &notificationUseCase{
+		auditRepo:           auditRepo,
+		orgRepo:             orgRepo,
+		notificationService: notificationService,
+		nameService:         nameService,
+		publicURL:           strings.TrimSuffix(publicURL, "/"),
+		branding:            defaultBranding(brandingConfig),
+	}
+}
+
+// brandingOf returns the branding of the organization of a user, the default branding outside an organization
+// or when the organization cannot be read
+func (uc *notificationUseCase) brandingOf(ctx context.Context, user *entity.User) entity.Branding {
+	if user.OrgID == nil {
+		return uc.branding
+	}
+
+	org, err := uc.orgRepo.GetByID(ctx, *user.OrgID)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", user.OrgID.String()).Msg("Failed to get organization branding, using the default branding")
+		return uc.branding
+	}
+	if org == nil {
+		return uc.branding
+	}
+	return org.Branding.WithDefaults(uc.branding)
+}
+
+// link returns the URL of a hosted page sent to a user. The organization of the user is passed along
+// so the page can follow its branding.
+func (uc *notificationUseCase) link(user *entity.User, path, token string) string {
+	query := url.Values{}
+	if token != "" {
+		query.Set("token", token)
+	}
+	if user.OrgID != nil {
+		query.Set("org", user.OrgID.String())
+	}
+
+	if len(query) == 0 {
+		return uc.publicURL + path
+	}
+	return uc.publicURL + path + "?" + query.Encode()
+}
+
+// compose builds a notification from its text body, signed with the branding of the user's organization and
+// with an HTML alternative. link is the link of the body shown as a button labelled action, if any.
+func (uc *notificationUseCase) compose(ctx context.Context, user *entity.User, subject, body, link, action string) (*entity.Notification, error) {
+	brand := uc.brandingOf(ctx, user)
+
+	var paragraphs []string
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	var html bytes.Buffer
+	if err := brandedEmailTemplate.Execute(&html, struct {
+		Brand      entity.Branding
+		Subject    string
+		Paragraphs []string
+		Link       string
+		Action     string
+	}{brand, subject, paragraphs, link, action}); err != nil {
+		return nil, fmt.Errorf("failed to render HTML email: %w", err)
+	}
+
+	signature := "\n-- \n" + brand.ProductName + "\n"
+	if brand.SupportEmail != "" {
+		signature += "Need help? Contact " + brand.SupportEmail + "\n"
+	}
+
+	return &entity.Notification{
+		Subject:  subject,
+		Body:     body + signature,
+		HTMLBody: html.String(),
+	}, nil
+}
+
+// NotifyAdminAction notifies a user of an administrative action performed on their account
+func (uc *notificationUseCase) NotifyAdminAction(ctx context.Context, action string, actorID uuid.UUID, user *entity.User, details map[string]string) {
+	policy, ok := adminActionPolicies[action]
+	if !ok {
+		return
+	}
+
+	var body bytes.Buffer
+	if err := policy.body.Execute(&body, struct {
+		User    *entity.User
+		Name    string
+		Details map[string]string
+	}{user, uc.nameService.DisplayName(user), details}); err != nil {
+		log.Error().Err(err).Str("action", action).Msg("Failed to render notification")
+		return
+	}
+	notification, err := uc.compose(ctx, user, policy.subject, body.String(), "", "")
+	if err != nil {
+		log.Error().Err(err).Str("action", action).Msg("Failed to render notification")
+		return
+	}
+
+	channels := user.NotificationChannels
+	if len(channels) == 0 {
+		channels = policy.channels
+	}
+	// Copy email notifications to the recovery email, in case the primary mailbox is compromised or inaccessible
+	if user.RecoveryEmailVerified && slices.Contains(channels, entity.NotificationChannelEmail) {
+		channels = append(slices.Clone(channels), entity.NotificationChannelRecoveryEmail)
+	}
+
+	// Keep delivering after the request that triggered the action has completed
+	ctx, cancel := detach(ctx)
+	go func() {
+		defer cancel()
+		for _, channel := range channels {
+			uc.deliver(ctx, action, actorID, user, channel, notification)
+		}
+	}()
+}
+
+// deliver sends a notification on a channel and records it in the audit trail
+func (uc *notificationUseCase) deliver(ctx context.Context, action string, actorID uuid.UUID, user *entity.User, channel string, notification *entity.Notification) {
+	if err := uc.notificationService.Send(ctx, user, channel, notification); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Str("channel", channel).Str("action", action).Msg("Failed to send notification")
+		return
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionNotificationSent, actorID, user.ID, map[string]string{
+		"trigger": action,
+		"channel": channel,
+		"subject": notification.Subject,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to record notification in audit trail")
+	}
+}
+
+// SendEmailVerification emails a verification token to a user
+func (uc *notificationUseCase) SendEmailVerification(ctx context.Context, user *entity.User, token string) error {
+	link := uc.link(user, "/verify-email", token)
+
+	var body bytes.Buffer
+	if err := emailVerificationTemplate.Execute(&body, struct {
+		User *entity.User
+		Name string
+		Link string
+	}{user, uc.nameService.DisplayName(user), link}); err != nil {
+		return fmt.Errorf("failed to render email verification: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "Confirm your email address", body.String(), link, "Confirm email address")
+	if err != nil {
+		return err
+	}
+	return uc.notificationService.Send(ctx, user, entity.NotificationChannelEmail, notification)
+}
+
+// SendRecoveryEmailVerification emails a verification token to the recovery email of a user
+func (uc *notificationUseCase) SendRecoveryEmailVerification(ctx context.Context, user *entity.User, token string) error {
+	link := uc.link(user, "/verify-recovery-email", token)
+
+	var body bytes.Buffer
+	if err := recoveryEmailVerificationTemplate.Execute(&body, struct {
+		User *entity.User
+		Name string
+		Link string
+	}{user, uc.nameService.DisplayName(user), link}); err != nil {
+		return fmt.Errorf("failed to render recovery email verification: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "Confirm your recovery email address", body.String(), link, "Confirm recovery email")
+	if err != nil {
+		return err
+	}
+	return uc.notificationService.Send(ctx, user, entity.NotificationChannelRecoveryEmail, notification)
+}
+
+// SendPasswordReset sends a password reset token to a user on the email or recovery email channel
+func (uc *notificationUseCase) SendPasswordReset(ctx context.Context, user *entity.User, channel, token string) error {
+	link := uc.link(user, "/reset-password", token)
+
+	var body bytes.Buffer
+	if err := passwordResetTemplate.Execute(&body, struct {
+		User *entity.User
+		Name string
+		Link string
+	}{user, uc.nameService.DisplayName(user), link}); err != nil {
+		return fmt.Errorf("failed to render password reset: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "Reset your password", body.String(), link, "Choose a new password")
+	if err != nil {
+		return err
+	}
+	return uc.notificationService.Send(ctx, user, channel, notification)
+}
+
+// SendInvitation emails an invitation token to a user created by an administrator
+func (uc *notificationUseCase) SendInvitation(ctx context.Context, user *entity.User, token string, expiresAt time.Time) error {
+	link := uc.link(user, "/accept-invitation", token)
+
+	var body bytes.Buffer
+	if err := invitationTemplate.Execute(&body, struct {
+		User      *entity.User
+		Name      string
+		Link      string
+		ExpiresAt time.Time
+	}{user, uc.nameService.DisplayName(user), link, expiresAt}); err != nil {
+		return fmt.Errorf("failed to render invitation: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "You have been invited", body.String(), link, "Accept invitation")
+	if err != nil {
+		return err
+	}
+	return uc.notificationService.Send(ctx, user, entity.NotificationChannelEmail, notification)
+}
+
+// SendAccountExists emails a user that someone tried to register with their email
+func (uc *notificationUseCase) SendAccountExists(ctx context.Context, user *entity.User) error {
+	link := uc.link(user, "/forgot-password", "")
+
+	var body bytes.Buffer
+	if err := accountExistsTemplate.Execute(&body, struct {
+		User *entity.User
+		Name string
+		Link string
+	}{user, uc.nameService.DisplayName(user), link}); err != nil {
+		return fmt.Errorf("failed to render account exists notice: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "You already have an account", body.String(), link, "Choose a new password")
+	if err != nil {
+		return err
+	}
+	return uc.notificationService.Send(ctx, user, entity.NotificationChannelEmail, notification)
+}
+
+// SendWaitlistApproved emails a waitlisted user that their account was activated
+func (uc *notificationUseCase) SendWaitlistApproved(ctx context.Context, user *entity.User) error {
+	link := uc.link(user, "/login", "")
+
+	var body bytes.Buffer
+	if err := waitlistApprovedTemplate.Execute(&body, struct {
+		User *entity.User
+		Name string
+		Link string
+	}{user, uc.nameService.DisplayName(user), link}); err != nil {
+		return fmt.Errorf("failed to render waitlist approval: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "Your account is now active", body.String(), link, "Sign in")
+	if err != nil {
+		return err
+	}
+	return uc.notificationService.Send(ctx, user, entity.NotificationChannelEmail, notification)
+}
+
+// SendInactivityWarning emails a user that the inactivity policy will take an action on their account
+func (uc *notificationUseCase) SendInactivityWarning(ctx context.Context, user *entity.User, action string, deadline time.Time) error {
+	link := uc.link(user, "/login", "")
+
+	var body bytes.Buffer
+	if err := inactivityWarningTemplate.Execute(&body, struct {
+		User         *entity.User
+		Name         string
+		Link         string
+		Action       string
+		LastActivity time.Time
+		Deadline     time.Time
+	}{user, uc.nameService.DisplayName(user), link, action, user.LastActivity(), deadline}); err != nil {
+		return fmt.Errorf("failed to render inactivity warning: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "Your account is inactive", body.String(), link, "Sign in")
+	if err != nil {
+		return err
+	}
+	return uc.notificationService.Send(ctx, user, entity.NotificationChannelEmail, notification)
+}
+
+// SendBreakGlassAlert emails the address of a break-glass account and the security contacts that it signed in
+func (uc *notificationUseCase) SendBreakGlassAlert(ctx context.Context, user *entity.User, contacts []string, usedAt, rotateAt time.Time) error {
+	var body bytes.Buffer
+	if err := breakGlassAlertTemplate.Execute(&body, struct {
+		Username string
+		UsedAt   time.Time
+		RotateAt time.Time
+	}{user.Username, usedAt.UTC(), rotateAt.UTC()}); err != nil {
+		return fmt.Errorf("failed to render break-glass alert: %w", err)
+	}
+
+	notification, err := uc.compose(ctx, user, "Break-glass account "+user.Username+" used", body.String(), "", "")
+	if err != nil {
+		return err
+	}
+
+	recipients := []string{user.Email}
+	for _, contact := range contacts {
+		if contact = entity.NormalizeEmail(contact); contact != "" && !slices.Contains(recipients, contact) {
+			recipients = append(recipients, contact)
+		}
+	}
+	for _, recipient := range recipients {
+		if err := uc.notificationService.SendEmail(ctx, recipient, notification); err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Str("recipient", recipient).Msg("Failed to send break-glass alert")
+		}
+	}
+	return nil
+}
+
+// SendLifecycleEmail sends the email of a lifecycle rule to a user on their preferred channels
+func (uc *notificationUseCase) SendLifecycleEmail(ctx context.Context, rule string, user *entity.User, tmpl *template.Template) error {
+	link := uc.link(user, "/login", "")
+	data := struct {
+		User        *entity.User
+		Name        string
+		ProductName string
+		Link        string
+	}{user, uc.nameService.DisplayName(user), uc.brandingOf(ctx, user).ProductName, link}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return fmt.Errorf("failed to render %s email subject: %w", rule, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return fmt.Errorf("failed to render %s email: %w", rule, err)
+	}
+
+	notification, err := uc.compose(ctx, user, strings.TrimSpace(subject.String()), body.String(), link, "Sign in")
+	if err != nil {
+		return err
+	}
+
+	channels := user.NotificationChannels
+	if len(channels) == 0 {
+		channels = []string{entity.NotificationChannelEmail}
+	}
+	for _, channel := range channels {
+		uc.deliver(ctx, "lifecycle."+rule, uuid.Nil, user, channel, notification)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/oauth"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrOAuthProviderNotFound is returned for a provider that is unknown or not configured
+	ErrOAuthProviderNotFound = errors.New("oauth provider not found")
+
+	// ErrInvalidOAuthState is returned when a callback carries an unknown, expired or already used state, or the
+	// state of a sign in with another provider
+	ErrInvalidOAuthState = errors.New("invalid oauth state")
+
+	// ErrInvalidOAuthCode is returned when the provider rejects the authorization code of a callback
+	ErrInvalidOAuthCode = errors.New("invalid oauth authorization code")
+)
+
+// OAuthUseCase defines the use case for signing in with an account at an external OAuth2 provider, and for
+// linking accounts at providers to signed in users
+type OAuthUseCase interface {
+	// Begin starts a sign in with a provider, returning the URL of its consent page and the state the callback
+	// must carry
+	Begin(ctx context.Context, provider string) (authURL, state string, err error)
+
+	// BeginLink starts linking the account of a signed in user at a provider, like Begin. The callback links
+	// the account the user signs in to at the provider instead of signing in.
+	BeginLink(ctx context.Context, provider string, userID uuid.UUID) (authURL, state string, err error)
+
+	// Finish exchanges the authorization code of a callback. A sign in returns tokens for the user of the
+	// account, linking the account or creating the user on first sign in, a link returns the linked identity.
+	Finish(ctx context.Context, provider, state, code string) (*entity.OAuthResult, error)
+
+	// Unlink unlinks the account of a user at a provider, ErrLastCredential when it is their only way to sign in.
+	// Accounts at providers no longer configured can be unlinked.
+	Unlink(ctx context.Context, userID uuid.UUID, provider string) error
+}
+
+// oauthUseCase implements OAuthUseCase interface
+type oauthUseCase struct {
+	providers   map[string]oauth.Provider
+	stateRepo   repository.OAuthStateRepository
+	userUseCase UserUseCase
+	authUseCase AuthUseCase
+	callbackURL string
+	stateTTL    time.Duration
+}
+
+// NewOAuthUseCase creates a new OAuthUseCase signing in with the given providers, by name
+func NewOAuthUseCase(
+	providers map[string]oauth.Provider,
+	stateRepo repository.OAuthStateRepository,
+	userUseCase UserUseCase,
+	authUseCase AuthUseCase,
+	oauthCfg config.OAuthConfig,
+) OAuthUseCase {
+	return &oauthUseCase{
+		providers:   providers,
+		stateRepo:   stateRepo,
+		userUseCase: userUseCase,
+		authUseCase: authUseCase,
+		callbackURL: oauthCfg.CallbackURL,
+		stateTTL:    oauthCfg.StateExpiration,
+	}
+}
+
+// Begin starts a sign in with a provider
+func (uc *oauthUseCase) Begin(ctx context.Context, provider string) (string, string, error) {
+	return uc.begin(ctx, provider, nil)
+}
+
+// BeginLink starts linking the account of a signed in user at a provider
+func (uc *oauthUseCase) BeginLink(ctx context.Context, provider string, userID uuid.UUID) (string, string, error) {
+	return uc.begin(ctx, provider, &userID)
+}
+
+// begin stores the state of a sign in with a provider, or of a link when userID is set, and returns the URL of
+// the consent page
+func (uc *oauthUseCase) begin(ctx context.Context, provider string, userID *uuid.UUID) (string, string, error) {
+	p, ok := uc.providers[provider]
+	if !ok {
+		return "", "", ErrOAuthProviderNotFound
+	}
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	// 64 hex characters, within the 43 to 128 characters PKCE allows
+	codeVerifier, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := uc.stateRepo.Store(ctx, &entity.OAuthState{
+		State:        state,
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		UserID:       userID,
+		ExpiresAt:    time.Now().Add(uc.stateTTL),
+	}); err != nil {
+		return "", "", err
+	}
+
+	return p.AuthCodeURL(state, oauth.CodeChallenge(codeVerifier), uc.redirectURI(provider)), state, nil
+}
+
+// Finish exchanges the authorization code of a callback, signing in or linking the account
+func (uc *oauthUseCase) Finish(ctx context.Context, provider, state, code string) (*entity.OAuthResult, error) {
+	p, ok := uc.providers[provider]
+	if !ok {
+		return nil, ErrOAuthProviderNotFound
+	}
+
+	pending, err := uc.stateRepo.Consume(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil || pending.Provider != provider {
+		return nil, ErrInvalidOAuthState
+	}
+
+	profile, err := p.Exchange(ctx, code, pending.CodeVerifier, uc.redirectURI(provider))
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider).Msg("Failed to exchange OAuth authorization code")
+		if errors.Is(err, oauth.ErrInvalidCode) {
+			return nil, ErrInvalidOAuthCode
+		}
+		return nil, err
+	}
+
+	if pending.UserID != nil {
+		identity, err := uc.userUseCase.LinkOAuth(ctx, *pending.UserID, profile)
+		if err != nil {
+			return nil, err
+		}
+		return &entity.OAuthResult{Linked: identity}, nil
+	}
+
+	user, err := uc.userUseCase.SignInWithOAuth(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	login, err := uc.authUseCase.StartSession(ctx, user, provider)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.OAuthResult{Login: login}, nil
+}
+
+// Unlink unlinks the account of a user at a provider
+func (uc *oauthUseCase) Unlink(ctx context.Context, userID uuid.UUID, provider string) error {
+	return uc.userUseCase.UnlinkOAuth(ctx, userID, provider)
+}
+
+// redirectURI returns the callback URL of a provider, the same in the authorization and token requests
+func (uc *oauthUseCase) redirectURI(provider string) string {
+	return uc.callbackURL + "/" + provider + "/callback"
+}
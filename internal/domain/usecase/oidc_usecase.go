@@ -0,0 +1,509 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrOIDCInvalidClient is returned when the client is unknown or the redirect URI is not registered to it,
+	// or a confidential client fails to authenticate. The error must not be redirected to the redirect URI.
+	ErrOIDCInvalidClient = errors.New("invalid client")
+
+	// ErrOIDCInvalidRequest is returned when a parameter of an authorization or token request is missing or malformed
+	ErrOIDCInvalidRequest = errors.New("invalid OIDC request")
+
+	// ErrOIDCUnsupportedResponseType is returned when an authorization request asks for another response type than code
+	ErrOIDCUnsupportedResponseType = errors.New("unsupported response type")
+
+	// ErrOIDCInvalidScope is returned when the openid scope is missing or an unknown scope is requested
+	ErrOIDCInvalidScope = errors.New("invalid scope")
+
+	// ErrOIDCUnsupportedGrantType is returned when a token request uses another grant than authorization_code or
+	// refresh_token
+	ErrOIDCUnsupportedGrantType = errors.New("unsupported grant type")
+
+	// ErrOIDCInvalidGrant is returned when an authorization code is unknown, expired, already exchanged,
+	// issued to another client or redirect URI, or does not match the code verifier, or when a refresh token is
+	// invalid, revoked, already used or issued to another client
+	ErrOIDCInvalidGrant = errors.New("invalid grant")
+
+	// ErrOIDCInvalidToken is returned when requesting the user info with an access token not issued by the provider
+	ErrOIDCInvalidToken = errors.New("access token not issued by the OIDC provider")
+)
+
+const (
+	oidcResponseTypeCode           = "code"
+	oidcGrantTypeAuthorizationCode = "authorization_code"
+	oidcGrantTypeRefreshToken      = "refresh_token"
+	oidcCodeChallengeMethodS256    = "S256"
+
+	// Length bounds of PKCE code verifiers and challenges (RFC 7636)
+	pkceMinLength = 43
+	pkceMaxLength = 128
+)
+
+// oidcScopes are the supported scopes
+var oidcScopes = []string{entity.OIDCScopeOpenID, entity.OIDCScopeProfile, entity.OIDCScopeEmail, entity.OIDCScopeOfflineAccess}
+
+// OIDCUseCase defines the use case for the OpenID Connect provider.
+// Clients authenticate with PKCE, which is mandatory, and confidential clients with their secret as well.
+type OIDCUseCase interface {
+	// Discovery returns the OpenID Connect discovery document
+	Discovery() *entity.OpenIDConfiguration
+
+	// ValidateAuthorizationRequest checks an authorization request before the user is asked to sign in and approve it
+	ValidateAuthorizationRequest(req *entity.AuthorizationRequest) error
+
+	// Authorize issues an authorization code to the client for the signed in user,
+	// and returns the redirect URI carrying the code and state
+	Authorize(ctx context.Context, userID uuid.UUID, req *entity.AuthorizationRequest) (string, error)
+
+	// Exchange exchanges an authorization code for an access token and an ID token, or a refresh token for new
+	// tokens. Refresh tokens are only issued with offline access. Confidential clients authenticate with their secret.
+	Exchange(ctx context.Context, req *entity.TokenRequest) (*entity.OIDCTokenResponse, error)
+
+	// UserInfo returns the claims of the granted scopes about the user of a session issued by the provider
+	UserInfo(ctx context.Context, userID, sessionID uuid.UUID) (*entity.UserInfo, error)
+}
+
+// oidcUseCase implements OIDCUseCase interface
+type oidcUseCase struct {
+	userRepo     repository.UserRepository
+	tokenRepo    repository.TokenRepository
+	oidcRepo     repository.OIDCRepository
+	auditRepo    repository.AuditRepository
+	tokenService service.TokenService
+	nameService  service.NameService
+	config       config.OIDCConfig
+	issuer       string
+}
+
+// NewOIDCUseCase creates a new OIDCUseCase
+func NewOIDCUseCase(
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	oidcRepo repository.OIDCRepository,
+	auditRepo repository.AuditRepository,
+	tokenService service.TokenService,
+	nameService service.NameService,
+	cfg config.OIDCConfig,
+) OIDCUseCase {
+	return &oidcUseCase{
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		oidcRepo:     oidcRepo,
+		auditRepo:    auditRepo,
+		tokenService: tokenService,
+		nameService:  nameService,
+		config:       cfg,
+		issuer:       strings.TrimSuffix(cfg.Issuer, "/"),
+	}
+}
+
+// Discovery returns the OpenID Connect discovery document
+func (uc *oidcUseCase) Discovery() *entity.OpenIDConfiguration {
+	return &entity.OpenIDConfiguration{
+		Issuer:                            uc.issuer,
+		AuthorizationEndpoint:             uc.issuer + "/oidc/authorize",
+		TokenEndpoint:                     uc.issuer + "/oidc/token",
+		UserInfoEndpoint:                  uc.issuer + "/oidc/userinfo",
+		JWKSURI:                           uc.issuer + "/.well-known/jwks.json",
+		ResponseTypesSupported:            []string{oidcResponseTypeCode},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"EdDSA"},
+		ScopesSupported:                   oidcScopes,
+		ClaimsSupported:                   []string{"sub", "name", "given_name", "family_name", "preferred_username", "locale", "updated_at", "email", "email_verified"},
+		GrantTypesSupported:               []string{oidcGrantTypeAuthorizationCode, oidcGrantTypeRefreshToken},
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:     []string{oidcCodeChallengeMethodS256},
+	}
+}
+
+// ValidateAuthorizationRequest checks an authorization request before the user is asked to sign in and approve it.
+// The client and redirect URI are checked first, so other errors can safely be redirected to the client.
+func (uc *oidcUseCase) ValidateAuthorizationRequest(req *entity.AuthorizationRequest) error {
+	redirectURIs, ok := uc.config.Clients[req.ClientID]
+	if !ok || !slices.Contains(redirectURIs, req.RedirectURI) {
+		return ErrOIDCInvalidClient
+	}
+
+	if req.ResponseType != oidcResponseTypeCode {
+		return ErrOIDCUnsupportedResponseType
+	}
+
+	scopes := strings.Fields(req.Scope)
+	if !slices.Contains(scopes, entity.OIDCScopeOpenID) {
+		return ErrOIDCInvalidScope
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(oidcScopes, scope) {
+			return ErrOIDCInvalidScope
+		}
+	}
+
+	// Only S256 is accepted, the plain method would expose the verifier in the browser
+	if req.CodeChallengeMethod != oidcCodeChallengeMethodS256 || !isValidPKCEValue(req.CodeChallenge) {
+		return ErrOIDCInvalidRequest
+	}
+
+	return nil
+}
+
+// Authorize issues an authorization code to the client for the signed in user
+func (uc *oidcUseCase) Authorize(ctx context.Context, userID uuid.UUID, req *entity.AuthorizationRequest) (string, error) {
+	if err := uc.ValidateAuthorizationRequest(req); err != nil {
+		return "", err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", ErrUserNotFound
+	}
+	if user.Status != entity.UserStatusActive {
+		return "", ErrAccountInactive
+	}
+
+	code, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate authorization code: %w", err)
+	}
+
+	scopes := strings.Fields(req.Scope)
+	grant := &entity.AuthorizationGrant{
+		UserID:        user.ID,
+		ClientID:      req.ClientID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        scopes,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      time.Now(),
+	}
+	if err := uc.oidcRepo.StoreCode(ctx, code, grant, uc.config.CodeExpiration); err != nil {
+		return "", err
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionOIDCAuthorized, user.ID, user.ID, map[string]string{
+		"client_id": req.ClientID,
+		"scope":     strings.Join(scopes, " "),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to record OIDC authorization in audit log")
+	}
+
+	return AuthorizationRedirect(req, url.Values{"code": {code}}), nil
+}
+
+// Exchange exchanges an authorization code or a refresh token for tokens
+func (uc *oidcUseCase) Exchange(ctx context.Context, req *entity.TokenRequest) (*entity.OIDCTokenResponse, error) {
+	switch req.GrantType {
+	case oidcGrantTypeAuthorizationCode:
+		return uc.exchangeCode(ctx, req)
+	case oidcGrantTypeRefreshToken:
+		return uc.refresh(ctx, req)
+	default:
+		return nil, ErrOIDCUnsupportedGrantType
+	}
+}
+
+// exchangeCode exchanges an authorization code for an access token and an ID token, and a refresh token with
+// offline access
+func (uc *oidcUseCase) exchangeCode(ctx context.Context, req *entity.TokenRequest) (*entity.OIDCTokenResponse, error) {
+	if req.Code == "" || req.ClientID == "" || req.RedirectURI == "" || !isValidPKCEValue(req.CodeVerifier) {
+		return nil, ErrOIDCInvalidRequest
+	}
+	if !uc.authenticateClient(req.ClientID, req.ClientSecret) {
+		return nil, ErrOIDCInvalidClient
+	}
+
+	// The code is consumed before it is checked, so a mismatching attempt also burns it
+	grant, err := uc.oidcRepo.ConsumeCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if grant == nil || grant.ClientID != req.ClientID || grant.RedirectURI != req.RedirectURI {
+		return nil, ErrOIDCInvalidGrant
+	}
+
+	challenge := sha256.Sum256([]byte(req.CodeVerifier))
+	if subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(challenge[:])), []byte(grant.CodeChallenge)) != 1 {
+		return nil, ErrOIDCInvalidGrant
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, grant.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != entity.UserStatusActive {
+		return nil, ErrOIDCInvalidGrant
+	}
+
+	response, accessDetails, err := uc.issueTokens(ctx, user, grant, uuid.New(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := uc.tokenService.SignJWT(&entity.IDTokenClaims{
+		Issuer:    uc.issuer,
+		Audience:  grant.ClientID,
+		ExpiresAt: accessDetails.Expiration.Unix(),
+		IssuedAt:  accessDetails.IssuedAt.Unix(),
+		AuthTime:  grant.AuthTime.Unix(),
+		Nonce:     grant.Nonce,
+		UserInfo:  *uc.userInfo(user, grant.Scopes),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to sign ID token")
+		return nil, fmt.Errorf("failed to sign ID token: %w", err)
+	}
+	response.IDToken = idToken
+
+	return response, nil
+}
+
+// refresh exchanges a refresh token issued with offline access for new tokens of the same session and scopes,
+// rotating it. A rotated token presented again revokes the session, as for the refresh tokens of users.
+func (uc *oidcUseCase) refresh(ctx context.Context, req *entity.TokenRequest) (*entity.OIDCTokenResponse, error) {
+	if req.RefreshToken == "" || req.ClientID == "" {
+		return nil, ErrOIDCInvalidRequest
+	}
+	if !uc.authenticateClient(req.ClientID, req.ClientSecret) {
+		return nil, ErrOIDCInvalidClient
+	}
+
+	claims, err := uc.tokenService.ValidateToken(req.RefreshToken)
+	if err != nil || claims.TokenType != entity.OIDCRefreshToken || claims.ClientID != req.ClientID {
+		return nil, ErrOIDCInvalidGrant
+	}
+
+	// Reject the tokens revoked by an administrator
+	cutoff, err := uc.tokenRepo.GetRevocationCutoff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !cutoff.IsZero() && claims.IssuedAt.Before(cutoff) {
+		return nil, ErrOIDCInvalidGrant
+	}
+	denied, err := uc.tokenRepo.IsTokenDenied(ctx, claims.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	if denied {
+		return nil, ErrOIDCInvalidGrant
+	}
+
+	details, err := uc.tokenRepo.GetToken(ctx, claims.TokenID, entity.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if details == nil {
+		return nil, uc.checkRefreshTokenReuse(ctx, claims)
+	}
+	consumed, err := uc.tokenRepo.ConsumeRefreshToken(ctx, claims.TokenID, time.Until(details.Expiration))
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, uc.checkRefreshTokenReuse(ctx, claims)
+	}
+
+	grant, err := uc.oidcRepo.GetSessionGrant(ctx, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if grant == nil || grant.UserID != claims.UserID || grant.ClientID != req.ClientID {
+		return nil, ErrOIDCInvalidGrant
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status != entity.UserStatusActive {
+		return nil, ErrOIDCInvalidGrant
+	}
+
+	// Revoke the tokens being replaced, including the access token still valid
+	if err := uc.tokenRepo.DeleteSession(ctx, claims.SessionID); err != nil {
+		log.Warn().Err(err).Str("session_id", claims.SessionID.String()).Msg("Failed to delete replaced session tokens")
+	}
+
+	response, _, err := uc.issueTokens(ctx, user, grant, claims.SessionID, &claims.TokenID)
+	return response, err
+}
+
+// checkRefreshTokenReuse tells a revoked refresh token from one that was already rotated, whose session is
+// revoked as the token leaked
+func (uc *oidcUseCase) checkRefreshTokenReuse(ctx context.Context, claims *service.TokenClaims) error {
+	session, err := uc.tokenRepo.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.RevokedAt != nil || !session.HasToken(claims.TokenID, entity.RefreshToken) {
+		return ErrOIDCInvalidGrant
+	}
+
+	log.Warn().
+		Str("session_id", claims.SessionID.String()).
+		Str("client_id", claims.ClientID).
+		Str("user_id", claims.UserID.String()).
+		Msg("Rotated OIDC refresh token reused, revoking session")
+
+	if err := uc.tokenRepo.RevokeSession(ctx, claims.SessionID, entity.SessionRevokedTokenReuse); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return ErrOIDCInvalidGrant
+}
+
+// issueTokens issues the tokens of a grant to its client within a session, rotated from a refresh token unless
+// parentID is nil
+func (uc *oidcUseCase) issueTokens(ctx context.Context, user *entity.User, grant *entity.AuthorizationGrant, sessionID uuid.UUID, parentID *uuid.UUID) (*entity.OIDCTokenResponse, *entity.TokenDetails, error) {
+	tokens, accessDetails, refreshDetails, err := uc.tokenService.GenerateOIDCTokens(user, grant.ClientID, grant.Scopes, sessionID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to generate tokens")
+		return nil, nil, fmt.Errorf("failed to generate tokens: %w", err)
+	}
+	accessDetails.ParentID = parentID
+
+	// The grant is stored first and lives as long as the tokens, so they never work without the scopes they
+	// were issued for
+	grantExpiration := accessDetails.Expiration
+	if refreshDetails != nil {
+		refreshDetails.ParentID = parentID
+		grantExpiration = refreshDetails.Expiration
+	}
+	if err := uc.oidcRepo.StoreSessionGrant(ctx, sessionID, grant, time.Until(grantExpiration)); err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.tokenRepo.StoreAccessToken(ctx, accessDetails); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to store access token")
+		return nil, nil, fmt.Errorf("failed to store access token: %w", err)
+	}
+	if refreshDetails != nil {
+		if err := uc.tokenRepo.StoreRefreshToken(ctx, refreshDetails); err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to store refresh token")
+			return nil, nil, fmt.Errorf("failed to store refresh token: %w", err)
+		}
+	}
+
+	return &entity.OIDCTokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(accessDetails.Expiration).Seconds()),
+		RefreshToken: tokens.RefreshToken,
+		Scope:        strings.Join(grant.Scopes, " "),
+	}, accessDetails, nil
+}
+
+// authenticateClient checks the secret of a confidential client. Public clients have no secret and must not
+// send one.
+func (uc *oidcUseCase) authenticateClient(clientID, secret string) bool {
+	expected, confidential := uc.config.ClientSecrets[clientID]
+	if !confidential {
+		return secret == ""
+	}
+	// Compare digests, so the comparison takes as long whatever the length of the secret sent
+	expectedSum, secretSum := sha256.Sum256([]byte(expected)), sha256.Sum256([]byte(secret))
+	return subtle.ConstantTimeCompare(expectedSum[:], secretSum[:]) == 1
+}
+
+// UserInfo returns the claims of the granted scopes about the user of a session issued by the provider
+func (uc *oidcUseCase) UserInfo(ctx context.Context, userID, sessionID uuid.UUID) (*entity.UserInfo, error) {
+	grant, err := uc.oidcRepo.GetSessionGrant(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if grant == nil || grant.UserID != userID {
+		return nil, ErrOIDCInvalidToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return uc.userInfo(user, grant.Scopes), nil
+}
+
+// userInfo returns the claims of the given scopes about a user
+func (uc *oidcUseCase) userInfo(user *entity.User, scopes []string) *entity.UserInfo {
+	info := &entity.UserInfo{
+		Subject: user.ID.String(),
+	}
+
+	if slices.Contains(scopes, entity.OIDCScopeProfile) {
+		info.Name = uc.nameService.DisplayName(user)
+		info.GivenName = user.FirstName
+		info.FamilyName = user.LastName
+		info.PreferredUsername = user.Username
+		info.Locale = user.Locale
+		info.UpdatedAt = user.UpdatedAt.Unix()
+	}
+
+	if slices.Contains(scopes, entity.OIDCScopeEmail) {
+		emailVerified := user.EmailVerified
+		info.Email = user.Email
+		info.EmailVerified = &emailVerified
+	}
+
+	return info
+}
+
+// AuthorizationRedirect returns the redirect URI of an authorization request with the given parameters and its state
+func AuthorizationRedirect(req *entity.AuthorizationRequest, params url.Values) string {
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+
+	redirectURI, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		// Registered redirect URIs are trusted, append the parameters as is
+		return req.RedirectURI + "?" + params.Encode()
+	}
+
+	query := redirectURI.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	redirectURI.RawQuery = query.Encode()
+	return redirectURI.String()
+}
+
+// isValidPKCEValue reports whether a PKCE code verifier or challenge has a valid length and alphabet
+func isValidPKCEValue(value string) bool {
+	if len(value) < pkceMinLength || len(value) > pkceMaxLength {
+		return false
+	}
+
+	for _, r := range value {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.', r == '_', r == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
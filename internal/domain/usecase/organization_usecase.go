@@ -0,0 +1,482 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrOrganizationNotFound    = errors.New("organization not found")
+	ErrInvalidOrganizationName = errors.New("invalid organization name")
+	ErrNotOrganizationMember   = errors.New("user is not a member of the organization")
+	ErrInvalidProfileFields    = errors.New("invalid profile field rules")
+	ErrInvalidBranding         = errors.New("invalid branding")
+	ErrInvalidSSOPolicy        = errors.New("invalid single sign-on policy")
+	ErrInvalidPlan             = errors.New("invalid organization plan")
+
+	// ErrMemberLimitReached is returned when adding a member to an organization holding as many members as its
+	// plan allows
+	ErrMemberLimitReached = errors.New("organization member limit reached")
+)
+
+// OrganizationUseCase defines the use case for organizations and their members
+type OrganizationUseCase interface {
+	// CreateOrganization creates a new organization
+	CreateOrganization(ctx context.Context, name string) (*entity.Organization, error)
+
+	// GetOrganization returns an organization
+	GetOrganization(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+
+	// ListOrganizations returns all organizations
+	ListOrganizations(ctx context.Context) ([]*entity.Organization, error)
+
+	// AddMember moves a user into an organization, performed by a platform administrator
+	AddMember(ctx context.Context, actorID, orgID, userID uuid.UUID) error
+
+	// RemoveMember removes a user from an organization, performed by a platform administrator
+	RemoveMember(ctx context.Context, actorID, orgID, userID uuid.UUID) error
+
+	// GetMemberQuota returns the number of members of an organization against the limit of its plan
+	GetMemberQuota(ctx context.Context, orgID uuid.UUID) (*entity.MemberQuota, error)
+
+	// CheckMemberLimit returns ErrMemberLimitReached when an organization cannot take another member
+	CheckMemberLimit(ctx context.Context, org *entity.Organization) error
+
+	// MemberAdded publishes the organization.member_limit_reached event when a new member filled the last seat
+	// of an organization
+	MemberAdded(ctx context.Context, org *entity.Organization)
+
+	// SetPlan changes the plan of an organization and overrides the member limit of the plan when memberLimit is
+	// set, performed by a platform administrator
+	SetPlan(ctx context.Context, actorID, orgID uuid.UUID, plan string, memberLimit *int) (*entity.Organization, error)
+
+	// SetProfileFields replaces the profile field rules of an organization, performed by an administrator
+	SetProfileFields(ctx context.Context, actorID, orgID uuid.UUID, rules entity.ProfileFieldRules) (*entity.Organization, error)
+
+	// SetSelfRegistration opens or closes an organization to self-registration, performed by a platform administrator
+	SetSelfRegistration(ctx context.Context, actorID, orgID uuid.UUID, enabled bool) (*entity.Organization, error)
+
+	// GetBranding returns the branding of an organization, completed with the default branding
+	GetBranding(ctx context.Context, orgID uuid.UUID) (entity.Branding, error)
+
+	// SetBranding replaces the branding of an organization, performed by an administrator
+	SetBranding(ctx context.Context, actorID, orgID uuid.UUID, branding entity.Branding) (*entity.Organization, error)
+
+	// SetSSOPolicy replaces the single sign-on policy of an organization, performed by a platform administrator
+	SetSSOPolicy(ctx context.Context, actorID, orgID uuid.UUID, policy entity.SSOPolicy) (*entity.Organization, error)
+}
+
+// organizationUseCase implements OrganizationUseCase interface
+type organizationUseCase struct {
+	orgRepo      repository.OrganizationRepository
+	userRepo     repository.UserRepository
+	tokenRepo    repository.TokenRepository
+	auditRepo    repository.AuditRepository
+	eventService service.EventService
+	branding     entity.Branding
+	plans        config.OrganizationConfig
+
+	// ssoProviders are the names of the configured providers organizations may require their members to sign
+	// in with
+	ssoProviders []string
+}
+
+// NewOrganizationUseCase creates a new OrganizationUseCase
+func NewOrganizationUseCase(
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+	auditRepo repository.AuditRepository,
+	eventService service.EventService,
+	brandingConfig config.BrandingConfig,
+	orgConfig config.OrganizationConfig,
+	ssoProviders []string,
+) OrganizationUseCase {
+	return &organizationUseCase{
+		orgRepo:      orgRepo,
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		auditRepo:    auditRepo,
+		eventService: eventService,
+		branding:     defaultBranding(brandingConfig),
+		plans:        orgConfig,
+		ssoProviders: ssoProviders,
+	}
+}
+
+// defaultBranding returns the branding of the service, used for the settings organizations leave empty
+func defaultBranding(cfg config.BrandingConfig) entity.Branding {
+	return entity.Branding{
+		ProductName:  cfg.ProductName,
+		LogoURL:      cfg.LogoURL,
+		SupportEmail: cfg.SupportEmail,
+		PrimaryColor: cfg.PrimaryColor,
+		AccentColor:  cfg.AccentColor,
+	}
+}
+
+// CreateOrganization creates a new organization
+func (uc *organizationUseCase) CreateOrganization(ctx context.Context, name string) (*entity.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrInvalidOrganizationName
+	}
+
+	org := entity.NewOrganization(name)
+	if err := uc.orgRepo.Create(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// GetOrganization returns an organization
+func (uc *organizationUseCase) GetOrganization(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	org, err := uc.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	return org, nil
+}
+
+// ListOrganizations returns all organizations
+func (uc *organizationUseCase) ListOrganizations(ctx context.Context) ([]*entity.Organization, error) {
+	return uc.orgRepo.List(ctx)
+}
+
+// AddMember moves a user into an organization, within the member limit of the organization. Users already members
+// are left as they are.
+func (uc *organizationUseCase) AddMember(ctx context.Context, actorID, orgID, userID uuid.UUID) error {
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.OrgID != nil && *user.OrgID == orgID {
+		return nil
+	}
+	if err := uc.CheckMemberLimit(ctx, org); err != nil {
+		return err
+	}
+
+	if err := uc.setOrganization(ctx, actorID, userID, &orgID); err != nil {
+		return err
+	}
+	uc.MemberAdded(ctx, org)
+	return nil
+}
+
+// RemoveMember removes a user from an organization
+func (uc *organizationUseCase) RemoveMember(ctx context.Context, actorID, orgID, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.OrgID == nil || *user.OrgID != orgID {
+		return ErrNotOrganizationMember
+	}
+
+	return uc.setOrganization(ctx, actorID, userID, nil)
+}
+
+// GetMemberQuota returns the number of members of an organization against the limit of its plan
+func (uc *organizationUseCase) GetMemberQuota(ctx context.Context, orgID uuid.UUID) (*entity.MemberQuota, error) {
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.memberQuota(ctx, org)
+}
+
+// CheckMemberLimit returns ErrMemberLimitReached when an organization cannot take another member. The limit is
+// soft: members beyond a lowered limit are kept, only new members are refused.
+func (uc *organizationUseCase) CheckMemberLimit(ctx context.Context, org *entity.Organization) error {
+	plan, limit, _ := uc.memberLimit(org)
+	if limit == 0 {
+		return nil
+	}
+
+	quota, err := uc.memberQuota(ctx, org)
+	if err != nil {
+		return err
+	}
+	if quota.Reached() {
+		log.Warn().Str("org_id", org.ID.String()).Str("plan", plan).Int("member_limit", limit).Int64("members", quota.Members).Msg("Organization member limit reached, new member refused")
+		return ErrMemberLimitReached
+	}
+	return nil
+}
+
+// MemberAdded publishes the organization.member_limit_reached event when a new member filled the last seat. The
+// member has already been added, so failures are logged rather than returned.
+func (uc *organizationUseCase) MemberAdded(ctx context.Context, org *entity.Organization) {
+	if _, limit, _ := uc.memberLimit(org); limit == 0 {
+		return
+	}
+
+	quota, err := uc.memberQuota(ctx, org)
+	if err != nil {
+		log.Error().Err(err).Str("org_id", org.ID.String()).Msg("Failed to count organization members")
+		return
+	}
+	// Only the member filling the last seat raises the event, not those added beyond it by a raised limit
+	if quota.Members != int64(quota.MemberLimit) {
+		return
+	}
+
+	publishEvent(ctx, uc.eventService, entity.EventOrgMemberLimitReached, &entity.OrgMemberLimitReachedEvent{
+		OrgID:       org.ID,
+		Plan:        quota.Plan,
+		MemberLimit: quota.MemberLimit,
+		Members:     quota.Members,
+		ReachedAt:   time.Now(),
+	})
+}
+
+// SetPlan changes the plan of an organization, an empty plan following the default plan. The member limit
+// overrides the limit of the plan when set, 0 lifting it, and is cleared otherwise.
+func (uc *organizationUseCase) SetPlan(ctx context.Context, actorID, orgID uuid.UUID, plan string, memberLimit *int) (*entity.Organization, error) {
+	plan = strings.TrimSpace(plan)
+	if _, ok := uc.plans.Plans[plan]; plan != "" && !ok {
+		return nil, ErrInvalidPlan
+	}
+	if memberLimit != nil && *memberLimit < 0 {
+		return nil, ErrInvalidPlan
+	}
+
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.Plan = plan
+	org.MemberLimit = memberLimit
+	org.UpdatedAt = time.Now()
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{
+		"org_id":       orgID.String(),
+		"plan":         plan,
+		"member_limit": "",
+	}
+	if memberLimit != nil {
+		details["member_limit"] = strconv.Itoa(*memberLimit)
+	}
+	uc.recordChange(ctx, entity.AuditActionOrgPlanChanged, actorID, details)
+
+	return org, nil
+}
+
+// memberLimit returns the plan of an organization and its member limit, 0 when unlimited, and whether the limit
+// was overridden by a platform admin
+func (uc *organizationUseCase) memberLimit(org *entity.Organization) (string, int, bool) {
+	plan := cmp.Or(org.Plan, uc.plans.DefaultPlan)
+	if org.MemberLimit != nil {
+		return plan, *org.MemberLimit, true
+	}
+	return plan, uc.plans.Plans[plan], false
+}
+
+// memberQuota counts the members of an organization against its limit, whatever their status
+func (uc *organizationUseCase) memberQuota(ctx context.Context, org *entity.Organization) (*entity.MemberQuota, error) {
+	_, members, err := uc.userRepo.List(ctx, 1, 1, entity.UserListOptions{OrgID: &org.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	plan, limit, overridden := uc.memberLimit(org)
+	return &entity.MemberQuota{
+		OrgID:       org.ID,
+		Plan:        plan,
+		MemberLimit: limit,
+		Members:     members,
+		Overridden:  overridden,
+	}, nil
+}
+
+// setOrganization updates the organization of a user, records the change in the audit trail and signs the user out
+// of every session, their tokens carrying the organization scoping what they may administer
+func (uc *organizationUseCase) setOrganization(ctx context.Context, actorID, userID uuid.UUID, orgID *uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	user.OrgID = orgID
+	user.UpdatedAt = time.Now()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	details := map[string]string{"org_id": ""}
+	if orgID != nil {
+		details["org_id"] = orgID.String()
+	}
+	entry := entity.NewAuditEntry(entity.AuditActionUserOrgChanged, actorID, userID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to record organization change in audit trail")
+	}
+
+	// Sign the user out of every session, so no token keeps acting for the previous organization
+	return uc.tokenRepo.DeleteUserTokens(ctx, userID)
+}
+
+// SetProfileFields replaces the profile field rules of an organization.
+// The rules apply to the next registration or update of the members, existing profiles are left as they are.
+func (uc *organizationUseCase) SetProfileFields(ctx context.Context, actorID, orgID uuid.UUID, rules entity.ProfileFieldRules) (*entity.Organization, error) {
+	if !rules.Valid() {
+		return nil, ErrInvalidProfileFields
+	}
+
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.ProfileFields = rules
+	org.UpdatedAt = time.Now()
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"org_id": orgID.String()}
+	for field, mode := range rules {
+		details[field] = mode
+	}
+	uc.recordChange(ctx, entity.AuditActionOrgProfileFieldsChanged, actorID, details)
+
+	return org, nil
+}
+
+// SetSelfRegistration opens or closes an organization to self-registration
+func (uc *organizationUseCase) SetSelfRegistration(ctx context.Context, actorID, orgID uuid.UUID, enabled bool) (*entity.Organization, error) {
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.SelfRegistration = enabled
+	org.UpdatedAt = time.Now()
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	uc.recordChange(ctx, entity.AuditActionOrgSelfRegistration, actorID, map[string]string{
+		"org_id":            orgID.String(),
+		"self_registration": strconv.FormatBool(enabled),
+	})
+
+	return org, nil
+}
+
+// GetBranding returns the branding of an organization, completed with the default branding
+func (uc *organizationUseCase) GetBranding(ctx context.Context, orgID uuid.UUID) (entity.Branding, error) {
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return entity.Branding{}, err
+	}
+
+	return org.Branding.WithDefaults(uc.branding), nil
+}
+
+// SetBranding replaces the branding of an organization, empty settings fall back to the default branding
+func (uc *organizationUseCase) SetBranding(ctx context.Context, actorID, orgID uuid.UUID, branding entity.Branding) (*entity.Organization, error) {
+	branding.ProductName = strings.TrimSpace(branding.ProductName)
+	if !branding.Valid() {
+		return nil, ErrInvalidBranding
+	}
+
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.Branding = branding
+	org.UpdatedAt = time.Now()
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	uc.recordChange(ctx, entity.AuditActionOrgBrandingChanged, actorID, map[string]string{
+		"org_id":        orgID.String(),
+		"product_name":  branding.ProductName,
+		"logo_url":      branding.LogoURL,
+		"support_email": branding.SupportEmail,
+		"primary_color": branding.PrimaryColor,
+		"accent_color":  branding.AccentColor,
+	})
+
+	return org, nil
+}
+
+// SetSSOPolicy replaces the single sign-on policy of an organization. A required policy must name a configured
+// provider, the members could not sign in at all otherwise.
+func (uc *organizationUseCase) SetSSOPolicy(ctx context.Context, actorID, orgID uuid.UUID, policy entity.SSOPolicy) (*entity.Organization, error) {
+	policy.Provider = strings.ToLower(strings.TrimSpace(policy.Provider))
+	if policy.Provider != "" && !slices.Contains(uc.ssoProviders, policy.Provider) {
+		return nil, ErrInvalidSSOPolicy
+	}
+	if policy.Required && policy.Provider == "" {
+		return nil, ErrInvalidSSOPolicy
+	}
+
+	org, err := uc.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.SSO = policy
+	org.UpdatedAt = time.Now()
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	uc.recordChange(ctx, entity.AuditActionOrgSSOChanged, actorID, map[string]string{
+		"org_id":       orgID.String(),
+		"sso_required": strconv.FormatBool(policy.Required),
+		"provider":     policy.Provider,
+	})
+
+	return org, nil
+}
+
+// recordChange records a change of the settings of an organization in the audit trail
+func (uc *organizationUseCase) recordChange(ctx context.Context, action string, actorID uuid.UUID, details map[string]string) {
+	entry := entity.NewAuditEntry(action, actorID, uuid.Nil, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("org_id", details["org_id"]).Msg("Failed to record organization change in audit trail")
+	}
+}
@@ -0,0 +1,372 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrQuotaExceeded is returned when an API key or service account made all the requests its quota allows in
+	// a period
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// ErrInvalidQuota is returned when setting a quota with a negative number of requests
+	ErrInvalidQuota = errors.New("invalid quota")
+)
+
+// quotaCounterGrace keeps the counter of a window past its end, so the usage of a window just ended can still be
+// read
+const quotaCounterGrace = time.Hour
+
+// QuotaUseCase defines the use case for the request quotas of API keys and service accounts
+type QuotaUseCase interface {
+	// ConsumeAPIKey counts a request of an API key. ErrQuotaExceeded is returned along with the usage of the
+	// exhausted period when the key made all the requests its quota allows.
+	ConsumeAPIKey(ctx context.Context, key *entity.APIKey) (*entity.QuotaUsage, error)
+
+	// ConsumeServiceAccount counts a request of a service account, like ConsumeAPIKey
+	ConsumeServiceAccount(ctx context.Context, id uuid.UUID) (*entity.QuotaUsage, error)
+
+	// APIKeyUsage returns the quota and usage of an API key of a user
+	APIKeyUsage(ctx context.Context, userID, id uuid.UUID) (*entity.QuotaReport, error)
+
+	// OrgAPIKeyUsage returns the quota and usage of an API key of an organization
+	OrgAPIKeyUsage(ctx context.Context, orgID, id uuid.UUID) (*entity.QuotaReport, error)
+
+	// ServiceAccountUsage returns the quota and usage of a service account
+	ServiceAccountUsage(ctx context.Context, id uuid.UUID) (*entity.QuotaReport, error)
+
+	// SetAPIKeyQuota sets the quota of an API key of a user on behalf of an administrator, nil restoring the
+	// default quota
+	SetAPIKeyQuota(ctx context.Context, actorID, userID, id uuid.UUID, quota *entity.Quota) (*entity.QuotaReport, error)
+
+	// SetOrgAPIKeyQuota sets the quota of an API key of an organization on behalf of an administrator, like
+	// SetAPIKeyQuota
+	SetOrgAPIKeyQuota(ctx context.Context, actorID, orgID, id uuid.UUID, quota *entity.Quota) (*entity.QuotaReport, error)
+
+	// SetServiceAccountQuota sets the quota of a service account on behalf of an administrator, like
+	// SetAPIKeyQuota
+	SetServiceAccountQuota(ctx context.Context, actorID, id uuid.UUID, quota *entity.Quota) (*entity.QuotaReport, error)
+}
+
+// quotaUseCase implements QuotaUseCase interface
+type quotaUseCase struct {
+	quotaRepo          repository.QuotaRepository
+	apiKeyRepo         repository.APIKeyRepository
+	serviceAccountRepo repository.ServiceAccountRepository
+	auditRepo          repository.AuditRepository
+	eventService       service.EventService
+
+	defaultQuota entity.Quota
+}
+
+// NewQuotaUseCase creates a new QuotaUseCase
+func NewQuotaUseCase(
+	quotaRepo repository.QuotaRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	serviceAccountRepo repository.ServiceAccountRepository,
+	auditRepo repository.AuditRepository,
+	eventService service.EventService,
+	quotaCfg config.QuotaConfig,
+) QuotaUseCase {
+	return &quotaUseCase{
+		quotaRepo:          quotaRepo,
+		apiKeyRepo:         apiKeyRepo,
+		serviceAccountRepo: serviceAccountRepo,
+		auditRepo:          auditRepo,
+		eventService:       eventService,
+		defaultQuota: entity.Quota{
+			Daily:   quotaCfg.DefaultDaily,
+			Monthly: quotaCfg.DefaultMonthly,
+		},
+	}
+}
+
+// ConsumeAPIKey counts a request of an API key against its quota, the default one when it has none
+func (uc *quotaUseCase) ConsumeAPIKey(ctx context.Context, key *entity.APIKey) (*entity.QuotaUsage, error) {
+	return uc.consume(ctx, entity.QuotaSubjectAPIKey, key.ID, uc.quotaOf(key.Quota))
+}
+
+// ConsumeServiceAccount counts a request of a service account against its quota, the default one when it has
+// none or was deleted since its token was issued
+func (uc *quotaUseCase) ConsumeServiceAccount(ctx context.Context, id uuid.UUID) (*entity.QuotaUsage, error) {
+	account, err := uc.serviceAccountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var quota *entity.Quota
+	if account != nil {
+		quota = account.Quota
+	}
+	return uc.consume(ctx, entity.QuotaSubjectServiceAccount, id, uc.quotaOf(quota))
+}
+
+// consume counts a request of a subject in the current day and month. The day is counted first and a request
+// refused for the day is not counted in the month, so a day over its quota does not eat into the month. Only the
+// first refused request of a window publishes the quota.exceeded event, the counters being shared by the
+// instances.
+func (uc *quotaUseCase) consume(ctx context.Context, subjectType string, subjectID uuid.UUID, quota entity.Quota) (*entity.QuotaUsage, error) {
+	now := time.Now()
+	for _, period := range []string{entity.QuotaPeriodDaily, entity.QuotaPeriodMonthly} {
+		window, resetsAt := entity.QuotaWindow(period, now)
+		used, err := uc.quotaRepo.Increment(ctx, quotaSubject(subjectType, subjectID), window, resetsAt.Sub(now)+quotaCounterGrace)
+		if err != nil {
+			return nil, err
+		}
+
+		usage := &entity.QuotaUsage{
+			Period:   period,
+			Used:     used,
+			Limit:    quotaLimit(quota, period),
+			ResetsAt: resetsAt,
+		}
+		if !usage.Exceeded() {
+			continue
+		}
+
+		if usage.Used == usage.Limit+1 {
+			publishEvent(ctx, uc.eventService, entity.EventQuotaExceeded, &entity.QuotaExceededEvent{
+				SubjectType: subjectType,
+				SubjectID:   subjectID,
+				Period:      period,
+				Limit:       usage.Limit,
+				ResetsAt:    resetsAt,
+				ExceededAt:  now,
+			})
+		}
+		return usage, ErrQuotaExceeded
+	}
+
+	return nil, nil
+}
+
+// APIKeyUsage returns the quota and usage of an API key of a user. API keys of other users are reported as not
+// found.
+func (uc *quotaUseCase) APIKeyUsage(ctx context.Context, userID, id uuid.UUID) (*entity.QuotaReport, error) {
+	key, err := uc.userAPIKey(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.report(ctx, entity.QuotaSubjectAPIKey, key.ID, key.Quota)
+}
+
+// OrgAPIKeyUsage returns the quota and usage of an API key of an organization. API keys of users and other
+// organizations are reported as not found.
+func (uc *quotaUseCase) OrgAPIKeyUsage(ctx context.Context, orgID, id uuid.UUID) (*entity.QuotaReport, error) {
+	key, err := uc.orgAPIKey(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.report(ctx, entity.QuotaSubjectAPIKey, key.ID, key.Quota)
+}
+
+// ServiceAccountUsage returns the quota and usage of a service account
+func (uc *quotaUseCase) ServiceAccountUsage(ctx context.Context, id uuid.UUID) (*entity.QuotaReport, error) {
+	account, err := uc.serviceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.report(ctx, entity.QuotaSubjectServiceAccount, account.ID, account.Quota)
+}
+
+// SetAPIKeyQuota sets the quota of an API key of a user, recorded in the audit trail with the user as the target
+func (uc *quotaUseCase) SetAPIKeyQuota(ctx context.Context, actorID, userID, id uuid.UUID, quota *entity.Quota) (*entity.QuotaReport, error) {
+	if !validQuota(quota) {
+		return nil, ErrInvalidQuota
+	}
+
+	key, err := uc.userAPIKey(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Quota = quota
+	if err := uc.apiKeyRepo.UpdateQuota(ctx, key); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionAPIKeyQuotaChanged, actorID, userID, quota, map[string]string{
+		"api_key_id":   key.ID.String(),
+		"api_key_name": key.Name,
+	})
+	return uc.report(ctx, entity.QuotaSubjectAPIKey, key.ID, key.Quota)
+}
+
+// SetOrgAPIKeyQuota sets the quota of an API key of an organization, recorded in the audit trail without a
+// target like the other actions on organization keys
+func (uc *quotaUseCase) SetOrgAPIKeyQuota(ctx context.Context, actorID, orgID, id uuid.UUID, quota *entity.Quota) (*entity.QuotaReport, error) {
+	if !validQuota(quota) {
+		return nil, ErrInvalidQuota
+	}
+
+	key, err := uc.orgAPIKey(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Quota = quota
+	if err := uc.apiKeyRepo.UpdateQuota(ctx, key); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionOrgAPIKeyQuotaChanged, actorID, uuid.Nil, quota, map[string]string{
+		"org_id":       orgID.String(),
+		"api_key_id":   key.ID.String(),
+		"api_key_name": key.Name,
+	})
+	return uc.report(ctx, entity.QuotaSubjectAPIKey, key.ID, key.Quota)
+}
+
+// SetServiceAccountQuota sets the quota of a service account, recorded in the audit trail with the service
+// account as the target
+func (uc *quotaUseCase) SetServiceAccountQuota(ctx context.Context, actorID, id uuid.UUID, quota *entity.Quota) (*entity.QuotaReport, error) {
+	if !validQuota(quota) {
+		return nil, ErrInvalidQuota
+	}
+
+	account, err := uc.serviceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Quota = quota
+	account.UpdatedAt = time.Now()
+	if err := uc.serviceAccountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionServiceAccountQuotaSet, actorID, account.ID, quota, map[string]string{
+		"client_id": account.ClientID,
+		"name":      account.Name,
+	})
+	return uc.report(ctx, entity.QuotaSubjectServiceAccount, account.ID, account.Quota)
+}
+
+// report returns the quota of a subject and its usage of the current day and month
+func (uc *quotaUseCase) report(ctx context.Context, subjectType string, subjectID uuid.UUID, custom *entity.Quota) (*entity.QuotaReport, error) {
+	quota := uc.quotaOf(custom)
+	report := &entity.QuotaReport{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		Quota:       quota,
+		Custom:      custom != nil,
+	}
+
+	now := time.Now()
+	for _, period := range []string{entity.QuotaPeriodDaily, entity.QuotaPeriodMonthly} {
+		window, resetsAt := entity.QuotaWindow(period, now)
+		used, err := uc.quotaRepo.Get(ctx, quotaSubject(subjectType, subjectID), window)
+		if err != nil {
+			return nil, err
+		}
+
+		usage := &entity.QuotaUsage{
+			Period:   period,
+			Used:     used,
+			Limit:    quotaLimit(quota, period),
+			ResetsAt: resetsAt,
+		}
+		if period == entity.QuotaPeriodDaily {
+			report.Daily = usage
+		} else {
+			report.Monthly = usage
+		}
+	}
+
+	return report, nil
+}
+
+// userAPIKey returns an API key of a user, ErrAPIKeyNotFound for the keys of others
+func (uc *quotaUseCase) userAPIKey(ctx context.Context, userID, id uuid.UUID) (*entity.APIKey, error) {
+	keys, err := uc.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return findAPIKey(keys, id)
+}
+
+// orgAPIKey returns an API key of an organization, ErrAPIKeyNotFound for the keys of users and others
+func (uc *quotaUseCase) orgAPIKey(ctx context.Context, orgID, id uuid.UUID) (*entity.APIKey, error) {
+	keys, err := uc.apiKeyRepo.ListByOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return findAPIKey(keys, id)
+}
+
+// serviceAccount returns a service account, ErrServiceAccountNotFound if unknown
+func (uc *quotaUseCase) serviceAccount(ctx context.Context, id uuid.UUID) (*entity.ServiceAccount, error) {
+	account, err := uc.serviceAccountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrServiceAccountNotFound
+	}
+	return account, nil
+}
+
+// quotaOf returns the quota of a subject, the default quota when it has none of its own
+func (uc *quotaUseCase) quotaOf(quota *entity.Quota) entity.Quota {
+	if quota == nil {
+		return uc.defaultQuota
+	}
+	return *quota
+}
+
+// recordAction records a quota change in the audit trail, "default" standing for the default quota
+func (uc *quotaUseCase) recordAction(ctx context.Context, action string, actorID, targetID uuid.UUID, quota *entity.Quota, details map[string]string) {
+	details["daily"], details["monthly"] = "default", "default"
+	if quota != nil {
+		details["daily"] = strconv.FormatInt(quota.Daily, 10)
+		details["monthly"] = strconv.FormatInt(quota.Monthly, 10)
+	}
+
+	entry := entity.NewAuditEntry(action, actorID, targetID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("target_id", targetID.String()).Msg("Failed to record quota change in audit trail")
+	}
+}
+
+// findAPIKey returns the API key of an ID among keys, ErrAPIKeyNotFound if missing
+func findAPIKey(keys []*entity.APIKey, id uuid.UUID) (*entity.APIKey, error) {
+	index := slices.IndexFunc(keys, func(key *entity.APIKey) bool {
+		return key.ID == id
+	})
+	if index < 0 {
+		return nil, ErrAPIKeyNotFound
+	}
+	return keys[index], nil
+}
+
+// quotaSubject returns the subject the requests of an API key or service account are counted under
+func quotaSubject(subjectType string, subjectID uuid.UUID) string {
+	return subjectType + ":" + subjectID.String()
+}
+
+// quotaLimit returns the limit of a quota for a period, 0 for unlimited
+func quotaLimit(quota entity.Quota, period string) int64 {
+	if period == entity.QuotaPeriodDaily {
+		return quota.Daily
+	}
+	return quota.Monthly
+}
+
+// validQuota reports whether a quota limits requests to a non-negative number, nil restoring the default quota
+func validQuota(quota *entity.Quota) bool {
+	return quota == nil || (quota.Daily >= 0 && quota.Monthly >= 0)
+}
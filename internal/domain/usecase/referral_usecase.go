@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrInvalidReferralCode is returned when registering with an unknown referral code, or the code of a user
+	// who cannot refer anyone anymore
+	ErrInvalidReferralCode = errors.New("invalid referral code")
+)
+
+const (
+	// referralCodeAlphabet leaves out lookalike characters, so codes can be read out and typed back
+	referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	referralCodeLength   = 8
+
+	// referralCodeAttempts bounds the codes drawn before giving up on collisions
+	referralCodeAttempts = 5
+
+	// maxLeaderboardSize caps the referrers listed in the leaderboard
+	maxLeaderboardSize = 100
+)
+
+// ReferralUseCase defines the use case for referral codes and the referral stats of users
+type ReferralUseCase interface {
+	// GetStats returns the referral code of a user, created on first use, and the number of users they referred
+	GetStats(ctx context.Context, userID uuid.UUID) (*entity.ReferralStats, error)
+
+	// Leaderboard returns the users with the most referrals, most referrals first
+	Leaderboard(ctx context.Context, limit int) ([]*entity.ReferrerRanking, error)
+}
+
+// referralUseCase implements ReferralUseCase interface
+type referralUseCase struct {
+	referralRepo repository.ReferralRepository
+	userRepo     repository.UserRepository
+}
+
+// NewReferralUseCase creates a new ReferralUseCase
+func NewReferralUseCase(referralRepo repository.ReferralRepository, userRepo repository.UserRepository) ReferralUseCase {
+	return &referralUseCase{
+		referralRepo: referralRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// GetStats returns the referral code of a user and the number of users they referred
+func (uc *referralUseCase) GetStats(ctx context.Context, userID uuid.UUID) (*entity.ReferralStats, error) {
+	code, err := uc.codeOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.referralRepo.GetStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stats.Code = code.Code
+
+	return stats, nil
+}
+
+// codeOf returns the referral code of a user, creating it on first use
+func (uc *referralUseCase) codeOf(ctx context.Context, userID uuid.UUID) (*entity.ReferralCode, error) {
+	code, err := uc.referralRepo.GetCodeByUser(ctx, userID)
+	if err != nil || code != nil {
+		return code, err
+	}
+
+	for range referralCodeAttempts {
+		value, err := generateReferralCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate referral code: %w", err)
+		}
+
+		code = &entity.ReferralCode{
+			Code:      value,
+			UserID:    userID,
+			CreatedAt: time.Now(),
+		}
+		created, err := uc.referralRepo.CreateCode(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return code, nil
+		}
+		log.Warn().Str("user_id", userID.String()).Msg("Referral code collision, drawing another code")
+	}
+
+	return nil, fmt.Errorf("failed to generate a unique referral code after %d attempts", referralCodeAttempts)
+}
+
+// Leaderboard returns the users with the most referrals, most referrals first
+func (uc *referralUseCase) Leaderboard(ctx context.Context, limit int) ([]*entity.ReferrerRanking, error) {
+	if limit <= 0 || limit > maxLeaderboardSize {
+		limit = maxLeaderboardSize
+	}
+
+	rankings, err := uc.referralRepo.Leaderboard(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Name the referrers, purged users are listed by ID only
+	for _, ranking := range rankings {
+		user, err := uc.userRepo.GetByID(ctx, ranking.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			ranking.Username = user.Username
+		}
+	}
+
+	return rankings, nil
+}
+
+// generateReferralCode draws a random referral code
+func generateReferralCode() (string, error) {
+	code := make([]byte, referralCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(referralCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = referralCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrRoleChangeNotFound is returned when acting on an unknown role change request
+	ErrRoleChangeNotFound = errors.New("role change request not found")
+
+	// ErrRoleChangeDecided is returned when deciding on a role change request already approved, rejected or expired
+	ErrRoleChangeDecided = errors.New("role change request already decided")
+
+	// ErrRoleChangeSelfApproval is returned when an administrator decides on their own role change request
+	ErrRoleChangeSelfApproval = errors.New("role change requested by the same administrator")
+
+	// ErrRoleChangeAlreadyRequested is returned when requesting a role change already pending approval
+	ErrRoleChangeAlreadyRequested = errors.New("role change already pending approval")
+
+	// ErrInvalidRoleChangeStatus is returned when filtering role change requests on an unknown status
+	ErrInvalidRoleChangeStatus = errors.New("invalid role change request status")
+)
+
+// RoleApprovalUseCase defines the use case for the two-person rule on privileged role changes: granting a
+// privileged role files a request, which takes effect once a second administrator approves it
+type RoleApprovalUseCase interface {
+	// RequiresApproval reports whether granting a role needs the approval of a second administrator
+	RequiresApproval(role string) bool
+
+	// Request files a request to grant a role to a user on behalf of an administrator, as their primary role or
+	// assigned on top of it, for the duration from the approval when not zero
+	Request(ctx context.Context, actorID, userID uuid.UUID, kind, role string, duration time.Duration, reason entity.ActionReason) (*entity.RoleChangeRequest, error)
+
+	// List returns the requests with a status, all of them when the status is empty, newest first
+	List(ctx context.Context, status string) ([]*entity.RoleChangeRequest, error)
+
+	// Get returns a request
+	Get(ctx context.Context, id uuid.UUID) (*entity.RoleChangeRequest, error)
+
+	// Approve applies a pending request on behalf of an administrator other than its requester
+	Approve(ctx context.Context, actorID, id uuid.UUID, note string) (*entity.RoleChangeRequest, error)
+
+	// Reject turns down a pending request on behalf of an administrator other than its requester
+	Reject(ctx context.Context, actorID, id uuid.UUID, note string) (*entity.RoleChangeRequest, error)
+}
+
+// roleApprovalUseCase implements RoleApprovalUseCase interface
+type roleApprovalUseCase struct {
+	requestRepo repository.RoleChangeRequestRepository
+	userRepo    repository.UserRepository
+	auditRepo   repository.AuditRepository
+	userUseCase UserUseCase
+	roleUseCase RoleUseCase
+	approvalCfg config.RoleApprovalConfig
+}
+
+// NewRoleApprovalUseCase creates a new RoleApprovalUseCase
+func NewRoleApprovalUseCase(
+	requestRepo repository.RoleChangeRequestRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	userUseCase UserUseCase,
+	roleUseCase RoleUseCase,
+	approvalCfg config.RoleApprovalConfig,
+) RoleApprovalUseCase {
+	return &roleApprovalUseCase{
+		requestRepo: requestRepo,
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		userUseCase: userUseCase,
+		roleUseCase: roleUseCase,
+		approvalCfg: approvalCfg,
+	}
+}
+
+// RequiresApproval reports whether the two-person rule is on and covers the role
+func (uc *roleApprovalUseCase) RequiresApproval(role string) bool {
+	return uc.approvalCfg.Enabled && slices.Contains(uc.approvalCfg.Roles, role)
+}
+
+// Request validates the role change like it would be applied right away, so requests bound to fail are not filed.
+// Only assignments can be limited in time.
+func (uc *roleApprovalUseCase) Request(ctx context.Context, actorID, userID uuid.UUID, kind, role string, duration time.Duration, reason entity.ActionReason) (*entity.RoleChangeRequest, error) {
+	reason, ok := reason.Normalize()
+	if !ok {
+		return nil, ErrInvalidReason
+	}
+	if duration < 0 || (duration > 0 && kind != entity.RoleChangeKindAssignment) {
+		return nil, ErrInvalidRoleExpiration
+	}
+
+	if _, err := uc.roleUseCase.GetRole(ctx, role); err != nil {
+		if kind == entity.RoleChangeKindPrimary && errors.Is(err, ErrRoleNotFound) {
+			return nil, ErrInvalidRole
+		}
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role == role {
+		return nil, ErrRoleAlreadyAssigned
+	}
+	if kind == entity.RoleChangeKindAssignment {
+		assignments, err := uc.roleUseCase.ListAssignedRoles(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, assignment := range assignments {
+			if assignment.Role == role {
+				return nil, ErrRoleAlreadyAssigned
+			}
+		}
+	}
+
+	pending, err := uc.List(ctx, entity.RoleChangeStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range pending {
+		if request.UserID == userID && request.Kind == kind && request.Role == role {
+			return nil, ErrRoleChangeAlreadyRequested
+		}
+	}
+
+	now := time.Now()
+	request := &entity.RoleChangeRequest{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Kind:        kind,
+		Role:        role,
+		Reason:      reason,
+		Status:      entity.RoleChangeStatusPending,
+		RequestedBy: actorID,
+		CreatedAt:   now,
+	}
+	if duration > 0 {
+		request.Duration = duration.String()
+	}
+	if uc.approvalCfg.Expiration > 0 {
+		expiresAt := now.Add(uc.approvalCfg.Expiration)
+		request.ExpiresAt = &expiresAt
+	}
+	if err := uc.requestRepo.Create(ctx, request); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionRoleChangeRequested, actorID, request)
+	return request, nil
+}
+
+// List returns the requests with a status, the pending requests past their expiration are expired first
+func (uc *roleApprovalUseCase) List(ctx context.Context, status string) ([]*entity.RoleChangeRequest, error) {
+	if status != "" && !entity.IsValidRoleChangeStatus(status) {
+		return nil, ErrInvalidRoleChangeStatus
+	}
+
+	pending, err := uc.requestRepo.List(ctx, entity.RoleChangeStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	expired := false
+	for _, request := range pending {
+		expired = uc.expire(ctx, request) || expired
+	}
+	if status == entity.RoleChangeStatusPending && !expired {
+		return pending, nil
+	}
+
+	return uc.requestRepo.List(ctx, status)
+}
+
+// Get returns a request, ErrRoleChangeNotFound if unknown
+func (uc *roleApprovalUseCase) Get(ctx context.Context, id uuid.UUID) (*entity.RoleChangeRequest, error) {
+	request, err := uc.requestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrRoleChangeNotFound
+	}
+	uc.expire(ctx, request)
+	return request, nil
+}
+
+// Approve claims the request, then applies the role change on behalf of the approver. The request is pending again
+// when the change cannot be applied, e.g. the user was deleted meanwhile, so it can still be rejected.
+func (uc *roleApprovalUseCase) Approve(ctx context.Context, actorID, id uuid.UUID, note string) (*entity.RoleChangeRequest, error) {
+	request, err := uc.decide(ctx, actorID, id, entity.RoleChangeStatusApproved, note)
+	if err != nil {
+		return nil, err
+	}
+
+	switch request.Kind {
+	case entity.RoleChangeKindAssignment:
+		_, err = uc.roleUseCase.AssignRole(ctx, actorID, request.UserID, request.Role, request.AssignmentExpiration(time.Now()))
+	default:
+		err = uc.userUseCase.UpdateRole(ctx, actorID, request.UserID, request.Role, request.Reason)
+	}
+	if err != nil {
+		reopened := *request
+		reopened.Status = entity.RoleChangeStatusPending
+		reopened.DecidedBy, reopened.DecidedAt, reopened.DecisionNote = nil, nil, ""
+		if _, reopenErr := uc.requestRepo.Transition(context.WithoutCancel(ctx), &reopened, entity.RoleChangeStatusApproved); reopenErr != nil {
+			log.Error().Err(reopenErr).Str("request_id", request.ID.String()).Msg("Failed to reopen role change request")
+		}
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionRoleChangeApproved, actorID, request)
+	return request, nil
+}
+
+// Reject turns down a pending request, the role change is not applied
+func (uc *roleApprovalUseCase) Reject(ctx context.Context, actorID, id uuid.UUID, note string) (*entity.RoleChangeRequest, error) {
+	request, err := uc.decide(ctx, actorID, id, entity.RoleChangeStatusRejected, note)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionRoleChangeRejected, actorID, request)
+	return request, nil
+}
+
+// decide moves a pending request to a decided status on behalf of an administrator other than its requester, only
+// one of concurrent decisions succeeds
+func (uc *roleApprovalUseCase) decide(ctx context.Context, actorID, id uuid.UUID, status, note string) (*entity.RoleChangeRequest, error) {
+	decision, ok := entity.ActionReason{Note: note}.Normalize()
+	if !ok {
+		return nil, ErrInvalidReason
+	}
+
+	request, err := uc.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != entity.RoleChangeStatusPending {
+		return nil, ErrRoleChangeDecided
+	}
+	if request.RequestedBy == actorID {
+		return nil, ErrRoleChangeSelfApproval
+	}
+
+	now := time.Now()
+	request.Status = status
+	request.DecidedBy = &actorID
+	request.DecidedAt = &now
+	request.DecisionNote = decision.Note
+	decided, err := uc.requestRepo.Transition(ctx, request, entity.RoleChangeStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if !decided {
+		return nil, ErrRoleChangeDecided
+	}
+	return request, nil
+}
+
+// expire marks a pending request past its expiration as expired and reports whether it did. The request is
+// reported expired even when the change cannot be stored, the next read stores it again.
+func (uc *roleApprovalUseCase) expire(ctx context.Context, request *entity.RoleChangeRequest) bool {
+	if !request.IsExpired(time.Now()) {
+		return false
+	}
+
+	request.Status = entity.RoleChangeStatusExpired
+	if _, err := uc.requestRepo.Transition(ctx, request, entity.RoleChangeStatusPending); err != nil {
+		log.Error().Err(err).Str("request_id", request.ID.String()).Msg("Failed to expire role change request")
+	}
+	return true
+}
+
+// recordAction records a step of a role change request in the audit trail, the user is the target
+func (uc *roleApprovalUseCase) recordAction(ctx context.Context, action string, actorID uuid.UUID, request *entity.RoleChangeRequest) {
+	details := map[string]string{
+		"request_id":   request.ID.String(),
+		"kind":         request.Kind,
+		"role":         request.Role,
+		"requested_by": request.RequestedBy.String(),
+	}
+	if request.Duration != "" {
+		details["duration"] = request.Duration
+	}
+	if request.DecisionNote != "" {
+		details["decision_note"] = request.DecisionNote
+	}
+	request.Reason.AddTo(details)
+
+	entry := entity.NewAuditEntry(action, actorID, request.UserID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("request_id", request.ID.String()).Msg("Failed to record role change request in audit trail")
+	}
+}
@@ -0,0 +1,905 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrRoleNotFound                 = errors.New("role not found")
+	ErrRoleAlreadyExists            = errors.New("role already exists")
+	ErrRoleInUse                    = errors.New("role is assigned to users or teams or inherited by roles")
+	ErrBuiltInRole                  = errors.New("built-in roles cannot be changed")
+	ErrInvalidRoleName              = errors.New("invalid role name")
+	ErrInvalidPermission            = errors.New("invalid permission")
+	ErrRoleCycle                    = errors.New("role inheritance cycle")
+	ErrPermissionGroupNotFound      = errors.New("permission group not found")
+	ErrPermissionGroupAlreadyExists = errors.New("permission group already exists")
+	ErrPermissionGroupInUse         = errors.New("permission group is included by roles")
+	ErrPermissionNotFound           = errors.New("permission not found")
+	ErrPermissionAlreadyExists      = errors.New("permission already exists")
+	ErrPermissionInUse              = errors.New("permission is granted by roles or permission groups")
+	ErrBuiltInPermission            = errors.New("built-in permissions cannot be changed")
+	ErrInvalidPermissionName        = errors.New("invalid permission name")
+	ErrRoleAlreadyAssigned          = errors.New("role already assigned to the user")
+	ErrRoleNotAssigned              = errors.New("role not assigned to the user")
+	ErrInvalidRoleExpiration        = errors.New("invalid role assignment expiration")
+)
+
+// roleExpiryBatchSize is the number of expired role assignments loaded at once
+const roleExpiryBatchSize = 100
+
+// RoleUseCase defines the use case for role, permission and permission group definitions, and the roles assigned to
+// users
+type RoleUseCase interface {
+	// CreateRole defines a new custom role
+	CreateRole(ctx context.Context, name string, spec entity.RoleSpec) (*entity.Role, error)
+
+	// GetRole returns a built-in or custom role
+	GetRole(ctx context.Context, name string) (*entity.Role, error)
+
+	// ListRoles returns the built-in roles followed by the custom roles
+	ListRoles(ctx context.Context) ([]*entity.Role, error)
+
+	// UpdateRole replaces the definition of a custom role
+	UpdateRole(ctx context.Context, name string, spec entity.RoleSpec) (*entity.Role, error)
+
+	// DeleteRole deletes a custom role that is neither held nor assigned by users, granted by teams, nor inherited by
+	// other roles
+	DeleteRole(ctx context.Context, name string) error
+
+	// EffectivePermissions resolves the permissions a role grants through its own permissions, groups and ancestors
+	EffectivePermissions(ctx context.Context, name string) (*entity.EffectivePermissions, error)
+
+	// CreatePermissionGroup defines a new permission group
+	CreatePermissionGroup(ctx context.Context, name, description string, permissions []string) (*entity.PermissionGroup, error)
+
+	// GetPermissionGroup returns a permission group
+	GetPermissionGroup(ctx context.Context, name string) (*entity.PermissionGroup, error)
+
+	// ListPermissionGroups returns all permission groups
+	ListPermissionGroups(ctx context.Context) ([]*entity.PermissionGroup, error)
+
+	// UpdatePermissionGroup replaces the description and permissions of a permission group
+	UpdatePermissionGroup(ctx context.Context, name, description string, permissions []string) (*entity.PermissionGroup, error)
+
+	// DeletePermissionGroup deletes a permission group no role includes
+	DeletePermissionGroup(ctx context.Context, name string) error
+
+	// CreatePermission defines a new custom permission
+	CreatePermission(ctx context.Context, name, description string) (*entity.Permission, error)
+
+	// GetPermission returns a built-in or custom permission
+	GetPermission(ctx context.Context, name string) (*entity.Permission, error)
+
+	// ListPermissions returns the built-in permissions followed by the custom permissions
+	ListPermissions(ctx context.Context) ([]*entity.Permission, error)
+
+	// UpdatePermission replaces the description of a custom permission
+	UpdatePermission(ctx context.Context, name, description string) (*entity.Permission, error)
+
+	// DeletePermission deletes a custom permission no role or permission group grants
+	DeletePermission(ctx context.Context, name string) error
+
+	// AssignRole assigns a role to a user on top of their primary role, on behalf of an administrator, for good or
+	// until expiresAt
+	AssignRole(ctx context.Context, actorID, userID uuid.UUID, role string, expiresAt *time.Time) (*entity.RoleAssignment, error)
+
+	// UnassignRole removes a role assigned to a user, on behalf of an administrator
+	UnassignRole(ctx context.Context, actorID, userID uuid.UUID, role string) error
+
+	// ListAssignedRoles returns the unexpired roles assigned to a user on top of their primary role
+	ListAssignedRoles(ctx context.Context, userID uuid.UUID) ([]*entity.RoleAssignment, error)
+
+	// ListRoleUsers returns the unexpired assignments of a role to users, not counting the users holding it as
+	// primary role
+	ListRoleUsers(ctx context.Context, role string) ([]*entity.RoleAssignment, error)
+
+	// DeleteAssignedRoles removes every role assigned to a user, when the user is deleted
+	DeleteAssignedRoles(ctx context.Context, userID uuid.UUID) error
+
+	// ExpireAssignedRoles removes the expired role assignments, reads already skip them
+	ExpireAssignedRoles(ctx context.Context) error
+
+	// RunExpiry removes the expired role assignments at every interval until the context is cancelled
+	RunExpiry(ctx context.Context, interval time.Duration)
+
+	// GrantedRoles returns the roles a user is granted on top of their primary role, assigned to them or granted by
+	// their teams
+	GrantedRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+
+	// UserPermissions resolves the permissions a user is granted by their primary role, assigned roles and teams
+	UserPermissions(ctx context.Context, userID uuid.UUID) (*entity.UserPermissions, error)
+
+	// HasPermission reports whether any of the roles grants a permission
+	HasPermission(ctx context.Context, roles []string, permission string) (bool, error)
+}
+
+// roleUseCase implements RoleUseCase interface
+type roleUseCase struct {
+	roleRepo       repository.RoleRepository
+	groupRepo      repository.PermissionGroupRepository
+	userRepo       repository.UserRepository
+	permissionRepo repository.PermissionRepository
+	assignmentRepo repository.RoleAssignmentRepository
+	teamRepo       repository.TeamRepository
+	memberRepo     repository.TeamMemberRepository
+	auditRepo      repository.AuditRepository
+}
+
+// NewRoleUseCase creates a new RoleUseCase
+func NewRoleUseCase(
+	roleRepo repository.RoleRepository,
+	groupRepo repository.PermissionGroupRepository,
+	userRepo repository.UserRepository,
+	permissionRepo repository.PermissionRepository,
+	assignmentRepo repository.RoleAssignmentRepository,
+	teamRepo repository.TeamRepository,
+	memberRepo repository.TeamMemberRepository,
+	auditRepo repository.AuditRepository,
+) RoleUseCase {
+	return &roleUseCase{
+		roleRepo:       roleRepo,
+		groupRepo:      groupRepo,
+		userRepo:       userRepo,
+		permissionRepo: permissionRepo,
+		assignmentRepo: assignmentRepo,
+		teamRepo:       teamRepo,
+		memberRepo:     memberRepo,
+		auditRepo:      auditRepo,
+	}
+}
+
+// CreateRole defines a new custom role
+func (uc *roleUseCase) CreateRole(ctx context.Context, name string, spec entity.RoleSpec) (*entity.Role, error) {
+	// Validate role
+	if !entity.IsValidRoleName(name) {
+		return nil, ErrInvalidRoleName
+	}
+	spec, err := uc.validateRoleSpec(ctx, name, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if role already exists
+	existing, err := uc.GetRole(ctx, name)
+	if err != nil && !errors.Is(err, ErrRoleNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrRoleAlreadyExists
+	}
+
+	role := entity.NewRole(name, spec)
+	if err := uc.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// GetRole returns a built-in or custom role
+func (uc *roleUseCase) GetRole(ctx context.Context, name string) (*entity.Role, error) {
+	if role := entity.BuiltInRole(name); role != nil {
+		return role, nil
+	}
+
+	role, err := uc.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	return role, nil
+}
+
+// ListRoles returns the built-in roles followed by the custom roles
+func (uc *roleUseCase) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	custom, err := uc.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(entity.BuiltInRoles(), custom...), nil
+}
+
+// UpdateRole replaces the definition of a custom role
+func (uc *roleUseCase) UpdateRole(ctx context.Context, name string, spec entity.RoleSpec) (*entity.Role, error) {
+	if entity.BuiltInRole(name) != nil {
+		return nil, ErrBuiltInRole
+	}
+
+	role, err := uc.GetRole(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err = uc.validateRoleSpec(ctx, name, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Description = spec.Description
+	role.Permissions = spec.Permissions
+	role.Parents = spec.Parents
+	role.Groups = spec.Groups
+	role.UpdatedAt = time.Now()
+
+	if err := uc.roleRepo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes a custom role that is neither assigned to users, granted by teams nor inherited by other roles
+func (uc *roleUseCase) DeleteRole(ctx context.Context, name string) error {
+	if entity.BuiltInRole(name) != nil {
+		return ErrBuiltInRole
+	}
+
+	if _, err := uc.GetRole(ctx, name); err != nil {
+		return err
+	}
+
+	// Refuse to leave users with a dangling role
+	_, assigned, err := uc.userRepo.List(ctx, 1, 1, entity.UserListOptions{Role: name})
+	if err != nil {
+		return err
+	}
+	if assigned > 0 {
+		return ErrRoleInUse
+	}
+	assignments, err := uc.ListRoleUsers(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(assignments) > 0 {
+		return ErrRoleInUse
+	}
+	teams, err := uc.teamRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		if slices.Contains(team.Roles, name) {
+			return ErrRoleInUse
+		}
+	}
+
+	// Refuse to leave roles with a dangling parent
+	roles, err := uc.roleRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if slices.Contains(role.Parents, name) {
+			return ErrRoleInUse
+		}
+	}
+
+	return uc.roleRepo.Delete(ctx, name)
+}
+
+// EffectivePermissions resolves the permissions a role grants through its own permissions, groups and ancestors
+func (uc *roleUseCase) EffectivePermissions(ctx context.Context, name string) (*entity.EffectivePermissions, error) {
+	effective := &entity.EffectivePermissions{
+		Role:        name,
+		Permissions: []string{},
+		Ancestors:   []string{},
+		Sources:     map[string][]string{},
+	}
+
+	groups := map[string]*entity.PermissionGroup{}
+	visited := map[string]bool{}
+
+	// Walk the inheritance graph breadth first, each role is resolved once even when inherited through several paths
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		role, err := uc.GetRole(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if current != name {
+			effective.Ancestors = append(effective.Ancestors, current)
+		}
+
+		for _, permission := range role.Permissions {
+			effective.Sources[permission] = append(effective.Sources[permission], "role:"+current)
+		}
+
+		for _, groupName := range role.Groups {
+			group, ok := groups[groupName]
+			if !ok {
+				group, err = uc.GetPermissionGroup(ctx, groupName)
+				if err != nil {
+					return nil, err
+				}
+				groups[groupName] = group
+			}
+			for _, permission := range group.Permissions {
+				effective.Sources[permission] = append(effective.Sources[permission], "group:"+groupName)
+			}
+		}
+
+		queue = append(queue, role.Parents...)
+	}
+
+	for permission := range effective.Sources {
+		effective.Permissions = append(effective.Permissions, permission)
+	}
+	sort.Strings(effective.Permissions)
+
+	return effective, nil
+}
+
+// CreatePermissionGroup defines a new permission group
+func (uc *roleUseCase) CreatePermissionGroup(ctx context.Context, name, description string, permissions []string) (*entity.PermissionGroup, error) {
+	// Validate permission group
+	if !entity.IsValidRoleName(name) {
+		return nil, ErrInvalidRoleName
+	}
+	permissions, err := uc.normalizePermissions(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if permission group already exists
+	existing, err := uc.groupRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrPermissionGroupAlreadyExists
+	}
+
+	group := entity.NewPermissionGroup(name, description, permissions)
+	if err := uc.groupRepo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// GetPermissionGroup returns a permission group
+func (uc *roleUseCase) GetPermissionGroup(ctx context.Context, name string) (*entity.PermissionGroup, error) {
+	group, err := uc.groupRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrPermissionGroupNotFound
+	}
+
+	return group, nil
+}
+
+// ListPermissionGroups returns all permission groups
+func (uc *roleUseCase) ListPermissionGroups(ctx context.Context) ([]*entity.PermissionGroup, error) {
+	return uc.groupRepo.List(ctx)
+}
+
+// UpdatePermissionGroup replaces the description and permissions of a permission group
+func (uc *roleUseCase) UpdatePermissionGroup(ctx context.Context, name, description string, permissions []string) (*entity.PermissionGroup, error) {
+	permissions, err := uc.normalizePermissions(ctx, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := uc.GetPermissionGroup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Description = description
+	group.Permissions = permissions
+	group.UpdatedAt = time.Now()
+
+	if err := uc.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// DeletePermissionGroup deletes a permission group no role includes
+func (uc *roleUseCase) DeletePermissionGroup(ctx context.Context, name string) error {
+	if _, err := uc.GetPermissionGroup(ctx, name); err != nil {
+		return err
+	}
+
+	roles, err := uc.roleRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if slices.Contains(role.Groups, name) {
+			return ErrPermissionGroupInUse
+		}
+	}
+
+	return uc.groupRepo.Delete(ctx, name)
+}
+
+// CreatePermission defines a new custom permission
+func (uc *roleUseCase) CreatePermission(ctx context.Context, name, description string) (*entity.Permission, error) {
+	if !entity.IsValidPermissionName(name) {
+		return nil, ErrInvalidPermissionName
+	}
+
+	// Check if permission already exists
+	existing, err := uc.GetPermission(ctx, name)
+	if err != nil && !errors.Is(err, ErrPermissionNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrPermissionAlreadyExists
+	}
+
+	permission := entity.NewPermission(name, description)
+	if err := uc.permissionRepo.Create(ctx, permission); err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// GetPermission returns a built-in or custom permission
+func (uc *roleUseCase) GetPermission(ctx context.Context, name string) (*entity.Permission, error) {
+	if permission := entity.BuiltInPermission(name); permission != nil {
+		return permission, nil
+	}
+
+	permission, err := uc.permissionRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if permission == nil {
+		return nil, ErrPermissionNotFound
+	}
+
+	return permission, nil
+}
+
+// ListPermissions returns the built-in permissions followed by the custom permissions
+func (uc *roleUseCase) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	custom, err := uc.permissionRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(entity.BuiltInPermissions(), custom...), nil
+}
+
+// UpdatePermission replaces the description of a custom permission, its name is referenced by roles and groups
+func (uc *roleUseCase) UpdatePermission(ctx context.Context, name, description string) (*entity.Permission, error) {
+	if entity.BuiltInPermission(name) != nil {
+		return nil, ErrBuiltInPermission
+	}
+
+	permission, err := uc.GetPermission(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	permission.Description = description
+	permission.UpdatedAt = time.Now()
+
+	if err := uc.permissionRepo.Update(ctx, permission); err != nil {
+		return nil, err
+	}
+
+	return permission, nil
+}
+
+// DeletePermission deletes a custom permission no role or permission group grants
+func (uc *roleUseCase) DeletePermission(ctx context.Context, name string) error {
+	if entity.BuiltInPermission(name) != nil {
+		return ErrBuiltInPermission
+	}
+
+	if _, err := uc.GetPermission(ctx, name); err != nil {
+		return err
+	}
+
+	// Refuse to leave roles and groups with a dangling permission
+	roles, err := uc.roleRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if slices.Contains(role.Permissions, name) {
+			return ErrPermissionInUse
+		}
+	}
+	groups, err := uc.groupRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		if slices.Contains(group.Permissions, name) {
+			return ErrPermissionInUse
+		}
+	}
+
+	return uc.permissionRepo.Delete(ctx, name)
+}
+
+// AssignRole assigns an existing role to an existing user, the primary role of the user cannot be assigned again.
+// An expired assignment of the role not removed yet is replaced.
+func (uc *roleUseCase) AssignRole(ctx context.Context, actorID, userID uuid.UUID, role string, expiresAt *time.Time) (*entity.RoleAssignment, error) {
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrInvalidRoleExpiration
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if _, err := uc.GetRole(ctx, role); err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return nil, ErrRoleAlreadyAssigned
+	}
+
+	if err := uc.expireAssignment(ctx, userID, role, now); err != nil {
+		return nil, err
+	}
+
+	assignment := &entity.RoleAssignment{
+		UserID:     userID,
+		Role:       role,
+		AssignedBy: actorID,
+		AssignedAt: now,
+		ExpiresAt:  expiresAt,
+	}
+	assigned, err := uc.assignmentRepo.Assign(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, ErrRoleAlreadyAssigned
+	}
+
+	details := map[string]string{"role": role}
+	if expiresAt != nil {
+		details["expires_at"] = expiresAt.UTC().Format(time.RFC3339)
+	}
+	uc.recordAssignment(ctx, entity.AuditActionUserRoleAssigned, actorID, userID, details)
+	return assignment, nil
+}
+
+// UnassignRole removes a role assigned to a user, their primary role is changed with the user instead
+func (uc *roleUseCase) UnassignRole(ctx context.Context, actorID, userID uuid.UUID, role string) error {
+	unassigned, err := uc.assignmentRepo.Unassign(ctx, userID, role)
+	if err != nil {
+		return err
+	}
+	if !unassigned {
+		return ErrRoleNotAssigned
+	}
+
+	uc.recordAssignment(ctx, entity.AuditActionUserRoleUnassigned, actorID, userID, map[string]string{"role": role})
+	return nil
+}
+
+// ListAssignedRoles returns the unexpired roles assigned to a user, ordered by role
+func (uc *roleUseCase) ListAssignedRoles(ctx context.Context, userID uuid.UUID) ([]*entity.RoleAssignment, error) {
+	assignments, err := uc.assignmentRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return unexpiredAssignments(assignments, time.Now()), nil
+}
+
+// ListRoleUsers returns the unexpired assignments of a role to users, oldest first
+func (uc *roleUseCase) ListRoleUsers(ctx context.Context, role string) ([]*entity.RoleAssignment, error) {
+	if _, err := uc.GetRole(ctx, role); err != nil {
+		return nil, err
+	}
+	assignments, err := uc.assignmentRepo.ListByRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	return unexpiredAssignments(assignments, time.Now()), nil
+}
+
+// DeleteAssignedRoles removes every role assigned to a user
+func (uc *roleUseCase) DeleteAssignedRoles(ctx context.Context, userID uuid.UUID) error {
+	return uc.assignmentRepo.DeleteByUser(ctx, userID)
+}
+
+// ExpireAssignedRoles removes the expired role assignments batch by batch. An assignment is removed by one instance
+// only, which records the expiration in the audit trail.
+func (uc *roleUseCase) ExpireAssignedRoles(ctx context.Context) error {
+	for {
+		assignments, err := uc.assignmentRepo.ListExpired(ctx, time.Now(), roleExpiryBatchSize)
+		if err != nil {
+			return err
+		}
+
+		expired := 0
+		for _, assignment := range assignments {
+			deleted, err := uc.assignmentRepo.DeleteExpired(ctx, assignment)
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				continue
+			}
+			uc.recordExpiration(ctx, assignment)
+			expired++
+		}
+
+		if expired > 0 {
+			log.Info().Int("assignments", expired).Msg("Removed expired role assignments")
+		}
+		// Stop on the last batch, or when the batch is being removed by other instances
+		if len(assignments) < roleExpiryBatchSize || expired == 0 {
+			return nil
+		}
+	}
+}
+
+// RunExpiry removes the expired role assignments at every interval until the context is cancelled
+func (uc *roleUseCase) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := uc.ExpireAssignedRoles(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to remove expired role assignments")
+		}
+	}
+}
+
+// expireAssignment removes the assignment of a role to a user if it expired, so the role can be assigned anew
+func (uc *roleUseCase) expireAssignment(ctx context.Context, userID uuid.UUID, role string, now time.Time) error {
+	assignments, err := uc.assignmentRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, assignment := range assignments {
+		if assignment.Role != role || !assignment.IsExpired(now) {
+			continue
+		}
+		deleted, err := uc.assignmentRepo.DeleteExpired(ctx, assignment)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			uc.recordExpiration(ctx, assignment)
+		}
+	}
+	return nil
+}
+
+// unexpiredAssignments returns the assignments that have not expired at now, in order
+func unexpiredAssignments(assignments []*entity.RoleAssignment, now time.Time) []*entity.RoleAssignment {
+	unexpired := make([]*entity.RoleAssignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		if !assignment.IsExpired(now) {
+			unexpired = append(unexpired, assignment)
+		}
+	}
+	return unexpired
+}
+
+// GrantedRoles returns the roles assigned to a user followed by the roles of their teams, without duplicates
+func (uc *roleUseCase) GrantedRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	assigned, err := uc.assignedRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	_, teamRoles, err := uc.teamRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dedupe(append(assigned, teamRoles...)), nil
+}
+
+// UserPermissions resolves the permissions of the stored primary role of a user, of their assigned roles and of the
+// roles of their teams
+func (uc *roleUseCase) UserPermissions(ctx context.Context, userID uuid.UUID) (*entity.UserPermissions, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	resolved := &entity.UserPermissions{
+		UserID: userID,
+		Role:   user.Role,
+	}
+	resolved.Roles, err = uc.assignedRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Teams, resolved.TeamRoles, err = uc.teamRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles := append(append([]string{user.Role}, resolved.Roles...), resolved.TeamRoles...)
+	resolved.Permissions, err = uc.resolvePermissions(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// assignedRoles returns the unexpired roles assigned to a user, ordered by role
+func (uc *roleUseCase) assignedRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	assignments, err := uc.ListAssignedRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		roles = append(roles, assignment.Role)
+	}
+	return roles, nil
+}
+
+// teamRoles returns the teams of a user and the roles they grant, without duplicates. A team that no longer exists
+// grants nothing, its leftover memberships being ignored.
+func (uc *roleUseCase) teamRoles(ctx context.Context, userID uuid.UUID) ([]string, []string, error) {
+	memberships, err := uc.memberRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	teams := make([]string, 0, len(memberships))
+	roles := []string{}
+	for _, membership := range memberships {
+		team, err := uc.teamRepo.GetByName(ctx, membership.Team)
+		if err != nil {
+			return nil, nil, err
+		}
+		if team == nil {
+			continue
+		}
+		teams = append(teams, team.Name)
+		roles = append(roles, team.Roles...)
+	}
+	return teams, dedupe(roles), nil
+}
+
+// HasPermission reports whether any of the roles grants a permission, directly, through its groups or its ancestors
+func (uc *roleUseCase) HasPermission(ctx context.Context, roles []string, permission string) (bool, error) {
+	permissions, err := uc.resolvePermissions(ctx, roles)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(permissions, permission), nil
+}
+
+// resolvePermissions returns the sorted union of the effective permissions of roles. A role that no longer exists
+// grants nothing rather than failing the resolution.
+func (uc *roleUseCase) resolvePermissions(ctx context.Context, roles []string) ([]string, error) {
+	permissions := []string{}
+	for _, role := range dedupe(roles) {
+		effective, err := uc.EffectivePermissions(ctx, role)
+		if err != nil {
+			if errors.Is(err, ErrRoleNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		permissions = append(permissions, effective.Permissions...)
+	}
+	permissions = dedupe(permissions)
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// recordExpiration records the removal of an expired role assignment in the audit trail, on behalf of the system
+func (uc *roleUseCase) recordExpiration(ctx context.Context, assignment *entity.RoleAssignment) {
+	uc.recordAssignment(ctx, entity.AuditActionUserRoleExpired, uuid.Nil, assignment.UserID, map[string]string{
+		"role":       assignment.Role,
+		"expired_at": assignment.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// recordAssignment records the assignment of a role to a user, its removal or expiration, in the audit trail
+func (uc *roleUseCase) recordAssignment(ctx context.Context, action string, actorID, userID uuid.UUID, details map[string]string) {
+	entry := entity.NewAuditEntry(action, actorID, userID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("user_id", userID.String()).Msg("Failed to record role assignment in audit trail")
+	}
+}
+
+// validateRoleSpec validates the definition of the named role and returns it normalized.
+// Parents and groups must exist, and no parent may inherit from the role itself.
+func (uc *roleUseCase) validateRoleSpec(ctx context.Context, name string, spec entity.RoleSpec) (entity.RoleSpec, error) {
+	permissions, err := uc.normalizePermissions(ctx, spec.Permissions)
+	if err != nil {
+		return spec, err
+	}
+	spec.Permissions = permissions
+	spec.Parents = dedupe(spec.Parents)
+	spec.Groups = dedupe(spec.Groups)
+
+	for _, group := range spec.Groups {
+		if _, err := uc.GetPermissionGroup(ctx, group); err != nil {
+			return spec, err
+		}
+	}
+
+	// Inheriting from a role that already inherits from this one would close a cycle
+	for _, parent := range spec.Parents {
+		if parent == name {
+			return spec, ErrRoleCycle
+		}
+		if _, err := uc.GetRole(ctx, parent); err != nil {
+			return spec, err
+		}
+		ancestors, err := uc.EffectivePermissions(ctx, parent)
+		if err != nil {
+			return spec, err
+		}
+		if slices.Contains(ancestors.Ancestors, name) {
+			return spec, ErrRoleCycle
+		}
+	}
+
+	return spec, nil
+}
+
+// normalizePermissions validates permissions are built in or defined, and returns them sorted without duplicates
+func (uc *roleUseCase) normalizePermissions(ctx context.Context, permissions []string) ([]string, error) {
+	normalized := dedupe(permissions)
+	for _, permission := range normalized {
+		if _, err := uc.GetPermission(ctx, permission); err != nil {
+			if errors.Is(err, ErrPermissionNotFound) {
+				return nil, ErrInvalidPermission
+			}
+			return nil, err
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// dedupe returns values without duplicates, keeping the first occurrences in order
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	deduped := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			deduped = append(deduped, value)
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,703 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrSCIMGroupNotFound is returned when acting on an unknown SCIM group
+	ErrSCIMGroupNotFound = errors.New("SCIM group not found")
+
+	// ErrSCIMGroupExists is returned when creating or renaming a SCIM group under a taken display name
+	ErrSCIMGroupExists = errors.New("SCIM group already exists")
+
+	// ErrInvalidSCIMGroup is returned when a SCIM group has no display name
+	ErrInvalidSCIMGroup = errors.New("invalid SCIM group")
+
+	// ErrSCIMUnknownMember is returned when a member of a SCIM group is not the ID of an existing user
+	ErrSCIMUnknownMember = errors.New("SCIM group member is not a known user")
+
+	// ErrInvalidSCIMFilter is returned for filters other than an equality on a supported attribute
+	ErrInvalidSCIMFilter = errors.New("invalid SCIM filter")
+
+	// ErrInvalidSCIMPatch is returned for PATCH operations that are not understood
+	ErrInvalidSCIMPatch = errors.New("invalid SCIM patch operation")
+)
+
+const (
+	// scimMaxResults caps the resources returned in a page
+	scimMaxResults = 100
+
+	// scimSyncNote is the note of the role changes made by the sync
+	scimSyncNote = "SCIM group sync"
+)
+
+var (
+	// scimFilterRegex matches the only filter supported, an equality such as userName eq "jane@example.com"
+	scimFilterRegex = regexp.MustCompile(`^\s*(\w+)\s+(?i:eq)\s+"([^"]*)"\s*$`)
+
+	// scimMemberPathRegex matches the path removing one member, members[value eq "<id>"]
+	scimMemberPathRegex = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+)
+
+// SCIMUseCase defines the use case for the SCIM provisioning of the identity provider. Groups are mapped by display
+// name to a role and an organization, which their members are given as the groups change.
+type SCIMUseCase interface {
+	// ListUsers returns a page of users, StartIndex counting from 1, along with the total
+	ListUsers(ctx context.Context, filter string, startIndex, count int) ([]*entity.User, int64, error)
+
+	// GetUser returns a user
+	GetUser(ctx context.Context, id uuid.UUID) (*entity.User, error)
+
+	// ListGroups returns a page of groups ordered by display name, StartIndex counting from 1, along with the total
+	ListGroups(ctx context.Context, filter string, startIndex, count int) ([]*entity.SCIMGroup, int, error)
+
+	// GetGroup returns a group
+	GetGroup(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error)
+
+	// CreateGroup creates a group and syncs its members
+	CreateGroup(ctx context.Context, resource *entity.SCIMGroupResource) (*entity.SCIMGroup, error)
+
+	// ReplaceGroup replaces a group and syncs the members added and removed
+	ReplaceGroup(ctx context.Context, id uuid.UUID, resource *entity.SCIMGroupResource) (*entity.SCIMGroup, error)
+
+	// PatchGroup applies PATCH operations to a group and syncs the members added and removed
+	PatchGroup(ctx context.Context, id uuid.UUID, operations []entity.SCIMPatchOperation) (*entity.SCIMGroup, error)
+
+	// DeleteGroup deletes a group and syncs its former members
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+
+	// ListGroupStatuses returns every group with its mapping and the outcome of its latest sync
+	ListGroupStatuses(ctx context.Context) ([]*entity.SCIMGroup, error)
+
+	// ResyncGroup syncs every member of a group again, such as after the mappings changed
+	ResyncGroup(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error)
+}
+
+// scimUseCase implements SCIMUseCase interface
+type scimUseCase struct {
+	groupRepo           repository.SCIMGroupRepository
+	userRepo            repository.UserRepository
+	userUseCase         UserUseCase
+	organizationUseCase OrganizationUseCase
+	roleApprovalUseCase RoleApprovalUseCase
+	groupRoles          map[string]string
+	groupOrgs           map[string]uuid.UUID
+	defaultRole         string
+	rolePrecedence      []string
+}
+
+// NewSCIMUseCase creates a new SCIMUseCase. Organization mappings that are not UUIDs are ignored with a warning.
+func NewSCIMUseCase(
+	groupRepo repository.SCIMGroupRepository,
+	userRepo repository.UserRepository,
+	userUseCase UserUseCase,
+	organizationUseCase OrganizationUseCase,
+	roleApprovalUseCase RoleApprovalUseCase,
+	scimCfg config.SCIMConfig,
+) SCIMUseCase {
+	groupOrgs := make(map[string]uuid.UUID, len(scimCfg.GroupOrgs))
+	for group, value := range scimCfg.GroupOrgs {
+		orgID, err := uuid.Parse(value)
+		if err != nil {
+			log.Warn().Str("group", group).Str("org_id", value).Msg("Ignoring SCIM group mapped to an invalid organization ID")
+			continue
+		}
+		groupOrgs[group] = orgID
+	}
+
+	return &scimUseCase{
+		groupRepo:           groupRepo,
+		userRepo:            userRepo,
+		userUseCase:         userUseCase,
+		organizationUseCase: organizationUseCase,
+		roleApprovalUseCase: roleApprovalUseCase,
+		groupRoles:          scimCfg.GroupRoles,
+		groupOrgs:           groupOrgs,
+		defaultRole:         scimCfg.DefaultRole,
+		rolePrecedence:      scimCfg.RolePrecedence,
+	}
+}
+
+// ListUsers returns a page of users, or the user whose email matches a userName filter
+func (uc *scimUseCase) ListUsers(ctx context.Context, filter string, startIndex, count int) ([]*entity.User, int64, error) {
+	if filter != "" {
+		attribute, value, err := parseSCIMFilter(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !strings.EqualFold(attribute, "userName") {
+			return nil, 0, ErrInvalidSCIMFilter
+		}
+		user, err := uc.userRepo.GetByEmail(ctx, value)
+		if err != nil {
+			return nil, 0, err
+		}
+		if user == nil {
+			return []*entity.User{}, 0, nil
+		}
+		return []*entity.User{user}, 1, nil
+	}
+
+	// The repository pages by page number, so the start index is rounded down to the start of its page
+	count = scimCount(count)
+	if count == 0 {
+		_, total, err := uc.userRepo.List(ctx, 1, 1, entity.UserListOptions{})
+		return []*entity.User{}, total, err
+	}
+	page := (max(startIndex, 1)-1)/count + 1
+	return uc.userRepo.List(ctx, page, count, entity.UserListOptions{})
+}
+
+// GetUser returns a user, ErrUserNotFound if unknown
+func (uc *scimUseCase) GetUser(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// ListGroups returns a page of the groups, those matching a displayName or externalId filter
+func (uc *scimUseCase) ListGroups(ctx context.Context, filter string, startIndex, count int) ([]*entity.SCIMGroup, int, error) {
+	groups, err := uc.groupRepo.List(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter != "" {
+		attribute, value, err := parseSCIMFilter(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		var match func(*entity.SCIMGroup) bool
+		switch strings.ToLower(attribute) {
+		case "displayname":
+			match = func(group *entity.SCIMGroup) bool { return strings.EqualFold(group.DisplayName, value) }
+		case "externalid":
+			match = func(group *entity.SCIMGroup) bool { return group.ExternalID == value }
+		default:
+			return nil, 0, ErrInvalidSCIMFilter
+		}
+		groups = slices.DeleteFunc(groups, func(group *entity.SCIMGroup) bool { return !match(group) })
+	}
+
+	total := len(groups)
+	start := min(max(startIndex, 1)-1, total)
+	end := min(start+scimCount(count), total)
+	page := groups[start:end]
+	for _, group := range page {
+		uc.annotate(group)
+	}
+	return page, total, nil
+}
+
+// GetGroup returns a group, ErrSCIMGroupNotFound if unknown
+func (uc *scimUseCase) GetGroup(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error) {
+	group, err := uc.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, ErrSCIMGroupNotFound
+	}
+
+	uc.annotate(group)
+	return group, nil
+}
+
+// CreateGroup creates a group under a display name no other group has, then gives its members its role and
+// organization
+func (uc *scimUseCase) CreateGroup(ctx context.Context, resource *entity.SCIMGroupResource) (*entity.SCIMGroup, error) {
+	now := time.Now()
+	group := &entity.SCIMGroup{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.applyResource(ctx, group, resource); err != nil {
+		return nil, err
+	}
+
+	if err := uc.groupRepo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return uc.syncGroup(ctx, group, group.Members), nil
+}
+
+// ReplaceGroup replaces the display name, external ID and members of a group. The members added and removed are
+// synced, every member when the display name, and so the mapping, changed.
+func (uc *scimUseCase) ReplaceGroup(ctx context.Context, id uuid.UUID, resource *entity.SCIMGroupResource) (*entity.SCIMGroup, error) {
+	group, err := uc.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	previous := *group
+
+	if err := uc.applyResource(ctx, group, resource); err != nil {
+		return nil, err
+	}
+
+	return uc.updateGroup(ctx, &previous, group)
+}
+
+// PatchGroup applies the add, remove and replace operations to the members, display name and external ID of a group
+func (uc *scimUseCase) PatchGroup(ctx context.Context, id uuid.UUID, operations []entity.SCIMPatchOperation) (*entity.SCIMGroup, error) {
+	group, err := uc.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	previous := *group
+	group.Members = slices.Clone(group.Members)
+
+	for _, operation := range operations {
+		if err := uc.applyPatch(ctx, group, operation); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.checkDisplayName(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return uc.updateGroup(ctx, &previous, group)
+}
+
+// DeleteGroup deletes a group, then syncs its former members so they lose its role and organization
+func (uc *scimUseCase) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	group, err := uc.GetGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.groupRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	sync := uc.syncUsers(ctx, group.Members)
+	if sync.Status != entity.SCIMSyncStatusSynced {
+		log.Warn().Str("scim_group_id", id.String()).Str("status", sync.Status).Int("issues", len(sync.Issues)).
+			Msg("Former members of a deleted SCIM group were not fully synced")
+	}
+	return nil
+}
+
+// ListGroupStatuses returns every group with its mapping and the outcome of its latest sync
+func (uc *scimUseCase) ListGroupStatuses(ctx context.Context) ([]*entity.SCIMGroup, error) {
+	groups, err := uc.groupRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		uc.annotate(group)
+	}
+	return groups, nil
+}
+
+// ResyncGroup syncs every member of a group
+func (uc *scimUseCase) ResyncGroup(ctx context.Context, id uuid.UUID) (*entity.SCIMGroup, error) {
+	group, err := uc.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.syncGroup(ctx, group, group.Members), nil
+}
+
+// applyResource sets the display name, external ID and members of a group from a SCIM resource
+func (uc *scimUseCase) applyResource(ctx context.Context, group *entity.SCIMGroup, resource *entity.SCIMGroupResource) error {
+	members, err := uc.parseMembers(ctx, resource.Members)
+	if err != nil {
+		return err
+	}
+
+	group.DisplayName = strings.TrimSpace(resource.DisplayName)
+	group.ExternalID = resource.ExternalID
+	group.Members = members
+	return uc.checkDisplayName(ctx, group)
+}
+
+// applyPatch applies a PATCH operation to a group
+func (uc *scimUseCase) applyPatch(ctx context.Context, group *entity.SCIMGroup, operation entity.SCIMPatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	path := strings.TrimSpace(operation.Path)
+
+	// A member removed by a filtered path, members[value eq "<id>"]
+	if matches := scimMemberPathRegex.FindStringSubmatch(path); matches != nil {
+		if op != "remove" {
+			return ErrInvalidSCIMPatch
+		}
+		memberID, err := uuid.Parse(matches[1])
+		if err != nil {
+			return ErrInvalidSCIMPatch
+		}
+		group.Members = slices.DeleteFunc(group.Members, func(id uuid.UUID) bool { return id == memberID })
+		return nil
+	}
+
+	// Without a path, the value holds the attributes to add or replace
+	if path == "" {
+		if op != "add" && op != "replace" {
+			return ErrInvalidSCIMPatch
+		}
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return ErrInvalidSCIMPatch
+		}
+		for attribute, value := range attributes {
+			if err := uc.applyPatch(ctx, group, entity.SCIMPatchOperation{Op: op, Path: attribute, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch strings.ToLower(path) {
+	case "members":
+		var members []entity.SCIMMember
+		if len(operation.Value) > 0 {
+			if err := json.Unmarshal(operation.Value, &members); err != nil {
+				return ErrInvalidSCIMPatch
+			}
+		}
+		memberIDs, err := uc.parseMembers(ctx, members)
+		if err != nil {
+			return err
+		}
+
+		switch op {
+		case "add":
+			for _, memberID := range memberIDs {
+				if !slices.Contains(group.Members, memberID) {
+					group.Members = append(group.Members, memberID)
+				}
+			}
+		case "replace":
+			group.Members = memberIDs
+		case "remove":
+			// Removing the members without a value removes them all
+			if len(operation.Value) == 0 {
+				group.Members = []uuid.UUID{}
+				return nil
+			}
+			group.Members = slices.DeleteFunc(group.Members, func(id uuid.UUID) bool { return slices.Contains(memberIDs, id) })
+		default:
+			return ErrInvalidSCIMPatch
+		}
+	case "displayname":
+		var value string
+		if op == "remove" || json.Unmarshal(operation.Value, &value) != nil {
+			return ErrInvalidSCIMPatch
+		}
+		group.DisplayName = strings.TrimSpace(value)
+	case "externalid":
+		var value string
+		if op != "remove" && json.Unmarshal(operation.Value, &value) != nil {
+			return ErrInvalidSCIMPatch
+		}
+		group.ExternalID = value
+	default:
+		return ErrInvalidSCIMPatch
+	}
+
+	return nil
+}
+
+// parseMembers returns the IDs of the members, ErrSCIMUnknownMember unless each is the ID of an existing user
+func (uc *scimUseCase) parseMembers(ctx context.Context, members []entity.SCIMMember) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member.Value)
+		if err != nil {
+			return nil, ErrSCIMUnknownMember
+		}
+		if slices.Contains(ids, id) {
+			continue
+		}
+		user, err := uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrSCIMUnknownMember
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// checkDisplayName returns ErrInvalidSCIMGroup without a display name, ErrSCIMGroupExists when another group has
+// it regardless of case
+func (uc *scimUseCase) checkDisplayName(ctx context.Context, group *entity.SCIMGroup) error {
+	if group.DisplayName == "" {
+		return ErrInvalidSCIMGroup
+	}
+
+	groups, err := uc.groupRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, other := range groups {
+		if other.ID != group.ID && strings.EqualFold(other.DisplayName, group.DisplayName) {
+			return ErrSCIMGroupExists
+		}
+	}
+	return nil
+}
+
+// updateGroup stores a changed group, then syncs the members added and removed, every member past and present when
+// the display name changed
+func (uc *scimUseCase) updateGroup(ctx context.Context, previous, group *entity.SCIMGroup) (*entity.SCIMGroup, error) {
+	group.UpdatedAt = time.Now()
+	if err := uc.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	var affected []uuid.UUID
+	renamed := previous.DisplayName != group.DisplayName
+	for _, id := range previous.Members {
+		if renamed || !slices.Contains(group.Members, id) {
+			affected = append(affected, id)
+		}
+	}
+	for _, id := range group.Members {
+		if renamed || !slices.Contains(previous.Members, id) {
+			affected = append(affected, id)
+		}
+	}
+
+	return uc.syncGroup(ctx, group, affected), nil
+}
+
+// syncGroup syncs users affected by a change of a group and stores the outcome on the group. The change itself is
+// kept whatever the outcome, which is reported as the sync status of the group.
+func (uc *scimUseCase) syncGroup(ctx context.Context, group *entity.SCIMGroup, affected []uuid.UUID) *entity.SCIMGroup {
+	group.Sync = uc.syncUsers(ctx, affected)
+	if err := uc.groupRepo.Update(ctx, group); err != nil {
+		log.Error().Err(err).Str("scim_group_id", group.ID.String()).Msg("Failed to store the sync status of a SCIM group")
+	}
+
+	uc.annotate(group)
+	return group
+}
+
+// syncUsers gives each user the role and organization of their groups, one user failing not stopping the others
+func (uc *scimUseCase) syncUsers(ctx context.Context, userIDs []uuid.UUID) *entity.SCIMGroupSync {
+	sync := &entity.SCIMGroupSync{
+		Status:   entity.SCIMSyncStatusSynced,
+		SyncedAt: time.Now(),
+	}
+
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		changed, issues := uc.syncUser(ctx, userID)
+		sync.Users++
+		if changed {
+			sync.Changed++
+		}
+		sync.Issues = append(sync.Issues, issues...)
+	}
+
+	// The status is the most severe of the issues, role conflicts being resolved by the precedence
+	for _, issue := range sync.Issues {
+		switch {
+		case issue.Kind == entity.SCIMSyncIssueFailed:
+			sync.Status = entity.SCIMSyncStatusFailed
+		case issue.Kind == entity.SCIMSyncIssueOrgConflict && sync.Status != entity.SCIMSyncStatusFailed:
+			sync.Status = entity.SCIMSyncStatusConflict
+		case issue.Kind == entity.SCIMSyncIssuePendingApproval && sync.Status == entity.SCIMSyncStatusSynced:
+			sync.Status = entity.SCIMSyncStatusPendingApproval
+		}
+	}
+	return sync
+}
+
+// syncUser gives a user the role and organization their groups are mapped to and reports whether either changed.
+// A user whose groups map to no role falls back to the default role when their role came from a group, and leaves
+// an organization that came from a group.
+func (uc *scimUseCase) syncUser(ctx context.Context, userID uuid.UUID) (bool, []entity.SCIMSyncIssue) {
+	var issues []entity.SCIMSyncIssue
+	fail := func(err error) (bool, []entity.SCIMSyncIssue) {
+		return false, append(issues, entity.SCIMSyncIssue{UserID: userID, Kind: entity.SCIMSyncIssueFailed, Detail: err.Error()})
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fail(err)
+	}
+	if user == nil {
+		return false, nil
+	}
+	groups, err := uc.groupRepo.ListByMember(ctx, userID)
+	if err != nil {
+		return fail(err)
+	}
+
+	var roles []string
+	var orgIDs []uuid.UUID
+	for _, group := range groups {
+		if role, ok := uc.groupRoles[group.DisplayName]; ok && !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+		if orgID, ok := uc.groupOrgs[group.DisplayName]; ok && !slices.Contains(orgIDs, orgID) {
+			orgIDs = append(orgIDs, orgID)
+		}
+	}
+
+	changed := false
+	reason := entity.ActionReason{Code: entity.ReasonCodeOther, Note: scimSyncNote}
+
+	role := ""
+	switch {
+	case len(roles) > 0:
+		role = uc.resolveRole(roles)
+		if len(roles) > 1 {
+			issues = append(issues, entity.SCIMSyncIssue{
+				UserID: userID,
+				Kind:   entity.SCIMSyncIssueRoleConflict,
+				Detail: fmt.Sprintf("groups grant roles %s, %s applied", strings.Join(roles, ", "), role),
+			})
+		}
+	case uc.isMappedRole(user.Role):
+		role = uc.defaultRole
+	}
+	if role != "" && role != user.Role {
+		if uc.roleApprovalUseCase.RequiresApproval(role) {
+			_, err := uc.roleApprovalUseCase.Request(ctx, uuid.Nil, userID, entity.RoleChangeKindPrimary, role, 0, reason)
+			switch {
+			case err == nil || errors.Is(err, ErrRoleChangeAlreadyRequested):
+				issues = append(issues, entity.SCIMSyncIssue{
+					UserID: userID,
+					Kind:   entity.SCIMSyncIssuePendingApproval,
+					Detail: fmt.Sprintf("role %s requires approval", role),
+				})
+			default:
+				issues = append(issues, entity.SCIMSyncIssue{UserID: userID, Kind: entity.SCIMSyncIssueFailed, Detail: err.Error()})
+			}
+		} else if err := uc.userUseCase.UpdateRole(ctx, uuid.Nil, userID, role, reason); err != nil {
+			issues = append(issues, entity.SCIMSyncIssue{UserID: userID, Kind: entity.SCIMSyncIssueFailed, Detail: err.Error()})
+		} else {
+			changed = true
+		}
+	}
+
+	switch {
+	case len(orgIDs) > 1:
+		// Users belong to a single organization, so the choice is left to an administrator
+		issues = append(issues, entity.SCIMSyncIssue{
+			UserID: userID,
+			Kind:   entity.SCIMSyncIssueOrgConflict,
+			Detail: fmt.Sprintf("groups are mapped to %d organizations, organization left unchanged", len(orgIDs)),
+		})
+	case len(orgIDs) == 1:
+		if user.OrgID == nil || *user.OrgID != orgIDs[0] {
+			if err := uc.organizationUseCase.AddMember(ctx, uuid.Nil, orgIDs[0], userID); err != nil {
+				issues = append(issues, entity.SCIMSyncIssue{UserID: userID, Kind: entity.SCIMSyncIssueFailed, Detail: err.Error()})
+			} else {
+				changed = true
+			}
+		}
+	case user.OrgID != nil && uc.isMappedOrg(*user.OrgID):
+		if err := uc.organizationUseCase.RemoveMember(ctx, uuid.Nil, *user.OrgID, userID); err != nil {
+			issues = append(issues, entity.SCIMSyncIssue{UserID: userID, Kind: entity.SCIMSyncIssueFailed, Detail: err.Error()})
+		} else {
+			changed = true
+		}
+	}
+
+	return changed, issues
+}
+
+// resolveRole picks the role of highest precedence, the roles missing from the precedence coming last in
+// alphabetical order
+func (uc *scimUseCase) resolveRole(roles []string) string {
+	rank := func(role string) int {
+		if i := slices.Index(uc.rolePrecedence, role); i >= 0 {
+			return i
+		}
+		return len(uc.rolePrecedence)
+	}
+
+	sorted := slices.Clone(roles)
+	sort.Slice(sorted, func(i, j int) bool {
+		if rank(sorted[i]) != rank(sorted[j]) {
+			return rank(sorted[i]) < rank(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted[0]
+}
+
+// isMappedRole reports whether a group is mapped to a role other than the default one, so users holding it got it
+// from a group
+func (uc *scimUseCase) isMappedRole(role string) bool {
+	if role == uc.defaultRole {
+		return false
+	}
+	for _, mapped := range uc.groupRoles {
+		if mapped == role {
+			return true
+		}
+	}
+	return false
+}
+
+// isMappedOrg reports whether a group is mapped to an organization
+func (uc *scimUseCase) isMappedOrg(orgID uuid.UUID) bool {
+	for _, mapped := range uc.groupOrgs {
+		if mapped == orgID {
+			return true
+		}
+	}
+	return false
+}
+
+// annotate sets the role and organization a group is mapped to
+func (uc *scimUseCase) annotate(group *entity.SCIMGroup) {
+	group.Role = uc.groupRoles[group.DisplayName]
+	group.OrgID = nil
+	if orgID, ok := uc.groupOrgs[group.DisplayName]; ok {
+		group.OrgID = &orgID
+	}
+}
+
+// parseSCIMFilter returns the attribute and value of an equality filter, ErrInvalidSCIMFilter for other filters
+func parseSCIMFilter(filter string) (string, string, error) {
+	matches := scimFilterRegex.FindStringSubmatch(filter)
+	if matches == nil {
+		return "", "", ErrInvalidSCIMFilter
+	}
+	return matches[1], matches[2], nil
+}
+
+// scimCount returns the page size for a requested count, capped and defaulting to scimMaxResults
+func scimCount(count int) int {
+	if count < 0 || count > scimMaxResults {
+		return scimMaxResults
+	}
+	return count
+}
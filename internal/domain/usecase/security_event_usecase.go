@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/chats/go-user-api/internal/infrastructure/siem"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// securityEventClaimScope is the dedup scope claiming the SIEM queue, so instances do not send the same events
+	securityEventClaimScope = "siem_dispatch"
+
+	// securityEventClaimKey is the single key of the SIEM queue, events are sent in order by one instance at a time
+	securityEventClaimKey = "queue"
+)
+
+// SecurityEventUseCase defines the use case streaming security events to the SIEM. Events are buffered in the
+// database until the sink accepts them, so an unreachable SIEM delays them without losing any.
+type SecurityEventUseCase interface {
+	// Create records an audit entry, queueing it for the SIEM when its action is security-relevant.
+	// It implements repository.AuditRepository so every use case auditing through it feeds the SIEM.
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+
+	// Record queues a security event that is not audited, failures are logged
+	Record(ctx context.Context, event *entity.SecurityEvent)
+
+	// Dispatch sends the queued events to the SIEM, oldest first, until the queue is empty or the sink fails
+	Dispatch(ctx context.Context) error
+
+	// Run dispatches the queued events periodically, and whenever events are queued, until the context is cancelled
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// securityEventUseCase implements SecurityEventUseCase interface
+type securityEventUseCase struct {
+	auditRepo repository.AuditRepository
+	eventRepo repository.SecurityEventRepository
+	dedupRepo repository.DedupRepository
+	sink      siem.Sink
+	config    config.SIEMConfig
+
+	// wake triggers a dispatch pass without waiting for the next tick
+	wake chan struct{}
+}
+
+// NewSecurityEventUseCase creates a new SecurityEventUseCase. The sink is only used when the SIEM is enabled.
+func NewSecurityEventUseCase(
+	auditRepo repository.AuditRepository,
+	eventRepo repository.SecurityEventRepository,
+	dedupRepo repository.DedupRepository,
+	sink siem.Sink,
+	config config.SIEMConfig,
+) SecurityEventUseCase {
+	return &securityEventUseCase{
+		auditRepo: auditRepo,
+		eventRepo: eventRepo,
+		dedupRepo: dedupRepo,
+		sink:      sink,
+		config:    config,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Create records an audit entry, queueing it for the SIEM when its action is security-relevant
+func (uc *securityEventUseCase) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		return err
+	}
+
+	if event := entity.SecurityEventFromAudit(entry); event != nil {
+		uc.Record(ctx, event)
+	}
+	return nil
+}
+
+// Record queues a security event that is not audited
+func (uc *securityEventUseCase) Record(ctx context.Context, event *entity.SecurityEvent) {
+	if !uc.config.Enabled {
+		return
+	}
+
+	if err := uc.eventRepo.Create(ctx, event); err != nil {
+		log.Error().Err(err).Str("event_id", event.ID.String()).Str("type", event.Type).Msg("Failed to queue security event")
+		return
+	}
+	uc.notify()
+}
+
+// Dispatch sends the queued events to the SIEM, oldest first
+func (uc *securityEventUseCase) Dispatch(ctx context.Context) error {
+	// Claim the queue, any other instance skips this pass. Fail open, the collector deduplicates on the event IDs.
+	claimed, err := uc.dedupRepo.Claim(ctx, securityEventClaimScope, securityEventClaimKey, uc.config.Timeout+time.Minute)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to claim the SIEM queue")
+	} else if !claimed {
+		return nil
+	} else {
+		defer func() {
+			if err := uc.dedupRepo.Release(context.WithoutCancel(ctx), securityEventClaimScope, securityEventClaimKey); err != nil {
+				log.Warn().Err(err).Msg("Failed to release the SIEM queue")
+			}
+		}()
+	}
+
+	for ctx.Err() == nil {
+		events, err := uc.eventRepo.ListPending(ctx, uc.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, uc.config.Timeout)
+		err = uc.sink.Send(sendCtx, events)
+		cancel()
+		if err != nil {
+			// Keep the events queued, the next pass sends them again
+			metrics.SIEMDeliveries.WithLabelValues("failed").Add(float64(len(events)))
+			return err
+		}
+		metrics.SIEMDeliveries.WithLabelValues("delivered").Add(float64(len(events)))
+
+		ids := make([]uuid.UUID, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		if err := uc.eventRepo.Delete(ctx, ids); err != nil {
+			return err
+		}
+		if len(events) < uc.config.BatchSize {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// Run dispatches the queued events periodically, and whenever events are queued, until the context is cancelled
+func (uc *securityEventUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+
+		if err := uc.Dispatch(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to send security events to the SIEM")
+		}
+	}
+}
+
+// notify wakes the dispatcher up, without blocking when a pass is already pending
+func (uc *securityEventUseCase) notify() {
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+}
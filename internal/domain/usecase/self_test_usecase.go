@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/internal/infrastructure/mailer"
+	"github.com/google/uuid"
+)
+
+// SelfTestUseCase defines the use case of the self-test, smoke testing the dependencies of an instance right after
+// a deploy. Checks only write throwaway probes, the users and their sessions are left untouched.
+type SelfTestUseCase interface {
+	// Run runs every check, each within the configured timeout, and returns the report
+	Run(ctx context.Context) *entity.SelfTestReport
+}
+
+// selfTestUseCase implements SelfTestUseCase interface
+type selfTestUseCase struct {
+	selfTestRepo      repository.SelfTestRepository
+	selfTestCacheRepo repository.SelfTestRepository
+	tokenService      service.TokenService
+	mailer            mailer.Mailer
+	securityConfig    config.SecurityConfig
+	config            config.SelfTestConfig
+}
+
+// NewSelfTestUseCase creates a new SelfTestUseCase. The probes of the database are written through selfTestRepo,
+// the probes of the cache through selfTestCacheRepo.
+func NewSelfTestUseCase(
+	selfTestRepo repository.SelfTestRepository,
+	selfTestCacheRepo repository.SelfTestRepository,
+	tokenService service.TokenService,
+	mailer mailer.Mailer,
+	securityConfig config.SecurityConfig,
+	config config.SelfTestConfig,
+) SelfTestUseCase {
+	return &selfTestUseCase{
+		selfTestRepo:      selfTestRepo,
+		selfTestCacheRepo: selfTestCacheRepo,
+		tokenService:      tokenService,
+		mailer:            mailer,
+		securityConfig:    securityConfig,
+		config:            config,
+	}
+}
+
+// Run runs every check and returns the report, failed when any check failed
+func (uc *selfTestUseCase) Run(ctx context.Context) *entity.SelfTestReport {
+	report := &entity.SelfTestReport{
+		Status:    entity.SelfTestStatusPass,
+		StartedAt: time.Now(),
+	}
+
+	for _, check := range []struct {
+		name string
+		run  func(ctx context.Context) (string, error)
+	}{
+		{"database", func(ctx context.Context) (string, error) { return uc.checkProbe(ctx, uc.selfTestRepo) }},
+		{"cache", func(ctx context.Context) (string, error) { return uc.checkProbe(ctx, uc.selfTestCacheRepo) }},
+		{"token", uc.checkToken},
+		{"mailer", uc.checkMailer},
+		{"keys", uc.checkKeys},
+	} {
+		result := uc.runCheck(ctx, check.name, check.run)
+		if result.Status == entity.SelfTestStatusFail {
+			report.Status = entity.SelfTestStatusFail
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	report.DurationMs = time.Since(report.StartedAt).Milliseconds()
+	return report
+}
+
+// runCheck runs a check within the configured timeout, skipped when it reports mailer.ErrNotConfigured
+func (uc *selfTestUseCase) runCheck(ctx context.Context, name string, run func(ctx context.Context) (string, error)) *entity.SelfTestCheck {
+	checkCtx, cancel := context.WithTimeout(ctx, uc.config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	detail, err := run(checkCtx)
+	result := &entity.SelfTestCheck{
+		Name:       name,
+		Status:     entity.SelfTestStatusPass,
+		DurationMs: time.Since(start).Milliseconds(),
+		Detail:     detail,
+	}
+	switch {
+	case errors.Is(err, mailer.ErrNotConfigured):
+		result.Status = entity.SelfTestStatusSkip
+		result.Detail = err.Error()
+	case err != nil:
+		result.Status = entity.SelfTestStatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// checkProbe writes a probe, reads it back and deletes it
+func (uc *selfTestUseCase) checkProbe(ctx context.Context, repo repository.SelfTestRepository) (string, error) {
+	probe := &entity.SelfTestProbe{
+		ID:        uuid.New(),
+		Value:     uuid.NewString(),
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if err := repo.Write(ctx, probe); err != nil {
+		return "", err
+	}
+	// Deleted even when the read fails, with a context of its own in case the check timed out
+	defer func() {
+		deleteCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), uc.config.Timeout)
+		defer cancel()
+		repo.Delete(deleteCtx, probe.ID)
+	}()
+
+	read, err := repo.Read(ctx, probe.ID)
+	if err != nil {
+		return "", err
+	}
+	if read == nil {
+		return "", errors.New("probe not found after write")
+	}
+	if read.Value != probe.Value {
+		return "", errors.New("probe read back differs from the one written")
+	}
+	return "", nil
+}
+
+// checkToken signs an access token for a throwaway user and verifies it
+func (uc *selfTestUseCase) checkToken(ctx context.Context) (string, error) {
+	user := &entity.User{
+		ID:     uuid.New(),
+		Role:   entity.UserRoleUser,
+		Status: entity.UserStatusActive,
+	}
+	tokens, _, _, err := uc.tokenService.GenerateTokens(user, uuid.New(), false)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := uc.tokenService.ValidateToken(tokens.AccessToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify signed token: %w", err)
+	}
+	if claims.UserID != user.ID || claims.TokenType != entity.AccessToken {
+		return "", errors.New("verified token claims differ from the signed ones")
+	}
+	return "", nil
+}
+
+// checkMailer opens a session with the SMTP server, skipped without an SMTP host
+func (uc *selfTestUseCase) checkMailer(ctx context.Context) (string, error) {
+	return "", uc.mailer.Verify(ctx)
+}
+
+// checkKeys checks the configured signing key pair matches, and reports the keys accepted for verification
+func (uc *selfTestUseCase) checkKeys(ctx context.Context) (string, error) {
+	publicKeys := uc.tokenService.PublicKeys()
+	if len(uc.tokenService.GetPublicKey()) != ed25519.PublicKeySize || len(publicKeys) == 0 {
+		return "", errors.New("no active signing key")
+	}
+
+	if uc.securityConfig.PasetoPublicKey != "" {
+		configured, err := hex.DecodeString(uc.securityConfig.PasetoPublicKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode public key: %w", err)
+		}
+		matched := false
+		for _, publicKey := range publicKeys {
+			matched = matched || bytes.Equal(publicKey, configured)
+		}
+		if !matched {
+			return "", errors.New("PASETO_PUBLIC_KEY does not match PASETO_PRIVATE_KEY")
+		}
+	}
+
+	rotation := "disabled"
+	if uc.securityConfig.SigningKeyEncryptionKey != "" {
+		rotation = "enabled"
+	}
+	return fmt.Sprintf("verification keys: %d, rotation: %s", len(publicKeys), rotation), nil
+}
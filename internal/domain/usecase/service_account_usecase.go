@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrServiceAccountNotFound is returned when acting on an unknown service account
+	ErrServiceAccountNotFound = errors.New("service account not found")
+
+	// ErrInvalidServiceAccountName is returned when creating a service account without a name or with an overly
+	// long one
+	ErrInvalidServiceAccountName = errors.New("invalid service account name")
+
+	// ErrInvalidServiceAccountScope is returned when creating a service account without scopes or with an unknown
+	// scope
+	ErrInvalidServiceAccountScope = errors.New("invalid service account scope")
+)
+
+const (
+	// serviceAccountClientIDPrefix and serviceAccountSecretPrefix start the client IDs and secrets, so leaked
+	// secrets are easy to recognize and scan for
+	serviceAccountClientIDPrefix = "sa_"
+	serviceAccountSecretPrefix   = "sas_"
+
+	// maxServiceAccountNameLength caps the length of service account names, in characters
+	maxServiceAccountNameLength = 64
+)
+
+// ServiceAccountUseCase defines the use case for the service accounts backend services obtain access tokens with
+type ServiceAccountUseCase interface {
+	// Create creates a service account on behalf of an administrator and returns it along with its client
+	// secret, which is not revealed again
+	Create(ctx context.Context, actorID uuid.UUID, name string, scopes []string) (*entity.CreatedServiceAccount, error)
+
+	// List returns the service accounts, oldest first
+	List(ctx context.Context) ([]*entity.ServiceAccount, error)
+
+	// RotateSecret replaces the client secret of a service account and returns the new one, the tokens already
+	// issued stay valid until revoked
+	RotateSecret(ctx context.Context, actorID, id uuid.UUID) (*entity.CreatedServiceAccount, error)
+
+	// Delete deletes a service account and revokes its tokens
+	Delete(ctx context.Context, actorID, id uuid.UUID) error
+
+	// ListTokens returns the sessions of the live tokens of a service account, one per token
+	ListTokens(ctx context.Context, id uuid.UUID) ([]*entity.Session, error)
+
+	// RevokeTokens revokes every token issued to a service account
+	RevokeTokens(ctx context.Context, actorID, id uuid.UUID) error
+
+	// IssueToken exchanges the client credentials of a service account for an access token, following the
+	// client credentials grant of RFC 6749
+	IssueToken(ctx context.Context, req *entity.ClientTokenRequest) (*entity.ClientTokenResponse, error)
+}
+
+// serviceAccountUseCase implements ServiceAccountUseCase interface
+type serviceAccountUseCase struct {
+	serviceAccountRepo repository.ServiceAccountRepository
+	tokenRepo          repository.TokenRepository
+	auditRepo          repository.AuditRepository
+	tokenService       service.TokenService
+}
+
+// NewServiceAccountUseCase creates a new ServiceAccountUseCase
+func NewServiceAccountUseCase(
+	serviceAccountRepo repository.ServiceAccountRepository,
+	tokenRepo repository.TokenRepository,
+	auditRepo repository.AuditRepository,
+	tokenService service.TokenService,
+) ServiceAccountUseCase {
+	return &serviceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		tokenRepo:          tokenRepo,
+		auditRepo:          auditRepo,
+		tokenService:       tokenService,
+	}
+}
+
+// clientSecretHash returns the hash of a client secret.
+// Only a hash of the secret is stored, so the database contents cannot be used to obtain tokens.
+func clientSecretHash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// newClientSecret generates a client secret along with its hash
+func newClientSecret() (string, string, error) {
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	secret = serviceAccountSecretPrefix + secret
+	return secret, clientSecretHash(secret), nil
+}
+
+// Create creates a service account granted the scopes, which cannot be changed afterwards
+func (uc *serviceAccountUseCase) Create(ctx context.Context, actorID uuid.UUID, name string, scopes []string) (*entity.CreatedServiceAccount, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxServiceAccountNameLength {
+		return nil, ErrInvalidServiceAccountName
+	}
+
+	scopes = slices.Compact(slices.Sorted(slices.Values(scopes)))
+	if len(scopes) == 0 {
+		return nil, ErrInvalidServiceAccountScope
+	}
+	for _, scope := range scopes {
+		if !entity.IsValidAPIKeyScope(scope) {
+			return nil, ErrInvalidServiceAccountScope
+		}
+	}
+
+	clientID, err := utils.GenerateRandomToken(12)
+	if err != nil {
+		return nil, err
+	}
+	secret, hash, err := newClientSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account := &entity.ServiceAccount{
+		ID:         uuid.New(),
+		Name:       name,
+		ClientID:   serviceAccountClientIDPrefix + clientID,
+		SecretHash: hash,
+		Scopes:     scopes,
+		CreatedBy:  actorID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := uc.serviceAccountRepo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionServiceAccountCreated, actorID, account)
+	return &entity.CreatedServiceAccount{ServiceAccount: account, ClientSecret: secret}, nil
+}
+
+// List returns the service accounts, oldest first
+func (uc *serviceAccountUseCase) List(ctx context.Context) ([]*entity.ServiceAccount, error) {
+	return uc.serviceAccountRepo.List(ctx)
+}
+
+// RotateSecret replaces the client secret of a service account, the former secret is rejected right away
+func (uc *serviceAccountUseCase) RotateSecret(ctx context.Context, actorID, id uuid.UUID) (*entity.CreatedServiceAccount, error) {
+	account, err := uc.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, hash, err := newClientSecret()
+	if err != nil {
+		return nil, err
+	}
+	account.SecretHash = hash
+	account.UpdatedAt = time.Now()
+	if err := uc.serviceAccountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionServiceAccountRotated, actorID, account)
+	return &entity.CreatedServiceAccount{ServiceAccount: account, ClientSecret: secret}, nil
+}
+
+// Delete deletes a service account, then revokes its tokens
+func (uc *serviceAccountUseCase) Delete(ctx context.Context, actorID, id uuid.UUID) error {
+	account, err := uc.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.serviceAccountRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, id); err != nil {
+		return err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionServiceAccountDeleted, actorID, account)
+	return nil
+}
+
+// ListTokens returns the sessions of the live tokens of a service account
+func (uc *serviceAccountUseCase) ListTokens(ctx context.Context, id uuid.UUID) ([]*entity.Session, error) {
+	if _, err := uc.get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.tokenRepo.ListUserSessions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []*entity.Session{}
+	}
+	return sessions, nil
+}
+
+// RevokeTokens revokes every token issued to a service account, it may obtain new ones with its client secret
+func (uc *serviceAccountUseCase) RevokeTokens(ctx context.Context, actorID, id uuid.UUID) error {
+	account, err := uc.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, id); err != nil {
+		return err
+	}
+
+	uc.recordAction(ctx, entity.AuditActionServiceAccountRevoked, actorID, account)
+	return nil
+}
+
+// IssueToken issues an access token granted the requested scopes, all the scopes of the service account when
+// none are requested. Every token is a session of its own, revoked like the sessions of users.
+func (uc *serviceAccountUseCase) IssueToken(ctx context.Context, req *entity.ClientTokenRequest) (*entity.ClientTokenResponse, error) {
+	if req.GrantType != entity.GrantTypeClientCredentials {
+		return nil, ErrOIDCUnsupportedGrantType
+	}
+	if req.ClientID == "" || req.ClientSecret == "" {
+		return nil, ErrOIDCInvalidClient
+	}
+
+	account, err := uc.serviceAccountRepo.GetByClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || subtle.ConstantTimeCompare([]byte(account.SecretHash), []byte(clientSecretHash(req.ClientSecret))) != 1 {
+		return nil, ErrOIDCInvalidClient
+	}
+
+	scopes := account.Scopes
+	if requested := strings.Fields(req.Scope); len(requested) > 0 {
+		scopes = slices.Compact(slices.Sorted(slices.Values(requested)))
+		for _, scope := range scopes {
+			if !account.HasScope(scope) {
+				return nil, ErrOIDCInvalidScope
+			}
+		}
+	}
+
+	token, details, err := uc.tokenService.GenerateClientToken(account, scopes, uuid.New())
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.tokenRepo.StoreAccessToken(ctx, details); err != nil {
+		return nil, err
+	}
+
+	// The token is issued even when its time cannot be stored, it is stored again with the next one
+	account.LastTokenAt = &details.IssuedAt
+	if err := uc.serviceAccountRepo.Update(ctx, account); err != nil {
+		log.Error().Err(err).Str("service_account_id", account.ID.String()).Msg("Failed to update service account last token time")
+	}
+
+	return &entity.ClientTokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(details.Expiration.Sub(details.IssuedAt).Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// get returns a service account, ErrServiceAccountNotFound if unknown
+func (uc *serviceAccountUseCase) get(ctx context.Context, id uuid.UUID) (*entity.ServiceAccount, error) {
+	account, err := uc.serviceAccountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrServiceAccountNotFound
+	}
+	return account, nil
+}
+
+// recordAction records an action on a service account in the audit trail, the service account is the target
+func (uc *serviceAccountUseCase) recordAction(ctx context.Context, action string, actorID uuid.UUID, account *entity.ServiceAccount) {
+	entry := entity.NewAuditEntry(action, actorID, account.ID, map[string]string{
+		"client_id": account.ClientID,
+		"name":      account.Name,
+		"scopes":    strings.Join(account.Scopes, ", "),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("service_account_id", account.ID.String()).Msg("Failed to record service account action in audit trail")
+	}
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidEmailEvent   = errors.New("invalid email event")
+	ErrSuppressionNotFound = errors.New("email suppression not found")
+	ErrTooManyEmailEvents  = errors.New("too many email events")
+)
+
+// maxEmailEvents is the maximum number of email events handled at once
+const maxEmailEvents = 100
+
+// SuppressionUseCase defines the use case for the email suppression list fed by the bounces and complaints
+// reported by the email provider
+type SuppressionUseCase interface {
+	// HandleEvents records bounces and complaints. Permanent bounces and complaints suppress the address and flag
+	// the accounts using it for re-verification, transient bounces are ignored.
+	HandleEvents(ctx context.Context, events []entity.EmailEvent) error
+
+	// ListSuppressions returns the suppression list, newest first
+	ListSuppressions(ctx context.Context) ([]*entity.EmailSuppression, error)
+
+	// RemoveSuppression lifts the suppression of an address, performed by an administrator
+	RemoveSuppression(ctx context.Context, actorID uuid.UUID, email string) error
+}
+
+// suppressionUseCase implements SuppressionUseCase interface
+type suppressionUseCase struct {
+	suppressionRepo repository.SuppressionRepository
+	userRepo        repository.UserRepository
+	auditRepo       repository.AuditRepository
+}
+
+// NewSuppressionUseCase creates a new SuppressionUseCase
+func NewSuppressionUseCase(
+	suppressionRepo repository.SuppressionRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+) SuppressionUseCase {
+	return &suppressionUseCase{
+		suppressionRepo: suppressionRepo,
+		userRepo:        userRepo,
+		auditRepo:       auditRepo,
+	}
+}
+
+// HandleEvents records bounces and complaints.
+// Every event is validated before any is applied, and applying an event twice is harmless, so the provider can
+// safely retry a failed delivery.
+func (uc *suppressionUseCase) HandleEvents(ctx context.Context, events []entity.EmailEvent) error {
+	if len(events) > maxEmailEvents {
+		return ErrTooManyEmailEvents
+	}
+	for _, event := range events {
+		if err := validateEmailEvent(event); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		if event.Type == entity.EmailEventBounce && event.BounceType == entity.BounceTypeTransient {
+			log.Info().Str("description", event.Description).Msg("Ignoring transient email bounce")
+			continue
+		}
+		if err := uc.suppress(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// suppress adds the address of an event to the suppression list and flags the accounts using it
+func (uc *suppressionUseCase) suppress(ctx context.Context, event entity.EmailEvent) error {
+	suppression := entity.NewEmailSuppression(event)
+	if err := uc.suppressionRepo.Add(ctx, suppression); err != nil {
+		return err
+	}
+	log.Warn().Str("reason", suppression.Reason).Msg("Email address suppressed")
+
+	// The account using the address as its email must verify it again
+	user, err := uc.userRepo.GetByEmail(ctx, suppression.Email)
+	if err != nil {
+		return err
+	}
+	if user != nil && !user.EmailReverificationRequired {
+		user.EmailVerified = false
+		user.EmailReverificationRequired = true
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		uc.recordSuppression(ctx, user, suppression, "email")
+	}
+
+	// A verified recovery email stops being used for password resets and security notifications
+	user, err = uc.userRepo.GetByRecoveryEmail(ctx, suppression.Email)
+	if err != nil {
+		return err
+	}
+	if user != nil && user.RecoveryEmailVerified {
+		user.RecoveryEmailVerified = false
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		uc.recordSuppression(ctx, user, suppression, "recovery_email")
+	}
+
+	return nil
+}
+
+// recordSuppression records in the audit trail that an address of a user was suppressed
+func (uc *suppressionUseCase) recordSuppression(ctx context.Context, user *entity.User, suppression *entity.EmailSuppression, field string) {
+	entry := entity.NewAuditEntry(entity.AuditActionEmailSuppressed, uuid.Nil, user.ID, map[string]string{
+		"field":       field,
+		"email":       suppression.Email,
+		"reason":      suppression.Reason,
+		"description": suppression.Description,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to record email suppression in audit trail")
+	}
+}
+
+// ListSuppressions returns the suppression list, newest first
+func (uc *suppressionUseCase) ListSuppressions(ctx context.Context) ([]*entity.EmailSuppression, error) {
+	return uc.suppressionRepo.List(ctx)
+}
+
+// RemoveSuppression lifts the suppression of an address.
+// Flagged accounts keep their flag until the address is verified again.
+func (uc *suppressionUseCase) RemoveSuppression(ctx context.Context, actorID uuid.UUID, email string) error {
+	email = entity.NormalizeEmail(email)
+
+	suppression, err := uc.suppressionRepo.Get(ctx, email)
+	if err != nil {
+		return err
+	}
+	if suppression == nil {
+		return ErrSuppressionNotFound
+	}
+
+	if err := uc.suppressionRepo.Remove(ctx, email); err != nil {
+		return err
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionSuppressionRemoved, actorID, uuid.Nil, map[string]string{
+		"email":  email,
+		"reason": suppression.Reason,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Failed to record email suppression removal in audit trail")
+	}
+
+	return nil
+}
+
+// validateEmailEvent checks the type and address of an email event
+func validateEmailEvent(event entity.EmailEvent) error {
+	switch event.Type {
+	case entity.EmailEventBounce:
+		switch event.BounceType {
+		case "", entity.BounceTypePermanent, entity.BounceTypeTransient:
+		default:
+			return ErrInvalidEmailEvent
+		}
+	case entity.EmailEventComplaint:
+	default:
+		return ErrInvalidEmailEvent
+	}
+
+	if address, err := mail.ParseAddress(event.Email); err != nil || address.Address != event.Email {
+		return ErrInvalidEmailEvent
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrTeamNotFound is returned when acting on an unknown team
+	ErrTeamNotFound = errors.New("team not found")
+
+	// ErrTeamAlreadyExists is returned when creating a team under a taken name
+	ErrTeamAlreadyExists = errors.New("team already exists")
+
+	// ErrInvalidTeamName is returned when creating a team under a name that is not a short lowercase slug
+	ErrInvalidTeamName = errors.New("invalid team name")
+
+	// ErrTeamRoleRequiresApproval is returned when a team would grant a role subject to the two-person rule, which
+	// would let adding a member bypass the approval
+	ErrTeamRoleRequiresApproval = errors.New("role requires approval and cannot be granted by teams")
+
+	// ErrTeamMemberAlreadyAdded is returned when adding a user to a team they are already a member of
+	ErrTeamMemberAlreadyAdded = errors.New("user already a member of the team")
+
+	// ErrTeamMemberNotFound is returned when removing a user from a team they are not a member of
+	ErrTeamMemberNotFound = errors.New("user not a member of the team")
+)
+
+// TeamUseCase defines the use case for teams, the groups of users granted the roles of their team
+type TeamUseCase interface {
+	// CreateTeam defines a new team
+	CreateTeam(ctx context.Context, name string, spec entity.TeamSpec) (*entity.Team, error)
+
+	// GetTeam returns a team
+	GetTeam(ctx context.Context, name string) (*entity.Team, error)
+
+	// ListTeams returns all teams ordered by name
+	ListTeams(ctx context.Context) ([]*entity.Team, error)
+
+	// UpdateTeam replaces the description and roles of a team, its members are granted the new roles right away
+	UpdateTeam(ctx context.Context, name string, spec entity.TeamSpec) (*entity.Team, error)
+
+	// DeleteTeam deletes a team along with its memberships
+	DeleteTeam(ctx context.Context, name string) error
+
+	// AddMember adds a user to a team on behalf of an administrator
+	AddMember(ctx context.Context, actorID uuid.UUID, team string, userID uuid.UUID) (*entity.TeamMember, error)
+
+	// RemoveMember removes a user from a team on behalf of an administrator
+	RemoveMember(ctx context.Context, actorID uuid.UUID, team string, userID uuid.UUID) error
+
+	// ListMembers returns the members of a team, oldest membership first
+	ListMembers(ctx context.Context, team string) ([]*entity.TeamMember, error)
+
+	// ListUserTeams returns the teams a user is a member of, ordered by team
+	ListUserTeams(ctx context.Context, userID uuid.UUID) ([]*entity.Team, error)
+}
+
+// teamUseCase implements TeamUseCase interface
+type teamUseCase struct {
+	teamRepo            repository.TeamRepository
+	memberRepo          repository.TeamMemberRepository
+	userRepo            repository.UserRepository
+	auditRepo           repository.AuditRepository
+	roleUseCase         RoleUseCase
+	roleApprovalUseCase RoleApprovalUseCase
+}
+
+// NewTeamUseCase creates a new TeamUseCase
+func NewTeamUseCase(
+	teamRepo repository.TeamRepository,
+	memberRepo repository.TeamMemberRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	roleUseCase RoleUseCase,
+	roleApprovalUseCase RoleApprovalUseCase,
+) TeamUseCase {
+	return &teamUseCase{
+		teamRepo:            teamRepo,
+		memberRepo:          memberRepo,
+		userRepo:            userRepo,
+		auditRepo:           auditRepo,
+		roleUseCase:         roleUseCase,
+		roleApprovalUseCase: roleApprovalUseCase,
+	}
+}
+
+// CreateTeam defines a new team
+func (uc *teamUseCase) CreateTeam(ctx context.Context, name string, spec entity.TeamSpec) (*entity.Team, error) {
+	if !entity.IsValidRoleName(name) {
+		return nil, ErrInvalidTeamName
+	}
+	spec, err := uc.validateTeamSpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if team already exists
+	existing, err := uc.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrTeamAlreadyExists
+	}
+
+	team := entity.NewTeam(name, spec)
+	if err := uc.teamRepo.Create(ctx, team); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// GetTeam returns a team, ErrTeamNotFound if unknown
+func (uc *teamUseCase) GetTeam(ctx context.Context, name string) (*entity.Team, error) {
+	team, err := uc.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	return team, nil
+}
+
+// ListTeams returns all teams ordered by name
+func (uc *teamUseCase) ListTeams(ctx context.Context) ([]*entity.Team, error) {
+	return uc.teamRepo.List(ctx)
+}
+
+// UpdateTeam replaces the description and roles of a team
+func (uc *teamUseCase) UpdateTeam(ctx context.Context, name string, spec entity.TeamSpec) (*entity.Team, error) {
+	team, err := uc.GetTeam(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err = uc.validateTeamSpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	team.Description = spec.Description
+	team.Roles = spec.Roles
+	team.UpdatedAt = time.Now()
+
+	if err := uc.teamRepo.Update(ctx, team); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// DeleteTeam deletes a team, then its memberships. The members lose the roles of the team as soon as it is gone, so
+// leftover memberships are only logged.
+func (uc *teamUseCase) DeleteTeam(ctx context.Context, name string) error {
+	if _, err := uc.GetTeam(ctx, name); err != nil {
+		return err
+	}
+
+	if err := uc.teamRepo.Delete(ctx, name); err != nil {
+		return err
+	}
+	if err := uc.memberRepo.DeleteByTeam(ctx, name); err != nil {
+		log.Error().Err(err).Str("team", name).Msg("Failed to delete the memberships of a deleted team")
+	}
+
+	return nil
+}
+
+// AddMember adds an existing user to an existing team
+func (uc *teamUseCase) AddMember(ctx context.Context, actorID uuid.UUID, team string, userID uuid.UUID) (*entity.TeamMember, error) {
+	if _, err := uc.GetTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	member := &entity.TeamMember{
+		Team:    team,
+		UserID:  userID,
+		AddedBy: actorID,
+		AddedAt: time.Now(),
+	}
+	added, err := uc.memberRepo.Add(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrTeamMemberAlreadyAdded
+	}
+
+	uc.recordMembership(ctx, entity.AuditActionTeamMemberAdded, actorID, userID, team)
+	return member, nil
+}
+
+// RemoveMember removes a user from a team
+func (uc *teamUseCase) RemoveMember(ctx context.Context, actorID uuid.UUID, team string, userID uuid.UUID) error {
+	removed, err := uc.memberRepo.Remove(ctx, team, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrTeamMemberNotFound
+	}
+
+	uc.recordMembership(ctx, entity.AuditActionTeamMemberRemoved, actorID, userID, team)
+	return nil
+}
+
+// ListMembers returns the members of an existing team, oldest membership first
+func (uc *teamUseCase) ListMembers(ctx context.Context, team string) ([]*entity.TeamMember, error) {
+	if _, err := uc.GetTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	return uc.memberRepo.ListByTeam(ctx, team)
+}
+
+// ListUserTeams returns the teams of an existing user, skipping the memberships of deleted teams
+func (uc *teamUseCase) ListUserTeams(ctx context.Context, userID uuid.UUID) ([]*entity.Team, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	memberships, err := uc.memberRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]*entity.Team, 0, len(memberships))
+	for _, membership := range memberships {
+		team, err := uc.teamRepo.GetByName(ctx, membership.Team)
+		if err != nil {
+			return nil, err
+		}
+		if team != nil {
+			teams = append(teams, team)
+		}
+	}
+
+	return teams, nil
+}
+
+// validateTeamSpec validates the roles of a team exist and are not subject to the two-person rule, and returns the
+// spec with the roles sorted without duplicates
+func (uc *teamUseCase) validateTeamSpec(ctx context.Context, spec entity.TeamSpec) (entity.TeamSpec, error) {
+	spec.Roles = dedupe(spec.Roles)
+	for _, role := range spec.Roles {
+		if _, err := uc.roleUseCase.GetRole(ctx, role); err != nil {
+			if errors.Is(err, ErrRoleNotFound) {
+				return spec, ErrInvalidRole
+			}
+			return spec, err
+		}
+		if uc.roleApprovalUseCase.RequiresApproval(role) {
+			return spec, ErrTeamRoleRequiresApproval
+		}
+	}
+	sort.Strings(spec.Roles)
+
+	return spec, nil
+}
+
+// recordMembership records the addition of a user to a team, or their removal, in the audit trail
+func (uc *teamUseCase) recordMembership(ctx context.Context, action string, actorID, userID uuid.UUID, team string) {
+	entry := entity.NewAuditEntry(action, actorID, userID, map[string]string{"team": team})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("user_id", userID.String()).Msg("Failed to record team membership in audit trail")
+	}
+}
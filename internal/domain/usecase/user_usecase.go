@@ -3,12 +3,21 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/mail"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/chats/go-user-api/config"
 	"github.com/chats/go-user-api/internal/domain/entity"
 	"github.com/chats/go-user-api/internal/domain/repository"
-	"github.com/chats/go-user-api/utils"
+	"github.com/chats/go-user-api/internal/domain/service"
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/text/language"
 )
 
 var (
@@ -16,30 +25,137 @@ var (
 	ErrEmailAlreadyExists    = errors.New("email already exists")
 	ErrUsernameAlreadyExists = errors.New("username already exists")
 	ErrInvalidCredentials    = errors.New("invalid credentials")
+	ErrInvalidStatus         = errors.New("invalid status")
+	ErrInvalidRole           = errors.New("invalid role")
+	ErrInvalidChannel        = errors.New("invalid notification channel")
+	ErrInvalidTag            = errors.New("invalid tag")
+	ErrDuplicateRegistration = errors.New("registration already in progress")
+	ErrInvalidDisplayName    = errors.New("invalid display name")
+	ErrInvalidLocale         = errors.New("invalid locale")
+	ErrInvalidPhone          = errors.New("invalid phone number")
+	ErrInvalidBirthDate      = errors.New("invalid birth date")
+	ErrRegistrationClosed    = errors.New("organization is closed to self-registration")
+	ErrInvalidUsername       = errors.New("invalid username")
+	ErrStatusReasonRequired  = errors.New("a reason is required to block a user")
+	ErrInvalidReason         = errors.New("invalid reason")
+	ErrDeletionPending       = errors.New("user is pending deletion")
+	ErrNotPendingDeletion    = errors.New("user is not pending deletion")
+	ErrUserWaitlisted        = errors.New("user is waitlisted")
+	ErrOAuthEmailUnverified  = errors.New("email not verified by the provider")
+	ErrOAuthAccountConflict  = errors.New("an unverified account has the email")
+	ErrOAuthIdentityNotFound = errors.New("oauth identity not found")
+	ErrOAuthIdentityInUse    = errors.New("oauth account linked to another user")
+	ErrOAuthProviderLinked   = errors.New("an account at the provider is linked already")
+	ErrInvalidEmail          = errors.New("invalid email")
+	ErrImportTooLarge        = errors.New("too many users to import at once")
+
+	// ErrLastCredential is returned when removing the only way a user can sign in
+	ErrLastCredential = errors.New("last sign in method of the user")
+)
+
+const (
+	// registrationDedupScope is the dedup scope of registrations, keyed by email
+	registrationDedupScope = "register"
+
+	// registrationDedupWindow is how long a registration claims its email, absorbing double-submitted forms
+	registrationDedupWindow = 10 * time.Second
+
+	// purgeDedupScope is the dedup scope of purges, keyed by user ID, so instances never purge a user twice
+	purgeDedupScope = "purge"
+
+	// purgeBatchSize is the number of users due for purge loaded at once
+	purgeBatchSize = 100
+
+	// maxImportUsers is the number of users imported at once at most
+	maxImportUsers = 1000
+
+	// freeUsernameAttempts is the number of usernames tried for a user created from an external account, before
+	// giving up on a free one
+	freeUsernameAttempts = 5
 )
 
 // UserUseCase defines the use case for user operations
 type UserUseCase interface {
-	// Register creates a new user
-	Register(ctx context.Context, email, username, password, firstName, lastName string) (*entity.User, error)
+	// Register creates a new user, in the organization when orgID is set and the organization is open to
+	// self-registration, following its profile field rules. When existing accounts are concealed, registering with
+	// the email of an account emails its owner and returns ErrEmailAlreadyExists, to be answered like a success.
+	// A referral code, when set, records the new user as referred by the owner of the code. While registrations
+	// are waitlisted, the user is created waitlisted until approved.
+	Register(ctx context.Context, email, username, password string, profile entity.UserProfile, orgID *uuid.UUID, referralCode string) (*entity.User, error)
+
+	// Import creates users exported from a legacy system, keeping the password hashes computed there until each user
+	// signs in and the password is hashed again with the configured algorithm. Users failing to import are reported
+	// without stopping the import.
+	Import(ctx context.Context, actorID uuid.UUID, users []entity.UserImport) (*entity.UserImportReport, error)
+
+	// SignInWithOAuth returns the user signing in with an account at an external provider. An account not linked
+	// yet is linked to the user with its verified email, or to a new user without a password when no user has it.
+	// Users whose email is not verified are not linked, as the account may not be theirs.
+	SignInWithOAuth(ctx context.Context, profile *entity.OAuthProfile) (*entity.User, error)
+
+	// LinkOAuth links an account at an external provider to a signed in user. A user links a single account per
+	// provider and an account links a single user, linking the same account again returns its identity.
+	LinkOAuth(ctx context.Context, userID uuid.UUID, profile *entity.OAuthProfile) (*entity.OAuthIdentity, error)
+
+	// UnlinkOAuth unlinks the account of a user at a provider. ErrLastCredential is returned when it is the only
+	// way the user can sign in, without a password, a passkey or another linked account.
+	UnlinkOAuth(ctx context.Context, userID uuid.UUID, provider string) error
 
 	// Get a user by ID
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 
-	// Update user information
-	Update(ctx context.Context, id uuid.UUID, firstName, lastName string) (*entity.User, error)
+	// Update user information, following the profile field rules of the user's organization
+	Update(ctx context.Context, id uuid.UUID, profile entity.UserProfile) (*entity.User, error)
+
+	// Delete a user, the reason is recorded in the audit trail. The user is pending deletion until the restoration
+	// window has elapsed, the returned purge time, or deleted immediately without a window, returning nil.
+	Delete(ctx context.Context, actorID, id uuid.UUID, reason entity.ActionReason) (*time.Time, error)
+
+	// ListPendingDeletions lists the users pending deletion with pagination
+	ListPendingDeletions(ctx context.Context, page, limit int) ([]*entity.User, int64, error)
 
-	// Delete a user
-	Delete(ctx context.Context, id uuid.UUID) error
+	// CancelDeletion restores a user pending deletion to their previous status, performed by an administrator
+	CancelDeletion(ctx context.Context, actorID, id uuid.UUID) (*entity.User, error)
+
+	// PurgeDeletions deletes the users whose restoration window has elapsed
+	PurgeDeletions(ctx context.Context) error
+
+	// RunPurge purges the users due for purge at every interval until the context is cancelled
+	RunPurge(ctx context.Context, interval time.Duration)
 
 	// List users with pagination
-	List(ctx context.Context, page, limit int) ([]*entity.User, int64, error)
+	List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error)
 
-	// Change user password
+	// Change user password, lifting the requirement to change it
 	ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword string) error
 
-	// Update user status
-	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	// RequirePasswordChange makes the next sign in of a user change their password before tokens are issued, or
+	// lifts the requirement, performed by an administrator
+	RequirePasswordChange(ctx context.Context, actorID, id uuid.UUID, required bool) error
+
+	// Update user status, performed by an administrator. A reason code or note is required to block the user.
+	UpdateStatus(ctx context.Context, actorID, id uuid.UUID, status string, reason entity.ActionReason) error
+
+	// StatusHistory lists the status changes of a user, newest first
+	StatusHistory(ctx context.Context, id uuid.UUID) ([]*entity.StatusChange, error)
+
+	// Update user role, performed by an administrator, the reason is recorded in the audit trail
+	UpdateRole(ctx context.Context, actorID, id uuid.UUID, role string, reason entity.ActionReason) error
+
+	// Update the channels a user prefers to be notified on
+	UpdateNotificationChannels(ctx context.Context, id uuid.UUID, channels []string) error
+
+	// Opt a user in or out of the lifecycle emails
+	UpdateLifecycleEmails(ctx context.Context, id uuid.UUID, enabled bool) error
+
+	// Update the verification status of a user, performed by an administrator. Nil values are left unchanged.
+	UpdateVerification(ctx context.Context, actorID, id uuid.UUID, emailVerified, phoneVerified *bool) error
+
+	// Add tags to a user, performed by an administrator. Returns the user's tags.
+	AddTags(ctx context.Context, actorID, id uuid.UUID, tags []string) ([]string, error)
+
+	// Remove tags from a user, performed by an administrator. Returns the user's tags.
+	RemoveTags(ctx context.Context, actorID, id uuid.UUID, tags []string) ([]string, error)
 
 	// Authenticate user and return user if successful
 	Authenticate(ctx context.Context, email, password string) (*entity.User, error)
@@ -47,21 +163,176 @@ type UserUseCase interface {
 
 // userUseCase implements UserUseCase interface
 type userUseCase struct {
-	userRepo repository.UserRepository
+	userRepo            repository.UserRepository
+	auditRepo           repository.AuditRepository
+	notificationUseCase NotificationUseCase
+	policyService       service.PolicyService
+	passwordService     service.PasswordService
+	passwordHasher      service.PasswordHasher
+	roleUseCase         RoleUseCase
+	dedupRepo           repository.DedupRepository
+	eventService        service.EventService
+	orgRepo             repository.OrganizationRepository
+	organizationUseCase OrganizationUseCase
+	statusHistoryRepo   repository.StatusHistoryRepository
+	tokenRepo           repository.TokenRepository
+	referralRepo        repository.ReferralRepository
+	passkeyRepo         repository.PasskeyRepository
+	oauthIdentityRepo   repository.OAuthIdentityRepository
+	adminNoteRepo       repository.AdminNoteRepository
+	teamMemberRepo      repository.TeamMemberRepository
+	loginCountryRepo    repository.LoginCountryRepository
+	statusCacheTTL      time.Duration
+	concealExisting     bool
+	waitlist            bool
+	restorationWindow   time.Duration
 }
 
 // NewUserUseCase creates a new UserUseCase
-func NewUserUseCase(userRepo repository.UserRepository) UserUseCase {
+func NewUserUseCase(
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	notificationUseCase NotificationUseCase,
+	policyService service.PolicyService,
+	passwordService service.PasswordService,
+	passwordHasher service.PasswordHasher,
+	roleUseCase RoleUseCase,
+	dedupRepo repository.DedupRepository,
+	eventService service.EventService,
+	orgRepo repository.OrganizationRepository,
+	organizationUseCase OrganizationUseCase,
+	statusHistoryRepo repository.StatusHistoryRepository,
+	tokenRepo repository.TokenRepository,
+	referralRepo repository.ReferralRepository,
+	passkeyRepo repository.PasskeyRepository,
+	oauthIdentityRepo repository.OAuthIdentityRepository,
+	adminNoteRepo repository.AdminNoteRepository,
+	teamMemberRepo repository.TeamMemberRepository,
+	loginCountryRepo repository.LoginCountryRepository,
+	securityCfg config.SecurityConfig,
+	registrationCfg config.RegistrationConfig,
+	deletionCfg config.DeletionConfig,
+) UserUseCase {
 	return &userUseCase{
-		userRepo: userRepo,
+		userRepo:            userRepo,
+		auditRepo:           auditRepo,
+		notificationUseCase: notificationUseCase,
+		policyService:       policyService,
+		passwordService:     passwordService,
+		passwordHasher:      passwordHasher,
+		roleUseCase:         roleUseCase,
+		dedupRepo:           dedupRepo,
+		eventService:        eventService,
+		orgRepo:             orgRepo,
+		organizationUseCase: organizationUseCase,
+		statusHistoryRepo:   statusHistoryRepo,
+		tokenRepo:           tokenRepo,
+		referralRepo:        referralRepo,
+		passkeyRepo:         passkeyRepo,
+		oauthIdentityRepo:   oauthIdentityRepo,
+		adminNoteRepo:       adminNoteRepo,
+		teamMemberRepo:      teamMemberRepo,
+		loginCountryRepo:    loginCountryRepo,
+		// Outlive every token issued before the status changed
+		statusCacheTTL:    time.Duration(max(securityCfg.RefreshTokenExpirationDays, securityCfg.RememberMeRefreshTokenExpirationDays)) * 24 * time.Hour,
+		concealExisting:   registrationCfg.ConcealExistingAccounts,
+		waitlist:          registrationCfg.Waitlist,
+		restorationWindow: deletionCfg.RestorationWindow,
 	}
 }
 
 // Register creates a new user
-func (uc *userUseCase) Register(ctx context.Context, email, username, password, firstName, lastName string) (*entity.User, error) {
+func (uc *userUseCase) Register(ctx context.Context, email, username, password string, profile entity.UserProfile, orgID *uuid.UUID, referralCode string) (*entity.User, error) {
+	email = entity.NormalizeEmail(email)
+
+	// Claim the email first, so a double-submitted form cannot race the uniqueness checks
+	dedupKey := email
+	claimed, err := uc.dedupRepo.Claim(ctx, registrationDedupScope, dedupKey, registrationDedupWindow)
+	if err != nil {
+		// Fail open, the unique indexes still reject duplicate accounts
+		log.Warn().Err(err).Str("email", email).Msg("Failed to deduplicate registration")
+	} else if !claimed {
+		return nil, ErrDuplicateRegistration
+	}
+
+	user, err := uc.register(ctx, email, username, password, profile, orgID, referralCode)
+	// A concealed existing account keeps the claim like a success, so a resubmission is answered alike
+	if err != nil && claimed && !(uc.concealExisting && errors.Is(err, ErrEmailAlreadyExists)) {
+		// Let the user correct the form and submit it again right away
+		if err := uc.dedupRepo.Release(ctx, registrationDedupScope, dedupKey); err != nil {
+			log.Warn().Err(err).Str("email", email).Msg("Failed to release registration claim")
+		}
+	}
+	return user, err
+}
+
+// register checks the email and username are free and creates the user
+func (uc *userUseCase) register(ctx context.Context, email, username, password string, profile entity.UserProfile, orgID *uuid.UUID, referralCode string) (*entity.User, error) {
+	if !entity.IsValidUsername(username) {
+		return nil, ErrInvalidUsername
+	}
+
+	// Create user, validating the whole form before looking up existing accounts, so an invalid form is rejected
+	// alike whether or not the email has an account
+	user := entity.NewUser(email, username, "", profile.FirstName, profile.LastName)
+	if err := applyProfile(user, profile); err != nil {
+		return nil, err
+	}
+	if uc.waitlist {
+		user.Status = entity.UserStatusWaitlisted
+	}
+
+	// Registering into an organization follows its profile field rules and member limit. Unknown organizations
+	// are reported as closed, so registrations do not reveal which organizations exist.
+	var joined *entity.Organization
+	if orgID != nil {
+		org, err := uc.orgRepo.GetByID(ctx, *orgID)
+		if err != nil {
+			return nil, err
+		}
+		if org == nil || !org.SelfRegistration {
+			return nil, ErrRegistrationClosed
+		}
+		if err := org.ProfileFields.Check(user, profile); err != nil {
+			return nil, err
+		}
+		if err := uc.organizationUseCase.CheckMemberLimit(ctx, org); err != nil {
+			return nil, err
+		}
+		user.OrgID = &org.ID
+		joined = org
+	}
+
+	// Only active users refer new users, the codes of blocked or deleted users are rejected like unknown codes
+	var referral *entity.ReferralCode
+	if referralCode = entity.NormalizeReferralCode(referralCode); referralCode != "" {
+		code, err := uc.referralRepo.GetCode(ctx, referralCode)
+		if err != nil {
+			return nil, err
+		}
+		if code == nil {
+			return nil, ErrInvalidReferralCode
+		}
+		referrer, err := uc.userRepo.GetByID(ctx, code.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if referrer == nil || referrer.Status != entity.UserStatusActive {
+			return nil, ErrInvalidReferralCode
+		}
+		referral = code
+	}
+
+	if err := uc.passwordService.Check(ctx, password, user.Email, username, user.FirstName, user.LastName); err != nil {
+		return nil, err
+	}
+
 	// Check if email already exists
-	existingUser, err := uc.userRepo.GetByEmail(ctx, email)
+	existingUser, err := uc.userRepo.GetByEmail(ctx, user.Email)
 	if err == nil && existingUser != nil {
+		if uc.concealExisting {
+			return nil, uc.concealExistingAccount(ctx, existingUser, password)
+		}
 		return nil, ErrEmailAlreadyExists
 	}
 
@@ -72,22 +343,381 @@ func (uc *userUseCase) Register(ctx context.Context, email, username, password,
 	}
 
 	// Hash password
-	hashedPassword, err := utils.HashPassword(password)
+	hashedPassword, err := uc.passwordHasher.Hash(password)
 	if err != nil {
 		return nil, err
 	}
-
-	// Create user
-	user := entity.NewUser(email, username, hashedPassword, firstName, lastName)
+	user.Password = hashedPassword
 
 	// Save to repository
 	if err := uc.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, user.ID, "", user.Status, entity.ActionReason{}))
+	publishEvent(ctx, uc.eventService, entity.EventUserCreated, userCreatedEvent(user))
+	if joined != nil {
+		uc.organizationUseCase.MemberAdded(ctx, joined)
+	}
+	if referral != nil {
+		uc.recordReferral(ctx, referral, user)
+	}
+
+	// Owners of existing accounts are emailed, so new users are too and both outcomes look alike
+	if uc.concealExisting {
+		runInBackground(ctx, "registration_email_verification", func(ctx context.Context) error {
+			return sendEmailVerification(ctx, uc.tokenRepo, uc.notificationUseCase, user)
+		})
+	}
+	return user, nil
+}
+
+// Import creates users exported from a legacy system with their password hashes
+func (uc *userUseCase) Import(ctx context.Context, actorID uuid.UUID, users []entity.UserImport) (*entity.UserImportReport, error) {
+	if len(users) > maxImportUsers {
+		return nil, ErrImportTooLarge
+	}
+
+	report := &entity.UserImportReport{Failed: []entity.UserImportFailure{}}
+	for i, imported := range users {
+		if err := uc.importUser(ctx, actorID, imported); err != nil {
+			report.Failed = append(report.Failed, entity.UserImportFailure{
+				Index: i,
+				Email: imported.Email,
+				Error: importFailure(err),
+			})
+			continue
+		}
+		report.Imported++
+	}
+
+	log.Info().Int("imported", report.Imported).Int("failed", len(report.Failed)).Str("actor_id", actorID.String()).Msg("Users imported")
+	return report, nil
+}
+
+// importUser checks a user exported from a legacy system and creates it with its password hash
+func (uc *userUseCase) importUser(ctx context.Context, actorID uuid.UUID, imported entity.UserImport) error {
+	email := entity.NormalizeEmail(imported.Email)
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return ErrInvalidEmail
+	}
+	if imported.Username == "" || !entity.IsValidUsername(imported.Username) {
+		return ErrInvalidUsername
+	}
+
+	hashedPassword, err := uc.passwordHasher.Import(imported.PasswordScheme, imported.PasswordHash)
+	if err != nil {
+		return err
+	}
+
+	user := entity.NewUser(email, imported.Username, hashedPassword, imported.FirstName, imported.LastName)
+	user.EmailVerified = imported.EmailVerified
+	if imported.Role != "" {
+		if _, err := uc.roleUseCase.GetRole(ctx, imported.Role); err != nil {
+			if errors.Is(err, ErrRoleNotFound) {
+				return ErrInvalidRole
+			}
+			return err
+		}
+		user.Role = imported.Role
+	}
+
+	var org *entity.Organization
+	if imported.OrgID != nil {
+		if org, err = uc.orgRepo.GetByID(ctx, *imported.OrgID); err != nil {
+			return err
+		}
+		if org == nil {
+			return ErrOrganizationNotFound
+		}
+		if err := uc.organizationUseCase.CheckMemberLimit(ctx, org); err != nil {
+			return err
+		}
+		user.OrgID = &org.ID
+	}
+
+	if existingUser, err := uc.userRepo.GetByEmail(ctx, user.Email); err == nil && existingUser != nil {
+		return ErrEmailAlreadyExists
+	}
+	if existingUser, err := uc.userRepo.GetByUsername(ctx, user.Username); err == nil && existingUser != nil {
+		return ErrUsernameAlreadyExists
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return err
+	}
+
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, actorID, "", user.Status, entity.ActionReason{}))
+	uc.recordAdminAction(ctx, entity.AuditActionUserImported, actorID, user, map[string]string{
+		"password_scheme": strings.ToLower(imported.PasswordScheme),
+	})
+	publishEvent(ctx, uc.eventService, entity.EventUserCreated, userCreatedEvent(user))
+	if org != nil {
+		uc.organizationUseCase.MemberAdded(ctx, org)
+	}
+	return nil
+}
+
+// importFailure returns the reason reported for a user failing to import, the unexpected errors logged instead
+func importFailure(err error) string {
+	for _, expected := range []error{
+		ErrInvalidEmail, ErrInvalidUsername, ErrInvalidRole, ErrOrganizationNotFound, ErrMemberLimitReached,
+		ErrEmailAlreadyExists, ErrUsernameAlreadyExists,
+		service.ErrUnsupportedPasswordScheme, service.ErrInvalidPasswordHash,
+	} {
+		if errors.Is(err, expected) {
+			return expected.Error()
+		}
+	}
+
+	log.Error().Err(err).Msg("Failed to import user")
+	return "internal error"
+}
+
+// recordReferral records a new user as referred by the owner of the code. The user is registered already, so
+// failures are only logged.
+func (uc *userUseCase) recordReferral(ctx context.Context, code *entity.ReferralCode, user *entity.User) {
+	referral := entity.NewReferral(code, user.ID)
+	if err := uc.referralRepo.Create(ctx, referral); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Str("referrer_id", referral.ReferrerID.String()).Msg("Failed to record referral")
+		return
+	}
+
+	publishEvent(ctx, uc.eventService, entity.EventUserReferred, &entity.UserReferredEvent{
+		ReferrerID: referral.ReferrerID,
+		RefereeID:  referral.RefereeID,
+		Code:       referral.Code,
+		ReferredAt: referral.CreatedAt,
+	})
+}
+
+// concealExistingAccount answers a registration with the email of an existing account like a successful one: the
+// password is hashed all the same and the owner is emailed in the background. It returns ErrEmailAlreadyExists.
+func (uc *userUseCase) concealExistingAccount(ctx context.Context, owner *entity.User, password string) error {
+	if _, err := uc.passwordHasher.Hash(password); err != nil {
+		return err
+	}
+
+	runInBackground(ctx, "registration_account_exists", func(ctx context.Context) error {
+		return uc.notificationUseCase.SendAccountExists(ctx, owner)
+	})
+	return ErrEmailAlreadyExists
+}
+
+// SignInWithOAuth returns the user signing in with an account at an external provider, linking the account
+// or creating the user on first sign in
+func (uc *userUseCase) SignInWithOAuth(ctx context.Context, profile *entity.OAuthProfile) (*entity.User, error) {
+	identity, err := uc.oauthIdentityRepo.Get(ctx, profile.Provider, profile.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		user, err := uc.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrInvalidCredentials
+		}
+		return user, nil
+	}
+
+	// Only an email the provider verified proves the account and the local user belong to the same person
+	if !profile.EmailVerified || profile.Email == "" {
+		return nil, ErrOAuthEmailUnverified
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, entity.NormalizeEmail(profile.Email))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return uc.registerWithOAuth(ctx, profile)
+	}
+
+	// Whoever registered an unverified email may not own it, linking would let them in alongside the owner
+	if !user.EmailVerified {
+		return nil, ErrOAuthAccountConflict
+	}
+	if err := uc.oauthIdentityRepo.Create(ctx, entity.NewOAuthIdentity(profile, user.ID)); err != nil {
+		return nil, err
+	}
+
+	uc.recordAdminAction(ctx, entity.AuditActionOAuthLinked, user.ID, user, map[string]string{
+		"provider":       profile.Provider,
+		"provider_email": entity.NormalizeEmail(profile.Email),
+	})
+	return user, nil
+}
+
+// LinkOAuth links an account at an external provider to a signed in user
+func (uc *userUseCase) LinkOAuth(ctx context.Context, userID uuid.UUID, profile *entity.OAuthProfile) (*entity.OAuthIdentity, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	existing, err := uc.oauthIdentityRepo.Get(ctx, profile.Provider, profile.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.UserID != user.ID {
+			return nil, ErrOAuthIdentityInUse
+		}
+		return existing, nil
+	}
+
+	identities, err := uc.oauthIdentityRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		if identity.Provider == profile.Provider {
+			return nil, ErrOAuthProviderLinked
+		}
+	}
+
+	identity := entity.NewOAuthIdentity(profile, user.ID)
+	if err := uc.oauthIdentityRepo.Create(ctx, identity); err != nil {
+		if errors.Is(err, repository.ErrOAuthIdentityExists) {
+			return nil, ErrOAuthIdentityInUse // Linked concurrently
+		}
+		return nil, err
+	}
+
+	uc.recordAdminAction(ctx, entity.AuditActionOAuthLinked, user.ID, user, map[string]string{
+		"provider":       identity.Provider,
+		"provider_email": identity.Email,
+	})
+	return identity, nil
+}
+
+// UnlinkOAuth unlinks the account of a user at a provider
+func (uc *userUseCase) UnlinkOAuth(ctx context.Context, userID uuid.UUID, provider string) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	identities, err := uc.oauthIdentityRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	var identity *entity.OAuthIdentity
+	for _, linked := range identities {
+		if linked.Provider == provider {
+			identity = linked
+			break
+		}
+	}
+	if identity == nil {
+		return ErrOAuthIdentityNotFound
+	}
+
+	credentials, err := countCredentials(ctx, uc.passkeyRepo, uc.oauthIdentityRepo, user)
+	if err != nil {
+		return err
+	}
+	if credentials <= 1 {
+		return ErrLastCredential
+	}
+
+	if err := uc.oauthIdentityRepo.Delete(ctx, identity); err != nil {
+		return err
+	}
+
+	uc.recordAdminAction(ctx, entity.AuditActionOAuthUnlinked, user.ID, user, map[string]string{
+		"provider":       identity.Provider,
+		"provider_email": identity.Email,
+	})
+	return nil
+}
+
+// countCredentials returns the number of ways a user can sign in: their password, passkeys and linked accounts
+func countCredentials(ctx context.Context, passkeyRepo repository.PasskeyRepository, oauthIdentityRepo repository.OAuthIdentityRepository, user *entity.User) (int, error) {
+	count := 0
+	if user.Password != "" {
+		count++
+	}
+
+	passkeys, err := passkeyRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return 0, err
+	}
+	identities, err := oauthIdentityRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return 0, err
+	}
+	return count + len(passkeys) + len(identities), nil
+}
+
+// registerWithOAuth creates a user without a password for an account at an external provider and links them
+func (uc *userUseCase) registerWithOAuth(ctx context.Context, profile *entity.OAuthProfile) (*entity.User, error) {
+	username, err := freeUsername(ctx, uc.userRepo, profile.Username, profile.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	user := entity.NewUser(profile.Email, username, "", profile.FirstName, profile.LastName)
+	user.EmailVerified = true
+	if uc.waitlist {
+		user.Status = entity.UserStatusWaitlisted
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := uc.oauthIdentityRepo.Create(ctx, entity.NewOAuthIdentity(profile, user.ID)); err != nil {
+		return nil, err
+	}
+
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, user.ID, "", user.Status, entity.ActionReason{}))
+	publishEvent(ctx, uc.eventService, entity.EventUserCreated, userCreatedEvent(user))
 	return user, nil
 }
 
+// freeUsername returns a free username for a user created from an external account, such as an account at a
+// provider or a directory entry, based on their login there or the local part of their email, with a numeric
+// suffix when taken
+func freeUsername(ctx context.Context, userRepo repository.UserRepository, login, email string) (string, error) {
+	base := login
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+	base = strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("._-", r)) {
+			return r
+		}
+		return -1
+	}, base)
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	username := base
+	for range freeUsernameAttempts {
+		existing, err := userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return username, nil
+		}
+		username = fmt.Sprintf("%s%04d", base, rand.IntN(10000))
+	}
+	return "", ErrUsernameAlreadyExists
+}
+
 // GetByID retrieves a user by ID
 func (uc *userUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	user, err := uc.userRepo.GetByID(ctx, id)
@@ -101,7 +731,7 @@ func (uc *userUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.User,
 }
 
 // Update updates a user's information
-func (uc *userUseCase) Update(ctx context.Context, id uuid.UUID, firstName, lastName string) (*entity.User, error) {
+func (uc *userUseCase) Update(ctx context.Context, id uuid.UUID, profile entity.UserProfile) (*entity.User, error) {
 	// Get user
 	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
@@ -111,9 +741,18 @@ func (uc *userUseCase) Update(ctx context.Context, id uuid.UUID, firstName, last
 		return nil, ErrUserNotFound
 	}
 
-	// Update fields
-	user.FirstName = firstName
-	user.LastName = lastName
+	// Check the account may update its profile
+	if err := uc.policyService.Check(user, entity.PolicyActionUpdateProfile); err != nil {
+		return nil, err
+	}
+
+	// Update fields, following the profile field rules of the user's organization
+	if err := applyProfile(user, profile); err != nil {
+		return nil, err
+	}
+	if err := uc.checkProfileRules(ctx, user, profile); err != nil {
+		return nil, err
+	}
 	user.UpdatedAt = time.Now()
 
 	// Save changes
@@ -121,26 +760,282 @@ func (uc *userUseCase) Update(ctx context.Context, id uuid.UUID, firstName, last
 		return nil, err
 	}
 
+	publishEvent(ctx, uc.eventService, entity.EventUserUpdated, &entity.UserUpdatedEvent{
+		UserID:    user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		UpdatedAt: user.UpdatedAt,
+	})
 	return user, nil
 }
 
-// Delete deletes a user
-func (uc *userUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+// applyProfile validates and normalizes the fields of a submitted profile and sets them on a user
+func applyProfile(user *entity.User, profile entity.UserProfile) error {
+	user.FirstName = entity.NormalizeName(profile.FirstName)
+	user.LastName = entity.NormalizeName(profile.LastName)
+	if profile.DisplayName != nil {
+		name := entity.NormalizeName(*profile.DisplayName)
+		if !entity.IsValidDisplayName(name) {
+			return ErrInvalidDisplayName
+		}
+		user.DisplayName = name
+	}
+	if profile.Locale != nil {
+		user.Locale = ""
+		if *profile.Locale != "" {
+			// Store the canonical form of the tag, e.g. ja-JP for ja_jp
+			tag, err := language.Parse(*profile.Locale)
+			if err != nil {
+				return ErrInvalidLocale
+			}
+			user.Locale = tag.String()
+		}
+	}
+	if profile.Phone != nil {
+		user.Phone = ""
+		if *profile.Phone != "" {
+			phone, ok := entity.NormalizePhone(*profile.Phone)
+			if !ok {
+				return ErrInvalidPhone
+			}
+			user.Phone = phone
+		}
+	}
+	if profile.BirthDate != nil {
+		user.BirthDate = ""
+		if *profile.BirthDate != "" {
+			birthDate, err := time.Parse(time.DateOnly, *profile.BirthDate)
+			if err != nil || birthDate.After(time.Now()) {
+				return ErrInvalidBirthDate
+			}
+			user.BirthDate = birthDate.Format(time.DateOnly)
+		}
+	}
+	return nil
+}
+
+// checkProfileRules applies the profile field rules of the user's organization to a submitted profile
+func (uc *userUseCase) checkProfileRules(ctx context.Context, user *entity.User, profile entity.UserProfile) error {
+	if user.OrgID == nil {
+		return nil
+	}
+
+	org, err := uc.orgRepo.GetByID(ctx, *user.OrgID)
+	if err != nil {
+		return err
+	}
+	if org == nil {
+		return nil
+	}
+	return org.ProfileFields.Check(user, profile)
+}
+
+// Delete deletes a user, deferring the deletion by the restoration window
+func (uc *userUseCase) Delete(ctx context.Context, actorID, id uuid.UUID, reason entity.ActionReason) (*time.Time, error) {
+	reason, ok := reason.Normalize()
+	if !ok {
+		return nil, ErrInvalidReason
+	}
+
 	// Check if user exists
 	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Status == entity.UserStatusPendingDeletion {
+		return nil, ErrDeletionPending
+	}
+
+	details := map[string]string{}
+	reason.AddTo(details)
+
+	if uc.restorationWindow <= 0 {
+		return nil, uc.purge(ctx, entity.AuditActionUserDeleted, actorID, user, details)
+	}
+
+	previousStatus := user.Status
+	user.ScheduleDeletion(time.Now(), uc.restorationWindow)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, actorID, previousStatus, user.Status, reason))
+
+	details["purge_at"] = user.PurgeAt.Format(time.RFC3339)
+	uc.recordAdminAction(ctx, entity.AuditActionUserDeleted, actorID, user, details)
+	publishEvent(ctx, uc.eventService, entity.EventUserStatusChanged, &entity.UserStatusChangedEvent{
+		UserID:         user.ID,
+		ActorID:        actorID,
+		PreviousStatus: previousStatus,
+		Status:         user.Status,
+	})
+
+	return user.PurgeAt, uc.revokeAccess(ctx, id, user.Status)
+}
+
+// purge deletes a user for good and records the deletion under the given audit action
+func (uc *userUseCase) purge(ctx context.Context, action string, actorID uuid.UUID, user *entity.User, details map[string]string) error {
+	if err := uc.userRepo.Delete(ctx, user.ID); err != nil {
 		return err
 	}
+
+	uc.recordAdminAction(ctx, action, actorID, user, details)
+
+	// Leftover passkeys cannot sign anyone in once the user is gone, so failures are only logged
+	if err := uc.passkeyRepo.DeleteByUser(ctx, user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to delete the passkeys of a deleted user")
+	}
+	if err := uc.oauthIdentityRepo.DeleteByUser(ctx, user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to delete the OAuth identities of a deleted user")
+	}
+	if err := uc.roleUseCase.DeleteAssignedRoles(ctx, user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to delete the assigned roles of a deleted user")
+	}
+	if err := uc.teamMemberRepo.DeleteByUser(ctx, user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to delete the team memberships of a deleted user")
+	}
+	// Admin notes are personal data of the user, erased along with the account
+	if err := uc.adminNoteRepo.DeleteByUser(ctx, user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to delete the admin notes of a deleted user")
+	}
+	if err := uc.loginCountryRepo.DeleteByUser(ctx, user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to delete the login countries of a deleted user")
+	}
+
+	publishEvent(ctx, uc.eventService, entity.EventUserDeleted, &entity.UserDeletedEvent{
+		UserID:    user.ID,
+		DeletedAt: time.Now(),
+	})
+
+	// The deletion cannot be retried, and refreshing the tokens of a deleted user fails anyway
+	if err := uc.revokeAccess(ctx, user.ID, entity.UserStatusDeleted); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to revoke the tokens of a deleted user")
+	}
+	return nil
+}
+
+// ListPendingDeletions lists the users pending deletion with pagination
+func (uc *userUseCase) ListPendingDeletions(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
+	return uc.userRepo.List(ctx, page, limit, entity.UserListOptions{
+		Status: entity.UserStatusPendingDeletion,
+	})
+}
+
+// CancelDeletion restores a user pending deletion to their previous status
+func (uc *userUseCase) CancelDeletion(ctx context.Context, actorID, id uuid.UUID) (*entity.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 	if user == nil {
-		return ErrUserNotFound
+		return nil, ErrUserNotFound
+	}
+	if user.Status != entity.UserStatusPendingDeletion {
+		return nil, ErrNotPendingDeletion
 	}
 
-	return uc.userRepo.Delete(ctx, id)
+	previousStatus := user.Status
+	user.CancelDeletion(time.Now())
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, actorID, previousStatus, user.Status, entity.ActionReason{}))
+	uc.recordAdminAction(ctx, entity.AuditActionUserDeletionCancelled, actorID, user, map[string]string{
+		"status": user.Status,
+	})
+	publishEvent(ctx, uc.eventService, entity.EventUserStatusChanged, &entity.UserStatusChangedEvent{
+		UserID:         user.ID,
+		ActorID:        actorID,
+		PreviousStatus: previousStatus,
+		Status:         user.Status,
+	})
+
+	// Replace the cached status, which would otherwise reject the tokens issued after the restoration
+	if err := uc.revokeAccess(ctx, id, user.Status); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// PurgeDeletions deletes the users whose restoration window has elapsed, a batch at a time
+func (uc *userUseCase) PurgeDeletions(ctx context.Context) error {
+	for {
+		users, err := uc.userRepo.ListDueForPurge(ctx, time.Now(), purgeBatchSize)
+		if err != nil {
+			return err
+		}
+
+		purged := 0
+		for _, user := range users {
+			// Claim the purge, any other instance listing the same user skips it. Fail open, a user deleted twice
+			// only records the purge twice.
+			claimed, err := uc.dedupRepo.Claim(ctx, purgeDedupScope, user.ID.String(), time.Minute)
+			if err != nil {
+				log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to claim user purge")
+			} else if !claimed {
+				continue
+			}
+
+			if err := uc.purge(ctx, entity.AuditActionUserPurged, uuid.Nil, user, map[string]string{}); err != nil {
+				return err
+			}
+			purged++
+		}
+
+		if purged > 0 {
+			log.Info().Int("users", purged).Msg("Purged users pending deletion")
+		}
+		// Stop on the last batch, or when the batch is being purged by other instances
+		if len(users) < purgeBatchSize || purged == 0 {
+			return nil
+		}
+	}
+}
+
+// RunPurge purges the users due for purge at every interval until the context is cancelled
+func (uc *userUseCase) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := uc.PurgeDeletions(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to purge users pending deletion")
+		}
+	}
 }
 
 // List lists users with pagination
-func (uc *userUseCase) List(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
-	return uc.userRepo.List(ctx, page, limit)
+func (uc *userUseCase) List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error) {
+	// Validate status filter
+	if opts.Status != "" && !entity.IsValidUserStatus(opts.Status) {
+		return nil, 0, ErrInvalidStatus
+	}
+
+	// Validate tag filter
+	if opts.Tag != "" {
+		tag, ok := entity.NormalizeUserTag(opts.Tag)
+		if !ok {
+			return nil, 0, ErrInvalidTag
+		}
+		opts.Tag = tag
+	}
+
+	// Hide the accounts that are not verified enough to be listed
+	requirement := uc.policyService.Requirement(entity.PolicyActionListed)
+	opts.EmailVerifiedOnly = requirement.Email
+	opts.PhoneVerifiedOnly = requirement.Phone
+
+	return uc.userRepo.List(ctx, page, limit, opts)
 }
 
 // ChangePassword changes a user's password
@@ -155,21 +1050,66 @@ func (uc *userUseCase) ChangePassword(ctx context.Context, id uuid.UUID, oldPass
 	}
 
 	// Verify old password
-	if !utils.CheckPasswordHash(oldPassword, user.Password) {
+	if !uc.passwordHasher.Verify(oldPassword, user.Password) {
 		return ErrInvalidCredentials
 	}
 
+	if err := uc.passwordService.Check(ctx, newPassword, user.Email, user.Username, user.FirstName, user.LastName); err != nil {
+		return err
+	}
+
 	// Hash new password
-	hashedPassword, err := utils.HashPassword(newPassword)
+	hashedPassword, err := uc.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.ChangePassword(ctx, id, hashedPassword); err != nil {
+		return err
+	}
+
+	// The password is changed, as an administrator required. Update caches the user as passed, so it must carry
+	// the new password.
+	if user.MustChangePassword {
+		user.Password = hashedPassword
+		user.MustChangePassword = false
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RequirePasswordChange makes the next sign in of a user change their password, or lifts the requirement
+func (uc *userUseCase) RequirePasswordChange(ctx context.Context, actorID, id uuid.UUID, required bool) error {
+	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.MustChangePassword == required {
+		return nil
+	}
+
+	user.MustChangePassword = required
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	uc.recordAdminAction(ctx, entity.AuditActionPasswordChangeRequired, actorID, user, map[string]string{
+		"required": strconv.FormatBool(required),
+	})
 
-	return uc.userRepo.ChangePassword(ctx, id, hashedPassword)
+	return nil
 }
 
 // UpdateStatus updates a user's status
-func (uc *userUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+func (uc *userUseCase) UpdateStatus(ctx context.Context, actorID, id uuid.UUID, status string, reason entity.ActionReason) error {
 	// Check if user exists
 	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
@@ -179,20 +1119,306 @@ func (uc *userUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status st
 		return ErrUserNotFound
 	}
 
-	// Validate status
-	if status != entity.UserStatusActive &&
-		status != entity.UserStatusInactive &&
-		status != entity.UserStatusBlocked {
-		return errors.New("invalid status")
+	// Users pending deletion are restored by cancelling the deletion, which clears the purge time
+	if user.Status == entity.UserStatusPendingDeletion {
+		return ErrDeletionPending
+	}
+
+	// Validate status, users only become invited through an invitation, waitlisted through a registration and
+	// pending deletion through a deletion
+	if !entity.IsValidUserStatus(status) || status == entity.UserStatusInvited || status == entity.UserStatusWaitlisted || status == entity.UserStatusPendingDeletion {
+		return ErrInvalidStatus
+	}
+
+	// Waitlisted users are activated by an approval, which emails them, they can still be deactivated or blocked
+	if user.Status == entity.UserStatusWaitlisted && status == entity.UserStatusActive {
+		return ErrUserWaitlisted
+	}
+
+	reason, ok := reason.Normalize()
+	if !ok {
+		return ErrInvalidReason
+	}
+	if status == entity.UserStatusBlocked && reason.IsZero() {
+		return ErrStatusReasonRequired
 	}
 
-	return uc.userRepo.UpdateStatus(ctx, id, status)
+	if err := uc.userRepo.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+
+	previousStatus := user.Status
+	user.Status = status
+	if previousStatus != status {
+		recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, actorID, previousStatus, status, reason))
+	}
+
+	details := map[string]string{
+		"status": status,
+	}
+	reason.AddTo(details)
+	uc.recordAdminAction(ctx, entity.AuditActionUserStatusChanged, actorID, user, details)
+	publishEvent(ctx, uc.eventService, entity.EventUserStatusChanged, &entity.UserStatusChangedEvent{
+		UserID:         user.ID,
+		ActorID:        actorID,
+		PreviousStatus: previousStatus,
+		Status:         status,
+	})
+
+	return uc.revokeAccess(ctx, id, status)
+}
+
+// revokeAccess caches the status of a user and, unless they are active, signs them out of every session.
+// The cached status rejects the access tokens already issued to a user who is no longer active.
+func (uc *userUseCase) revokeAccess(ctx context.Context, userID uuid.UUID, status string) error {
+	if err := uc.tokenRepo.StoreUserStatus(ctx, userID, status, uc.statusCacheTTL); err != nil {
+		return err
+	}
+	if status == entity.UserStatusActive {
+		return nil
+	}
+	return uc.tokenRepo.DeleteUserTokens(ctx, userID)
+}
+
+// StatusHistory lists the status changes of a user
+func (uc *userUseCase) StatusHistory(ctx context.Context, id uuid.UUID) ([]*entity.StatusChange, error) {
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return uc.statusHistoryRepo.ListByUser(ctx, id)
+}
+
+// UpdateRole updates a user's role and signs them out of every session, their tokens carrying the role
+func (uc *userUseCase) UpdateRole(ctx context.Context, actorID, id uuid.UUID, role string, reason entity.ActionReason) error {
+	reason, ok := reason.Normalize()
+	if !ok {
+		return ErrInvalidReason
+	}
+
+	// Validate role against the built-in and custom roles
+	if _, err := uc.roleUseCase.GetRole(ctx, role); err != nil {
+		if errors.Is(err, ErrRoleNotFound) {
+			return ErrInvalidRole
+		}
+		return err
+	}
+
+	// Get user
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	previousRole := user.Role
+	user.Role = role
+	user.UpdatedAt = time.Now()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	details := map[string]string{
+		"role":          role,
+		"previous_role": previousRole,
+	}
+	reason.AddTo(details)
+	uc.recordAdminAction(ctx, entity.AuditActionUserRoleChanged, actorID, user, details)
+	publishEvent(ctx, uc.eventService, entity.EventUserRoleChanged, &entity.UserRoleChangedEvent{
+		UserID:       user.ID,
+		ActorID:      actorID,
+		PreviousRole: previousRole,
+		Role:         role,
+	})
+
+	// Sign the user out of every session, so no token keeps acting with the previous role
+	if role == previousRole {
+		return nil
+	}
+	return uc.tokenRepo.DeleteUserTokens(ctx, user.ID)
+}
+
+// UpdateNotificationChannels updates the channels a user prefers to be notified on
+func (uc *userUseCase) UpdateNotificationChannels(ctx context.Context, id uuid.UUID, channels []string) error {
+	// Validate channels
+	for _, channel := range channels {
+		if !entity.IsValidNotificationChannel(channel) {
+			return ErrInvalidChannel
+		}
+	}
+
+	// Get user
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	user.NotificationChannels = channels
+	user.UpdatedAt = time.Now()
+
+	return uc.userRepo.Update(ctx, user)
+}
+
+// UpdateLifecycleEmails opts a user in or out of the lifecycle emails
+func (uc *userUseCase) UpdateLifecycleEmails(ctx context.Context, id uuid.UUID, enabled bool) error {
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	user.LifecycleEmailsDisabled = !enabled
+	user.UpdatedAt = time.Now()
+
+	return uc.userRepo.Update(ctx, user)
+}
+
+// UpdateVerification updates the verification status of a user. Nil values are left unchanged.
+func (uc *userUseCase) UpdateVerification(ctx context.Context, actorID, id uuid.UUID, emailVerified, phoneVerified *bool) error {
+	// Get user
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if emailVerified != nil {
+		user.EmailVerified = *emailVerified
+	}
+	if phoneVerified != nil {
+		user.PhoneVerified = *phoneVerified
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	uc.recordAdminAction(ctx, entity.AuditActionUserVerificationChanged, actorID, user, map[string]string{
+		"email_verified": strconv.FormatBool(user.EmailVerified),
+		"phone_verified": strconv.FormatBool(user.PhoneVerified),
+	})
+
+	return nil
+}
+
+// AddTags adds tags to a user
+func (uc *userUseCase) AddTags(ctx context.Context, actorID, id uuid.UUID, tags []string) ([]string, error) {
+	return uc.changeTags(ctx, actorID, id, tags, "added", uc.userRepo.AddTags)
+}
+
+// RemoveTags removes tags from a user
+func (uc *userUseCase) RemoveTags(ctx context.Context, actorID, id uuid.UUID, tags []string) ([]string, error) {
+	return uc.changeTags(ctx, actorID, id, tags, "removed", uc.userRepo.RemoveTags)
+}
+
+// changeTags validates tags, applies a tag change to a user and records it in the audit trail
+func (uc *userUseCase) changeTags(
+	ctx context.Context,
+	actorID, id uuid.UUID,
+	tags []string,
+	change string,
+	apply func(ctx context.Context, id uuid.UUID, tags []string) error,
+) ([]string, error) {
+	// Validate tags
+	if len(tags) == 0 {
+		return nil, ErrInvalidTag
+	}
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag, ok := entity.NormalizeUserTag(tag)
+		if !ok {
+			return nil, ErrInvalidTag
+		}
+		normalized = append(normalized, tag)
+	}
+
+	// Check if user exists
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := apply(ctx, id, normalized); err != nil {
+		return nil, err
+	}
+
+	uc.recordAdminAction(ctx, entity.AuditActionUserTagsChanged, actorID, user, map[string]string{
+		change: strings.Join(normalized, ","),
+	})
+
+	// Return the tags as stored
+	user, err = uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user.Tags, nil
+}
+
+// recordAdminAction records an administrative action in the audit trail and notifies the affected user.
+// The action has already been applied, so failures are logged rather than returned.
+func (uc *userUseCase) recordAdminAction(ctx context.Context, action string, actorID uuid.UUID, user *entity.User, details map[string]string) {
+	entry := entity.NewAuditEntry(action, actorID, user.ID, details)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("action", action).Str("user_id", user.ID.String()).Msg("Failed to record administrative action in audit trail")
+	}
+
+	uc.notificationUseCase.NotifyAdminAction(ctx, action, actorID, user, details)
+}
+
+// publishEvent publishes a domain event.
+// The change has already been applied, so failures are logged rather than returned.
+func publishEvent(ctx context.Context, eventService service.EventService, eventType string, data interface{}) {
+	if err := eventService.Publish(ctx, eventType, data); err != nil {
+		log.Error().Err(err).Str("event_type", eventType).Msg("Failed to publish event")
+	}
+}
+
+// recordStatusChange records a status change in the status history of the user.
+// The change has already been applied, so failures are logged rather than returned.
+func recordStatusChange(ctx context.Context, statusHistoryRepo repository.StatusHistoryRepository, change *entity.StatusChange) {
+	if err := statusHistoryRepo.Create(ctx, change); err != nil {
+		log.Error().Err(err).Str("user_id", change.UserID.String()).Str("status", change.Status).Msg("Failed to record status change")
+	}
+}
+
+// userCreatedEvent returns the data of the user.created event of a user
+func userCreatedEvent(user *entity.User) *entity.UserCreatedEvent {
+	return &entity.UserCreatedEvent{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Username:  user.Username,
+		Role:      user.Role,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+	}
 }
 
 // Authenticate authenticates a user
 func (uc *userUseCase) Authenticate(ctx context.Context, email, password string) (*entity.User, error) {
 	// Get user by email
-	user, err := uc.userRepo.GetByEmail(ctx, email)
+	user, err := uc.userRepo.GetByEmail(ctx, entity.NormalizeEmail(email))
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +1432,7 @@ func (uc *userUseCase) Authenticate(ctx context.Context, email, password string)
 	}
 
 	// Verify password
-	if !utils.CheckPasswordHash(password, user.Password) {
+	if !uc.passwordHasher.Verify(password, user.Password) {
 		return nil, ErrInvalidCredentials
 	}
 
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository/inmem"
+	"github.com/chats/go-user-api/internal/mocks"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+// newDeletionTest creates a UserUseCase keeping deleted users for a day, over the users of an authTest
+func newDeletionTest(t *testing.T) (UserUseCase, *authTest) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	at := newAuthTest(t)
+
+	auditRepo := mocks.NewMockAuditRepository(ctrl)
+	auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	notificationUseCase := mocks.NewMockNotificationUseCase(ctrl)
+	notificationUseCase.EXPECT().NotifyAdminAction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	eventService := mocks.NewMockEventService(ctrl)
+	eventService.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	uc := NewUserUseCase(
+		at.users,
+		auditRepo,
+		notificationUseCase,
+		mocks.NewMockPolicyService(ctrl),
+		mocks.NewMockPasswordService(ctrl),
+		at.hasher,
+		mocks.NewMockRoleUseCase(ctrl),
+		mocks.NewMockDedupRepository(ctrl),
+		eventService,
+		mocks.NewMockOrganizationRepository(ctrl),
+		mocks.NewMockOrganizationUseCase(ctrl),
+		inmem.NewStatusHistoryRepository(),
+		at.tokens,
+		mocks.NewMockReferralRepository(ctrl),
+		mocks.NewMockPasskeyRepository(ctrl),
+		mocks.NewMockOAuthIdentityRepository(ctrl),
+		mocks.NewMockAdminNoteRepository(ctrl),
+		mocks.NewMockTeamMemberRepository(ctrl),
+		mocks.NewMockLoginCountryRepository(ctrl),
+		config.SecurityConfig{RefreshTokenExpirationDays: 7},
+		config.RegistrationConfig{},
+		config.DeletionConfig{RestorationWindow: 24 * time.Hour},
+	)
+	return uc, at
+}
+
+func TestCancelDeletionRestoresBlockedUser(t *testing.T) {
+	uc, at := newDeletionTest(t)
+	ctx := context.Background()
+	actorID := uuid.New()
+
+	if err := at.users.UpdateStatus(ctx, at.user.ID, entity.UserStatusBlocked); err != nil {
+		t.Fatalf("failed to block user: %v", err)
+	}
+	if _, err := uc.Delete(ctx, actorID, at.user.ID, entity.ActionReason{}); err != nil {
+		t.Fatalf("failed to delete user: %v", err)
+	}
+
+	restored, err := uc.CancelDeletion(ctx, actorID, at.user.ID)
+	if err != nil {
+		t.Fatalf("failed to cancel deletion: %v", err)
+	}
+
+	if restored.Status != entity.UserStatusBlocked {
+		t.Errorf("restored status: got %q, want %q", restored.Status, entity.UserStatusBlocked)
+	}
+	at.users.expire()
+	stored, err := at.users.GetByID(ctx, at.user.ID)
+	if err != nil {
+		t.Fatalf("failed to get user: %v", err)
+	}
+	if stored.Status != entity.UserStatusBlocked {
+		t.Errorf("stored status: got %q, want %q", stored.Status, entity.UserStatusBlocked)
+	}
+	if status, err := at.tokens.GetUserStatus(ctx, at.user.ID); err != nil || status != entity.UserStatusBlocked {
+		t.Errorf("cached token status: got %q (error %v), want %q", status, err, entity.UserStatusBlocked)
+	}
+}
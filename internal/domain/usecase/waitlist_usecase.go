@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/service"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrInvalidWaitlistBatch is returned when approving no users, or more than maxWaitlistBatch at once
+var ErrInvalidWaitlistBatch = errors.New("invalid waitlist batch")
+
+const (
+	// maxWaitlistBatch caps the users approved by a single call
+	maxWaitlistBatch = 100
+
+	// waitlistApprovalDedupScope is the dedup scope of approvals, keyed by user ID, so a user approved by an
+	// administrator and the quota job at once is activated and emailed once
+	waitlistApprovalDedupScope = "waitlist_approval"
+
+	// waitlistQuotaDedupScope is the dedup scope of the quota job, keyed by interval, so instances approve the
+	// quota once per interval between them
+	waitlistQuotaDedupScope = "waitlist_quota"
+)
+
+// WaitlistUseCase defines the use case for the users waiting for their self-registration to be approved
+type WaitlistUseCase interface {
+	// Approve activates the given waitlisted users and emails them, performed by an administrator.
+	// Users who are not waitlisted are skipped, the approved users are returned.
+	Approve(ctx context.Context, actorID uuid.UUID, ids []uuid.UUID) ([]*entity.User, error)
+
+	// ApproveNext activates the count earliest waitlisted users and emails them. The actor is nil when the quota
+	// job approves them.
+	ApproveNext(ctx context.Context, actorID uuid.UUID, count int) ([]*entity.User, error)
+
+	// RunQuota approves quota waitlisted users at every interval until the context is cancelled
+	RunQuota(ctx context.Context, quota int, interval time.Duration)
+}
+
+// waitlistUseCase implements WaitlistUseCase interface
+type waitlistUseCase struct {
+	userRepo            repository.UserRepository
+	auditRepo           repository.AuditRepository
+	dedupRepo           repository.DedupRepository
+	notificationUseCase NotificationUseCase
+	eventService        service.EventService
+	statusHistoryRepo   repository.StatusHistoryRepository
+}
+
+// NewWaitlistUseCase creates a new WaitlistUseCase
+func NewWaitlistUseCase(
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	dedupRepo repository.DedupRepository,
+	notificationUseCase NotificationUseCase,
+	eventService service.EventService,
+	statusHistoryRepo repository.StatusHistoryRepository,
+) WaitlistUseCase {
+	return &waitlistUseCase{
+		userRepo:            userRepo,
+		auditRepo:           auditRepo,
+		dedupRepo:           dedupRepo,
+		notificationUseCase: notificationUseCase,
+		eventService:        eventService,
+		statusHistoryRepo:   statusHistoryRepo,
+	}
+}
+
+// Approve activates the given waitlisted users and emails them
+func (uc *waitlistUseCase) Approve(ctx context.Context, actorID uuid.UUID, ids []uuid.UUID) ([]*entity.User, error) {
+	if len(ids) == 0 || len(ids) > maxWaitlistBatch {
+		return nil, ErrInvalidWaitlistBatch
+	}
+
+	approved := []*entity.User{}
+	for _, id := range ids {
+		user, err := uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return approved, err
+		}
+		if user == nil || user.Status != entity.UserStatusWaitlisted {
+			continue
+		}
+
+		ok, err := uc.approve(ctx, actorID, user)
+		if err != nil {
+			return approved, err
+		}
+		if ok {
+			approved = append(approved, user)
+		}
+	}
+
+	return approved, nil
+}
+
+// ApproveNext activates the count earliest waitlisted users and emails them
+func (uc *waitlistUseCase) ApproveNext(ctx context.Context, actorID uuid.UUID, count int) ([]*entity.User, error) {
+	if count <= 0 || count > maxWaitlistBatch {
+		return nil, ErrInvalidWaitlistBatch
+	}
+
+	users, err := uc.userRepo.ListWaitlisted(ctx, count)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := []*entity.User{}
+	for _, user := range users {
+		ok, err := uc.approve(ctx, actorID, user)
+		if err != nil {
+			return approved, err
+		}
+		if ok {
+			approved = append(approved, user)
+		}
+	}
+
+	return approved, nil
+}
+
+// RunQuota approves quota waitlisted users at every interval until the context is cancelled
+func (uc *waitlistUseCase) RunQuota(ctx context.Context, quota int, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Claim the interval, the instances ticking within it skip their turn. Fail open, a quota approved twice
+		// only shortens the waitlist faster.
+		window := strconv.FormatInt(time.Now().Truncate(interval).Unix(), 10)
+		claimed, err := uc.dedupRepo.Claim(ctx, waitlistQuotaDedupScope, window, interval)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to claim waitlist quota")
+		} else if !claimed {
+			continue
+		}
+
+		approved, err := uc.approveQuota(ctx, quota)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to approve waitlisted users")
+		}
+		if approved > 0 {
+			log.Info().Int("users", approved).Msg("Approved waitlisted users")
+		}
+	}
+}
+
+// approveQuota approves quota waitlisted users a batch at a time and returns how many were approved
+func (uc *waitlistUseCase) approveQuota(ctx context.Context, quota int) (int, error) {
+	approved := 0
+	for approved < quota {
+		users, err := uc.ApproveNext(ctx, uuid.Nil, min(quota-approved, maxWaitlistBatch))
+		approved += len(users)
+		if err != nil {
+			return approved, err
+		}
+		// Stop when the waitlist is empty, or its head is being approved by someone else
+		if len(users) == 0 {
+			return approved, nil
+		}
+	}
+	return approved, nil
+}
+
+// approve activates a waitlisted user and emails them in the background. It reports false when the user is
+// being approved by someone else already.
+func (uc *waitlistUseCase) approve(ctx context.Context, actorID uuid.UUID, user *entity.User) (bool, error) {
+	// Claim the approval, fail open like purges: a user approved twice is only emailed twice
+	claimed, err := uc.dedupRepo.Claim(ctx, waitlistApprovalDedupScope, user.ID.String(), time.Minute)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to claim waitlist approval")
+	} else if !claimed {
+		return false, nil
+	}
+
+	if err := uc.userRepo.UpdateStatus(ctx, user.ID, entity.UserStatusActive); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	user.Status = entity.UserStatusActive
+	user.UpdatedAt = now
+
+	recordStatusChange(ctx, uc.statusHistoryRepo, entity.NewStatusChange(user.ID, actorID, entity.UserStatusWaitlisted, user.Status, entity.ActionReason{}))
+	entry := entity.NewAuditEntry(entity.AuditActionWaitlistApproved, actorID, user.ID, nil)
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to record waitlist approval in audit trail")
+	}
+	publishEvent(ctx, uc.eventService, entity.EventUserStatusChanged, &entity.UserStatusChangedEvent{
+		UserID:         user.ID,
+		ActorID:        actorID,
+		PreviousStatus: entity.UserStatusWaitlisted,
+		Status:         user.Status,
+	})
+	publishEvent(ctx, uc.eventService, entity.EventUserApproved, &entity.UserApprovedEvent{
+		UserID:       user.ID,
+		ActorID:      actorID,
+		WaitlistedAt: user.CreatedAt,
+		ApprovedAt:   now,
+	})
+
+	// The account is active even if the email fails, the user can sign in all the same
+	runInBackground(ctx, "waitlist_approved_email", func(ctx context.Context) error {
+		return uc.notificationUseCase.SendWaitlistApproved(ctx, user)
+	})
+	return true, nil
+}
@@ -0,0 +1,449 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/chats/go-user-api/internal/infrastructure/webhook"
+	"github.com/chats/go-user-api/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrWebhookEndpointNotFound  = errors.New("webhook endpoint not found")
+	ErrWebhookDeliveryNotFound  = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL        = errors.New("invalid webhook URL")
+	ErrInvalidWebhookEventTypes = errors.New("invalid webhook event types")
+	ErrInvalidWebhookReplay     = errors.New("invalid webhook replay")
+)
+
+const (
+	// dispatchBatchSize is the maximum number of deliveries attempted per dispatch pass
+	dispatchBatchSize = 100
+
+	// maxReplayDeliveries is the maximum number of deliveries replayed at once
+	maxReplayDeliveries = 100
+
+	// deliveryClaimScope is the dedup scope claiming a delivery attempt, so instances do not send it twice
+	deliveryClaimScope = "webhook_delivery"
+
+	// maxBackoffDoublings caps the growth of the retry backoff
+	maxBackoffDoublings = 10
+
+	// maxLastErrorLength truncates the error stored with a failed delivery
+	maxLastErrorLength = 500
+)
+
+// WebhookUseCase defines the use case for webhook endpoints and the delivery of events to them
+type WebhookUseCase interface {
+	// Publish queues a delivery of an event to every endpoint subscribed to its type, implementing eventbus.Bus
+	Publish(ctx context.Context, eventType string, payload []byte) error
+
+	// CreateEndpoint registers an endpoint receiving events of the given types
+	CreateEndpoint(ctx context.Context, actorID uuid.UUID, url string, eventTypes []string) (*entity.WebhookEndpoint, error)
+
+	// GetEndpoint returns an endpoint
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error)
+
+	// ListEndpoints returns all endpoints
+	ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error)
+
+	// DeleteEndpoint removes an endpoint along with its deliveries
+	DeleteEndpoint(ctx context.Context, actorID, id uuid.UUID) error
+
+	// SetEndpointPaused pauses or resumes an endpoint. Events keep being queued for a paused endpoint
+	// and are delivered once it is resumed.
+	SetEndpointPaused(ctx context.Context, actorID, id uuid.UUID, paused bool) (*entity.WebhookEndpoint, error)
+
+	// ListDeliveries returns the deliveries matching a filter, newest first
+	ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+
+	// GetDelivery returns a delivery
+	GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+
+	// Replay queues deliveries again with a fresh set of attempts, typically from the dead-letter queue
+	Replay(ctx context.Context, actorID uuid.UUID, deliveryIDs []uuid.UUID) ([]*entity.WebhookDelivery, error)
+
+	// Dispatch attempts the deliveries that are due
+	Dispatch(ctx context.Context) error
+
+	// Run dispatches the due deliveries periodically, and whenever deliveries are queued, until the context is cancelled
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// webhookUseCase implements WebhookUseCase interface
+type webhookUseCase struct {
+	webhookRepo repository.WebhookRepository
+	dedupRepo   repository.DedupRepository
+	auditRepo   repository.AuditRepository
+	sender      webhook.Sender
+	config      config.WebhookConfig
+
+	// wake triggers a dispatch pass without waiting for the next tick
+	wake chan struct{}
+}
+
+// NewWebhookUseCase creates a new WebhookUseCase
+func NewWebhookUseCase(
+	webhookRepo repository.WebhookRepository,
+	dedupRepo repository.DedupRepository,
+	auditRepo repository.AuditRepository,
+	sender webhook.Sender,
+	config config.WebhookConfig,
+) WebhookUseCase {
+	return &webhookUseCase{
+		webhookRepo: webhookRepo,
+		dedupRepo:   dedupRepo,
+		auditRepo:   auditRepo,
+		sender:      sender,
+		config:      config,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Publish queues a delivery of an event to every endpoint subscribed to its type
+func (uc *webhookUseCase) Publish(ctx context.Context, eventType string, payload []byte) error {
+	endpoints, err := uc.webhookRepo.ListEndpoints(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+
+	var errs []error
+	queued := 0
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(eventType) {
+			continue
+		}
+		delivery := entity.NewWebhookDelivery(endpoint.ID, eventType, payload)
+		if err := uc.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		queued++
+	}
+
+	if queued > 0 {
+		uc.notify()
+	}
+	return errors.Join(errs...)
+}
+
+// CreateEndpoint registers an endpoint receiving events of the given types
+func (uc *webhookUseCase) CreateEndpoint(ctx context.Context, actorID uuid.UUID, rawURL string, eventTypes []string) (*entity.WebhookEndpoint, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	eventTypes, err = normalizeEventTypes(eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	endpoint := entity.NewWebhookEndpoint(parsed.String(), "whsec_"+secret, eventTypes)
+	if err := uc.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	uc.recordEndpointAction(ctx, entity.AuditActionWebhookEndpointCreated, actorID, endpoint)
+	return endpoint, nil
+}
+
+// GetEndpoint returns an endpoint
+func (uc *webhookUseCase) GetEndpoint(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	endpoint, err := uc.webhookRepo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil {
+		return nil, ErrWebhookEndpointNotFound
+	}
+
+	return endpoint, nil
+}
+
+// ListEndpoints returns all endpoints
+func (uc *webhookUseCase) ListEndpoints(ctx context.Context) ([]*entity.WebhookEndpoint, error) {
+	return uc.webhookRepo.ListEndpoints(ctx)
+}
+
+// DeleteEndpoint removes an endpoint along with its deliveries
+func (uc *webhookUseCase) DeleteEndpoint(ctx context.Context, actorID, id uuid.UUID) error {
+	endpoint, err := uc.GetEndpoint(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.webhookRepo.DeleteEndpoint(ctx, id); err != nil {
+		return err
+	}
+
+	uc.recordEndpointAction(ctx, entity.AuditActionWebhookEndpointDeleted, actorID, endpoint)
+	return nil
+}
+
+// SetEndpointPaused pauses or resumes an endpoint
+func (uc *webhookUseCase) SetEndpointPaused(ctx context.Context, actorID, id uuid.UUID, paused bool) (*entity.WebhookEndpoint, error) {
+	endpoint, err := uc.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Paused == paused {
+		return endpoint, nil
+	}
+
+	endpoint.Paused = paused
+	endpoint.UpdatedAt = time.Now()
+	if err := uc.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	action := entity.AuditActionWebhookEndpointPaused
+	if !paused {
+		action = entity.AuditActionWebhookEndpointResumed
+		// Deliver what was queued while the endpoint was paused
+		uc.notify()
+	}
+	uc.recordEndpointAction(ctx, action, actorID, endpoint)
+
+	return endpoint, nil
+}
+
+// ListDeliveries returns the deliveries matching a filter, newest first
+func (uc *webhookUseCase) ListDeliveries(ctx context.Context, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	return uc.webhookRepo.ListDeliveries(ctx, filter)
+}
+
+// GetDelivery returns a delivery
+func (uc *webhookUseCase) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	delivery, err := uc.webhookRepo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+// Replay queues deliveries again with a fresh set of attempts.
+// All deliveries are looked up first so an unknown ID replays nothing.
+func (uc *webhookUseCase) Replay(ctx context.Context, actorID uuid.UUID, deliveryIDs []uuid.UUID) ([]*entity.WebhookDelivery, error) {
+	if len(deliveryIDs) == 0 || len(deliveryIDs) > maxReplayDeliveries {
+		return nil, ErrInvalidWebhookReplay
+	}
+
+	deliveries := make([]*entity.WebhookDelivery, 0, len(deliveryIDs))
+	seen := make(map[uuid.UUID]bool, len(deliveryIDs))
+	for _, id := range deliveryIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		delivery, err := uc.GetDelivery(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	now := time.Now()
+	replayed := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		delivery.Status = entity.WebhookDeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = now
+		delivery.DeliveredAt = nil
+		delivery.UpdatedAt = now
+		if err := uc.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return nil, err
+		}
+		replayed = append(replayed, delivery.ID.String())
+	}
+
+	entry := entity.NewAuditEntry(entity.AuditActionWebhookReplayed, actorID, uuid.Nil, map[string]string{
+		"delivery_ids": strings.Join(replayed, ","),
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Msg("Failed to record webhook replay in audit trail")
+	}
+
+	uc.notify()
+	return deliveries, nil
+}
+
+// Dispatch attempts the deliveries that are due.
+// Deliveries of paused endpoints stay pending and are attempted once the endpoint is resumed.
+func (uc *webhookUseCase) Dispatch(ctx context.Context) error {
+	list, err := uc.webhookRepo.ListEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoints := make(map[uuid.UUID]*entity.WebhookEndpoint, len(list))
+	var paused []uuid.UUID
+	for _, endpoint := range list {
+		endpoints[endpoint.ID] = endpoint
+		if endpoint.Paused {
+			paused = append(paused, endpoint.ID)
+		}
+	}
+
+	deliveries, err := uc.webhookRepo.ListDueDeliveries(ctx, time.Now(), paused, dispatchBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		// A delivery whose endpoint was deleted meanwhile is dead-lettered by attempt
+		endpoint := endpoints[delivery.EndpointID]
+
+		// Claim this attempt, any other instance listing the same version of the delivery skips it.
+		// Fail open, receivers deduplicate on the delivery ID header.
+		claimed, err := uc.dedupRepo.Claim(ctx, deliveryClaimScope, attemptKey(delivery), uc.config.Timeout+time.Minute)
+		if err != nil {
+			log.Warn().Err(err).Str("delivery_id", delivery.ID.String()).Msg("Failed to claim webhook delivery")
+		} else if !claimed {
+			continue
+		}
+
+		uc.attempt(ctx, endpoint, delivery)
+	}
+
+	return nil
+}
+
+// Run dispatches the due deliveries periodically, and whenever deliveries are queued, until the context is cancelled
+func (uc *webhookUseCase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+
+		if err := uc.Dispatch(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to dispatch webhook deliveries")
+		}
+	}
+}
+
+// attempt sends a delivery and records the outcome, scheduling a retry or moving it to the dead-letter queue
+func (uc *webhookUseCase) attempt(ctx context.Context, endpoint *entity.WebhookEndpoint, delivery *entity.WebhookDelivery) {
+	var statusCode int
+	var err error
+	if endpoint == nil {
+		err = ErrWebhookEndpointNotFound
+	} else {
+		statusCode, err = uc.sender.Send(ctx, endpoint.URL, endpoint.Secret, delivery.ID.String(), []byte(delivery.Payload))
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		delivery.Status = entity.WebhookDeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		metrics.WebhookDeliveryAttempts.WithLabelValues("delivered").Inc()
+	case endpoint == nil || delivery.Attempts >= uc.config.MaxAttempts:
+		delivery.Status = entity.WebhookDeliveryDead
+		delivery.LastError = truncate(err.Error(), maxLastErrorLength)
+		metrics.WebhookDeliveryAttempts.WithLabelValues("dead").Inc()
+		log.Warn().Err(err).
+			Str("delivery_id", delivery.ID.String()).
+			Str("endpoint_id", delivery.EndpointID.String()).
+			Int("attempts", delivery.Attempts).
+			Msg("Webhook delivery moved to the dead-letter queue")
+	default:
+		delivery.LastError = truncate(err.Error(), maxLastErrorLength)
+		delivery.NextAttemptAt = now.Add(uc.config.RetryBackoff << min(delivery.Attempts-1, maxBackoffDoublings))
+		metrics.WebhookDeliveryAttempts.WithLabelValues("retry").Inc()
+	}
+
+	if err := uc.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Error().Err(err).Str("delivery_id", delivery.ID.String()).Msg("Failed to record webhook delivery attempt")
+	}
+}
+
+// notify wakes the dispatcher up, without blocking when a pass is already pending
+func (uc *webhookUseCase) notify() {
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+}
+
+// recordEndpointAction records a change of a webhook endpoint in the audit trail
+func (uc *webhookUseCase) recordEndpointAction(ctx context.Context, action string, actorID uuid.UUID, endpoint *entity.WebhookEndpoint) {
+	entry := entity.NewAuditEntry(action, actorID, uuid.Nil, map[string]string{
+		"endpoint_id": endpoint.ID.String(),
+		"url":         endpoint.URL,
+	})
+	if err := uc.auditRepo.Create(ctx, entry); err != nil {
+		log.Error().Err(err).Str("endpoint_id", endpoint.ID.String()).Msg("Failed to record webhook endpoint change in audit trail")
+	}
+}
+
+// normalizeEventTypes checks the event types of an endpoint subscription and removes duplicates
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	known := make(map[string]bool, len(entity.EventTypes))
+	for _, eventType := range entity.EventTypes {
+		known[eventType] = true
+	}
+
+	normalized := make([]string, 0, len(eventTypes))
+	seen := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !known[eventType] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWebhookEventTypes, eventType)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			normalized = append(normalized, eventType)
+		}
+	}
+
+	if len(normalized) == 0 {
+		return nil, ErrInvalidWebhookEventTypes
+	}
+	return normalized, nil
+}
+
+// attemptKey identifies an attempt of a delivery, it changes whenever the delivery is updated
+func attemptKey(delivery *entity.WebhookDelivery) string {
+	return delivery.ID.String() + ":" + strconv.FormatInt(delivery.UpdatedAt.UnixNano(), 10)
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -26,6 +26,9 @@ func (f *CacheFactory) Create(config config.CacheConfig) (Cache, error) {
 	case "redis":
 		log.Info().Msg("Creating Redis cache connection")
 		return NewRedis(config)
+	case "memory":
+		log.Info().Msg("Creating in-memory cache")
+		return NewMemory(), nil
 	//case "memcached":
 	//	log.Info().Msg("Creating Memcached cache connection")
 	//	return NewMemcached(config)
@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// subscriptionBuffer is the number of messages a subscription holds until the subscriber receives them
+const subscriptionBuffer = 64
+
 // Cache defines the interface for cache operations
 type Cache interface {
 	// Connect establishes a connection to the cache
@@ -22,6 +25,16 @@ type Cache interface {
 	// Set stores a value in the cache with an optional expiration time
 	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
 
+	// SetNX stores a value only if the key does not exist yet, reporting whether it was stored
+	SetNX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error)
+
+	// SetXX replaces a value only if the key exists, reporting whether it was stored
+	SetXX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error)
+
+	// Increment atomically adds delta to the integer stored at key and returns the new value.
+	// A missing key is created with the given expiration.
+	Increment(ctx context.Context, key string, delta int64, expiration time.Duration) (int64, error)
+
 	// Delete removes a key from the cache
 	Delete(ctx context.Context, key string) error
 
@@ -31,6 +44,23 @@ type Cache interface {
 	// GetMulti retrieves multiple values from the cache
 	GetMulti(ctx context.Context, keys []string) (map[string][]byte, error)
 
+	// AddToSet adds a member to the set stored at key. The set expires after the given expiration
+	// unless it already lives longer, so it outlives all of its members.
+	AddToSet(ctx context.Context, key, member string, expiration time.Duration) error
+
+	// GetSetMembers returns the members of the set stored at key, empty if the set does not exist
+	GetSetMembers(ctx context.Context, key string) ([]string, error)
+
+	// RemoveFromSet removes members from the set stored at key
+	RemoveFromSet(ctx context.Context, key string, members ...string) error
+
+	// Publish sends a message to the subscribers of a channel, on every instance
+	Publish(ctx context.Context, channel string, message []byte) error
+
+	// Subscribe returns the messages published to a channel from now on. The returned channel is closed when the
+	// context is cancelled or the subscription is lost, messages published meanwhile are not redelivered.
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+
 	// GetInstance returns the cache client instance
 	GetInstance() interface{}
 }
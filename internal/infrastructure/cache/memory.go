@@ -0,0 +1,284 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// memoryEntry is a value stored in the in-memory cache
+type memoryEntry struct {
+	value     []byte
+	members   map[string]struct{} // members of a set, nil for plain values
+	expiresAt time.Time           // zero when the entry never expires
+}
+
+// expired reports whether the entry expired at now
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// MemoryCache implements the Cache interface in process memory, for tests and the no-infrastructure demo mode
+type MemoryCache struct {
+	mu          sync.Mutex
+	entries     map[string]memoryEntry
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+// NewMemory creates a new in-memory cache
+func NewMemory() Cache {
+	return &MemoryCache{
+		entries:     map[string]memoryEntry{},
+		subscribers: map[string]map[chan []byte]struct{}{},
+	}
+}
+
+// Connect is a no-op, the in-memory cache is always available
+func (c *MemoryCache) Connect(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op, the in-memory cache holds no connection
+func (c *MemoryCache) Close() error {
+	return nil
+}
+
+// Ping always succeeds for the in-memory cache
+func (c *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Get retrieves a value from memory
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key, time.Now())
+	if !ok {
+		return nil, nil // Key not found, return nil without error
+	}
+	return append([]byte(nil), entry.value...), nil
+}
+
+// Set stores a value in memory
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := memoryEntry{value: append([]byte(nil), value...)}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+	c.entries[key] = entry
+	return nil
+}
+
+// SetNX stores a value in memory only if the key does not exist yet
+func (c *MemoryCache) SetNX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := c.lookup(key, now); ok {
+		return false, nil
+	}
+
+	entry := memoryEntry{value: append([]byte(nil), value...)}
+	if expiration > 0 {
+		entry.expiresAt = now.Add(expiration)
+	}
+	c.entries[key] = entry
+	return true, nil
+}
+
+// SetXX replaces a value in memory only if the key exists
+func (c *MemoryCache) SetXX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := c.lookup(key, now); !ok {
+		return false, nil
+	}
+
+	entry := memoryEntry{value: append([]byte(nil), value...)}
+	if expiration > 0 {
+		entry.expiresAt = now.Add(expiration)
+	}
+	c.entries[key] = entry
+	return true, nil
+}
+
+// Increment atomically adds delta to the integer stored at key in memory
+func (c *MemoryCache) Increment(ctx context.Context, key string, delta int64, expiration time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := c.lookup(key, now)
+
+	var current int64
+	if ok {
+		parsed, err := strconv.ParseInt(string(entry.value), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		current = parsed
+	} else if expiration > 0 {
+		// Only set the expiration when the key was just created
+		entry.expiresAt = now.Add(expiration)
+	}
+
+	current += delta
+	entry.value = []byte(strconv.FormatInt(current, 10))
+	c.entries[key] = entry
+	return current, nil
+}
+
+// Delete removes a key from memory
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	return nil
+}
+
+// Clear clears all keys in memory
+func (c *MemoryCache) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]memoryEntry{}
+	return nil
+}
+
+// GetMulti retrieves multiple values from memory
+func (c *MemoryCache) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	results := make(map[string][]byte)
+	for _, key := range keys {
+		if entry, ok := c.lookup(key, now); ok {
+			results[key] = append([]byte(nil), entry.value...)
+		}
+	}
+	return results, nil
+}
+
+// AddToSet adds a member to the set stored at key in memory
+func (c *MemoryCache) AddToSet(ctx context.Context, key, member string, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := c.lookup(key, now)
+	if !ok || entry.members == nil {
+		entry = memoryEntry{members: map[string]struct{}{}}
+		if expiration > 0 {
+			entry.expiresAt = now.Add(expiration)
+		}
+	} else if expiration > 0 && !entry.expiresAt.IsZero() && entry.expiresAt.Before(now.Add(expiration)) {
+		// Only extend the expiration of an existing set
+		entry.expiresAt = now.Add(expiration)
+	}
+
+	entry.members[member] = struct{}{}
+	c.entries[key] = entry
+	return nil
+}
+
+// GetSetMembers returns the members of the set stored at key in memory
+func (c *MemoryCache) GetSetMembers(ctx context.Context, key string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key, time.Now())
+	if !ok {
+		return nil, nil
+	}
+
+	members := make([]string, 0, len(entry.members))
+	for member := range entry.members {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// RemoveFromSet removes members from the set stored at key in memory
+func (c *MemoryCache) RemoveFromSet(ctx context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key, time.Now())
+	if !ok {
+		return nil
+	}
+
+	for _, member := range members {
+		delete(entry.members, member)
+	}
+	if len(entry.members) == 0 {
+		// Like Redis, an empty set does not exist
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// Publish sends a message to the subscribers of a channel in this process.
+// A subscriber whose buffer is full misses the message rather than blocking the publisher.
+func (c *MemoryCache) Publish(ctx context.Context, channel string, message []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for subscriber := range c.subscribers[channel] {
+		select {
+		case subscriber <- append([]byte(nil), message...):
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns the messages published to a channel in this process, until the context is cancelled
+func (c *MemoryCache) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	messages := make(chan []byte, subscriptionBuffer)
+
+	c.mu.Lock()
+	if c.subscribers[channel] == nil {
+		c.subscribers[channel] = map[chan []byte]struct{}{}
+	}
+	c.subscribers[channel][messages] = struct{}{}
+	c.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.subscribers[channel], messages)
+		close(messages)
+	}()
+	return messages, nil
+}
+
+// GetInstance returns the in-memory cache itself, it has no underlying client
+func (c *MemoryCache) GetInstance() interface{} {
+	return c
+}
+
+// lookup returns the live entry stored at key, evicting it when expired. The caller must hold the lock.
+func (c *MemoryCache) lookup(key string, now time.Time) (memoryEntry, bool) {
+	entry, ok := c.entries[key]
+	if !ok {
+		return memoryEntry{}, false
+	}
+	if entry.expired(now) {
+		delete(c.entries, key)
+		return memoryEntry{}, false
+	}
+	return entry, true
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/chats/go-user-api/config"
@@ -13,6 +14,7 @@ import (
 // RedisCache implements the Cache interface for Redis
 type RedisCache struct {
 	config config.CacheConfig
+	mu     sync.RWMutex
 	client *redis.Client
 }
 
@@ -23,7 +25,8 @@ func NewRedis(config config.CacheConfig) (Cache, error) {
 	}, nil
 }
 
-// Connect establishes a connection to Redis
+// Connect establishes a connection to Redis.
+// Calling it again replaces the current client, which is then closed.
 func (c *RedisCache) Connect(ctx context.Context) error {
 	client := redis.NewClient(&redis.Options{
 		Addr:         fmt.Sprintf("%s:%d", c.config.Host, c.config.Port),
@@ -42,31 +45,48 @@ func (c *RedisCache) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to Redis: %v", err)
 	}
 
+	c.mu.Lock()
+	previous := c.client
 	c.client = client
+	c.mu.Unlock()
 	log.Info().Msg("Connected to Redis successfully")
+
+	if previous != nil {
+		if err := previous.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close previous Redis client")
+		}
+	}
 	return nil
 }
 
+// conn returns the current Redis client
+func (c *RedisCache) conn() *redis.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.client
+}
+
 // Close closes the Redis connection
 func (c *RedisCache) Close() error {
-	if c.client != nil {
+	if client := c.conn(); client != nil {
 		log.Info().Msg("Closing Redis connection")
-		return c.client.Close()
+		return client.Close()
 	}
 	return nil
 }
 
 // Ping verifies the connection to Redis
 func (c *RedisCache) Ping(ctx context.Context) error {
-	if c.client == nil {
+	client := c.conn()
+	if client == nil {
 		return fmt.Errorf("Redis client not initialized")
 	}
-	return c.client.Ping(ctx).Err()
+	return client.Ping(ctx).Err()
 }
 
 // Get retrieves a value from Redis
 func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
-	val, err := c.client.Get(ctx, key).Bytes()
+	val, err := c.conn().Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		return nil, nil // Key not found, return nil without error
 	}
@@ -75,22 +95,48 @@ func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
 
 // Set stores a value in Redis
 func (c *RedisCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
-	return c.client.Set(ctx, key, value, expiration).Err()
+	return c.conn().Set(ctx, key, value, expiration).Err()
+}
+
+// SetNX stores a value in Redis only if the key does not exist yet
+func (c *RedisCache) SetNX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	return c.conn().SetNX(ctx, key, value, expiration).Result()
+}
+
+// SetXX replaces a value in Redis only if the key exists
+func (c *RedisCache) SetXX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	return c.conn().SetXX(ctx, key, value, expiration).Result()
+}
+
+// Increment atomically adds delta to the integer stored at key in Redis
+func (c *RedisCache) Increment(ctx context.Context, key string, delta int64, expiration time.Duration) (int64, error) {
+	pipeline := c.conn().TxPipeline()
+	incr := pipeline.IncrBy(ctx, key, delta)
+	if expiration > 0 {
+		// Only set the expiration when the key was just created
+		pipeline.ExpireNX(ctx, key, expiration)
+	}
+
+	if _, err := pipeline.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
 }
 
 // Delete removes a key from Redis
 func (c *RedisCache) Delete(ctx context.Context, key string) error {
-	return c.client.Del(ctx, key).Err()
+	return c.conn().Del(ctx, key).Err()
 }
 
 // Clear clears all keys in Redis
 func (c *RedisCache) Clear(ctx context.Context) error {
-	return c.client.FlushAll(ctx).Err()
+	return c.conn().FlushAll(ctx).Err()
 }
 
 // GetMulti retrieves multiple values from Redis
 func (c *RedisCache) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
-	pipeline := c.client.Pipeline()
+	pipeline := c.conn().Pipeline()
 
 	cmds := make(map[string]*redis.StringCmd)
 	for _, key := range keys {
@@ -116,7 +162,79 @@ func (c *RedisCache) GetMulti(ctx context.Context, keys []string) (map[string][]
 	return results, nil
 }
 
+// AddToSet adds a member to the set stored at key in Redis
+func (c *RedisCache) AddToSet(ctx context.Context, key, member string, expiration time.Duration) error {
+	pipeline := c.conn().TxPipeline()
+	pipeline.SAdd(ctx, key, member)
+	if expiration > 0 {
+		// Set the expiration of a new set, only extend the expiration of an existing one
+		pipeline.ExpireNX(ctx, key, expiration)
+		pipeline.ExpireGT(ctx, key, expiration)
+	}
+
+	_, err := pipeline.Exec(ctx)
+	return err
+}
+
+// GetSetMembers returns the members of the set stored at key in Redis
+func (c *RedisCache) GetSetMembers(ctx context.Context, key string) ([]string, error) {
+	return c.conn().SMembers(ctx, key).Result()
+}
+
+// RemoveFromSet removes members from the set stored at key in Redis
+func (c *RedisCache) RemoveFromSet(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+	return c.conn().SRem(ctx, key, values...).Err()
+}
+
+// Publish sends a message to the subscribers of a Redis channel
+func (c *RedisCache) Publish(ctx context.Context, channel string, message []byte) error {
+	return c.conn().Publish(ctx, channel, message).Err()
+}
+
+// Subscribe returns the messages published to a Redis channel. The subscription is lost when the client is replaced
+// after a reconnection, closing the returned channel.
+func (c *RedisCache) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	pubsub := c.conn().Subscribe(ctx, channel)
+	// Wait for the confirmation, so messages published once Subscribe returns are received
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	messages := make(chan []byte, subscriptionBuffer)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		received := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages, nil
+}
+
 // GetInstance returns the Redis client instance
 func (c *RedisCache) GetInstance() interface{} {
-	return c.client
+	return c.conn()
 }
@@ -23,12 +23,15 @@ func NewDatabaseFactory() Factory {
 // Create creates a new database connection based on the provided configuration
 func (f *DatabaseFactory) Create(config config.DatabaseConfig) (Database, error) {
 	switch config.Type {
-	//case "postgresql":
-	//	log.Info().Msg("Creating PostgreSQL database connection")
-	//	return NewPostgreSQL(config)
+	case "postgresql":
+		log.Info().Msg("Creating PostgreSQL database connection")
+		return NewPostgreSQL(config)
 	case "mongodb":
 		log.Info().Msg("Creating MongoDB database connection")
 		return NewMongoDB(config)
+	case "memory":
+		log.Warn().Msg("Using in-memory database, data is lost on restart")
+		return NewMemory()
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", config.Type)
 	}
@@ -0,0 +1,32 @@
+package db
+
+import "context"
+
+// MemoryDatabase implements the Database interface for the in-memory repositories.
+// It holds no data itself, the repositories in repository/inmem keep their own state.
+type MemoryDatabase struct{}
+
+// NewMemory creates a new in-memory database
+func NewMemory() (Database, error) {
+	return &MemoryDatabase{}, nil
+}
+
+// Connect is a no-op, the in-memory database is always available
+func (db *MemoryDatabase) Connect(ctx context.Context) error {
+	return nil
+}
+
+// Close is a no-op, the in-memory database holds no connection
+func (db *MemoryDatabase) Close(ctx context.Context) error {
+	return nil
+}
+
+// Ping always succeeds for the in-memory database
+func (db *MemoryDatabase) Ping(ctx context.Context) error {
+	return nil
+}
+
+// GetInstance returns the in-memory database itself, it has no underlying client
+func (db *MemoryDatabase) GetInstance() interface{} {
+	return db
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/chats/go-user-api/config"
@@ -15,6 +16,7 @@ import (
 // MongoDatabase implements the Database interface for MongoDB
 type MongoDatabase struct {
 	config   config.DatabaseConfig
+	mu       sync.RWMutex
 	client   *mongo.Client
 	database *mongo.Database
 }
@@ -26,7 +28,8 @@ func NewMongoDB(config config.DatabaseConfig) (Database, error) {
 	}, nil
 }
 
-// Connect establishes a connection to MongoDB
+// Connect establishes a connection to MongoDB.
+// Calling it again replaces the current client, which is then disconnected.
 func (db *MongoDatabase) Connect(ctx context.Context) error {
 	uri := fmt.Sprintf("mongodb://%s:%s@%s:%d",
 		db.config.Username,
@@ -41,7 +44,9 @@ func (db *MongoDatabase) Connect(ctx context.Context) error {
 		SetConnectTimeout(10 * time.Second).
 		SetMaxPoolSize(100).
 		SetMinPoolSize(10).
-		SetMaxConnIdleTime(30 * time.Minute)
+		SetMaxConnIdleTime(30 * time.Minute).
+		SetMonitor(newCommandMonitor()).
+		SetPoolMonitor(newPoolMonitor())
 
 	// Connect to MongoDB
 	client, err := mongo.Connect(ctx, clientOptions)
@@ -54,45 +59,60 @@ func (db *MongoDatabase) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed to ping MongoDB server: %v", err)
 	}
 
+	db.mu.Lock()
+	previous := db.client
 	db.client = client
 	db.database = client.Database(db.config.Database)
+	db.mu.Unlock()
 	log.Info().Msg("Connected to MongoDB successfully")
+
+	if previous != nil {
+		if err := previous.Disconnect(ctx); err != nil {
+			log.Warn().Err(err).Msg("Failed to disconnect previous MongoDB client")
+		}
+	}
 	return nil
 }
 
 // Close closes the MongoDB connection
 func (db *MongoDatabase) Close(ctx context.Context) error {
-	if db.client != nil {
+	client := db.GetClient()
+	if client != nil {
 		log.Info().Msg("Closing MongoDB connection")
-		return db.client.Disconnect(ctx)
+		return client.Disconnect(ctx)
 	}
 	return nil
 }
 
 // Ping verifies the connection to MongoDB
 func (db *MongoDatabase) Ping(ctx context.Context) error {
-	if db.client == nil {
+	client := db.GetClient()
+	if client == nil {
 		return fmt.Errorf("MongoDB client not initialized")
 	}
-	return db.client.Ping(ctx, readpref.Primary())
+	return client.Ping(ctx, readpref.Primary())
 }
 
 // GetInstance returns the MongoDB client instance
 func (db *MongoDatabase) GetInstance() interface{} {
-	return db.client
+	return db.GetClient()
 }
 
 // GetClient returns the MongoDB client
 func (db *MongoDatabase) GetClient() *mongo.Client {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 	return db.client
 }
 
 // GetDatabase returns the MongoDB database
 func (db *MongoDatabase) GetDatabase() *mongo.Database {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 	return db.database
 }
 
 // Collection returns a specific collection
 func (db *MongoDatabase) Collection(name string) *mongo.Collection {
-	return db.database.Collection(name)
+	return db.GetDatabase().Collection(name)
 }
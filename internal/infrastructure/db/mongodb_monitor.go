@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+)
+
+// newCommandMonitor creates a command monitor recording the latency and errors of MongoDB commands, and tracing
+// each command as a child span of the repository operation running it
+func newCommandMonitor() *event.CommandMonitor {
+	tracer := otelmongo.NewMonitor()
+	return &event.CommandMonitor{
+		Started: tracer.Started,
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			tracer.Succeeded(ctx, evt)
+			metrics.MongoCommandDuration.WithLabelValues(evt.CommandName, "success").Observe(evt.Duration.Seconds())
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			tracer.Failed(ctx, evt)
+			metrics.MongoCommandDuration.WithLabelValues(evt.CommandName, "error").Observe(evt.Duration.Seconds())
+			metrics.MongoCommandErrors.WithLabelValues(evt.CommandName).Inc()
+		},
+	}
+}
+
+// newPoolMonitor creates a pool monitor tracking the saturation of the MongoDB connection pool
+func newPoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.ConnectionCreated:
+				metrics.MongoPoolConnectionsOpen.Inc()
+			case event.ConnectionClosed:
+				metrics.MongoPoolConnectionsOpen.Dec()
+			case event.GetSucceeded:
+				metrics.MongoPoolConnectionsInUse.Inc()
+				metrics.MongoPoolCheckouts.WithLabelValues("success").Inc()
+				metrics.MongoPoolCheckoutDuration.Observe(evt.Duration.Seconds())
+			case event.ConnectionReturned:
+				metrics.MongoPoolConnectionsInUse.Dec()
+			case event.GetFailed:
+				metrics.MongoPoolCheckouts.WithLabelValues("failed").Inc()
+				metrics.MongoPoolCheckoutDuration.Observe(evt.Duration.Seconds())
+				log.Warn().Str("address", evt.Address).Str("reason", evt.Reason).Msg("MongoDB connection checkout failed")
+			case event.PoolCleared:
+				metrics.MongoPoolCleared.Inc()
+				log.Warn().Str("address", evt.Address).Msg("MongoDB connection pool cleared")
+			}
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// PostgresDatabase implements the Database interface for PostgreSQL
+type PostgresDatabase struct {
+	config config.DatabaseConfig
+	mu     sync.RWMutex
+	pool   *pgxpool.Pool
+}
+
+// NewPostgreSQL creates a new PostgreSQL database connection
+func NewPostgreSQL(config config.DatabaseConfig) (Database, error) {
+	return &PostgresDatabase{
+		config: config,
+	}, nil
+}
+
+// Connect establishes a connection pool to PostgreSQL.
+// Calling it again replaces the current pool, which is then closed.
+func (db *PostgresDatabase) Connect(ctx context.Context) error {
+	dsn := (&url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(db.config.Username, db.config.Password),
+		Host:     fmt.Sprintf("%s:%d", db.config.Host, db.config.Port),
+		Path:     db.config.Database,
+		RawQuery: url.Values{"sslmode": {db.config.SSLMode}}.Encode(),
+	}).String()
+
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return fmt.Errorf("failed to parse PostgreSQL connection string: %v", err)
+	}
+
+	// Configure the connection pool
+	poolConfig.MaxConns = 20
+	poolConfig.MinConns = 5
+	poolConfig.MaxConnLifetime = time.Hour
+	poolConfig.MaxConnIdleTime = 30 * time.Minute
+	poolConfig.HealthCheckPeriod = time.Minute
+	poolConfig.ConnConfig.ConnectTimeout = 10 * time.Second
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %v", err)
+	}
+
+	// Ping the PostgreSQL server to verify connection
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return fmt.Errorf("failed to ping PostgreSQL server: %v", err)
+	}
+
+	db.mu.Lock()
+	previous := db.pool
+	db.pool = pool
+	db.mu.Unlock()
+	log.Info().Msg("Connected to PostgreSQL successfully")
+
+	if previous != nil {
+		previous.Close()
+	}
+	return nil
+}
+
+// Close closes the PostgreSQL connection pool
+func (db *PostgresDatabase) Close(ctx context.Context) error {
+	pool := db.GetPool()
+	if pool != nil {
+		log.Info().Msg("Closing PostgreSQL connection pool")
+		pool.Close()
+	}
+	return nil
+}
+
+// Ping verifies the connection to PostgreSQL
+func (db *PostgresDatabase) Ping(ctx context.Context) error {
+	pool := db.GetPool()
+	if pool == nil {
+		return fmt.Errorf("PostgreSQL connection pool not initialized")
+	}
+	return pool.Ping(ctx)
+}
+
+// GetInstance returns the PostgreSQL connection pool instance
+func (db *PostgresDatabase) GetInstance() interface{} {
+	return db.GetPool()
+}
+
+// GetPool returns the PostgreSQL connection pool
+func (db *PostgresDatabase) GetPool() *pgxpool.Pool {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.pool
+}
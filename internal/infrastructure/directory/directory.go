@@ -0,0 +1,22 @@
+package directory
+
+import (
+	"context"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+// Directory defines the interface of an upstream directory, the source of truth of the accounts of its users
+type Directory interface {
+	// Entries returns the user entries of the directory
+	Entries(ctx context.Context) ([]entity.DirectoryEntry, error)
+}
+
+// NewDirectory creates the configured directory, nil when none is configured
+func NewDirectory(cfg config.DirectoryConfig) Directory {
+	if cfg.LDAP.URL == "" {
+		return nil
+	}
+	return NewLDAP(cfg.LDAP)
+}
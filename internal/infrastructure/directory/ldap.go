@@ -0,0 +1,123 @@
+package directory
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// ldapPageSize is the number of entries requested per page, below the 1000 entries Active Directory
+	// returns at most
+	ldapPageSize = 500
+
+	// userAccountControlAttribute carries the flags of Active Directory accounts
+	userAccountControlAttribute = "userAccountControl"
+
+	// accountDisableFlag is the userAccountControl flag of disabled Active Directory accounts
+	accountDisableFlag = 0x2
+)
+
+// ldapDirectory reads the user entries of an LDAP or Active Directory server
+type ldapDirectory struct {
+	cfg config.LDAPConfig
+}
+
+// NewLDAP creates a new Directory reading an LDAP or Active Directory server
+func NewLDAP(cfg config.LDAPConfig) Directory {
+	return &ldapDirectory{
+		cfg: cfg,
+	}
+}
+
+// Entries searches the user entries of the directory, entries without an email are skipped
+func (d *ldapDirectory) Entries(ctx context.Context) ([]entity.DirectoryEntry, error) {
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// The search does not take a context, closing the connection aborts it
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	request := ldap.NewSearchRequest(
+		d.cfg.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		d.cfg.UserFilter,
+		[]string{d.cfg.EmailAttribute, d.cfg.UsernameAttribute, d.cfg.FirstNameAttribute, d.cfg.LastNameAttribute, userAccountControlAttribute},
+		nil,
+	)
+	result, err := conn.SearchWithPaging(request, ldapPageSize)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to search directory: %w", err)
+	}
+
+	entries := make([]entity.DirectoryEntry, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		email := entity.NormalizeEmail(e.GetAttributeValue(d.cfg.EmailAttribute))
+		if email == "" {
+			continue
+		}
+
+		entries = append(entries, entity.DirectoryEntry{
+			DN:        e.DN,
+			Email:     email,
+			Username:  strings.TrimSpace(e.GetAttributeValue(d.cfg.UsernameAttribute)),
+			FirstName: entity.NormalizeName(e.GetAttributeValue(d.cfg.FirstNameAttribute)),
+			LastName:  entity.NormalizeName(e.GetAttributeValue(d.cfg.LastNameAttribute)),
+			Disabled:  disabled(e.GetAttributeValue(userAccountControlAttribute)),
+		})
+	}
+	return entries, nil
+}
+
+// connect dials the server and binds as the service account
+func (d *ldapDirectory) connect() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(d.cfg.URL, ldap.DialWithDialer(&net.Dialer{Timeout: d.cfg.Timeout}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to directory: %w", err)
+	}
+	conn.SetTimeout(d.cfg.Timeout)
+
+	if d.cfg.StartTLS {
+		serverName := ""
+		if u, err := url.Parse(d.cfg.URL); err == nil {
+			serverName = u.Hostname()
+		}
+		if err := conn.StartTLS(&tls.Config{ServerName: serverName}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start TLS with directory: %w", err)
+		}
+	}
+
+	if d.cfg.BindDN != "" {
+		if err := conn.Bind(d.cfg.BindDN, d.cfg.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to bind to directory: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// disabled reports whether the userAccountControl flags of an Active Directory account disable it, entries of
+// other directories do not carry the attribute and are enabled
+func disabled(userAccountControl string) bool {
+	flags, err := strconv.ParseInt(userAccountControl, 10, 64)
+	if err != nil {
+		return false
+	}
+	return flags&accountDisableFlag != 0
+}
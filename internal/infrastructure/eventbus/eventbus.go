@@ -0,0 +1,49 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Bus defines the interface for delivering serialized events to their consumers
+type Bus interface {
+	// Publish delivers the JSON payload of an event
+	Publish(ctx context.Context, eventType string, payload []byte) error
+}
+
+// NewLogBus creates a bus logging events instead of delivering them, which is convenient in development
+func NewLogBus() Bus {
+	return &logBus{}
+}
+
+// logBus logs events instead of delivering them
+type logBus struct{}
+
+// Publish logs the event
+func (b *logBus) Publish(_ context.Context, eventType string, payload []byte) error {
+	log.Info().Str("event_type", eventType).RawJSON("event", payload).Msg("Event published")
+	return nil
+}
+
+// NewFanoutBus creates a bus publishing every event to each of the given buses
+func NewFanoutBus(buses ...Bus) Bus {
+	return &fanoutBus{buses: buses}
+}
+
+// fanoutBus publishes events to several buses
+type fanoutBus struct {
+	buses []Bus
+}
+
+// Publish publishes the event to every bus, a failing bus does not prevent delivery to the others
+func (b *fanoutBus) Publish(ctx context.Context, eventType string, payload []byte) error {
+	var errs []error
+	for _, bus := range b.buses {
+		if err := bus.Publish(ctx, eventType, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
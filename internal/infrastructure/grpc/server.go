@@ -0,0 +1,104 @@
+// Package grpc runs the gRPC server of the service from its configuration
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/chats/go-user-api/api/proto"
+	"github.com/chats/go-user-api/config"
+	"github.com/rs/zerolog/log"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// Server wraps a gRPC server configured with the message size limits, TLS and reflection settings
+type Server struct {
+	config config.GRPCConfig
+	server *grpclib.Server
+}
+
+// NewServer creates a new gRPC server running the interceptors in order, services are registered
+// on the server returned by GetServer before Start
+func NewServer(cfg config.GRPCConfig, unary []grpclib.UnaryServerInterceptor, stream []grpclib.StreamServerInterceptor) (*Server, error) {
+	// Refuse to serve contracts that do not match the registry of served versions
+	if err := proto.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid protobuf contracts: %w", err)
+	}
+
+	opts := []grpclib.ServerOption{
+		grpclib.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpclib.MaxSendMsgSize(cfg.MaxSendMsgSize),
+		grpclib.ChainUnaryInterceptor(unary...),
+		grpclib.ChainStreamInterceptor(stream...),
+	}
+
+	if cfg.UseTLS {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("GRPC_CERT_FILE and GRPC_KEY_FILE are required when TLS is enabled")
+		}
+		creds, err := credentials.NewServerTLSFromFile(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gRPC TLS credentials: %w", err)
+		}
+		opts = append(opts, grpclib.Creds(creds))
+	}
+
+	return &Server{
+		config: cfg,
+		server: grpclib.NewServer(opts...),
+	}, nil
+}
+
+// GetServer returns the gRPC server services are registered on
+func (s *Server) GetServer() *grpclib.Server {
+	return s.server
+}
+
+// PublicMethods returns the methods of the server itself callable without an access token, the reflection
+// methods when reflection is enabled
+func PublicMethods(cfg config.GRPCConfig) []string {
+	if !cfg.EnableReflection {
+		return nil
+	}
+	return []string{
+		reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+		reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+	}
+}
+
+// Start listens on the configured port and serves calls until the server is stopped
+func (s *Server) Start() error {
+	// Reflection is registered last so it lists every registered service
+	if s.config.EnableReflection {
+		reflection.Register(s.server)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port: %w", err)
+	}
+
+	log.Info().Int("port", s.config.Port).Bool("tls", s.config.UseTLS).Msg("Starting gRPC server")
+	return s.server.Serve(listener)
+}
+
+// Shutdown stops accepting calls and waits for the pending ones, until the context is done
+func (s *Server) Shutdown(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Warn().Msg("gRPC calls still pending at shutdown, stopping the server")
+		s.server.Stop()
+	}
+}
@@ -0,0 +1,153 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNotConfigured is returned when verifying a mailer without an SMTP host
+var ErrNotConfigured = errors.New("no SMTP host configured")
+
+// Mailer defines the interface for sending emails
+type Mailer interface {
+	// Send sends a plain text email, with an HTML alternative when htmlBody is not empty
+	Send(ctx context.Context, to, subject, body, htmlBody string) error
+
+	// Verify checks the SMTP server accepts a session, and the credentials when configured, without sending an email
+	Verify(ctx context.Context) error
+}
+
+// NewMailer creates a mailer from configuration.
+// Without an SMTP host, emails are only logged, which is convenient in development.
+func NewMailer(config config.MailerConfig) Mailer {
+	if config.Host == "" {
+		return &logMailer{}
+	}
+	return &smtpMailer{config: config}
+}
+
+// smtpMailer sends emails through an SMTP server
+type smtpMailer struct {
+	config config.MailerConfig
+}
+
+// Send sends a plain text email through the SMTP server, with an HTML alternative when htmlBody is not empty
+func (m *smtpMailer) Send(ctx context.Context, to, subject, body, htmlBody string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	contentType := "text/plain; charset=UTF-8"
+	if htmlBody != "" {
+		var err error
+		if contentType, body, err = alternative(body, htmlBody); err != nil {
+			return fmt.Errorf("failed to build email: %w", err)
+		}
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.config.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: " + contentType,
+		"",
+		body,
+	}, "\r\n")
+
+	addr := fmt.Sprintf("%s:%d", m.config.Host, m.config.Port)
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// Verify opens a session with the SMTP server the way Send does, then quits before sending anything
+func (m *smtpMailer) Verify(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", m.config.Host, m.config.Port))
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to greet SMTP server: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS with SMTP server: %w", err)
+		}
+	}
+	if m.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)); err != nil {
+				return fmt.Errorf("failed to authenticate with SMTP server: %w", err)
+			}
+		}
+	}
+
+	return client.Quit()
+}
+
+// alternative returns the content type and the body of a multipart/alternative message with the plain text
+// and HTML versions of a body, in order of preference of the mail clients
+func alternative(text, html string) (string, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return "", "", err
+		}
+		if _, err := w.Write([]byte(part.body)); err != nil {
+			return "", "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	return "multipart/alternative; boundary=" + writer.Boundary(), buf.String(), nil
+}
+
+// logMailer logs emails instead of sending them
+type logMailer struct{}
+
+// Send logs the email, the HTML alternative is only flagged to keep the logs readable
+func (m *logMailer) Send(_ context.Context, to, subject, body, htmlBody string) error {
+	log.Info().Str("to", to).Str("subject", subject).Str("body", body).Bool("html", htmlBody != "").Msg("Email not sent, no SMTP host configured")
+	return nil
+}
+
+// Verify returns ErrNotConfigured, there is no server to check
+func (m *logMailer) Verify(_ context.Context) error {
+	return ErrNotConfigured
+}
@@ -0,0 +1,192 @@
+package metrics
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "user_api"
+
+var (
+	// MongoCommandDuration tracks the latency of MongoDB commands
+	MongoCommandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "command_duration_seconds",
+		Help:      "Latency of MongoDB commands.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"command", "status"})
+
+	// MongoCommandErrors counts failed MongoDB commands
+	MongoCommandErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "command_errors_total",
+		Help:      "Number of failed MongoDB commands.",
+	}, []string{"command"})
+
+	// MongoPoolConnectionsOpen tracks the connections opened by the MongoDB pool
+	MongoPoolConnectionsOpen = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_connections_open",
+		Help:      "Number of open connections in the MongoDB pool.",
+	})
+
+	// MongoPoolConnectionsInUse tracks the connections checked out of the MongoDB pool
+	MongoPoolConnectionsInUse = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_connections_in_use",
+		Help:      "Number of MongoDB connections currently checked out.",
+	})
+
+	// MongoPoolCheckouts counts connection checkouts by result
+	MongoPoolCheckouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_checkouts_total",
+		Help:      "Number of MongoDB connection checkouts.",
+	}, []string{"result"})
+
+	// MongoPoolCheckoutDuration tracks how long callers wait for a connection
+	MongoPoolCheckoutDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_checkout_duration_seconds",
+		Help:      "Time spent waiting for a MongoDB connection.",
+		Buckets:   []float64{.0005, .001, .005, .01, .05, .1, .5, 1, 5},
+	})
+
+	// MongoPoolCleared counts pool clears caused by server errors
+	MongoPoolCleared = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "pool_cleared_total",
+		Help:      "Number of times the MongoDB pool was cleared.",
+	})
+
+	// WatchdogUp reports whether a watched dependency currently answers pings
+	WatchdogUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "watchdog",
+		Name:      "up",
+		Help:      "Whether the watched dependency answers pings (1) or not (0).",
+	}, []string{"component"})
+
+	// WatchdogReconnects counts client rebuilds attempted by the watchdogs
+	WatchdogReconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "watchdog",
+		Name:      "reconnects_total",
+		Help:      "Number of client reconnections attempted by the watchdogs.",
+	}, []string{"component", "result"})
+
+	// PolicyViolations counts the requests exceeding a rate limit or lockout policy
+	PolicyViolations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "policy",
+		Name:      "violations_total",
+		Help:      "Number of requests exceeding a rate limit or lockout policy, rejected (enforce) or only recorded (shadow).",
+	}, []string{"policy", "mode"})
+
+	// WebhookDeliveryAttempts counts the attempts to deliver events to webhook endpoints
+	WebhookDeliveryAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "delivery_attempts_total",
+		Help:      "Number of webhook delivery attempts by result (delivered, retry, dead).",
+	}, []string{"result"})
+
+	// SIEMDeliveries counts the security events sent to the SIEM
+	SIEMDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "siem",
+		Name:      "events_total",
+		Help:      "Number of security events sent to the SIEM by result (delivered, failed).",
+	}, []string{"result"})
+
+	// TokenValidationCache counts the lookups of the in-process token validation cache
+	TokenValidationCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "token_validation_cache",
+		Name:      "lookups_total",
+		Help:      "Number of access token state lookups by result (hit, miss).",
+	}, []string{"result"})
+
+	// UserCacheStaleReads counts the users served from the cache past their expiration while the database failed
+	UserCacheStaleReads = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "user_cache",
+		Name:      "stale_reads_total",
+		Help:      "Number of users served from the cache past their expiration because the database failed.",
+	})
+
+	// PasswordBreachChecks counts the lookups of chosen passwords in the corpus of breached passwords
+	PasswordBreachChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "password_breach_check",
+		Name:      "lookups_total",
+		Help:      "Number of password breach lookups by result (clean, breached, error).",
+	}, []string{"result"})
+
+	// BusinessUsers tracks the users by status and role, as of the latest KPI aggregation
+	BusinessUsers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "users",
+		Help:      "Number of users by status and role.",
+	}, []string{"status", "role"})
+
+	// BusinessActiveUsers tracks the active users
+	BusinessActiveUsers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "active_users",
+		Help:      "Number of active users.",
+	})
+
+	// BusinessDailySignups tracks the users created in the last 24 hours
+	BusinessDailySignups = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "daily_signups",
+		Help:      "Number of users created in the 24 hours before the aggregation.",
+	})
+
+	// BusinessPasskeyUsers tracks the users holding a passkey
+	BusinessPasskeyUsers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "passkey_users",
+		Help:      "Number of users holding at least one passkey.",
+	})
+
+	// BusinessPasskeyAdoption tracks the share of active users holding a passkey
+	BusinessPasskeyAdoption = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "passkey_adoption_ratio",
+		Help:      "Number of users holding a passkey over the number of active users.",
+	})
+
+	// BusinessAggregatedAt tracks when the business metrics were aggregated, to alert on stale values
+	BusinessAggregatedAt = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "aggregated_timestamp_seconds",
+		Help:      "Unix time of the aggregation the business metrics come from.",
+	})
+)
+
+// Handler returns a handler exposing the registered metrics in the Prometheus text format, or the OpenMetrics
+// format to the scrapers asking for it
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	))
+}
@@ -0,0 +1,91 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+const (
+	githubAuthURL   = "https://github.com/login/oauth/authorize"
+	githubTokenURL  = "https://github.com/login/oauth/access_token"
+	githubUserURL   = "https://api.github.com/user"
+	githubEmailsURL = "https://api.github.com/user/emails"
+	githubScope     = "read:user user:email"
+)
+
+// githubProvider signs users in with their GitHub account
+type githubProvider struct {
+	client      *http.Client
+	credentials config.OAuthProviderConfig
+}
+
+func newGitHubProvider(client *http.Client, credentials config.OAuthProviderConfig) *githubProvider {
+	return &githubProvider{
+		client:      client,
+		credentials: credentials,
+	}
+}
+
+// Name returns the name of the provider
+func (p *githubProvider) Name() string {
+	return ProviderGitHub
+}
+
+// AuthCodeURL returns the URL of the GitHub consent page
+func (p *githubProvider) AuthCodeURL(state, codeChallenge, redirectURI string) string {
+	return authCodeURL(githubAuthURL, p.credentials.ClientID, githubScope, state, codeChallenge, redirectURI)
+}
+
+// Exchange exchanges an authorization code and returns the profile of the user with their primary email.
+// The public email of a GitHub profile is not verified, so the email comes from the email addresses instead.
+func (p *githubProvider) Exchange(ctx context.Context, code, codeVerifier, redirectURI string) (*entity.OAuthProfile, error) {
+	accessToken, err := exchangeCode(ctx, p.client, githubTokenURL, p.credentials, code, codeVerifier, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := getJSON(ctx, p.client, githubUserURL, accessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, errors.New("github user has no ID")
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, p.client, githubEmailsURL, accessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	// GitHub profiles have a single name, its first word is taken as the first name
+	firstName, lastName, _ := strings.Cut(strings.TrimSpace(user.Name), " ")
+	profile := &entity.OAuthProfile{
+		Provider:  ProviderGitHub,
+		Subject:   strconv.FormatInt(user.ID, 10),
+		FirstName: firstName,
+		LastName:  strings.TrimSpace(lastName),
+		Username:  user.Login,
+	}
+	for _, email := range emails {
+		if email.Primary {
+			profile.Email = email.Email
+			profile.EmailVerified = email.Verified
+			break
+		}
+	}
+	return profile, nil
+}
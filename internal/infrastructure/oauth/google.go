@@ -0,0 +1,71 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+const (
+	googleAuthURL     = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	googleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+	googleScope       = "openid email profile"
+)
+
+// googleProvider signs users in with their Google account
+type googleProvider struct {
+	client      *http.Client
+	credentials config.OAuthProviderConfig
+}
+
+func newGoogleProvider(client *http.Client, credentials config.OAuthProviderConfig) *googleProvider {
+	return &googleProvider{
+		client:      client,
+		credentials: credentials,
+	}
+}
+
+// Name returns the name of the provider
+func (p *googleProvider) Name() string {
+	return ProviderGoogle
+}
+
+// AuthCodeURL returns the URL of the Google consent page
+func (p *googleProvider) AuthCodeURL(state, codeChallenge, redirectURI string) string {
+	return authCodeURL(googleAuthURL, p.credentials.ClientID, googleScope, state, codeChallenge, redirectURI)
+}
+
+// Exchange exchanges an authorization code and returns the OpenID Connect profile of the user
+func (p *googleProvider) Exchange(ctx context.Context, code, codeVerifier, redirectURI string) (*entity.OAuthProfile, error) {
+	accessToken, err := exchangeCode(ctx, p.client, googleTokenURL, p.credentials, code, codeVerifier, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var userInfo struct {
+		Subject       string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		GivenName     string `json:"given_name"`
+		FamilyName    string `json:"family_name"`
+	}
+	if err := getJSON(ctx, p.client, googleUserInfoURL, accessToken, &userInfo); err != nil {
+		return nil, err
+	}
+	if userInfo.Subject == "" {
+		return nil, errors.New("google user info has no subject")
+	}
+
+	return &entity.OAuthProfile{
+		Provider:      ProviderGoogle,
+		Subject:       userInfo.Subject,
+		Email:         userInfo.Email,
+		EmailVerified: userInfo.EmailVerified,
+		FirstName:     userInfo.GivenName,
+		LastName:      userInfo.FamilyName,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+// Provider names, as they appear in the sign in URLs
+const (
+	ProviderGoogle = "google"
+	ProviderGitHub = "github"
+)
+
+// requestTimeout bounds every request to a provider, a user is waiting on the callback
+const requestTimeout = 10 * time.Second
+
+// ErrInvalidCode is returned when a provider rejects an authorization code, it was already used, expired or
+// issued to another client
+var ErrInvalidCode = errors.New("authorization code rejected by the provider")
+
+// Provider defines the interface of an external OAuth2 provider users sign in with
+type Provider interface {
+	// Name returns the name of the provider
+	Name() string
+
+	// AuthCodeURL returns the URL of the consent page of the provider, the user is redirected to it to sign in
+	AuthCodeURL(state, codeChallenge, redirectURI string) string
+
+	// Exchange exchanges an authorization code for a token and returns the profile of the signed in user
+	Exchange(ctx context.Context, code, codeVerifier, redirectURI string) (*entity.OAuthProfile, error)
+}
+
+// NewProviders creates the providers configured with a client ID, by name
+func NewProviders(cfg config.OAuthConfig) map[string]Provider {
+	client := &http.Client{Timeout: requestTimeout}
+
+	providers := map[string]Provider{}
+	if cfg.Google.ClientID != "" {
+		providers[ProviderGoogle] = newGoogleProvider(client, cfg.Google)
+	}
+	if cfg.GitHub.ClientID != "" {
+		providers[ProviderGitHub] = newGitHubProvider(client, cfg.GitHub)
+	}
+	return providers
+}
+
+// CodeChallenge returns the S256 PKCE challenge of a code verifier
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// authCodeURL builds the URL of an authorization request with PKCE
+func authCodeURL(endpoint, clientID, scope, state, codeChallenge, redirectURI string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {scope},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	return endpoint + "?" + query.Encode()
+}
+
+// exchangeCode redeems an authorization code at the token endpoint of a provider and returns the access token
+func exchangeCode(ctx context.Context, client *http.Client, endpoint string, credentials config.OAuthProviderConfig, code, codeVerifier, redirectURI string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {credentials.ClientID},
+		"client_secret": {credentials.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := doJSON(client, req, &token)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	// Rejected codes are answered with an error, with a 200 status by some providers
+	if token.Error != "" {
+		return "", fmt.Errorf("%w: %s %s", ErrInvalidCode, token.Error, token.ErrorDescription)
+	}
+	if status != http.StatusOK || token.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint responded with status %d", status)
+	}
+	return token.AccessToken, nil
+}
+
+// getJSON gets a resource of the signed in user from the API of a provider
+func getJSON(ctx context.Context, client *http.Client, endpoint, accessToken string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	status, err := doJSON(client, req, target)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", endpoint, err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("%s responded with status %d", endpoint, status)
+	}
+	return nil
+}
+
+// doJSON sends a request and decodes the JSON response body into target, returning the response status code
+func doJSON(client *http.Client, req *http.Request, target any) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Error responses may not be JSON, only the status code tells then
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(target); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,81 @@
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Checker defines the interface for looking passwords up in a corpus of breached passwords
+type Checker interface {
+	// Count returns how many times a password appeared in data breaches, zero if it never did
+	Count(ctx context.Context, password string) (int, error)
+}
+
+// NewChecker creates a checker querying the Have I Been Pwned range API at baseURL. Only the first 5 characters
+// of the SHA-1 hash of a password leave the process (k-anonymity), and responses are padded so their size does
+// not reveal the prefix either.
+func NewChecker(baseURL, userAgent string, timeout time.Duration) Checker {
+	return &rangeChecker{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// rangeChecker queries the range API over HTTP
+type rangeChecker struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+}
+
+// Count returns how many times a password appeared in data breaches
+func (c *rangeChecker) Count(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create range request: %w", err)
+	}
+	req.Header.Set("Add-Padding", "true")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query range API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return 0, fmt.Errorf("range API responded with status %d", resp.StatusCode)
+	}
+
+	// Each line is "SUFFIX:COUNT", the padding lines have a count of 0
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, 4<<20))
+	for scanner.Scan() {
+		line, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(line, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, fmt.Errorf("invalid count in range response: %w", err)
+		}
+		return n, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read range response: %w", err)
+	}
+	return 0, nil
+}
@@ -0,0 +1,132 @@
+package querybudget
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// contextKey is the key of the budget of a request in its context
+type contextKey struct{}
+
+// Budget counts the queries a request makes and the time they take, against a maximum of each. Queries are the
+// repository operations, which read the cache or the database.
+type Budget struct {
+	maxQueries  int
+	maxDuration time.Duration
+
+	mu       sync.Mutex
+	queries  int
+	duration time.Duration
+	calls    map[callKey]*Call
+}
+
+// callKey identifies the queries of the same operation made from the same call site
+type callKey struct {
+	site      string
+	operation string
+}
+
+// Call sums up the queries of an operation made from a call site
+type Call struct {
+	Site      string
+	Operation string
+	Count     int
+	Duration  time.Duration
+}
+
+// New creates a budget of at most maxQueries queries taking maxDuration in total, unlimited at 0
+func New(maxQueries int, maxDuration time.Duration) *Budget {
+	return &Budget{
+		maxQueries:  maxQueries,
+		maxDuration: maxDuration,
+		calls:       make(map[callKey]*Call),
+	}
+}
+
+// Attach sets the budget of a request, found by FromContext in the contexts derived from the request context
+func Attach(ctx *fasthttp.RequestCtx, b *Budget) {
+	ctx.SetUserValue(contextKey{}, b)
+}
+
+// FromContext returns the budget of the request of a context, nil outside requests or when budgets are disabled
+func FromContext(ctx context.Context) *Budget {
+	b, _ := ctx.Value(contextKey{}).(*Budget)
+	return b
+}
+
+// Record counts a query of an operation made from a call site
+func (b *Budget) Record(site, operation string, elapsed time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.queries++
+	b.duration += elapsed
+
+	key := callKey{site: site, operation: operation}
+	call, ok := b.calls[key]
+	if !ok {
+		call = &Call{Site: site, Operation: operation}
+		b.calls[key] = call
+	}
+	call.Count++
+	call.Duration += elapsed
+}
+
+// Queries returns the number of queries made and the time they took
+func (b *Budget) Queries() (int, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.queries, b.duration
+}
+
+// Exceeded reports whether the queries made exceed either maximum
+func (b *Budget) Exceeded() bool {
+	queries, duration := b.Queries()
+	return (b.maxQueries > 0 && queries > b.maxQueries) || (b.maxDuration > 0 && duration > b.maxDuration)
+}
+
+// Report lists the queries made by call site and operation, the most repeated first, formatted for the logs
+func (b *Budget) Report() []string {
+	b.mu.Lock()
+	calls := make([]Call, 0, len(b.calls))
+	for _, call := range b.calls {
+		calls = append(calls, *call)
+	}
+	b.mu.Unlock()
+
+	slices.SortFunc(calls, func(a, b Call) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(b.Duration, a.Duration), strings.Compare(a.Site, b.Site))
+	})
+
+	report := make([]string, 0, len(calls))
+	for _, call := range calls {
+		report = append(report, fmt.Sprintf("%s %s x%d %s", call.Site, call.Operation, call.Count, call.Duration))
+	}
+	return report
+}
+
+// CallSite returns the file, line and function of the first caller outside a package, the code calling into the
+// repositories rather than their decorators
+func CallSite(pkgPath string) string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPath+".") {
+			name := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+			return fmt.Sprintf("%s/%s:%d %s", filepath.Base(filepath.Dir(frame.File)), filepath.Base(frame.File), frame.Line, name)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
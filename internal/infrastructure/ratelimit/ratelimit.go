@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+)
+
+const keyPrefix = "ratelimit:"
+
+// Result describes the state of a budget after a request
+type Result struct {
+	Limit     int
+	Count     int // Requests made in the current window
+	Remaining int
+	Reset     time.Time
+	Allowed   bool
+}
+
+// RetryAfter returns the time left until the budget resets
+func (r Result) RetryAfter() time.Duration {
+	if d := time.Until(r.Reset); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Limiter defines fixed window rate limiting operations
+type Limiter interface {
+	// Allow consumes one request from the budget identified by key
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+
+	// Peek returns the state of the budget identified by key without consuming it
+	Peek(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+
+	// Reset restores the full budget identified by key for the current window
+	Reset(ctx context.Context, key string, window time.Duration) error
+}
+
+type limiter struct {
+	cache cache.Cache
+}
+
+// NewLimiter creates a new limiter sharing its counters through the cache
+func NewLimiter(cache cache.Cache) Limiter {
+	return &limiter{
+		cache: cache,
+	}
+}
+
+// Allow consumes one request from the budget identified by key
+func (l *limiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	windowKey, reset := windowOf(key, window)
+
+	count, err := l.cache.Increment(ctx, windowKey, 1, window)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to increment rate limit counter: %w", err)
+	}
+
+	return newResult(limit, count, reset), nil
+}
+
+// Peek returns the state of the budget identified by key without consuming it
+func (l *limiter) Peek(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	windowKey, reset := windowOf(key, window)
+
+	data, err := l.cache.Get(ctx, windowKey)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to get rate limit counter: %w", err)
+	}
+
+	var count int64
+	if data != nil {
+		count, err = strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to parse rate limit counter: %w", err)
+		}
+	}
+
+	result := newResult(limit, count, reset)
+	result.Allowed = count < int64(limit)
+	return result, nil
+}
+
+// Reset restores the full budget identified by key for the current window
+func (l *limiter) Reset(ctx context.Context, key string, window time.Duration) error {
+	windowKey, _ := windowOf(key, window)
+
+	if err := l.cache.Delete(ctx, windowKey); err != nil {
+		return fmt.Errorf("failed to delete rate limit counter: %w", err)
+	}
+	return nil
+}
+
+// windowOf returns the counter key of the current window and the time it resets
+func windowOf(key string, window time.Duration) (string, time.Time) {
+	start := time.Now().Truncate(window)
+	return fmt.Sprintf("%s%s:%d", keyPrefix, key, start.Unix()), start.Add(window)
+}
+
+// newResult builds the result of a budget after count requests
+func newResult(limit int, count int64, reset time.Time) Result {
+	remaining := int64(limit) - count
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return Result{
+		Limit:     limit,
+		Count:     int(count),
+		Remaining: int(remaining),
+		Reset:     reset,
+		Allowed:   count <= int64(limit),
+	}
+}
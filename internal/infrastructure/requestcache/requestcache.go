@@ -0,0 +1,56 @@
+package requestcache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/valyala/fasthttp"
+)
+
+// contextKey is the key of the cache of a request in its context
+type contextKey struct{}
+
+// Cache keeps the entities read during a request, so the repeated lookups of an entity within the request are
+// served from memory. It lives as long as the request.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]any
+}
+
+// New creates an empty cache
+func New() *Cache {
+	return &Cache{entries: make(map[string]any)}
+}
+
+// Attach sets the cache of a request, found by FromContext in the contexts derived from the request context
+func Attach(ctx *fasthttp.RequestCtx, c *Cache) {
+	ctx.SetUserValue(contextKey{}, c)
+}
+
+// FromContext returns the cache of the request of a context, nil outside requests
+func FromContext(ctx context.Context) *Cache {
+	c, _ := ctx.Value(contextKey{}).(*Cache)
+	return c
+}
+
+// Get returns the entity cached under a key
+func (c *Cache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.entries[key]
+	return value, ok
+}
+
+// Set caches an entity under a key
+func (c *Cache) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+}
+
+// Delete drops the entity cached under a key, once it changed
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
@@ -0,0 +1,77 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotConfigured is returned when writing a secret without a secrets backend
+var ErrNotConfigured = errors.New("secrets backend not configured")
+
+// Store defines the interface for the secrets backend holding the sealed credentials, out of the reach of the
+// service's database and API
+type Store interface {
+	// Put stores a secret under a name, replacing its previous value
+	Put(ctx context.Context, name string, value []byte) error
+}
+
+// NewStore creates a store writing each secret to a file of dir, meant to be a mounted volume synchronized with
+// the secrets manager of the platform. Without a directory, every write fails with ErrNotConfigured.
+func NewStore(dir string) Store {
+	if dir == "" {
+		return &unconfiguredStore{}
+	}
+	return &fileStore{dir: dir}
+}
+
+// fileStore writes secrets to files readable by their owner only
+type fileStore struct {
+	dir string
+}
+
+// Put writes a secret to a temporary file renamed over the previous one, so readers never see a partial secret
+func (s *fileStore) Put(ctx context.Context, name string, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid secret name %q", name)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+	file, err := os.CreateTemp(s.dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create secret file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(value); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write secret: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write secret: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write secret: %w", err)
+	}
+	if err := os.Rename(file.Name(), filepath.Join(s.dir, name)); err != nil {
+		return fmt.Errorf("failed to replace secret: %w", err)
+	}
+	return nil
+}
+
+// unconfiguredStore rejects every write, so credentials are never rotated into a secret nobody can read
+type unconfiguredStore struct{}
+
+// Put fails with ErrNotConfigured
+func (s *unconfiguredStore) Put(ctx context.Context, name string, value []byte) error {
+	return ErrNotConfigured
+}
@@ -0,0 +1,118 @@
+package siem
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+// Formats of the security events
+const (
+	FormatCEF  = "cef"
+	FormatJSON = "json"
+)
+
+// formatter serializes a security event
+type formatter interface {
+	Format(event *entity.SecurityEvent) ([]byte, error)
+
+	// ContentType is the media type of the formatted events posted over HTTP, one per line
+	ContentType() string
+}
+
+// newFormatter creates the formatter of a format
+func newFormatter(format, appName string) (formatter, error) {
+	switch format {
+	case FormatCEF:
+		return &cefFormatter{product: appName}, nil
+	case FormatJSON:
+		return &jsonFormatter{product: appName}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SIEM format %q", format)
+	}
+}
+
+// jsonFormatter serializes events as JSON objects
+type jsonFormatter struct {
+	product string
+}
+
+// jsonEvent is a security event as serialized in JSON, tagged with the product raising it
+type jsonEvent struct {
+	Product string `json:"product"`
+	*entity.SecurityEvent
+}
+
+// Format serializes an event as a JSON object
+func (f *jsonFormatter) Format(event *entity.SecurityEvent) ([]byte, error) {
+	body, err := json.Marshal(jsonEvent{Product: f.product, SecurityEvent: event})
+	if err != nil {
+		return nil, fmt.Errorf("failed to format security event: %w", err)
+	}
+	return body, nil
+}
+
+// ContentType is newline delimited JSON
+func (f *jsonFormatter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+// cefFormatter serializes events in the ArcSight Common Event Format
+type cefFormatter struct {
+	product string
+}
+
+// Format serializes an event as a CEF line, the details are carried by the msg extension as sorted key=value pairs
+func (f *cefFormatter) Format(event *entity.SecurityEvent) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("CEF:0|")
+	b.WriteString(cefHeader(f.product))
+	b.WriteString("|")
+	b.WriteString(cefHeader(f.product))
+	b.WriteString("|1.0|")
+	b.WriteString(cefHeader(event.Type))
+	b.WriteString("|")
+	b.WriteString(cefHeader(event.Type))
+	b.WriteString("|")
+	b.WriteString(strconv.Itoa(event.Severity))
+	b.WriteString("|")
+
+	b.WriteString("rt=" + strconv.FormatInt(event.OccurredAt.UnixMilli(), 10))
+	b.WriteString(" externalId=" + event.ID.String())
+	b.WriteString(" suid=" + event.ActorID.String())
+	b.WriteString(" duid=" + event.TargetID.String())
+	if len(event.Details) > 0 {
+		keys := make([]string, 0, len(event.Details))
+		for key := range event.Details {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, key+"="+event.Details[key])
+		}
+		b.WriteString(" msg=" + cefExtension(strings.Join(pairs, " ")))
+	}
+	return []byte(b.String()), nil
+}
+
+// ContentType is plain text
+func (f *cefFormatter) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// cefHeader escapes a header field of CEF
+func cefHeader(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
+
+// cefExtension escapes an extension value of CEF
+func cefExtension(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "=", `\=`, "\r", `\r`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,178 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
+)
+
+// Sinks the security events can be delivered to
+const (
+	SinkSyslog = "syslog"
+	SinkHTTP   = "http"
+)
+
+// syslogFacility is the security/authorization facility (authpriv) of RFC 5424
+const syslogFacility = 10
+
+// Sink defines the interface for delivering security events to a SIEM
+type Sink interface {
+	// Send delivers a batch of events. An error means the batch must be sent again, the collector
+	// deduplicates on the event IDs.
+	Send(ctx context.Context, events []*entity.SecurityEvent) error
+}
+
+// NewSink creates the sink configured for the SIEM, formatting the events of appName
+func NewSink(cfg config.SIEMConfig, appName string) (Sink, error) {
+	formatter, err := newFormatter(cfg.Format, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Sink {
+	case SinkSyslog:
+		if cfg.SyslogNetwork != "udp" && cfg.SyslogNetwork != "tcp" {
+			return nil, fmt.Errorf("unsupported syslog network %q", cfg.SyslogNetwork)
+		}
+		if cfg.SyslogAddress == "" {
+			return nil, fmt.Errorf("SIEM_SYSLOG_ADDRESS is required by the syslog sink")
+		}
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "-"
+		}
+		return &syslogSink{
+			network:   cfg.SyslogNetwork,
+			address:   cfg.SyslogAddress,
+			timeout:   cfg.Timeout,
+			hostname:  hostname,
+			appName:   appName,
+			formatter: formatter,
+		}, nil
+	case SinkHTTP:
+		if cfg.HTTPURL == "" {
+			return nil, fmt.Errorf("SIEM_HTTP_URL is required by the http sink")
+		}
+		return &httpSink{
+			client:    &http.Client{Timeout: cfg.Timeout},
+			url:       cfg.HTTPURL,
+			token:     cfg.HTTPToken,
+			formatter: formatter,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SIEM sink %q", cfg.Sink)
+	}
+}
+
+// syslogSink sends events as RFC 5424 messages, over a connection opened for each batch
+type syslogSink struct {
+	network   string
+	address   string
+	timeout   time.Duration
+	hostname  string
+	appName   string
+	formatter formatter
+}
+
+// Send writes one syslog message per event. Over TCP the messages are framed with their length (RFC 6587).
+func (s *syslogSink) Send(ctx context.Context, events []*entity.SecurityEvent) error {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog collector: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else if s.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	for _, event := range events {
+		body, err := s.formatter.Format(event)
+		if err != nil {
+			return err
+		}
+		message := fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+			syslogFacility*8+syslogSeverity(event.Severity),
+			event.OccurredAt.UTC().Format(time.RFC3339Nano),
+			s.hostname,
+			s.appName,
+			event.Type,
+			body,
+		)
+		if s.network == "tcp" {
+			message = strconv.Itoa(len(message)) + " " + message
+		}
+		if _, err := io.WriteString(conn, message); err != nil {
+			return fmt.Errorf("failed to write to syslog collector: %w", err)
+		}
+	}
+	return nil
+}
+
+// syslogSeverity maps a CEF severity to the syslog one
+func syslogSeverity(severity int) int {
+	switch {
+	case severity >= entity.SecuritySeverityHigh:
+		return 2 // Critical
+	case severity >= entity.SecuritySeverityMedium:
+		return 4 // Warning
+	default:
+		return 5 // Notice
+	}
+}
+
+// httpSink posts events to a collector, one per line
+type httpSink struct {
+	client    *http.Client
+	url       string
+	token     string
+	formatter formatter
+}
+
+// Send posts a batch of events, any status outside 2xx is an error
+func (s *httpSink) Send(ctx context.Context, events []*entity.SecurityEvent) error {
+	var body bytes.Buffer
+	for _, event := range events {
+		line, err := s.formatter.Format(event)
+		if err != nil {
+			return err
+		}
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create SIEM request: %w", err)
+	}
+	req.Header.Set("Content-Type", s.formatter.ContentType())
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post security events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("SIEM collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
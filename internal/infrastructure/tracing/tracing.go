@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/rs/zerolog/log"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contextKey is the key of the span of a request in its context
+type contextKey struct{}
+
+// Setup registers a tracer provider exporting the spans to the configured OTLP/HTTP collector, along with the W3C
+// trace context propagator. It returns nil when tracing is disabled, leaving the default no-op provider.
+func Setup(ctx context.Context, cfg config.TracingConfig) (*sdktrace.TracerProvider, error) {
+	if !cfg.Enabled {
+		log.Info().Msg("Tracing is disabled")
+		return nil, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %v", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	log.Info().
+		Str("endpoint", cfg.Endpoint).
+		Str("service", cfg.ServiceName).
+		Float64("sample_ratio", cfg.SampleRatio).
+		Msg("Tracing initialized")
+
+	return tp, nil
+}
+
+// Shutdown flushes the spans not exported yet and stops the tracer provider
+func Shutdown(ctx context.Context, tp *sdktrace.TracerProvider) {
+	if tp == nil {
+		return
+	}
+
+	if err := tp.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to shut down tracer provider")
+	}
+}
+
+// Attach sets the span of a request, the parent of the spans started from the contexts derived from the request
+// context
+func Attach(ctx *fasthttp.RequestCtx, span trace.Span) {
+	ctx.SetUserValue(contextKey{}, span)
+}
+
+// Context returns a context carrying the span of its request. The request context of fasthttp cannot carry the
+// span the way trace.ContextWithSpan does, so the span attached to it is set on the returned context unless the
+// context already carries one.
+func Context(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	span, ok := ctx.Value(contextKey{}).(trace.Span)
+	if !ok {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, span)
+}
@@ -0,0 +1,111 @@
+package watchdog
+
+import (
+	"context"
+	"time"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/infrastructure/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+// Target is a connection the watchdog keeps alive
+type Target interface {
+	// Connect establishes a connection, replacing the current one
+	Connect(ctx context.Context) error
+
+	// Ping verifies the connection
+	Ping(ctx context.Context) error
+}
+
+// Watchdog pings a target periodically and rebuilds its client after persistent failures
+type Watchdog struct {
+	name   string
+	target Target
+	config config.WatchdogConfig
+}
+
+// New creates a new watchdog for a target
+func New(name string, target Target, config config.WatchdogConfig) *Watchdog {
+	return &Watchdog{
+		name:   name,
+		target: target,
+		config: config,
+	}
+}
+
+// Run watches the target until the context is cancelled
+func (w *Watchdog) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	metrics.WatchdogUp.WithLabelValues(w.name).Set(1)
+	failures := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := w.ping(ctx); err != nil {
+			failures++
+			metrics.WatchdogUp.WithLabelValues(w.name).Set(0)
+			log.Warn().Err(err).Str("component", w.name).Int("failures", failures).Msg("Watchdog ping failed")
+
+			if failures >= w.config.FailureThreshold {
+				w.reconnect(ctx)
+				failures = 0
+			}
+			continue
+		}
+
+		if failures > 0 {
+			log.Info().Str("component", w.name).Msg("Watchdog ping recovered")
+		}
+		failures = 0
+		metrics.WatchdogUp.WithLabelValues(w.name).Set(1)
+	}
+}
+
+// ping pings the target with the configured timeout
+func (w *Watchdog) ping(ctx context.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx, w.config.PingTimeout)
+	defer cancel()
+	return w.target.Ping(pingCtx)
+}
+
+// reconnect rebuilds the target client, retrying with exponential backoff until it succeeds or the context is cancelled
+func (w *Watchdog) reconnect(ctx context.Context) {
+	backoff := w.config.Interval
+
+	for attempt := 1; ; attempt++ {
+		log.Warn().Str("component", w.name).Int("attempt", attempt).Msg("Watchdog reconnecting client")
+
+		connectCtx, cancel := context.WithTimeout(ctx, w.config.Interval+w.config.PingTimeout)
+		err := w.target.Connect(connectCtx)
+		cancel()
+
+		if err == nil {
+			metrics.WatchdogReconnects.WithLabelValues(w.name, "success").Inc()
+			metrics.WatchdogUp.WithLabelValues(w.name).Set(1)
+			log.Info().Str("component", w.name).Int("attempt", attempt).Msg("Watchdog reconnected client")
+			return
+		}
+
+		metrics.WatchdogReconnects.WithLabelValues(w.name, "failed").Inc()
+		log.Error().Err(err).Str("component", w.name).Int("attempt", attempt).Dur("retry_in", backoff).Msg("Watchdog failed to reconnect client")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > w.config.MaxBackoff {
+			backoff = w.config.MaxBackoff
+		}
+	}
+}
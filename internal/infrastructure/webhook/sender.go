@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of "<timestamp>.<body>" keyed with the endpoint secret
+	SignatureHeader = "X-Webhook-Signature"
+
+	// TimestampHeader carries the Unix time the delivery was signed at, so receivers can reject replays
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	// IDHeader carries the ID of the delivery, stable across retries so receivers can deduplicate
+	IDHeader = "X-Webhook-ID"
+)
+
+// Sender defines the interface for posting events to webhook endpoints
+type Sender interface {
+	// Send posts a signed payload to an endpoint, returning the response status code.
+	// Any status outside 2xx is an error.
+	Send(ctx context.Context, url, secret, deliveryID string, payload []byte) (int, error)
+}
+
+// NewSender creates a sender posting with the given timeout per attempt
+func NewSender(timeout time.Duration) Sender {
+	return &httpSender{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// httpSender posts events over HTTP
+type httpSender struct {
+	client *http.Client
+}
+
+// Send posts a signed payload to an endpoint
+func (s *httpSender) Send(ctx context.Context, url, secret, deliveryID string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, deliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of a payload signed at a timestamp
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
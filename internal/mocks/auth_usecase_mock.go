@@ -12,8 +12,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/chats/go-user-api/internal/domain/entity"
+	service "github.com/chats/go-user-api/internal/domain/service"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,33 +44,209 @@ func (m *MockAuthUseCase) EXPECT() *MockAuthUseCaseMockRecorder {
 	return m.recorder
 }
 
+// BeginPasskeyLogin mocks base method.
+func (m *MockAuthUseCase) BeginPasskeyLogin(ctx context.Context) (*entity.PasskeyCeremonyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginPasskeyLogin", ctx)
+	ret0, _ := ret[0].(*entity.PasskeyCeremonyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginPasskeyLogin indicates an expected call of BeginPasskeyLogin.
+func (mr *MockAuthUseCaseMockRecorder) BeginPasskeyLogin(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginPasskeyLogin", reflect.TypeOf((*MockAuthUseCase)(nil).BeginPasskeyLogin), ctx)
+}
+
+// BeginPasskeyRegistration mocks base method.
+func (m *MockAuthUseCase) BeginPasskeyRegistration(ctx context.Context, userID uuid.UUID) (*entity.PasskeyCeremonyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginPasskeyRegistration", ctx, userID)
+	ret0, _ := ret[0].(*entity.PasskeyCeremonyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginPasskeyRegistration indicates an expected call of BeginPasskeyRegistration.
+func (mr *MockAuthUseCaseMockRecorder) BeginPasskeyRegistration(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginPasskeyRegistration", reflect.TypeOf((*MockAuthUseCase)(nil).BeginPasskeyRegistration), ctx, userID)
+}
+
+// ChangeRequiredPassword mocks base method.
+func (m *MockAuthUseCase) ChangeRequiredPassword(ctx context.Context, token, newPassword string) (*entity.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRequiredPassword", ctx, token, newPassword)
+	ret0, _ := ret[0].(*entity.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeRequiredPassword indicates an expected call of ChangeRequiredPassword.
+func (mr *MockAuthUseCaseMockRecorder) ChangeRequiredPassword(ctx, token, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRequiredPassword", reflect.TypeOf((*MockAuthUseCase)(nil).ChangeRequiredPassword), ctx, token, newPassword)
+}
+
+// ConfirmEmailVerification mocks base method.
+func (m *MockAuthUseCase) ConfirmEmailVerification(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailVerification", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailVerification indicates an expected call of ConfirmEmailVerification.
+func (mr *MockAuthUseCaseMockRecorder) ConfirmEmailVerification(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailVerification", reflect.TypeOf((*MockAuthUseCase)(nil).ConfirmEmailVerification), ctx, token)
+}
+
+// ConfirmRecoveryEmail mocks base method.
+func (m *MockAuthUseCase) ConfirmRecoveryEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmRecoveryEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmRecoveryEmail indicates an expected call of ConfirmRecoveryEmail.
+func (mr *MockAuthUseCaseMockRecorder) ConfirmRecoveryEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmRecoveryEmail", reflect.TypeOf((*MockAuthUseCase)(nil).ConfirmRecoveryEmail), ctx, token)
+}
+
+// DeletePasskey mocks base method.
+func (m *MockAuthUseCase) DeletePasskey(ctx context.Context, userID uuid.UUID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasskey", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasskey indicates an expected call of DeletePasskey.
+func (mr *MockAuthUseCaseMockRecorder) DeletePasskey(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasskey", reflect.TypeOf((*MockAuthUseCase)(nil).DeletePasskey), ctx, userID, id)
+}
+
+// DenyToken mocks base method.
+func (m *MockAuthUseCase) DenyToken(ctx context.Context, actorID, tokenID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyToken", ctx, actorID, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyToken indicates an expected call of DenyToken.
+func (mr *MockAuthUseCaseMockRecorder) DenyToken(ctx, actorID, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyToken", reflect.TypeOf((*MockAuthUseCase)(nil).DenyToken), ctx, actorID, tokenID)
+}
+
+// FinishPasskeyLogin mocks base method.
+func (m *MockAuthUseCase) FinishPasskeyLogin(ctx context.Context, ceremonyID uuid.UUID, assertion *entity.PasskeyAssertion, client entity.ClientInfo) (*entity.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishPasskeyLogin", ctx, ceremonyID, assertion, client)
+	ret0, _ := ret[0].(*entity.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishPasskeyLogin indicates an expected call of FinishPasskeyLogin.
+func (mr *MockAuthUseCaseMockRecorder) FinishPasskeyLogin(ctx, ceremonyID, assertion, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishPasskeyLogin", reflect.TypeOf((*MockAuthUseCase)(nil).FinishPasskeyLogin), ctx, ceremonyID, assertion, client)
+}
+
+// FinishPasskeyRegistration mocks base method.
+func (m *MockAuthUseCase) FinishPasskeyRegistration(ctx context.Context, userID, ceremonyID uuid.UUID, name string, attestation *entity.PasskeyAttestation) (*entity.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishPasskeyRegistration", ctx, userID, ceremonyID, name, attestation)
+	ret0, _ := ret[0].(*entity.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishPasskeyRegistration indicates an expected call of FinishPasskeyRegistration.
+func (mr *MockAuthUseCaseMockRecorder) FinishPasskeyRegistration(ctx, userID, ceremonyID, name, attestation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishPasskeyRegistration", reflect.TypeOf((*MockAuthUseCase)(nil).FinishPasskeyRegistration), ctx, userID, ceremonyID, name, attestation)
+}
+
+// GetSecurityOverview mocks base method.
+func (m *MockAuthUseCase) GetSecurityOverview(ctx context.Context, userID, sessionID uuid.UUID) (*entity.SecurityOverview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityOverview", ctx, userID, sessionID)
+	ret0, _ := ret[0].(*entity.SecurityOverview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityOverview indicates an expected call of GetSecurityOverview.
+func (mr *MockAuthUseCaseMockRecorder) GetSecurityOverview(ctx, userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityOverview", reflect.TypeOf((*MockAuthUseCase)(nil).GetSecurityOverview), ctx, userID, sessionID)
+}
+
+// GetSession mocks base method.
+func (m *MockAuthUseCase) GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionID)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockAuthUseCaseMockRecorder) GetSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockAuthUseCase)(nil).GetSession), ctx, sessionID)
+}
+
+// ListPasskeys mocks base method.
+func (m *MockAuthUseCase) ListPasskeys(ctx context.Context, userID uuid.UUID) ([]*entity.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPasskeys", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPasskeys indicates an expected call of ListPasskeys.
+func (mr *MockAuthUseCaseMockRecorder) ListPasskeys(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPasskeys", reflect.TypeOf((*MockAuthUseCase)(nil).ListPasskeys), ctx, userID)
+}
+
 // Login mocks base method.
-func (m *MockAuthUseCase) Login(ctx context.Context, email, password string) (*entity.LoginResponse, error) {
+func (m *MockAuthUseCase) Login(ctx context.Context, identifier, password string, rememberMe bool, client entity.ClientInfo) (*entity.LoginResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret := m.ctrl.Call(m, "Login", ctx, identifier, password, rememberMe, client)
 	ret0, _ := ret[0].(*entity.LoginResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockAuthUseCaseMockRecorder) Login(ctx, email, password any) *gomock.Call {
+func (mr *MockAuthUseCaseMockRecorder) Login(ctx, identifier, password, rememberMe, client any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthUseCase)(nil).Login), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthUseCase)(nil).Login), ctx, identifier, password, rememberMe, client)
 }
 
 // Logout mocks base method.
-func (m *MockAuthUseCase) Logout(ctx context.Context, tokenID uuid.UUID) error {
+func (m *MockAuthUseCase) Logout(ctx context.Context, sessionID, tokenID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Logout", ctx, tokenID)
+	ret := m.ctrl.Call(m, "Logout", ctx, sessionID, tokenID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Logout indicates an expected call of Logout.
-func (mr *MockAuthUseCaseMockRecorder) Logout(ctx, tokenID any) *gomock.Call {
+func (mr *MockAuthUseCaseMockRecorder) Logout(ctx, sessionID, tokenID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUseCase)(nil).Logout), ctx, tokenID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUseCase)(nil).Logout), ctx, sessionID, tokenID)
 }
 
 // LogoutAll mocks base method.
@@ -100,11 +278,154 @@ func (mr *MockAuthUseCaseMockRecorder) RefreshToken(ctx, refreshToken any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthUseCase)(nil).RefreshToken), ctx, refreshToken)
 }
 
+// RemoveRecoveryEmail mocks base method.
+func (m *MockAuthUseCase) RemoveRecoveryEmail(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRecoveryEmail", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRecoveryEmail indicates an expected call of RemoveRecoveryEmail.
+func (mr *MockAuthUseCaseMockRecorder) RemoveRecoveryEmail(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRecoveryEmail", reflect.TypeOf((*MockAuthUseCase)(nil).RemoveRecoveryEmail), ctx, userID)
+}
+
+// ReportActivity mocks base method.
+func (m *MockAuthUseCase) ReportActivity(ctx context.Context, userID, sessionID uuid.UUID, forcePasswordReset bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportActivity", ctx, userID, sessionID, forcePasswordReset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportActivity indicates an expected call of ReportActivity.
+func (mr *MockAuthUseCaseMockRecorder) ReportActivity(ctx, userID, sessionID, forcePasswordReset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportActivity", reflect.TypeOf((*MockAuthUseCase)(nil).ReportActivity), ctx, userID, sessionID, forcePasswordReset)
+}
+
+// RequestEmailVerification mocks base method.
+func (m *MockAuthUseCase) RequestEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailVerification", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailVerification indicates an expected call of RequestEmailVerification.
+func (mr *MockAuthUseCaseMockRecorder) RequestEmailVerification(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailVerification", reflect.TypeOf((*MockAuthUseCase)(nil).RequestEmailVerification), ctx, userID)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAuthUseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthUseCaseMockRecorder) RequestPasswordReset(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthUseCase)(nil).RequestPasswordReset), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthUseCase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthUseCaseMockRecorder) ResetPassword(ctx, token, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthUseCase)(nil).ResetPassword), ctx, token, newPassword)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthUseCase) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthUseCaseMockRecorder) RevokeSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthUseCase)(nil).RevokeSession), ctx, sessionID)
+}
+
+// RevokeTokensIssuedBefore mocks base method.
+func (m *MockAuthUseCase) RevokeTokensIssuedBefore(ctx context.Context, actorID uuid.UUID, cutoff time.Time) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokensIssuedBefore", ctx, actorID, cutoff)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeTokensIssuedBefore indicates an expected call of RevokeTokensIssuedBefore.
+func (mr *MockAuthUseCaseMockRecorder) RevokeTokensIssuedBefore(ctx, actorID, cutoff any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokensIssuedBefore", reflect.TypeOf((*MockAuthUseCase)(nil).RevokeTokensIssuedBefore), ctx, actorID, cutoff)
+}
+
+// SetRecoveryEmail mocks base method.
+func (m *MockAuthUseCase) SetRecoveryEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecoveryEmail", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecoveryEmail indicates an expected call of SetRecoveryEmail.
+func (mr *MockAuthUseCaseMockRecorder) SetRecoveryEmail(ctx, userID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecoveryEmail", reflect.TypeOf((*MockAuthUseCase)(nil).SetRecoveryEmail), ctx, userID, email)
+}
+
+// StartSession mocks base method.
+func (m *MockAuthUseCase) StartSession(ctx context.Context, user *entity.User, provider string) (*entity.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", ctx, user, provider)
+	ret0, _ := ret[0].(*entity.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MockAuthUseCaseMockRecorder) StartSession(ctx, user, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MockAuthUseCase)(nil).StartSession), ctx, user, provider)
+}
+
+// ValidateOIDCToken mocks base method.
+func (m *MockAuthUseCase) ValidateOIDCToken(ctx context.Context, token string) (*service.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateOIDCToken", ctx, token)
+	ret0, _ := ret[0].(*service.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateOIDCToken indicates an expected call of ValidateOIDCToken.
+func (mr *MockAuthUseCaseMockRecorder) ValidateOIDCToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateOIDCToken", reflect.TypeOf((*MockAuthUseCase)(nil).ValidateOIDCToken), ctx, token)
+}
+
 // ValidateToken mocks base method.
-func (m *MockAuthUseCase) ValidateToken(ctx context.Context, token string) (uuid.UUID, error) {
+func (m *MockAuthUseCase) ValidateToken(ctx context.Context, token string) (*service.TokenClaims, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ValidateToken", ctx, token)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret0, _ := ret[0].(*service.TokenClaims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/chats/go-user-api/internal/domain/entity"
 	uuid "github.com/google/uuid"
@@ -42,6 +43,65 @@ func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ConsumeOneTimeToken mocks base method.
+func (m *MockTokenRepository) ConsumeOneTimeToken(ctx context.Context, purpose, token string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOneTimeToken", ctx, purpose, token)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOneTimeToken indicates an expected call of ConsumeOneTimeToken.
+func (mr *MockTokenRepositoryMockRecorder) ConsumeOneTimeToken(ctx, purpose, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOneTimeToken", reflect.TypeOf((*MockTokenRepository)(nil).ConsumeOneTimeToken), ctx, purpose, token)
+}
+
+// ConsumeRefreshToken mocks base method.
+func (m *MockTokenRepository) ConsumeRefreshToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRefreshToken", ctx, tokenID, expiration)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeRefreshToken indicates an expected call of ConsumeRefreshToken.
+func (mr *MockTokenRepositoryMockRecorder) ConsumeRefreshToken(ctx, tokenID, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRefreshToken", reflect.TypeOf((*MockTokenRepository)(nil).ConsumeRefreshToken), ctx, tokenID, expiration)
+}
+
+// ExtendAccessToken mocks base method.
+func (m *MockTokenRepository) ExtendAccessToken(ctx context.Context, details *entity.TokenDetails) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendAccessToken", ctx, details)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtendAccessToken indicates an expected call of ExtendAccessToken.
+func (mr *MockTokenRepositoryMockRecorder) ExtendAccessToken(ctx, details any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendAccessToken", reflect.TypeOf((*MockTokenRepository)(nil).ExtendAccessToken), ctx, details)
+}
+
+// DeleteSession mocks base method.
+func (m *MockTokenRepository) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockTokenRepositoryMockRecorder) DeleteSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockTokenRepository)(nil).DeleteSession), ctx, sessionID)
+}
+
 // DeleteToken mocks base method.
 func (m *MockTokenRepository) DeleteToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) error {
 	m.ctrl.T.Helper()
@@ -70,6 +130,65 @@ func (mr *MockTokenRepositoryMockRecorder) DeleteUserTokens(ctx, userID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTokens", reflect.TypeOf((*MockTokenRepository)(nil).DeleteUserTokens), ctx, userID)
 }
 
+// DenyToken mocks base method.
+func (m *MockTokenRepository) DenyToken(ctx context.Context, tokenID uuid.UUID, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyToken", ctx, tokenID, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyToken indicates an expected call of DenyToken.
+func (mr *MockTokenRepositoryMockRecorder) DenyToken(ctx, tokenID, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyToken", reflect.TypeOf((*MockTokenRepository)(nil).DenyToken), ctx, tokenID, expiration)
+}
+
+// GetAccessTokenState mocks base method.
+func (m *MockTokenRepository) GetAccessTokenState(ctx context.Context, tokenID, userID uuid.UUID) (*entity.TokenState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessTokenState", ctx, tokenID, userID)
+	ret0, _ := ret[0].(*entity.TokenState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessTokenState indicates an expected call of GetAccessTokenState.
+func (mr *MockTokenRepositoryMockRecorder) GetAccessTokenState(ctx, tokenID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokenState", reflect.TypeOf((*MockTokenRepository)(nil).GetAccessTokenState), ctx, tokenID, userID)
+}
+
+// GetRevocationCutoff mocks base method.
+func (m *MockTokenRepository) GetRevocationCutoff(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevocationCutoff", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevocationCutoff indicates an expected call of GetRevocationCutoff.
+func (mr *MockTokenRepositoryMockRecorder) GetRevocationCutoff(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevocationCutoff", reflect.TypeOf((*MockTokenRepository)(nil).GetRevocationCutoff), ctx)
+}
+
+// GetSession mocks base method.
+func (m *MockTokenRepository) GetSession(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionID)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockTokenRepositoryMockRecorder) GetSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockTokenRepository)(nil).GetSession), ctx, sessionID)
+}
+
 // GetToken mocks base method.
 func (m *MockTokenRepository) GetToken(ctx context.Context, tokenID uuid.UUID, tokenType entity.TokenType) (*entity.TokenDetails, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +204,65 @@ func (mr *MockTokenRepositoryMockRecorder) GetToken(ctx, tokenID, tokenType any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockTokenRepository)(nil).GetToken), ctx, tokenID, tokenType)
 }
 
+// GetUserStatus mocks base method.
+func (m *MockTokenRepository) GetUserStatus(ctx context.Context, userID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStatus", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStatus indicates an expected call of GetUserStatus.
+func (mr *MockTokenRepositoryMockRecorder) GetUserStatus(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStatus", reflect.TypeOf((*MockTokenRepository)(nil).GetUserStatus), ctx, userID)
+}
+
+// IsTokenDenied mocks base method.
+func (m *MockTokenRepository) IsTokenDenied(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenDenied", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenDenied indicates an expected call of IsTokenDenied.
+func (mr *MockTokenRepositoryMockRecorder) IsTokenDenied(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenDenied", reflect.TypeOf((*MockTokenRepository)(nil).IsTokenDenied), ctx, tokenID)
+}
+
+// ListUserSessions mocks base method.
+func (m *MockTokenRepository) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessions", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessions indicates an expected call of ListUserSessions.
+func (mr *MockTokenRepositoryMockRecorder) ListUserSessions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*MockTokenRepository)(nil).ListUserSessions), ctx, userID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockTokenRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockTokenRepositoryMockRecorder) RevokeSession(ctx, sessionID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockTokenRepository)(nil).RevokeSession), ctx, sessionID, reason)
+}
+
 // StoreAccessToken mocks base method.
 func (m *MockTokenRepository) StoreAccessToken(ctx context.Context, details *entity.TokenDetails) error {
 	m.ctrl.T.Helper()
@@ -99,6 +277,20 @@ func (mr *MockTokenRepositoryMockRecorder) StoreAccessToken(ctx, details any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreAccessToken", reflect.TypeOf((*MockTokenRepository)(nil).StoreAccessToken), ctx, details)
 }
 
+// StoreOneTimeToken mocks base method.
+func (m *MockTokenRepository) StoreOneTimeToken(ctx context.Context, purpose, token string, userID uuid.UUID, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreOneTimeToken", ctx, purpose, token, userID, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreOneTimeToken indicates an expected call of StoreOneTimeToken.
+func (mr *MockTokenRepositoryMockRecorder) StoreOneTimeToken(ctx, purpose, token, userID, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOneTimeToken", reflect.TypeOf((*MockTokenRepository)(nil).StoreOneTimeToken), ctx, purpose, token, userID, expiration)
+}
+
 // StoreRefreshToken mocks base method.
 func (m *MockTokenRepository) StoreRefreshToken(ctx context.Context, details *entity.TokenDetails) error {
 	m.ctrl.T.Helper()
@@ -112,3 +304,31 @@ func (mr *MockTokenRepositoryMockRecorder) StoreRefreshToken(ctx, details any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRefreshToken", reflect.TypeOf((*MockTokenRepository)(nil).StoreRefreshToken), ctx, details)
 }
+
+// StoreRevocationCutoff mocks base method.
+func (m *MockTokenRepository) StoreRevocationCutoff(ctx context.Context, cutoff time.Time, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreRevocationCutoff", ctx, cutoff, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreRevocationCutoff indicates an expected call of StoreRevocationCutoff.
+func (mr *MockTokenRepositoryMockRecorder) StoreRevocationCutoff(ctx, cutoff, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRevocationCutoff", reflect.TypeOf((*MockTokenRepository)(nil).StoreRevocationCutoff), ctx, cutoff, expiration)
+}
+
+// StoreUserStatus mocks base method.
+func (m *MockTokenRepository) StoreUserStatus(ctx context.Context, userID uuid.UUID, status string, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserStatus", ctx, userID, status, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserStatus indicates an expected call of StoreUserStatus.
+func (mr *MockTokenRepositoryMockRecorder) StoreUserStatus(ctx, userID, status, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserStatus", reflect.TypeOf((*MockTokenRepository)(nil).StoreUserStatus), ctx, userID, status, expiration)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/chats/go-user-api/internal/domain/entity"
 	uuid "github.com/google/uuid"
@@ -42,6 +43,20 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddTags mocks base method.
+func (m *MockUserRepository) AddTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, id, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockUserRepositoryMockRecorder) AddTags(ctx, id, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockUserRepository)(nil).AddTags), ctx, id, tags)
+}
+
 // ChangePassword mocks base method.
 func (m *MockUserRepository) ChangePassword(ctx context.Context, id uuid.UUID, hashedPassword string) error {
 	m.ctrl.T.Helper()
@@ -56,6 +71,36 @@ func (mr *MockUserRepositoryMockRecorder) ChangePassword(ctx, id, hashedPassword
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserRepository)(nil).ChangePassword), ctx, id, hashedPassword)
 }
 
+// CountByStatusAndRole mocks base method.
+func (m *MockUserRepository) CountByStatusAndRole(ctx context.Context) ([]entity.UserCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatusAndRole", ctx)
+	ret0, _ := ret[0].([]entity.UserCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatusAndRole indicates an expected call of CountByStatusAndRole.
+func (mr *MockUserRepositoryMockRecorder) CountByStatusAndRole(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatusAndRole", reflect.TypeOf((*MockUserRepository)(nil).CountByStatusAndRole), ctx)
+}
+
+// CountCreatedSince mocks base method.
+func (m *MockUserRepository) CountCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreatedSince", ctx, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreatedSince indicates an expected call of CountCreatedSince.
+func (mr *MockUserRepositoryMockRecorder) CountCreatedSince(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreatedSince", reflect.TypeOf((*MockUserRepository)(nil).CountCreatedSince), ctx, since)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(ctx context.Context, user *entity.User) error {
 	m.ctrl.T.Helper()
@@ -70,6 +115,20 @@ func (mr *MockUserRepositoryMockRecorder) Create(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), ctx, user)
 }
 
+// CreateMany mocks base method.
+func (m *MockUserRepository) CreateMany(ctx context.Context, users []*entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockUserRepositoryMockRecorder) CreateMany(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockUserRepository)(nil).CreateMany), ctx, users)
+}
+
 // Delete mocks base method.
 func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -114,6 +173,21 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByRecoveryEmail mocks base method.
+func (m *MockUserRepository) GetByRecoveryEmail(ctx context.Context, email string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRecoveryEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRecoveryEmail indicates an expected call of GetByRecoveryEmail.
+func (mr *MockUserRepositoryMockRecorder) GetByRecoveryEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRecoveryEmail", reflect.TypeOf((*MockUserRepository)(nil).GetByRecoveryEmail), ctx, email)
+}
+
 // GetByUsername mocks base method.
 func (m *MockUserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -130,9 +204,9 @@ func (mr *MockUserRepositoryMockRecorder) GetByUsername(ctx, username any) *gomo
 }
 
 // List mocks base method.
-func (m *MockUserRepository) List(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
+func (m *MockUserRepository) List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, page, limit)
+	ret := m.ctrl.Call(m, "List", ctx, page, limit, opts)
 	ret0, _ := ret[0].([]*entity.User)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -140,9 +214,111 @@ func (m *MockUserRepository) List(ctx context.Context, page, limit int) ([]*enti
 }
 
 // List indicates an expected call of List.
-func (mr *MockUserRepositoryMockRecorder) List(ctx, page, limit any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) List(ctx, page, limit, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, page, limit, opts)
+}
+
+// ListCreatedBetween mocks base method.
+func (m *MockUserRepository) ListCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCreatedBetween", ctx, from, to, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCreatedBetween indicates an expected call of ListCreatedBetween.
+func (mr *MockUserRepositoryMockRecorder) ListCreatedBetween(ctx, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCreatedBetween", reflect.TypeOf((*MockUserRepository)(nil).ListCreatedBetween), ctx, from, to, limit)
+}
+
+// ListDueForPurge mocks base method.
+func (m *MockUserRepository) ListDueForPurge(ctx context.Context, before time.Time, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueForPurge", ctx, before, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueForPurge indicates an expected call of ListDueForPurge.
+func (mr *MockUserRepositoryMockRecorder) ListDueForPurge(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueForPurge", reflect.TypeOf((*MockUserRepository)(nil).ListDueForPurge), ctx, before, limit)
+}
+
+// ListLastActiveBetween mocks base method.
+func (m *MockUserRepository) ListLastActiveBetween(ctx context.Context, from, to time.Time, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLastActiveBetween", ctx, from, to, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLastActiveBetween indicates an expected call of ListLastActiveBetween.
+func (mr *MockUserRepositoryMockRecorder) ListLastActiveBetween(ctx, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLastActiveBetween", reflect.TypeOf((*MockUserRepository)(nil).ListLastActiveBetween), ctx, from, to, limit)
+}
+
+// ListWaitlisted mocks base method.
+func (m *MockUserRepository) ListWaitlisted(ctx context.Context, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWaitlisted", ctx, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWaitlisted indicates an expected call of ListWaitlisted.
+func (mr *MockUserRepositoryMockRecorder) ListWaitlisted(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWaitlisted", reflect.TypeOf((*MockUserRepository)(nil).ListWaitlisted), ctx, limit)
+}
+
+// RecordActivity mocks base method.
+func (m *MockUserRepository) RecordActivity(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordActivity", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordActivity indicates an expected call of RecordActivity.
+func (mr *MockUserRepositoryMockRecorder) RecordActivity(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordActivity", reflect.TypeOf((*MockUserRepository)(nil).RecordActivity), ctx, id, at)
+}
+
+// RehashPassword mocks base method.
+func (m *MockUserRepository) RehashPassword(ctx context.Context, id uuid.UUID, currentHash, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RehashPassword", ctx, id, currentHash, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RehashPassword indicates an expected call of RehashPassword.
+func (mr *MockUserRepositoryMockRecorder) RehashPassword(ctx, id, currentHash, hashedPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehashPassword", reflect.TypeOf((*MockUserRepository)(nil).RehashPassword), ctx, id, currentHash, hashedPassword)
+}
+
+// RemoveTags mocks base method.
+func (m *MockUserRepository) RemoveTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTags", ctx, id, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTags indicates an expected call of RemoveTags.
+func (mr *MockUserRepositoryMockRecorder) RemoveTags(ctx, id, tags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockUserRepository)(nil).RemoveTags), ctx, id, tags)
 }
 
 // Update mocks base method.
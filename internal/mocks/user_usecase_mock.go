@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/chats/go-user-api/internal/domain/entity"
 	uuid "github.com/google/uuid"
@@ -42,6 +43,21 @@ func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AddTags mocks base method.
+func (m *MockUserUseCase) AddTags(ctx context.Context, actorID, id uuid.UUID, tags []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, actorID, id, tags)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockUserUseCaseMockRecorder) AddTags(ctx, actorID, id, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockUserUseCase)(nil).AddTags), ctx, actorID, id, tags)
+}
+
 // Authenticate mocks base method.
 func (m *MockUserUseCase) Authenticate(ctx context.Context, email, password string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +73,21 @@ func (mr *MockUserUseCaseMockRecorder) Authenticate(ctx, email, password any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserUseCase)(nil).Authenticate), ctx, email, password)
 }
 
+// CancelDeletion mocks base method.
+func (m *MockUserUseCase) CancelDeletion(ctx context.Context, actorID, id uuid.UUID) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDeletion", ctx, actorID, id)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelDeletion indicates an expected call of CancelDeletion.
+func (mr *MockUserUseCaseMockRecorder) CancelDeletion(ctx, actorID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDeletion", reflect.TypeOf((*MockUserUseCase)(nil).CancelDeletion), ctx, actorID, id)
+}
+
 // ChangePassword mocks base method.
 func (m *MockUserUseCase) ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword string) error {
 	m.ctrl.T.Helper()
@@ -72,17 +103,18 @@ func (mr *MockUserUseCaseMockRecorder) ChangePassword(ctx, id, oldPassword, newP
 }
 
 // Delete mocks base method.
-func (m *MockUserUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockUserUseCase) Delete(ctx context.Context, actorID, id uuid.UUID, reason entity.ActionReason) (*time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Delete", ctx, actorID, id, reason)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserUseCaseMockRecorder) Delete(ctx, id any) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) Delete(ctx, actorID, id, reason any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserUseCase)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserUseCase)(nil).Delete), ctx, actorID, id, reason)
 }
 
 // GetByID mocks base method.
@@ -100,10 +132,40 @@ func (mr *MockUserUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserUseCase)(nil).GetByID), ctx, id)
 }
 
+// Import mocks base method.
+func (m *MockUserUseCase) Import(ctx context.Context, actorID uuid.UUID, users []entity.UserImport) (*entity.UserImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, actorID, users)
+	ret0, _ := ret[0].(*entity.UserImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserUseCaseMockRecorder) Import(ctx, actorID, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserUseCase)(nil).Import), ctx, actorID, users)
+}
+
+// LinkOAuth mocks base method.
+func (m *MockUserUseCase) LinkOAuth(ctx context.Context, userID uuid.UUID, profile *entity.OAuthProfile) (*entity.OAuthIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkOAuth", ctx, userID, profile)
+	ret0, _ := ret[0].(*entity.OAuthIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkOAuth indicates an expected call of LinkOAuth.
+func (mr *MockUserUseCaseMockRecorder) LinkOAuth(ctx, userID, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkOAuth", reflect.TypeOf((*MockUserUseCase)(nil).LinkOAuth), ctx, userID, profile)
+}
+
 // List mocks base method.
-func (m *MockUserUseCase) List(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
+func (m *MockUserUseCase) List(ctx context.Context, page, limit int, opts entity.UserListOptions) ([]*entity.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, page, limit)
+	ret := m.ctrl.Call(m, "List", ctx, page, limit, opts)
 	ret0, _ := ret[0].([]*entity.User)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -111,51 +173,222 @@ func (m *MockUserUseCase) List(ctx context.Context, page, limit int) ([]*entity.
 }
 
 // List indicates an expected call of List.
-func (mr *MockUserUseCaseMockRecorder) List(ctx, page, limit any) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) List(ctx, page, limit, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserUseCase)(nil).List), ctx, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserUseCase)(nil).List), ctx, page, limit, opts)
+}
+
+// ListPendingDeletions mocks base method.
+func (m *MockUserUseCase) ListPendingDeletions(ctx context.Context, page, limit int) ([]*entity.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingDeletions", ctx, page, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPendingDeletions indicates an expected call of ListPendingDeletions.
+func (mr *MockUserUseCaseMockRecorder) ListPendingDeletions(ctx, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingDeletions", reflect.TypeOf((*MockUserUseCase)(nil).ListPendingDeletions), ctx, page, limit)
+}
+
+// PurgeDeletions mocks base method.
+func (m *MockUserUseCase) PurgeDeletions(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletions", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDeletions indicates an expected call of PurgeDeletions.
+func (mr *MockUserUseCaseMockRecorder) PurgeDeletions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletions", reflect.TypeOf((*MockUserUseCase)(nil).PurgeDeletions), ctx)
 }
 
 // Register mocks base method.
-func (m *MockUserUseCase) Register(ctx context.Context, email, username, password, firstName, lastName string) (*entity.User, error) {
+func (m *MockUserUseCase) Register(ctx context.Context, email, username, password string, profile entity.UserProfile, orgID *uuid.UUID, referralCode string) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", ctx, email, username, password, firstName, lastName)
+	ret := m.ctrl.Call(m, "Register", ctx, email, username, password, profile, orgID, referralCode)
 	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockUserUseCaseMockRecorder) Register(ctx, email, username, password, firstName, lastName any) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) Register(ctx, email, username, password, profile, orgID, referralCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), ctx, email, username, password, profile, orgID, referralCode)
+}
+
+// RemoveTags mocks base method.
+func (m *MockUserUseCase) RemoveTags(ctx context.Context, actorID, id uuid.UUID, tags []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTags", ctx, actorID, id, tags)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTags indicates an expected call of RemoveTags.
+func (mr *MockUserUseCaseMockRecorder) RemoveTags(ctx, actorID, id, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockUserUseCase)(nil).RemoveTags), ctx, actorID, id, tags)
+}
+
+// RequirePasswordChange mocks base method.
+func (m *MockUserUseCase) RequirePasswordChange(ctx context.Context, actorID, id uuid.UUID, required bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequirePasswordChange", ctx, actorID, id, required)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequirePasswordChange indicates an expected call of RequirePasswordChange.
+func (mr *MockUserUseCaseMockRecorder) RequirePasswordChange(ctx, actorID, id, required any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequirePasswordChange", reflect.TypeOf((*MockUserUseCase)(nil).RequirePasswordChange), ctx, actorID, id, required)
+}
+
+// RunPurge mocks base method.
+func (m *MockUserUseCase) RunPurge(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunPurge", ctx, interval)
+}
+
+// RunPurge indicates an expected call of RunPurge.
+func (mr *MockUserUseCaseMockRecorder) RunPurge(ctx, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunPurge", reflect.TypeOf((*MockUserUseCase)(nil).RunPurge), ctx, interval)
+}
+
+// SignInWithOAuth mocks base method.
+func (m *MockUserUseCase) SignInWithOAuth(ctx context.Context, profile *entity.OAuthProfile) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInWithOAuth", ctx, profile)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInWithOAuth indicates an expected call of SignInWithOAuth.
+func (mr *MockUserUseCaseMockRecorder) SignInWithOAuth(ctx, profile any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), ctx, email, username, password, firstName, lastName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInWithOAuth", reflect.TypeOf((*MockUserUseCase)(nil).SignInWithOAuth), ctx, profile)
+}
+
+// StatusHistory mocks base method.
+func (m *MockUserUseCase) StatusHistory(ctx context.Context, id uuid.UUID) ([]*entity.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusHistory", ctx, id)
+	ret0, _ := ret[0].([]*entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatusHistory indicates an expected call of StatusHistory.
+func (mr *MockUserUseCaseMockRecorder) StatusHistory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusHistory", reflect.TypeOf((*MockUserUseCase)(nil).StatusHistory), ctx, id)
+}
+
+// UnlinkOAuth mocks base method.
+func (m *MockUserUseCase) UnlinkOAuth(ctx context.Context, userID uuid.UUID, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkOAuth", ctx, userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkOAuth indicates an expected call of UnlinkOAuth.
+func (mr *MockUserUseCaseMockRecorder) UnlinkOAuth(ctx, userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkOAuth", reflect.TypeOf((*MockUserUseCase)(nil).UnlinkOAuth), ctx, userID, provider)
 }
 
 // Update mocks base method.
-func (m *MockUserUseCase) Update(ctx context.Context, id uuid.UUID, firstName, lastName string) (*entity.User, error) {
+func (m *MockUserUseCase) Update(ctx context.Context, id uuid.UUID, profile entity.UserProfile) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, firstName, lastName)
+	ret := m.ctrl.Call(m, "Update", ctx, id, profile)
 	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserUseCaseMockRecorder) Update(ctx, id, firstName, lastName any) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) Update(ctx, id, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserUseCase)(nil).Update), ctx, id, profile)
+}
+
+// UpdateLifecycleEmails mocks base method.
+func (m *MockUserUseCase) UpdateLifecycleEmails(ctx context.Context, id uuid.UUID, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLifecycleEmails", ctx, id, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLifecycleEmails indicates an expected call of UpdateLifecycleEmails.
+func (mr *MockUserUseCaseMockRecorder) UpdateLifecycleEmails(ctx, id, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLifecycleEmails", reflect.TypeOf((*MockUserUseCase)(nil).UpdateLifecycleEmails), ctx, id, enabled)
+}
+
+// UpdateNotificationChannels mocks base method.
+func (m *MockUserUseCase) UpdateNotificationChannels(ctx context.Context, id uuid.UUID, channels []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationChannels", ctx, id, channels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationChannels indicates an expected call of UpdateNotificationChannels.
+func (mr *MockUserUseCaseMockRecorder) UpdateNotificationChannels(ctx, id, channels any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserUseCase)(nil).Update), ctx, id, firstName, lastName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationChannels", reflect.TypeOf((*MockUserUseCase)(nil).UpdateNotificationChannels), ctx, id, channels)
+}
+
+// UpdateRole mocks base method.
+func (m *MockUserUseCase) UpdateRole(ctx context.Context, actorID, id uuid.UUID, role string, reason entity.ActionReason) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, actorID, id, role, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockUserUseCaseMockRecorder) UpdateRole(ctx, actorID, id, role, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockUserUseCase)(nil).UpdateRole), ctx, actorID, id, role, reason)
 }
 
 // UpdateStatus mocks base method.
-func (m *MockUserUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+func (m *MockUserUseCase) UpdateStatus(ctx context.Context, actorID, id uuid.UUID, status string, reason entity.ActionReason) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, actorID, id, status, reason)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockUserUseCaseMockRecorder) UpdateStatus(ctx, id, status any) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) UpdateStatus(ctx, actorID, id, status, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockUserUseCase)(nil).UpdateStatus), ctx, actorID, id, status, reason)
+}
+
+// UpdateVerification mocks base method.
+func (m *MockUserUseCase) UpdateVerification(ctx context.Context, actorID, id uuid.UUID, emailVerified, phoneVerified *bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVerification", ctx, actorID, id, emailVerified, phoneVerified)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVerification indicates an expected call of UpdateVerification.
+func (mr *MockUserUseCaseMockRecorder) UpdateVerification(ctx, actorID, id, emailVerified, phoneVerified any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockUserUseCase)(nil).UpdateStatus), ctx, id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerification", reflect.TypeOf((*MockUserUseCase)(nil).UpdateVerification), ctx, actorID, id, emailVerified, phoneVerified)
 }
@@ -7,6 +7,62 @@ db.createCollection('users');
 db.users.createIndex({ "email": 1 }, { unique: true });
 db.users.createIndex({ "username": 1 }, { unique: true });
 db.users.createIndex({ "status": 1 });
+db.users.createIndex({ "tags": 1, "created_at": -1 });
+db.users.createIndex({ "org_id": 1, "created_at": -1 });
+db.users.createIndex({ "recovery_email": 1 }, { partialFilterExpression: { "recovery_email_verified": true } });
+db.users.createIndex({ "purge_at": 1 }, { partialFilterExpression: { "status": "pending_deletion" } });
+db.users.createIndex({ "created_at": 1 }, { partialFilterExpression: { "status": "waitlisted" } });
+
+// Status history of the accounts, listed per user newest first
+db.user_status_history.createIndex({ "user_id": 1, "created_at": -1 });
+
+// Referral codes looked up per user, referrals counted per referrer
+db.referral_codes.createIndex({ "user_id": 1, "created_at": 1 });
+db.referrals.createIndex({ "referrer_id": 1, "created_at": -1 });
+
+// Passkeys listed per user
+db.passkeys.createIndex({ "user_id": 1, "created_at": 1 });
+
+// Identities at external OAuth2 providers deleted with their user
+db.oauth_identities.createIndex({ "user_id": 1 });
+
+// API keys authenticated by the hash of the key, listed per user and per organization
+db.api_keys.createIndex({ "hash": 1 }, { unique: true });
+db.api_keys.createIndex({ "user_id": 1, "created_at": 1 });
+db.api_keys.createIndex({ "org_id": 1, "created_at": 1 }, { partialFilterExpression: { "org_id": { $exists: true } } });
+
+// Service accounts authenticated by their client ID
+db.service_accounts.createIndex({ "client_id": 1 }, { unique: true });
+
+// Roles assigned once per user, listed per user and per role, the expired ones swept by expiry
+db.user_roles.createIndex({ "user_id": 1, "role": 1 }, { unique: true });
+db.user_roles.createIndex({ "role": 1, "assigned_at": 1 });
+db.user_roles.createIndex({ "expires_at": 1 }, { partialFilterExpression: { "expires_at": { $exists: true } } });
+
+// Team memberships added once per user, listed per team and per user
+db.team_members.createIndex({ "team": 1, "user_id": 1 }, { unique: true });
+db.team_members.createIndex({ "team": 1, "added_at": 1 });
+db.team_members.createIndex({ "user_id": 1, "team": 1 });
+
+// Admin notes listed per user newest first
+db.user_admin_notes.createIndex({ "user_id": 1, "created_at": -1 });
+
+// IP denials keyed by IP, the active ones listed by expiry
+db.ip_denials.createIndex({ "expires_at": 1 });
+
+// Login countries are keyed by user ID, the _id index serves their lookups
+
+// Security events streamed to the SIEM oldest first
+db.security_events.createIndex({ "occurred_at": 1, "_id": 1 });
+
+// Webhook deliveries listed per endpoint and per status newest first, the pending ones retried when due
+db.webhook_deliveries.createIndex({ "endpoint_id": 1, "created_at": -1 });
+db.webhook_deliveries.createIndex({ "status": 1, "created_at": -1 });
+db.webhook_deliveries.createIndex({ "status": 1, "next_attempt_at": 1 });
+
+// SCIM groups listed by display name, those of a member through its memberships
+db.scim_groups.createIndex({ "display_name": 1 });
+db.scim_groups.createIndex({ "members": 1 });
 
 // Insert admin user
 db.users.insertOne({
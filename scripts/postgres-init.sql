@@ -5,18 +5,44 @@ CREATE TABLE IF NOT EXISTS users (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     email VARCHAR(255) NOT NULL UNIQUE,
     username VARCHAR(50) NOT NULL UNIQUE,
-    password VARCHAR(255) NOT NULL,
+    password VARCHAR(255) NOT NULL DEFAULT '', -- Empty for invited users until the invitation is accepted
     first_name VARCHAR(100) NOT NULL,
     last_name VARCHAR(100) NOT NULL,
+    display_name VARCHAR(400) NOT NULL DEFAULT '',
+    locale VARCHAR(35) NOT NULL DEFAULT '',
+    phone VARCHAR(16) NOT NULL DEFAULT '',
+    birth_date VARCHAR(10) NOT NULL DEFAULT '',
     role VARCHAR(50) NOT NULL DEFAULT 'user',
     status VARCHAR(20) NOT NULL DEFAULT 'active',
+    org_id UUID,
+    email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    phone_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    email_reverification_required BOOLEAN NOT NULL DEFAULT FALSE,
+    recovery_email VARCHAR(255) NOT NULL DEFAULT '',
+    recovery_email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    notification_channels TEXT[],
+    tags TEXT[],
+    password_reset_required BOOLEAN NOT NULL DEFAULT FALSE,
+    must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+    terms_accepted_at TIMESTAMP WITH TIME ZONE,
+    deletion_requested_at TIMESTAMP WITH TIME ZONE,
+    purge_at TIMESTAMP WITH TIME ZONE,
+    status_before_deletion VARCHAR(20) NOT NULL DEFAULT '',
+    lifecycle_emails_disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_active_at TIMESTAMP WITH TIME ZONE, -- NULL until the user signs in
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
 );
 
-CREATE INDEX idx_users_email ON users(email);
-CREATE INDEX idx_users_username ON users(username);
-CREATE INDEX idx_users_status ON users(status);
+CREATE INDEX IF NOT EXISTS idx_users_status ON users(status);
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
+CREATE INDEX IF NOT EXISTS idx_users_org_id ON users(org_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_users_recovery_email ON users(recovery_email) WHERE recovery_email_verified;
+CREATE INDEX IF NOT EXISTS idx_users_tags ON users USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_users_purge_at ON users(purge_at) WHERE status = 'pending_deletion';
+CREATE INDEX IF NOT EXISTS idx_users_waitlisted ON users(created_at) WHERE status = 'waitlisted';
+CREATE INDEX IF NOT EXISTS idx_users_last_activity ON users((COALESCE(last_active_at, created_at)));
 
 -- Create an admin user with password 'admin123' (bcrypt hashed)
 INSERT INTO users (id, email, username, password, first_name, last_name, role, status)
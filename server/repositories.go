@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/repository"
+	"github.com/chats/go-user-api/internal/domain/repository/inmem"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+	"github.com/chats/go-user-api/internal/infrastructure/db"
+	"github.com/rs/zerolog/log"
+)
+
+// repositories holds the repositories used by the use cases
+type repositories struct {
+	user            repository.UserRepository
+	token           repository.TokenRepository
+	settings        repository.SettingsRepository
+	dedup           repository.DedupRepository
+	quota           repository.QuotaRepository
+	usage           repository.UsageRepository
+	audit           repository.AuditRepository
+	role            repository.RoleRepository
+	permissionGroup repository.PermissionGroupRepository
+	organization    repository.OrganizationRepository
+	signingKey      repository.SigningKeyRepository
+	webhook         repository.WebhookRepository
+	suppression     repository.SuppressionRepository
+	statusHistory   repository.StatusHistoryRepository
+	oidc            repository.OIDCRepository
+	device          repository.DeviceAuthorizationRepository
+	referral        repository.ReferralRepository
+	passkey         repository.PasskeyRepository
+	passkeyCeremony repository.PasskeyCeremonyRepository
+	oauthIdentity   repository.OAuthIdentityRepository
+	oauthState      repository.OAuthStateRepository
+	directoryReport repository.DirectoryReportRepository
+	lifecycle       repository.LifecycleRepository
+	apiKey          repository.APIKeyRepository
+	inactivity      repository.InactivityRepository
+	serviceAccount  repository.ServiceAccountRepository
+	kpi             repository.KPIRepository
+	adminNote       repository.AdminNoteRepository
+	permission      repository.PermissionRepository
+	roleAssignment  repository.RoleAssignmentRepository
+	roleChange      repository.RoleChangeRequestRepository
+	team            repository.TeamRepository
+	teamMember      repository.TeamMemberRepository
+	breakGlass      repository.BreakGlassRepository
+	securityEvent   repository.SecurityEventRepository
+	ipDenial        repository.IPDenialRepository
+	loginCountry    repository.LoginCountryRepository
+	selfTest        repository.SelfTestRepository
+	selfTestCache   repository.SelfTestRepository
+	scimGroup       repository.SCIMGroupRepository
+}
+
+// newRepositories creates the traced repositories for the configured database type, the users cached per request.
+// The in-memory database selects the repositories of package inmem, seeded with the demo users. PostgreSQL is
+// refused, only the users are stored there so far and every other repository would fail on its first query.
+func newRepositories(cfg *config.Config, database db.Database, cacheClient cache.Cache) (*repositories, error) {
+	if cfg.Database.Type == config.PostgreSQL {
+		return nil, fmt.Errorf("%s only backs the user repository so far, set DB_TYPE to %s or %s", config.PostgreSQL, config.MongoDB, config.MemoryDB)
+	}
+
+	repos := &repositories{
+		token:           repository.NewTokenRepository(cacheClient),
+		settings:        repository.NewSettingsRepository(cacheClient),
+		dedup:           repository.NewDedupRepository(cacheClient),
+		quota:           repository.NewQuotaRepository(cacheClient),
+		oidc:            repository.NewOIDCRepository(cacheClient),
+		device:          repository.NewDeviceAuthorizationRepository(cacheClient),
+		passkeyCeremony: repository.NewPasskeyCeremonyRepository(cacheClient),
+		oauthState:      repository.NewOAuthStateRepository(cacheClient),
+		directoryReport: repository.NewDirectoryReportRepository(cacheClient),
+		lifecycle:       repository.NewLifecycleRepository(cacheClient),
+		inactivity:      repository.NewInactivityRepository(cacheClient),
+		kpi:             repository.NewKPIRepository(cacheClient),
+		selfTestCache:   repository.NewSelfTestCacheRepository(cacheClient),
+	}
+
+	switch cfg.Database.Type {
+	case config.MemoryDB:
+		repos.user = inmem.NewUserRepository()
+		repos.usage = inmem.NewUsageRepository()
+		repos.audit = inmem.NewAuditRepository()
+		repos.role = inmem.NewRoleRepository()
+		repos.permissionGroup = inmem.NewPermissionGroupRepository()
+		repos.organization = inmem.NewOrganizationRepository()
+		repos.signingKey = inmem.NewSigningKeyRepository()
+		repos.webhook = inmem.NewWebhookRepository()
+		repos.suppression = inmem.NewSuppressionRepository()
+		repos.statusHistory = inmem.NewStatusHistoryRepository()
+		repos.referral = inmem.NewReferralRepository()
+		repos.passkey = inmem.NewPasskeyRepository()
+		repos.oauthIdentity = inmem.NewOAuthIdentityRepository()
+		repos.apiKey = inmem.NewAPIKeyRepository()
+		repos.serviceAccount = inmem.NewServiceAccountRepository()
+		repos.adminNote = inmem.NewAdminNoteRepository()
+		repos.permission = inmem.NewPermissionRepository()
+		repos.roleAssignment = inmem.NewRoleAssignmentRepository()
+		repos.roleChange = inmem.NewRoleChangeRequestRepository()
+		repos.team = inmem.NewTeamRepository()
+		repos.teamMember = inmem.NewTeamMemberRepository()
+		repos.breakGlass = inmem.NewBreakGlassRepository()
+		repos.securityEvent = inmem.NewSecurityEventRepository()
+		repos.ipDenial = inmem.NewIPDenialRepository()
+		repos.loginCountry = inmem.NewLoginCountryRepository()
+		repos.selfTest = inmem.NewSelfTestRepository()
+		repos.scimGroup = inmem.NewSCIMGroupRepository()
+
+		if err := inmem.Seed(context.Background(), repos.user); err != nil {
+			return nil, fmt.Errorf("failed to seed in-memory users: %v", err)
+		}
+		log.Warn().Msg("Running on in-memory repositories, seeded admin@example.com / admin123")
+	default:
+		repos.user = repository.NewUserRepository(database, cacheClient, cfg.Cache.StaleTTL)
+		repos.usage = repository.NewUsageRepository(database)
+		repos.audit = repository.NewAuditRepository(database)
+		repos.role = repository.NewRoleRepository(database)
+		repos.permissionGroup = repository.NewPermissionGroupRepository(database)
+		repos.organization = repository.NewOrganizationRepository(database)
+		repos.signingKey = repository.NewSigningKeyRepository(database)
+		repos.webhook = repository.NewWebhookRepository(database)
+		repos.suppression = repository.NewSuppressionRepository(database)
+		repos.statusHistory = repository.NewStatusHistoryRepository(database)
+		repos.referral = repository.NewReferralRepository(database)
+		repos.passkey = repository.NewPasskeyRepository(database, cacheClient)
+		repos.oauthIdentity = repository.NewOAuthIdentityRepository(database)
+		repos.apiKey = repository.NewAPIKeyRepository(database, cacheClient)
+		repos.serviceAccount = repository.NewServiceAccountRepository(database)
+		repos.adminNote = repository.NewAdminNoteRepository(database)
+		repos.permission = repository.NewPermissionRepository(database)
+		repos.roleAssignment = repository.NewRoleAssignmentRepository(database)
+		repos.roleChange = repository.NewRoleChangeRequestRepository(database)
+		repos.team = repository.NewTeamRepository(database)
+		repos.teamMember = repository.NewTeamMemberRepository(database)
+		repos.breakGlass = repository.NewBreakGlassRepository(database)
+		repos.securityEvent = repository.NewSecurityEventRepository(database)
+		repos.ipDenial = repository.NewIPDenialRepository(database)
+		repos.loginCountry = repository.NewLoginCountryRepository(database)
+		repos.selfTest = repository.NewSelfTestRepository(database)
+		repos.scimGroup = repository.NewSCIMGroupRepository(database)
+	}
+
+	return &repositories{
+		user:            repository.NewRequestCachedUserRepository(repository.NewTracedUserRepository(repos.user)),
+		token:           repository.NewTracedTokenRepository(repos.token),
+		settings:        repository.NewTracedSettingsRepository(repos.settings),
+		dedup:           repository.NewTracedDedupRepository(repos.dedup),
+		quota:           repository.NewTracedQuotaRepository(repos.quota),
+		usage:           repository.NewTracedUsageRepository(repos.usage),
+		audit:           repository.NewTracedAuditRepository(repos.audit),
+		role:            repository.NewTracedRoleRepository(repos.role),
+		permissionGroup: repository.NewTracedPermissionGroupRepository(repos.permissionGroup),
+		organization:    repository.NewTracedOrganizationRepository(repos.organization),
+		signingKey:      repository.NewTracedSigningKeyRepository(repos.signingKey),
+		webhook:         repository.NewTracedWebhookRepository(repos.webhook),
+		suppression:     repository.NewTracedSuppressionRepository(repos.suppression),
+		statusHistory:   repository.NewTracedStatusHistoryRepository(repos.statusHistory),
+		oidc:            repository.NewTracedOIDCRepository(repos.oidc),
+		device:          repository.NewTracedDeviceAuthorizationRepository(repos.device),
+		referral:        repository.NewTracedReferralRepository(repos.referral),
+		passkey:         repository.NewTracedPasskeyRepository(repos.passkey),
+		passkeyCeremony: repository.NewTracedPasskeyCeremonyRepository(repos.passkeyCeremony),
+		oauthIdentity:   repository.NewTracedOAuthIdentityRepository(repos.oauthIdentity),
+		oauthState:      repository.NewTracedOAuthStateRepository(repos.oauthState),
+		directoryReport: repository.NewTracedDirectoryReportRepository(repos.directoryReport),
+		lifecycle:       repository.NewTracedLifecycleRepository(repos.lifecycle),
+		apiKey:          repository.NewTracedAPIKeyRepository(repos.apiKey),
+		inactivity:      repository.NewTracedInactivityRepository(repos.inactivity),
+		serviceAccount:  repository.NewTracedServiceAccountRepository(repos.serviceAccount),
+		kpi:             repository.NewTracedKPIRepository(repos.kpi),
+		adminNote:       repository.NewTracedAdminNoteRepository(repos.adminNote),
+		permission:      repository.NewTracedPermissionRepository(repos.permission),
+		roleAssignment:  repository.NewTracedRoleAssignmentRepository(repos.roleAssignment),
+		roleChange:      repository.NewTracedRoleChangeRequestRepository(repos.roleChange),
+		team:            repository.NewTracedTeamRepository(repos.team),
+		teamMember:      repository.NewTracedTeamMemberRepository(repos.teamMember),
+		breakGlass:      repository.NewTracedBreakGlassRepository(repos.breakGlass),
+		securityEvent:   repository.NewTracedSecurityEventRepository(repos.securityEvent),
+		ipDenial:        repository.NewTracedIPDenialRepository(repos.ipDenial),
+		loginCountry:    repository.NewTracedLoginCountryRepository(repos.loginCountry),
+		selfTest:        repository.NewTracedSelfTestRepository(repos.selfTest),
+		selfTestCache:   repository.NewTracedSelfTestCacheRepository(repos.selfTestCache),
+		scimGroup:       repository.NewTracedSCIMGroupRepository(repos.scimGroup),
+	}, nil
+}
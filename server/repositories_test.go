@@ -0,0 +1,16 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/infrastructure/cache"
+)
+
+func TestNewRepositoriesRefusesPostgreSQL(t *testing.T) {
+	cfg := &config.Config{Database: config.DatabaseConfig{Type: config.PostgreSQL}}
+
+	if _, err := newRepositories(cfg, nil, cache.NewMemory()); err == nil {
+		t.Fatal("repositories created on PostgreSQL, which only backs the users")
+	}
+}
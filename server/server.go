@@ -3,46 +3,80 @@ package server
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
+	grpchandler "github.com/chats/go-user-api/api/grpc/handler"
+	"github.com/chats/go-user-api/api/grpc/interceptor"
 	"github.com/chats/go-user-api/api/http/handler"
 	"github.com/chats/go-user-api/api/http/middleware"
 	"github.com/chats/go-user-api/api/http/router"
+	userv1 "github.com/chats/go-user-api/api/proto/user/v1"
 	"github.com/chats/go-user-api/config"
+	"github.com/chats/go-user-api/internal/domain/entity"
 	"github.com/chats/go-user-api/internal/domain/repository"
 	"github.com/chats/go-user-api/internal/domain/service"
 	"github.com/chats/go-user-api/internal/domain/usecase"
 	"github.com/chats/go-user-api/internal/infrastructure/cache"
 	"github.com/chats/go-user-api/internal/infrastructure/db"
-
-	//"github.com/chats/go-user-api/internal/infrastructure/grpc"
-	//	"github.com/chats/go-user-api/internal/infrastructure/tracing"
+	"github.com/chats/go-user-api/internal/infrastructure/directory"
+	"github.com/chats/go-user-api/internal/infrastructure/eventbus"
+	"github.com/chats/go-user-api/internal/infrastructure/grpc"
+	"github.com/chats/go-user-api/internal/infrastructure/mailer"
+	"github.com/chats/go-user-api/internal/infrastructure/oauth"
+	"github.com/chats/go-user-api/internal/infrastructure/pwned"
+	"github.com/chats/go-user-api/internal/infrastructure/ratelimit"
+	"github.com/chats/go-user-api/internal/infrastructure/secrets"
+	"github.com/chats/go-user-api/internal/infrastructure/siem"
+	"github.com/chats/go-user-api/internal/infrastructure/tracing"
+	"github.com/chats/go-user-api/internal/infrastructure/watchdog"
+	"github.com/chats/go-user-api/internal/infrastructure/webhook"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	grpclib "google.golang.org/grpc"
 )
 
 // Server represents the application server
 type Server struct {
-	config     *config.Config
-	httpServer *fiber.App
-	//	grpcServer     *grpc.Server
+	config      *config.Config
+	httpServer  *fiber.App
+	grpcServer  *grpc.Server
 	database    db.Database
 	cacheClient cache.Cache
-	// tracerProvider *sdktrace.TracerProvider
+
+	// background is the context of the background workers, cancelled on shutdown
+	background     context.Context
+	stopBackground context.CancelFunc
+
+	meteringUseCase usecase.MeteringUseCase
+	selfTestUseCase usecase.SelfTestUseCase
+	tracerProvider  *sdktrace.TracerProvider
 }
 
 // NewServer creates a new application server
 func NewServer(cfg *config.Config) *Server {
+	background, stopBackground := context.WithCancel(context.Background())
 	return &Server{
-		config: cfg,
+		config:         cfg,
+		background:     background,
+		stopBackground: stopBackground,
 	}
 }
 
 // Setup initializes the server
 func (s *Server) Setup() error {
+	// Set up tracing, before the database so its commands are traced
+	tracerProvider, err := tracing.Setup(context.Background(), s.config.Tracing)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %v", err)
+	}
+	s.tracerProvider = tracerProvider
+
 	// Set up database
 	dbFactory := db.NewDatabaseFactory()
 	database, err := dbFactory.Create(s.config.Database)
@@ -69,30 +103,242 @@ func (s *Server) Setup() error {
 		return fmt.Errorf("failed to connect to cache: %v", err)
 	}
 
+	// Watch database and cache connections
+	if s.config.Watchdog.Enabled {
+		s.startWatchdogs()
+	}
+
 	// Set up repositories
-	userRepo := repository.NewUserRepository(s.database, s.cacheClient)
-	tokenRepo := repository.NewTokenRepository(s.cacheClient)
+	repos, err := newRepositories(s.config, s.database, s.cacheClient)
+	if err != nil {
+		return err
+	}
+	userRepo := repos.user
+	// Valid access tokens are kept in memory when enabled, revocations publish invalidations either way so the
+	// instances caching tokens evict them
+	tokenRepo := repository.NewCachedTokenRepository(repos.token, s.cacheClient, s.config.Security.TokenCacheTTL, s.config.Security.TokenCacheSize)
+	if s.config.Security.TokenCacheTTL > 0 {
+		go tokenRepo.Subscribe(s.background)
+	}
+	settingsRepo := repos.settings
+	usageRepo := repos.usage
+	roleRepo := repos.role
+	permissionGroupRepo := repos.permissionGroup
+	organizationRepo := repos.organization
+	signingKeyRepo := repos.signingKey
+	dedupRepo := repos.dedup
+	webhookRepo := repos.webhook
+	suppressionRepo := repos.suppression
+	statusHistoryRepo := repos.statusHistory
+	referralRepo := repos.referral
+
+	// Audit entries of security-relevant actions are also buffered and streamed to the SIEM
+	var siemSink siem.Sink
+	if s.config.SIEM.Enabled {
+		if siemSink, err = siem.NewSink(s.config.SIEM, s.config.App.Name); err != nil {
+			return fmt.Errorf("failed to create SIEM sink: %v", err)
+		}
+	}
+	securityEventUseCase := usecase.NewSecurityEventUseCase(repos.audit, repos.securityEvent, dedupRepo, siemSink, s.config.SIEM)
+	if s.config.SIEM.Enabled {
+		go securityEventUseCase.Run(s.background, s.config.SIEM.DispatchInterval)
+	}
+	auditRepo := repository.AuditRepository(securityEventUseCase)
 
 	tokenService, err := service.NewTokenService(s.config.Security)
 	if err != nil {
-		return fmt.Errorf("failed to create token service")
+		return fmt.Errorf("failed to create token service: %v", err)
+	}
+
+	mail := mailer.NewMailer(s.config.Mailer)
+	notificationService := service.NewNotificationService(mail, suppressionRepo)
+	policyService := service.NewPolicyService(s.config.Policy)
+	passwordHasher, err := service.NewPasswordHasher(s.config.Security)
+	if err != nil {
+		return fmt.Errorf("failed to create password hasher: %v", err)
+	}
+	passwordService := service.NewPasswordService(pwned.NewChecker(s.config.Password.BreachCheckURL, s.config.App.Name, s.config.Password.BreachCheckTimeout), s.config.Password)
+	nameService := service.NewNameService(s.config.Name)
+
+	// Events are validated against their schemas, then logged and queued for the subscribed webhook endpoints
+	webhookUseCase := usecase.NewWebhookUseCase(webhookRepo, dedupRepo, auditRepo, webhook.NewSender(s.config.Webhook.Timeout), s.config.Webhook)
+	if s.config.Webhook.Enabled {
+		go webhookUseCase.Run(s.background, s.config.Webhook.DispatchInterval)
+	}
+	eventService, err := service.NewEventService(eventbus.NewFanoutBus(eventbus.NewLogBus(), webhookUseCase))
+	if err != nil {
+		return fmt.Errorf("failed to create event service: %v", err)
 	}
 
 	// Set up use cases
-	userUseCase := usecase.NewUserUseCase(userRepo)
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenRepo, tokenService)
+	notificationUseCase := usecase.NewNotificationUseCase(auditRepo, organizationRepo, notificationService, nameService, s.config.App.PublicURL, s.config.Branding)
+	lifecycleUseCase, err := usecase.NewLifecycleUseCase(userRepo, repos.lifecycle, dedupRepo, notificationUseCase, s.config.Lifecycle)
+	if err != nil {
+		return fmt.Errorf("failed to create lifecycle rules: %v", err)
+	}
+	if s.config.Lifecycle.Enabled && (s.config.Lifecycle.WelcomeEnabled || s.config.Lifecycle.ReengagementEnabled) {
+		go lifecycleUseCase.RunRules(s.background, s.config.Lifecycle.Interval)
+	}
+	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionGroupRepo, userRepo, repos.permission, repos.roleAssignment, repos.team, repos.teamMember, auditRepo)
+	if s.config.RoleGrant.ExpiryEnabled {
+		go roleUseCase.RunExpiry(s.background, s.config.RoleGrant.ExpiryInterval)
+	}
+	oauthProviders := oauth.NewProviders(s.config.OAuth)
+	organizationUseCase := usecase.NewOrganizationUseCase(organizationRepo, userRepo, tokenRepo, auditRepo, eventService, s.config.Branding, s.config.Organization, slices.Sorted(maps.Keys(oauthProviders)))
+	userUseCase := usecase.NewUserUseCase(userRepo, auditRepo, notificationUseCase, policyService, passwordService, passwordHasher, roleUseCase, dedupRepo, eventService, organizationRepo, organizationUseCase, statusHistoryRepo, tokenRepo, referralRepo, repos.passkey, repos.oauthIdentity, repos.adminNote, repos.teamMember, repos.loginCountry, s.config.Security, s.config.Register, s.config.Deletion)
+	if s.config.Deletion.PurgeEnabled && s.config.Deletion.RestorationWindow > 0 {
+		go userUseCase.RunPurge(s.background, s.config.Deletion.PurgeInterval)
+	}
+	roleApprovalUseCase := usecase.NewRoleApprovalUseCase(repos.roleChange, userRepo, auditRepo, userUseCase, roleUseCase, s.config.RoleApproval)
+	limiter := ratelimit.NewLimiter(s.cacheClient)
+	enforcementUseCase := usecase.NewEnforcementUseCase(auditRepo, securityEventUseCase, limiter, s.config.RateLimit, s.config.Lockout, s.config.Reset)
+	anomalyUseCase := usecase.NewAnomalyUseCase(repos.ipDenial, repos.loginCountry, userRepo, auditRepo, limiter, s.config.Anomaly)
+	breakGlassUseCase := usecase.NewBreakGlassUseCase(repos.breakGlass, userRepo, tokenRepo, auditRepo, dedupRepo, notificationUseCase, passwordHasher, secrets.NewStore(s.config.BreakGlass.SecretsDir), s.config.BreakGlass)
+	if s.config.BreakGlass.RotationInterval > 0 {
+		go breakGlassUseCase.RunRotation(s.background, s.config.BreakGlass.RotationInterval)
+	}
+	authUseCase := usecase.NewAuthUseCase(userRepo, tokenRepo, auditRepo, tokenService, passwordService, passwordHasher, notificationUseCase, enforcementUseCase, securityEventUseCase, statusHistoryRepo, repos.passkey, repos.passkeyCeremony, service.NewPasskeyService(s.config.Passkey), repos.oauthIdentity, organizationRepo, breakGlassUseCase, anomalyUseCase, s.config.Security, s.config.Reset, s.config.Passkey, s.config.OAuth)
+	deviceUseCase := usecase.NewDeviceUseCase(repos.device, userRepo, tokenRepo, auditRepo, dedupRepo, tokenService, s.config.Device, s.config.Security)
+	invitationUseCase := usecase.NewInvitationUseCase(userRepo, tokenRepo, auditRepo, notificationUseCase, roleUseCase, organizationUseCase, eventService, passwordService, passwordHasher, statusHistoryRepo, s.config.Invitation)
+	referralUseCase := usecase.NewReferralUseCase(referralRepo, userRepo)
+	waitlistUseCase := usecase.NewWaitlistUseCase(userRepo, auditRepo, dedupRepo, notificationUseCase, eventService, statusHistoryRepo)
+	if s.config.Register.WaitlistQuota > 0 {
+		go waitlistUseCase.RunQuota(s.background, s.config.Register.WaitlistQuota, s.config.Register.WaitlistInterval)
+	}
+	directoryUseCase := usecase.NewDirectoryUseCase(directory.NewDirectory(s.config.Directory), userRepo, userUseCase, invitationUseCase, repos.directoryReport, dedupRepo, s.config.Directory)
+	if s.config.Directory.SyncEnabled && s.config.Directory.LDAP.URL != "" && len(s.config.Directory.EmailDomains) > 0 {
+		go directoryUseCase.RunReconciliation(s.background, s.config.Directory.SyncInterval)
+	}
+	inactivityUseCase := usecase.NewInactivityUseCase(userRepo, repos.inactivity, dedupRepo, userUseCase, notificationUseCase, s.config.Inactivity)
+	if s.config.Inactivity.Enabled && s.config.Inactivity.After > 0 {
+		go inactivityUseCase.RunPolicy(s.background, s.config.Inactivity.Interval)
+	}
+	if s.config.Metrics.Enabled && s.config.Metrics.KPIEnabled && s.config.Metrics.KPIInterval > 0 {
+		kpiUseCase := usecase.NewKPIUseCase(userRepo, repos.passkey, repos.kpi, dedupRepo)
+		go kpiUseCase.RunExport(s.background, s.config.Metrics.KPIInterval)
+	}
+	suppressionUseCase := usecase.NewSuppressionUseCase(suppressionRepo, userRepo, auditRepo)
+	maintenanceUseCase := usecase.NewMaintenanceUseCase(settingsRepo, s.config.App.ReadOnly)
+	meteringUseCase := usecase.NewMeteringUseCase(usageRepo)
+	if s.config.Metering.Enabled {
+		go meteringUseCase.Run(s.background, s.config.Metering.FlushInterval)
+	}
+	s.meteringUseCase = meteringUseCase
+	s.selfTestUseCase = usecase.NewSelfTestUseCase(repos.selfTest, repos.selfTestCache, tokenService, mail, s.config.Security, s.config.SelfTest)
+
+	// Load the rotated signing keys, then keep up with rotations performed by other instances
+	keyUseCase, err := usecase.NewKeyUseCase(signingKeyRepo, userRepo, auditRepo, tokenService, passwordHasher, enforcementUseCase, s.config.Security)
+	if err != nil {
+		return fmt.Errorf("failed to create key use case: %v", err)
+	}
+	if keyUseCase.Enabled() {
+		if err := keyUseCase.Sync(context.Background()); err != nil {
+			return fmt.Errorf("failed to load signing keys: %v", err)
+		}
+		go keyUseCase.Run(s.background, s.config.Security.SigningKeyRefreshInterval)
+	}
 
 	// Set up HTTP handlers
-	userHandler := handler.NewUserHandler(userUseCase)
-	authHandler := handler.NewAuthHandler(authUseCase)
+	userHandler := handler.NewUserHandler(userUseCase, roleApprovalUseCase, nameService, passwordService, s.config.Register)
+	authHandler := handler.NewAuthHandler(authUseCase, nameService, s.config.Session, s.config.Anomaly)
+	adminHandler := handler.NewAdminHandler(maintenanceUseCase, meteringUseCase, s.selfTestUseCase)
+	roleHandler := handler.NewRoleHandler(roleUseCase, roleApprovalUseCase)
+	organizationHandler := handler.NewOrganizationHandler(organizationUseCase)
+	keyHandler := handler.NewKeyHandler(keyUseCase)
+	sessionHandler := handler.NewSessionHandler(authUseCase)
+	deviceHandler := handler.NewDeviceHandler(deviceUseCase)
+	invitationHandler := handler.NewInvitationHandler(invitationUseCase)
+	eventHandler := handler.NewEventHandler(eventService)
+	webhookHandler := handler.NewWebhookHandler(webhookUseCase)
+	suppressionHandler := handler.NewSuppressionHandler(suppressionUseCase, s.config.Mailer)
+	referralHandler := handler.NewReferralHandler(referralUseCase)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistUseCase)
+	directoryHandler := handler.NewDirectoryHandler(directoryUseCase)
+	inactivityHandler := handler.NewInactivityHandler(inactivityUseCase)
+	adminNoteHandler := handler.NewAdminNoteHandler(usecase.NewAdminNoteUseCase(repos.adminNote, userRepo, auditRepo))
+	anomalyHandler := handler.NewAnomalyHandler(anomalyUseCase)
+	teamHandler := handler.NewTeamHandler(usecase.NewTeamUseCase(repos.team, repos.teamMember, userRepo, auditRepo, roleUseCase, roleApprovalUseCase))
+
+	// Sign in with the external OAuth2 providers configured with a client ID, others answer not found
+	oauthUseCase := usecase.NewOAuthUseCase(oauthProviders, repos.oauthState, userUseCase, authUseCase, s.config.OAuth)
+	oauthHandler := handler.NewOAuthHandler(oauthUseCase, authHandler, s.config.OAuth.StateExpiration)
+
+	// Set up the OpenID Connect provider, other internal apps sign their users in through it
+	var oidcHandler *handler.OIDCHandler
+	if s.config.OIDC.Enabled {
+		oidcUseCase := usecase.NewOIDCUseCase(userRepo, tokenRepo, repos.oidc, auditRepo, tokenService, nameService, s.config.OIDC)
+		oidcHandler = handler.NewOIDCHandler(oidcUseCase, s.config.OIDC.LoginURL)
+	}
+
+	// Provision the groups of the identity provider over SCIM, their members given the mapped roles and organizations
+	var scimHandler *handler.SCIMHandler
+	if s.config.SCIM.Enabled {
+		scimUseCase := usecase.NewSCIMUseCase(repos.scimGroup, userRepo, userUseCase, organizationUseCase, roleApprovalUseCase, s.config.SCIM)
+		scimHandler = handler.NewSCIMHandler(scimUseCase, s.config.SCIM.Token)
+	}
+
+	// Count the requests of API keys and service accounts against their quota, when turned on
+	var quotaUseCase usecase.QuotaUseCase
+	if s.config.Quota.Enabled {
+		quotaUseCase = usecase.NewQuotaUseCase(repos.quota, repos.apiKey, repos.serviceAccount, auditRepo, eventService, s.config.Quota)
+	}
+
+	// Accept API keys in place of access tokens, and let users manage them, unless turned off
+	var apiKeyUseCase usecase.APIKeyUseCase
+	var apiKeyHandler *handler.APIKeyHandler
+	if s.config.APIKey.Enabled {
+		apiKeyUseCase = usecase.NewAPIKeyUseCase(repos.apiKey, userRepo, organizationRepo, auditRepo, notificationUseCase, s.config.APIKey)
+		apiKeyHandler = handler.NewAPIKeyHandler(apiKeyUseCase, quotaUseCase)
+	}
+
+	// Issue access tokens to the service accounts of backend services, unless turned off
+	var serviceAccountHandler *handler.ServiceAccountHandler
+	if s.config.ServiceAccount.Enabled {
+		serviceAccountUseCase := usecase.NewServiceAccountUseCase(repos.serviceAccount, tokenRepo, auditRepo, tokenService)
+		serviceAccountHandler = handler.NewServiceAccountHandler(serviceAccountUseCase, quotaUseCase)
+	}
 
 	// Create auth middleware
-	authMiddleware := middleware.AuthMiddleware(authUseCase)
+	authMiddleware := middleware.AuthMiddleware(authUseCase, apiKeyUseCase, quotaUseCase)
+
+	// Create the middleware of the OIDC user info endpoint, the only one accepting the tokens of relying parties
+	oidcTokenMiddleware := middleware.OIDCTokenMiddleware(authUseCase)
+
+	// Create read-only middleware, auth stays available so sessions keep working
+	// and admin routes stay available so the mode can be turned off
+	readOnlyMiddleware := middleware.ReadOnlyMiddleware(maintenanceUseCase, "/api/v1/auth/", "/api/v1/admin/")
+
+	// Create rate limiter, budgets are shared by all instances through the cache
+	rateLimiter := middleware.NewRateLimiter(limiter, tokenService, enforcementUseCase, s.config.RateLimit)
+
+	// Create metering middleware
+	var meteringMiddleware fiber.Handler
+	if s.config.Metering.Enabled {
+		meteringMiddleware = middleware.MeteringMiddleware(meteringUseCase)
+	}
 
 	// Set up HTTP server
-	httpServer := router.Setup(s.config, userHandler, authHandler, authMiddleware)
+	httpServer := router.Setup(s.config, userHandler, authHandler, adminHandler, roleHandler, organizationHandler, keyHandler, sessionHandler, invitationHandler, eventHandler, webhookHandler, suppressionHandler, oidcHandler, deviceHandler, referralHandler, waitlistHandler, oauthHandler, directoryHandler, apiKeyHandler, inactivityHandler, serviceAccountHandler, adminNoteHandler, teamHandler, anomalyHandler, scimHandler, authMiddleware, oidcTokenMiddleware, readOnlyMiddleware, rateLimiter, meteringMiddleware)
 	s.httpServer = httpServer
 
+	// Set up gRPC server, authenticated and rate limited like the HTTP API
+	if s.config.GRPC.Enabled {
+		userGRPCHandler := grpchandler.NewUserHandler(userUseCase, s.config.Register)
+		publicMethods := append(grpc.PublicMethods(s.config.GRPC), userGRPCHandler.PublicMethods()...)
+		authInterceptor := interceptor.NewAuthInterceptor(authUseCase, publicMethods...)
+		grpcRateLimiter := interceptor.NewRateLimiter(limiter, tokenService, enforcementUseCase, s.config.RateLimit)
+
+		grpcServer, err := grpc.NewServer(s.config.GRPC,
+			[]grpclib.UnaryServerInterceptor{grpcRateLimiter.UnaryServerInterceptor(), authInterceptor.UnaryServerInterceptor()},
+			[]grpclib.StreamServerInterceptor{grpcRateLimiter.StreamServerInterceptor(), authInterceptor.StreamServerInterceptor()},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create gRPC server: %v", err)
+		}
+		userv1.RegisterUserServiceServer(grpcServer.GetServer(), userGRPCHandler)
+		s.grpcServer = grpcServer
+	}
+
 	return nil
 }
 
@@ -106,6 +352,15 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	// Start gRPC server
+	if s.grpcServer != nil {
+		go func() {
+			if err := s.grpcServer.Start(); err != nil {
+				log.Fatal().Err(err).Msg("Failed to start gRPC server")
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -121,6 +376,22 @@ func (s *Server) Start() error {
 	if err := s.httpServer.ShutdownWithContext(ctx); err != nil {
 		log.Error().Err(err).Msg("Failed to shutdown HTTP server gracefully")
 	}
+
+	// Shutdown gRPC server
+	if s.grpcServer != nil {
+		s.grpcServer.Shutdown(ctx)
+	}
+
+	// Stop background workers before closing the connections they use
+	s.stopBackground()
+
+	// Flush usage recorded since the last periodic flush
+	if s.config.Metering.Enabled {
+		if err := s.meteringUseCase.Flush(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to flush usage")
+		}
+	}
+
 	// Close database connection
 	if err := s.database.Close(ctx); err != nil {
 		log.Error().Err(err).Msg("Failed to close database connection")
@@ -131,15 +402,34 @@ func (s *Server) Start() error {
 		log.Error().Err(err).Msg("Failed to close cache connection")
 	}
 
+	// Export the spans of the last requests
+	tracing.Shutdown(ctx, s.tracerProvider)
+
 	log.Info().Msg("Server gracefully stopped")
 	return nil
 }
 
+// SelfTest runs the self-test against the dependencies set up, for the --selftest mode
+func (s *Server) SelfTest(ctx context.Context) *entity.SelfTestReport {
+	return s.selfTestUseCase.Run(ctx)
+}
+
+// startWatchdogs starts the liveness watchdogs rebuilding the database and cache clients after persistent outages
+func (s *Server) startWatchdogs() {
+	go watchdog.New("database", s.database, s.config.Watchdog).Run(s.background)
+	go watchdog.New("cache", s.cacheClient, s.config.Watchdog).Run(s.background)
+}
+
 // GetHTTPServer returns the HTTP server
 func (s *Server) GetHTTPServer() *fiber.App {
 	return s.httpServer
 }
 
+// GetGRPCServer returns the gRPC server, nil when it is disabled
+func (s *Server) GetGRPCServer() *grpc.Server {
+	return s.grpcServer
+}
+
 // CheckRepositories is a helper function to check if all necessary repositories are registered
 func CheckRepositories(ur repository.UserRepository) bool {
 	return ur != nil
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/chats/go-user-api/config"
+)
+
+// NewTestServer creates a server set up on the in-memory database and cache, so the API can be
+// exercised through GetHTTPServer().Test without MongoDB or Redis. The configuration is copied
+// and its database, cache and watchdog settings are overridden. Without a configured PASETO key
+// an ephemeral one is generated.
+func NewTestServer(cfg *config.Config) (*Server, error) {
+	testConfig := *cfg
+	testConfig.Database.Type = config.MemoryDB
+	testConfig.Cache.Type = config.MemoryCache
+	testConfig.Watchdog.Enabled = false
+
+	if testConfig.Security.PasetoPrivateKey == "" {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate token signing key: %v", err)
+		}
+		testConfig.Security.PasetoPrivateKey = hex.EncodeToString(privateKey)
+	}
+
+	s := NewServer(&testConfig)
+	if err := s.Setup(); err != nil {
+		return nil, fmt.Errorf("failed to set up test server: %v", err)
+	}
+	return s, nil
+}
+
+// Close stops the background workers of a server that was set up but never started
+func (s *Server) Close() {
+	s.stopBackground()
+}
@@ -1,36 +1,72 @@
 package utils
 
 import (
+	"crypto/pbkdf2"
 	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
+	"hash"
+	"strconv"
 	"strings"
 
 	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword hashes a password using bcrypt
-func HashPassword(password string) (string, error) {
-	// Use cost 12 as a good balance between security and performance
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+// DefaultBcryptCost is the bcrypt cost of the passwords hashed without a configured cost, such as the seeded users
+const DefaultBcryptCost = 12
+
+// argon2idPrefix starts the encoded Argon2id hashes
+const argon2idPrefix = "$argon2id$"
+
+// pbkdf2Prefix starts the encoded PBKDF2 hashes, in the modular crypt format of passlib:
+// $pbkdf2-<digest>$<iterations>$<salt>$<key>, the salt and key in adapted base64, SHA-1 hashes starting with $pbkdf2$
+const pbkdf2Prefix = "$pbkdf2"
+
+// PBKDF2 digests
+const (
+	PBKDF2SHA1   = "sha1"
+	PBKDF2SHA256 = "sha256"
+	PBKDF2SHA512 = "sha512"
+)
+
+// pbkdf2Digests are the hash functions of the PBKDF2 digests
+var pbkdf2Digests = map[string]func() hash.Hash{
+	PBKDF2SHA1:   sha1.New,
+	PBKDF2SHA256: sha256.New,
+	PBKDF2SHA512: sha512.New,
+}
+
+// pbkdf2Encoding is the adapted base64 of passlib, with . in place of +
+var pbkdf2Encoding = base64.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./").WithPadding(base64.NoPadding)
+
+// HashPassword hashes a password using bcrypt with the given cost
+func HashPassword(password string, cost int) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 	return string(bytes), err
 }
 
-// CheckPasswordHash compares a password with a hash
+// CheckPasswordHash compares a password with a bcrypt, Argon2id or PBKDF2 hash, whichever algorithm hashed it
 func CheckPasswordHash(password, hash string) bool {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		ok, err := CheckPasswordArgon2(password, hash)
+		return err == nil && ok
+	}
+	if IsPBKDF2Hash(hash) {
+		ok, err := CheckPasswordPBKDF2(password, hash)
+		return err == nil && ok
+	}
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
 
-// UseArgon2 indicates whether to use Argon2 for password hashing
-// Set this to true if you want to use Argon2 instead of bcrypt
-const UseArgon2 = false
-
 // Argon2Params defines the parameters for Argon2 hashing
 type Argon2Params struct {
-	Memory      uint32
+	Memory      uint32 // KiB
 	Iterations  uint32
 	Parallelism uint8
 	SaltLength  uint32
@@ -48,10 +84,8 @@ func DefaultArgon2Params() *Argon2Params {
 	}
 }
 
-// HashPasswordArgon2 hashes a password using Argon2
-func HashPasswordArgon2(password string) (string, error) {
-	p := DefaultArgon2Params()
-
+// HashPasswordArgon2 hashes a password using Argon2id with the given parameters
+func HashPasswordArgon2(password string, p *Argon2Params) (string, error) {
 	// Generate a random salt
 	salt, err := generateRandomBytes(p.SaltLength)
 	if err != nil {
@@ -74,39 +108,130 @@ func HashPasswordArgon2(password string) (string, error) {
 
 // CheckPasswordArgon2 compares a password with an Argon2 hash
 func CheckPasswordArgon2(password, encodedHash string) (bool, error) {
-	// Extract parameters, salt, and hash from encoded hash
+	p, salt, hash, err := decodeArgon2Hash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	// Compute the hash of the provided password
+	comparisonHash := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+
+	// Constant-time comparison to prevent timing attacks
+	return subtle.ConstantTimeCompare(hash, comparisonHash) == 1, nil
+}
+
+// Argon2HashParams returns the parameters an Argon2id hash was computed with
+func Argon2HashParams(encodedHash string) (*Argon2Params, error) {
+	p, _, _, err := decodeArgon2Hash(encodedHash)
+	return p, err
+}
+
+// decodeArgon2Hash extracts the parameters, salt and hash of an encoded Argon2id hash
+func decodeArgon2Hash(encodedHash string) (*Argon2Params, []byte, []byte, error) {
 	parts := strings.Split(encodedHash, "$")
 	if len(parts) != 6 {
-		return false, fmt.Errorf("invalid hash format")
+		return nil, nil, nil, fmt.Errorf("invalid hash format")
 	}
 
 	var version int
 	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
-		return false, fmt.Errorf("invalid hash format")
+		return nil, nil, nil, fmt.Errorf("invalid hash format")
 	}
 
-	var memory, iterations uint32
-	var parallelism uint8
-	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
-		return false, fmt.Errorf("invalid hash format")
+	p := &Argon2Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil || p.Iterations == 0 || p.Parallelism == 0 {
+		return nil, nil, nil, fmt.Errorf("invalid hash format")
 	}
 
 	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
 	if err != nil {
-		return false, fmt.Errorf("invalid salt: %v", err)
+		return nil, nil, nil, fmt.Errorf("invalid salt: %v", err)
 	}
 
 	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
 	if err != nil {
-		return false, fmt.Errorf("invalid hash: %v", err)
+		return nil, nil, nil, fmt.Errorf("invalid hash: %v", err)
 	}
 
-	// Compute the hash of the provided password
-	keyLength := uint32(len(hash))
-	comparisonHash := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, keyLength)
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(hash))
+	return p, salt, hash, nil
+}
+
+// PBKDF2Hash is a decoded PBKDF2 hash
+type PBKDF2Hash struct {
+	Digest     string
+	Iterations int
+	Salt       []byte
+	Key        []byte
+}
+
+// IsPBKDF2Hash reports whether a hash is an encoded PBKDF2 hash
+func IsPBKDF2Hash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, pbkdf2Prefix+"$") || strings.HasPrefix(encodedHash, pbkdf2Prefix+"-")
+}
+
+// EncodePBKDF2Hash encodes a PBKDF2 hash in the modular crypt format
+func EncodePBKDF2Hash(h *PBKDF2Hash) string {
+	scheme := pbkdf2Prefix
+	if h.Digest != PBKDF2SHA1 {
+		scheme += "-" + h.Digest
+	}
+	return fmt.Sprintf("%s$%d$%s$%s", scheme, h.Iterations, pbkdf2Encoding.EncodeToString(h.Salt), pbkdf2Encoding.EncodeToString(h.Key))
+}
+
+// DecodePBKDF2Hash decodes a PBKDF2 hash in the modular crypt format
+func DecodePBKDF2Hash(encodedHash string) (*PBKDF2Hash, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 5 || parts[0] != "" {
+		return nil, fmt.Errorf("invalid hash format")
+	}
+
+	h := &PBKDF2Hash{Digest: PBKDF2SHA1}
+	if scheme, digest, ok := strings.Cut(parts[1], "-"); ok {
+		if scheme != pbkdf2Prefix[1:] {
+			return nil, fmt.Errorf("invalid hash format")
+		}
+		h.Digest = digest
+	} else if parts[1] != pbkdf2Prefix[1:] {
+		return nil, fmt.Errorf("invalid hash format")
+	}
+	if _, ok := pbkdf2Digests[h.Digest]; !ok {
+		return nil, fmt.Errorf("unsupported PBKDF2 digest %q", h.Digest)
+	}
+
+	iterations, err := strconv.Atoi(parts[2])
+	if err != nil || iterations < 1 {
+		return nil, fmt.Errorf("invalid iterations")
+	}
+	h.Iterations = iterations
+
+	if h.Salt, err = pbkdf2Encoding.DecodeString(parts[3]); err != nil {
+		return nil, fmt.Errorf("invalid salt: %v", err)
+	}
+	if h.Key, err = pbkdf2Encoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("invalid hash: %v", err)
+	}
+	if len(h.Key) == 0 {
+		return nil, fmt.Errorf("invalid hash format")
+	}
+	return h, nil
+}
+
+// CheckPasswordPBKDF2 compares a password with a PBKDF2 hash
+func CheckPasswordPBKDF2(password, encodedHash string) (bool, error) {
+	h, err := DecodePBKDF2Hash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	key, err := pbkdf2.Key(pbkdf2Digests[h.Digest], password, h.Salt, h.Iterations, len(h.Key))
+	if err != nil {
+		return false, err
+	}
 
 	// Constant-time comparison to prevent timing attacks
-	return subtle.ConstantTimeCompare(hash, comparisonHash) == 1, nil
+	return subtle.ConstantTimeCompare(h.Key, key) == 1, nil
 }
 
 // generateRandomBytes generates random bytes
@@ -0,0 +1,14 @@
+package utils
+
+import (
+	"encoding/hex"
+)
+
+// GenerateRandomToken generates a random hex-encoded token of n bytes, suitable for one-time links
+func GenerateRandomToken(n uint32) (string, error) {
+	b, err := generateRandomBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}